@@ -3,11 +3,18 @@ package commands
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/dwirx/ghex/internal/account"
 	"github.com/dwirx/ghex/internal/config"
 	"github.com/dwirx/ghex/internal/git"
+	"github.com/dwirx/ghex/internal/i18n"
+	"github.com/dwirx/ghex/internal/offline"
+	"github.com/dwirx/ghex/internal/platform"
 	"github.com/dwirx/ghex/internal/ssh"
 	"github.com/dwirx/ghex/internal/ui"
 	"github.com/spf13/cobra"
@@ -15,47 +22,203 @@ import (
 
 // NewListCmd creates the list command
 func NewListCmd() *cobra.Command {
+	var recent bool
+
+	cmd := &cobra.Command{
+		Use:   "list [account]",
+		Short: i18n.T("List all configured accounts"),
+		Long: `List all configured accounts, or show the details of one account
+including its notes and when it was added and last edited.
+
+Examples:
+  ghex list
+  ghex list --recent
+  ghex list work`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 1 {
+				runShowAccount(args[0])
+				return
+			}
+			runList(recent)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&recent, "recent", "r", false, "Sort recently added accounts first")
+
+	return cmd
+}
+
+// NewFavoriteCmd creates the favorite command
+func NewFavoriteCmd() *cobra.Command {
 	return &cobra.Command{
-		Use:   "list",
-		Short: "List all configured accounts",
+		Use:   "favorite <account>",
+		Short: i18n.T("Mark or unmark an account as a favorite"),
+		Long: `Toggle an account's favorite mark. Favorites are listed right after the
+account detected for the current repository in every account selector.
+
+Examples:
+  ghex favorite work`,
+		Args: cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			runList()
+			runFavorite(args[0])
+		},
+	}
+}
+
+// NewSortOrderCmd creates the sort-order command
+func NewSortOrderCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "sort-order [manual|recent|alphabetical|added]",
+		Short: i18n.T("Set how accounts are ordered in selectors"),
+		Long: `Set the order of accounts in selectors and 'ghex list':
+
+  manual        the order of the config file (default)
+  recent        most recently switched to first
+  alphabetical  by name
+  added         most recently added first
+
+The account detected for the current repository always comes first,
+followed by favorites. Without an argument, shows the current order.
+
+Examples:
+  ghex sort-order
+  ghex sort-order recent`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			order := ""
+			if len(args) == 1 {
+				order = args[0]
+			}
+			runSortOrder(order)
+		},
+	}
+}
+
+// NewLanguageCmd creates the language command
+func NewLanguageCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "language [en|id|auto]",
+		Short: i18n.T("Set the language of ghex messages"),
+		Long: `Set the language used for ghex messages and prompts:
+
+  en    English
+  id    Indonesian (Bahasa Indonesia)
+  auto  follow the environment (default)
+
+The language is taken from GHEX_LANG first, then this setting, then
+LC_ALL, LC_MESSAGES and LANG. Without an argument, shows the language
+in use.
+
+Examples:
+  ghex language
+  ghex language id
+  GHEX_LANG=en ghex list`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			lang := ""
+			if len(args) == 1 {
+				lang = args[0]
+			}
+			runLanguage(lang)
+		},
+	}
+}
+
+// NewAccessibleCmd creates the accessible command
+func NewAccessibleCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "accessible [on|off]",
+		Short: i18n.T("Turn screen-reader friendly output on or off"),
+		Long: `Turn screen-reader friendly output on or off.
+
+In accessible mode spinners and progress bars print one line when they
+start and one when they finish instead of redrawing in place, and
+selectors become numbered lists answered by typing a number.
+
+GHEX_ACCESSIBLE=1 or GHEX_ACCESSIBLE=0 overrides this setting for a
+single run. Without an argument, shows whether the mode is on.
+
+Examples:
+  ghex accessible on
+  GHEX_ACCESSIBLE=1 ghex switch`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			value := ""
+			if len(args) == 1 {
+				value = args[0]
+			}
+			runAccessible(value)
 		},
 	}
 }
 
 // NewStatusCmd creates the status command
 func NewStatusCmd() *cobra.Command {
-	return &cobra.Command{
+	var authTrace bool
+
+	cmd := &cobra.Command{
 		Use:   "status",
-		Short: "Show current repository status",
+		Short: i18n.T("Show current repository status"),
+		Long: `Show the repository's remote, git identity and active account.
+
+With --auth-trace, also show which credential git would actually use:
+the credential helper that answers for HTTPS remotes (git credential fill)
+or the SSH keys offered for SSH remotes (ssh -G), checked against the
+account the repository is pinned to.
+
+Examples:
+  ghex status
+  ghex status --auth-trace`,
 		Run: func(cmd *cobra.Command, args []string) {
-			runStatus()
+			runStatus(authTrace)
 		},
 	}
+
+	cmd.Flags().BoolVar(&authTrace, "auth-trace", false, "Show which credential or SSH key git would use")
+
+	return cmd
 }
 
 // NewSwitchCmd creates the switch command
 func NewSwitchCmd() *cobra.Command {
-	return &cobra.Command{
+	var email string
+
+	cmd := &cobra.Command{
 		Use:   "switch [account]",
-		Short: "Switch to a specific account",
-		Args:  cobra.MaximumNArgs(1),
+		Short: i18n.T("Switch to a specific account"),
+		Long: `Switch the current repository to an account: its remote URL, credentials
+and git identity.
+
+An account with several emails (gitEmail plus "emails" in the config,
+e.g. a GitHub noreply address) asks which one to commit with, unless
+--email picks it. When the account has a token, the address is checked
+against the platform so commits don't end up unverified.
+
+Examples:
+  ghex switch
+  ghex switch work
+  ghex switch work --email 12345+me@users.noreply.github.com`,
+		Args: cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			if len(args) > 0 {
-				runSwitchTo(args[0])
+				runSwitchTo(args[0], email)
 			} else {
-				runSwitch()
+				runSwitch(email)
 			}
 		},
 	}
+
+	cmd.Flags().StringVar(&email, "email", "", "Commit with this one of the account's emails")
+
+	return cmd
 }
 
 // NewAddCmd creates the add command
 func NewAddCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "add",
-		Short: "Add a new account",
+		Short: i18n.T("Add a new account"),
 		Run: func(cmd *cobra.Command, args []string) {
 			cfg, _ := config.Load()
 			runAddAccount(cfg)
@@ -67,7 +230,7 @@ func NewAddCmd() *cobra.Command {
 func NewRemoveCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "remove [account]",
-		Short: "Remove an account",
+		Short: i18n.T("Remove an account"),
 		Args:  cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			cfg, _ := config.Load()
@@ -80,7 +243,7 @@ func NewRemoveCmd() *cobra.Command {
 func NewEditCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "edit [account]",
-		Short: "Edit an account",
+		Short: i18n.T("Edit an account"),
 		Args:  cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			cfg, _ := config.Load()
@@ -89,12 +252,99 @@ func NewEditCmd() *cobra.Command {
 	}
 }
 
-func runStatus() {
+// NewWhoamiCmd creates the whoami command
+func NewWhoamiCmd() *cobra.Command {
+	var copyIdentity, copyToken bool
+
+	cmd := &cobra.Command{
+		Use:   "whoami",
+		Short: i18n.T("Show the git identity and account in use"),
+		Long: `Show the git identity and ghex account in use here, with the
+account's token masked.
+
+--copy puts the identity ("Name <email>") on the clipboard, e.g. for a
+Co-authored-by trailer. --copy-token copies the account's token after
+confirming it by its masked form.
+
+Examples:
+  ghex whoami
+  ghex whoami --copy
+  ghex whoami --copy-token`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, _ := config.Load()
+			runWhoami(cfg, copyIdentity, copyToken)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&copyIdentity, "copy", "c", false, "Copy the identity to the clipboard")
+	cmd.Flags().BoolVar(&copyToken, "copy-token", false, "Copy the account's token to the clipboard")
+
+	return cmd
+}
+
+func runWhoami(cfg *config.AppConfig, copyIdentity, copyToken bool) {
+	cwd, _ := os.Getwd()
+	userName, userEmail, _ := git.GetCurrentUser(cwd)
+	manager := account.NewManager(cfg)
+
+	// In a repository use detection; elsewhere match the global email
+	var acc *config.Account
+	if git.IsGitRepo(cwd) {
+		if name, _ := manager.DetectActive(cwd); name != "" {
+			acc = manager.Find(name)
+		}
+	} else if userEmail != "" {
+		for i := range cfg.Accounts {
+			if cfg.Accounts[i].HasEmail(userEmail) {
+				if acc != nil {
+					acc = nil
+					break
+				}
+				acc = &cfg.Accounts[i]
+			}
+		}
+	}
+
+	fmt.Println()
+	ui.ShowKeyValue("Name", userName)
+	ui.ShowKeyValue("Email", userEmail)
+	if acc == nil {
+		ui.ShowKeyValue("Account", ui.Dim("none detected"))
+	} else {
+		ui.ShowKeyValue("Account", ui.Success(acc.Name))
+		if acc.SSH != nil {
+			ui.ShowKeyValue("SSH Key", acc.SSH.KeyPath)
+		}
+		if acc.Token != nil {
+			ui.ShowKeyValue("Token", fmt.Sprintf("%s (%s)", ui.MaskSecret(acc.Token.Token), acc.Token.Username))
+		}
+	}
+
+	if copyIdentity {
+		if userName == "" && userEmail == "" {
+			ui.ShowError(i18n.T("No git identity configured"))
+		} else {
+			copyToClipboard(fmt.Sprintf("%s <%s>", userName, userEmail), "identity", true)
+		}
+	}
+
+	if copyToken {
+		if acc == nil || acc.Token == nil || acc.Token.Token == "" {
+			ui.ShowError(i18n.T("No account token to copy"))
+			return
+		}
+		if ui.Confirm(i18n.T("Copy token %s for %s to the clipboard?", ui.MaskSecret(acc.Token.Token), acc.Name)) {
+			copyToClipboard(acc.Token.Token, "token", false)
+		}
+	}
+}
+
+func runStatus(authTrace bool) {
 	cfg, _ := config.Load()
 	cwd, _ := os.Getwd()
 
 	if !git.IsGitRepo(cwd) {
-		ui.ShowError("Not in a git repository")
+		ui.ShowError(i18n.T("Not in a git repository"))
 		return
 	}
 
@@ -105,7 +355,11 @@ func runStatus() {
 	manager := account.NewManager(cfg)
 	
 	// Use enhanced detection with scoring
-	matchScore, _ := manager.DetectActiveWithScore(cwd)
+	candidates := manager.DetectCandidates(cwd)
+	var matchScore *account.MatchScore
+	if len(candidates) > 0 && !account.IsAmbiguous(candidates) {
+		matchScore = &candidates[0]
+	}
 	remoteInfo, _ := account.GetRemoteInfo(cwd)
 	userName, userEmail, _ := git.GetCurrentUser(cwd)
 	branch, _ := git.GetCurrentBranch(cwd)
@@ -131,12 +385,25 @@ func runStatus() {
 	if matchScore != nil && matchScore.IsActive {
 		ui.ShowKeyValue("Account", ui.Success(matchScore.AccountName))
 		ui.ShowKeyValue("Confidence", fmt.Sprintf("%d%% (%s)", matchScore.Score, strings.Join(matchScore.MatchedFields, ", ")))
+	} else if account.IsAmbiguous(candidates) {
+		matchScore = resolveAmbiguousAccount(candidates, cwd)
 	} else {
-		ui.ShowWarning("No matching account detected")
+		ui.ShowWarning(i18n.T("No matching account detected"))
 		if userName != "" || userEmail != "" {
-			ui.ShowInfo(fmt.Sprintf("Current identity: %s <%s>", userName, userEmail))
+			ui.ShowInfo(i18n.T("Current identity: %s <%s>", userName, userEmail))
 		}
 	}
+	if pinned := account.PinnedAccount(cwd); pinned != "" {
+		ui.ShowKeyValue("Pinned", pinned)
+	}
+
+	if authTrace && remoteInfo != nil {
+		expected := manager.VerifyPinned(cwd).Account
+		if expected == nil && matchScore != nil && matchScore.IsActive {
+			expected = manager.Find(matchScore.AccountName)
+		}
+		showAuthTrace(remoteInfo.RemoteURL, expected, cwd)
+	}
 
 	if branch != "" {
 		fmt.Println()
@@ -146,10 +413,111 @@ func runStatus() {
 	}
 }
 
-func runList() {
+// resolveAmbiguousAccount lists the evidence for accounts that match the
+// repository equally well and lets the user pick one, pinning the choice
+func resolveAmbiguousAccount(candidates []account.MatchScore, cwd string) *account.MatchScore {
+	top := candidates[0].Score
+	var tied []account.MatchScore
+	for _, c := range candidates {
+		if c.Score == top {
+			tied = append(tied, c)
+		}
+	}
+
+	ui.ShowWarning(i18n.T("%d accounts match this repository equally well", len(tied)))
+	items := make([]ui.SelectorItem, 0, len(tied)+1)
+	for _, c := range tied {
+		fmt.Printf("  %s %s %s\n", ui.Dim("•"), c.AccountName, ui.Dim(fmt.Sprintf("(%d%%: %s)", c.Score, strings.Join(c.MatchedFields, ", "))))
+		items = append(items, ui.SelectorItem{
+			Title:       c.AccountName,
+			Description: strings.Join(c.MatchedFields, ", "),
+			Value:       c.AccountName,
+		})
+	}
+	items = append(items, ui.SelectorItem{Title: i18n.T("Skip"), Description: i18n.T("Leave the repository unpinned"), Value: ""})
+	fmt.Println()
+
+	idx, err := ui.RunSelector(i18n.T("Which account does this repository belong to?"), items)
+	if err != nil || idx < 0 || items[idx].Value == "" {
+		return nil
+	}
+
+	chosen := tied[idx]
+	if err := account.PinAccount(chosen.AccountName, cwd); err != nil {
+		ui.ShowWarning(i18n.T("Failed to pin account: %v", err))
+	} else {
+		ui.ShowSuccess(i18n.T("Pinned repository to %s", chosen.AccountName))
+	}
+	ui.ShowKeyValue("Account", ui.Success(chosen.AccountName))
+	return &chosen
+}
+
+// showAuthTrace shows the credential or SSH key git would use for a remote
+// and warns when it does not belong to the expected account
+func showAuthTrace(remoteURL string, expected *config.Account, cwd string) {
+	fmt.Println()
+	fmt.Println(ui.Primary("🔎 Auth Trace"))
+	ui.ShowSeparator()
+
+	info, err := git.ParseURL(remoteURL)
+	if err != nil {
+		ui.ShowError(i18n.T("Cannot parse remote URL: %v", err))
+		return
+	}
+
+	if !info.IsSSH {
+		trace, err := git.TraceCredential(remoteURL, cwd)
+		if err != nil {
+			ui.ShowError(i18n.T("git credential fill failed: %v", err))
+			return
+		}
+		if len(trace.Asked) > 0 {
+			ui.ShowKeyValue("Helpers asked", strings.Join(trace.Asked, " → "))
+		}
+		if trace.Helper == "" {
+			ui.ShowWarning(i18n.T("No credential helper has a credential; git would prompt"))
+			return
+		}
+		ui.ShowKeyValue("Answered by", trace.Helper)
+		ui.ShowKeyValue("Username", trace.Username)
+
+		if expected != nil && expected.Token != nil && !strings.EqualFold(trace.Username, expected.Token.Username) {
+			ui.ShowWarning(i18n.T("git would authenticate as '%s', but account '%s' uses '%s'",
+				trace.Username, expected.Name, expected.Token.Username))
+		}
+		return
+	}
+
+	resolved, err := ssh.ResolveConfig("git@"+info.Host, info.Port)
+	if err != nil {
+		ui.ShowError(err.Error())
+		return
+	}
+	ui.ShowKeyValue("Host", fmt.Sprintf("%s@%s:%d", resolved.User, resolved.HostName, resolved.Port))
+	ui.ShowKeyValue("Identity files", strings.Join(resolved.IdentityFiles, ", "))
+	if !resolved.IdentitiesOnly && os.Getenv("SSH_AUTH_SOCK") != "" {
+		ui.ShowInfo(i18n.T("IdentitiesOnly is off: keys loaded in ssh-agent are offered first"))
+	}
+
+	if expected != nil && expected.SSH != nil {
+		want := filepath.Clean(platform.ExpandPath(expected.SSH.KeyPath))
+		found := false
+		for _, f := range resolved.IdentityFiles {
+			if filepath.Clean(platform.ExpandPath(f)) == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			ui.ShowWarning(i18n.T("ssh would not offer %s, the key for account '%s'", expected.SSH.KeyPath, expected.Name))
+		}
+	}
+}
+
+func runList(recent bool) {
 	cfg, err := config.Load()
 	if err != nil {
-		ui.ShowError(fmt.Sprintf("Failed to load config: %v", err))
+		ui.ShowError(i18n.T("Failed to load config: %v", err))
 		return
 	}
 
@@ -175,27 +543,227 @@ func runList() {
 
 	// Render enhanced table
 	fmt.Println()
-	fmt.Print(ui.RenderAccountTable(cfg.Accounts, activeAccount, healthStatuses))
+	var accounts []config.Account
+	if recent {
+		accounts = account.RecentlyAdded(cfg.Accounts)
+	} else {
+		for _, acc := range orderedAccounts(cfg, nil) {
+			accounts = append(accounts, *acc)
+		}
+	}
+	fmt.Print(ui.RenderAccountTable(accounts, activeAccount, healthStatuses))
 
 	fmt.Println()
 	fmt.Println(ui.RenderAccountSummary(len(cfg.Accounts), activeAccount))
 }
 
-func runSwitch() {
+func runFavorite(name string) {
+	cfg, err := config.Load()
+	if err != nil {
+		ui.ShowError(i18n.T("Failed to load config: %v", err))
+		return
+	}
+
+	acc := account.NewManager(cfg).Find(name)
+	if acc == nil {
+		ui.ShowError(i18n.T("Account '%s' not found", name))
+		return
+	}
+	acc.Favorite = !acc.Favorite
+
+	if err := config.Save(cfg); err != nil {
+		ui.ShowError(i18n.T("Failed to save config: %v", err))
+		return
+	}
+	if acc.Favorite {
+		ui.ShowSuccess(i18n.T("Marked '%s' as a favorite", acc.Name))
+	} else {
+		ui.ShowSuccess(i18n.T("Removed '%s' from favorites", acc.Name))
+	}
+}
+
+func runSortOrder(order string) {
+	cfg, err := config.Load()
+	if err != nil {
+		ui.ShowError(i18n.T("Failed to load config: %v", err))
+		return
+	}
+
+	if order == "" {
+		current := cfg.AccountSort
+		if current == "" {
+			current = account.SortManual
+		}
+		ui.ShowKeyValue("Sort order", current)
+		return
+	}
+
+	if err := account.ValidateSortOrder(order); err != nil {
+		ui.ShowError(err.Error())
+		return
+	}
+	cfg.AccountSort = order
+	if order == account.SortManual {
+		cfg.AccountSort = ""
+	}
+
+	if err := config.Save(cfg); err != nil {
+		ui.ShowError(i18n.T("Failed to save config: %v", err))
+		return
+	}
+	ui.ShowSuccess(i18n.T("Accounts are now sorted by: %s", order))
+}
+
+// runLanguage shows or sets the configured message language
+func runLanguage(lang string) {
+	cfg, err := config.Load()
+	if err != nil {
+		ui.ShowError(i18n.T("Failed to load config: %v", err))
+		return
+	}
+
+	if lang == "" {
+		ui.ShowKeyValue("Language", i18n.Language())
+		configured := cfg.Language
+		if configured == "" {
+			configured = "auto"
+		}
+		ui.ShowKeyValue("Configured", configured)
+		return
+	}
+
+	lang = strings.ToLower(lang)
+	if lang == "auto" {
+		lang = ""
+	} else if !i18n.IsSupported(lang) {
+		ui.ShowError(i18n.T("Unknown language '%s' (use %s or auto)", lang, strings.Join(i18n.Languages, ", ")))
+		return
+	}
+	cfg.Language = lang
+
+	if err := config.Save(cfg); err != nil {
+		ui.ShowError(i18n.T("Failed to save config: %v", err))
+		return
+	}
+	i18n.SetLanguage(i18n.Detect(cfg.Language))
+	ui.ShowSuccess(i18n.T("Language set to: %s", i18n.Language()))
+}
+
+// runAccessible shows or sets the configured accessible mode
+func runAccessible(value string) {
 	cfg, err := config.Load()
 	if err != nil {
-		ui.ShowError(fmt.Sprintf("Failed to load config: %v", err))
+		ui.ShowError(i18n.T("Failed to load config: %v", err))
+		return
+	}
+
+	if value == "" {
+		ui.ShowKeyValue("Accessible", onOff(ui.Accessible()))
+		ui.ShowKeyValue("Configured", onOff(cfg.Accessible))
+		return
+	}
+
+	switch strings.ToLower(value) {
+	case "on":
+		cfg.Accessible = true
+	case "off":
+		cfg.Accessible = false
+	default:
+		ui.ShowError(i18n.T("Unknown value '%s' (use on or off)", value))
+		return
+	}
+
+	if err := config.Save(cfg); err != nil {
+		ui.ShowError(i18n.T("Failed to save config: %v", err))
+		return
+	}
+	ui.SetAccessible(ui.DetectAccessible(cfg.Accessible))
+	ui.ShowSuccess(i18n.T("Accessible mode: %s", onOff(cfg.Accessible)))
+}
+
+// onOff formats a setting as "on" or "off"
+func onOff(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
+}
+
+// runShowAccount shows the details of one account
+func runShowAccount(name string) {
+	cfg, err := config.Load()
+	if err != nil {
+		ui.ShowError(i18n.T("Failed to load config: %v", err))
+		return
+	}
+
+	acc := account.NewManager(cfg).Find(name)
+	if acc == nil {
+		ui.ShowError(i18n.T("Account '%s' not found", name))
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.Primary("👤 " + acc.Name))
+	ui.ShowSeparator()
+	platformType := account.PlatformGitHub
+	if acc.Platform != nil && acc.Platform.Type != "" {
+		platformType = acc.Platform.Type
+	}
+	ui.ShowKeyValue("Platform", account.GetPlatformDisplay(platformType, ""))
+	if acc.Platform != nil && acc.Platform.Domain != "" {
+		ui.ShowKeyValue("Domain", acc.Platform.Domain)
+	}
+	if acc.GitUserName != "" || acc.GitEmail != "" {
+		ui.ShowKeyValue("Identity", fmt.Sprintf("%s <%s>", acc.GitUserName, acc.GitEmail))
+	}
+	if acc.SSH != nil {
+		ui.ShowKeyValue("SSH Key", acc.SSH.KeyPath)
+	}
+	if acc.Token != nil {
+		ui.ShowKeyValue("Token", fmt.Sprintf("%s (%s)", ui.MaskSecret(acc.Token.Token), acc.Token.Username))
+	}
+	if acc.CloneDir != "" {
+		ui.ShowKeyValue("Clone Dir", acc.CloneDir)
+	}
+	if acc.CreatedAt != "" {
+		ui.ShowKeyValue("Added", formatTimestamp(acc.CreatedAt))
+	}
+	if acc.UpdatedAt != "" && acc.UpdatedAt != acc.CreatedAt {
+		ui.ShowKeyValue("Edited", formatTimestamp(acc.UpdatedAt))
+	}
+	if acc.Notes != "" {
+		fmt.Println()
+		fmt.Println(ui.Primary("📝 Notes"))
+		ui.ShowSeparator()
+		fmt.Println(acc.Notes)
+	}
+}
+
+// formatTimestamp renders an RFC3339 timestamp in local time
+func formatTimestamp(ts string) string {
+	t, err := time.Parse(time.RFC3339, ts)
+	if err != nil {
+		return ts
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+func runSwitch(email string) {
+	cfg, err := config.Load()
+	if err != nil {
+		ui.ShowError(i18n.T("Failed to load config: %v", err))
 		return
 	}
 
 	cwd, _ := os.Getwd()
 	if !git.IsGitRepo(cwd) {
-		ui.ShowError("Not in a git repository")
+		ui.ShowError(i18n.T("Not in a git repository"))
 		return
 	}
 
 	if len(cfg.Accounts) == 0 {
-		ui.ShowWarning("No accounts configured")
+		ui.ShowWarning(i18n.T("No accounts configured"))
 		return
 	}
 
@@ -203,8 +771,9 @@ func runSwitch() {
 	manager := account.NewManager(cfg)
 	activeAccount, _ := manager.DetectActive(cwd)
 
-	items := make([]ui.SelectorItem, len(cfg.Accounts))
-	for i, acc := range cfg.Accounts {
+	accounts := orderedAccounts(cfg, nil)
+	items := make([]ui.SelectorItem, len(accounts))
+	for i, acc := range accounts {
 		methods := []string{}
 		if acc.SSH != nil {
 			methods = append(methods, "🔑SSH")
@@ -226,36 +795,36 @@ func runSwitch() {
 		}
 
 		items[i] = ui.SelectorItem{
-			Title:       acc.Name,
+			Title:       accountTitle(acc),
 			Description: desc,
 			Value:       acc.Name,
 		}
 	}
 
 	// Run interactive selector
-	idx, err := ui.RunSelector("Select Account (↑/k ↓/j to navigate, enter/l to select)", items)
+	idx, err := ui.RunSelector(i18n.T("Select Account (↑/k ↓/j to navigate, enter/l to select)"), items)
 	if err != nil {
-		ui.ShowError(fmt.Sprintf("Selection error: %v", err))
+		ui.ShowError(i18n.T("Selection error: %v", err))
 		return
 	}
 
 	if idx < 0 {
-		ui.ShowInfo("Cancelled")
+		ui.ShowInfo(i18n.T("Cancelled"))
 		return
 	}
 
-	acc := cfg.Accounts[idx]
+	acc := *accounts[idx]
 
 	// Select method if both available
 	method := account.MethodSSH
 	if acc.SSH != nil && acc.Token != nil {
 		methodStr, err := ui.SelectMethodInteractive(acc.SSH != nil, acc.Token != nil)
 		if err != nil {
-			ui.ShowError(fmt.Sprintf("Selection error: %v", err))
+			ui.ShowError(i18n.T("Selection error: %v", err))
 			return
 		}
 		if methodStr == "" {
-			ui.ShowInfo("Cancelled")
+			ui.ShowInfo(i18n.T("Cancelled"))
 			return
 		}
 		if methodStr == "token" {
@@ -265,35 +834,49 @@ func runSwitch() {
 		method = account.MethodToken
 	}
 
-	if err := manager.Switch(acc.Name, method, cwd); err != nil {
-		ui.ShowError(fmt.Sprintf("Failed to switch account: %v", err))
+	email, ok := chooseCommitEmail(&acc, email, cwd)
+	if !ok {
+		return
+	}
+
+	safety, ok := confirmSwitchSafety(cwd)
+	if !ok {
+		ui.ShowInfo(i18n.T("Cancelled"))
+		return
+	}
+
+	if err := manager.SwitchWithEmail(acc.Name, method, cwd, email); err != nil {
+		ui.ShowError(i18n.T("Failed to switch account: %v", err))
 		return
 	}
 
 	if err := config.Save(cfg); err != nil {
-		ui.ShowWarning(fmt.Sprintf("Failed to save config: %v", err))
+		ui.ShowWarning(i18n.T("Failed to save config: %v", err))
 	}
 
-	ui.ShowSuccess(fmt.Sprintf("Switched to account: %s (%s)", acc.Name, method))
+	retargetStaleBranches(safety, cwd)
+
+	ui.ShowSuccess(i18n.T("Switched to account: %s (%s)", acc.Name, method))
+	checkEmailPrivacy(cfg, acc.Name, cwd)
 }
 
-func runSwitchTo(accountName string) {
+func runSwitchTo(accountName, email string) {
 	cfg, err := config.Load()
 	if err != nil {
-		ui.ShowError(fmt.Sprintf("Failed to load config: %v", err))
+		ui.ShowError(i18n.T("Failed to load config: %v", err))
 		return
 	}
 
 	cwd, _ := os.Getwd()
 	if !git.IsGitRepo(cwd) {
-		ui.ShowError("Not in a git repository")
+		ui.ShowError(i18n.T("Not in a git repository"))
 		return
 	}
 
 	manager := account.NewManager(cfg)
 	acc := manager.Find(accountName)
 	if acc == nil {
-		ui.ShowError(fmt.Sprintf("Account '%s' not found", accountName))
+		ui.ShowError(i18n.T("Account '%s' not found", accountName))
 		return
 	}
 
@@ -302,54 +885,254 @@ func runSwitchTo(accountName string) {
 		method = account.MethodToken
 	}
 
-	if err := manager.Switch(acc.Name, method, cwd); err != nil {
-		ui.ShowError(fmt.Sprintf("Failed to switch account: %v", err))
+	email, ok := chooseCommitEmail(acc, email, cwd)
+	if !ok {
+		return
+	}
+
+	safety, ok := confirmSwitchSafety(cwd)
+	if !ok {
+		ui.ShowInfo(i18n.T("Cancelled"))
+		return
+	}
+
+	if err := manager.SwitchWithEmail(acc.Name, method, cwd, email); err != nil {
+		ui.ShowError(i18n.T("Failed to switch account: %v", err))
 		return
 	}
 
 	if err := config.Save(cfg); err != nil {
-		ui.ShowWarning(fmt.Sprintf("Failed to save config: %v", err))
+		ui.ShowWarning(i18n.T("Failed to save config: %v", err))
+	}
+
+	retargetStaleBranches(safety, cwd)
+
+	ui.ShowSuccess(i18n.T("Switched to account: %s", acc.Name))
+	checkEmailPrivacy(cfg, acc.Name, cwd)
+}
+
+// chooseCommitEmail returns the address to commit with in repoPath: the one
+// given with --email, or a choice when the account has several. A token
+// account's choice is checked against the platform. It returns false when
+// cancelled or the email isn't the account's.
+func chooseCommitEmail(acc *config.Account, email, repoPath string) (string, bool) {
+	emails := acc.AllEmails()
+	if email != "" {
+		if !acc.HasEmail(email) {
+			ui.ShowError(i18n.T("'%s' is not an email of account '%s' (%s)", email, acc.Name, strings.Join(emails, ", ")))
+			return "", false
+		}
+	} else if len(emails) > 1 {
+		// The address in use here is listed first
+		_, current, _ := git.GetCurrentUser(repoPath)
+		sort.SliceStable(emails, func(i, j int) bool {
+			return strings.EqualFold(emails[i], current) && !strings.EqualFold(emails[j], current)
+		})
+		items := make([]ui.SelectorItem, len(emails))
+		for i, e := range emails {
+			var notes []string
+			if strings.EqualFold(e, current) {
+				notes = append(notes, i18n.T("in use"))
+			}
+			if strings.EqualFold(e, acc.GitEmail) {
+				notes = append(notes, i18n.T("default"))
+			}
+			if git.IsNoreplyEmail(e) {
+				notes = append(notes, i18n.T("private noreply"))
+			}
+			items[i] = ui.SelectorItem{Title: e, Description: strings.Join(notes, ", "), Value: e}
+		}
+		idx, err := ui.RunSelector(i18n.T("Commit email for this repository"), items)
+		if err != nil {
+			ui.ShowError(i18n.T("Selection error: %v", err))
+			return "", false
+		}
+		if idx < 0 {
+			ui.ShowInfo(i18n.T("Cancelled"))
+			return "", false
+		}
+		email = emails[idx]
+	} else {
+		return "", true
 	}
 
-	ui.ShowSuccess(fmt.Sprintf("Switched to account: %s", acc.Name))
+	return email, confirmEmailVerified(acc, email)
+}
+
+// confirmEmailVerified checks with the platform that email is a verified
+// address of the token account, and asks before using one that isn't.
+// Without a token, offline, or when the check fails, it goes ahead.
+func confirmEmailVerified(acc *config.Account, email string) bool {
+	if acc.Token == nil || offline.Enabled() {
+		return true
+	}
+	opts := account.RepoListOptions(acc)
+	emails, err := git.ListEmails(opts)
+	if err != nil {
+		ui.ShowInfo(i18n.T("Could not check whether %s is verified: %v", email, err))
+		return true
+	}
+	registered, verified := git.EmailVerified(email, emails)
+	switch {
+	case !registered:
+		ui.ShowWarning(i18n.T("%s is not an email of %s on %s; commits with it won't be linked to the account", email, opts.Username, opts.Host))
+	case !verified:
+		ui.ShowWarning(i18n.T("%s is not verified on %s; commits with it show as unverified", email, opts.Host))
+	default:
+		return true
+	}
+	return ui.Confirm(i18n.T("Use it anyway?"))
+}
+
+// checkEmailPrivacy warns when a GitHub account keeps its email addresses
+// private but repoPath commits with a personal one. GitHub then rejects
+// pushes if the account blocks command line pushes that expose its email,
+// so the noreply address is offered instead. Without a token or offline
+// nothing is checked.
+func checkEmailPrivacy(cfg *config.AppConfig, accountName, repoPath string) {
+	acc := account.NewManager(cfg).Find(accountName)
+	if acc == nil || acc.Token == nil || offline.Enabled() {
+		return
+	}
+	opts := account.RepoListOptions(acc)
+	if opts.Platform != "github" {
+		return
+	}
+	_, email, _ := git.GetCurrentUser(repoPath)
+	if email == "" || git.IsNoreplyEmail(email) {
+		return
+	}
+	emails, err := git.ListEmails(opts)
+	if err != nil || !git.ExposesEmail(email, emails) {
+		return
+	}
+	ui.ShowWarning(i18n.T("%s keeps its email addresses private, but commits here use %s", opts.Username, email))
+	ui.ShowInfo(i18n.T("If the account blocks command line pushes that expose your email, GitHub will reject them"))
+	useNoreplyEmail(cfg, acc, repoPath)
+}
+
+// useNoreplyEmail offers to commit in repoPath with the account's noreply
+// address, adding it to the account's emails. It returns true once set.
+func useNoreplyEmail(cfg *config.AppConfig, acc *config.Account, repoPath string) bool {
+	noreply := ""
+	for _, e := range acc.AllEmails() {
+		if git.IsNoreplyEmail(e) {
+			noreply = e
+			break
+		}
+	}
+	if noreply == "" {
+		var err error
+		if noreply, err = git.NoreplyEmail(account.RepoListOptions(acc)); err != nil {
+			ui.ShowInfo(i18n.T("Could not look up the noreply address: %v", err))
+			return false
+		}
+	}
+
+	if !ui.Confirm(i18n.T("Commit with %s in this repository?", noreply)) {
+		return false
+	}
+	if err := git.SetLocalConfig("user.email", noreply, repoPath); err != nil {
+		ui.ShowError(i18n.T("Failed to set user.email: %v", err))
+		return false
+	}
+	if !acc.HasEmail(noreply) {
+		// Keep the repository matching its pinned account
+		acc.Emails = append(acc.Emails, noreply)
+		if err := config.Save(cfg); err != nil {
+			ui.ShowWarning(i18n.T("Failed to save config: %v", err))
+		}
+	}
+	ui.ShowSuccess(i18n.T("user.email set to %s", noreply))
+	return true
+}
+
+// confirmSwitchSafety warns about repository state that makes rewriting the
+// remote risky and asks before continuing. It returns false if cancelled.
+func confirmSwitchSafety(cwd string) (*account.SwitchSafety, bool) {
+	safety, err := account.CheckSwitchSafety(cwd)
+	if err != nil || safety.OK() {
+		return safety, true
+	}
+
+	if safety.Operation != "" {
+		ui.ShowWarning(i18n.T("A %s is in progress; finish or abort it before switching if it needs the remote", safety.Operation))
+	}
+	if len(safety.Unpushed) > 0 {
+		ui.ShowWarning(i18n.T("These branches have unpushed commits for the current remote:"))
+		for _, b := range safety.Unpushed {
+			fmt.Printf("  %s %s %s\n", ui.Dim("•"), b.Name, ui.Dim(fmt.Sprintf("(%d ahead of %s)", b.Ahead, b.Upstream)))
+		}
+	}
+	if len(safety.Stale) > 0 {
+		ui.ShowWarning(i18n.T("These branches track the current URL without going through origin:"))
+		for _, b := range safety.Stale {
+			fmt.Printf("  %s %s %s\n", ui.Dim("•"), b.Name, ui.Dim("→ "+b.Remote))
+		}
+	}
+	fmt.Println()
+
+	if safety.Operation == "" && len(safety.Unpushed) == 0 {
+		// Stale branches alone are handled after the switch
+		return safety, true
+	}
+	return safety, ui.Confirm(i18n.T("Switch anyway?"))
+}
+
+// retargetStaleBranches offers to point branches that tracked the old URL at
+// the rewritten origin remote
+func retargetStaleBranches(safety *account.SwitchSafety, cwd string) {
+	if safety == nil || len(safety.Stale) == 0 {
+		return
+	}
+	if !ui.Confirm(i18n.T("Update %d branch upstreams to the new origin?", len(safety.Stale))) {
+		return
+	}
+	if err := account.RetargetBranches(safety.Stale, cwd); err != nil {
+		ui.ShowWarning(err.Error())
+		return
+	}
+	ui.ShowSuccess(i18n.T("Updated upstream for %d branches", len(safety.Stale)))
 }
 
 func runAddAccount(cfg *config.AppConfig) {
-	ui.ShowSection("Add Account")
+	ui.ShowSection(i18n.T("Add Account"))
 
-	name := ui.Prompt("Account label (e.g., work, personal)")
+	name := ui.Prompt(i18n.T("Account label (e.g., work, personal)"))
 	if name == "" {
-		ui.ShowError("Account name is required")
+		ui.ShowError(i18n.T("Account name is required"))
 		return
 	}
 
 	// Validate for duplicate name early
 	validator := account.NewDuplicateValidator(cfg.Accounts)
 	if validator.CheckNameDuplicate(name) {
-		ui.ShowError(fmt.Sprintf("Account with name '%s' already exists", name))
+		ui.ShowError(i18n.T("Account with name '%s' already exists", name))
 		return
 	}
 
-	gitUserName := ui.Prompt("Git user.name (optional)")
-	gitEmail := ui.Prompt("Git user.email (optional)")
+	gitUserName := ui.Prompt(i18n.T("Git user.name (optional)"))
+	gitEmail := ui.Prompt(i18n.T("Git user.email (optional)"))
+	cloneDir := ui.Prompt(i18n.T("Clone directory, e.g. ~/src/work (optional)"))
+	notes := ui.PromptLine(i18n.T("Notes (optional)"), "")
 
 	// Interactive platform selection with icons
 	platformItems := []ui.SelectorItem{
 		{Title: account.IconGitHub + " GitHub", Description: "github.com", Value: account.PlatformGitHub},
 		{Title: account.IconGitLab + " GitLab", Description: "gitlab.com", Value: account.PlatformGitLab},
 		{Title: account.IconBitbucket + " Bitbucket", Description: "bitbucket.org", Value: account.PlatformBitbucket},
-		{Title: account.IconGitea + " Gitea", Description: "Self-hosted Gitea", Value: account.PlatformGitea},
+		{Title: account.IconGitea + " Gitea", Description: i18n.T("Self-hosted Gitea"), Value: account.PlatformGitea},
 		{Title: account.IconCodeberg + " Codeberg", Description: "codeberg.org", Value: account.PlatformCodeberg},
-		{Title: account.IconOther + " Other", Description: "Other Git platform", Value: account.PlatformOther},
+		{Title: account.IconOther + " Other", Description: i18n.T("Other Git platform"), Value: account.PlatformOther},
 	}
 
-	platformIdx, err := ui.RunSelector("Select Platform", platformItems)
+	platformIdx, err := ui.RunSelector(i18n.T("Select Platform"), platformItems)
 	if err != nil {
-		ui.ShowError(fmt.Sprintf("Selection error: %v", err))
+		ui.ShowError(i18n.T("Selection error: %v", err))
 		return
 	}
 	if platformIdx < 0 {
-		ui.ShowInfo("Cancelled")
+		ui.ShowInfo(i18n.T("Cancelled"))
 		return
 	}
 
@@ -358,23 +1141,23 @@ func runAddAccount(cfg *config.AppConfig) {
 	// Prompt for custom domain if needed
 	customDomain := ""
 	if platformType == account.PlatformGitea || platformType == account.PlatformOther {
-		customDomain = ui.Prompt("Custom domain (e.g., git.company.com)")
+		customDomain = ui.Prompt(i18n.T("Custom domain (e.g., git.company.com)"))
 	}
 
 	// Interactive method selection
 	methodItems := []ui.SelectorItem{
-		{Title: "🔑 SSH only", Description: "Use SSH key authentication", Value: "1"},
-		{Title: "🔐 Token only", Description: "Use Personal Access Token", Value: "2"},
-		{Title: "🔑🔐 Both", Description: "Configure both SSH and Token", Value: "3"},
+		{Title: i18n.T("🔑 SSH only"), Description: i18n.T("Use SSH key authentication"), Value: "1"},
+		{Title: i18n.T("🔐 Token only"), Description: i18n.T("Use Personal Access Token"), Value: "2"},
+		{Title: i18n.T("🔑🔐 Both"), Description: i18n.T("Configure both SSH and Token"), Value: "3"},
 	}
 
-	methodIdx, err := ui.RunSelector("Select Authentication Method", methodItems)
+	methodIdx, err := ui.RunSelector(i18n.T("Select Authentication Method"), methodItems)
 	if err != nil {
-		ui.ShowError(fmt.Sprintf("Selection error: %v", err))
+		ui.ShowError(i18n.T("Selection error: %v", err))
 		return
 	}
 	if methodIdx < 0 {
-		ui.ShowInfo("Cancelled")
+		ui.ShowInfo(i18n.T("Cancelled"))
 		return
 	}
 
@@ -385,6 +1168,8 @@ func runAddAccount(cfg *config.AppConfig) {
 		GitUserName: gitUserName,
 		GitEmail:    gitEmail,
 		Platform:    &config.PlatformConfig{Type: platformType, Domain: customDomain},
+		CloneDir:    cloneDir,
+		Notes:       notes,
 	}
 
 	if methodChoice == "1" || methodChoice == "3" {
@@ -392,7 +1177,7 @@ func runAddAccount(cfg *config.AppConfig) {
 		keys, _ := ssh.ListPrivateKeys()
 		if len(keys) > 0 {
 			fmt.Println()
-			ui.ShowInfo("Existing SSH keys found. Select one or enter a new path:")
+			ui.ShowInfo(i18n.T("Existing SSH keys found. Select one or enter a new path:"))
 
 			keyItems := make([]ui.SelectorItem, len(keys)+1)
 			for i, key := range keys {
@@ -402,21 +1187,21 @@ func runAddAccount(cfg *config.AppConfig) {
 				}
 			}
 			keyItems[len(keys)] = ui.SelectorItem{
-				Title:       "📝 Enter custom path",
-				Description: "Type a new SSH key path",
+				Title:       i18n.T("📝 Enter custom path"),
+				Description: i18n.T("Type a new SSH key path"),
 				Value:       "__custom__",
 			}
 
-			keyIdx, err := ui.RunSelector("Select SSH Key", keyItems)
+			keyIdx, err := ui.RunSelector(i18n.T("Select SSH Key"), keyItems)
 			if err == nil && keyIdx >= 0 {
 				selectedKey := keyItems[keyIdx].Value
 				
 				// Check for SSH key duplicate
 				if selectedKey != "__custom__" {
 					if conflictAcc := validator.CheckSSHKeyDuplicate(selectedKey); conflictAcc != nil {
-						ui.ShowWarning(fmt.Sprintf("SSH key is already used by account '%s'", conflictAcc.Name))
-						if !ui.Confirm("Continue anyway?") {
-							ui.ShowInfo("Cancelled")
+						ui.ShowWarning(i18n.T("SSH key is already used by account '%s'", conflictAcc.Name))
+						if !ui.Confirm(i18n.T("Continue anyway?")) {
+							ui.ShowInfo(i18n.T("Cancelled"))
 							return
 						}
 					}
@@ -424,38 +1209,54 @@ func runAddAccount(cfg *config.AppConfig) {
 				
 				if selectedKey == "__custom__" {
 					acc.SSH = &config.SshConfig{
-						KeyPath:   ui.PromptWithDefault("SSH key path", fmt.Sprintf("~/.ssh/id_ed25519_%s", name)),
-						HostAlias: ui.PromptWithDefault("SSH host alias", fmt.Sprintf("%s-%s", platformType, name)),
+						KeyPath:   ui.PromptWithDefault(i18n.T("SSH key path"), fmt.Sprintf("~/.ssh/id_ed25519_%s", name)),
+						HostAlias: ui.PromptWithDefault(i18n.T("SSH host alias"), fmt.Sprintf("%s-%s", platformType, name)),
 					}
 				} else {
 					acc.SSH = &config.SshConfig{
 						KeyPath:   selectedKey,
-						HostAlias: ui.PromptWithDefault("SSH host alias", fmt.Sprintf("%s-%s", platformType, name)),
+						HostAlias: ui.PromptWithDefault(i18n.T("SSH host alias"), fmt.Sprintf("%s-%s", platformType, name)),
 					}
 				}
 			}
 		} else {
 			acc.SSH = &config.SshConfig{
-				KeyPath:   ui.PromptWithDefault("SSH key path", fmt.Sprintf("~/.ssh/id_ed25519_%s", name)),
-				HostAlias: ui.PromptWithDefault("SSH host alias", fmt.Sprintf("%s-%s", platformType, name)),
+				KeyPath:   ui.PromptWithDefault(i18n.T("SSH key path"), fmt.Sprintf("~/.ssh/id_ed25519_%s", name)),
+				HostAlias: ui.PromptWithDefault(i18n.T("SSH host alias"), fmt.Sprintf("%s-%s", platformType, name)),
 			}
 		}
 	}
 
+	if acc.SSH != nil && ui.Confirm(i18n.T("Configure advanced SSH options (port, jump host, proxy)?")) {
+		promptAdvancedSSH(acc.SSH)
+	}
+
+	// Pin the host key of self-hosted servers so the first clone does not
+	// stop at an interactive host key prompt
+	if acc.SSH != nil && customDomain != "" {
+		pinHostKey(customDomain, acc.SSH.Port)
+	}
+
 	if methodChoice == "2" || methodChoice == "3" {
-		username := ui.Prompt(fmt.Sprintf("%s username", account.GetPlatformName(platformType)))
+		username := ui.Prompt(i18n.T("%s username", account.GetPlatformName(platformType)))
 		
 		// Check for token username duplicate
 		if conflictAcc := validator.CheckTokenDuplicate(username, platformType); conflictAcc != nil {
-			ui.ShowWarning(fmt.Sprintf("Token username '%s' is already used by account '%s' on %s", 
+			ui.ShowWarning(i18n.T("Token username '%s' is already used by account '%s' on %s", 
 				username, conflictAcc.Name, platformType))
-			if !ui.Confirm("Continue anyway?") {
-				ui.ShowInfo("Cancelled")
+			if !ui.Confirm(i18n.T("Continue anyway?")) {
+				ui.ShowInfo(i18n.T("Cancelled"))
 				return
 			}
 		}
 		
-		token := ui.PromptPassword("Personal Access Token")
+		token := ui.PromptPassword(i18n.T("Personal Access Token"))
+		if token != "" {
+			ui.ShowInfo(i18n.T("Token: %s", ui.MaskSecret(token)))
+			if !ui.Confirm(i18n.T("Is this the right token?")) {
+				token = ui.PromptPassword(i18n.T("Personal Access Token"))
+			}
+		}
 		acc.Token = &config.TokenConfig{
 			Username: username,
 			Token:    token,
@@ -465,117 +1266,221 @@ func runAddAccount(cfg *config.AppConfig) {
 	// Check for email duplicate
 	if gitEmail != "" {
 		if conflictAcc := validator.CheckEmailDuplicate(gitEmail, platformType); conflictAcc != nil {
-			ui.ShowWarning(fmt.Sprintf("Email '%s' is already used by account '%s' on %s", 
+			ui.ShowWarning(i18n.T("Email '%s' is already used by account '%s' on %s", 
 				gitEmail, conflictAcc.Name, platformType))
 		}
 	}
 
 	manager := account.NewManager(cfg)
 	if err := manager.Add(acc); err != nil {
-		ui.ShowError(fmt.Sprintf("Failed to add account: %v", err))
+		ui.ShowError(i18n.T("Failed to add account: %v", err))
 		return
 	}
 
 	if err := config.Save(cfg); err != nil {
-		ui.ShowError(fmt.Sprintf("Failed to save config: %v", err))
+		ui.ShowError(i18n.T("Failed to save config: %v", err))
+		return
+	}
+
+	ui.ShowSuccess(i18n.T("Account '%s' added successfully", name))
+}
+
+// pinHostKey scans a server's SSH host keys, shows their fingerprints and
+// adds them to known_hosts once confirmed
+func pinHostKey(host string, port int) {
+	if ssh.IsKnownHost(host, port) {
+		return
+	}
+
+	spinner := ui.NewSpinner(i18n.T("Scanning host keys for %s...", host))
+	spinner.Start()
+	keys, err := ssh.ScanHostKeys(host, port)
+	if err != nil {
+		spinner.StopWithError(i18n.T("Could not scan host keys: %v", err))
 		return
 	}
+	spinner.Stop()
+
+	fmt.Println()
+	ui.ShowInfo(i18n.T("Host keys for %s:", host))
+	for _, k := range keys {
+		fmt.Printf("  %s %s %s\n", ui.Dim("•"), k.Type, k.Fingerprint())
+	}
+	ui.ShowInfo(i18n.T("Compare these with the fingerprints your server administrator publishes"))
 
-	ui.ShowSuccess(fmt.Sprintf("Account '%s' added successfully", name))
+	if !ui.Confirm(i18n.T("Trust these keys and add them to known_hosts?")) {
+		ui.ShowWarning(i18n.T("Host keys not added; the first SSH connection will ask to verify them"))
+		return
+	}
+	if err := ssh.AddKnownHosts(keys); err != nil {
+		ui.ShowError(err.Error())
+		return
+	}
+	ui.ShowSuccess(i18n.T("Added %d host key(s) to %s", len(keys), ssh.GetKnownHostsPath()))
 }
 
 func runEditAccount(cfg *config.AppConfig) {
 	if len(cfg.Accounts) == 0 {
-		ui.ShowWarning("No accounts to edit")
+		ui.ShowWarning(i18n.T("No accounts to edit"))
 		return
 	}
 
 	// Build items for selector
-	items := make([]ui.SelectorItem, len(cfg.Accounts))
-	for i, acc := range cfg.Accounts {
+	accounts := orderedAccounts(cfg, nil)
+	items := make([]ui.SelectorItem, len(accounts))
+	for i, acc := range accounts {
 		desc := ""
 		if acc.GitEmail != "" {
 			desc = acc.GitEmail
 		}
 		items[i] = ui.SelectorItem{
-			Title:       acc.Name,
+			Title:       accountTitle(acc),
 			Description: desc,
 			Value:       acc.Name,
 		}
 	}
 
-	idx, err := ui.RunSelector("Select Account to Edit", items)
+	idx, err := ui.RunSelector(i18n.T("Select Account to Edit"), items)
 	if err != nil {
-		ui.ShowError(fmt.Sprintf("Selection error: %v", err))
+		ui.ShowError(i18n.T("Selection error: %v", err))
 		return
 	}
 	if idx < 0 {
-		ui.ShowInfo("Cancelled")
+		ui.ShowInfo(i18n.T("Cancelled"))
 		return
 	}
 
-	acc := &cfg.Accounts[idx]
+	// Edit a copy so the manager can stamp the update
+	edited := *accounts[idx]
+	acc := &edited
 
 	fmt.Println()
-	acc.Name = ui.PromptWithDefault("Account label", acc.Name)
-	acc.GitUserName = ui.PromptWithDefault("Git user.name", acc.GitUserName)
-	acc.GitEmail = ui.PromptWithDefault("Git user.email", acc.GitEmail)
+	acc.Name = ui.PromptWithDefault(i18n.T("Account label"), acc.Name)
+	acc.GitUserName = ui.PromptWithDefault(i18n.T("Git user.name"), acc.GitUserName)
+	acc.GitEmail = ui.PromptWithDefault(i18n.T("Git user.email"), acc.GitEmail)
+	if emails := ui.PromptLine(i18n.T("Other emails, comma-separated (\"none\" to clear)"), strings.Join(acc.Emails, ", ")); emails == "none" {
+		acc.Emails = nil
+	} else {
+		acc.Emails = nil
+		for _, email := range strings.Split(emails, ",") {
+			if email = strings.TrimSpace(email); email != "" {
+				acc.Emails = append(acc.Emails, email)
+			}
+		}
+	}
+	if cloneDir := ui.PromptWithDefault(i18n.T("Clone directory (\"none\" to clear)"), acc.CloneDir); cloneDir == "none" {
+		acc.CloneDir = ""
+	} else {
+		acc.CloneDir = cloneDir
+	}
+
+	if notes := ui.PromptLine(i18n.T("Notes (\"none\" to clear)"), acc.Notes); notes == "none" {
+		acc.Notes = ""
+	} else {
+		acc.Notes = notes
+	}
 
+	if acc.SSH != nil && ui.Confirm(i18n.T("Edit advanced SSH options (port, jump host, proxy)?")) {
+		promptAdvancedSSH(acc.SSH)
+	}
+
+	if err := account.NewManager(cfg).Update(accounts[idx].Name, edited); err != nil {
+		ui.ShowError(err.Error())
+		return
+	}
 	if err := config.Save(cfg); err != nil {
-		ui.ShowError(fmt.Sprintf("Failed to save config: %v", err))
+		ui.ShowError(i18n.T("Failed to save config: %v", err))
 		return
 	}
 
-	ui.ShowSuccess(fmt.Sprintf("Account '%s' updated", acc.Name))
+	ui.ShowSuccess(i18n.T("Account '%s' updated", acc.Name))
+}
+
+// promptAdvancedSSH asks for a custom SSH port, jump host and proxy command
+// Entering "none" clears an existing value
+func promptAdvancedSSH(sshCfg *config.SshConfig) {
+	portDefault := ""
+	if sshCfg.Port > 0 {
+		portDefault = strconv.Itoa(sshCfg.Port)
+	}
+	portStr := ui.PromptWithDefault(i18n.T("SSH port (blank for 22)"), portDefault)
+	switch {
+	case portStr == "" || portStr == "none":
+		sshCfg.Port = 0
+	default:
+		port, err := strconv.Atoi(portStr)
+		if err != nil || port < 1 || port > 65535 {
+			ui.ShowWarning(i18n.T("Invalid port '%s', keeping current value", portStr))
+		} else {
+			sshCfg.Port = port
+		}
+	}
+
+	jump := ui.PromptWithDefault(i18n.T("ProxyJump host (e.g. user@bastion:22)"), sshCfg.ProxyJump)
+	if jump == "none" {
+		jump = ""
+	}
+	sshCfg.ProxyJump = jump
+
+	// ssh ignores ProxyCommand when ProxyJump is set
+	if jump != "" {
+		return
+	}
+	proxyCmd := ui.PromptWithDefault(i18n.T("ProxyCommand (e.g. nc -X 5 -x proxy:1080 %h %p)"), sshCfg.ProxyCommand)
+	if proxyCmd == "none" {
+		proxyCmd = ""
+	}
+	sshCfg.ProxyCommand = proxyCmd
 }
 
 func runRemoveAccount(cfg *config.AppConfig) {
 	if len(cfg.Accounts) == 0 {
-		ui.ShowWarning("No accounts to remove")
+		ui.ShowWarning(i18n.T("No accounts to remove"))
 		return
 	}
 
 	// Build items for selector
-	items := make([]ui.SelectorItem, len(cfg.Accounts))
-	for i, acc := range cfg.Accounts {
+	accounts := orderedAccounts(cfg, nil)
+	items := make([]ui.SelectorItem, len(accounts))
+	for i, acc := range accounts {
 		desc := ""
 		if acc.GitEmail != "" {
 			desc = acc.GitEmail
 		}
 		items[i] = ui.SelectorItem{
-			Title:       acc.Name,
+			Title:       accountTitle(acc),
 			Description: desc,
 			Value:       acc.Name,
 		}
 	}
 
-	idx, err := ui.RunSelector("Select Account to Remove", items)
+	idx, err := ui.RunSelector(i18n.T("Select Account to Remove"), items)
 	if err != nil {
-		ui.ShowError(fmt.Sprintf("Selection error: %v", err))
+		ui.ShowError(i18n.T("Selection error: %v", err))
 		return
 	}
 	if idx < 0 {
-		ui.ShowInfo("Cancelled")
+		ui.ShowInfo(i18n.T("Cancelled"))
 		return
 	}
 
-	acc := cfg.Accounts[idx]
+	acc := *accounts[idx]
 	fmt.Println()
-	if !ui.Confirm(fmt.Sprintf("Remove account '%s'?", acc.Name)) {
-		ui.ShowInfo("Cancelled")
+	if !ui.Confirm(i18n.T("Remove account '%s'?", acc.Name)) {
+		ui.ShowInfo(i18n.T("Cancelled"))
 		return
 	}
 
 	manager := account.NewManager(cfg)
 	if err := manager.Remove(acc.Name); err != nil {
-		ui.ShowError(fmt.Sprintf("Failed to remove account: %v", err))
+		ui.ShowError(i18n.T("Failed to remove account: %v", err))
 		return
 	}
 
 	if err := config.Save(cfg); err != nil {
-		ui.ShowError(fmt.Sprintf("Failed to save config: %v", err))
+		ui.ShowError(i18n.T("Failed to save config: %v", err))
 		return
 	}
 
-	ui.ShowSuccess(fmt.Sprintf("Account '%s' removed", acc.Name))
+	ui.ShowSuccess(i18n.T("Account '%s' removed", acc.Name))
 }
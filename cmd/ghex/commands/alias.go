@@ -0,0 +1,169 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dwirx/ghex/internal/alias"
+	"github.com/dwirx/ghex/internal/config"
+	"github.com/dwirx/ghex/internal/i18n"
+	"github.com/dwirx/ghex/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// NewAliasCmd creates the alias command
+func NewAliasCmd() *cobra.Command {
+	aliasCmd := &cobra.Command{
+		Use:   "alias",
+		Short: i18n.T("Manage command aliases"),
+		Long: `Define short names for ghex commands. An alias is replaced by its
+expansion before the command line is parsed, and the remaining arguments
+are kept, so with "dlr" set to "dlx release", "ghex dlr user/repo" runs
+"ghex dlx release user/repo".
+
+Built-in commands always win over aliases, and an alias cannot refer to
+another alias. Aliases are stored in the config file.
+
+Examples:
+  ghex alias set dlr dlx release
+  ghex alias set tw "test --account work"
+  ghex alias list
+  ghex alias remove dlr`,
+		Run: func(cmd *cobra.Command, args []string) {
+			runAliasList()
+		},
+	}
+
+	aliasCmd.AddCommand(&cobra.Command{
+		Use:   "set <name> <command...>",
+		Short: i18n.T("Create or change an alias"),
+		Args:  cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			runAliasSet(cmd.Root(), args[0], strings.Join(args[1:], " "))
+		},
+	})
+
+	aliasCmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: i18n.T("List aliases"),
+		Run: func(cmd *cobra.Command, args []string) {
+			runAliasList()
+		},
+	})
+
+	aliasCmd.AddCommand(&cobra.Command{
+		Use:     "remove <name>",
+		Aliases: []string{"rm"},
+		Short:   i18n.T("Remove an alias"),
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runAliasRemove(args[0])
+		},
+	})
+
+	return aliasCmd
+}
+
+func runAliasList() {
+	cfg, err := config.Load()
+	if err != nil {
+		ui.ShowError(i18n.T("Failed to load config: %v", err))
+		return
+	}
+	if len(cfg.Aliases) == 0 {
+		ui.ShowInfo(i18n.T("No aliases defined. Add one with: ghex alias set <name> <command>"))
+		return
+	}
+
+	names := make([]string, 0, len(cfg.Aliases))
+	for name := range cfg.Aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ui.ShowSection(i18n.T("Aliases"))
+	for _, name := range names {
+		fmt.Printf("  %s → ghex %s\n", ui.Primary(name), cfg.Aliases[name])
+	}
+}
+
+func runAliasSet(root *cobra.Command, name, expansion string) {
+	if err := alias.ValidateName(name); err != nil {
+		ui.ShowError(err.Error())
+		return
+	}
+	if isCommandName(root, name) {
+		ui.ShowError(i18n.T("'%s' is a ghex command and cannot be used as an alias", name))
+		return
+	}
+	words, err := alias.Split(expansion)
+	if err == nil && len(words) == 0 {
+		err = fmt.Errorf("empty command")
+	}
+	if err != nil {
+		ui.ShowError(i18n.T("Invalid alias: %v", err))
+		return
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		ui.ShowError(i18n.T("Failed to load config: %v", err))
+		return
+	}
+	if _, isAlias := cfg.Aliases[words[0]]; isAlias && !isCommandName(root, words[0]) {
+		ui.ShowWarning(i18n.T("'%s' is another alias; aliases are not expanded recursively", words[0]))
+	}
+
+	if cfg.Aliases == nil {
+		cfg.Aliases = map[string]string{}
+	}
+	cfg.Aliases[name] = expansion
+	if err := config.Save(cfg); err != nil {
+		ui.ShowError(i18n.T("Failed to save config: %v", err))
+		return
+	}
+	ui.ShowSuccess(i18n.T("Alias set: ghex %s → ghex %s", name, expansion))
+}
+
+func runAliasRemove(name string) {
+	cfg, err := config.Load()
+	if err != nil {
+		ui.ShowError(i18n.T("Failed to load config: %v", err))
+		return
+	}
+	if _, ok := cfg.Aliases[name]; !ok {
+		ui.ShowError(i18n.T("Alias '%s' not found", name))
+		return
+	}
+
+	delete(cfg.Aliases, name)
+	if err := config.Save(cfg); err != nil {
+		ui.ShowError(i18n.T("Failed to save config: %v", err))
+		return
+	}
+	ui.ShowSuccess(i18n.T("Alias '%s' removed", name))
+}
+
+// isCommandName reports whether name is a top-level command or one of its
+// aliases, including cobra's help and completion commands
+func isCommandName(root *cobra.Command, name string) bool {
+	if name == "help" || name == "completion" {
+		return true
+	}
+	for _, c := range root.Commands() {
+		if c.Name() == name || c.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// expandAlias expands a user alias in the first argument, unless a
+// built-in command has that name
+func expandAlias(root *cobra.Command, args []string, aliases map[string]string) ([]string, error) {
+	if len(args) == 0 || isCommandName(root, args[0]) {
+		return args, nil
+	}
+	return alias.Expand(args, aliases)
+}
@@ -0,0 +1,313 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/dwirx/ghex/internal/account"
+	"github.com/dwirx/ghex/internal/backup"
+	"github.com/dwirx/ghex/internal/config"
+	"github.com/dwirx/ghex/internal/git"
+	"github.com/dwirx/ghex/internal/i18n"
+	"github.com/dwirx/ghex/internal/platform"
+	"github.com/dwirx/ghex/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// backupOptions are the flags of 'ghex backup run'
+type backupOptions struct {
+	dir          string
+	all          bool
+	orgs         []string
+	jobs         int
+	skipArchived bool
+	compress     bool
+	keep         int
+}
+
+// backupResult counts what a backup run did for one account
+type backupResult struct {
+	account  string
+	created  int
+	updated  int
+	archived int
+	pruned   int
+	failed   []string
+}
+
+// NewBackupCmd creates the backup command
+func NewBackupCmd() *cobra.Command {
+	var opts backupOptions
+
+	backupCmd := &cobra.Command{
+		Use:   "backup",
+		Short: i18n.T("Back up the repositories of accounts"),
+		Long: `Keep bare mirrors of every repository of your accounts.
+
+'ghex backup run' lists the repositories of each selected account (its token
+user, or the users and organizations given with --org) through the platform
+API and mirrors them into the backup directory:
+
+  <dir>/mirrors/<account>/<owner>/<repo>.git
+
+The first run clones each repository with 'git clone --mirror', later runs
+fetch all refs and drop those deleted upstream. With --compress a
+timestamped .tar.gz snapshot of each mirror is written to
+<dir>/archives/<account>/<owner>/, and --keep removes all but the newest
+snapshots.
+
+Accounts with a token fetch over HTTPS, others with their SSH key. The
+credentials are passed to git for each command only, so the SSH config and
+credential store are left untouched.
+
+--non-interactive never prompts, backs up every account unless some are
+named, prints plain line-by-line output and exits with status 1 when any
+repository failed, which suits cron jobs and scheduled tasks.
+
+Examples:
+  ghex backup run
+  ghex backup run work personal --dir /mnt/backup/git
+  ghex backup run work --org my-company --jobs 8
+  ghex backup run --all --compress --keep 7
+  ghex backup run --non-interactive --dir ~/git-backups --compress --keep 14`,
+	}
+
+	runCmd := &cobra.Command{
+		Use:   "run [account...]",
+		Short: i18n.T("Mirror the repositories of accounts"),
+		Run: func(cmd *cobra.Command, args []string) {
+			if !runBackup(args, opts) {
+				os.Exit(1)
+			}
+		},
+	}
+	runCmd.Flags().StringVarP(&opts.dir, "dir", "d", filepath.Join("~", "ghex-backups"), "Backup directory")
+	runCmd.Flags().BoolVar(&opts.all, "all", false, "Back up every account without asking")
+	runCmd.Flags().StringSliceVar(&opts.orgs, "org", nil, "Back up these users or organizations instead of the account's own repositories")
+	runCmd.Flags().IntVarP(&opts.jobs, "jobs", "j", 4, "Number of repositories to mirror at once")
+	runCmd.Flags().BoolVar(&opts.skipArchived, "skip-archived", false, "Leave out archived repositories")
+	runCmd.Flags().BoolVar(&opts.compress, "compress", false, "Write a .tar.gz snapshot of each mirror")
+	runCmd.Flags().IntVar(&opts.keep, "keep", 0, "Keep only this many snapshots per repository (0 = all)")
+	backupCmd.AddCommand(runCmd)
+
+	return backupCmd
+}
+
+// runBackup mirrors the repositories of the selected accounts and prints a
+// summary. It returns false if anything failed.
+func runBackup(names []string, opts backupOptions) bool {
+	cfg, err := config.Load()
+	if err != nil {
+		ui.ShowError(i18n.T("Failed to load config: %v", err))
+		return false
+	}
+	manager := account.NewManager(cfg)
+
+	accounts, ok := selectBackupAccounts(manager, cfg, names, opts.all || ui.NonInteractive())
+	if !ok {
+		return false
+	}
+	if len(accounts) == 0 {
+		ui.ShowInfo(i18n.T("No accounts selected"))
+		return true
+	}
+
+	dir := workspaceDir(opts.dir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		ui.ShowError(i18n.T("Failed to create %s: %v", dir, err))
+		return false
+	}
+
+	start := time.Now()
+	var results []backupResult
+	for _, acc := range accounts {
+		ui.ShowSection(fmt.Sprintf("%s → %s", acc.Name, filepath.Join(dir, "mirrors", acc.Name)))
+		results = append(results, backupAccount(acc, dir, opts))
+	}
+
+	return showBackupSummary(results, dir, time.Since(start))
+}
+
+// selectBackupAccounts returns the named accounts, every account when all
+// is set, or the ones picked in a selector
+func selectBackupAccounts(manager *account.Manager, cfg *config.AppConfig, names []string, all bool) ([]*config.Account, bool) {
+	var accounts []*config.Account
+	if len(names) > 0 {
+		for _, name := range names {
+			acc := manager.Find(name)
+			if acc == nil {
+				ui.ShowError(i18n.T("Account '%s' not found", name))
+				return nil, false
+			}
+			accounts = append(accounts, acc)
+		}
+		return accounts, true
+	}
+
+	if len(cfg.Accounts) == 0 {
+		ui.ShowError(i18n.T("No accounts configured"))
+		return nil, false
+	}
+	if all {
+		for i := range cfg.Accounts {
+			accounts = append(accounts, &cfg.Accounts[i])
+		}
+		return accounts, true
+	}
+
+	items := make([]ui.SelectorItem, len(cfg.Accounts))
+	for i, acc := range cfg.Accounts {
+		platformType := account.PlatformGitHub
+		if acc.Platform != nil && acc.Platform.Type != "" {
+			platformType = acc.Platform.Type
+		}
+		desc := account.GetPlatformIcon(platformType)
+		if acc.Token != nil && acc.Token.Username != "" {
+			desc += " " + acc.Token.Username
+		}
+		items[i] = ui.SelectorItem{Title: accountTitle(&cfg.Accounts[i]), Description: desc, Value: acc.Name}
+	}
+	idxs, err := ui.RunMultiSelector(i18n.T("Select accounts to back up (space to toggle, enter to confirm)"), items)
+	if err != nil {
+		ui.ShowError(err.Error())
+		return nil, false
+	}
+	for _, i := range idxs {
+		accounts = append(accounts, &cfg.Accounts[i])
+	}
+	return accounts, true
+}
+
+// backupAccount mirrors the repositories of one account, jobs at a time,
+// then writes and prunes snapshots when asked to
+func backupAccount(acc *config.Account, dir string, opts backupOptions) backupResult {
+	result := backupResult{account: acc.Name}
+
+	owners := opts.orgs
+	if len(owners) == 0 && acc.Token != nil && acc.Token.Username != "" {
+		owners = []string{acc.Token.Username}
+	}
+	if len(owners) == 0 {
+		msg := i18n.T("No user or organization to back up; add a token username to the account or pass --org")
+		ui.ShowError(msg)
+		result.failed = append(result.failed, msg)
+		return result
+	}
+
+	var repos []string
+	seen := map[string]bool{}
+	listOpts := account.RepoListOptions(acc)
+	for _, owner := range owners {
+		spinner := ui.NewSpinner(i18n.T("Listing repositories of %s...", owner))
+		spinner.Start()
+		listed, err := git.ListRepos(owner, listOpts)
+		if err != nil {
+			spinner.StopWithError(err.Error())
+			result.failed = append(result.failed, err.Error())
+			continue
+		}
+		spinner.StopWithSuccess(i18n.T("%s: %d repositories", owner, len(listed)))
+		for _, repo := range listed {
+			if (opts.skipArchived && repo.Archived) || seen[repo.FullName] {
+				continue
+			}
+			seen[repo.FullName] = true
+			repos = append(repos, repo.FullName)
+		}
+	}
+
+	auth := backup.NewAuth(acc)
+	jobs := opts.jobs
+	if jobs < 1 {
+		jobs = 1
+	}
+
+	var mu sync.Mutex
+	var mirrored []string
+	work := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < jobs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for repo := range work {
+				remote := auth.RemoteURL(repo)
+				created, err := backup.Mirror(remote, backup.MirrorPath(dir, acc.Name, repo), auth.Env(remote))
+
+				mu.Lock()
+				switch {
+				case err != nil:
+					ui.ShowError(fmt.Sprintf("%s: %v", repo, err))
+					result.failed = append(result.failed, fmt.Sprintf("%s: %v", repo, err))
+				case created:
+					ui.ShowSuccess(i18n.T("Mirrored %s", repo))
+					result.created++
+					mirrored = append(mirrored, repo)
+				default:
+					ui.ShowSuccess(i18n.T("Updated %s", repo))
+					result.updated++
+					mirrored = append(mirrored, repo)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, repo := range repos {
+		work <- repo
+	}
+	close(work)
+	wg.Wait()
+
+	if !opts.compress {
+		return result
+	}
+	now := time.Now()
+	for _, repo := range mirrored {
+		archiveDir := backup.ArchiveDir(dir, acc.Name, repo)
+		name := filepath.Base(filepath.FromSlash(repo))
+		if _, err := backup.Archive(backup.MirrorPath(dir, acc.Name, repo), archiveDir, name, now); err != nil {
+			ui.ShowError(fmt.Sprintf("%s: %v", repo, err))
+			result.failed = append(result.failed, fmt.Sprintf("%s: %v", repo, err))
+			continue
+		}
+		result.archived++
+		if opts.keep > 0 {
+			removed, err := backup.Prune(archiveDir, name, opts.keep)
+			if err != nil {
+				ui.ShowWarning(i18n.T("Failed to prune snapshots of %s: %v", repo, err))
+			}
+			result.pruned += len(removed)
+		}
+	}
+	if result.archived > 0 {
+		ui.ShowSuccess(i18n.T("Wrote %d snapshots", result.archived))
+	}
+	return result
+}
+
+// showBackupSummary prints what each account's backup did and returns false
+// if anything failed
+func showBackupSummary(results []backupResult, dir string, elapsed time.Duration) bool {
+	ui.ShowSection(i18n.T("Backup Summary"))
+	ok := true
+	for _, r := range results {
+		line := i18n.T("%d new, %d updated, %d failed", r.created, r.updated, len(r.failed))
+		if r.archived > 0 || r.pruned > 0 {
+			line += i18n.T(", %d snapshots written, %d pruned", r.archived, r.pruned)
+		}
+		ui.ShowKeyValue(r.account, line)
+		for _, f := range r.failed {
+			fmt.Printf("    %s %s\n", ui.Error("✗"), f)
+		}
+		if len(r.failed) > 0 {
+			ok = false
+		}
+	}
+	fmt.Println()
+	ui.ShowKeyValue(i18n.T("Directory"), platform.NormalizePath(dir))
+	ui.ShowKeyValue(i18n.T("Duration"), elapsed.Round(time.Second).String())
+	return ok
+}
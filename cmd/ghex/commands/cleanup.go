@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"os"
+	"time"
+
+	"github.com/dwirx/ghex/internal/cleanup"
+	"github.com/dwirx/ghex/internal/config"
+	"github.com/dwirx/ghex/internal/i18n"
+	"github.com/dwirx/ghex/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// NewCleanupCmd creates the cleanup command
+func NewCleanupCmd() *cobra.Command {
+	var days int
+	var dryRun, yes bool
+
+	cmd := &cobra.Command{
+		Use:   "cleanup",
+		Short: i18n.T("Remove stale credentials, SSH host blocks and update backups"),
+		Long: `Find what is left behind after accounts are removed or keys are deleted,
+and remove it after confirmation:
+
+  - credentials in ~/.git-credentials for hosts no account uses
+  - Host blocks written by ghex whose IdentityFile no longer exists
+  - binary backups made by 'ghex update' older than --days
+
+Host blocks you wrote by hand (extra options, patterns or Match blocks)
+are never touched.
+
+Examples:
+  ghex cleanup
+  ghex cleanup --dry-run
+  ghex cleanup --days 7 --yes`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if !runCleanup(days, dryRun, yes) {
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().IntVar(&days, "days", 30, "Remove update backups older than this many days")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only list what would be removed")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Remove without asking for confirmation")
+
+	return cmd
+}
+
+// runCleanup lists the stale items and removes them once confirmed. It
+// returns false on failure.
+func runCleanup(days int, dryRun, yes bool) bool {
+	cfg, err := config.Load()
+	if err != nil {
+		ui.ShowError(i18n.T("Failed to load config: %v", err))
+		return false
+	}
+
+	items, err := cleanup.Find(cfg, time.Duration(days)*24*time.Hour, time.Now())
+	if err != nil {
+		ui.ShowError(err.Error())
+		return false
+	}
+	if len(items) == 0 {
+		ui.ShowSuccess(i18n.T("Nothing to clean up"))
+		return true
+	}
+
+	sections := []struct {
+		kind  cleanup.Kind
+		title string
+	}{
+		{cleanup.KindCredential, i18n.T("Stored credentials")},
+		{cleanup.KindSSHHost, i18n.T("SSH host blocks")},
+		{cleanup.KindBackup, i18n.T("Update backups")},
+	}
+	for _, section := range sections {
+		var lines []string
+		for _, item := range items {
+			if item.Kind == section.kind {
+				lines = append(lines, item.Name+" ("+item.Detail+")")
+			}
+		}
+		if len(lines) > 0 {
+			ui.ShowSection(section.title)
+			ui.ShowList(lines)
+		}
+	}
+
+	if dryRun {
+		ui.ShowInfo(i18n.T("Dry run: %d items would be removed", len(items)))
+		return true
+	}
+	if !yes && !ui.Confirm(i18n.T("Remove these %d items?", len(items))) {
+		ui.ShowInfo(i18n.T("Cancelled"))
+		return true
+	}
+
+	errs := cleanup.Remove(items)
+	for _, err := range errs {
+		ui.ShowError(err.Error())
+	}
+	if len(errs) > 0 {
+		return false
+	}
+	ui.ShowSuccess(i18n.T("Removed %d items", len(items)))
+	return true
+}
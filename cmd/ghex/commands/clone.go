@@ -2,76 +2,138 @@ package commands
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/dwirx/ghex/internal/account"
 	"github.com/dwirx/ghex/internal/config"
 	"github.com/dwirx/ghex/internal/git"
+	"github.com/dwirx/ghex/internal/i18n"
 	"github.com/dwirx/ghex/internal/ui"
+	"github.com/spf13/cobra"
 )
 
-func runClone(repoURL, targetDir string) {
+// NewCloneCmd creates the clone command
+func NewCloneCmd() *cobra.Command {
+	var accountName string
+
+	cmd := &cobra.Command{
+		Use:   "clone <url> [directory]",
+		Short: i18n.T("Clone a repository and set up an account for it"),
+		Long: `Clone a repository and configure the chosen account's identity and
+credentials in it.
+
+Accounts with a clone directory place repositories under
+<cloneDir>/<owner>/<repo> unless a directory is given, so checkouts are
+grouped by identity. Passing a URL directly (ghex <url>) works the same.
+
+Examples:
+  ghex clone https://github.com/owner/repo
+  ghex clone git@github.com:owner/repo.git --account work
+  ghex clone https://github.com/owner/repo ./repo --account personal`,
+		Args: cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			targetDir := ""
+			if len(args) > 1 {
+				targetDir = args[1]
+			}
+			runClone(args[0], targetDir, accountName)
+		},
+	}
+
+	cmd.Flags().StringVarP(&accountName, "account", "a", "", "Account to use (skips the prompt)")
+
+	return cmd
+}
+
+func runClone(repoURL, targetDir, accountName string) {
 	cfg, _ := config.Load()
 
 	ui.ShowTitle()
-	ui.ShowInfo(fmt.Sprintf("Cloning: %s", repoURL))
+	ui.ShowInfo(i18n.T("Cloning: %s", repoURL))
 
 	urlInfo, err := git.ParseURL(repoURL)
 	if err != nil {
-		ui.ShowError(fmt.Sprintf("Invalid URL: %v", err))
+		ui.ShowError(i18n.T("Invalid URL: %v", err))
 		return
 	}
 
-	if len(cfg.Accounts) > 0 {
+	accounts := orderedAccounts(cfg, nil)
+	var idx int
+	if accountName != "" {
+		for i, acc := range accounts {
+			if acc.Name == accountName {
+				idx = i + 1
+			}
+		}
+		if idx == 0 {
+			ui.ShowError(i18n.T("Account '%s' not found", accountName))
+			return
+		}
+	} else if len(accounts) > 0 {
 		fmt.Println(ui.Primary("Select account (or press Enter to skip):"))
-		for i, acc := range cfg.Accounts {
-			fmt.Printf("  %s %s\n", ui.Dim(fmt.Sprintf("[%d]", i+1)), acc.Name)
+		for i, acc := range accounts {
+			fmt.Printf("  %s %s\n", ui.Dim(fmt.Sprintf("[%d]", i+1)), accountTitle(acc))
 		}
 		fmt.Printf("  %s Skip account setup\n", ui.Dim("[0]"))
 
-		choice := ui.Prompt("Enter choice")
-		var idx int
+		choice := ui.Prompt(i18n.T("Enter choice"))
 		_, _ = fmt.Sscanf(choice, "%d", &idx)
+	}
 
-		if idx > 0 && idx <= len(cfg.Accounts) {
-			acc := cfg.Accounts[idx-1]
-
-			spinner := ui.NewSpinner("Cloning repository...")
-			spinner.Start()
-
-			clonedDir, err := git.CloneWithIdentity(repoURL, targetDir, acc.GitUserName, acc.GitEmail)
-			if err != nil {
-				spinner.StopWithError(fmt.Sprintf("Clone failed: %v", err))
-				return
+	if idx > 0 && idx <= len(accounts) {
+		acc := *accounts[idx-1]
+
+		// Group clones by identity under the account's clone directory
+		if targetDir == "" {
+			if targetDir = account.ClonePath(&acc, urlInfo.Owner, urlInfo.Repo); targetDir != "" {
+				if _, err := os.Stat(targetDir); err == nil {
+					ui.ShowError(i18n.T("%s already exists", targetDir))
+					return
+				}
+				if err := os.MkdirAll(filepath.Dir(targetDir), 0755); err != nil {
+					ui.ShowError(i18n.T("Failed to create %s: %v", filepath.Dir(targetDir), err))
+					return
+				}
 			}
+		}
 
-			spinner.StopWithSuccess(fmt.Sprintf("Cloned to: %s", clonedDir))
+		spinner := ui.NewSpinner(i18n.T("Cloning repository..."))
+		spinner.Start()
 
-			manager := account.NewManager(cfg)
-			method := account.MethodSSH
-			if acc.SSH == nil && acc.Token != nil {
-				method = account.MethodToken
-			}
+		clonedDir, err := git.CloneWithIdentity(repoURL, targetDir, acc.GitUserName, acc.GitEmail)
+		if err != nil {
+			spinner.StopWithError(i18n.T("Clone failed: %v", err))
+			return
+		}
 
-			if err := manager.Switch(acc.Name, method, clonedDir); err != nil {
-				ui.ShowWarning(fmt.Sprintf("Failed to set up account: %v", err))
-			} else {
-				ui.ShowSuccess(fmt.Sprintf("Account '%s' configured", acc.Name))
-			}
+		spinner.StopWithSuccess(i18n.T("Cloned to: %s", clonedDir))
 
-			_ = config.Save(cfg)
-			return
+		manager := account.NewManager(cfg)
+		method := account.MethodSSH
+		if acc.SSH == nil && acc.Token != nil {
+			method = account.MethodToken
+		}
+
+		if err := manager.Switch(acc.Name, method, clonedDir); err != nil {
+			ui.ShowWarning(i18n.T("Failed to set up account: %v", err))
+		} else {
+			ui.ShowSuccess(i18n.T("Account '%s' configured", acc.Name))
 		}
+
+		_ = config.Save(cfg)
+		return
 	}
 
-	spinner := ui.NewSpinner("Cloning repository...")
+	spinner := ui.NewSpinner(i18n.T("Cloning repository..."))
 	spinner.Start()
 
 	clonedDir, err := git.Clone(repoURL, targetDir)
 	if err != nil {
-		spinner.StopWithError(fmt.Sprintf("Clone failed: %v", err))
+		spinner.StopWithError(i18n.T("Clone failed: %v", err))
 		return
 	}
 
-	spinner.StopWithSuccess(fmt.Sprintf("Cloned to: %s", clonedDir))
-	ui.ShowInfo(fmt.Sprintf("Repository: %s/%s", urlInfo.Owner, urlInfo.Repo))
+	spinner.StopWithSuccess(i18n.T("Cloned to: %s", clonedDir))
+	ui.ShowInfo(i18n.T("Repository: %s/%s", urlInfo.Owner, urlInfo.Repo))
 }
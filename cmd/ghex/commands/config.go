@@ -0,0 +1,350 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dwirx/ghex/internal/config"
+	"github.com/dwirx/ghex/internal/i18n"
+	"github.com/dwirx/ghex/internal/platform"
+	"github.com/dwirx/ghex/internal/shell"
+	"github.com/dwirx/ghex/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// NewConfigCmd creates the config command
+func NewConfigCmd() *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: i18n.T("Work with the config file"),
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Println(config.GetManager().GetConfigPath())
+		},
+	}
+
+	configCmd.AddCommand(&cobra.Command{
+		Use:   "edit",
+		Short: i18n.T("Edit the config file, validated before saving"),
+		Long: `Open the config file in your editor. When the editor exits the
+changes are checked: the JSON must parse, every field must be one ghex
+knows with the right type, and values such as account names, platform
+types and workspace accounts must make sense. Problems are listed with
+their line number and you can edit again; the config file is only
+replaced once the changes are valid.
+
+The editor is taken from VISUAL, then EDITOR, and defaults to vi
+(notepad on Windows).
+
+Examples:
+  ghex config edit
+  EDITOR="code --wait" ghex config edit`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := runConfigEdit(); err != nil {
+				ui.ShowError(err.Error())
+				return err
+			}
+			return nil
+		},
+	})
+
+	configCmd.AddCommand(&cobra.Command{
+		Use:   "get [key]",
+		Short: i18n.T("Print a config value as JSON"),
+		Long: `Print the value at a key path as JSON, or the whole config without one.
+
+A key path names fields with dots and list elements with brackets, by
+index or by name: accounts are matched by name, menu entries by key and
+workspaces by dir. Map keys containing dots go in brackets too.
+
+A missing value exits with status 1.
+
+Examples:
+  ghex config get accounts[work].gitEmail
+  ghex config get accounts[0]
+  ghex config get aliases
+  ghex config get 'workspaces[~/src/work].account'`,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := ""
+			if len(args) == 1 {
+				path = args[0]
+			}
+			if err := runConfigGet(path); err != nil {
+				ui.ShowError(err.Error())
+				return err
+			}
+			return nil
+		},
+	})
+
+	setCmd := &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: i18n.T("Set a config value"),
+		Long: `Set the value at a key path (see "ghex config get --help"). Objects
+missing along the path are created, and [<length>] appends to a list.
+
+The value is read as JSON when it parses, so numbers, true/false, lists
+and objects work, and as a plain string otherwise or when a string is
+what the field needs. Use --string to always store a string. The config
+is validated before saving, like "ghex config edit" does.
+
+Examples:
+  ghex config set accounts[work].gitEmail x@corp.com
+  ghex config set accounts[work].ssh.port 2222
+  ghex config set accounts[home].favorite true
+  ghex config set 'downloadHeaders[X-Api-Key]' secret
+  ghex config set menu.hide '["ssh", "dlx"]'`,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			asString, _ := cmd.Flags().GetBool("string")
+			if err := runConfigSet(args[0], args[1], asString); err != nil {
+				ui.ShowError(err.Error())
+				return err
+			}
+			return nil
+		},
+	}
+	setCmd.Flags().Bool("string", false, "Store the value as a string, even if it looks like JSON")
+	configCmd.AddCommand(setCmd)
+
+	configCmd.AddCommand(&cobra.Command{
+		Use:   "unset <key>",
+		Short: i18n.T("Remove a config value"),
+		Long: `Remove the field or list element at a key path (see "ghex config get
+--help"). Unsetting a value that is not set does nothing.
+
+Examples:
+  ghex config unset accounts[work].ssh.port
+  ghex config unset aliases.dlr
+  ghex config unset accounts[old]`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := runConfigUnset(args[0]); err != nil {
+				ui.ShowError(err.Error())
+				return err
+			}
+			return nil
+		},
+	})
+
+	return configCmd
+}
+
+// currentConfigJSON returns the config as ghex loads it, so get and set see
+// the legacy location and defaults the same way other commands do
+func currentConfigJSON() ([]byte, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	return json.Marshal(cfg)
+}
+
+// runConfigGet prints the value at a key path, the whole config for ""
+func runConfigGet(path string) error {
+	data, err := currentConfigJSON()
+	if err != nil {
+		return err
+	}
+	var value interface{} = json.RawMessage(data)
+	if path != "" {
+		if value, err = config.GetKey(data, path); err != nil {
+			return err
+		}
+	}
+	out, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+// runConfigSet sets the value at a key path and saves the config once it
+// validates
+func runConfigSet(path, raw string, asString bool) error {
+	data, err := currentConfigJSON()
+	if err != nil {
+		return err
+	}
+
+	// Try the value as JSON first, then as a string: "22" is a port number
+	// but "1234" can also be a user name, and validation tells them apart
+	candidates := []interface{}{raw}
+	var parsed interface{}
+	if !asString && json.Unmarshal([]byte(raw), &parsed) == nil {
+		if _, isString := parsed.(string); !isString {
+			candidates = []interface{}{parsed, raw}
+		} else {
+			candidates = []interface{}{parsed}
+		}
+	}
+
+	var firstErrs []*config.ValidationError
+	for _, value := range candidates {
+		updated, err := config.SetKey(data, path, value)
+		if err != nil {
+			return err
+		}
+		errs := config.Validate(updated)
+		if len(errs) == 0 {
+			if err := saveConfigJSON(updated); err != nil {
+				return err
+			}
+			ui.ShowSuccess(i18n.T("Set %s", path))
+			return nil
+		}
+		if firstErrs == nil {
+			firstErrs = errs
+		}
+	}
+	return invalidConfigError(firstErrs)
+}
+
+// runConfigUnset removes the value at a key path and saves the config once
+// it validates
+func runConfigUnset(path string) error {
+	data, err := currentConfigJSON()
+	if err != nil {
+		return err
+	}
+	updated, found, err := config.UnsetKey(data, path)
+	if err != nil {
+		return err
+	}
+	if !found {
+		ui.ShowInfo(i18n.T("%s is not set", path))
+		return nil
+	}
+	if errs := config.Validate(updated); len(errs) > 0 {
+		return invalidConfigError(errs)
+	}
+	if err := saveConfigJSON(updated); err != nil {
+		return err
+	}
+	ui.ShowSuccess(i18n.T("Unset %s", path))
+	return nil
+}
+
+// saveConfigJSON saves validated config JSON through config.Save, which
+// keeps the usual field order and formatting
+func saveConfigJSON(data []byte) error {
+	cfg, err := config.AppConfigFromJSON(string(data))
+	if err != nil {
+		return err
+	}
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	return nil
+}
+
+// invalidConfigError refuses a change, listing the validation problems
+func invalidConfigError(errs []*config.ValidationError) error {
+	var b strings.Builder
+	b.WriteString("not saved, the change would make the config invalid:")
+	for _, e := range errs {
+		// Lines refer to the generated JSON, not the file, so only paths are shown
+		e.Line = 0
+		b.WriteString("\n  " + e.Error())
+	}
+	return errors.New(b.String())
+}
+
+// runConfigEdit edits a copy of the config file and saves it back once it
+// validates
+func runConfigEdit() error {
+	path := config.GetManager().GetConfigPath()
+	if !platform.FileExists(path) {
+		// Write the current (possibly legacy or empty) config so there is
+		// something to edit
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		if err := config.Save(cfg); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+	}
+
+	original, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	// The copy keeps the .json extension so editors pick JSON highlighting
+	tmp, err := os.CreateTemp("", "ghex-config-*"+filepath.Ext(path))
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+	_, err = tmp.Write(original)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	for {
+		if err := openInEditor(tmpPath); err != nil {
+			return err
+		}
+		edited, err := os.ReadFile(tmpPath)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(edited, original) {
+			ui.ShowInfo(i18n.T("No changes"))
+			return nil
+		}
+
+		errs := config.Validate(edited)
+		if len(errs) == 0 {
+			if err := os.WriteFile(path, edited, 0600); err != nil {
+				return err
+			}
+			_ = config.GetManager().FixPermissions()
+			ui.ShowSuccess(i18n.T("Config saved: %s", path))
+			return nil
+		}
+
+		ui.ShowError(i18n.T("The config has %d problems:", len(errs)))
+		for _, e := range errs {
+			fmt.Printf("  %s\n", e.Error())
+		}
+		if !ui.Confirm(i18n.T("Edit again?")) {
+			ui.ShowWarning(i18n.T("Changes discarded, the config file is unchanged"))
+			return nil
+		}
+	}
+}
+
+// openInEditor opens path in the user's editor and waits for it to exit
+func openInEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if strings.TrimSpace(editor) == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	args := strings.Fields(editor)
+	if len(args) == 0 {
+		args = []string{"vi"}
+		if platform.IsWindows() {
+			args = []string{"notepad"}
+		}
+	}
+	if err := shell.RunInteractive(args[0], append(args[1:], path)...); err != nil {
+		return fmt.Errorf("editor %s failed: %w", args[0], err)
+	}
+	return nil
+}
@@ -1,11 +1,16 @@
 package commands
 
 import (
-	"bufio"
 	"fmt"
-	"os"
+	"net/http"
+	"net/url"
 	"strings"
 
+	"github.com/dwirx/ghex/internal/account"
+	"github.com/dwirx/ghex/internal/clipboard"
+	"github.com/dwirx/ghex/internal/config"
+	"github.com/dwirx/ghex/internal/git"
+	"github.com/dwirx/ghex/internal/i18n"
 	"github.com/dwirx/ghex/internal/ui"
 	"github.com/dwirx/ghex/pkg/download"
 	"github.com/spf13/cobra"
@@ -14,35 +19,214 @@ import (
 // NewDlxCmd creates the dlx (download) command group
 func NewDlxCmd() *cobra.Command {
 	dlxCmd := &cobra.Command{
-		Use:   "dlx [url]",
-		Short: "Universal file downloader",
+		Use:   "dlx [url|owner/repo] [path]",
+		Short: i18n.T("Universal file downloader"),
 		Long: `Download files from any URL (HTTP/HTTPS) or GitHub repositories.
 
 GitHub URL formats supported:
   File:   https://github.com/{owner}/{repo}/blob/{branch}/{path}
   Folder: https://github.com/{owner}/{repo}/tree/{branch}/{path}
+  Repo:   https://github.com/{owner}/{repo} (pick entries, or --all)
+
+Shorthand (no URL needed):
+  owner/repo                 pick entries from the repository
+  owner/repo@v1.2.3 docs/a.md file or folder at a tag/branch/commit
+  owner/repo:ref:path        same, with the ref and path inline
+  owner/repo::path           path on the default branch
+
+GitLab URLs (including nested groups and self-hosted instances) are supported too:
+  File:   https://gitlab.com/{group}/{subgroup}/{project}/-/blob/{branch}/{path}
+  Folder: https://gitlab.com/{group}/{subgroup}/{project}/-/tree/{branch}/{path}
+
+Gitea/Forgejo URLs (codeberg.org and custom domains) work for files and folders:
+  Path:   https://codeberg.org/{owner}/{repo}/src/branch/{branch}/{path}
+
+Bitbucket URLs work the same way:
+  Path:   https://bitbucket.org/{workspace}/{repo}/src/{branch}/{path}
+
+Gists download every file under its own name, or one with --file:
+  Gist:   https://gist.github.com/{user}/{id}[/{revision}]
+
+Rate-limited (403/429) and failing (5xx) requests are retried with
+exponential backoff, waiting for Retry-After or the rate limit reset when it
+is less than a minute away; --retries sets how often.
+
+--header "Name: value" (repeatable) adds a header to every request, API
+calls included, e.g. a User-Agent or token a corporate proxy or mirror
+requires. Headers under "downloadHeaders" in the config file are sent by
+default; --header replaces a configured header of the same name.
+
+Requests go through the proxy in HTTPS_PROXY/HTTP_PROXY, or the one given
+with --proxy (http://, https:// or socks5://); hosts in NO_PROXY are
+reached directly either way. --ca-cert trusts the CA certificates in a PEM
+file besides the system ones, e.g. a proxy that re-signs TLS traffic;
+--insecure skips certificate verification altogether.
+
+--extract unpacks a downloaded .zip, .tar.gz/.tgz, .tar.xz or .tar.bz2
+archive into the output directory (--strip-components drops leading
+directories); entries that would escape the directory are refused.
+
+Directory and release downloads keep their state until every file is
+done: finished files, and partial data of the others in ".part" files.
+Running the same download again skips what is finished and resumes the
+rest from where it stopped; --continue does that for the most recent
+unfinished download, or lets you pick one, without repeating the command.
+
+--json prints one JSON document on stdout when the command finishes: the
+status (downloaded, skipped or failed), output path, URL, size and time of
+every file, with totals and the error if any. Messages and progress go to
+stderr instead.
+
+Without arguments, dlx shows a menu. A URL on the clipboard (read with
+pbpaste, PowerShell, wl-paste, xclip or xsel) is offered as the default:
+Enter picks the action that fits it and the URL prompt is prefilled.
+
+A single downloaded file can be verified with --checksum sha256:<hex> (or
+sha512:<hex>) or with --checksum-file pointing at a checksums file that
+lists it; a file that doesn't match is deleted.
 
 Examples:
   ghex dlx https://github.com/user/repo/blob/main/README.md
   ghex dlx https://github.com/user/repo/tree/main/src/
-  ghex dlx https://example.com/file.tar.gz`,
-		Args: cobra.MaximumNArgs(1),
+  ghex dlx https://github.com/user/repo/blob/v1.2.0/README.md
+  ghex dlx file https://github.com/user/repo/blob/main/go.mod --branch 3f2a9c1
+  ghex dlx user/repo@v1.2.3 docs/guide.md
+  ghex dlx release user/repo
+  ghex dlx https://gitlab.com/group/project/-/tree/main/docs
+  ghex dlx https://codeberg.org/owner/repo/src/branch/main/docs
+  ghex dlx https://example.com/file.tar.gz
+  ghex dlx https://example.com/large.iso --resume
+  ghex dlx https://example.com/large.iso --connections 8
+  ghex dlx https://github.com/user/repo/tree/main/docs --retries 6
+  ghex dlx --continue
+  ghex dlx https://mirror.example.com/file.zip -H "X-Proxy-Token: abc"
+  ghex dlx release user/tool --proxy http://proxy.corp:3128 --ca-cert corp-ca.pem
+  ghex dlx https://gist.github.com/user/0123abcd --file notes.md
+  ghex dlx https://example.com/tool.tar.gz --checksum-file https://example.com/SHA256SUMS
+  ghex dlx https://example.com/tool.tar.gz --extract --strip-components 1 -d tool
+  ghex dlx dir user/repo::docs --json > result.json`,
+		Args:              cobra.MaximumNArgs(2),
+		ValidArgsFunction: completeRepoArg,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			registerAccountHosts()
+			retries, _ := cmd.Flags().GetInt("retries")
+			download.SetRetries(retries)
+
+			headers, err := dlxHeaders(cmd)
+			if err != nil {
+				ui.ShowError(err.Error())
+				return err
+			}
+			download.SetHeaders(headers)
+
+			if err := download.SetNetwork(dlxNetwork(cmd)); err != nil {
+				ui.ShowError(err.Error())
+				return err
+			}
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if cont, _ := cmd.Flags().GetBool("continue"); cont {
+				if len(args) > 0 {
+					return fmt.Errorf("--continue takes no URL")
+				}
+				token, _ := cmd.Flags().GetString("token")
+				if err := runDlxContinue(token); err != nil {
+					ui.ShowError(err.Error())
+					return err
+				}
+				return nil
+			}
 			if len(args) > 0 {
 				output, _ := cmd.Flags().GetString("output")
 				outputDir, _ := cmd.Flags().GetString("dir")
 				overwrite, _ := cmd.Flags().GetBool("overwrite")
 				showInfo, _ := cmd.Flags().GetBool("info")
+				// Without --token, git hosts pick GITHUB_TOKEN, GITLAB_TOKEN,
+				// GITEA_TOKEN, BITBUCKET_TOKEN or an account's token; other
+				// URLs get none
 				token, _ := cmd.Flags().GetString("token")
-				if token == "" {
-					token = os.Getenv("GITHUB_TOKEN")
-				}
+				all, _ := cmd.Flags().GetBool("all")
+				force, _ := cmd.Flags().GetBool("force")
+				maxSizeMB, _ := cmd.Flags().GetInt64("max-size")
+				resume, _ := cmd.Flags().GetBool("resume")
+				connections, _ := cmd.Flags().GetInt("connections")
+				parallel, _ := cmd.Flags().GetInt("parallel")
+				verify := dlxVerification(cmd)
+				extract, _ := cmd.Flags().GetBool("extract")
+				strip, _ := cmd.Flags().GetInt("strip-components")
 
 				rawURL := args[0]
 
+				if _, ok := download.ParseShorthand(rawURL); extract && (ok || download.IsGistURL(rawURL) || isGitHubURL(rawURL) || isGitLabURL(rawURL) || download.IsRepoPathURL(rawURL)) {
+					err := fmt.Errorf("--extract applies to URL and release downloads")
+					ui.ShowError(err.Error())
+					return err
+				}
+
+				// owner/repo[@ref][:ref[:path]] shorthand
+				if sh, ok := download.ParseShorthand(rawURL); ok {
+					if len(args) > 1 && sh.Path == "" {
+						sh.Path = strings.Trim(args[1], "/")
+					}
+					if err := runShorthandDownload(sh, output, outputDir, showInfo, overwrite, all, force, maxSizeMB*1024*1024, parallel, verify, token); err != nil {
+						ui.ShowError(err.Error())
+						return err
+					}
+					return nil
+				}
+				if len(args) > 1 {
+					err := fmt.Errorf("a path argument is only supported with owner/repo shorthand")
+					ui.ShowError(err.Error())
+					return err
+				}
+
+				// Gists, all files or the one picked with --file
+				if download.IsGistURL(rawURL) {
+					file, _ := cmd.Flags().GetString("file")
+					opts := download.GistOptions{
+						File:         file,
+						Output:       output,
+						OutputDir:    outputDir,
+						Overwrite:    overwrite,
+						Token:        token,
+						Parallel:     parallel,
+						Verification: verify,
+					}
+					if err := download.GitGist(rawURL, opts); err != nil {
+						ui.ShowError(err.Error())
+						return err
+					}
+					return nil
+				}
+				if file, _ := cmd.Flags().GetString("file"); file != "" {
+					err := fmt.Errorf("--file only applies to gist downloads")
+					ui.ShowError(err.Error())
+					return err
+				}
+
 				// Auto-detect GitHub URLs and route to the appropriate downloader
 				if isGitHubURL(rawURL) {
-					if err := runGitHubDownload(rawURL, output, outputDir, showInfo, overwrite, token); err != nil {
+					if err := runGitHubDownload(rawURL, output, outputDir, showInfo, overwrite, all, force, maxSizeMB*1024*1024, parallel, verify, token); err != nil {
+						ui.ShowError(err.Error())
+						return err
+					}
+					return nil
+				}
+
+				// GitLab files and folders, falling back to GITLAB_TOKEN
+				if isGitLabURL(rawURL) {
+					if err := runGitLabDownload(rawURL, output, outputDir, showInfo, overwrite, force, maxSizeMB*1024*1024, parallel, verify, token); err != nil {
+						ui.ShowError(err.Error())
+						return err
+					}
+					return nil
+				}
+
+				// Files and folders on Gitea/Forgejo, Bitbucket and forges added
+				// with download.RegisterProvider, falling back to their token variable
+				if download.IsRepoPathURL(rawURL) {
+					if err := runRepoPathDownload(rawURL, output, outputDir, showInfo, overwrite, force, maxSizeMB*1024*1024, parallel, verify, token); err != nil {
 						ui.ShowError(err.Error())
 						return err
 					}
@@ -58,6 +242,11 @@ Examples:
 					ShowInfo:        showInfo,
 					FollowRedirects: true,
 					Token:           token,
+					Resume:          resume,
+					Connections:     connections,
+					Verification:    verify,
+					Extract:         extract,
+					StripComponents: strip,
 				}
 				if err := download.FromURL(rawURL, opts); err != nil {
 					ui.ShowError(err.Error())
@@ -71,26 +260,81 @@ Examples:
 	}
 
 	// Flags
-	dlxCmd.Flags().StringP("output", "o", "", "Output filename")
+	dlxCmd.Flags().StringP("output", "o", "", "Output filename (directory name for directory URLs)")
 	dlxCmd.Flags().StringP("dir", "d", "", "Output directory")
 	dlxCmd.Flags().BoolP("overwrite", "w", false, "Overwrite existing files")
 	dlxCmd.Flags().BoolP("info", "i", false, "Show file info before download")
-	dlxCmd.Flags().StringP("token", "t", "", "GitHub personal access token (falls back to GITHUB_TOKEN env var)")
+	dlxCmd.Flags().StringP("token", "t", "", "Access token (git hosts fall back to GITHUB_TOKEN, GITLAB_TOKEN, GITEA_TOKEN, BITBUCKET_TOKEN or a configured account's token)")
+	dlxCmd.Flags().BoolP("all", "a", false, "Download the whole repository without the entry picker")
+	dlxCmd.Flags().BoolP("force", "f", false, "Skip confirmation for large downloads")
+	dlxCmd.Flags().Int64("max-size", 100, "Ask for confirmation above this total size in MB (0 = the 100 MB default, -1 = never)")
+	dlxCmd.Flags().Bool("resume", false, "Keep partial data of URL downloads so an interrupted download can be resumed")
+	dlxCmd.Flags().Int("connections", 1, "Download URLs over this many parallel connections when the server supports ranges")
+	dlxCmd.Flags().IntP("parallel", "p", download.DefaultParallel, "Files of a directory or repository to download at once")
+	dlxCmd.Flags().String("file", "", "Only download this file of a gist")
+	dlxCmd.Flags().Bool("continue", false, "Continue an interrupted or partly failed directory or release download")
+	addChecksumFlags(dlxCmd)
+	addExtractFlags(dlxCmd)
+	dlxCmd.PersistentFlags().Int("retries", 3, "Retry rate-limited and failed requests this many times, with backoff (0 = never)")
+	dlxCmd.PersistentFlags().StringArrayP("header", "H", nil, "Extra request header \"Name: value\" for every request, API calls included (repeatable)")
+	dlxCmd.PersistentFlags().String("proxy", "", "Proxy URL for every request (default: HTTPS_PROXY/HTTP_PROXY)")
+	dlxCmd.PersistentFlags().String("ca-cert", "", "PEM file of CA certificates to trust besides the system ones")
+	dlxCmd.PersistentFlags().Bool("insecure", false, "Skip TLS certificate verification")
 
 	// Subcommands
 	dlxCmd.AddCommand(newDlxFileCmd())
 	dlxCmd.AddCommand(newDlxDirCmd())
+	dlxCmd.AddCommand(newDlxRepoCmd())
+	dlxCmd.AddCommand(newDlxSyncCmd())
 	dlxCmd.AddCommand(newDlxReleaseCmd())
 	dlxCmd.AddCommand(newDlxListCmd())
+	addDlxJSON(dlxCmd)
 
 	return dlxCmd
 }
 
+// runDlxContinue continues an unfinished directory or release download,
+// asking which one when there are several
+func runDlxContinue(token string) error {
+	jobs, err := download.ListJobs()
+	if err != nil {
+		return err
+	}
+	if len(jobs) == 0 {
+		ui.ShowInfo(i18n.T("No unfinished downloads to continue"))
+		return nil
+	}
+
+	job := jobs[0]
+	if len(jobs) > 1 {
+		items := make([]ui.SelectorItem, len(jobs))
+		for i, j := range jobs {
+			files, bytes := j.Remaining()
+			desc := i18n.T("%d of %d files left", files, len(j.Files))
+			if bytes > 0 {
+				desc += ", " + ui.FormatBytes(bytes)
+			}
+			items[i] = ui.SelectorItem{
+				Title:       fmt.Sprintf("%s %s → %s", j.Kind, j.Source, j.OutputDir),
+				Description: desc + " · " + j.Updated.Local().Format("2006-01-02 15:04"),
+				Value:       j.ID,
+			}
+		}
+		idx, err := ui.RunSelector(i18n.T("Continue which download?"), items)
+		if err != nil || idx < 0 {
+			return nil
+		}
+		job = jobs[idx]
+	}
+	return download.ContinueJob(job, token)
+}
+
 func newDlxFileCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "file [url]",
-		Short: "Download a single file from Git repository",
-		Args:  cobra.ExactArgs(1),
+		Use:               "file [url|owner/repo] [path]",
+		Short:             i18n.T("Download a single file from Git repository"),
+		Args:              cobra.RangeArgs(1, 2),
+		ValidArgsFunction: completeRepoArg,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			branch, _ := cmd.Flags().GetString("branch")
 			output, _ := cmd.Flags().GetString("output")
@@ -98,19 +342,17 @@ func newDlxFileCmd() *cobra.Command {
 			overwrite, _ := cmd.Flags().GetBool("overwrite")
 			showInfo, _ := cmd.Flags().GetBool("info")
 			token, _ := cmd.Flags().GetString("token")
-			if token == "" {
-				token = os.Getenv("GITHUB_TOKEN")
-			}
 
 			opts := download.GitOptions{
-				Branch:    branch,
-				Output:    output,
-				OutputDir: outputDir,
-				Overwrite: overwrite,
-				ShowInfo:  showInfo,
-				Token:     token,
+				Branch:       branch,
+				Output:       output,
+				OutputDir:    outputDir,
+				Overwrite:    overwrite,
+				ShowInfo:     showInfo,
+				Token:        token,
+				Verification: dlxVerification(cmd),
 			}
-			if err := download.GitFile(args[0], opts); err != nil {
+			if err := download.GitFile(expandDlxArgs(args, "file"), opts); err != nil {
 				ui.ShowError(err.Error())
 				return err
 			}
@@ -123,25 +365,53 @@ func newDlxFileCmd() *cobra.Command {
 	cmd.Flags().StringP("dir", "d", "", "Output directory")
 	cmd.Flags().BoolP("overwrite", "w", false, "Overwrite existing files")
 	cmd.Flags().BoolP("info", "i", false, "Show file info before download")
-	cmd.Flags().StringP("token", "t", "", "GitHub personal access token (falls back to GITHUB_TOKEN env var)")
+	cmd.Flags().StringP("token", "t", "", "Access token (falls back to GITHUB_TOKEN, GITLAB_TOKEN, GITEA_TOKEN or BITBUCKET_TOKEN by host, then a configured account's token)")
+	addChecksumFlags(cmd)
 
 	return cmd
 }
 
+// addChecksumFlags adds the flags that verify a downloaded file
+func addChecksumFlags(cmd *cobra.Command) {
+	cmd.Flags().String("checksum", "", "Expected checksum of the file, sha256:<hex> or sha512:<hex>; a mismatching file is deleted")
+	cmd.Flags().String("checksum-file", "", "URL or path of a checksums file (e.g. SHA256SUMS) listing the file")
+}
+
+// addExtractFlags adds the flags that unpack a downloaded archive
+func addExtractFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolP("extract", "x", false, "Unpack .zip, .tar.gz, .tar.xz and .tar.bz2 downloads into the output directory")
+	cmd.Flags().Int("strip-components", 0, "Drop this many leading path components when extracting")
+}
+
+// dlxVerification returns the checksum given with the checksum flags
+func dlxVerification(cmd *cobra.Command) download.Verification {
+	sum, _ := cmd.Flags().GetString("checksum")
+	file, _ := cmd.Flags().GetString("checksum-file")
+	return download.Verification{Checksum: sum, ChecksumFile: file}
+}
+
 func newDlxDirCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "dir [url]",
-		Short: "Download a directory from Git repository",
-		Args:  cobra.ExactArgs(1),
+		Use:               "dir [url|owner/repo] [path]",
+		Short:             i18n.T("Download a directory from Git repository"),
+		Args:              cobra.RangeArgs(1, 2),
+		ValidArgsFunction: completeRepoArg,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			branch, _ := cmd.Flags().GetString("branch")
 			outputDir, _ := cmd.Flags().GetString("dir")
 			depth, _ := cmd.Flags().GetInt("depth")
 			overwrite, _ := cmd.Flags().GetBool("overwrite")
 			showInfo, _ := cmd.Flags().GetBool("info")
+			force, _ := cmd.Flags().GetBool("force")
+			maxSizeMB, _ := cmd.Flags().GetInt64("max-size")
+			backendName, _ := cmd.Flags().GetString("backend")
 			token, _ := cmd.Flags().GetString("token")
-			if token == "" {
-				token = os.Getenv("GITHUB_TOKEN")
+			parallel, _ := cmd.Flags().GetInt("parallel")
+
+			backend, err := download.ParseBackend(backendName)
+			if err != nil {
+				ui.ShowError(err.Error())
+				return err
 			}
 
 			opts := download.GitOptions{
@@ -151,8 +421,12 @@ func newDlxDirCmd() *cobra.Command {
 				Overwrite: overwrite,
 				ShowInfo:  showInfo,
 				Token:     token,
+				MaxSize:   maxSizeMB * 1024 * 1024,
+				Force:     force,
+				Backend:   backend,
+				Parallel:  parallel,
 			}
-			if err := download.GitDirectory(args[0], opts); err != nil {
+			if err := download.GitDirectory(expandDlxArgs(args, "dir"), opts); err != nil {
 				ui.ShowError(err.Error())
 				return err
 			}
@@ -165,36 +439,264 @@ func newDlxDirCmd() *cobra.Command {
 	cmd.Flags().IntP("depth", "n", 100, "Max directory depth (0 = unlimited)")
 	cmd.Flags().BoolP("overwrite", "w", false, "Overwrite existing files")
 	cmd.Flags().BoolP("info", "i", false, "Show file info before download")
-	cmd.Flags().StringP("token", "t", "", "GitHub personal access token (falls back to GITHUB_TOKEN env var)")
+	cmd.Flags().StringP("token", "t", "", "Access token (falls back to GITHUB_TOKEN, GITLAB_TOKEN, GITEA_TOKEN or BITBUCKET_TOKEN by host, then a configured account's token)")
+	cmd.Flags().BoolP("force", "f", false, "Skip confirmation for large downloads")
+	cmd.Flags().Int64("max-size", 100, "Ask for confirmation above this total size in MB (0 = the 100 MB default, -1 = never)")
+	cmd.Flags().String("backend", "auto", "Download backend: auto (API, git on rate limit), api, or git (sparse clone)")
+	cmd.Flags().IntP("parallel", "p", download.DefaultParallel, "Files to download at once")
+
+	return cmd
+}
+
+func newDlxRepoCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "repo [url|owner/repo]",
+		Short: i18n.T("Download a repository, picking top-level entries"),
+		Long: `Download a GitHub, GitLab, Gitea or Bitbucket repository.
+
+An interactive picker lists the top-level GitHub files and folders so you
+can choose what to fetch. Use --all to download everything without
+prompting.
+
+With --archive, the whole repository is fetched as one .tar.gz snapshot
+(codeload.github.com, or the API when a token is used) and extracted into
+the output directory, which is far faster than file-by-file downloads for
+large repositories. GitLab, Gitea and Bitbucket repositories are always
+fetched this way.
+
+Examples:
+  ghex dlx repo user/repo
+  ghex dlx repo user/repo --all --depth 2
+  ghex dlx repo user/repo --archive --branch v1.2.0 -d repo-1.2.0
+  ghex dlx repo https://gitlab.com/group/project`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeRepoArg,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			branch, _ := cmd.Flags().GetString("branch")
+			outputDir, _ := cmd.Flags().GetString("dir")
+			depth, _ := cmd.Flags().GetInt("depth")
+			overwrite, _ := cmd.Flags().GetBool("overwrite")
+			all, _ := cmd.Flags().GetBool("all")
+			force, _ := cmd.Flags().GetBool("force")
+			maxSizeMB, _ := cmd.Flags().GetInt64("max-size")
+			token, _ := cmd.Flags().GetString("token")
+			parallel, _ := cmd.Flags().GetInt("parallel")
+			archive, _ := cmd.Flags().GetBool("archive")
+
+			opts := download.RepoOptions{
+				Branch:    branch,
+				OutputDir: outputDir,
+				Depth:     depth,
+				Overwrite: overwrite,
+				Token:     token,
+				All:       all,
+				MaxSize:   maxSizeMB * 1024 * 1024,
+				Force:     force,
+				Parallel:  parallel,
+				Archive:   archive,
+			}
+			if err := download.GitRepo(expandDlxArgs(args, "repo"), opts); err != nil {
+				ui.ShowError(err.Error())
+				return err
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("branch", "b", "", "Branch/tag/commit")
+	cmd.Flags().StringP("dir", "d", "", "Output directory")
+	cmd.Flags().IntP("depth", "n", 100, "Max directory depth (0 = unlimited)")
+	cmd.Flags().BoolP("overwrite", "w", false, "Overwrite existing files")
+	cmd.Flags().BoolP("all", "a", false, "Download everything without the entry picker")
+	cmd.Flags().Bool("archive", false, "Download the whole repository as one archive and extract it")
+	cmd.Flags().StringP("token", "t", "", "Access token (falls back to GITHUB_TOKEN, GITLAB_TOKEN, GITEA_TOKEN or BITBUCKET_TOKEN by host, then a configured account's token)")
+	cmd.Flags().BoolP("force", "f", false, "Skip confirmation for large downloads")
+	cmd.Flags().Int64("max-size", 100, "Ask for confirmation above this total size in MB (0 = the 100 MB default, -1 = never)")
+	cmd.Flags().IntP("parallel", "p", download.DefaultParallel, "Files to download at once")
+
+	return cmd
+}
+
+func newDlxSyncCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync [url|owner/repo] [path]",
+		Short: i18n.T("Mirror a repository directory, downloading only changed files"),
+		Long: `Mirror a GitHub, GitLab, Gitea or Bitbucket directory into a local one.
+
+The first run downloads every file and writes ` + download.ManifestName + `
+with each file's path, blob SHA and size. Later runs compare the SHAs and
+only download files that changed or are missing locally. Files removed
+upstream are listed, and deleted with --prune.
+
+Examples:
+  ghex dlx sync https://github.com/user/repo/tree/main/docs
+  ghex dlx sync user/repo docs -d vendor/docs
+  ghex dlx sync user/repo::docs --prune`,
+		Args:              cobra.RangeArgs(1, 2),
+		ValidArgsFunction: completeRepoArg,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			branch, _ := cmd.Flags().GetString("branch")
+			outputDir, _ := cmd.Flags().GetString("dir")
+			depth, _ := cmd.Flags().GetInt("depth")
+			token, _ := cmd.Flags().GetString("token")
+			parallel, _ := cmd.Flags().GetInt("parallel")
+			prune, _ := cmd.Flags().GetBool("prune")
+
+			opts := download.SyncOptions{
+				Branch:    branch,
+				OutputDir: outputDir,
+				Depth:     depth,
+				Token:     token,
+				Parallel:  parallel,
+				Prune:     prune,
+			}
+			if err := download.GitSync(expandDlxArgs(args, "dir"), opts); err != nil {
+				ui.ShowError(err.Error())
+				return err
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("branch", "b", "", "Branch/tag/commit")
+	cmd.Flags().StringP("dir", "d", "", "Output directory (default: the directory's name)")
+	cmd.Flags().IntP("depth", "n", 100, "Max directory depth (0 = unlimited)")
+	cmd.Flags().StringP("token", "t", "", "Access token (falls back to GITHUB_TOKEN, GITLAB_TOKEN, GITEA_TOKEN or BITBUCKET_TOKEN by host, then a configured account's token)")
+	cmd.Flags().IntP("parallel", "p", download.DefaultParallel, "Files to download at once")
+	cmd.Flags().Bool("prune", false, "Delete local files that were removed upstream")
 
 	return cmd
 }
 
 func newDlxReleaseCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "release [repo-url]",
-		Short: "Download release assets from GitHub",
-		Args:  cobra.ExactArgs(1),
+		Use:   "release [repo-url|owner/repo[@tag]]",
+		Short: i18n.T("Download release assets from GitHub, GitLab or Gitea"),
+		Long: `Download release assets from GitHub, GitLab (release links and
+generic package registry files) or Gitea/Forgejo, including codeberg.org
+and the custom domains of configured accounts. Bitbucket has no tagged
+releases; its repository Downloads page is offered as the "downloads"
+release.
+
+With --manifest, installs every tool listed in a YAML manifest and skips
+tools that are already at the resolved version:
+
+  dir: ~/.local/bin
+  tools:
+    - repo: junegunn/fzf
+      version: "^0.44"          # latest, an exact tag or a range
+      asset: "*{os}_{arch}.tar.gz"
+      binary: fzf               # extracted from .tar.gz/.zip assets
+      path: ~/bin/fzf           # optional, defaults to dir/binary
+
+Installed versions are recorded in <manifest>.lock.
+
+With --list-all, every release is listed with its date and prerelease flag
+(following pagination) and the picked one continues to asset selection.
+--tag narrows the list to tags matching a glob, --version to a range.
+
+--auto picks the asset built for this OS and architecture from its name
+(linux/darwin/macos/windows, amd64/x86_64/arm64/aarch64, ...), skipping
+checksums, signatures and OS packages, so a third-party binary installs
+with one command. When several fit equally, narrow them with --asset.
+
+Only stable releases are considered unless --prerelease is given, whether
+picking the latest release, resolving a range or listing. Drafts are
+always skipped.
+
+Examples:
+  ghex dlx release user/repo
+  ghex dlx release user/repo@v1.2.0 --asset linux
+  ghex dlx release user/repo --version "^1.4"
+  ghex dlx release user/repo --prerelease --asset linux
+  ghex dlx release user/repo --install --asset linux_amd64
+  ghex dlx release user/repo --install --auto
+  ghex dlx release user/repo --install --require-attestation
+  ghex dlx release user/repo --asset linux --qr
+  ghex dlx release user/repo --asset linux_amd64 --extract -d tool
+  ghex dlx release user/repo --list-all --tag "v1.*"
+  ghex dlx release user/repo --list-all --list
+  ghex dlx release --manifest tools.yml`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeRepoArg,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			manifest, _ := cmd.Flags().GetString("manifest")
 			version, _ := cmd.Flags().GetString("version")
 			asset, _ := cmd.Flags().GetString("asset")
 			outputDir, _ := cmd.Flags().GetString("dir")
 			listOnly, _ := cmd.Flags().GetBool("list")
 			overwrite, _ := cmd.Flags().GetBool("overwrite")
+			// Without --token, GITHUB_TOKEN or GITLAB_TOKEN is picked per host
 			token, _ := cmd.Flags().GetString("token")
-			if token == "" {
-				token = os.Getenv("GITHUB_TOKEN")
+
+			if manifest != "" {
+				if len(args) > 0 {
+					return fmt.Errorf("--manifest cannot be combined with a repository argument")
+				}
+				force, _ := cmd.Flags().GetBool("force")
+				opts := download.ManifestOptions{
+					Token:    token,
+					Force:    force,
+					ListOnly: listOnly,
+				}
+				if err := download.GitReleaseManifest(manifest, opts); err != nil {
+					ui.ShowError(err.Error())
+					return err
+				}
+				return nil
+			}
+			if len(args) == 0 {
+				return fmt.Errorf("requires a repository argument or --manifest")
+			}
+
+			// owner/repo@tag selects the release when --version isn't given
+			repoArg := args[0]
+			if sh, ok := download.ParseShorthand(repoArg); ok {
+				if version == "" {
+					version = sh.Ref
+				}
+				repoArg = sh.RepoURL()
+			}
+
+			install, _ := cmd.Flags().GetBool("install")
+			binDir, _ := cmd.Flags().GetString("bin-dir")
+			binary, _ := cmd.Flags().GetString("binary")
+			qr, _ := cmd.Flags().GetBool("qr")
+			requireAttestation, _ := cmd.Flags().GetBool("require-attestation")
+			extract, _ := cmd.Flags().GetBool("extract")
+			strip, _ := cmd.Flags().GetInt("strip-components")
+			listAll, _ := cmd.Flags().GetBool("list-all")
+			tagPattern, _ := cmd.Flags().GetString("tag")
+			prerelease, _ := cmd.Flags().GetBool("prerelease")
+			auto, _ := cmd.Flags().GetBool("auto")
+			if install {
+				binDir = installBinDir(binDir)
 			}
 
 			opts := download.ReleaseOptions{
 				Version:   version,
 				Asset:     asset,
+				Auto:      auto,
 				OutputDir: outputDir,
 				ListOnly:  listOnly,
 				Overwrite: overwrite,
 				Token:     token,
+				Install:   install,
+				BinDir:    binDir,
+				Binary:    binary,
+				QR:        qr,
+
+				RequireAttestation: requireAttestation,
+
+				Extract:         extract,
+				StripComponents: strip,
+
+				ListAll:    listAll,
+				TagPattern: tagPattern,
+
+				Prerelease: prerelease,
 			}
-			if err := download.GitRelease(args[0], opts); err != nil {
+			if err := download.GitRelease(repoArg, opts); err != nil {
 				ui.ShowError(err.Error())
 				return err
 			}
@@ -202,12 +704,24 @@ func newDlxReleaseCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringP("version", "v", "", "Release version/tag (default: latest)")
+	cmd.Flags().StringP("version", "v", "", "Release tag or range like \"^1.4\" or \">=2 <3\" (default: latest)")
 	cmd.Flags().StringP("asset", "a", "", "Asset name filter")
+	cmd.Flags().Bool("auto", false, "Pick the asset built for this OS and architecture")
 	cmd.Flags().StringP("dir", "d", "", "Output directory")
-	cmd.Flags().BoolP("list", "l", false, "List assets only")
+	cmd.Flags().BoolP("list", "l", false, "List assets only (with --list-all, list releases only)")
+	cmd.Flags().Bool("list-all", false, "List every release and pick one interactively")
+	cmd.Flags().String("tag", "", "With --list-all, only list tags matching a glob like \"v1.*\"")
+	cmd.Flags().Bool("prerelease", false, "Include prereleases (default: stable releases only)")
 	cmd.Flags().BoolP("overwrite", "w", false, "Overwrite existing files")
-	cmd.Flags().StringP("token", "t", "", "GitHub personal access token (falls back to GITHUB_TOKEN env var)")
+	cmd.Flags().StringP("token", "t", "", "Access token (falls back to GITHUB_TOKEN, GITLAB_TOKEN, GITEA_TOKEN or BITBUCKET_TOKEN by host, then a configured account's token)")
+	cmd.Flags().StringP("manifest", "m", "", "Install/update every tool in a YAML manifest")
+	cmd.Flags().BoolP("force", "f", false, "With --manifest, reinstall tools that are already up to date")
+	cmd.Flags().BoolP("install", "i", false, "Install the asset as a binary tracked by 'ghex outdated'")
+	cmd.Flags().String("bin-dir", "", "Install directory for --install (default: binDir from the config, else ~/.local/bin)")
+	cmd.Flags().String("binary", "", "Binary name to extract and install (default: repo name)")
+	cmd.Flags().Bool("qr", false, "Show the selected asset's download URL as a QR code")
+	cmd.Flags().Bool("require-attestation", false, "With --install, refuse assets without a GitHub artifact attestation")
+	addExtractFlags(cmd)
 
 	return cmd
 }
@@ -215,11 +729,49 @@ func newDlxReleaseCmd() *cobra.Command {
 func newDlxListCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "list [file]",
-		Short: "Download files from a URL list file",
-		Args:  cobra.ExactArgs(1),
+		Short: i18n.T("Download files from a URL list file"),
+		Long: `Download every URL of a queue file, --parallel at a time.
+
+Plain files have one download per line: the URL, optionally followed by the
+output path (a trailing slash names a directory), a checksum and headers.
+Blank lines and # comments are skipped:
+
+  https://example.com/a.tar.gz
+  https://example.com/b.zip vendor/b.zip checksum=sha256:9f86d0...
+  https://example.com/c.bin header="Authorization: Bearer xyz"
+
+Files ending in .yaml, .yml or .json hold a list of entries, at the top
+level or under "items":
+
+  items:
+    - url: https://example.com/b.zip
+      output: vendor/b.zip
+      checksum: sha256:9f86d0...
+      headers:
+        Authorization: Bearer xyz
+
+Each finished or failed entry is recorded in <file>.state.json. Running the
+same queue again skips the entries already downloaded and resumes the
+failed and unfinished ones where they stopped. --restart ignores the
+recorded state, --overwrite downloads every entry again.
+
+Examples:
+  ghex dlx list urls.txt
+  ghex dlx list queue.yaml --parallel 8 -d downloads
+  ghex dlx list urls.txt --restart`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			parallel, _ := cmd.Flags().GetInt("parallel")
-			if err := downloadFromFileList(args[0], parallel); err != nil {
+			outputDir, _ := cmd.Flags().GetString("dir")
+			overwrite, _ := cmd.Flags().GetBool("overwrite")
+			restart, _ := cmd.Flags().GetBool("restart")
+			opts := download.QueueOptions{
+				OutputDir: outputDir,
+				Parallel:  parallel,
+				Overwrite: overwrite,
+				Restart:   restart,
+			}
+			if err := download.DownloadQueue(args[0], opts); err != nil {
 				ui.ShowError(err.Error())
 				return err
 			}
@@ -227,36 +779,257 @@ func newDlxListCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().IntP("parallel", "p", 5, "Number of parallel downloads")
+	cmd.Flags().IntP("parallel", "p", download.DefaultQueueParallel, "Number of parallel downloads")
+	cmd.Flags().StringP("dir", "d", "", "Directory output paths are relative to")
+	cmd.Flags().BoolP("overwrite", "w", false, "Download every entry again, replacing existing files")
+	cmd.Flags().Bool("restart", false, "Ignore the recorded state (existing files are still skipped without --overwrite)")
 
 	return cmd
 }
 
+// expandDlxArgs converts owner/repo shorthand (plus an optional path
+// argument) into the URL form a subcommand expects. URLs pass through.
+func expandDlxArgs(args []string, kind string) string {
+	sh, ok := download.ParseShorthand(args[0])
+	if !ok {
+		return args[0]
+	}
+	if len(args) > 1 && sh.Path == "" {
+		sh.Path = strings.Trim(args[1], "/")
+	}
+
+	switch kind {
+	case "file":
+		return sh.BlobURL()
+	case "dir":
+		return sh.TreeURL()
+	default:
+		if sh.Ref == "" && sh.Path == "" {
+			return sh.RepoURL()
+		}
+		return sh.TreeURL()
+	}
+}
+
+// runShorthandDownload downloads an owner/repo shorthand reference. Without a
+// path it opens the repository picker; a path is tried as a file first and
+// as a directory if no such file exists.
+func runShorthandDownload(sh *download.Shorthand, output, outputDir string, showInfo, overwrite, all, force bool, maxSize int64, parallel int, verify download.Verification, token string) error {
+	if sh.Path == "" {
+		return download.GitRepo(sh.RepoURL(), download.RepoOptions{
+			Branch:       sh.Ref,
+			OutputDir:    outputDir,
+			Depth:        100,
+			Overwrite:    overwrite,
+			Token:        token,
+			Verification: verify,
+			All:          all,
+			MaxSize:      maxSize,
+			Parallel:     parallel,
+			Force:        force,
+		})
+	}
+
+	err := download.GitFile(sh.BlobURL(), download.GitOptions{
+		Output:       output,
+		OutputDir:    outputDir,
+		Overwrite:    overwrite,
+		ShowInfo:     showInfo,
+		Token:        token,
+		Verification: verify,
+	})
+	if download.IsNotFound(err) {
+		ui.ShowInfo(i18n.T("No file at that path, trying it as a directory..."))
+		return download.GitDirectory(sh.TreeURL(), download.GitOptions{
+			Output:       output,
+			OutputDir:    outputDir,
+			Depth:        100,
+			Overwrite:    overwrite,
+			ShowInfo:     showInfo,
+			Token:        token,
+			Verification: verify,
+			MaxSize:      maxSize,
+			Parallel:     parallel,
+			Force:        force,
+		})
+	}
+	return err
+}
+
+// dlxNetwork returns the proxy and TLS settings given on the command line
+func dlxNetwork(cmd *cobra.Command) download.Network {
+	var n download.Network
+	n.Proxy, _ = cmd.Flags().GetString("proxy")
+	n.CACert, _ = cmd.Flags().GetString("ca-cert")
+	n.Insecure, _ = cmd.Flags().GetBool("insecure")
+	if n.Insecure {
+		ui.ShowWarning(i18n.T("TLS certificate verification is off (--insecure)"))
+	}
+	return n
+}
+
+// dlxHeaders returns the configured download headers with those given by
+// --header, which replace configured ones of the same name
+func dlxHeaders(cmd *cobra.Command) (http.Header, error) {
+	headers := http.Header{}
+	if cfg, err := config.Load(); err == nil {
+		for name, value := range cfg.DownloadHeaders {
+			headers.Set(name, value)
+		}
+	}
+
+	lines, _ := cmd.Flags().GetStringArray("header")
+	flagHeaders, err := download.ParseHeaders(lines)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range flagHeaders {
+		headers[name] = values
+	}
+	return headers, nil
+}
+
+// registerAccountHosts lets dlx recognize the custom domains of configured
+// accounts (self-hosted GitLab, Gitea, Forgejo) and use their tokens when
+// neither --token nor the environment gives one.
+func registerAccountHosts() {
+	cfg, err := config.Load()
+	if err != nil {
+		return
+	}
+	for _, acc := range cfg.Accounts {
+		if acc.Platform != nil && acc.Platform.Domain != "" {
+			download.RegisterHost(acc.Platform.Domain, acc.Platform.Type)
+		}
+		if acc.Token != nil {
+			platformType, domain := account.PlatformGitHub, ""
+			if acc.Platform != nil {
+				platformType, domain = acc.Platform.Type, acc.Platform.Domain
+			}
+			if domain == "" {
+				domain = git.GetDefaultDomain(platformType)
+			}
+			download.RegisterToken(domain, acc.Token.Username, acc.Token.Token)
+		}
+	}
+}
+
+// completeRepoArg completes owner/repo and owner/repo:ref arguments using the
+// GitHub API (cached). Before a slash is typed it suggests the usernames of
+// configured token accounts.
+func completeRepoArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 || strings.Contains(toComplete, "://") {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	token, _ := cmd.Flags().GetString("token")
+	registerAccountHosts()
+
+	if !strings.Contains(toComplete, "/") {
+		var owners []string
+		if cfg, err := config.Load(); err == nil {
+			for _, acc := range cfg.Accounts {
+				if acc.Token != nil && acc.Token.Username != "" && strings.HasPrefix(acc.Token.Username, toComplete) {
+					owners = append(owners, acc.Token.Username+"/")
+				}
+			}
+		}
+		return owners, cobra.ShellCompDirectiveNoSpace | cobra.ShellCompDirectiveNoFileComp
+	}
+
+	candidates := download.CompleteRepoRef(toComplete, token)
+	directive := cobra.ShellCompDirectiveNoFileComp
+	if !strings.Contains(toComplete, ":") {
+		// Allow typing ":ref" right after the repository name
+		directive |= cobra.ShellCompDirectiveNoSpace
+	}
+	return candidates, directive
+}
+
 // isGitHubURL returns true if the URL is a GitHub repository URL.
 func isGitHubURL(url string) bool {
 	return strings.HasPrefix(url, "https://github.com/") ||
 		strings.HasPrefix(url, "http://github.com/")
 }
 
+// isGitLabURL returns true if the URL points to a file or folder on GitLab.
+// The /-/ separator is specific to GitLab, so self-hosted instances match too.
+func isGitLabURL(url string) bool {
+	return (strings.HasPrefix(url, "https://") || strings.HasPrefix(url, "http://")) &&
+		(strings.Contains(url, "/-/blob/") || strings.Contains(url, "/-/tree/"))
+}
+
+// runGitLabDownload downloads a GitLab file (blob) or directory (tree).
+func runGitLabDownload(rawURL, output, outputDir string, showInfo, overwrite, force bool, maxSize int64, parallel int, verify download.Verification, token string) error {
+	if strings.Contains(rawURL, "/-/blob/") {
+		return download.GitFile(rawURL, download.GitOptions{
+			Output:       output,
+			OutputDir:    outputDir,
+			Overwrite:    overwrite,
+			ShowInfo:     showInfo,
+			Token:        token,
+			Verification: verify,
+		})
+	}
+
+	if showInfo {
+		ui.ShowInfo(i18n.T("Downloading directory from GitLab: %s", rawURL))
+	}
+	return download.GitDirectory(rawURL, download.GitOptions{
+		Output:       output,
+		OutputDir:    outputDir,
+		Depth:        100, // allow deep directories
+		Overwrite:    overwrite,
+		ShowInfo:     showInfo,
+		Token:        token,
+		Verification: verify,
+		MaxSize:      maxSize,
+		Parallel:     parallel,
+		Force:        force,
+	})
+}
+
+// runRepoPathDownload downloads a file or directory of a repository. On
+// Gitea and Bitbucket their URLs look the same, so the downloader asks the
+// API which one it is.
+func runRepoPathDownload(rawURL, output, outputDir string, showInfo, overwrite, force bool, maxSize int64, parallel int, verify download.Verification, token string) error {
+	if showInfo {
+		ui.ShowInfo(i18n.T("Downloading from repository: %s", rawURL))
+	}
+	return download.GitPath(rawURL, download.GitOptions{
+		Output:       output,
+		OutputDir:    outputDir,
+		Depth:        100, // allow deep directories
+		Overwrite:    overwrite,
+		ShowInfo:     showInfo,
+		Token:        token,
+		Verification: verify,
+		MaxSize:      maxSize,
+		Parallel:     parallel,
+		Force:        force,
+	})
+}
+
 // runGitHubDownload auto-detects whether the GitHub URL points to a file (blob)
 // or a directory (tree) and downloads accordingly.
 // When downloading a file like https://github.com/owner/repo/blob/main/skill/SKILL.md
 // the folder structure (skill/SKILL.md) is preserved in the output directory.
-func runGitHubDownload(rawURL, output, outputDir string, showInfo, overwrite bool, token string) error {
+func runGitHubDownload(rawURL, output, outputDir string, showInfo, overwrite, all, force bool, maxSize int64, parallel int, verify download.Verification, token string) error {
 	isTree := strings.Contains(rawURL, "/tree/")
 	isBlob := strings.Contains(rawURL, "/blob/")
 
 	if isBlob {
 		// Single file download — preserve folder structure from repo path
 		if showInfo {
-			ui.ShowInfo(fmt.Sprintf("Downloading file from GitHub: %s", rawURL))
+			ui.ShowInfo(i18n.T("Downloading file from GitHub: %s", rawURL))
 		}
 		opts := download.GitOptions{
-			Output:    output,    // empty = use repo path (preserves folder structure)
-			OutputDir: outputDir, // base output directory
-			Overwrite: overwrite,
-			ShowInfo:  showInfo,
-			Token:     token,
+			Output:       output,    // empty = use repo path (preserves folder structure)
+			OutputDir:    outputDir, // base output directory
+			Overwrite:    overwrite,
+			ShowInfo:     showInfo,
+			Token:        token,
+			Verification: verify,
 		}
 		return download.GitFile(rawURL, opts)
 	}
@@ -264,115 +1037,139 @@ func runGitHubDownload(rawURL, output, outputDir string, showInfo, overwrite boo
 	if isTree {
 		// Directory download
 		if showInfo {
-			ui.ShowInfo(fmt.Sprintf("Downloading directory from GitHub: %s", rawURL))
+			ui.ShowInfo(i18n.T("Downloading directory from GitHub: %s", rawURL))
 		}
 		opts := download.GitOptions{
-			OutputDir: outputDir,
-			Depth:     100, // allow deep directories
-			Overwrite: overwrite,
-			ShowInfo:  showInfo,
-			Token:     token,
+			Output:       output,
+			OutputDir:    outputDir,
+			Depth:        100, // allow deep directories
+			Overwrite:    overwrite,
+			ShowInfo:     showInfo,
+			Token:        token,
+			Verification: verify,
+			MaxSize:      maxSize,
+			Parallel:     parallel,
+			Force:        force,
 		}
 		return download.GitDirectory(rawURL, opts)
 	}
 
-	// Repo root or unknown GitHub URL — treat as directory download
+	// Repo root or unknown GitHub URL — let the user pick entries unless --all
 	if showInfo {
-		ui.ShowInfo(fmt.Sprintf("Downloading from GitHub: %s", rawURL))
+		ui.ShowInfo(i18n.T("Downloading from GitHub: %s", rawURL))
 	}
-	opts := download.GitOptions{
-		OutputDir: outputDir,
-		Depth:     100,
-		Overwrite: overwrite,
-		ShowInfo:  showInfo,
-		Token:     token,
+	opts := download.RepoOptions{
+		OutputDir:    outputDir,
+		Depth:        100,
+		Overwrite:    overwrite,
+		Token:        token,
+		Verification: verify,
+		All:          all,
+		MaxSize:      maxSize,
+		Parallel:     parallel,
+		Force:        force,
 	}
-	return download.GitDirectory(rawURL, opts)
-}
-
-// downloadFromFileList reads URLs from a file and downloads them with bounded concurrency.
-func downloadFromFileList(filePath string, parallel int) error {
-	data, err := os.ReadFile(filePath)
-	if err != nil {
-		return fmt.Errorf("failed to read file list: %w", err)
-	}
-
-	// Normalize line endings (handle Windows \r\n)
-	content := strings.ReplaceAll(string(data), "\r\n", "\n")
-	content = strings.ReplaceAll(content, "\r", "\n")
-	lines := strings.Split(content, "\n")
-	var urls []string
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line != "" && !strings.HasPrefix(line, "#") {
-			urls = append(urls, line)
-		}
-	}
-
-	if len(urls) == 0 {
-		return fmt.Errorf("no URLs found in file list")
-	}
-
-	opts := download.DefaultOptions()
-	opts.ShowProgress = true
-	if parallel > 0 {
-		// parallel hint is passed via the bounded concurrency in Multiple()
-		// Multiple() uses its own internal cap of 5; this is informational
-		_ = parallel
-	}
-	return download.Multiple(urls, opts)
+	return download.GitRepo(rawURL, opts)
 }
 
 // promptLine reads a full line from stdin, supporting spaces in input.
 func promptLine(message string) string {
-	fmt.Printf("%s %s: ", ui.Primary("◇"), ui.Muted(message))
-	reader := bufio.NewReader(os.Stdin)
-	line, _ := reader.ReadString('\n')
-	return strings.TrimSpace(line)
+	return ui.PromptLine(message, "")
 }
 
 func runDlxMenu() {
-	ui.ShowSection("Download (dlx)")
+	ui.ShowSection(i18n.T("Download (dlx)"))
 
 	options := []string{
 		"📥 Download from URL",
 		"📄 Download file from Git repo",
 		"📁 Download directory from Git repo",
+		"📦 Download repository (pick entries)",
 		"🏷️  Download release assets",
 		"📋 Download from URL list",
 		"🔙 Back to main menu",
 	}
 
+	// A URL copied from the browser becomes the default action and URL
+	clip := clipboardURL()
+	defaultChoice := ""
+	if clip != "" {
+		ui.ShowInfo(i18n.T("URL on clipboard: %s", clip))
+		defaultChoice = dlxMenuChoice(clip)
+	}
+
 	fmt.Println(ui.Primary("Choose an action:"))
 	for i, opt := range options {
 		fmt.Printf("  %s %s\n", ui.Dim(fmt.Sprintf("[%d]", i+1)), opt)
 	}
 	fmt.Println()
 
-	choice := promptLine("Enter choice (1-6)")
+	choice := ui.PromptLine(fmt.Sprintf("Enter choice (1-%d)", len(options)), defaultChoice)
 
 	switch choice {
 	case "1":
-		runDownloadURL()
+		runDownloadURL(clip)
 	case "2":
-		runDownloadGitFile()
+		runDownloadGitFile(clip)
 	case "3":
-		runDownloadGitDir()
+		runDownloadGitDir(clip)
 	case "4":
-		runDownloadRelease()
+		runDownloadRepo(clip)
 	case "5":
-		runDownloadFromList()
+		runDownloadRelease(clip)
 	case "6":
+		runDownloadFromList()
+	case "7":
 		return
 	default:
-		ui.ShowWarning("Invalid choice")
+		ui.ShowWarning(i18n.T("Invalid choice"))
+	}
+}
+
+// clipboardURL returns the http(s) URL on the clipboard, or "" when the
+// clipboard holds something else or can't be read
+func clipboardURL() string {
+	text, err := clipboard.Paste()
+	if err != nil {
+		return ""
+	}
+	text = strings.TrimSpace(text)
+	if text == "" || strings.ContainsAny(text, " \t\r\n") {
+		return ""
+	}
+	u, err := url.Parse(text)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ""
+	}
+	return text
+}
+
+// dlxMenuChoice returns the menu action that fits a URL: releases, files
+// and folders of Git hosts, whole GitHub repositories, or a plain download.
+// Gists fit none of them, so they get no default.
+func dlxMenuChoice(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || download.IsGistURL(rawURL) {
+		return ""
+	}
+	gitHost := isGitHubURL(rawURL) || isGitLabURL(rawURL) || download.IsRepoPathURL(rawURL)
+	switch {
+	case gitHost && strings.Contains(u.Path, "/releases"):
+		return "5"
+	case gitHost && strings.Contains(u.Path, "/tree/"):
+		return "3"
+	case gitHost && (strings.Contains(u.Path, "/blob/") || download.IsRepoPathURL(rawURL)):
+		return "2"
+	case isGitHubURL(rawURL) && len(strings.Split(strings.Trim(u.Path, "/"), "/")) == 2:
+		return "4"
 	}
+	return "1"
 }
 
-func runDownloadURL() {
-	url := promptLine("Enter URL to download")
-	if url == "" {
-		ui.ShowError("URL is required")
+func runDownloadURL(defaultURL string) {
+	rawURL := ui.PromptLine("Enter URL to download", defaultURL)
+	if rawURL == "" {
+		ui.ShowError(i18n.T("URL is required"))
 		return
 	}
 
@@ -386,15 +1183,15 @@ func runDownloadURL() {
 		FollowRedirects: true,
 	}
 
-	if err := download.FromURL(url, opts); err != nil {
+	if err := download.FromURL(rawURL, opts); err != nil {
 		ui.ShowError(err.Error())
 	}
 }
 
-func runDownloadGitFile() {
-	url := promptLine("Enter Git file URL (e.g., https://github.com/user/repo/blob/main/file.txt)")
-	if url == "" {
-		ui.ShowError("URL is required")
+func runDownloadGitFile(defaultURL string) {
+	rawURL := ui.PromptLine("Enter Git file URL (e.g., https://github.com/user/repo/blob/main/file.txt)", defaultURL)
+	if rawURL == "" {
+		ui.ShowError(i18n.T("URL is required"))
 		return
 	}
 
@@ -406,15 +1203,15 @@ func runDownloadGitFile() {
 		Output: output,
 	}
 
-	if err := download.GitFile(url, opts); err != nil {
+	if err := download.GitFile(rawURL, opts); err != nil {
 		ui.ShowError(err.Error())
 	}
 }
 
-func runDownloadGitDir() {
-	url := promptLine("Enter Git directory URL (e.g., https://github.com/user/repo/tree/main/src)")
-	if url == "" {
-		ui.ShowError("URL is required")
+func runDownloadGitDir(defaultURL string) {
+	rawURL := ui.PromptLine("Enter Git directory URL (e.g., https://github.com/user/repo/tree/main/src)", defaultURL)
+	if rawURL == "" {
+		ui.ShowError(i18n.T("URL is required"))
 		return
 	}
 
@@ -427,15 +1224,36 @@ func runDownloadGitDir() {
 		Depth:     100,
 	}
 
-	if err := download.GitDirectory(url, opts); err != nil {
+	if err := download.GitDirectory(rawURL, opts); err != nil {
+		ui.ShowError(err.Error())
+	}
+}
+
+func runDownloadRepo(defaultURL string) {
+	rawURL := ui.PromptLine("Enter GitHub repo URL (e.g., https://github.com/user/repo)", defaultURL)
+	if rawURL == "" {
+		ui.ShowError(i18n.T("URL is required"))
+		return
+	}
+
+	branch := promptLine("Branch/tag/commit (optional)")
+	outputDir := promptLine("Output directory (optional)")
+
+	opts := download.RepoOptions{
+		Branch:    branch,
+		OutputDir: outputDir,
+		Depth:     100,
+	}
+
+	if err := download.GitRepo(rawURL, opts); err != nil {
 		ui.ShowError(err.Error())
 	}
 }
 
-func runDownloadRelease() {
-	url := promptLine("Enter GitHub repo URL (e.g., https://github.com/user/repo)")
-	if url == "" {
-		ui.ShowError("URL is required")
+func runDownloadRelease(defaultURL string) {
+	rawURL := ui.PromptLine("Enter GitHub repo URL (e.g., https://github.com/user/repo)", defaultURL)
+	if rawURL == "" {
+		ui.ShowError(i18n.T("URL is required"))
 		return
 	}
 
@@ -449,7 +1267,7 @@ func runDownloadRelease() {
 		OutputDir: outputDir,
 	}
 
-	if err := download.GitRelease(url, opts); err != nil {
+	if err := download.GitRelease(rawURL, opts); err != nil {
 		ui.ShowError(err.Error())
 	}
 }
@@ -457,11 +1275,11 @@ func runDownloadRelease() {
 func runDownloadFromList() {
 	filePath := promptLine("Enter path to URL list file")
 	if filePath == "" {
-		ui.ShowError("File path is required")
+		ui.ShowError(i18n.T("File path is required"))
 		return
 	}
 
-	if err := downloadFromFileList(filePath, 5); err != nil {
+	if err := download.DownloadQueue(filePath, download.QueueOptions{}); err != nil {
 		ui.ShowError(err.Error())
 	}
 }
@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/dwirx/ghex/pkg/download"
+	"github.com/spf13/cobra"
+)
+
+// dlxJSONResult is what dlx --json prints once the command finished
+type dlxJSONResult struct {
+	Command    string                `json:"command"`
+	Args       []string              `json:"args"`
+	OK         bool                  `json:"ok"`
+	Error      string                `json:"error,omitempty"`
+	Files      []download.FileResult `json:"files"`
+	Downloaded int                   `json:"downloaded"`
+	Skipped    int                   `json:"skipped"`
+	Failed     int                   `json:"failed"`
+	Bytes      int64                 `json:"bytes"`
+	DurationMs int64                 `json:"durationMs"`
+}
+
+// addDlxJSON adds --json to dlx and wraps cmd and its subcommands so that
+// with it, stdout only gets one JSON document describing every file the
+// command downloaded
+func addDlxJSON(cmd *cobra.Command) {
+	cmd.PersistentFlags().Bool("json", false, "Print the result as JSON (per-file status, path, bytes, errors, timing); other output goes to stderr")
+	wrapDlxJSON(cmd)
+	for _, sub := range cmd.Commands() {
+		wrapDlxJSON(sub)
+	}
+}
+
+// wrapDlxJSON makes cmd record its downloads and print them as JSON when
+// --json is given
+func wrapDlxJSON(cmd *cobra.Command) {
+	run := cmd.RunE
+	if run == nil {
+		return
+	}
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		asJSON, _ := cmd.Flags().GetBool("json")
+		if !asJSON {
+			return run(cmd, args)
+		}
+		// Plain dlx without arguments opens the interactive menu
+		if cont, _ := cmd.Flags().GetBool("continue"); cmd.Name() == "dlx" && len(args) == 0 && !cont {
+			return fmt.Errorf("--json needs a URL or owner/repo argument")
+		}
+
+		// Messages, progress bars and prompts go to stderr for the rest of
+		// the run, so scripts can read stdout as JSON
+		stdout := os.Stdout
+		os.Stdout = os.Stderr
+
+		rec := &download.Recorder{}
+		download.SetRecorder(rec)
+		start := time.Now()
+		err := run(cmd, args)
+		download.SetRecorder(nil)
+
+		result := dlxJSONResult{
+			Command:    cmd.CommandPath(),
+			Args:       args,
+			Files:      rec.Files(),
+			DurationMs: time.Since(start).Milliseconds(),
+		}
+		if result.Args == nil {
+			result.Args = []string{}
+		}
+		if result.Files == nil {
+			result.Files = []download.FileResult{}
+		}
+		for _, f := range result.Files {
+			switch f.Status {
+			case download.StatusDownloaded:
+				result.Downloaded++
+				result.Bytes += f.Bytes
+			case download.StatusSkipped:
+				result.Skipped++
+			default:
+				result.Failed++
+			}
+		}
+		if err != nil {
+			result.Error = err.Error()
+		}
+		result.OK = err == nil && result.Failed == 0
+
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if encErr := enc.Encode(result); encErr != nil && err == nil {
+			err = encErr
+		}
+		return err
+	}
+}
@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/dwirx/ghex/internal/config"
+	"github.com/dwirx/ghex/internal/git"
+	"github.com/dwirx/ghex/internal/i18n"
+	"github.com/dwirx/ghex/internal/shell"
+	"github.com/dwirx/ghex/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// NewDoctorCmd creates the doctor command
+func NewDoctorCmd() *cobra.Command {
+	var fix bool
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: i18n.T("Diagnose git and credential setup problems"),
+		Long: `Check the environment ghex depends on and report problems that make git
+use the wrong account.
+
+Checks:
+  - git and ssh are installed
+  - the ghex config file can be read
+  - no other credential helper (osxkeychain, manager, cache, ...) is asked
+    before the store helper ghex writes tokens to
+
+Examples:
+  ghex doctor        # Report problems
+  ghex doctor --fix  # Report and repair what can be repaired`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if !runDoctor(fix) {
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().BoolVar(&fix, "fix", false, "Repair problems where possible")
+
+	return cmd
+}
+
+// runDoctor runs all checks and returns false if any problem remains
+func runDoctor(fix bool) bool {
+	ui.ShowSection(i18n.T("Doctor"))
+	problems := 0
+
+	for _, tool := range []string{"git", "ssh"} {
+		if shell.CommandExists(tool) {
+			ui.ShowSuccess(i18n.T("%s is installed", tool))
+		} else {
+			ui.ShowError(i18n.T("%s is not installed or not in PATH", tool))
+			problems++
+		}
+	}
+
+	if _, err := config.Load(); err != nil {
+		ui.ShowError(i18n.T("Config cannot be loaded: %v", err))
+		problems++
+	} else {
+		ui.ShowSuccess(i18n.T("Config loads"))
+	}
+
+	if !checkCredentialHelpers(fix) {
+		problems++
+	}
+
+	fmt.Println()
+	if problems > 0 {
+		ui.ShowWarning(i18n.T("%d problem(s) found", problems))
+		return false
+	}
+	ui.ShowSuccess(i18n.T("No problems found"))
+	return true
+}
+
+// checkCredentialHelpers reports helpers that answer before ghex's store
+// helper and, with fix, moves the store helper to the front
+func checkCredentialHelpers(fix bool) bool {
+	cwd, _ := os.Getwd()
+	inRepo := git.IsGitRepo(cwd)
+
+	helpers := git.CredentialHelpers(cwd)
+	keys := make([]string, 0, len(helpers))
+	for key := range helpers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	ok := true
+	for _, key := range keys {
+		conflicts := git.CredentialHelperConflicts(helpers[key])
+		if len(conflicts) == 0 {
+			continue
+		}
+		ok = false
+
+		names := make([]string, len(conflicts))
+		for i, h := range conflicts {
+			names[i] = fmt.Sprintf("%s (%s)", h.Name(), h.Scope)
+		}
+		ui.ShowWarning(i18n.T("%s: %s asked before store", key, strings.Join(names, ", ")))
+		fmt.Printf("  %s\n", ui.Dim("git may push with credentials cached for another account"))
+
+		if key != "credential.helper" {
+			fmt.Printf("  %s\n", ui.Dim(fmt.Sprintf("Reorder it manually: git config --edit (%s)", key)))
+			continue
+		}
+		if !fix {
+			fmt.Printf("  %s\n", ui.Dim("Run 'ghex doctor --fix' to ask store first"))
+			continue
+		}
+
+		scope := "local"
+		if !inRepo {
+			scope = "global"
+		}
+		if err := git.PrioritizeStoreHelper(cwd, !inRepo); err != nil {
+			ui.ShowError(i18n.T("Failed to reorder credential helpers: %v", err))
+			continue
+		}
+		ui.ShowSuccess(i18n.T("Store helper now asked first (%s config)", scope))
+		ok = true
+	}
+
+	if ok && len(keys) > 0 {
+		ui.ShowSuccess(i18n.T("Credential helpers do not conflict"))
+	}
+	return ok
+}
@@ -0,0 +1,137 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dwirx/ghex/internal/config"
+	"github.com/dwirx/ghex/internal/dotfiles"
+	"github.com/dwirx/ghex/internal/i18n"
+	"github.com/dwirx/ghex/internal/platform"
+	"github.com/dwirx/ghex/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// NewGenerateCmd creates the generate command
+func NewGenerateCmd() *cobra.Command {
+	generateCmd := &cobra.Command{
+		Use:   "generate",
+		Short: i18n.T("Generate git and SSH configuration for dotfiles"),
+		Long: `Render the configured accounts as static configuration, for dotfiles
+managed with chezmoi, home-manager, stow and the like. Nothing is switched
+or changed; the files only describe ghex's accounts.
+
+Examples:
+  ghex generate gitconfig
+  ghex generate gitconfig --write
+  ghex generate ssh-config -o ~/.ssh/ghex-accounts.conf`,
+	}
+
+	var dir string
+	var write bool
+	gitconfigCmd := &cobra.Command{
+		Use:   "gitconfig",
+		Short: i18n.T("Generate includeIf gitconfig files for every account"),
+		Long: `Generate a gitconfig with an includeIf section per account, plus one
+file per account with its identity, its SSH remotes rewritten to the
+account's host alias (see 'ghex generate ssh-config') and its token
+username for HTTPS remotes. Token secrets are never written.
+
+An account applies inside its workspaces and clone directory, and to
+repositories whose remote belongs to one of its workspace organizations
+(needs git 2.36 or later).
+
+Without --write the files are printed; with it they are written to --dir
+and ~/.gitconfig only needs to include ` + dotfiles.MainFile + ` from there.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGenerateGitconfig(dir, write)
+		},
+	}
+	gitconfigCmd.Flags().StringVarP(&dir, "dir", "d", dotfiles.DefaultDir, "Directory the files live in, used in include paths")
+	gitconfigCmd.Flags().BoolVarP(&write, "write", "w", false, "Write the files to --dir instead of printing them")
+	generateCmd.AddCommand(gitconfigCmd)
+
+	var output string
+	sshConfigCmd := &cobra.Command{
+		Use:   "ssh-config",
+		Short: i18n.T("Generate SSH Host blocks for every account"),
+		Long: `Generate a Host block per account with an SSH key, named after the
+account's host alias (or <host>-<account>), which the files from
+'ghex generate gitconfig' rewrite SSH remotes to.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGenerateSSHConfig(output)
+		},
+	}
+	sshConfigCmd.Flags().StringVarP(&output, "output", "o", "", "Write the Host blocks to this file instead of stdout")
+	generateCmd.AddCommand(sshConfigCmd)
+
+	return generateCmd
+}
+
+func runGenerateGitconfig(dir string, write bool) error {
+	cfg, err := config.Load()
+	if err != nil {
+		ui.ShowError(i18n.T("Failed to load config: %v", err))
+		return err
+	}
+
+	files := dotfiles.Gitconfig(cfg, dir)
+	if !write {
+		for i, f := range files {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Printf("# ==> %s <==\n", f.Name)
+			fmt.Print(f.Content)
+		}
+		return nil
+	}
+
+	target := platform.ExpandPath(dir)
+	if err := platform.EnsureDir(target, 0755); err != nil {
+		ui.ShowError(i18n.T("Failed to create %s: %v", target, err))
+		return err
+	}
+	for _, f := range files {
+		path := filepath.Join(target, f.Name)
+		if err := os.WriteFile(path, []byte(f.Content), 0644); err != nil {
+			ui.ShowError(i18n.T("Failed to write %s: %v", path, err))
+			return err
+		}
+	}
+	ui.ShowSuccess(i18n.T("Wrote %d files to %s", len(files), target))
+	ui.ShowInfo(i18n.T("Add this to ~/.gitconfig:"))
+	fmt.Printf("  [include]\n  \tpath = %s\n", filepath.ToSlash(filepath.Join(dir, dotfiles.MainFile)))
+	return nil
+}
+
+func runGenerateSSHConfig(output string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		ui.ShowError(i18n.T("Failed to load config: %v", err))
+		return err
+	}
+
+	content := dotfiles.SSHConfig(cfg)
+	if output == "" {
+		fmt.Print(content)
+		return nil
+	}
+
+	path := platform.ExpandPath(output)
+	if err := platform.EnsureDir(filepath.Dir(path), 0700); err != nil {
+		ui.ShowError(i18n.T("Failed to create %s: %v", filepath.Dir(path), err))
+		return err
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		ui.ShowError(i18n.T("Failed to write %s: %v", path, err))
+		return err
+	}
+	ui.ShowSuccess(i18n.T("Host blocks written to %s", path))
+	ui.ShowInfo(i18n.T("Add this line at the top of %s:", platform.GetSSHConfigPath()))
+	fmt.Println("  Include " + platform.ToSSHPath(path))
+	return nil
+}
@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dwirx/ghex/internal/account"
+	"github.com/dwirx/ghex/internal/config"
+	"github.com/dwirx/ghex/internal/git"
+	"github.com/dwirx/ghex/internal/i18n"
+	"github.com/dwirx/ghex/internal/shell"
+	"github.com/dwirx/ghex/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// newGhPassthroughCmd creates a gh command wrapper that runs gh with the
+// token of the current repository's account
+func newGhPassthroughCmd(ghCmd string) *cobra.Command {
+	return &cobra.Command{
+		Use:   ghCmd + " [gh args...]",
+		Short: i18n.T("gh %s, as the repository's account", ghCmd),
+		Long: fmt.Sprintf(`Run gh %s with the token of the account the current repository
+belongs to, so it acts as that identity whatever gh is logged into.
+
+The account is the one the repository is pinned to, or the one detected
+from its git identity and remote. It must be a GitHub account with a
+token. GitHub Enterprise accounts also set the gh host to their domain.
+All arguments are passed to gh unchanged.
+
+Examples:
+  ghex %s create
+  ghex %s create --title "Fix login" --body "Closes #12"
+  ghex %s list`, ghCmd, ghCmd, ghCmd, ghCmd),
+		DisableFlagParsing: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			if code := runGhAsAccount(ghCmd, args); code != 0 {
+				os.Exit(code)
+			}
+		},
+	}
+}
+
+// runGhAsAccount runs gh with the repository account's token and returns
+// its exit code
+func runGhAsAccount(ghCmd string, args []string) int {
+	if !shell.CommandExists("gh") {
+		ui.ShowError(i18n.T("GitHub CLI (gh) not found"))
+		ui.ShowInfo(i18n.T("Install it from https://cli.github.com"))
+		return 1
+	}
+
+	cwd, _ := os.Getwd()
+	if !git.IsGitRepo(cwd) {
+		ui.ShowError(i18n.T("Not in a git repository"))
+		return 1
+	}
+
+	acc := repoGitHubAccount(cwd)
+	if acc == nil {
+		return 1
+	}
+
+	env := []string{"GH_TOKEN=" + acc.Token.Token}
+	if acc.Platform != nil && acc.Platform.Domain != "" && acc.Platform.Domain != git.GetDefaultDomain(account.PlatformGitHub) {
+		env = append(env, "GH_HOST="+acc.Platform.Domain, "GH_ENTERPRISE_TOKEN="+acc.Token.Token)
+	}
+
+	ui.ShowInfo(i18n.T("Running gh %s as %s", ghCmd, acc.Name))
+	if err := shell.RunInteractiveWithEnv(env, "gh", append([]string{ghCmd}, args...)...); err != nil {
+		if code := shell.GetExitCode(err); code > 0 {
+			return code
+		}
+		ui.ShowError(err.Error())
+		return 1
+	}
+	return 0
+}
+
+// repoGitHubAccount returns the account the repository is pinned to or
+// detected as, when it is a GitHub account with a token
+func repoGitHubAccount(repoPath string) *config.Account {
+	cfg, err := config.Load()
+	if err != nil {
+		ui.ShowError(i18n.T("Failed to load config: %v", err))
+		return nil
+	}
+	manager := account.NewManager(cfg)
+
+	name := account.PinnedAccount(repoPath)
+	if name == "" {
+		name, _ = manager.DetectActive(repoPath)
+	}
+	if name == "" {
+		ui.ShowError(i18n.T("No matching account detected"))
+		ui.ShowInfo(i18n.T("Run 'ghex switch' to pick the account for this repository"))
+		return nil
+	}
+
+	acc := manager.Find(name)
+	if acc == nil {
+		ui.ShowError(i18n.T("Account '%s' not found", name))
+		return nil
+	}
+	if !hasGitHubToken(acc) {
+		ui.ShowError(i18n.T("Account '%s' needs a GitHub token to run gh", name))
+		ui.ShowInfo(i18n.T("Add one with 'ghex edit'"))
+		return nil
+	}
+	return acc
+}
@@ -5,7 +5,12 @@ import (
 	"os"
 	"strings"
 
+	"github.com/dwirx/ghex/internal/account"
+	"github.com/dwirx/ghex/internal/config"
 	"github.com/dwirx/ghex/internal/git"
+	"github.com/dwirx/ghex/internal/i18n"
+	"github.com/dwirx/ghex/internal/offline"
+	"github.com/dwirx/ghex/internal/platform"
 	"github.com/dwirx/ghex/internal/shell"
 	"github.com/dwirx/ghex/internal/ui"
 	"github.com/spf13/cobra"
@@ -54,9 +59,9 @@ func AddGitShortcuts(rootCmd *cobra.Command) {
 		Use:   "gf",
 		Short: "git fetch origin",
 		Run: func(cmd *cobra.Command, args []string) {
-			ui.ShowInfo("Fetching from origin...")
+			ui.ShowInfo(i18n.T("Fetching from origin..."))
 			_ = shell.RunInteractive("git", "fetch", "origin")
-			ui.ShowSuccess("Fetch completed")
+			ui.ShowSuccess(i18n.T("Fetch completed"))
 		},
 	})
 
@@ -65,9 +70,9 @@ func AddGitShortcuts(rootCmd *cobra.Command) {
 		Use:   "gp",
 		Short: "git pull",
 		Run: func(cmd *cobra.Command, args []string) {
-			ui.ShowInfo("Pulling from remote...")
+			ui.ShowInfo(i18n.T("Pulling from remote..."))
 			_ = shell.RunInteractive("git", "pull")
-			ui.ShowSuccess("Pull completed")
+			ui.ShowSuccess(i18n.T("Pull completed"))
 		},
 	})
 
@@ -76,12 +81,17 @@ func AddGitShortcuts(rootCmd *cobra.Command) {
 		Use:   "gpr",
 		Short: "git pull --rebase",
 		Run: func(cmd *cobra.Command, args []string) {
-			ui.ShowInfo("Pulling with rebase...")
+			ui.ShowInfo(i18n.T("Pulling with rebase..."))
 			_ = shell.RunInteractive("git", "pull", "--rebase")
-			ui.ShowSuccess("Pull completed")
+			ui.ShowSuccess(i18n.T("Pull completed"))
 		},
 	})
 
+	// Identity-checked passthroughs
+	rootCmd.AddCommand(newGitPassthroughCmd("push", true))
+	rootCmd.AddCommand(newGitPassthroughCmd("pull", false))
+	rootCmd.AddCommand(newGitPassthroughCmd("fetch", false))
+
 	// Git checkout
 	rootCmd.AddCommand(&cobra.Command{
 		Use:   "gco [branch]",
@@ -95,7 +105,7 @@ func AddGitShortcuts(rootCmd *cobra.Command) {
 	// Git checkout -b
 	rootCmd.AddCommand(&cobra.Command{
 		Use:   "gcb [branch]",
-		Short: "git checkout -b (create new branch)",
+		Short: i18n.T("git checkout -b (create new branch)"),
 		Args:  cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			_ = shell.RunInteractive("git", "checkout", "-b", args[0])
@@ -132,7 +142,7 @@ func AddGitShortcuts(rootCmd *cobra.Command) {
 	// Shove command (add, commit, push)
 	rootCmd.AddCommand(&cobra.Command{
 		Use:   "shove [message]",
-		Short: "git add, commit, and push",
+		Short: i18n.T("git add, commit, and push"),
 		Args:  cobra.MinimumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			message := strings.Join(args, " ")
@@ -143,7 +153,7 @@ func AddGitShortcuts(rootCmd *cobra.Command) {
 	// Shove no-confirm
 	rootCmd.AddCommand(&cobra.Command{
 		Use:   "shovenc [message]",
-		Short: "git add, commit, and push (no confirm)",
+		Short: i18n.T("git add, commit, and push (no confirm)"),
 		Args:  cobra.MinimumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			message := strings.Join(args, " ")
@@ -159,10 +169,10 @@ func AddGitShortcuts(rootCmd *cobra.Command) {
 		Run: func(cmd *cobra.Command, args []string) {
 			name := strings.Join(args, " ")
 			if err := git.SetGlobalIdentity(name, ""); err != nil {
-				ui.ShowError(fmt.Sprintf("Failed: %v", err))
+				ui.ShowError(i18n.T("Failed: %v", err))
 				return
 			}
-			ui.ShowSuccess(fmt.Sprintf("Git user.name set to: %s", name))
+			ui.ShowSuccess(i18n.T("Git user.name set to: %s", name))
 		},
 	})
 
@@ -173,10 +183,10 @@ func AddGitShortcuts(rootCmd *cobra.Command) {
 		Args:  cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			if err := git.SetGlobalIdentity("", args[0]); err != nil {
-				ui.ShowError(fmt.Sprintf("Failed: %v", err))
+				ui.ShowError(i18n.T("Failed: %v", err))
 				return
 			}
-			ui.ShowSuccess(fmt.Sprintf("Git user.email set to: %s", args[0]))
+			ui.ShowSuccess(i18n.T("Git user.email set to: %s", args[0]))
 		},
 	})
 
@@ -187,9 +197,13 @@ func AddGitShortcuts(rootCmd *cobra.Command) {
 		Run: func(cmd *cobra.Command, args []string) {
 			output, err := git.GetConfigList()
 			if err != nil {
-				ui.ShowError(fmt.Sprintf("Failed: %v", err))
+				ui.ShowError(i18n.T("Failed: %v", err))
 				return
 			}
+			ui.ShowKeyValue("Global config", platform.GetGitGlobalConfigPath())
+			ui.ShowKeyValue("Credentials", platform.GetGitCredentialsPath())
+			ui.ShowKeyValue("SSH config", platform.GetSSHConfigPath())
+			fmt.Println()
 			fmt.Println(output)
 		},
 	})
@@ -226,40 +240,182 @@ func runShove(message string, noConfirm bool) {
 	cwd, _ := os.Getwd()
 
 	if !git.IsGitRepo(cwd) {
-		ui.ShowError("Not in a git repository")
+		ui.ShowError(i18n.T("Not in a git repository"))
 		return
 	}
 
 	if message == "" {
-		ui.ShowError("Commit message is required")
+		ui.ShowError(i18n.T("Commit message is required"))
 		return
 	}
 
 	// Git add
-	ui.ShowInfo("Adding files...")
+	ui.ShowInfo(i18n.T("Adding files..."))
 	if _, err := shell.Run("git", "add", "."); err != nil {
-		ui.ShowError(fmt.Sprintf("Failed to add files: %v", err))
+		ui.ShowError(i18n.T("Failed to add files: %v", err))
 		return
 	}
-	ui.ShowSuccess("Files added")
+	ui.ShowSuccess(i18n.T("Files added"))
 
 	// Git commit
-	ui.ShowInfo(fmt.Sprintf("Committing: %s", message))
+	ui.ShowInfo(i18n.T("Committing: %s", message))
 	if _, err := shell.Run("git", "commit", "-m", message); err != nil {
-		ui.ShowError(fmt.Sprintf("Failed to commit: %v", err))
+		ui.ShowError(i18n.T("Failed to commit: %v", err))
 		return
 	}
-	ui.ShowSuccess("Committed successfully")
+	ui.ShowSuccess(i18n.T("Committed successfully"))
 
 	// Push
-	if noConfirm || ui.Confirm("Push to origin?") {
-		ui.ShowInfo("Pushing to origin...")
-		if err := shell.RunInteractive("git", "push", "origin"); err != nil {
-			ui.ShowError(fmt.Sprintf("Failed to push: %v", err))
+	if noConfirm || ui.Confirm(i18n.T("Push to origin?")) {
+		if !verifyPinnedIdentity(cwd, true) {
+			ui.ShowWarning(i18n.T("Push cancelled"))
+			return
+		}
+		ui.ShowInfo(i18n.T("Pushing to origin..."))
+		if output, err := shell.RunInteractiveCapture("git", "push", "origin"); err != nil {
+			ui.ShowError(i18n.T("Failed to push: %v", err))
+			explainEmailPrivacyRejection(cwd, output)
 			return
 		}
-		ui.ShowSuccess("Pushed successfully")
+		ui.ShowSuccess(i18n.T("Pushed successfully"))
 	} else {
-		ui.ShowWarning("Push cancelled")
+		ui.ShowWarning(i18n.T("Push cancelled"))
+	}
+}
+
+// newGitPassthroughCmd creates a git command wrapper that checks the
+// repository's pinned account before handing all arguments to git.
+// Pushes are blocked on a mismatch; pulls and fetches only warn.
+func newGitPassthroughCmd(gitCmd string, block bool) *cobra.Command {
+	verb := "warns"
+	if block {
+		verb = "blocks"
+	}
+
+	return &cobra.Command{
+		Use:   gitCmd + " [git args...]",
+		Short: fmt.Sprintf("git %s, checked against the pinned account", gitCmd),
+		Long: fmt.Sprintf(`Run git %s after verifying the repository's git identity.
+
+Repositories switched with ghex are pinned to that account. If user.name,
+user.email or the remote's credentials no longer match the pinned account,
+ghex %s and offers to switch back before running git.
+All arguments are passed to git unchanged.
+
+Examples:
+  ghex %s
+  ghex %s origin main`, gitCmd, verb, gitCmd, gitCmd),
+		DisableFlagParsing: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cwd, _ := os.Getwd()
+			if git.IsGitRepo(cwd) && !verifyPinnedIdentity(cwd, block) {
+				ui.ShowWarning(i18n.T("git %s cancelled", gitCmd))
+				os.Exit(1)
+			}
+
+			if gitCmd != "push" {
+				if err := shell.RunInteractive("git", append([]string{gitCmd}, args...)...); err != nil {
+					os.Exit(1)
+				}
+				return
+			}
+			if output, err := shell.RunInteractiveCapture("git", append([]string{gitCmd}, args...)...); err != nil {
+				explainEmailPrivacyRejection(cwd, output)
+				os.Exit(1)
+			}
+		},
+	}
+}
+
+// explainEmailPrivacyRejection explains a push GitHub rejected for exposing
+// a private email (GH007) and offers the account's noreply address for
+// future commits
+func explainEmailPrivacyRejection(repoPath, output string) {
+	if !git.IsEmailPrivacyRejection(output) {
+		return
+	}
+	fmt.Println()
+	ui.ShowWarning(i18n.T("GitHub rejected the push: its commits use an email the account keeps private"))
+
+	cfg, err := config.Load()
+	if err != nil {
+		return
+	}
+	name := account.PinnedAccount(repoPath)
+	if name == "" {
+		name, _ = account.DetectActiveAccount(cfg, repoPath)
+	}
+	acc := account.NewManager(cfg).Find(name)
+	if acc == nil || acc.Token == nil || offline.Enabled() || !useNoreplyEmail(cfg, acc, repoPath) {
+		return
+	}
+	ui.ShowInfo(i18n.T("Rewrite the rejected commits with the new email before pushing again, e.g. for the last one:"))
+	fmt.Printf("  %s\n", ui.Dim("git commit --amend --reset-author --no-edit"))
+}
+
+// verifyPinnedIdentity checks the repository against its pinned account and
+// offers to fix a mismatch. It returns false when the caller should not run
+// the git command.
+func verifyPinnedIdentity(repoPath string, block bool) bool {
+	cfg, err := config.Load()
+	if err != nil {
+		return true
+	}
+
+	manager := account.NewManager(cfg)
+	check := manager.VerifyPinned(repoPath)
+	if check.OK() {
+		return true
+	}
+
+	ui.ShowWarning(i18n.T("This repository is pinned to account '%s'", check.Pinned))
+	for _, problem := range check.Problems {
+		fmt.Printf("  %s %s\n", ui.Dim("•"), problem)
+	}
+	fmt.Println()
+
+	var items []ui.SelectorItem
+	if check.Account != nil {
+		items = append(items, ui.SelectorItem{
+			Title:       fmt.Sprintf("🔄 Switch to %s", check.Pinned),
+			Description: i18n.T("Restore the pinned account's identity and credentials"),
+			Value:       "fix",
+		})
+	}
+	items = append(items,
+		ui.SelectorItem{Title: i18n.T("➡️  Continue anyway"), Description: i18n.T("Run git with the current identity"), Value: "continue"},
+		ui.SelectorItem{Title: i18n.T("❌ Abort"), Description: i18n.T("Do nothing"), Value: "abort"},
+	)
+	if !block {
+		// Pulls and fetches are harmless enough to default to continuing
+		items[0], items[len(items)-2] = items[len(items)-2], items[0]
+	}
+
+	idx, err := ui.RunSelector(i18n.T("Identity mismatch"), items)
+	if err != nil || idx < 0 {
+		return false
+	}
+
+	switch items[idx].Value {
+	case "fix":
+		safety, ok := confirmSwitchSafety(repoPath)
+		if !ok {
+			return false
+		}
+		method := check.FixMethod()
+		if err := manager.Switch(check.Pinned, method, repoPath); err != nil {
+			ui.ShowError(i18n.T("Failed to switch account: %v", err))
+			return false
+		}
+		if err := config.Save(cfg); err != nil {
+			ui.ShowWarning(i18n.T("Failed to save config: %v", err))
+		}
+		retargetStaleBranches(safety, repoPath)
+		ui.ShowSuccess(i18n.T("Switched to account: %s (%s)", check.Pinned, method))
+		return true
+	case "continue":
+		return true
+	default:
+		return false
 	}
 }
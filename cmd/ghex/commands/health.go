@@ -6,6 +6,7 @@ import (
 	"github.com/dwirx/ghex/internal/account"
 	"github.com/dwirx/ghex/internal/config"
 	"github.com/dwirx/ghex/internal/git"
+	"github.com/dwirx/ghex/internal/i18n"
 	"github.com/dwirx/ghex/internal/ssh"
 	"github.com/dwirx/ghex/internal/ui"
 	"github.com/spf13/cobra"
@@ -15,18 +16,54 @@ import (
 func NewHealthCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "health",
-		Short: "Check health of all accounts",
+		Short: i18n.T("Check health of all accounts"),
 		Run: func(cmd *cobra.Command, args []string) {
 			runHealthCheck()
 		},
 	}
 }
 
+// checkConfigPermissions warns when other users can access the config,
+// which holds tokens, and restricts it to the user
+func checkConfigPermissions() {
+	m := config.GetManager()
+	issues := m.CheckPermissions()
+	if len(issues) == 0 {
+		return
+	}
+	for _, issue := range issues {
+		ui.ShowWarning(i18n.T("%s can be accessed by other users (mode %04o)", issue.Path, issue.Mode))
+	}
+	if err := m.FixPermissions(); err != nil {
+		ui.ShowError(err.Error())
+		return
+	}
+	ui.ShowSuccess(i18n.T("Config restricted to your user"))
+}
+
+// checkEmailExposure warns when a GitHub account keeps its email addresses
+// private but commits with a personal one by default, which GitHub can
+// reject on push, and names the noreply address to use instead
+func checkEmailExposure(acc *config.Account) {
+	opts := account.RepoListOptions(acc)
+	if opts.Platform != "github" || acc.GitEmail == "" {
+		return
+	}
+	emails, err := git.ListEmails(opts)
+	if err != nil || !git.ExposesEmail(acc.GitEmail, emails) {
+		return
+	}
+	ui.ShowWarning(i18n.T("  Email: %s is kept private on GitHub, pushes of commits using it may be rejected", acc.GitEmail))
+	if noreply, err := git.NoreplyEmail(opts); err == nil {
+		fmt.Printf("    %s\n", ui.Dim(i18n.T("Commit with %s instead: ghex config set accounts[%s].gitEmail %s", noreply, acc.Name, noreply)))
+	}
+}
+
 // NewLogCmd creates the log command
 func NewLogCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "log",
-		Short: "Show activity log",
+		Short: i18n.T("Show activity log"),
 		Run: func(cmd *cobra.Command, args []string) {
 			runActivityLog()
 		},
@@ -36,23 +73,21 @@ func NewLogCmd() *cobra.Command {
 func runHealthCheck() {
 	cfg, err := config.Load()
 	if err != nil {
-		ui.ShowError(fmt.Sprintf("Failed to load config: %v", err))
+		ui.ShowError(i18n.T("Failed to load config: %v", err))
 		return
 	}
+	checkConfigPermissions()
 
 	if len(cfg.Accounts) == 0 {
-		ui.ShowWarning("No accounts configured")
+		ui.ShowWarning(i18n.T("No accounts configured"))
 		return
 	}
-
-	ui.ShowSection("Health Check")
-
-	// Fix permissions for ALL SSH keys first
-	fixedCount, _ := ssh.FixAllKeyPermissions()
-	if fixedCount > 0 {
-		ui.ShowSuccess(fmt.Sprintf("✓ Fixed permissions for %d SSH key(s)", fixedCount))
+	if skipOffline(i18n.T("Health check")) {
+		return
 	}
 
+	ui.ShowSection(i18n.T("Health Check"))
+
 	// Track summary
 	total := len(cfg.Accounts)
 	healthy := 0
@@ -70,10 +105,10 @@ func runHealthCheck() {
 		if acc.SSH != nil {
 			expandedPath := ExpandKeyPath(acc.SSH.KeyPath)
 
-			spinner := ui.NewSpinner(fmt.Sprintf("  Testing SSH with %s...", acc.SSH.KeyPath))
+			spinner := ui.NewSpinner(i18n.T("  Testing SSH with %s...", acc.SSH.KeyPath))
 			spinner.Start()
 
-			ok, msg, _ := ssh.TestConnectionWithKey(platform.Host, expandedPath)
+			ok, msg, _ := ssh.TestConnectionWithOptions(platform.Host, expandedPath, ssh.OptionsForAccount(&acc))
 			if ok {
 				spinner.StopWithSuccess(fmt.Sprintf("  SSH: %s", msg))
 			} else {
@@ -83,7 +118,7 @@ func runHealthCheck() {
 		}
 
 		if acc.Token != nil {
-			spinner := ui.NewSpinner("  Testing Token...")
+			spinner := ui.NewSpinner(i18n.T("  Testing Token..."))
 			spinner.Start()
 
 			// Determine API host based on account platform
@@ -93,9 +128,10 @@ func runHealthCheck() {
 			}
 			ok, msg, _ := git.TestTokenAuthForHost(acc.Token.Username, acc.Token.Token, apiHost)
 			if ok {
-				spinner.StopWithSuccess(fmt.Sprintf("  Token: %s", msg))
+				spinner.StopWithSuccess(i18n.T("  Token: %s", msg))
+				checkEmailExposure(&acc)
 			} else {
-				spinner.StopWithError(fmt.Sprintf("  Token: %s", msg))
+				spinner.StopWithError(i18n.T("  Token: %s", msg))
 				accountHealthy = false
 			}
 		}
@@ -128,16 +164,16 @@ func runHealthCheck() {
 func runActivityLog() {
 	cfg, err := config.Load()
 	if err != nil {
-		ui.ShowError(fmt.Sprintf("Failed to load config: %v", err))
+		ui.ShowError(i18n.T("Failed to load config: %v", err))
 		return
 	}
 
 	if len(cfg.ActivityLog) == 0 {
-		ui.ShowInfo("No activity logged yet")
+		ui.ShowInfo(i18n.T("No activity logged yet"))
 		return
 	}
 
-	ui.ShowSection("Activity Log")
+	ui.ShowSection(i18n.T("Activity Log"))
 
 	manager := account.NewManager(cfg)
 	entries := manager.GetRecentActivity(20)
@@ -3,9 +3,14 @@ package commands
 import (
 	"fmt"
 	"os"
+	"time"
 
+	"github.com/dwirx/ghex/internal/account"
+	"github.com/dwirx/ghex/internal/clipboard"
 	"github.com/dwirx/ghex/internal/config"
 	"github.com/dwirx/ghex/internal/git"
+	"github.com/dwirx/ghex/internal/i18n"
+	"github.com/dwirx/ghex/internal/offline"
 	"github.com/dwirx/ghex/internal/platform"
 	"github.com/dwirx/ghex/internal/ssh"
 	"github.com/dwirx/ghex/internal/ui"
@@ -70,11 +75,138 @@ func ExpandKeyPath(keyPath string) string {
 	return platform.ExpandPath(keyPath)
 }
 
+// SSHTestOptions overrides how SSH connection tests run
+type SSHTestOptions struct {
+	Port    int  // Port to connect to (0 = account's port or 22)
+	Verbose bool // Run ssh -vvv and show the handshake log
+}
+
+// sshDebugHighlights are the -vvv lines that explain most failures
+var sshDebugHighlights = []string{
+	"Connecting to",
+	"Connection established",
+	"Offering public key",
+	"Server accepts key",
+	"Authentications that can continue",
+	"Permission denied",
+	"Connection refused",
+	"Connection timed out",
+	"Host key verification failed",
+	"no matching",
+}
+
+// fixKeyPermissions tightens the permissions of the key about to be used
+// when ssh would refuse them, leaving every other file in ~/.ssh alone
+func fixKeyPermissions(keyPath string, show bool) {
+	fixed, err := ssh.EnsureKeyPermissions(keyPath)
+	if !show {
+		return
+	}
+	if err != nil {
+		ui.ShowWarning(i18n.T("Could not fix permissions of %s: %v", keyPath, err))
+	} else if fixed {
+		ui.ShowInfo(i18n.T("Fixed permissions of %s", keyPath))
+	}
+}
+
+// skipOffline reports whether ghex is offline, saying that what was skipped
+// when it is
+func skipOffline(what string) bool {
+	if !offline.Enabled() {
+		return false
+	}
+	ui.ShowWarning(i18n.T("%s skipped (offline)", what))
+	return true
+}
+
+// runSSHTest runs the connection test and returns the result and, with
+// Verbose, the debug log to show once the spinner has stopped
+func runSSHTest(host, keyPath string, hostOpts ssh.HostOptions, opts SSHTestOptions) (bool, string, string) {
+	if opts.Port > 0 {
+		hostOpts.Port = opts.Port
+	}
+	if opts.Verbose {
+		ok, msg, debugLog, _ := ssh.TestConnectionVerbose(host, keyPath, hostOpts)
+		return ok, msg, debugLog
+	}
+	ok, msg, _ := ssh.TestConnectionWithOptions(host, keyPath, hostOpts)
+	return ok, msg, ""
+}
+
+// orderedAccounts returns the accounts that pass filter (all when nil) in
+// selector order: the account detected for the working directory first,
+// then favorites, then the rest by the configured sort order
+func orderedAccounts(cfg *config.AppConfig, filter func(*config.Account) bool) []*config.Account {
+	var accounts []*config.Account
+	for i := range cfg.Accounts {
+		if filter == nil || filter(&cfg.Accounts[i]) {
+			accounts = append(accounts, &cfg.Accounts[i])
+		}
+	}
+
+	detected := ""
+	if cwd, err := os.Getwd(); err == nil && git.IsGitRepo(cwd) {
+		detected, _ = account.NewManager(cfg).DetectActive(cwd)
+	}
+	return account.SortForSelection(cfg, accounts, detected)
+}
+
+// accountTitle returns an account's name for selectors, starred when it
+// is a favorite
+func accountTitle(acc *config.Account) string {
+	if acc.Favorite {
+		return "★ " + acc.Name
+	}
+	return acc.Name
+}
+
+// hasSSH is an orderedAccounts filter for accounts with SSH configured
+func hasSSH(acc *config.Account) bool {
+	return acc.SSH != nil
+}
+
+// copyToClipboard copies text and reports the result. Without a clipboard
+// the text is printed instead when printFallback is set (never for secrets).
+func copyToClipboard(text, what string, printFallback bool) bool {
+	err := clipboard.Copy(text)
+	if err == nil {
+		ui.ShowSuccess(i18n.T("Copied %s to the clipboard", what))
+		return true
+	}
+	ui.ShowWarning(i18n.T("Could not copy %s: %v", what, err))
+	if printFallback {
+		fmt.Println(text)
+	}
+	return false
+}
+
+// showPublicKeyStep prints the "copy your public key" hint with the key
+// itself, pointing at ghex ssh export when the account is known
+func showPublicKeyStep(keyPath, accountName string) {
+	pub, err := ssh.ReadPublicKey(keyPath)
+	if err != nil {
+		ui.ShowInfo(i18n.T("1. Copy your public key: %s.pub", keyPath))
+		return
+	}
+	if accountName != "" {
+		ui.ShowInfo(i18n.T("1. Copy your public key (or run: ghex ssh export -a %s --clipboard):", accountName))
+	} else {
+		ui.ShowInfo(i18n.T("1. Copy your public key:"))
+	}
+	fmt.Println("   " + pub)
+}
+
 // TestAccountSSH tests SSH connection for an account and shows result
 // Returns true if test passed
 func TestAccountSSH(acc *config.Account, showDetails bool) bool {
+	return TestAccountSSHWithOptions(acc, showDetails, SSHTestOptions{})
+}
+
+// TestAccountSSHWithOptions is TestAccountSSH with a port override and
+// verbose handshake log
+func TestAccountSSHWithOptions(acc *config.Account, showDetails bool, opts SSHTestOptions) bool {
 	if acc.SSH == nil {
-		ui.ShowWarning("Account has no SSH configuration")
+		ui.ShowWarning(i18n.T("Account has no SSH configuration"))
 		return false
 	}
 
@@ -84,41 +216,42 @@ func TestAccountSSH(acc *config.Account, showDetails bool) bool {
 
 	// Check if key exists
 	if _, err := os.Stat(expandedPath); os.IsNotExist(err) {
-		ui.ShowError(fmt.Sprintf("SSH key not found: %s", keyPath))
+		ui.ShowError(i18n.T("SSH key not found: %s", keyPath))
 		return false
 	}
 
-	// Fix permissions for ALL SSH keys
-	fixedCount, _ := ssh.FixAllKeyPermissions()
-	if fixedCount > 0 && showDetails {
-		ui.ShowSuccess(fmt.Sprintf("✓ Fixed permissions for %d SSH key(s)", fixedCount))
+	fixKeyPermissions(expandedPath, showDetails)
+	if skipOffline(i18n.T("SSH connection test")) {
+		return false
 	}
 
 	if showDetails {
 		fmt.Println()
-		ui.ShowInfo(fmt.Sprintf("🔑 Using key: %s", keyPath))
-		ui.ShowInfo(fmt.Sprintf("🌐 Host: %s %s (%s)", platform.Icon, platform.Name, platform.Host))
+		ui.ShowInfo(i18n.T("🔑 Using key: %s", keyPath))
+		ui.ShowInfo(i18n.T("🌐 Host: %s %s (%s)", platform.Icon, platform.Name, platform.Host))
 		fmt.Println()
 	}
 
-	spinner := ui.NewSpinner("Testing SSH connection...")
+	spinner := ui.NewSpinner(i18n.T("Testing SSH connection..."))
 	spinner.Start()
 
-	ok, msg, _ := ssh.TestConnectionWithKey(platform.Host, expandedPath)
+	ok, msg, debugLog := runSSHTest(platform.Host, expandedPath, ssh.OptionsForAccount(acc), opts)
 	if ok {
-		spinner.StopWithSuccess("✓ SSH connection test passed!")
+		spinner.StopWithSuccess(i18n.T("✓ SSH connection test passed!"))
 		if showDetails {
-			ui.ShowSuccess(fmt.Sprintf("Authenticated successfully to %s", platform.Host))
+			ui.ShowSuccess(i18n.T("Authenticated successfully to %s", platform.Host))
 		}
+		ui.ShowDetails("Verbose log", debugLog, sshDebugHighlights)
 		return true
 	}
 
-	spinner.StopWithError("✗ SSH connection test failed!")
+	spinner.StopWithError(i18n.T("✗ SSH connection test failed!"))
+	ui.ShowDetails("Verbose log", debugLog, sshDebugHighlights)
 	if showDetails {
 		fmt.Println()
-		ui.ShowWarning(fmt.Sprintf("Make sure your SSH key is added to %s:", platform.Name))
-		ui.ShowInfo(fmt.Sprintf("1. Copy your public key: cat %s.pub", keyPath))
-		ui.ShowInfo(fmt.Sprintf("2. Add it at: %s", platform.KeysURL))
+		ui.ShowWarning(i18n.T("Make sure your SSH key is added to %s:", platform.Name))
+		showPublicKeyStep(keyPath, acc.Name)
+		ui.ShowInfo(i18n.T("2. Add it at: %s", platform.KeysURL))
 		if msg != "" {
 			fmt.Println()
 			fmt.Println(ui.Muted(fmt.Sprintf("Details: %s", msg)))
@@ -131,31 +264,34 @@ func TestAccountSSH(acc *config.Account, showDetails bool) bool {
 // Returns true if test passed
 func TestAccountToken(acc *config.Account, showDetails bool) bool {
 	if acc.Token == nil {
-		ui.ShowWarning("Account has no token configuration")
+		ui.ShowWarning(i18n.T("Account has no token configuration"))
+		return false
+	}
+	if skipOffline(i18n.T("Token test")) {
 		return false
 	}
 
 	platformInfo := GetPlatformInfo(acc)
 
-	spinner := ui.NewSpinner("Testing token authentication...")
+	spinner := ui.NewSpinner(i18n.T("Testing token authentication..."))
 	spinner.Start()
 
 	ok, msg, _ := git.TestTokenAuthForHost(acc.Token.Username, acc.Token.Token, platformInfo.Host)
 	if ok {
-		spinner.StopWithSuccess("✓ Token authentication test passed!")
+		spinner.StopWithSuccess(i18n.T("✓ Token authentication test passed!"))
 		if showDetails {
-			ui.ShowInfo(fmt.Sprintf("Successfully authenticated as %s", acc.Token.Username))
+			ui.ShowInfo(i18n.T("Successfully authenticated as %s", acc.Token.Username))
 		}
 		return true
 	}
 
-	spinner.StopWithError("✗ Token authentication failed!")
+	spinner.StopWithError(i18n.T("✗ Token authentication failed!"))
 	if showDetails {
-		ui.ShowWarning("Please check:")
-		ui.ShowInfo("• Token has not expired")
-		ui.ShowInfo("• Token has correct permissions (repo access)")
-		ui.ShowInfo("• Username is correct")
-		ui.ShowInfo(fmt.Sprintf("\nCreate a new token at: %s", platformInfo.TokenURL))
+		ui.ShowWarning(i18n.T("Please check:"))
+		ui.ShowInfo(i18n.T("• Token has not expired"))
+		ui.ShowInfo(i18n.T("• Token has correct permissions (repo access)"))
+		ui.ShowInfo(i18n.T("• Username is correct"))
+		ui.ShowInfo(i18n.T("\nCreate a new token at: %s", platformInfo.TokenURL))
 		if msg != "" {
 			fmt.Println(ui.Muted(fmt.Sprintf("\nDetails: %s", msg)))
 		}
@@ -165,46 +301,47 @@ func TestAccountToken(acc *config.Account, showDetails bool) bool {
 
 // TestSSHKeyDirect tests an SSH key directly against a host
 // Returns true if test passed
-func TestSSHKeyDirect(keyPath, host string, showDetails bool) bool {
+func TestSSHKeyDirect(keyPath, host string, showDetails bool, opts SSHTestOptions) bool {
 	expandedPath := ExpandKeyPath(keyPath)
 
 	// Check if key exists
 	if _, err := os.Stat(expandedPath); os.IsNotExist(err) {
-		ui.ShowError(fmt.Sprintf("SSH key not found: %s", keyPath))
+		ui.ShowError(i18n.T("SSH key not found: %s", keyPath))
 		return false
 	}
 
-	// Fix permissions for ALL SSH keys
-	fixedCount, _ := ssh.FixAllKeyPermissions()
-	if fixedCount > 0 && showDetails {
-		ui.ShowSuccess(fmt.Sprintf("✓ Fixed permissions for %d SSH key(s)", fixedCount))
+	fixKeyPermissions(expandedPath, showDetails)
+	if skipOffline(i18n.T("SSH connection test")) {
+		return false
 	}
 
 	if showDetails {
 		fmt.Println()
-		ui.ShowInfo(fmt.Sprintf("🔑 Using key: %s", keyPath))
-		ui.ShowInfo(fmt.Sprintf("🌐 Host: %s", host))
+		ui.ShowInfo(i18n.T("🔑 Using key: %s", keyPath))
+		ui.ShowInfo(i18n.T("🌐 Host: %s", host))
 		fmt.Println()
 	}
 
-	spinner := ui.NewSpinner("Testing SSH connection...")
+	spinner := ui.NewSpinner(i18n.T("Testing SSH connection..."))
 	spinner.Start()
 
-	ok, msg, _ := ssh.TestConnectionWithKey(host, expandedPath)
+	ok, msg, debugLog := runSSHTest(host, expandedPath, ssh.HostOptions{}, opts)
 	if ok {
-		spinner.StopWithSuccess("✓ SSH connection test passed!")
+		spinner.StopWithSuccess(i18n.T("✓ SSH connection test passed!"))
 		if showDetails {
-			ui.ShowSuccess(fmt.Sprintf("Authenticated successfully to %s", host))
+			ui.ShowSuccess(i18n.T("Authenticated successfully to %s", host))
 		}
+		ui.ShowDetails("Verbose log", debugLog, sshDebugHighlights)
 		return true
 	}
 
-	spinner.StopWithError("✗ SSH connection test failed!")
+	spinner.StopWithError(i18n.T("✗ SSH connection test failed!"))
+	ui.ShowDetails("Verbose log", debugLog, sshDebugHighlights)
 	if showDetails {
 		fmt.Println()
-		ui.ShowWarning(fmt.Sprintf("Make sure your SSH key is added to %s:", host))
-		ui.ShowInfo(fmt.Sprintf("1. Copy your public key: cat %s.pub", keyPath))
-		ui.ShowInfo("2. Add it to your Git service settings")
+		ui.ShowWarning(i18n.T("Make sure your SSH key is added to %s:", host))
+		showPublicKeyStep(keyPath, "")
+		ui.ShowInfo(i18n.T("2. Add it to your Git service settings"))
 		if msg != "" {
 			fmt.Println()
 			fmt.Println(ui.Muted(fmt.Sprintf("Details: %s", msg)))
@@ -212,3 +349,40 @@ func TestSSHKeyDirect(keyPath, host string, showDetails bool) bool {
 	}
 	return false
 }
+
+// generatedKeyComment returns the comment for a new key of acc: its email,
+// username or name@platform, followed by this machine's name
+func generatedKeyComment(acc *config.Account) string {
+	comment := acc.GitEmail
+	if comment == "" {
+		comment = acc.GitUserName
+	}
+	if comment == "" {
+		platformType := "github"
+		if acc.Platform != nil {
+			platformType = acc.Platform.Type
+		}
+		comment = fmt.Sprintf("%s@%s", acc.Name, platformType)
+	}
+	return ssh.DeviceComment(comment, platform.DeviceName())
+}
+
+// recordKeyDevice records in acc that its key at keyPath lives on this
+// machine, with the fingerprint and comment of the public key
+func recordKeyDevice(acc *config.Account, keyPath string) {
+	device := platform.DeviceName()
+	if device == "" || acc.SSH == nil {
+		return
+	}
+	fingerprint := ""
+	if pub, err := os.ReadFile(platform.ExpandPath(keyPath) + ".pub"); err == nil {
+		fingerprint = ssh.PublicKeyFingerprint(string(pub))
+	}
+	acc.SSH.RecordDevice(config.SshDevice{
+		Name:        device,
+		KeyPath:     keyPath,
+		Fingerprint: fingerprint,
+		Comment:     ssh.KeyComment(keyPath),
+		AddedAt:     time.Now().UTC().Format(time.RFC3339),
+	})
+}
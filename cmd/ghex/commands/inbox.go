@@ -0,0 +1,239 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dwirx/ghex/internal/account"
+	"github.com/dwirx/ghex/internal/config"
+	"github.com/dwirx/ghex/internal/git"
+	"github.com/dwirx/ghex/internal/i18n"
+	"github.com/dwirx/ghex/internal/platform"
+	"github.com/dwirx/ghex/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// inboxItem is a notification or an assigned pull request
+type inboxItem struct {
+	notification *git.Notification
+	pr           *git.PullRequest
+}
+
+// NewInboxCmd creates the inbox command
+func NewInboxCmd() *cobra.Command {
+	var listOnly bool
+
+	cmd := &cobra.Command{
+		Use:   "inbox [account]",
+		Short: i18n.T("Show GitHub notifications and assigned pull requests"),
+		Long: `List the unread GitHub notifications and the open pull requests assigned
+to an account, using the account's token. Pick an entry to open it in the
+browser or mark the notification as read.
+
+Without an account, the GitHub account with a token is used; when there
+are several you pick one, with the account detected for the current
+repository first.
+
+Examples:
+  ghex inbox
+  ghex inbox work
+  ghex inbox work --list`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			name := ""
+			if len(args) > 0 {
+				name = args[0]
+			}
+			runInbox(name, listOnly)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&listOnly, "list", "l", false, "Only print the inbox, without actions")
+
+	return cmd
+}
+
+func runInbox(name string, listOnly bool) {
+	cfg, err := config.Load()
+	if err != nil {
+		ui.ShowError(i18n.T("Failed to load config: %v", err))
+		return
+	}
+
+	if skipOffline(i18n.T("Inbox")) {
+		return
+	}
+	acc := selectInboxAccount(cfg, name)
+	if acc == nil {
+		return
+	}
+	opts := account.RepoListOptions(acc)
+
+	spinner := ui.NewSpinner(i18n.T("Loading inbox of %s...", acc.Name))
+	spinner.Start()
+	notifications, err := git.ListNotifications(opts)
+	if err != nil {
+		spinner.StopWithError(err.Error())
+		return
+	}
+	prs, err := git.ListAssignedPullRequests(opts)
+	if err != nil {
+		spinner.StopWithError(err.Error())
+		return
+	}
+	spinner.StopWithSuccess(i18n.T("%d unread notifications, %d assigned pull requests", len(notifications), len(prs)))
+
+	var items []inboxItem
+	for i := range notifications {
+		items = append(items, inboxItem{notification: &notifications[i]})
+	}
+	for i := range prs {
+		items = append(items, inboxItem{pr: &prs[i]})
+	}
+	if len(items) == 0 {
+		ui.ShowSuccess(i18n.T("Inbox zero"))
+		return
+	}
+
+	if listOnly {
+		fmt.Println()
+		for _, item := range items {
+			title, desc := item.describe()
+			fmt.Printf("  %s\n    %s\n", title, ui.Dim(desc))
+		}
+		return
+	}
+
+	for len(items) > 0 {
+		selectorItems := make([]ui.SelectorItem, 0, len(items)+1)
+		for _, item := range items {
+			title, desc := item.describe()
+			selectorItems = append(selectorItems, ui.SelectorItem{Title: title, Description: desc})
+		}
+		selectorItems = append(selectorItems, ui.SelectorItem{Title: i18n.T("❌ Exit"), Description: i18n.T("Close the inbox")})
+
+		idx, err := ui.RunSelector(i18n.T("Inbox of %s (↑/k ↓/j to navigate, enter/l to select)", acc.Name), selectorItems)
+		if err != nil || idx < 0 || idx >= len(items) {
+			return
+		}
+		if handleInboxItem(items[idx], opts) {
+			items = append(items[:idx], items[idx+1:]...)
+		}
+	}
+	ui.ShowSuccess(i18n.T("Inbox zero"))
+}
+
+// selectInboxAccount returns the named account, or the GitHub account with
+// a token, asking when there are several
+func selectInboxAccount(cfg *config.AppConfig, name string) *config.Account {
+	if name != "" {
+		acc := account.NewManager(cfg).Find(name)
+		if acc == nil {
+			ui.ShowError(i18n.T("Account '%s' not found", name))
+			return nil
+		}
+		if !hasGitHubToken(acc) {
+			ui.ShowError(i18n.T("Account '%s' needs a GitHub token for the inbox", name))
+			return nil
+		}
+		return acc
+	}
+
+	accounts := orderedAccounts(cfg, hasGitHubToken)
+	switch len(accounts) {
+	case 0:
+		ui.ShowError(i18n.T("No GitHub account with a token configured"))
+		return nil
+	case 1:
+		return accounts[0]
+	}
+
+	items := make([]ui.SelectorItem, len(accounts))
+	for i, acc := range accounts {
+		items[i] = ui.SelectorItem{Title: accountTitle(acc), Description: acc.Token.Username, Value: acc.Name}
+	}
+	idx, err := ui.RunSelector(i18n.T("Select Account (↑/k ↓/j to navigate, enter/l to select)"), items)
+	if err != nil || idx < 0 {
+		return nil
+	}
+	return accounts[idx]
+}
+
+// hasGitHubToken is an orderedAccounts filter for GitHub accounts with a token
+func hasGitHubToken(acc *config.Account) bool {
+	return acc.Token != nil && acc.Token.Token != "" &&
+		(acc.Platform == nil || acc.Platform.Type == "" || acc.Platform.Type == account.PlatformGitHub)
+}
+
+// handleInboxItem offers the actions for an item and returns true when it
+// should leave the inbox
+func handleInboxItem(item inboxItem, opts git.RepoListOptions) bool {
+	actions := []ui.SelectorItem{
+		{Title: i18n.T("🌐 Open in browser"), Value: "open"},
+	}
+	if item.notification != nil {
+		actions = append(actions,
+			ui.SelectorItem{Title: i18n.T("🌐 Open and mark as read"), Value: "open-read"},
+			ui.SelectorItem{Title: i18n.T("✓ Mark as read"), Value: "read"},
+		)
+	}
+	actions = append(actions, ui.SelectorItem{Title: i18n.T("← Back"), Value: "back"})
+
+	title, _ := item.describe()
+	idx, err := ui.RunSelector(title, actions)
+	if err != nil || idx < 0 {
+		return false
+	}
+
+	action := actions[idx].Value
+	if action == "open" || action == "open-read" {
+		if err := platform.OpenURL(item.url()); err != nil {
+			ui.ShowError(err.Error())
+			ui.ShowInfo(item.url())
+			return false
+		}
+	}
+	if action == "read" || action == "open-read" {
+		if err := git.MarkNotificationRead(item.notification.ID, opts); err != nil {
+			ui.ShowError(err.Error())
+			return false
+		}
+		ui.ShowSuccess(i18n.T("Marked as read"))
+		return true
+	}
+	return false
+}
+
+// describe returns the selector title and description of an item
+func (item inboxItem) describe() (string, string) {
+	if n := item.notification; n != nil {
+		return fmt.Sprintf("🔔 %s — %s", n.Repo, n.Title),
+			fmt.Sprintf("%s • %s • %s", n.Type, n.Reason, formatAge(n.UpdatedAt))
+	}
+	pr := item.pr
+	return fmt.Sprintf("🔀 %s#%d — %s", pr.Repo, pr.Number, pr.Title),
+		i18n.T("assigned pull request • %s", formatAge(pr.UpdatedAt))
+}
+
+// url returns the web page of an item
+func (item inboxItem) url() string {
+	if item.notification != nil {
+		return item.notification.URL
+	}
+	return item.pr.URL
+}
+
+// formatAge returns how long ago t was, e.g. "5m ago" or "3d ago"
+func formatAge(t time.Time) string {
+	d := time.Since(t)
+	switch {
+	case d < time.Minute:
+		return i18n.T("just now")
+	case d < time.Hour:
+		return i18n.T("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return i18n.T("%dh ago", int(d.Hours()))
+	default:
+		return i18n.T("%dd ago", int(d.Hours()/24))
+	}
+}
@@ -0,0 +1,198 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dwirx/ghex/internal/config"
+	"github.com/dwirx/ghex/internal/i18n"
+	"github.com/dwirx/ghex/internal/platform"
+	"github.com/dwirx/ghex/internal/selfinstall"
+	"github.com/dwirx/ghex/internal/ui"
+	"github.com/dwirx/ghex/pkg/download"
+	"github.com/spf13/cobra"
+)
+
+// NewInstallCmd creates the install command for release binaries
+func NewInstallCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "install <owner/repo[@version]|url>...",
+		Short: i18n.T("Install tools from their release binaries"),
+		Long: `Download the release asset built for this OS and architecture, extract
+the binary and install it into the bin directory.
+
+The bin directory is --bin-dir, else "binDir" in the ghex config, else
+~/.local/bin. Installed tools are recorded in ~/.config/ghe/tools.json, so
+'ghex outdated' reports newer releases and --upgrade installs them. When
+several assets fit this platform, narrow them down with --asset.
+
+Examples:
+  ghex install junegunn/fzf
+  ghex install cli/cli --binary gh
+  ghex install BurntSushi/ripgrep@14.1.0 --binary rg
+  ghex install sharkdp/bat --version "^0.24"
+  ghex install --upgrade
+  ghex install --upgrade fzf
+  ghex install list
+  ghex install remove fzf`,
+		ValidArgsFunction: completeRepoArg,
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			upgrade, _ := cmd.Flags().GetBool("upgrade")
+			token, _ := cmd.Flags().GetString("token")
+			registerAccountHosts()
+
+			if upgrade {
+				if skipOffline(i18n.T("Upgrades")) {
+					return nil
+				}
+				return upgradeTools(args, token)
+			}
+			if len(args) == 0 {
+				return fmt.Errorf("specify a repository, or --upgrade")
+			}
+
+			version, _ := cmd.Flags().GetString("version")
+			asset, _ := cmd.Flags().GetString("asset")
+			binary, _ := cmd.Flags().GetString("binary")
+			binDir, _ := cmd.Flags().GetString("bin-dir")
+			overwrite, _ := cmd.Flags().GetBool("overwrite")
+			requireAttestation, _ := cmd.Flags().GetBool("require-attestation")
+			prerelease, _ := cmd.Flags().GetBool("prerelease")
+			if len(args) > 1 && (version != "" || binary != "") {
+				return fmt.Errorf("--version and --binary apply to a single repository")
+			}
+			if skipOffline(i18n.T("Installs")) {
+				return nil
+			}
+
+			binDir = installBinDir(binDir)
+			failed := 0
+			for _, arg := range args {
+				// owner/repo@tag selects the release when --version isn't given
+				repoArg, repoVersion := arg, version
+				if sh, ok := download.ParseShorthand(arg); ok {
+					if repoVersion == "" {
+						repoVersion = sh.Ref
+					}
+					repoArg = sh.RepoURL()
+				}
+
+				opts := download.ReleaseOptions{
+					Version:   repoVersion,
+					Asset:     asset,
+					Auto:      asset == "",
+					Install:   true,
+					BinDir:    binDir,
+					Binary:    binary,
+					Overwrite: overwrite,
+					Token:     token,
+
+					RequireAttestation: requireAttestation,
+
+					Prerelease: prerelease,
+				}
+				if err := download.GitRelease(repoArg, opts); err != nil {
+					ui.ShowError(fmt.Sprintf("%s: %v", arg, err))
+					failed++
+				}
+			}
+
+			if failed < len(args) && !selfinstall.InPath(binDir) {
+				ui.ShowWarning(i18n.T("%s is not in your PATH; add it to run the installed tools", binDir))
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d installs failed", failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("upgrade", false, "Upgrade the named installed tools, or all of them")
+	cmd.Flags().StringP("version", "v", "", "Release tag or range like \"^1.4\" or \">=2 <3\" (default: latest)")
+	cmd.Flags().StringP("asset", "a", "", "Asset name filter, instead of picking the asset for this platform")
+	cmd.Flags().String("binary", "", "Binary name to extract and install (default: repo name)")
+	cmd.Flags().String("bin-dir", "", "Install directory (default: binDir from the config, else ~/.local/bin)")
+	cmd.Flags().BoolP("overwrite", "w", false, "Replace an existing file not installed by ghex")
+	cmd.Flags().Bool("prerelease", false, "Include prereleases (default: stable releases only)")
+	cmd.Flags().Bool("require-attestation", false, "Refuse assets without a GitHub artifact attestation")
+	cmd.Flags().StringP("token", "t", "", "Access token (falls back to GITHUB_TOKEN, GITLAB_TOKEN, GITEA_TOKEN or BITBUCKET_TOKEN by host, then a configured account's token)")
+
+	cmd.AddCommand(newInstallListCmd())
+	cmd.AddCommand(newInstallRemoveCmd())
+
+	return cmd
+}
+
+func newInstallListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: i18n.T("List installed tools"),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tools, err := download.ListInstalled()
+			if err != nil {
+				ui.ShowError(err.Error())
+				return err
+			}
+			if len(tools) == 0 {
+				ui.ShowInfo(i18n.T("No tools installed yet (use 'ghex install owner/repo')"))
+				return nil
+			}
+
+			ui.ShowSection(i18n.T("Installed Tools"))
+			for _, t := range tools {
+				version := t.Version
+				if t.Constraint != "" {
+					version += " (" + t.Constraint + ")"
+				}
+				path := t.Path
+				if !platform.FileExists(path) {
+					path += " " + i18n.T("(missing)")
+				}
+				fmt.Printf("  %-20s %-20s %s %s\n", t.Name, version, ui.Dim(fmt.Sprintf("%-24s", t.Repo)), ui.Dim(path))
+			}
+			fmt.Println()
+			return nil
+		},
+	}
+}
+
+func newInstallRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:               "remove <tool>...",
+		Aliases:           []string{"rm", "uninstall"},
+		Short:             i18n.T("Remove installed tools"),
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeInstalledTool,
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var failed []string
+			for _, name := range args {
+				tool, err := download.RemoveInstalled(name)
+				if err != nil {
+					ui.ShowError(err.Error())
+					failed = append(failed, name)
+					continue
+				}
+				ui.ShowSuccess(i18n.T("Removed %s (%s)", tool.Name, tool.Path))
+			}
+			if len(failed) > 0 {
+				return fmt.Errorf("failed to remove %s", strings.Join(failed, ", "))
+			}
+			return nil
+		},
+	}
+}
+
+// installBinDir returns the directory tools are installed into: flag when
+// given, else the configured binDir, else download's default
+func installBinDir(flag string) string {
+	if flag != "" {
+		return platform.ExpandPath(flag)
+	}
+	if cfg, err := config.Load(); err == nil && cfg.BinDir != "" {
+		return platform.ExpandPath(cfg.BinDir)
+	}
+	return download.DefaultBinDir()
+}
@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/dwirx/ghex/internal/i18n"
+	"github.com/dwirx/ghex/internal/platform"
+	"github.com/dwirx/ghex/internal/selfinstall"
+	"github.com/dwirx/ghex/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// NewInstallSelfCmd creates the install-self command
+func NewInstallSelfCmd() *cobra.Command {
+	var dir string
+	var addPath bool
+	var noCompletion bool
+	var shellName string
+
+	cmd := &cobra.Command{
+		Use:   "install-self",
+		Short: i18n.T("Install this ghex binary for the current user"),
+		Long: `Copy the running ghex binary into a stable directory and set up the shell.
+
+With --add-path, the directory is added to PATH: on Windows in the user's
+PATH, elsewhere in the shell rc file (~/.bashrc, ~/.zshrc or fish's
+config.fish). Shell completion is set up in the same rc file unless
+--no-completion is given. ghex only edits the block between its
+"# >>> ghex >>>" markers, and 'ghex uninstall' removes it.
+
+Examples:
+  ghex install-self
+  ghex install-self --add-path
+  ghex install-self --dir ~/bin --add-path --shell zsh`,
+		Run: func(cmd *cobra.Command, args []string) {
+			runInstallSelf(dir, addPath, !noCompletion, shellName)
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", "", "Install directory (default: ~/.local/bin, %LOCALAPPDATA%\\ghex on Windows)")
+	cmd.Flags().BoolVar(&addPath, "add-path", false, "Add the install directory to PATH")
+	cmd.Flags().BoolVar(&noCompletion, "no-completion", false, "Don't set up shell completion")
+	cmd.Flags().StringVar(&shellName, "shell", "", "Shell to set up: bash, zsh or fish (default: current shell)")
+
+	return cmd
+}
+
+func runInstallSelf(dir string, addPath, completion bool, shellName string) {
+	if dir == "" {
+		dir = selfinstall.DefaultDir()
+	}
+	target, copied, err := selfinstall.CopySelf(dir)
+	if err != nil {
+		ui.ShowError(i18n.T("Failed to install ghex: %v", err))
+		return
+	}
+	if copied {
+		ui.ShowSuccess(i18n.T("Installed ghex to %s", target))
+	} else {
+		ui.ShowInfo(i18n.T("ghex is already installed at %s", target))
+	}
+	binDir := filepath.Dir(target)
+
+	if shellName == "" {
+		shellName = platform.DetectShell()
+	}
+
+	// PowerShell and cmd keep PATH in the registry and have no rc file
+	if shellName == "powershell" || shellName == "cmd" {
+		if addPath && !selfinstall.InPath(binDir) {
+			if err := selfinstall.AddToWindowsPath(binDir); err != nil {
+				ui.ShowError(err.Error())
+			} else {
+				ui.ShowSuccess(i18n.T("Added %s to your PATH (open a new terminal to use it)", binDir))
+			}
+		}
+		if completion {
+			ui.ShowInfo(i18n.T("For PowerShell completion, add this line to your $PROFILE:"))
+			fmt.Printf("  & '%s' completion powershell | Out-String | Invoke-Expression\n", target)
+		}
+		warnNotInPath(binDir, addPath)
+		return
+	}
+
+	setup := &selfinstall.ShellSetup{
+		BinDir:     binDir,
+		Binary:     target,
+		AddPath:    addPath,
+		Completion: completion,
+	}
+	rc, changed, err := selfinstall.WriteRCBlock(shellName, setup)
+	if err != nil {
+		if addPath || completion {
+			ui.ShowWarning(i18n.T("Could not set up the shell: %v", err))
+		}
+	} else if changed {
+		ui.ShowSuccess(i18n.T("Updated %s", rc))
+		ui.ShowInfo(i18n.T("Restart your shell or run: source %s", rc))
+	}
+	warnNotInPath(binDir, setup.AddPath)
+}
+
+// warnNotInPath points out an install directory the shell won't search
+func warnNotInPath(binDir string, addPath bool) {
+	if addPath || selfinstall.InPath(binDir) {
+		return
+	}
+	ui.ShowWarning(i18n.T("%s is not in your PATH. Re-run with --add-path or add it yourself.", binDir))
+}
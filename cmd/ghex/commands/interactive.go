@@ -3,11 +3,14 @@ package commands
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/dwirx/ghex/internal/account"
 	"github.com/dwirx/ghex/internal/config"
 	"github.com/dwirx/ghex/internal/git"
+	"github.com/dwirx/ghex/internal/i18n"
+	"github.com/dwirx/ghex/internal/shell"
 	"github.com/dwirx/ghex/internal/ui"
 )
 
@@ -16,42 +19,42 @@ func runInteractive() {
 
 	cfg, err := config.Load()
 	if err != nil {
-		ui.ShowError(fmt.Sprintf("Failed to load config: %v", err))
+		ui.ShowError(i18n.T("Failed to load config: %v", err))
 		return
 	}
 
 	for {
 		showRepositoryContext(cfg)
 
-		items := []ui.SelectorItem{
-			{Title: "🔄 Switch account", Description: "Switch account for current repository", Value: "switch"},
-			{Title: "📋 List accounts", Description: "Show all configured accounts", Value: "list"},
-			{Title: "➕ Add account", Description: "Add a new GitHub account", Value: "add"},
-			{Title: "✏️  Edit account", Description: "Modify an existing account", Value: "edit"},
-			{Title: "🗑️  Remove account", Description: "Delete an account", Value: "remove"},
-			{Title: "🔑 SSH Management", Description: "Generate, import, or manage SSH keys", Value: "ssh"},
-			{Title: "🌐 Switch SSH globally", Description: "Change global SSH configuration", Value: "globalssh"},
-			{Title: "📥 Download (dlx)", Description: "Download files from URLs or Git repos", Value: "dlx"},
-			{Title: "🧪 Test connection", Description: "Test SSH/Token authentication", Value: "test"},
-			{Title: "🏥 Health check", Description: "Check all account connections", Value: "health"},
-			{Title: "📜 Activity log", Description: "View recent activity", Value: "log"},
-			{Title: "🚪 Exit", Description: "Quit GHEX", Value: "exit"},
-		}
+		items := mainMenuItems(cfg)
+		// Recent commands go first so repeating one takes a single keystroke
+		items = append(recentActionItems(3), items...)
 
-		idx, err := ui.RunSelector("Main Menu (↑/k ↓/j navigate, enter/l select, q quit)", items)
+		idx, err := ui.RunSelector(i18n.T("Main Menu (↑/k ↓/j navigate, enter/l select, q quit)"), items)
 		if err != nil || idx < 0 {
 			ui.ShowSeparator()
-			ui.ShowSuccess("Thank you for using GHEX! 👋")
+			ui.ShowSuccess(i18n.T("Thank you for using GHEX! 👋"))
 			return
 		}
 
 		fmt.Println()
 
+		if n, ok := strings.CutPrefix(items[idx].Value, "redo:"); ok {
+			if i, _ := strconv.Atoi(n); i > 0 {
+				repeatCommand(i)
+			}
+		}
+		if cfg != nil {
+			if entry, ok := cfg.Menu.CustomEntry(items[idx].Value); ok {
+				runCustomMenuEntry(entry)
+			}
+		}
+
 		switch items[idx].Value {
 		case "switch":
-			runSwitch()
+			runSwitch("")
 		case "list":
-			runList()
+			runList(false)
 		case "add":
 			runAddAccount(cfg)
 		case "edit":
@@ -65,31 +68,90 @@ func runInteractive() {
 		case "dlx":
 			runDlxMenu()
 		case "test":
-			runTestConnection(cfg)
+			runTestConnection(cfg, SSHTestOptions{})
 		case "health":
 			runHealthCheck()
 		case "log":
 			runActivityLog()
 		case "exit":
 			ui.ShowSeparator()
-			ui.ShowSuccess("Thank you for using GHEX! 👋")
+			ui.ShowSuccess(i18n.T("Thank you for using GHEX! 👋"))
 			return
 		}
 
 		fmt.Println()
-		ui.Prompt("Press Enter to continue...")
+		ui.Prompt(i18n.T("Press Enter to continue..."))
 
 		// Reload config in case it changed
 		cfg, _ = config.Load()
 	}
 }
 
+// mainMenuItems returns the main menu entries, with the custom entries,
+// hiding and ordering from the "menu" config section applied
+func mainMenuItems(cfg *config.AppConfig) []ui.SelectorItem {
+	items := []ui.SelectorItem{
+		{Title: i18n.T("🔄 Switch account"), Description: i18n.T("Switch account for current repository"), Value: "switch"},
+		{Title: i18n.T("📋 List accounts"), Description: i18n.T("Show all configured accounts"), Value: "list"},
+		{Title: i18n.T("➕ Add account"), Description: i18n.T("Add a new GitHub account"), Value: "add"},
+		{Title: i18n.T("✏️  Edit account"), Description: i18n.T("Modify an existing account"), Value: "edit"},
+		{Title: i18n.T("🗑️  Remove account"), Description: i18n.T("Delete an account"), Value: "remove"},
+		{Title: i18n.T("🔑 SSH Management"), Description: i18n.T("Generate, import, or manage SSH keys"), Value: "ssh"},
+		{Title: i18n.T("🌐 Switch SSH globally"), Description: i18n.T("Change global SSH configuration"), Value: "globalssh"},
+		{Title: i18n.T("📥 Download (dlx)"), Description: i18n.T("Download files from URLs or Git repos"), Value: "dlx"},
+		{Title: i18n.T("🧪 Test connection"), Description: i18n.T("Test SSH/Token authentication"), Value: "test"},
+		{Title: i18n.T("🏥 Health check"), Description: i18n.T("Check all account connections"), Value: "health"},
+		{Title: i18n.T("📜 Activity log"), Description: i18n.T("View recent activity"), Value: "log"},
+		{Title: i18n.T("🚪 Exit"), Description: i18n.T("Quit GHEX"), Value: "exit"},
+	}
+	if cfg == nil || cfg.Menu == nil {
+		return items
+	}
+
+	byKey := make(map[string]ui.SelectorItem, len(items)+len(cfg.Menu.Custom))
+	var keys []string
+	for _, item := range items[:len(items)-1] {
+		byKey[item.Value] = item
+		keys = append(keys, item.Value)
+	}
+	for _, e := range cfg.Menu.Custom {
+		// Entries without a command, or whose key a built-in uses, are skipped
+		if _, taken := byKey[e.Key]; taken || e.Key == "exit" || e.Key == "" || e.Command == "" {
+			continue
+		}
+		description := e.Description
+		if description == "" {
+			description = e.Command
+		}
+		byKey[e.Key] = ui.SelectorItem{Title: e.Title, Description: description, Value: e.Key}
+		keys = append(keys, e.Key)
+	}
+	exit := items[len(items)-1]
+	byKey[exit.Value] = exit
+	keys = append(keys, exit.Value)
+
+	arranged := make([]ui.SelectorItem, 0, len(keys))
+	for _, k := range cfg.Menu.Arrange(keys) {
+		arranged = append(arranged, byKey[k])
+	}
+	return arranged
+}
+
+// runCustomMenuEntry runs the shell command of a custom main menu entry
+func runCustomMenuEntry(e config.MenuEntry) {
+	ui.ShowInfo(i18n.T("Running: %s", e.Command))
+	fmt.Println()
+	if err := shell.RunCommandLine(e.Command); err != nil {
+		ui.ShowError(i18n.T("Command failed: %v", err))
+	}
+}
+
 func showRepositoryContext(cfg *config.AppConfig) {
 	cwd, _ := os.Getwd()
 
 	if !git.IsGitRepo(cwd) {
 		ui.ShowBox(ui.Muted("Run ghex inside a Git repository to see active account details."), ui.BoxOptions{
-			Title: "Repository Context",
+			Title: i18n.T("Repository Context"),
 			Type:  "info",
 		})
 		return
@@ -123,7 +185,7 @@ func showRepositoryContext(cfg *config.AppConfig) {
 	}
 
 	ui.ShowBox(strings.Join(lines, "\n"), ui.BoxOptions{
-		Title: "Repository Context",
+		Title: i18n.T("Repository Context"),
 		Type:  boxType,
 	})
 }
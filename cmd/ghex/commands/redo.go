@@ -0,0 +1,155 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dwirx/ghex/internal/history"
+	"github.com/dwirx/ghex/internal/i18n"
+	"github.com/dwirx/ghex/internal/shell"
+	"github.com/dwirx/ghex/internal/ui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// notRecorded lists commands that are not worth repeating
+var notRecorded = map[string]bool{
+	"redo":       true,
+	"help":       true,
+	"version":    true,
+	"completion": true,
+}
+
+// NewRedoCmd creates the redo command
+func NewRedoCmd() *cobra.Command {
+	var list bool
+
+	cmd := &cobra.Command{
+		Use:   "redo [n]",
+		Short: i18n.T("Repeat a recent command"),
+		Long: `Run a recent ghex command again. Without an argument the last command
+is repeated; n picks the n-th most recent one shown by --list.
+
+Commands run with a token, password or secret flag are not recorded.
+
+Examples:
+  ghex redo
+  ghex redo --list
+  ghex redo 3`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if list {
+				runRedoList()
+				return
+			}
+			n := 1
+			if len(args) == 1 {
+				var err error
+				if n, err = strconv.Atoi(args[0]); err != nil || n < 1 {
+					ui.ShowError(i18n.T("Invalid number: %s", args[0]))
+					return
+				}
+			}
+			runRedo(n)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&list, "list", "l", false, "List recent commands")
+
+	return cmd
+}
+
+func runRedoList() {
+	entries := history.Recent(0)
+	if len(entries) == 0 {
+		ui.ShowInfo(i18n.T("No recent commands"))
+		return
+	}
+
+	ui.ShowSection(i18n.T("Recent Commands"))
+	for i, e := range entries {
+		fmt.Printf("  %2d. %s  %s\n", i+1, e.Command(), ui.Muted(e.At.Local().Format("2006-01-02 15:04")))
+	}
+}
+
+// runRedo repeats the n-th most recent command and exits with its status
+func runRedo(n int) {
+	if code := repeatCommand(n); code != 0 {
+		os.Exit(code)
+	}
+}
+
+// repeatCommand runs the n-th most recent command line in a new ghex
+// process and returns its exit code
+func repeatCommand(n int) int {
+	entries := history.Recent(n)
+	if len(entries) < n {
+		ui.ShowError(i18n.T("No recent command #%d (see 'ghex redo --list')", n))
+		return 1
+	}
+	e := entries[n-1]
+
+	self, err := os.Executable()
+	if err != nil {
+		self = "ghex"
+	}
+	ui.ShowInfo(i18n.T("Running: %s", e.Command()))
+	fmt.Println()
+
+	if err := shell.RunInteractive(self, e.Args...); err != nil {
+		if code := shell.GetExitCode(err); code > 0 {
+			return code
+		}
+		ui.ShowError(err.Error())
+		return 1
+	}
+	return 0
+}
+
+// recordHistory remembers a successful command line for `ghex redo`.
+// The interactive menu, help, config set (whose values can be secrets) and
+// commands given secrets are skipped; header and proxy values are redacted
+// by history.Record.
+func recordHistory(cmd *cobra.Command, args []string) {
+	if len(args) == 0 || !cmd.HasParent() || cmd.Hidden {
+		return
+	}
+	if cmd.Name() == "set" && cmd.Parent().Name() == "config" {
+		return
+	}
+	for c := cmd; c.HasParent(); c = c.Parent() {
+		if notRecorded[c.Name()] || strings.HasPrefix(c.Name(), "__") {
+			return
+		}
+	}
+
+	secret := false
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		name := strings.ToLower(f.Name)
+		if name == "help" || strings.Contains(name, "token") || strings.Contains(name, "password") || strings.Contains(name, "secret") {
+			secret = true
+		}
+	})
+	if secret {
+		return
+	}
+
+	// History is a convenience; failing to save it is not an error
+	_ = history.Record(args)
+}
+
+// recentActionItems returns selector items for the last few commands, for
+// the interactive main menu
+func recentActionItems(limit int) []ui.SelectorItem {
+	var items []ui.SelectorItem
+	for i, e := range history.Recent(limit) {
+		items = append(items, ui.SelectorItem{
+			Title:       "↺ " + e.Command(),
+			Description: i18n.T("Recent action"),
+			Value:       "redo:" + strconv.Itoa(i+1),
+		})
+	}
+	return items
+}
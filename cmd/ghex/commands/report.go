@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dwirx/ghex/internal/config"
+	"github.com/dwirx/ghex/internal/i18n"
+	"github.com/dwirx/ghex/internal/offline"
+	"github.com/dwirx/ghex/internal/platform"
+	"github.com/dwirx/ghex/internal/report"
+	"github.com/dwirx/ghex/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// NewReportCmd creates the report command
+func NewReportCmd() *cobra.Command {
+	var format, output string
+
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: i18n.T("Export an inventory of accounts, SSH keys and tokens"),
+		Long: `Export an inventory of the configured accounts for security audits: the
+git identity and platform of each account, its SSH key (type, SHA256
+fingerprint, age and whether it is registered on the platform) and its
+token (whether it is valid, its scopes and expiry where the platform
+reports them). Token secrets are never written.
+
+Tokens and keys are checked with the platform APIs; --offline, or no
+network, only reads the local files. Key ages come from the key file's
+modification time. GitHub reports scopes and expiry for classic tokens,
+GitLab for all personal access tokens.
+
+Examples:
+  ghex report
+  ghex report --format json --output inventory.json
+  ghex report --format csv --offline > inventory.csv`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if !runReport(format, output, offline.Enabled()) {
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "f", "markdown", "Output format: "+strings.Join(report.Formats, ", "))
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write the report to this file instead of stdout")
+
+	return cmd
+}
+
+// runReport collects the inventory and writes it to output, or stdout when
+// empty. It returns false on failure.
+func runReport(format, output string, skipNetwork bool) bool {
+	cfg, err := config.Load()
+	if err != nil {
+		ui.ShowError(i18n.T("Failed to load config: %v", err))
+		return false
+	}
+
+	// Check the format before the slow platform calls
+	if err := report.CheckFormat(format); err != nil {
+		ui.ShowError(err.Error())
+		return false
+	}
+
+	if output == "" {
+		// Progress output would end up in the report
+		r := report.Collect(cfg, !skipNetwork, time.Now())
+		if err := report.Write(os.Stdout, r, format); err != nil {
+			ui.ShowError(err.Error())
+			return false
+		}
+		return true
+	}
+
+	spinner := ui.NewSpinner(i18n.T("Collecting the inventory of %d accounts...", len(cfg.Accounts)))
+	spinner.Start()
+	r := report.Collect(cfg, !skipNetwork, time.Now())
+	spinner.Stop()
+
+	var buf bytes.Buffer
+	if err := report.Write(&buf, r, format); err != nil {
+		ui.ShowError(err.Error())
+		return false
+	}
+	path := platform.ExpandPath(output)
+	// The inventory names keys and accounts, keep it private
+	if err := os.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		ui.ShowError(i18n.T("Failed to write %s: %v", path, err))
+		return false
+	}
+	ui.ShowSuccess(i18n.T("Report written to %s", path))
+	return true
+}
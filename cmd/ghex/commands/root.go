@@ -4,8 +4,14 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 
+	"github.com/dwirx/ghex/internal/config"
+	"github.com/dwirx/ghex/internal/i18n"
+	"github.com/dwirx/ghex/internal/offline"
+	"github.com/dwirx/ghex/internal/platform"
+	"github.com/dwirx/ghex/internal/ssh"
 	"github.com/dwirx/ghex/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -17,23 +23,73 @@ var Version = "0.0.3"
 func NewRootCmd() *cobra.Command {
 	rootCmd := &cobra.Command{
 		Use:   "ghex",
-		Short: "Beautiful GitHub Account Switcher & Universal Downloader",
+		Short: i18n.T("Beautiful GitHub Account Switcher & Universal Downloader"),
 		Long:  "GHEX - Interactive CLI tool for managing multiple GitHub accounts per repository with universal download capabilities",
 		Run: func(cmd *cobra.Command, args []string) {
 			runInteractive()
 		},
 	}
 
+	// Bare repositories and dotfile setups (git --git-dir=... --work-tree=...)
+	var gitDir, workTree string
+	rootCmd.PersistentFlags().StringVar(&gitDir, "git-dir", "", "Path to the repository (.git or bare) to operate on")
+	rootCmd.PersistentFlags().StringVar(&workTree, "work-tree", "", "Path to the working tree (with --git-dir)")
+
+	// Without a network, update checks, API calls and connection tests are
+	// skipped instead of timing out. Detected when github.com can't be
+	// resolved; GHEX_OFFLINE=0 turns detection off.
+	var offlineMode bool
+	rootCmd.PersistentFlags().BoolVar(&offlineMode, "offline", false, "Skip everything that needs the network")
+
+	// For CI pipelines and cron jobs: --non-interactive answers prompts with
+	// their default, fails where an answer is needed and prints plain
+	// line-by-line output; --quiet leaves only warnings, errors and results
+	var quiet, nonInteractive bool
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Print only warnings, errors and results; no spinners or progress bars")
+	rootCmd.PersistentFlags().BoolVar(&nonInteractive, "non-interactive", false, "Never prompt: take default answers and fail where input is needed")
+	cobra.OnInitialize(func() {
+		setGitEnv("GIT_DIR", gitDir)
+		setGitEnv("GIT_WORK_TREE", workTree)
+		offline.Set(offlineMode)
+		ui.SetQuiet(quiet)
+		if nonInteractive {
+			ui.SetNonInteractive(true)
+			// Nor may git stop to ask for credentials
+			_ = os.Setenv("GIT_TERMINAL_PROMPT", "0")
+			// Spinners and progress redraws make unreadable logs
+			ui.SetAccessible(true)
+		}
+	})
+
 	// Add all subcommands
 	rootCmd.AddCommand(NewVersionCmd())
 	rootCmd.AddCommand(NewStatusCmd())
+	rootCmd.AddCommand(NewWhoamiCmd())
+	rootCmd.AddCommand(NewVerifyCmd())
 	rootCmd.AddCommand(NewListCmd())
+	rootCmd.AddCommand(NewFavoriteCmd())
+	rootCmd.AddCommand(NewSortOrderCmd())
+	rootCmd.AddCommand(NewLanguageCmd())
+	rootCmd.AddCommand(NewAccessibleCmd())
 	rootCmd.AddCommand(NewSwitchCmd())
+	rootCmd.AddCommand(NewWorkspaceCmd())
+	rootCmd.AddCommand(NewBackupCmd())
+	rootCmd.AddCommand(NewInboxCmd())
+	rootCmd.AddCommand(newGhPassthroughCmd("pr"))
+	rootCmd.AddCommand(newGhPassthroughCmd("issue"))
 	rootCmd.AddCommand(NewHealthCmd())
+	rootCmd.AddCommand(NewReportCmd())
+	rootCmd.AddCommand(NewGenerateCmd())
+	rootCmd.AddCommand(NewCleanupCmd())
+	rootCmd.AddCommand(NewDoctorCmd())
 	rootCmd.AddCommand(NewLogCmd())
+	rootCmd.AddCommand(NewRedoCmd())
+	rootCmd.AddCommand(NewAliasCmd())
+	rootCmd.AddCommand(NewConfigCmd())
 	rootCmd.AddCommand(NewAddCmd())
 	rootCmd.AddCommand(NewRemoveCmd())
 	rootCmd.AddCommand(NewEditCmd())
+	rootCmd.AddCommand(NewCloneCmd())
 
 	// SSH commands
 	rootCmd.AddCommand(NewSSHCmd())
@@ -42,11 +98,15 @@ func NewRootCmd() *cobra.Command {
 
 	// Download commands (dlx)
 	rootCmd.AddCommand(NewDlxCmd())
+	rootCmd.AddCommand(NewOutdatedCmd())
+	rootCmd.AddCommand(NewUpgradeCmd())
+	rootCmd.AddCommand(NewInstallCmd())
 
 	// Update command
 	rootCmd.AddCommand(NewUpdateCmd())
 
-	// Uninstall command
+	// Install/uninstall commands
+	rootCmd.AddCommand(NewInstallSelfCmd())
 	rootCmd.AddCommand(NewUninstallCmd())
 
 	// Git shortcuts
@@ -55,6 +115,18 @@ func NewRootCmd() *cobra.Command {
 	return rootCmd
 }
 
+// setGitEnv exports a --git-dir/--work-tree flag so every git command ghex
+// runs uses it, regardless of the directory it runs in
+func setGitEnv(key, path string) {
+	if path == "" {
+		return
+	}
+	if abs, err := filepath.Abs(platform.ExpandPath(path)); err == nil {
+		path = abs
+	}
+	_ = os.Setenv(key, path)
+}
+
 // Execute runs the root command
 func Execute() {
 	// Handle Ctrl+C gracefully
@@ -63,27 +135,83 @@ func Execute() {
 	go func() {
 		<-c
 		fmt.Println()
-		ui.ShowSuccess("Thank you for using GHEX! 👋")
+		ui.ShowSuccess(i18n.T("Thank you for using GHEX! 👋"))
 		os.Exit(0)
 	}()
 
+	// Pick the message language before building commands, since their
+	// descriptions are translated as they are created
+	settings := config.NewAppConfig()
+	if cfg, err := config.Load(); err == nil {
+		settings = cfg
+	}
+	i18n.SetLanguage(i18n.Detect(settings.Language))
+	ui.SetAccessible(ui.DetectAccessible(settings.Accessible))
+	ssh.SetConfigMode(settings.SSHConfigMode)
+
 	rootCmd := NewRootCmd()
 
+	args, err := expandAlias(rootCmd, os.Args[1:], settings.Aliases)
+	if err != nil {
+		ui.ShowError(err.Error())
+		os.Exit(1)
+	}
+	rootCmd.SetArgs(args)
+	warnConfigPermissions(rootCmd, args)
+
 	// Handle URL arguments for clone
-	if len(os.Args) > 1 {
-		arg := os.Args[1]
+	if len(args) > 0 {
+		arg := args[0]
 		if isGitURL(arg) {
 			targetDir := ""
-			if len(os.Args) > 2 {
-				targetDir = os.Args[2]
+			if len(args) > 1 {
+				targetDir = args[1]
 			}
-			runClone(arg, targetDir)
+			runClone(arg, targetDir, "")
 			return
 		}
 	}
 
-	if err := rootCmd.Execute(); err != nil {
+	cmd, err := rootCmd.ExecuteC()
+	if err != nil {
 		ui.ShowError(err.Error())
 		os.Exit(1)
 	}
+	if ui.InputRefused() {
+		// Most commands treat a refused prompt like a cancelled one, which
+		// must not pass for success in a pipeline
+		ui.ShowError(i18n.T("Input was needed but --non-interactive is set; pass the missing values as arguments or flags"))
+		os.Exit(1)
+	}
+	recordHistory(cmd, args)
+}
+
+// warnConfigPermissions warns on stderr when other users can read the
+// config. Completion scripts and JSON output are left alone, since they
+// are read by shells and scripts.
+func warnConfigPermissions(rootCmd *cobra.Command, args []string) {
+	if len(config.GetManager().PermissionIssues()) == 0 {
+		return
+	}
+	cmd, _, err := rootCmd.Find(args)
+	if err != nil {
+		return
+	}
+	for c := cmd; c != nil; c = c.Parent() {
+		switch c.Name() {
+		case "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+			return
+		}
+	}
+	for _, arg := range args {
+		switch arg {
+		case "--json", "--json=true", "--ci", "--ci=true":
+			return
+		}
+	}
+
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	ui.ShowWarning(i18n.T("The config holds tokens but other users can read it; run 'ghex health' to fix"))
+	os.Stdout = stdout
 }
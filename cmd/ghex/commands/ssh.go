@@ -2,11 +2,18 @@ package commands
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/dwirx/ghex/internal/account"
 	"github.com/dwirx/ghex/internal/config"
+	"github.com/dwirx/ghex/internal/dotfiles"
+	"github.com/dwirx/ghex/internal/git"
+	"github.com/dwirx/ghex/internal/i18n"
 	"github.com/dwirx/ghex/internal/platform"
 	"github.com/dwirx/ghex/internal/ssh"
 	"github.com/dwirx/ghex/internal/ui"
@@ -17,7 +24,7 @@ import (
 func NewGlobalSSHCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "global-ssh",
-		Short: "Switch SSH globally",
+		Short: i18n.T("Switch SSH globally"),
 		Long:  "Change global SSH configuration for github.com or other platforms",
 		Run: func(cmd *cobra.Command, args []string) {
 			cfg, _ := config.Load()
@@ -28,22 +35,44 @@ func NewGlobalSSHCmd() *cobra.Command {
 
 // NewTestCmd creates the test connection command
 func NewTestCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "test",
-		Short: "Test SSH/Token connection",
-		Long:  "Test SSH key or token authentication for an account",
+	return newTestConnectionCmd("test", "Test SSH/Token connection")
+}
+
+// newTestConnectionCmd creates a connection test command with --port and
+// --verbose
+func newTestConnectionCmd(use, short string) *cobra.Command {
+	var opts SSHTestOptions
+
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Long: `Test SSH key or token authentication for an account.
+
+--port tests SSH against a non-default port. --verbose runs ssh with -vvv
+and shows the handshake log (collapsed, with the key lines highlighted),
+which is usually what diagnosing enterprise SSH failures needs.
+
+Examples:
+  ghex ssh test
+  ghex ssh test --port 2222
+  ghex ssh test --verbose`,
 		Run: func(cmd *cobra.Command, args []string) {
 			cfg, _ := config.Load()
-			runTestConnection(cfg)
+			runTestConnection(cfg, opts)
 		},
 	}
+
+	cmd.Flags().IntVarP(&opts.Port, "port", "p", 0, "SSH port to test (default: account's port or 22)")
+	cmd.Flags().BoolVarP(&opts.Verbose, "verbose", "v", false, "Capture the ssh -vvv handshake log")
+
+	return cmd
 }
 
 // NewSSHCmd creates the SSH command group
 func NewSSHCmd() *cobra.Command {
 	sshCmd := &cobra.Command{
 		Use:   "ssh",
-		Short: "SSH key management",
+		Short: i18n.T("SSH key management"),
 		Run: func(cmd *cobra.Command, args []string) {
 			cfg, _ := config.Load()
 			runSSHMenu(cfg)
@@ -52,62 +81,352 @@ func NewSSHCmd() *cobra.Command {
 
 	sshCmd.AddCommand(&cobra.Command{
 		Use:   "generate",
-		Short: "Generate a new SSH key",
+		Short: i18n.T("Generate a new SSH key"),
 		Run: func(cmd *cobra.Command, args []string) {
 			cfg, _ := config.Load()
 			runGenerateSSHKey(cfg)
 		},
 	})
 
+	sshCmd.AddCommand(newSSHImportCmd())
+
+	sshCmd.AddCommand(newTestConnectionCmd("test", "Test SSH connection"))
+
+	sshCmd.AddCommand(newSSHExportCmd())
+
+	sshCmd.AddCommand(newSSHRekeyCommentCmd())
+
+	sshCmd.AddCommand(newSSHDevicesCmd())
+
 	sshCmd.AddCommand(&cobra.Command{
-		Use:   "import",
-		Short: "Import an existing SSH key",
+		Use:   "global",
+		Short: i18n.T("Switch SSH globally"),
 		Run: func(cmd *cobra.Command, args []string) {
 			cfg, _ := config.Load()
-			runImportSSHKey(cfg)
+			runSwitchGlobalSSH(cfg)
 		},
 	})
 
 	sshCmd.AddCommand(&cobra.Command{
-		Use:   "test",
-		Short: "Test SSH connection",
+		Use:   "list",
+		Short: i18n.T("List SSH keys"),
 		Run: func(cmd *cobra.Command, args []string) {
 			cfg, _ := config.Load()
-			runTestConnection(cfg)
+			runListSSHKeys(cfg)
 		},
 	})
 
 	sshCmd.AddCommand(&cobra.Command{
-		Use:   "global",
-		Short: "Switch SSH globally",
+		Use:   "config-mode [edit|include|print]",
+		Short: i18n.T("Set how ghex applies changes to ~/.ssh/config"),
+		Long: `Set how ghex applies SSH Host blocks:
+
+  edit     edit ~/.ssh/config in place (default)
+  include  write blocks to ~/.ssh/ghex.conf and leave ~/.ssh/config alone;
+           add "Include ~/.ssh/ghex.conf" at the top of ~/.ssh/config
+  print    write nothing and print each block for you to add yourself
+
+Use include or print when ~/.ssh/config is managed by another tool such
+as chezmoi or ansible. Without an argument, shows the current mode.
+
+Examples:
+  ghex ssh config-mode
+  ghex ssh config-mode include`,
+		Args: cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			cfg, _ := config.Load()
-			runSwitchGlobalSSH(cfg)
+			mode := ""
+			if len(args) == 1 {
+				mode = args[0]
+			}
+			runSSHConfigMode(mode)
 		},
 	})
 
+	sshCmd.AddCommand(newSSHConfigCmd())
+
 	sshCmd.AddCommand(&cobra.Command{
-		Use:   "list",
-		Short: "List SSH keys",
+		Use:   "fix-permissions",
+		Short: i18n.T("Fix permissions of every private key in ~/.ssh"),
+		Long: `Fix permissions of every private key in ~/.ssh, plus ~/.ssh itself and
+~/.ssh/config, so ssh does not refuse them.
+
+Keys are set to 600 (on native Windows, an ACL granting only you access).
+Keys that are already private are left alone. Testing a connection only
+fixes the key being tested; run this to fix the rest.
+
+Examples:
+  ghex ssh fix-permissions`,
 		Run: func(cmd *cobra.Command, args []string) {
-			runListSSHKeys()
+			runFixSSHPermissions()
 		},
 	})
 
 	return sshCmd
 }
 
+// newSSHExportCmd creates the public key export command
+func newSSHExportCmd() *cobra.Command {
+	var accountName string
+	var toClipboard, showQR bool
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: i18n.T("Print or copy an account's public key"),
+		Long: `Print the public key of an account's SSH key, ready to paste into your
+Git service. The .pub file is generated from the private key if missing.
+
+Without --account, the account active in the current repository is used,
+or you are asked to pick one. --qr shows the key as a QR code for moving
+it to a phone or a machine without a shared clipboard.
+
+Examples:
+  ghex ssh export
+  ghex ssh export --account work --clipboard
+  ghex ssh export -a work > work.pub
+  ghex ssh export -a work --qr`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, _ := config.Load()
+			runSSHExport(cfg, accountName, toClipboard, showQR)
+		},
+	}
+
+	cmd.Flags().StringVarP(&accountName, "account", "a", "", "Account whose key to export")
+	cmd.Flags().BoolVarP(&toClipboard, "clipboard", "c", false, "Copy the key to the clipboard instead of printing it")
+	cmd.Flags().BoolVar(&showQR, "qr", false, "Show the key as a QR code")
+
+	return cmd
+}
+
+// newSSHImportCmd creates the key import command
+func newSSHImportCmd() *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: i18n.T("Import an existing SSH key"),
+		Long: `Import an existing SSH key for an account: the key is copied into ~/.ssh
+under a name of your choice and set as the account's key.
+
+With --all, every private key in ~/.ssh that no account uses is taken in
+one guided pass. Each key gets an account proposed from its public key
+comment (matched against emails and usernames) or its filename, is renamed
+to id_<type>_<account> (e.g. id_ed25519_work), and gets a Host block for
+the account. The config is saved once at the end. Keys named in Host
+blocks you wrote yourself are copied instead of renamed.
+
+Examples:
+  ghex ssh import
+  ghex ssh import --all`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, _ := config.Load()
+			if all {
+				runImportAllSSHKeys(cfg)
+				return
+			}
+			runImportSSHKey(cfg)
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Scan ~/.ssh and assign every unused key to an account")
+
+	return cmd
+}
+
+// newSSHRekeyCommentCmd creates the key comment command
+func newSSHRekeyCommentCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rekey-comment <key> [comment]",
+		Short: i18n.T("Change the comment of an SSH key"),
+		Long: `Change the comment of an SSH key pair, e.g. to the email of the account
+it belongs to. The comment is what "ghex ssh import --all" matches keys to
+accounts by, and what Git services show as the key's default label.
+
+<key> is a key path, a file name in ~/.ssh, or an account name for the
+account's key. Without a comment, the email of the account using the key
+is proposed.
+
+ssh-keygen -c rewrites both key files (converting a PEM key to the OpenSSH
+format) and asks for the passphrase of a protected key. Without
+ssh-keygen only the .pub file is changed.
+
+Examples:
+  ghex ssh rekey-comment ~/.ssh/id_ed25519 me@work.example
+  ghex ssh rekey-comment id_rsa_old "old laptop"
+  ghex ssh rekey-comment work`,
+		Args: cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, _ := config.Load()
+			comment, hasComment := "", len(args) == 2
+			if hasComment {
+				comment = args[1]
+			}
+			if err := runSSHRekeyComment(cfg, args[0], comment, hasComment); err != nil {
+				ui.ShowError(err.Error())
+			}
+		},
+	}
+}
+
+func runSSHRekeyComment(cfg *config.AppConfig, keyArg, comment string, hasComment bool) error {
+	keyPath, err := resolveKeyArg(cfg, keyArg)
+	if err != nil {
+		return err
+	}
+	info, err := ssh.IdentifyKey(keyPath)
+	if err != nil {
+		return fmt.Errorf("failed to read key: %w", err)
+	}
+	if info.Encrypted && ui.NonInteractive() {
+		return fmt.Errorf("%s is protected by a passphrase, which can't be asked for without prompts", keyPath)
+	}
+
+	old := ssh.KeyComment(keyPath)
+	if !hasComment {
+		comment = ui.PromptWithDefault(i18n.T("New comment"), keyOwnerEmail(cfg, keyPath))
+		if comment == "" {
+			return fmt.Errorf("a comment is required")
+		}
+	}
+
+	private, err := ssh.SetKeyComment(keyPath, comment)
+	if err != nil {
+		return err
+	}
+	if old != "" {
+		ui.ShowSuccess(i18n.T("Comment of %s: %s → %s", keyPath, old, comment))
+	} else {
+		ui.ShowSuccess(i18n.T("Comment of %s: %s", keyPath, comment))
+	}
+	if !private {
+		ui.ShowWarning(i18n.T("ssh-keygen not found: only %s.pub was changed", keyPath))
+	}
+	return nil
+}
+
+// resolveKeyArg finds the private key an argument names: a path, a file in
+// ~/.ssh, or an account with an SSH key
+func resolveKeyArg(cfg *config.AppConfig, arg string) (string, error) {
+	if path := platform.ExpandPath(arg); platform.FileExists(path) {
+		return path, nil
+	}
+	if path := filepath.Join(platform.GetSSHDir(), arg); platform.FileExists(path) {
+		return path, nil
+	}
+	if cfg != nil {
+		for _, acc := range cfg.Accounts {
+			if strings.EqualFold(acc.Name, arg) && acc.SSH != nil && acc.SSH.KeyPath != "" {
+				return platform.ExpandPath(acc.SSH.KeyPath), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no key file or account with an SSH key named %q", arg)
+}
+
+// keyOwnerEmail returns the email of the first account using a key, or ""
+func keyOwnerEmail(cfg *config.AppConfig, keyPath string) string {
+	for _, name := range ssh.KeyUses(cfg, nil, []string{keyPath})[keyPath].Accounts {
+		for _, acc := range cfg.Accounts {
+			if acc.Name == name && acc.GitEmail != "" {
+				return acc.GitEmail
+			}
+		}
+	}
+	return ""
+}
+
+func runSSHExport(cfg *config.AppConfig, accountName string, toClipboard, showQR bool) {
+	acc := selectSSHAccount(cfg, accountName)
+	if acc == nil {
+		return
+	}
+
+	pub, err := ssh.ReadPublicKey(acc.SSH.KeyPath)
+	if err != nil {
+		ui.ShowError(err.Error())
+		return
+	}
+
+	if showQR {
+		if err := ui.ShowQR(pub); err != nil {
+			ui.ShowError(err.Error())
+			return
+		}
+		if !toClipboard {
+			return
+		}
+	}
+
+	if !toClipboard {
+		// Plain output so it can be redirected to a file
+		fmt.Println(pub)
+		return
+	}
+
+	if copyToClipboard(pub, fmt.Sprintf("public key for %s", acc.Name), true) {
+		ui.ShowInfo(i18n.T("Add it at: %s", GetPlatformInfo(acc).KeysURL))
+	}
+}
+
+// selectSSHAccount returns the named account, the account active in the
+// current repository, or one picked by the user; only accounts with SSH
+// configured qualify
+func selectSSHAccount(cfg *config.AppConfig, accountName string) *config.Account {
+	manager := account.NewManager(cfg)
+
+	if accountName != "" {
+		acc := manager.Find(accountName)
+		if acc == nil {
+			ui.ShowError(i18n.T("Account '%s' not found", accountName))
+			return nil
+		}
+		if acc.SSH == nil {
+			ui.ShowError(i18n.T("Account '%s' has no SSH configuration", accountName))
+			return nil
+		}
+		return acc
+	}
+
+	if cwd, err := os.Getwd(); err == nil {
+		if name, _ := manager.DetectActive(cwd); name != "" {
+			if acc := manager.Find(name); acc != nil && acc.SSH != nil {
+				return acc
+			}
+		}
+	}
+
+	sshAccounts := orderedAccounts(cfg, hasSSH)
+	switch len(sshAccounts) {
+	case 0:
+		ui.ShowWarning(i18n.T("No accounts with SSH configured"))
+		return nil
+	case 1:
+		return sshAccounts[0]
+	}
+
+	items := make([]ui.SelectorItem, len(sshAccounts))
+	for i, acc := range sshAccounts {
+		items[i] = ui.SelectorItem{Title: accountTitle(acc), Description: acc.SSH.KeyPath, Value: acc.Name}
+	}
+	idx, err := ui.RunSelector(i18n.T("Select Account"), items)
+	if err != nil || idx < 0 {
+		ui.ShowInfo(i18n.T("Cancelled"))
+		return nil
+	}
+	return sshAccounts[idx]
+}
+
 func runSSHMenu(cfg *config.AppConfig) {
 	items := []ui.SelectorItem{
-		{Title: "🔑 Generate SSH key", Description: "Create a new Ed25519 SSH key pair", Value: "generate"},
-		{Title: "📥 Import SSH key", Description: "Import an existing private key", Value: "import"},
-		{Title: "🌐 Switch SSH globally", Description: "Set default SSH key for github.com", Value: "global"},
-		{Title: "🧪 Test connection", Description: "Test SSH authentication", Value: "test"},
-		{Title: "📋 List SSH keys", Description: "Show all SSH keys in ~/.ssh", Value: "list"},
-		{Title: "🔙 Back", Description: "Return to main menu", Value: "back"},
+		{Title: i18n.T("🔑 Generate SSH key"), Description: i18n.T("Create a new Ed25519 SSH key pair"), Value: "generate"},
+		{Title: i18n.T("📥 Import SSH key"), Description: i18n.T("Import an existing private key"), Value: "import"},
+		{Title: i18n.T("🌐 Switch SSH globally"), Description: i18n.T("Set default SSH key for github.com"), Value: "global"},
+		{Title: i18n.T("🧪 Test connection"), Description: i18n.T("Test SSH authentication"), Value: "test"},
+		{Title: i18n.T("📋 List SSH keys"), Description: i18n.T("Show all SSH keys in ~/.ssh"), Value: "list"},
+		{Title: i18n.T("📤 Export public key"), Description: i18n.T("Print or copy an account's public key"), Value: "export"},
+		{Title: i18n.T("🔙 Back"), Description: i18n.T("Return to main menu"), Value: "back"},
 	}
 
-	idx, err := ui.RunSelector("SSH Management", items)
+	idx, err := ui.RunSelector(i18n.T("SSH Management"), items)
 	if err != nil || idx < 0 {
 		return
 	}
@@ -120,9 +439,11 @@ func runSSHMenu(cfg *config.AppConfig) {
 	case "global":
 		runSwitchGlobalSSH(cfg)
 	case "test":
-		runTestConnection(cfg)
+		runTestConnection(cfg, SSHTestOptions{})
 	case "list":
-		runListSSHKeys()
+		runListSSHKeys(cfg)
+	case "export":
+		runSSHExport(cfg, "", true, false)
 	case "back":
 		return
 	}
@@ -130,13 +451,14 @@ func runSSHMenu(cfg *config.AppConfig) {
 
 func runGenerateSSHKey(cfg *config.AppConfig) {
 	if len(cfg.Accounts) == 0 {
-		ui.ShowWarning("No accounts configured. Add an account first.")
+		ui.ShowWarning(i18n.T("No accounts configured. Add an account first."))
 		return
 	}
 
 	// Build items for selector
-	items := make([]ui.SelectorItem, len(cfg.Accounts))
-	for i, acc := range cfg.Accounts {
+	accounts := orderedAccounts(cfg, nil)
+	items := make([]ui.SelectorItem, len(accounts))
+	for i, acc := range accounts {
 		desc := ""
 		if acc.SSH != nil {
 			desc = acc.SSH.KeyPath
@@ -144,58 +466,58 @@ func runGenerateSSHKey(cfg *config.AppConfig) {
 			desc = "No SSH configured"
 		}
 		items[i] = ui.SelectorItem{
-			Title:       acc.Name,
+			Title:       accountTitle(acc),
 			Description: desc,
 			Value:       acc.Name,
 		}
 	}
 
-	idx, err := ui.RunSelector("Select Account for SSH Key Generation", items)
+	idx, err := ui.RunSelector(i18n.T("Select Account for SSH Key Generation"), items)
 	if err != nil {
-		ui.ShowError(fmt.Sprintf("Selection error: %v", err))
+		ui.ShowError(i18n.T("Selection error: %v", err))
 		return
 	}
 	if idx < 0 {
-		ui.ShowInfo("Cancelled")
+		ui.ShowInfo(i18n.T("Cancelled"))
 		return
 	}
 
-	acc := &cfg.Accounts[idx]
+	acc := accounts[idx]
 	if acc.SSH == nil {
-		ui.ShowWarning("Account has no SSH configuration")
+		ui.ShowWarning(i18n.T("Account has no SSH configuration"))
 		return
 	}
 
-	comment := acc.GitEmail
-	if comment == "" {
-		comment = acc.GitUserName
-	}
-	if comment == "" {
-		comment = fmt.Sprintf("%s@github", acc.Name)
-	}
+	comment := generatedKeyComment(acc)
 
 	fmt.Println()
-	spinner := ui.NewSpinner("Generating SSH key...")
+	spinner := ui.NewSpinner(i18n.T("Generating SSH key..."))
 	spinner.Start()
 
 	if err := ssh.GenerateKey(acc.SSH.KeyPath, comment); err != nil {
-		spinner.StopWithError(fmt.Sprintf("Failed to generate key: %v", err))
+		spinner.StopWithError(i18n.T("Failed to generate key: %v", err))
 		return
 	}
 
-	spinner.StopWithSuccess(fmt.Sprintf("Generated SSH key: %s", acc.SSH.KeyPath))
-	ui.ShowInfo(fmt.Sprintf("Public key: %s.pub", acc.SSH.KeyPath))
+	spinner.StopWithSuccess(i18n.T("Generated SSH key: %s", acc.SSH.KeyPath))
+	recordKeyDevice(acc, acc.SSH.KeyPath)
+	if err := config.Save(cfg); err != nil {
+		ui.ShowWarning(i18n.T("Failed to save config: %v", err))
+	}
+	ui.ShowInfo(i18n.T("Public key: %s.pub", acc.SSH.KeyPath))
+	ui.ShowInfo(i18n.T("Copy it with: ghex ssh export -a %s --clipboard", acc.Name))
 }
 
 func runImportSSHKey(cfg *config.AppConfig) {
 	if len(cfg.Accounts) == 0 {
-		ui.ShowWarning("No accounts configured. Add an account first.")
+		ui.ShowWarning(i18n.T("No accounts configured. Add an account first."))
 		return
 	}
 
 	// Build items for selector
-	items := make([]ui.SelectorItem, len(cfg.Accounts))
-	for i, acc := range cfg.Accounts {
+	accounts := orderedAccounts(cfg, nil)
+	items := make([]ui.SelectorItem, len(accounts))
+	for i, acc := range accounts {
 		desc := ""
 		if acc.SSH != nil {
 			desc = acc.SSH.KeyPath
@@ -203,23 +525,23 @@ func runImportSSHKey(cfg *config.AppConfig) {
 			desc = "No SSH configured"
 		}
 		items[i] = ui.SelectorItem{
-			Title:       acc.Name,
+			Title:       accountTitle(acc),
 			Description: desc,
 			Value:       acc.Name,
 		}
 	}
 
-	idx, err := ui.RunSelector("Select Account for SSH Key Import", items)
+	idx, err := ui.RunSelector(i18n.T("Select Account for SSH Key Import"), items)
 	if err != nil {
-		ui.ShowError(fmt.Sprintf("Selection error: %v", err))
+		ui.ShowError(i18n.T("Selection error: %v", err))
 		return
 	}
 	if idx < 0 {
-		ui.ShowInfo("Cancelled")
+		ui.ShowInfo(i18n.T("Cancelled"))
 		return
 	}
 
-	acc := &cfg.Accounts[idx]
+	acc := accounts[idx]
 
 	// Show existing SSH keys for selection
 	existingKeys, _ := ssh.ListPrivateKeys()
@@ -234,37 +556,37 @@ func runImportSSHKey(cfg *config.AppConfig) {
 			}
 		}
 		keyItems[len(existingKeys)] = ui.SelectorItem{
-			Title:       "📝 Enter custom path",
-			Description: "Type a new SSH key path",
+			Title:       i18n.T("📝 Enter custom path"),
+			Description: i18n.T("Type a new SSH key path"),
 			Value:       "__custom__",
 		}
 
-		keyIdx, err := ui.RunSelector("Select Source SSH Key", keyItems)
+		keyIdx, err := ui.RunSelector(i18n.T("Select Source SSH Key"), keyItems)
 		if err != nil || keyIdx < 0 {
-			ui.ShowInfo("Cancelled")
+			ui.ShowInfo(i18n.T("Cancelled"))
 			return
 		}
 
 		if keyItems[keyIdx].Value == "__custom__" {
-			srcPath = ui.Prompt("Source private key path")
+			srcPath = ui.Prompt(i18n.T("Source private key path"))
 		} else {
 			srcPath = keyItems[keyIdx].Value
 		}
 	} else {
-		srcPath = ui.Prompt("Source private key path")
+		srcPath = ui.Prompt(i18n.T("Source private key path"))
 	}
 
 	if srcPath == "" {
-		ui.ShowError("Source path is required")
+		ui.ShowError(i18n.T("Source path is required"))
 		return
 	}
 
-	destName := ui.PromptWithDefault("Destination filename", fmt.Sprintf("id_ed25519_%s", acc.Name))
+	destName := ui.PromptWithDefault(i18n.T("Destination filename"), fmt.Sprintf("id_ed25519_%s", acc.Name))
 	sshDir := platform.GetSSHDir()
 	destPath := filepath.Join(sshDir, destName)
 
 	if err := ssh.ImportKey(srcPath, destPath); err != nil {
-		ui.ShowError(fmt.Sprintf("Failed to import key: %v", err))
+		ui.ShowError(i18n.T("Failed to import key: %v", err))
 		return
 	}
 
@@ -274,31 +596,33 @@ func runImportSSHKey(cfg *config.AppConfig) {
 	acc.SSH.KeyPath = destPath
 
 	// Ask if user wants to set as default
-	if ui.Confirm("Set as default SSH key for github.com?") {
+	if ui.Confirm(i18n.T("Set as default SSH key for github.com?")) {
 		host := "github.com"
 		if acc.Platform != nil && acc.Platform.Domain != "" {
 			host = acc.Platform.Domain
 		}
 		if err := ssh.EnsureConfigBlock(host, destPath, host); err != nil {
-			ui.ShowWarning(fmt.Sprintf("Failed to configure SSH: %v", err))
+			ui.ShowWarning(i18n.T("Failed to configure SSH: %v", err))
 		} else {
-			ui.ShowSuccess(fmt.Sprintf("Set as default Host %s", host))
+			ui.ShowSuccess(i18n.T("Set as default Host %s", host))
 		}
 	}
 
+	pubPath, pubErr := ssh.EnsurePublicKey(destPath)
+	recordKeyDevice(acc, destPath)
+
 	if err := config.Save(cfg); err != nil {
-		ui.ShowWarning(fmt.Sprintf("Failed to save config: %v", err))
+		ui.ShowWarning(i18n.T("Failed to save config: %v", err))
 	}
 
-	ui.ShowSuccess(fmt.Sprintf("Imported SSH key: %s", destPath))
+	ui.ShowSuccess(i18n.T("Imported SSH key: %s", destPath))
 
-	pubPath, err := ssh.EnsurePublicKey(destPath)
-	if err == nil {
-		ui.ShowInfo(fmt.Sprintf("Public key: %s", pubPath))
+	if pubErr == nil {
+		ui.ShowInfo(i18n.T("Public key: %s", pubPath))
 	}
 
 	// Ask if user wants to test connection
-	if ui.Confirm("Test SSH connection now?") {
+	if !skipOffline(i18n.T("SSH connection test")) && ui.Confirm(i18n.T("Test SSH connection now?")) {
 		host := "github.com"
 		if acc.Platform != nil && acc.Platform.Domain != "" {
 			host = acc.Platform.Domain
@@ -307,14 +631,10 @@ func runImportSSHKey(cfg *config.AppConfig) {
 		// Expand destPath for testing
 		expandedDest := platform.ExpandPath(destPath)
 
-		// Auto-fix permissions for ALL keys
-		fixedCount, _ := ssh.FixAllKeyPermissions()
-		if fixedCount > 0 {
-			ui.ShowInfo(fmt.Sprintf("Fixed permissions for %d SSH key(s)", fixedCount))
-		}
+		fixKeyPermissions(expandedDest, true)
 
-		ui.ShowInfo(fmt.Sprintf("Testing with key: %s", destPath))
-		spinner := ui.NewSpinner(fmt.Sprintf("Testing SSH connection to %s...", host))
+		ui.ShowInfo(i18n.T("Testing with key: %s", destPath))
+		spinner := ui.NewSpinner(i18n.T("Testing SSH connection to %s...", host))
 		spinner.Start()
 
 		ok, msg, _ := ssh.TestConnectionWithKey(host, expandedDest)
@@ -322,31 +642,162 @@ func runImportSSHKey(cfg *config.AppConfig) {
 			spinner.StopWithSuccess(fmt.Sprintf("SSH: %s", msg))
 		} else {
 			spinner.StopWithError(fmt.Sprintf("SSH: %s", msg))
-			ui.ShowWarning("Make sure your SSH key is added to your Git service:")
-			ui.ShowInfo(fmt.Sprintf("1. Copy your public key: cat %s.pub", destPath))
-			ui.ShowInfo("2. Add it to your Git service settings")
+			ui.ShowWarning(i18n.T("Make sure your SSH key is added to your Git service:"))
+			showPublicKeyStep(destPath, acc.Name)
+			ui.ShowInfo(i18n.T("2. Add it to your Git service settings"))
 		}
 	}
 }
 
-func runSwitchGlobalSSH(cfg *config.AppConfig) {
+// runImportAllSSHKeys walks through the keys in ~/.ssh that no account
+// uses, assigning each to an account, normalizing its name and writing the
+// account's Host block. The config is saved once, after the last key.
+func runImportAllSSHKeys(cfg *config.AppConfig) {
 	if len(cfg.Accounts) == 0 {
-		ui.ShowWarning("No accounts configured")
+		ui.ShowWarning(i18n.T("No accounts configured. Add an account first."))
 		return
 	}
 
-	var sshAccounts []config.Account
-	for _, acc := range cfg.Accounts {
-		if acc.SSH != nil {
-			sshAccounts = append(sshAccounts, acc)
+	keys, err := ssh.ListKeyFiles()
+	if err != nil {
+		ui.ShowError(i18n.T("Failed to list SSH keys: %v", err))
+		return
+	}
+	blocks, _ := ssh.ListHostBlocks()
+	proposals := ssh.ProposeImports(cfg, blocks, keys)
+
+	sshDir := platform.GetSSHDir()
+	if len(proposals) == 0 {
+		ui.ShowSuccess(i18n.T("Every private key in %s is used by an account", sshDir))
+		return
+	}
+	ui.ShowInfo(i18n.T("Found %d keys in %s not used by any account", len(proposals), sshDir))
+
+	imported := 0
+	for i, p := range proposals {
+		fmt.Println()
+		ui.ShowInfo(fmt.Sprintf("[%d/%d] %s (%s)", i+1, len(proposals), p.Key.Path, p.Key.Label()))
+		if p.Comment != "" {
+			ui.ShowInfo(i18n.T("Comment: %s", p.Comment))
+		}
+		if len(p.Hosts) > 0 {
+			ui.ShowInfo(i18n.T("Used by Host: %s", strings.Join(p.Hosts, ", ")))
+		}
+
+		acc, ok := selectImportAccount(cfg, p)
+		if !ok {
+			ui.ShowInfo(i18n.T("Cancelled"))
+			break
+		}
+		if acc == nil {
+			continue
+		}
+		if acc.SSH != nil && acc.SSH.KeyPath != "" && platform.FileExists(platform.ExpandPath(acc.SSH.KeyPath)) &&
+			!ui.Confirm(i18n.T("%s already uses %s. Replace it?", acc.Name, acc.SSH.KeyPath)) {
+			continue
+		}
+
+		destName := ui.PromptWithDefault(i18n.T("Destination filename"), ssh.NormalizedKeyName(p.Key, acc.Name))
+		destPath := filepath.Join(sshDir, destName)
+		if destPath != p.Key.Path {
+			var err error
+			if len(p.Hosts) > 0 {
+				// Renaming would break the Host blocks naming the key
+				err = ssh.ImportKey(p.Key.Path, destPath)
+			} else {
+				err = ssh.RenameKey(p.Key.Path, destPath)
+			}
+			if err != nil {
+				ui.ShowWarning(i18n.T("Failed to import key: %v", err))
+				continue
+			}
+		}
+
+		if acc.SSH == nil {
+			acc.SSH = &config.SshConfig{}
+		}
+		acc.SSH.KeyPath = destPath
+		alias := dotfiles.SSHAlias(acc)
+		acc.SSH.HostAlias = alias
+		if _, err := ssh.EnsurePublicKey(destPath); err != nil {
+			ui.ShowWarning(i18n.T("Failed to generate public key: %v", err))
+		}
+		recordKeyDevice(acc, destPath)
+
+		platformType, domain := "github", ""
+		if acc.Platform != nil {
+			platformType, domain = acc.Platform.Type, acc.Platform.Domain
+		}
+		host := git.GetPlatformSSHHost(platformType, domain)
+		if err := ssh.EnsureConfigBlockWithOptions(alias, destPath, host, ssh.OptionsForAccount(acc)); err != nil {
+			ui.ShowWarning(i18n.T("Failed to configure SSH: %v", err))
+		}
+		ui.ShowSuccess(i18n.T("%s → %s (Host %s)", destPath, acc.Name, alias))
+		imported++
+	}
+
+	fmt.Println()
+	if imported == 0 {
+		ui.ShowInfo(i18n.T("No keys imported"))
+		return
+	}
+	if err := config.Save(cfg); err != nil {
+		ui.ShowError(i18n.T("Failed to save config: %v", err))
+		return
+	}
+	ui.ShowSuccess(i18n.T("Imported %d keys", imported))
+	ui.ShowInfo(i18n.T("Test them with: ghex ssh test"))
+}
+
+// selectImportAccount asks which account a key belongs to, the proposed
+// one first. It returns nil to skip the key and false when cancelled.
+func selectImportAccount(cfg *config.AppConfig, p ssh.ImportProposal) (*config.Account, bool) {
+	var accounts []*config.Account
+	var items []ui.SelectorItem
+	for _, acc := range orderedAccounts(cfg, nil) {
+		desc := i18n.T("No SSH configured")
+		if acc.SSH != nil && acc.SSH.KeyPath != "" {
+			desc = acc.SSH.KeyPath
 		}
+		item := ui.SelectorItem{Title: accountTitle(acc), Description: desc, Value: acc.Name}
+		if acc.Name == p.Account {
+			item.Description = i18n.T("Proposed: %s", i18n.T(p.Reason))
+			accounts = append([]*config.Account{acc}, accounts...)
+			items = append([]ui.SelectorItem{item}, items...)
+			continue
+		}
+		accounts = append(accounts, acc)
+		items = append(items, item)
+	}
+	items = append(items, ui.SelectorItem{
+		Title:       i18n.T("Skip this key"),
+		Description: i18n.T("Leave it unchanged"),
+		Value:       "__skip__",
+	})
+
+	idx, err := ui.RunSelector(i18n.T("Account for %s", filepath.Base(p.Key.Path)), items)
+	if err != nil || idx < 0 {
+		return nil, false
 	}
+	if idx == len(accounts) {
+		return nil, true
+	}
+	return accounts[idx], true
+}
+
+func runSwitchGlobalSSH(cfg *config.AppConfig) {
+	if len(cfg.Accounts) == 0 {
+		ui.ShowWarning(i18n.T("No accounts configured"))
+		return
+	}
+
+	sshAccounts := orderedAccounts(cfg, hasSSH)
 
 	if len(sshAccounts) == 0 {
 		// Show available SSH keys instead
 		keys, _ := ssh.ListPrivateKeys()
 		if len(keys) == 0 {
-			ui.ShowWarning("No SSH keys found")
+			ui.ShowWarning(i18n.T("No SSH keys found"))
 			return
 		}
 
@@ -358,28 +809,24 @@ func runSwitchGlobalSSH(cfg *config.AppConfig) {
 			}
 		}
 
-		idx, err := ui.RunSelector("Select SSH Key for Global Use", items)
+		idx, err := ui.RunSelector(i18n.T("Select SSH Key for Global Use"), items)
 		if err != nil || idx < 0 {
 			return
 		}
 
 		if err := ssh.EnsureConfigBlock("github.com", keys[idx], "github.com"); err != nil {
-			ui.ShowError(fmt.Sprintf("Failed to configure SSH: %v", err))
+			ui.ShowError(i18n.T("Failed to configure SSH: %v", err))
 			return
 		}
 
-		ui.ShowSuccess(fmt.Sprintf("Set global SSH to: %s", keys[idx]))
+		ui.ShowSuccess(i18n.T("Set global SSH to: %s", keys[idx]))
 
 		// Ask to test connection
-		if ui.Confirm("Test SSH connection now?") {
-			// Auto-fix permissions for ALL keys
-			fixedCount, _ := ssh.FixAllKeyPermissions()
-			if fixedCount > 0 {
-				ui.ShowInfo(fmt.Sprintf("Fixed permissions for %d SSH key(s)", fixedCount))
-			}
+		if !skipOffline(i18n.T("SSH connection test")) && ui.Confirm(i18n.T("Test SSH connection now?")) {
+			fixKeyPermissions(keys[idx], true)
 
-			ui.ShowInfo(fmt.Sprintf("Testing with key: %s", keys[idx]))
-			spinner := ui.NewSpinner("Testing SSH connection to github.com...")
+			ui.ShowInfo(i18n.T("Testing with key: %s", keys[idx]))
+			spinner := ui.NewSpinner(i18n.T("Testing SSH connection to github.com..."))
 			spinner.Start()
 
 			ok, msg, _ := ssh.TestConnectionWithKey("github.com", keys[idx])
@@ -387,9 +834,9 @@ func runSwitchGlobalSSH(cfg *config.AppConfig) {
 				spinner.StopWithSuccess(fmt.Sprintf("SSH: %s", msg))
 			} else {
 				spinner.StopWithError(fmt.Sprintf("SSH: %s", msg))
-				ui.ShowWarning("Make sure your SSH key is added to GitHub:")
-				ui.ShowInfo(fmt.Sprintf("1. Copy your public key: cat %s.pub", keys[idx]))
-				ui.ShowInfo("2. Add it at: https://github.com/settings/keys")
+				ui.ShowWarning(i18n.T("Make sure your SSH key is added to GitHub:"))
+				showPublicKeyStep(keys[idx], "")
+				ui.ShowInfo(i18n.T("2. Add it at: https://github.com/settings/keys"))
 			}
 		}
 		return
@@ -412,23 +859,23 @@ func runSwitchGlobalSSH(cfg *config.AppConfig) {
 			}
 		}
 		items[i] = ui.SelectorItem{
-			Title:       acc.Name,
+			Title:       accountTitle(acc),
 			Description: fmt.Sprintf("%s • %s", platformName, acc.SSH.KeyPath),
 			Value:       acc.Name,
 		}
 	}
 
-	idx, err := ui.RunSelector("Select Account for Global SSH", items)
+	idx, err := ui.RunSelector(i18n.T("Select Account for Global SSH"), items)
 	if err != nil {
-		ui.ShowError(fmt.Sprintf("Selection error: %v", err))
+		ui.ShowError(i18n.T("Selection error: %v", err))
 		return
 	}
 	if idx < 0 {
-		ui.ShowInfo("Cancelled")
+		ui.ShowInfo(i18n.T("Cancelled"))
 		return
 	}
 
-	acc := sshAccounts[idx]
+	acc := *sshAccounts[idx]
 
 	// Get platform-specific host
 	host := "github.com"
@@ -463,113 +910,98 @@ func runSwitchGlobalSSH(cfg *config.AppConfig) {
 	expandedPath := platform.ExpandPath(keyPath)
 
 	if _, err := os.Stat(expandedPath); os.IsNotExist(err) {
-		if ui.Confirm(fmt.Sprintf("SSH key not found at %s. Generate now?", keyPath)) {
-			comment := acc.GitEmail
-			if comment == "" {
-				comment = acc.GitUserName
-			}
-			if comment == "" {
-				platformType := "github"
-				if acc.Platform != nil {
-					platformType = acc.Platform.Type
-				}
-				comment = fmt.Sprintf("%s@%s", acc.Name, platformType)
-			}
-
-			spinner := ui.NewSpinner("Generating SSH key...")
+		if ui.Confirm(i18n.T("SSH key not found at %s. Generate now?", keyPath)) {
+			spinner := ui.NewSpinner(i18n.T("Generating SSH key..."))
 			spinner.Start()
 
-			if err := ssh.GenerateKey(keyPath, comment); err != nil {
-				spinner.StopWithError(fmt.Sprintf("Failed to generate key: %v", err))
+			if err := ssh.GenerateKey(keyPath, generatedKeyComment(&acc)); err != nil {
+				spinner.StopWithError(i18n.T("Failed to generate key: %v", err))
 				return
 			}
-			spinner.StopWithSuccess(fmt.Sprintf("Generated SSH key: %s", keyPath))
+			spinner.StopWithSuccess(i18n.T("Generated SSH key: %s", keyPath))
+			recordKeyDevice(sshAccounts[idx], keyPath)
+			if err := config.Save(cfg); err != nil {
+				ui.ShowWarning(i18n.T("Failed to save config: %v", err))
+			}
 		} else {
-			ui.ShowInfo("Aborted")
+			ui.ShowInfo(i18n.T("Aborted"))
 			return
 		}
 	}
 
 	fmt.Println()
-	if err := ssh.EnsureConfigBlock(host, keyPath, host); err != nil {
-		ui.ShowError(fmt.Sprintf("Failed to configure SSH: %v", err))
+	if err := ssh.EnsureConfigBlockWithOptions(host, keyPath, host, ssh.OptionsForAccount(&acc)); err != nil {
+		ui.ShowError(i18n.T("Failed to configure SSH: %v", err))
 		return
 	}
 
-	ui.ShowSuccess(fmt.Sprintf("Updated ~/.ssh/config → Host %s %s (%s) using: %s", platformIcon, platformName, host, keyPath))
+	if configPath := ssh.ConfigTargetPath(); configPath != "" {
+		ui.ShowSuccess(i18n.T("Updated %s → Host %s %s (%s) using: %s", configPath, platformIcon, platformName, host, keyPath))
+	}
 
 	// Ask to test connection
-	if ui.Confirm("Test SSH connection now?") {
-		// Auto-fix permissions for ALL keys
-		fixedCount, _ := ssh.FixAllKeyPermissions()
-		if fixedCount > 0 {
-			ui.ShowInfo(fmt.Sprintf("Fixed permissions for %d SSH key(s)", fixedCount))
-		}
+	if !skipOffline(i18n.T("SSH connection test")) && ui.Confirm(i18n.T("Test SSH connection now?")) {
+		fixKeyPermissions(expandedPath, true)
 
-		ui.ShowInfo(fmt.Sprintf("Testing with key: %s", keyPath))
-		spinner := ui.NewSpinner(fmt.Sprintf("Testing SSH connection to %s (%s)...", platformName, host))
+		ui.ShowInfo(i18n.T("Testing with key: %s", keyPath))
+		spinner := ui.NewSpinner(i18n.T("Testing SSH connection to %s (%s)...", platformName, host))
 		spinner.Start()
 
-		ok, msg, _ := ssh.TestConnectionWithKey(host, expandedPath)
+		ok, msg, _ := ssh.TestConnectionWithOptions(host, expandedPath, ssh.OptionsForAccount(&acc))
 		if ok {
 			spinner.StopWithSuccess(fmt.Sprintf("SSH: %s", msg))
 		} else {
 			spinner.StopWithError(fmt.Sprintf("SSH: %s", msg))
-			ui.ShowWarning(fmt.Sprintf("Make sure your SSH key is added to %s:", platformName))
-			ui.ShowInfo(fmt.Sprintf("1. Copy your public key: cat %s.pub", keyPath))
+			ui.ShowWarning(i18n.T("Make sure your SSH key is added to %s:", platformName))
+			showPublicKeyStep(keyPath, acc.Name)
 			platformType := "github"
 			if acc.Platform != nil {
 				platformType = acc.Platform.Type
 			}
 			switch platformType {
 			case "gitlab":
-				ui.ShowInfo("2. Add it at: https://gitlab.com/-/profile/keys")
+				ui.ShowInfo(i18n.T("2. Add it at: https://gitlab.com/-/profile/keys"))
 			case "bitbucket":
-				ui.ShowInfo("2. Add it at: https://bitbucket.org/account/settings/ssh-keys/")
+				ui.ShowInfo(i18n.T("2. Add it at: https://bitbucket.org/account/settings/ssh-keys/"))
 			case "codeberg":
-				ui.ShowInfo("2. Add it at: https://codeberg.org/user/settings/keys")
+				ui.ShowInfo(i18n.T("2. Add it at: https://codeberg.org/user/settings/keys"))
 			case "gitea":
-				ui.ShowInfo("2. Add it at your Gitea instance: /user/settings/keys")
+				ui.ShowInfo(i18n.T("2. Add it at your Gitea instance: /user/settings/keys"))
 			default:
-				ui.ShowInfo("2. Add it at: https://github.com/settings/keys")
+				ui.ShowInfo(i18n.T("2. Add it at: https://github.com/settings/keys"))
 			}
 		}
 	}
 }
 
-func runTestConnection(cfg *config.AppConfig) {
-	ui.ShowSection("Test Connection")
-
-	// Fix permissions for ALL SSH keys first
-	fixedCount, _ := ssh.FixAllKeyPermissions()
-	if fixedCount > 0 {
-		ui.ShowInfo(fmt.Sprintf("Fixed permissions for %d SSH key(s)", fixedCount))
-	}
+func runTestConnection(cfg *config.AppConfig, opts SSHTestOptions) {
+	ui.ShowSection(i18n.T("Test Connection"))
 
 	// If no accounts, offer to test SSH keys directly
 	if len(cfg.Accounts) == 0 {
 		keys, _ := ssh.ListPrivateKeys()
 		if len(keys) == 0 {
-			ui.ShowWarning("No accounts or SSH keys found")
+			ui.ShowWarning(i18n.T("No accounts or SSH keys found"))
 			return
 		}
 
-		ui.ShowInfo("No accounts configured. Testing SSH keys directly...")
-		testSSHKeyDirectly(keys)
+		ui.ShowInfo(i18n.T("No accounts configured. Testing SSH keys directly..."))
+		testSSHKeyDirectly(keys, opts)
 		return
 	}
 
 	// Build items for selector - add option to test SSH key directly
-	items := make([]ui.SelectorItem, len(cfg.Accounts)+1)
+	accounts := orderedAccounts(cfg, nil)
+	items := make([]ui.SelectorItem, len(accounts)+1)
 
 	// Add "Test SSH key directly" option first
 	items[0] = ui.SelectorItem{
-		Title:       "🔑 Test SSH key directly",
-		Description: "Select any SSH key from ~/.ssh to test",
+		Title:       i18n.T("🔑 Test SSH key directly"),
+		Description: i18n.T("Select any SSH key from ~/.ssh to test"),
 		Value:       "__direct__",
 	}
 
-	for i, acc := range cfg.Accounts {
+	for i, acc := range accounts {
 		methods := []string{}
 		if acc.SSH != nil {
 			methods = append(methods, "🔑 SSH")
@@ -596,19 +1028,19 @@ func runTestConnection(cfg *config.AppConfig) {
 			}
 		}
 		items[i+1] = ui.SelectorItem{
-			Title:       acc.Name,
+			Title:       accountTitle(acc),
 			Description: fmt.Sprintf("%s %s • %s", platformIcon, platformName, strings.Join(methods, ", ")),
 			Value:       acc.Name,
 		}
 	}
 
-	idx, err := ui.RunSelector("Select Account to Test", items)
+	idx, err := ui.RunSelector(i18n.T("Select Account to Test"), items)
 	if err != nil {
-		ui.ShowError(fmt.Sprintf("Selection error: %v", err))
+		ui.ShowError(i18n.T("Selection error: %v", err))
 		return
 	}
 	if idx < 0 {
-		ui.ShowInfo("Cancelled")
+		ui.ShowInfo(i18n.T("Cancelled"))
 		return
 	}
 
@@ -616,15 +1048,15 @@ func runTestConnection(cfg *config.AppConfig) {
 	if items[idx].Value == "__direct__" {
 		keys, _ := ssh.ListPrivateKeys()
 		if len(keys) == 0 {
-			ui.ShowWarning("No SSH keys found in ~/.ssh")
+			ui.ShowWarning(i18n.T("No SSH keys found in ~/.ssh"))
 			return
 		}
-		testSSHKeyDirectly(keys)
+		testSSHKeyDirectly(keys, opts)
 		return
 	}
 
 	// Get the account (index is offset by 1 because of the direct test option)
-	acc := cfg.Accounts[idx-1]
+	acc := *accounts[idx-1]
 
 	// Get platform info
 	host := "github.com"
@@ -656,14 +1088,14 @@ func runTestConnection(cfg *config.AppConfig) {
 	// If both methods available, ask which to test
 	if acc.SSH != nil && acc.Token != nil {
 		methodItems := []ui.SelectorItem{
-			{Title: "🔑 SSH", Description: "Test SSH key authentication", Value: "ssh"},
-			{Title: "🔐 Token", Description: "Test Personal Access Token", Value: "token"},
-			{Title: "🔄 Both", Description: "Test both methods", Value: "both"},
+			{Title: "🔑 SSH", Description: i18n.T("Test SSH key authentication"), Value: "ssh"},
+			{Title: "🔐 Token", Description: i18n.T("Test Personal Access Token"), Value: "token"},
+			{Title: i18n.T("🔄 Both"), Description: i18n.T("Test both methods"), Value: "both"},
 		}
 
-		methodIdx, err := ui.RunSelector("Test which authentication method?", methodItems)
+		methodIdx, err := ui.RunSelector(i18n.T("Test which authentication method?"), methodItems)
 		if err != nil || methodIdx < 0 {
-			ui.ShowInfo("Cancelled")
+			ui.ShowInfo(i18n.T("Cancelled"))
 			return
 		}
 
@@ -671,11 +1103,11 @@ func runTestConnection(cfg *config.AppConfig) {
 
 		switch methodItems[methodIdx].Value {
 		case "ssh":
-			testSSHConnection(acc, host, platformName, platformIcon)
+			testSSHConnection(acc, host, platformName, platformIcon, opts)
 		case "token":
 			testTokenConnection(acc, platformName)
 		case "both":
-			testSSHConnection(acc, host, platformName, platformIcon)
+			testSSHConnection(acc, host, platformName, platformIcon, opts)
 			fmt.Println()
 			testTokenConnection(acc, platformName)
 		}
@@ -685,7 +1117,7 @@ func runTestConnection(cfg *config.AppConfig) {
 	fmt.Println()
 
 	if acc.SSH != nil {
-		testSSHConnection(acc, host, platformName, platformIcon)
+		testSSHConnection(acc, host, platformName, platformIcon, opts)
 	}
 
 	if acc.Token != nil {
@@ -694,8 +1126,8 @@ func runTestConnection(cfg *config.AppConfig) {
 }
 
 // testSSHConnection uses helper function to test SSH connection
-func testSSHConnection(acc config.Account, host, platformName, platformIcon string) {
-	TestAccountSSH(&acc, true)
+func testSSHConnection(acc config.Account, host, platformName, platformIcon string, opts SSHTestOptions) {
+	TestAccountSSHWithOptions(&acc, true, opts)
 }
 
 // testTokenConnection uses helper function to test token connection
@@ -703,28 +1135,477 @@ func testTokenConnection(acc config.Account, platformName string) {
 	TestAccountToken(&acc, true)
 }
 
-func runListSSHKeys() {
-	keys, err := ssh.ListPrivateKeys()
+func runListSSHKeys(cfg *config.AppConfig) {
+	files, err := ssh.ListKeyFiles()
 	if err != nil {
-		ui.ShowError(fmt.Sprintf("Failed to list SSH keys: %v", err))
+		ui.ShowError(i18n.T("Failed to list SSH keys: %v", err))
 		return
 	}
 
+	var keys, others []ssh.KeyInfo
+	var paths []string
+	for _, f := range files {
+		if f.Kind == ssh.KindPrivateKey {
+			keys = append(keys, f)
+			paths = append(paths, f.Path)
+		} else {
+			others = append(others, f)
+		}
+	}
+
+	// Unreadable config only leaves the Host aliases out
+	blocks, _ := ssh.ListHostBlocks()
+	uses := ssh.KeyUses(cfg, blocks, paths)
+
 	if len(keys) == 0 {
-		ui.ShowWarning("No SSH keys found in ~/.ssh")
+		ui.ShowWarning(i18n.T("No SSH keys found in ~/.ssh"))
+	} else {
+		ui.ShowSection(i18n.T("SSH Keys"))
+		orphans := 0
+		for _, key := range keys {
+			label := key.Label()
+			if key.Encrypted {
+				label += ", " + i18n.T("passphrase")
+			}
+			use := uses[key.Path]
+			owner := ui.Success("→ " + strings.Join(use.Accounts, ", "))
+			if use.Orphan() {
+				owner = ui.Warning(i18n.T("no account"))
+				orphans++
+			}
+			if len(use.Hosts) > 0 {
+				owner += " " + ui.Muted(i18n.T("(Host %s)", strings.Join(use.Hosts, ", ")))
+			}
+			fmt.Printf("  • %s %s %s\n", ui.Accent(key.Path), ui.Muted("("+label+")"), owner)
+		}
+		fmt.Println()
+		ui.ShowInfo(i18n.T("Total: %d keys", len(keys)))
+		if orphans > 0 {
+			ui.ShowInfo(i18n.T("Keys not used by any account: %d", orphans))
+		}
+	}
+
+	if missing := ssh.MissingAccountKeys(cfg); len(missing) > 0 {
+		fmt.Println()
+		ui.ShowWarning(i18n.T("Accounts whose SSH key file is missing:"))
+		for _, m := range missing {
+			fmt.Printf("  • %s %s\n", ui.Accent(m.Account), ui.Muted(m.KeyPath))
+		}
+	}
+
+	// Files that look like keys by name but aren't usable as one
+	if len(others) > 0 {
+		fmt.Println()
+		ui.ShowInfo(i18n.T("Other files in ~/.ssh, not used as keys:"))
+		for _, f := range others {
+			label := i18n.T(string(f.Kind))
+			if f.Kind == ssh.KindOther {
+				label = i18n.T("not a key")
+			} else if f.Type != "" {
+				label += ", " + f.Label()
+			}
+			fmt.Printf("  • %s %s\n", ui.Muted(f.Path), ui.Muted("("+label+")"))
+		}
+	}
+}
+
+// newSSHDevicesCmd creates the commands tracking which machines hold an
+// account's keys
+func newSSHDevicesCmd() *cobra.Command {
+	devicesCmd := &cobra.Command{
+		Use:   "devices [account]",
+		Short: i18n.T("Show the machines holding each account's SSH keys"),
+		Long: `Show the machines (devices) each account's SSH keys were generated or
+imported on, with the key's fingerprint, so a key left on an old laptop can
+be found and revoked. Devices are recorded in the config when ghex
+generates or imports a key; keys made before that are recorded with
+"ghex ssh devices add". Generated keys carry the device name in their
+comment too, e.g. "me@work.example (laptop)".
+
+"remove" forgets a device and shows the fingerprint to delete on the Git
+service's key settings page.
+
+Examples:
+  ghex ssh devices
+  ghex ssh devices work
+  ghex ssh devices add
+  ghex ssh devices remove work old-laptop`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, _ := config.Load()
+			name := ""
+			if len(args) == 1 {
+				name = args[0]
+			}
+			runSSHDevices(cfg, name)
+		},
+	}
+
+	devicesCmd.AddCommand(&cobra.Command{
+		Use:   "add [account]",
+		Short: i18n.T("Record this machine for the accounts whose key is here"),
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, _ := config.Load()
+			name := ""
+			if len(args) == 1 {
+				name = args[0]
+			}
+			runSSHDevicesAdd(cfg, name)
+		},
+	})
+
+	devicesCmd.AddCommand(&cobra.Command{
+		Use:   "remove <account> <device>",
+		Short: i18n.T("Forget a device of an account"),
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, _ := config.Load()
+			runSSHDevicesRemove(cfg, args[0], args[1])
+		},
+	})
+
+	return devicesCmd
+}
+
+// devicesAccounts returns the accounts a devices command applies to: the
+// named one, or all with SSH
+func devicesAccounts(cfg *config.AppConfig, name string) []*config.Account {
+	accounts := orderedAccounts(cfg, hasSSH)
+	if name == "" {
+		return accounts
+	}
+	for _, acc := range accounts {
+		if strings.EqualFold(acc.Name, name) {
+			return []*config.Account{acc}
+		}
+	}
+	ui.ShowError(i18n.T("No account with SSH named %s", name))
+	return nil
+}
+
+func runSSHDevices(cfg *config.AppConfig, name string) {
+	accounts := devicesAccounts(cfg, name)
+	if len(accounts) == 0 {
+		if name == "" {
+			ui.ShowWarning(i18n.T("No accounts with SSH configured"))
+		}
 		return
 	}
 
-	ui.ShowSection("SSH Keys")
-	for _, key := range keys {
-		fmt.Printf("  • %s\n", ui.Accent(key))
+	this := platform.DeviceName()
+	ui.ShowSection(i18n.T("SSH Devices"))
+	unrecorded := false
+	for _, acc := range accounts {
+		fmt.Printf("%s %s\n", ui.Accent(accountTitle(acc)), ui.Muted(acc.SSH.KeyPath))
+		if len(acc.SSH.Devices) == 0 {
+			fmt.Printf("  %s\n", ui.Muted(i18n.T("no devices recorded")))
+		}
+		here := false
+		for _, d := range acc.SSH.Devices {
+			label := d.Name
+			if strings.EqualFold(d.Name, this) {
+				label += " " + i18n.T("(this device)")
+				here = true
+			}
+			added := ""
+			if t, err := time.Parse(time.RFC3339, d.AddedAt); err == nil {
+				added = i18n.T("added %s", t.Local().Format("2006-01-02"))
+			}
+			fmt.Printf("  • %s %s %s %s\n", ui.Success(label), d.KeyPath, ui.Muted(d.Fingerprint), ui.Muted(added))
+		}
+		if !here && platform.FileExists(platform.ExpandPath(acc.SSH.KeyPath)) {
+			unrecorded = true
+		}
+		fmt.Println()
 	}
-	fmt.Println()
-	ui.ShowInfo(fmt.Sprintf("Total: %d keys", len(keys)))
+
+	if unrecorded {
+		ui.ShowInfo(i18n.T("Keys on this machine (%s) that aren't recorded yet can be added with: ghex ssh devices add", this))
+	}
+}
+
+func runSSHDevicesAdd(cfg *config.AppConfig, name string) {
+	this := platform.DeviceName()
+	if this == "" {
+		ui.ShowError(i18n.T("Could not read this machine's hostname"))
+		return
+	}
+
+	added := 0
+	for _, acc := range devicesAccounts(cfg, name) {
+		keyPath := acc.SSH.KeyPath
+		if !platform.FileExists(platform.ExpandPath(keyPath)) {
+			if name != "" {
+				ui.ShowWarning(i18n.T("Key %s of %s is not on this machine", keyPath, acc.Name))
+			}
+			continue
+		}
+		if _, err := ssh.EnsurePublicKey(keyPath); err != nil {
+			ui.ShowWarning(i18n.T("Failed to generate public key: %v", err))
+		}
+		recordKeyDevice(acc, keyPath)
+		ui.ShowSuccess(i18n.T("Recorded %s for %s (%s)", this, acc.Name, keyPath))
+		added++
+	}
+	if added == 0 {
+		return
+	}
+	if err := config.Save(cfg); err != nil {
+		ui.ShowError(i18n.T("Failed to save config: %v", err))
+	}
+}
+
+func runSSHDevicesRemove(cfg *config.AppConfig, name, device string) {
+	accounts := devicesAccounts(cfg, name)
+	if len(accounts) == 0 {
+		return
+	}
+	acc := accounts[0]
+
+	d, ok := acc.SSH.RemoveDevice(device)
+	if !ok {
+		ui.ShowError(i18n.T("%s has no device named %s", acc.Name, device))
+		return
+	}
+	if err := config.Save(cfg); err != nil {
+		ui.ShowError(i18n.T("Failed to save config: %v", err))
+		return
+	}
+
+	ui.ShowSuccess(i18n.T("Removed device %s from %s", d.Name, acc.Name))
+	if d.Fingerprint != "" {
+		ui.ShowInfo(i18n.T("Revoke the key with fingerprint %s at: %s", d.Fingerprint, GetPlatformInfo(acc).KeysURL))
+	} else {
+		ui.ShowInfo(i18n.T("Revoke its key at: %s", GetPlatformInfo(acc).KeysURL))
+	}
+	if strings.EqualFold(d.Name, platform.DeviceName()) {
+		ui.ShowInfo(i18n.T("The key file %s on this machine was left in place", d.KeyPath))
+	}
+}
+
+// newSSHConfigCmd creates the commands moving ghex's Host blocks between
+// machines
+func newSSHConfigCmd() *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: i18n.T("Export or import the SSH Host blocks ghex manages"),
+		Long: `Move the SSH Host blocks ghex wrote to another machine, next to the
+accounts in the config file. Only blocks ghex manages are exported and
+imported; hand-written ones, Host patterns and Match blocks stay where
+they are.
+
+Key paths in the home directory are exported as ~/..., so they resolve on
+the new machine. Copy the keys themselves separately.
+
+Examples:
+  ghex ssh config export -o ghex-ssh.conf
+  ghex ssh config import ghex-ssh.conf
+  ghex ssh config import ghex-ssh.conf --dry-run
+  ghex ssh config import ghex-ssh.conf --yes`,
+	}
+
+	var output string
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: i18n.T("Print the SSH Host blocks ghex manages"),
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			runSSHConfigExport(output)
+		},
+	}
+	exportCmd.Flags().StringVarP(&output, "output", "o", "", "Write the blocks to this file instead of stdout")
+	configCmd.AddCommand(exportCmd)
+
+	var dryRun, yes bool
+	importCmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: i18n.T("Apply exported SSH Host blocks"),
+		Long: `Apply the Host blocks of a file written by 'ghex ssh config export'
+("-" reads stdin). A block replaces one with the same alias, and goes
+where the SSH config mode says (see 'ghex ssh config-mode').
+
+Each block is listed with its port and proxy settings. Blocks that replace
+a different block with the same alias, or that run a ProxyCommand, are
+only applied once confirmed, or with --yes.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runSSHConfigImport(args[0], dryRun, yes)
+		},
+	}
+	importCmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false, "Only list the blocks that would be imported")
+	importCmd.Flags().BoolVarP(&yes, "yes", "y", false, "Replace blocks and import ProxyCommands without asking for confirmation")
+	configCmd.AddCommand(importCmd)
+
+	return configCmd
+}
+
+// runSSHConfigExport prints or writes the Host blocks ghex manages
+func runSSHConfigExport(output string) {
+	content, blocks, err := ssh.ExportHostBlocks()
+	if err != nil {
+		ui.ShowError(err.Error())
+		return
+	}
+	if len(blocks) == 0 {
+		ui.ShowWarning(i18n.T("No Host blocks managed by ghex in %s", ssh.ConfigTargetPath()))
+		return
+	}
+
+	if output == "" {
+		fmt.Print(content)
+		return
+	}
+	path := platform.ExpandPath(output)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		ui.ShowError(i18n.T("Failed to write %s: %v", path, err))
+		return
+	}
+	ui.ShowSuccess(i18n.T("Exported %d Host blocks to %s", len(blocks), path))
+}
+
+// runSSHConfigImport applies the Host blocks of an exported file, asking
+// before it replaces a block or imports a ProxyCommand unless yes is set
+func runSSHConfigImport(file string, dryRun, yes bool) {
+	var data []byte
+	var err error
+	if file == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(platform.ExpandPath(file))
+	}
+	if err != nil {
+		ui.ShowError(i18n.T("Failed to read %s: %v", file, err))
+		return
+	}
+
+	planned, skipped, err := ssh.PlanImport(string(data))
+	if err != nil {
+		ui.ShowError(err.Error())
+		return
+	}
+	for _, block := range planned {
+		showImportBlock(block)
+	}
+	for _, alias := range skipped {
+		ui.ShowWarning(i18n.T("Skipped Host %s: not a block ghex writes", alias))
+	}
+
+	var blocks []ssh.ImportBlock
+	for _, block := range planned {
+		if dryRun || yes || !block.NeedsConfirmation() {
+			blocks = append(blocks, block)
+			continue
+		}
+		question := i18n.T("Replace the existing Host %s?", block.Alias)
+		if !block.Replaces {
+			question = i18n.T("Import Host %s, which runs a ProxyCommand?", block.Alias)
+		}
+		if ui.Confirm(question) {
+			blocks = append(blocks, block)
+		} else {
+			ui.ShowInfo(i18n.T("Skipped Host %s", block.Alias))
+		}
+	}
+
+	var applied []ssh.HostBlock
+	for _, block := range blocks {
+		applied = append(applied, block.HostBlock)
+	}
+	for _, key := range ssh.MissingKeys(applied) {
+		ui.ShowWarning(i18n.T("Key %s does not exist on this machine yet", key))
+	}
+
+	if dryRun {
+		ui.ShowInfo(i18n.T("%d Host blocks would be imported", len(blocks)))
+		return
+	}
+	imported, err := ssh.ImportHostBlocks(blocks)
+	if err != nil {
+		ui.ShowError(err.Error())
+		return
+	}
+	ui.ShowSuccess(i18n.T("Imported %d Host blocks", len(imported)))
+}
+
+// showImportBlock prints a Host block of an import with the settings that
+// change how ssh connects
+func showImportBlock(block ssh.ImportBlock) {
+	mark := ui.Success("+")
+	if block.Replaces {
+		mark = ui.Warning("~")
+	}
+	fmt.Printf("  %s %s → %s (%s)\n", mark, ui.Accent(block.Alias), block.HostName, block.IdentityFile)
+	if block.Port != 0 {
+		ui.ShowIndentedKeyValue("Port", strconv.Itoa(block.Port), 2)
+	}
+	if block.ProxyJump != "" {
+		ui.ShowIndentedKeyValue("ProxyJump", block.ProxyJump, 2)
+	}
+	if block.ProxyCommand != "" {
+		ui.ShowIndentedKeyValue("ProxyCommand", ui.Warning(block.ProxyCommand), 2)
+	}
+}
+
+// runSSHConfigMode shows or sets how Host blocks are applied
+func runSSHConfigMode(mode string) {
+	cfg, err := config.Load()
+	if err != nil {
+		ui.ShowError(i18n.T("Failed to load config: %v", err))
+		return
+	}
+
+	if mode == "" {
+		ui.ShowKeyValue("SSH config mode", ssh.ConfigMode())
+		if target := ssh.ConfigTargetPath(); target != "" {
+			ui.ShowKeyValue("Writes to", target)
+		}
+		if ssh.ConfigMode() == ssh.ConfigModeInclude && !ssh.HasManagedInclude() {
+			ui.ShowWarning(i18n.T("%s does not include %s yet. Add this line at the top:", ssh.GetSSHConfigPath(), ssh.GetManagedConfigPath()))
+			fmt.Println("  " + ssh.IncludeLine())
+		}
+		return
+	}
+
+	if err := ssh.ValidateConfigMode(mode); err != nil {
+		ui.ShowError(err.Error())
+		return
+	}
+	cfg.SSHConfigMode = mode
+	if mode == ssh.ConfigModeEdit {
+		cfg.SSHConfigMode = ""
+	}
+
+	if err := config.Save(cfg); err != nil {
+		ui.ShowError(i18n.T("Failed to save config: %v", err))
+		return
+	}
+	ssh.SetConfigMode(mode)
+	ui.ShowSuccess(i18n.T("SSH config mode set to: %s", mode))
+
+	if mode == ssh.ConfigModeInclude && !ssh.HasManagedInclude() {
+		ui.ShowInfo(i18n.T("Add this line at the top of %s:", ssh.GetSSHConfigPath()))
+		fmt.Println("  " + ssh.IncludeLine())
+	}
+}
+
+// runFixSSHPermissions fixes every private key in ~/.ssh on request
+func runFixSSHPermissions() {
+	ssh.EnsureSSHDirPermissions()
+
+	fixed, err := ssh.FixAllKeyPermissions()
+	if err != nil {
+		ui.ShowWarning(i18n.T("Some keys could not be fixed: %v", err))
+	}
+	if fixed == 0 {
+		ui.ShowSuccess(i18n.T("All SSH keys already have private permissions"))
+		return
+	}
+	ui.ShowSuccess(i18n.T("Fixed permissions for %d SSH key(s)", fixed))
 }
 
 // testSSHKeyDirectly allows testing any SSH key directly without an account
-func testSSHKeyDirectly(keys []string) {
+func testSSHKeyDirectly(keys []string, opts SSHTestOptions) {
 	// Build items for selector
 	items := make([]ui.SelectorItem, len(keys))
 	for i, key := range keys {
@@ -734,9 +1615,9 @@ func testSSHKeyDirectly(keys []string) {
 		}
 	}
 
-	idx, err := ui.RunSelector("Select SSH Key to Test", items)
+	idx, err := ui.RunSelector(i18n.T("Select SSH Key to Test"), items)
 	if err != nil || idx < 0 {
-		ui.ShowInfo("Cancelled")
+		ui.ShowInfo(i18n.T("Cancelled"))
 		return
 	}
 
@@ -748,23 +1629,23 @@ func testSSHKeyDirectly(keys []string) {
 		{Title: "🦊 GitLab", Description: "gitlab.com", Value: "gitlab.com"},
 		{Title: "🪣 Bitbucket", Description: "bitbucket.org", Value: "bitbucket.org"},
 		{Title: "🏔️ Codeberg", Description: "codeberg.org", Value: "codeberg.org"},
-		{Title: "🌐 Custom", Description: "Enter custom host", Value: "__custom__"},
+		{Title: i18n.T("🌐 Custom"), Description: i18n.T("Enter custom host"), Value: "__custom__"},
 	}
 
-	hostIdx, err := ui.RunSelector("Select Host to Test", hostItems)
+	hostIdx, err := ui.RunSelector(i18n.T("Select Host to Test"), hostItems)
 	if err != nil || hostIdx < 0 {
-		ui.ShowInfo("Cancelled")
+		ui.ShowInfo(i18n.T("Cancelled"))
 		return
 	}
 
 	host := hostItems[hostIdx].Value
 	if host == "__custom__" {
-		host = ui.PromptWithDefault("Enter host", "github.com")
+		host = ui.PromptWithDefault(i18n.T("Enter host"), "github.com")
 		if host == "" {
 			host = "github.com"
 		}
 	}
 
 	// Use helper function
-	TestSSHKeyDirect(selectedKey, host, true)
+	TestSSHKeyDirect(selectedKey, host, true, opts)
 }
@@ -0,0 +1,170 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/dwirx/ghex/internal/i18n"
+	"github.com/dwirx/ghex/internal/ui"
+	"github.com/dwirx/ghex/pkg/download"
+	"github.com/spf13/cobra"
+)
+
+// NewOutdatedCmd creates the outdated command for dlx-installed tools
+func NewOutdatedCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "outdated",
+		Short: i18n.T("Show tools installed with dlx that have newer releases"),
+		Long: `Check upstream releases for every tool installed with
+'ghex dlx release --install' or a release manifest.
+
+Tools installed with a version range (e.g. --version "^1.4") are only
+reported when a newer release satisfies that range.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token, _ := cmd.Flags().GetString("token")
+			registerAccountHosts()
+
+			tools, err := download.ListInstalled()
+			if err != nil {
+				ui.ShowError(err.Error())
+				return err
+			}
+			if len(tools) == 0 {
+				ui.ShowInfo(i18n.T("No tools installed with dlx yet (use 'ghex dlx release --install')"))
+				return nil
+			}
+			if skipOffline(i18n.T("Release checks")) {
+				return nil
+			}
+
+			spinner := ui.NewSpinner(i18n.T("Checking %d tools...", len(tools)))
+			spinner.Start()
+			outdated, errs := download.CheckOutdated(tools, token)
+			spinner.Stop()
+
+			for _, err := range errs {
+				ui.ShowWarning(err.Error())
+			}
+			if len(outdated) == 0 {
+				ui.ShowSuccess(i18n.T("All %d tools are up to date", len(tools)-len(errs)))
+				return nil
+			}
+
+			ui.ShowSection(i18n.T("Outdated Tools"))
+			for _, o := range outdated {
+				fmt.Printf("  %-20s %s → %s  %s\n", o.Tool.Name, ui.Dim(o.Tool.Version), ui.Primary(o.Latest), ui.Dim(o.Tool.Repo))
+			}
+			fmt.Println()
+			ui.ShowInfo(i18n.T("Run 'ghex upgrade --all' or 'ghex upgrade <tool>' to update"))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("token", "t", "", "Access token (falls back to GITHUB_TOKEN, GITLAB_TOKEN, GITEA_TOKEN or BITBUCKET_TOKEN by host, then a configured account's token)")
+
+	return cmd
+}
+
+// NewUpgradeCmd creates the upgrade command for dlx-installed tools
+func NewUpgradeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upgrade [tool...]",
+		Short: i18n.T("Upgrade tools installed with dlx"),
+		Long: `Download and install the newest allowed release of dlx-installed tools.
+
+Examples:
+  ghex upgrade fzf
+  ghex upgrade --all`,
+		ValidArgsFunction: completeInstalledTool,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			all, _ := cmd.Flags().GetBool("all")
+			token, _ := cmd.Flags().GetString("token")
+			registerAccountHosts()
+
+			if !all && len(args) == 0 {
+				return fmt.Errorf("specify a tool name or --all")
+			}
+			if skipOffline(i18n.T("Upgrades")) {
+				return nil
+			}
+
+			if all {
+				args = nil
+			}
+			return upgradeTools(args, token)
+		},
+	}
+
+	cmd.Flags().BoolP("all", "a", false, "Upgrade every outdated tool")
+	cmd.Flags().StringP("token", "t", "", "Access token (falls back to GITHUB_TOKEN, GITLAB_TOKEN, GITEA_TOKEN or BITBUCKET_TOKEN by host, then a configured account's token)")
+
+	return cmd
+}
+
+// upgradeTools installs the newest allowed release of the named installed
+// tools, or of every installed tool when names is empty
+func upgradeTools(names []string, token string) error {
+	tools, err := download.ListInstalled()
+	if err != nil {
+		ui.ShowError(err.Error())
+		return err
+	}
+
+	var selected []download.InstalledTool
+	if len(names) == 0 {
+		selected = tools
+	} else {
+		byName := make(map[string]download.InstalledTool, len(tools))
+		for _, t := range tools {
+			byName[t.Name] = t
+		}
+		for _, name := range names {
+			t, ok := byName[name]
+			if !ok {
+				err := fmt.Errorf("%s is not installed with dlx (see 'ghex outdated')", name)
+				ui.ShowError(err.Error())
+				return err
+			}
+			selected = append(selected, t)
+		}
+	}
+
+	outdated, errs := download.CheckOutdated(selected, token)
+	for _, err := range errs {
+		ui.ShowWarning(err.Error())
+	}
+	if len(outdated) == 0 {
+		ui.ShowSuccess(i18n.T("Everything is up to date"))
+		return nil
+	}
+
+	failed := 0
+	for _, o := range outdated {
+		ui.ShowInfo(i18n.T("Upgrading %s %s → %s", o.Tool.Name, o.Tool.Version, o.Latest))
+		tag, err := download.UpgradeTool(o.Tool, token)
+		if err != nil {
+			ui.ShowError(fmt.Sprintf("%s: %v", o.Tool.Name, err))
+			failed++
+			continue
+		}
+		ui.ShowSuccess(i18n.T("%s is now %s", o.Tool.Name, tag))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d upgrades failed", failed)
+	}
+	return nil
+}
+
+// completeInstalledTool completes names of dlx-installed tools
+func completeInstalledTool(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	tools, err := download.ListInstalled()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	names := make([]string, 0, len(tools))
+	for _, t := range tools {
+		names = append(names, t.Name)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
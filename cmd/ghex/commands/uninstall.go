@@ -6,6 +6,8 @@ import (
 	"os"
 	"strings"
 
+	"github.com/dwirx/ghex/internal/i18n"
+	"github.com/dwirx/ghex/internal/platform"
 	"github.com/dwirx/ghex/internal/ui"
 	"github.com/dwirx/ghex/internal/uninstall"
 	"github.com/spf13/cobra"
@@ -20,7 +22,7 @@ func NewUninstallCmd() *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use:   "uninstall",
-		Short: "Uninstall GHEX from your system",
+		Short: i18n.T("Uninstall GHEX from your system"),
 		Long:  "Remove GHEX binary and optionally configuration files from your system",
 		Run: func(cmd *cobra.Command, args []string) {
 			runUninstall(force, purge, keepConfig, dryRun)
@@ -39,7 +41,7 @@ func runUninstall(force, purge, keepConfig, dryRun bool) {
 	svc := uninstall.NewService()
 
 	// Show banner
-	ui.ShowSection("GHEX Uninstaller")
+	ui.ShowSection(i18n.T("GHEX Uninstaller"))
 	fmt.Println()
 
 	// Get preview
@@ -50,7 +52,7 @@ func runUninstall(force, purge, keepConfig, dryRun bool) {
 	fmt.Println()
 
 	if svc.BinaryExists() {
-		fmt.Printf("  Binary: %s\n", preview.BinaryPath)
+		fmt.Printf("  Binary: %s (%s)\n", preview.BinaryPath, scopeLabel(svc.GetScope()))
 	} else {
 		fmt.Printf("  Binary: (not found)\n")
 	}
@@ -66,18 +68,22 @@ func runUninstall(force, purge, keepConfig, dryRun bool) {
 		fmt.Printf("  PATH entry: %s\n", preview.PathEntry)
 	}
 
+	for _, rc := range preview.ShellRCFiles {
+		fmt.Printf("  Shell setup in: %s\n", rc)
+	}
+
 	fmt.Println()
 
 	// Dry run - just show preview and exit
 	if dryRun {
-		ui.ShowInfo("Dry run mode - no files will be removed")
+		ui.ShowInfo(i18n.T("Dry run mode - no files will be removed"))
 		return
 	}
 
 	// Confirm uninstallation
 	if !force {
 		if !confirm("Do you want to uninstall GHEX?") {
-			ui.ShowInfo("Uninstallation cancelled")
+			ui.ShowInfo(i18n.T("Uninstallation cancelled"))
 			return
 		}
 	}
@@ -89,12 +95,21 @@ func runUninstall(force, purge, keepConfig, dryRun bool) {
 		removeConfig = confirm("Do you want to remove configuration files as well?")
 	}
 
+	// System-wide installs need sudo for the binary only; without sudo
+	// (e.g. Windows) the failure below shows how to remove it as admin
+	elevate := false
+	if svc.NeedsElevation() && platform.CanElevate() {
+		fmt.Println()
+		elevate = force || confirm(fmt.Sprintf("%s needs administrator rights to remove. Use sudo?", preview.BinaryPath))
+	}
+
 	// Execute uninstallation
 	opts := uninstall.Options{
 		Force:      force,
 		Purge:      removeConfig,
 		KeepConfig: keepConfig && !removeConfig,
 		DryRun:     false,
+		Elevate:    elevate,
 	}
 
 	result := svc.Execute(opts)
@@ -103,31 +118,31 @@ func runUninstall(force, purge, keepConfig, dryRun bool) {
 	fmt.Println()
 
 	if result.BinaryRemoved {
-		ui.ShowSuccess("Binary removed")
+		ui.ShowSuccess(i18n.T("Binary removed"))
 	} else if !svc.BinaryExists() {
-		ui.ShowInfo("Binary was not installed")
+		ui.ShowInfo(i18n.T("Binary was not installed"))
 	} else {
-		ui.ShowError("Failed to remove binary")
+		ui.ShowError(i18n.T("Failed to remove binary"))
 		fmt.Println()
 		fmt.Println(svc.GetManualRemovalInstructions())
 	}
 
 	if result.ConfigRemoved {
-		ui.ShowSuccess("Configuration files removed")
+		ui.ShowSuccess(i18n.T("Configuration files removed"))
 	} else if opts.Purge && svc.ConfigExists() {
-		ui.ShowWarning("Some configuration files could not be removed")
+		ui.ShowWarning(i18n.T("Some configuration files could not be removed"))
 	} else if !opts.Purge {
-		ui.ShowInfo("Configuration files preserved")
+		ui.ShowInfo(i18n.T("Configuration files preserved"))
 	}
 
 	if result.PathUpdated {
-		ui.ShowSuccess("PATH updated")
+		ui.ShowSuccess(i18n.T("PATH updated"))
 	}
 
 	// Show errors if any
 	if len(result.Errors) > 0 {
 		fmt.Println()
-		ui.ShowWarning("Some operations failed:")
+		ui.ShowWarning(i18n.T("Some operations failed:"))
 		for _, err := range result.Errors {
 			fmt.Printf("  - %s\n", err)
 		}
@@ -136,17 +151,28 @@ func runUninstall(force, purge, keepConfig, dryRun bool) {
 	// Final message
 	fmt.Println()
 	if result.Success || result.BinaryRemoved {
-		ui.ShowSuccess("GHEX has been uninstalled!")
+		ui.ShowSuccess(i18n.T("GHEX has been uninstalled!"))
 		fmt.Println()
 		fmt.Println("Thank you for using GHEX! 👋")
 	} else {
-		ui.ShowError("Uninstallation incomplete")
+		ui.ShowError(i18n.T("Uninstallation incomplete"))
 		fmt.Println()
 		fmt.Println(svc.GetManualRemovalInstructions())
 	}
 }
 
+// scopeLabel describes an install scope for the uninstall preview
+func scopeLabel(scope platform.InstallScope) string {
+	if scope == platform.ScopeSystem {
+		return "system-wide"
+	}
+	return "per-user"
+}
+
 func confirm(prompt string) bool {
+	if ui.NonInteractive() {
+		return false
+	}
 	reader := bufio.NewReader(os.Stdin)
 	fmt.Printf("%s [y/N]: ", prompt)
 	
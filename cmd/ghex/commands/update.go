@@ -2,8 +2,12 @@ package commands
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/dwirx/ghex/internal/i18n"
+	"github.com/dwirx/ghex/internal/platform"
 	"github.com/dwirx/ghex/internal/ui"
 	"github.com/dwirx/ghex/internal/update"
 	"github.com/spf13/cobra"
@@ -15,13 +19,18 @@ var (
 	updateRollback  bool
 	updateForce     bool
 	updateYes       bool
+	updateRefresh   bool
+	updateAttest    bool
+	updatePre       bool
+	updateResume    string
+	updateResumeSum string
 )
 
 // NewUpdateCmd creates the update command
 func NewUpdateCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "update",
-		Short: "Update ghex to the latest version",
+		Short: i18n.T("Update ghex to the latest version"),
 		Long:  "Check for updates, download and install the latest version of ghex",
 		Run: func(cmd *cobra.Command, args []string) {
 			runUpdate()
@@ -33,6 +42,15 @@ func NewUpdateCmd() *cobra.Command {
 	cmd.Flags().BoolVar(&updateRollback, "rollback", false, "Rollback to previous version")
 	cmd.Flags().BoolVarP(&updateForce, "force", "f", false, "Force update without confirmation")
 	cmd.Flags().BoolVarP(&updateYes, "yes", "y", false, "Auto-confirm prompts")
+	cmd.Flags().BoolVar(&updateRefresh, "refresh", false, "With --check, ask GitHub instead of using the last check")
+	cmd.Flags().BoolVar(&updateAttest, "require-attestation", false, "Refuse releases without a GitHub artifact attestation")
+	cmd.Flags().BoolVar(&updatePre, "prerelease", false, "Include prereleases (default: stable releases only)")
+
+	// Used by the sudo run that installs a binary downloaded without sudo
+	cmd.Flags().StringVar(&updateResume, "resume", "", "Install a binary downloaded by a previous 'ghex update'")
+	cmd.Flags().StringVar(&updateResumeSum, "resume-sha256", "", "Expected SHA256 of the --resume binary")
+	_ = cmd.Flags().MarkHidden("resume")
+	_ = cmd.Flags().MarkHidden("resume-sha256")
 
 	return cmd
 }
@@ -42,30 +60,52 @@ func runUpdate() {
 		runRollback()
 		return
 	}
+	if updateResume != "" {
+		runResume()
+		return
+	}
+
+	// --check answers from the last check while it is fresh. The cache
+	// only knows stable releases.
+	if updateCheck && !updateRefresh && !updateChangelog && !updatePre {
+		if cache := update.LoadCheckCache(); cache != nil && cache.IsFresh() {
+			showCachedCheck(cache)
+			return
+		}
+	}
+	if skipOffline(i18n.T("Update check")) {
+		// The last check is still worth showing, however old
+		if cache := update.LoadCheckCache(); cache != nil && updateCheck && !updatePre {
+			showCachedCheck(cache)
+		}
+		return
+	}
 
 	updater, err := update.NewUpdater(Version)
 	if err != nil {
-		ui.ShowError(fmt.Sprintf("Failed to initialize updater: %v", err))
+		ui.ShowError(i18n.T("Failed to initialize updater: %v", err))
 		return
 	}
+	updater.RequireAttestation = updateAttest
+	updater.Prerelease = updatePre
 
 	// Check for updates
-	ui.ShowInfo("Checking for updates...")
+	ui.ShowInfo(i18n.T("Checking for updates..."))
 	release, hasUpdate, err := updater.CheckForUpdate()
 	if err != nil {
-		ui.ShowError(fmt.Sprintf("Failed to check for updates: %v", err))
+		ui.ShowError(i18n.T("Failed to check for updates: %v", err))
 		return
 	}
 
 	if !hasUpdate {
-		ui.ShowSuccess(fmt.Sprintf("You're already running the latest version (v%s)", Version))
+		ui.ShowSuccess(i18n.T("You're already running the latest version (v%s)", Version))
 		return
 	}
 
 	// Show update info
 	fmt.Println()
-	ui.ShowInfo(fmt.Sprintf("Current version: v%s", Version))
-	ui.ShowSuccess(fmt.Sprintf("Latest version:  %s", release.TagName))
+	ui.ShowInfo(i18n.T("Current version: v%s", Version))
+	ui.ShowSuccess(i18n.T("Latest version:  %s", release.TagName))
 	fmt.Println()
 
 	// Show changelog if requested
@@ -75,107 +115,221 @@ func runUpdate() {
 
 	// If only checking, stop here
 	if updateCheck {
-		ui.ShowInfo("Run 'ghex update' to install the latest version")
+		ui.ShowInfo(i18n.T("Run 'ghex update' to install the latest version"))
 		return
 	}
 
 	// Check permissions before asking for confirmation
 	permErr, err := update.CheckUpdatePermissions()
 	if err != nil {
-		ui.ShowError(fmt.Sprintf("Failed to check permissions: %v", err))
+		ui.ShowError(i18n.T("Failed to check permissions: %v", err))
 		return
 	}
 	if permErr != nil {
-		ui.ShowError(permErr.Instruction)
+		if confirmElevation(permErr) {
+			updateElevated(updater, release, permErr.Path)
+		}
 		return
 	}
 
 	// Confirm update
 	if !updateForce && !updateYes {
-		fmt.Print("Do you want to update? [y/N]: ")
-		var response string
-		_, _ = fmt.Scanln(&response)
-		response = strings.ToLower(strings.TrimSpace(response))
-		if response != "y" && response != "yes" {
-			ui.ShowInfo("Update cancelled")
+		if !ui.Confirm(i18n.T("Do you want to update?")) {
+			ui.ShowInfo(i18n.T("Update cancelled"))
 			return
 		}
 	}
 
+	warnAttestationPresenceOnly()
+
 	// Perform update
-	ui.ShowInfo("Downloading update...")
+	ui.ShowInfo(i18n.T("Downloading update..."))
+	var bar *ui.ProgressBar
 	err = updater.Update(release, func(current, total int64) {
-		percent := float64(current) / float64(total) * 100
-		fmt.Printf("\rDownloading: %.1f%% (%d/%d bytes)", percent, current, total)
+		if bar == nil {
+			bar = ui.NewProgressBar("Downloading", total)
+		}
+		bar.Set(current)
 	})
-	fmt.Println() // New line after progress
+	if bar != nil {
+		bar.Finish()
+	} else {
+		fmt.Println()
+	}
 
 	if err != nil {
-		ui.ShowError(fmt.Sprintf("Update failed: %v", err))
+		ui.ShowError(i18n.T("Update failed: %v", err))
 		if updater.HasBackup() {
-			ui.ShowInfo("You can rollback to the previous version with: ghex update --rollback")
+			ui.ShowInfo(i18n.T("You can rollback to the previous version with: ghex update --rollback"))
 		}
 		return
 	}
 
-	ui.ShowSuccess(fmt.Sprintf("Successfully updated to %s!", release.TagName))
-	ui.ShowInfo("Please restart ghex to use the new version")
+	ui.ShowSuccess(i18n.T("Successfully updated to %s!", release.TagName))
+	ui.ShowInfo(i18n.T("Please restart ghex to use the new version"))
+}
+
+// showCachedCheck reports an update check answered from the cache
+func showCachedCheck(cache *update.CheckCache) {
+	if !cache.HasUpdate(Version) {
+		ui.ShowSuccess(i18n.T("You're already running the latest version (v%s)", Version))
+	} else {
+		ui.ShowInfo(i18n.T("Current version: v%s", Version))
+		ui.ShowSuccess(i18n.T("Latest version:  %s", cache.LatestVersion))
+		fmt.Println()
+		ui.ShowInfo(i18n.T("Run 'ghex update' to install the latest version"))
+	}
+	ui.ShowInfo(i18n.T("Last checked %s (use --refresh to check now)", cache.CheckedAt.Local().Format("2006-01-02 15:04")))
 }
 
 func runRollback() {
 	updater, err := update.NewUpdater(Version)
 	if err != nil {
-		ui.ShowError(fmt.Sprintf("Failed to initialize updater: %v", err))
+		ui.ShowError(i18n.T("Failed to initialize updater: %v", err))
 		return
 	}
 
 	if !updater.HasBackup() {
-		ui.ShowError("No backup available for rollback")
+		ui.ShowError(i18n.T("No backup available for rollback"))
 		return
 	}
 
 	// Check permissions before rollback
 	permErr, err := update.CheckUpdatePermissions()
 	if err != nil {
-		ui.ShowError(fmt.Sprintf("Failed to check permissions: %v", err))
+		ui.ShowError(i18n.T("Failed to check permissions: %v", err))
 		return
 	}
 	if permErr != nil {
-		ui.ShowError(permErr.Instruction)
+		if confirmElevation(permErr) {
+			rerunElevated(permErr.Path)
+		}
 		return
 	}
 
 	// Confirm rollback
 	if !updateForce && !updateYes {
-		fmt.Print("Do you want to rollback to the previous version? [y/N]: ")
-		var response string
-		_, _ = fmt.Scanln(&response)
-		response = strings.ToLower(strings.TrimSpace(response))
-		if response != "y" && response != "yes" {
-			ui.ShowInfo("Rollback cancelled")
+		if !ui.Confirm(i18n.T("Do you want to rollback to the previous version?")) {
+			ui.ShowInfo(i18n.T("Rollback cancelled"))
 			return
 		}
 	}
 
-	ui.ShowInfo("Rolling back to previous version...")
+	ui.ShowInfo(i18n.T("Rolling back to previous version..."))
 	if err := updater.Rollback(); err != nil {
-		ui.ShowError(fmt.Sprintf("Rollback failed: %v", err))
+		ui.ShowError(i18n.T("Rollback failed: %v", err))
 		return
 	}
 
-	ui.ShowSuccess("Successfully rolled back to previous version!")
-	ui.ShowInfo("Please restart ghex to use the restored version")
+	ui.ShowSuccess(i18n.T("Successfully rolled back to previous version!"))
+	ui.ShowInfo(i18n.T("Please restart ghex to use the restored version"))
+}
+
+// runResume installs a binary handed off by updateElevated. It runs under
+// sudo and does nothing but verify the file and swap it in.
+func runResume() {
+	updater, err := update.NewUpdater(Version)
+	if err != nil {
+		ui.ShowError(i18n.T("Failed to initialize updater: %v", err))
+		os.Exit(1)
+	}
+
+	ui.ShowInfo(i18n.T("Installing update..."))
+	if err := updater.Resume(updateResume, updateResumeSum); err != nil {
+		ui.ShowError(i18n.T("Update failed: %v", err))
+		if updater.HasBackup() {
+			ui.ShowInfo(i18n.T("You can rollback to the previous version with: ghex update --rollback"))
+		}
+		os.Exit(1)
+	}
+}
+
+// confirmElevation handles an update or rollback that cannot write the
+// installed binary. It reports whether to continue with sudo: only
+// system-wide installs on Unix are offered that, everything else gets
+// instructions.
+func confirmElevation(permErr *update.PermissionError) bool {
+	if !permErr.NeedsSudo || !platform.CanElevate() {
+		ui.ShowError(permErr.Instruction)
+		return false
+	}
+
+	ui.ShowWarning(i18n.T("ghex is installed system-wide in %s", filepath.Dir(permErr.Path)))
+	if !updateYes && !updateForce && !ui.Confirm(i18n.T("Continue with sudo?")) {
+		ui.ShowInfo(permErr.Instruction)
+		return false
+	}
+	return true
+}
+
+// updateElevated downloads and verifies the release as the current user,
+// then runs only the install step with sudo. The installed (old) binary
+// performs that step, so nothing downloaded runs as root.
+func updateElevated(updater *update.Updater, release *update.ReleaseInfo, binaryPath string) {
+	warnAttestationPresenceOnly()
+	ui.ShowInfo(i18n.T("Downloading update..."))
+	var bar *ui.ProgressBar
+	newBinary, err := updater.Download(release, func(current, total int64) {
+		if bar == nil {
+			bar = ui.NewProgressBar("Downloading", total)
+		}
+		bar.Set(current)
+	})
+	if bar != nil {
+		bar.Finish()
+	} else {
+		fmt.Println()
+	}
+	if err != nil {
+		ui.ShowError(i18n.T("Update failed: %v", err))
+		return
+	}
+	defer os.RemoveAll(filepath.Dir(newBinary))
+
+	// Pin the file to what was just verified, so it cannot be swapped
+	// between this run and the privileged one
+	checksum, err := update.CalculateChecksum(newBinary)
+	if err != nil {
+		ui.ShowError(i18n.T("Update failed: %v", err))
+		return
+	}
+
+	if err := platform.RunElevated(binaryPath, "update", "--resume", newBinary, "--resume-sha256", checksum); err != nil {
+		ui.ShowError(i18n.T("sudo run failed: %v", err))
+		return
+	}
+
+	ui.ShowSuccess(i18n.T("Successfully updated to %s!", release.TagName))
+	ui.ShowInfo(i18n.T("Please restart ghex to use the new version"))
+}
+
+// warnAttestationPresenceOnly tells --require-attestation users when the
+// signature cannot be checked because gh is missing
+func warnAttestationPresenceOnly() {
+	if updateAttest && !update.CanVerifyAttestationSignature() {
+		ui.ShowWarning(i18n.T("gh is not installed: only checking that GitHub has an attestation for the release, not its signature"))
+	}
+}
+
+// rerunElevated re-runs the current command with sudo. The prompts were
+// answered here, so the privileged run skips them.
+func rerunElevated(binaryPath string) {
+	args := append(os.Args[1:], "--yes")
+	if err := platform.RunElevated(binaryPath, args...); err != nil {
+		ui.ShowError(i18n.T("sudo run failed: %v", err))
+		os.Exit(1)
+	}
 }
 
 func showChangelog(updater *update.Updater) {
 	releases, err := updater.GetChangelog(Version)
 	if err != nil {
-		ui.ShowError(fmt.Sprintf("Failed to fetch changelog: %v", err))
+		ui.ShowError(i18n.T("Failed to fetch changelog: %v", err))
 		return
 	}
 
 	if len(releases) == 0 {
-		ui.ShowInfo("No changelog available")
+		ui.ShowInfo(i18n.T("No changelog available"))
 		return
 	}
 
@@ -0,0 +1,157 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dwirx/ghex/internal/account"
+	"github.com/dwirx/ghex/internal/config"
+	"github.com/dwirx/ghex/internal/git"
+	"github.com/dwirx/ghex/internal/i18n"
+	"github.com/dwirx/ghex/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// Exit codes of ghex verify
+const (
+	verifyExitMismatch = 1 // The repository doesn't match the expected account
+	verifyExitError    = 2 // The check couldn't run
+)
+
+// verifyResult is what verify --ci prints
+type verifyResult struct {
+	OK        bool     `json:"ok"`
+	Repo      string   `json:"repo"`
+	Expected  string   `json:"expected,omitempty"`
+	Pinned    string   `json:"pinned,omitempty"`
+	UserName  string   `json:"userName,omitempty"`
+	UserEmail string   `json:"userEmail,omitempty"`
+	RemoteURL string   `json:"remoteUrl,omitempty"`
+	Problems  []string `json:"problems"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// NewVerifyCmd creates the verify command
+func NewVerifyCmd() *cobra.Command {
+	var repo, expect string
+	var ci bool
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: i18n.T("Check that a repository uses the expected account"),
+		Long: `Check a repository's git identity and origin remote against an account:
+user.name and user.email, the origin URL the account would set, a pin to
+another account, and whether the account can authenticate over the
+remote's protocol. Without --expect, the account the repository is pinned
+to is expected.
+
+The check only reads git config and never prompts, so it is safe in git
+hooks, also while a rebase or merge is in progress, and in CI jobs. It
+exits 0 when the repository matches, 1 when it doesn't and 2 when it
+can't be checked (not a repository, unknown account, nothing expected).
+--ci prints the result as JSON on stdout instead.
+
+Examples:
+  ghex verify --expect work
+  ghex verify --repo . --expect work --ci`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if code := runVerify(repo, expect, ci); code != 0 {
+				os.Exit(code)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&repo, "repo", ".", "Repository to check")
+	cmd.Flags().StringVar(&expect, "expect", "", "Account the repository must use (default: the pinned account)")
+	cmd.Flags().BoolVar(&ci, "ci", false, "Print the result as JSON and never prompt")
+
+	return cmd
+}
+
+// runVerify checks repo against the expected account and returns the exit
+// code
+func runVerify(repo, expect string, ci bool) int {
+	result := verifyResult{Repo: repo, Expected: expect, Problems: []string{}}
+	if abs, err := filepath.Abs(repo); err == nil {
+		result.Repo = abs
+	}
+
+	fail := func(msg string) int {
+		if ci {
+			result.Error = msg
+			printVerifyResult(result)
+		} else {
+			ui.ShowError(msg)
+		}
+		return verifyExitError
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fail(i18n.T("Failed to load config: %v", err))
+	}
+	if !git.IsGitRepo(repo) {
+		return fail(i18n.T("%s is not a git repository", result.Repo))
+	}
+
+	manager := account.NewManager(cfg)
+	if expect == "" {
+		expect = account.PinnedAccount(repo)
+		if expect == "" {
+			return fail(i18n.T("No account expected: pass --expect or pin the repository with 'ghex switch'"))
+		}
+		result.Expected = expect
+	}
+	if manager.Find(expect) == nil {
+		return fail(i18n.T("Account '%s' not found", expect))
+	}
+
+	check := manager.VerifyAccount(expect, repo)
+	result.OK = check.OK()
+	result.Pinned = check.Pinned
+	result.UserName = check.UserName
+	result.UserEmail = check.UserEmail
+	result.RemoteURL = check.RemoteURL
+	result.Problems = append(result.Problems, check.Problems...)
+
+	if ci {
+		printVerifyResult(result)
+	} else {
+		showVerifyResult(result)
+	}
+	if !result.OK {
+		return verifyExitMismatch
+	}
+	return 0
+}
+
+func printVerifyResult(result verifyResult) {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(result)
+}
+
+func showVerifyResult(result verifyResult) {
+	ui.ShowSection(i18n.T("Verify"))
+	ui.ShowKeyValue("Repository", result.Repo)
+	ui.ShowKeyValue("Expected", result.Expected)
+	ui.ShowKeyValue("Identity", fmt.Sprintf("%s <%s>", result.UserName, result.UserEmail))
+	if result.RemoteURL != "" {
+		ui.ShowKeyValue("Remote URL", result.RemoteURL)
+	}
+	fmt.Println()
+
+	if result.OK {
+		ui.ShowSuccess(i18n.T("Repository uses account '%s'", result.Expected))
+		return
+	}
+	ui.ShowError(i18n.T("Repository doesn't match account '%s'", result.Expected))
+	for _, problem := range result.Problems {
+		fmt.Printf("  %s %s\n", ui.Dim("•"), problem)
+	}
+	fmt.Println()
+	ui.ShowInfo(i18n.T("Run 'ghex switch %s' to fix it", result.Expected))
+}
@@ -3,6 +3,9 @@ package commands
 import (
 	"fmt"
 
+	"github.com/dwirx/ghex/internal/i18n"
+	"github.com/dwirx/ghex/internal/ui"
+	"github.com/dwirx/ghex/internal/update"
 	"github.com/spf13/cobra"
 )
 
@@ -10,7 +13,7 @@ import (
 func NewVersionCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "version",
-		Short: "Show version information",
+		Short: i18n.T("Show version information"),
 		Run: func(cmd *cobra.Command, args []string) {
 			showVersion()
 		},
@@ -23,4 +26,10 @@ func showVersion() {
 	fmt.Println("Interactive CLI tool for managing multiple GitHub accounts per repository")
 	fmt.Println()
 	fmt.Println("GitHub: https://github.com/dwirx/ghex")
+
+	// Only the cached check is used here; version never calls the API
+	if cache := update.LoadCheckCache(); cache != nil && cache.HasUpdate(Version) {
+		fmt.Println()
+		ui.ShowInfo(i18n.T("Update available: %s (run 'ghex update')", cache.LatestVersion))
+	}
 }
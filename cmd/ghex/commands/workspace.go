@@ -0,0 +1,479 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/dwirx/ghex/internal/account"
+	"github.com/dwirx/ghex/internal/config"
+	"github.com/dwirx/ghex/internal/git"
+	"github.com/dwirx/ghex/internal/i18n"
+	"github.com/dwirx/ghex/internal/platform"
+	"github.com/dwirx/ghex/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// NewWorkspaceCmd creates the workspace command
+func NewWorkspaceCmd() *cobra.Command {
+	var fix bool
+	var orgs []string
+	var cloneOpts workspaceCloneOptions
+
+	workspaceCmd := &cobra.Command{
+		Use:   "workspace",
+		Short: i18n.T("Group repositories in a directory under one account"),
+		Long: `A workspace is a directory whose git repositories all belong to one
+account. 'ghex workspace status' checks every repository found up to three
+levels below it and lists those with another identity, a pin to another
+account or an origin that has drifted from what 'ghex switch' would set.
+With --fix, or after confirming, they are switched to the workspace's
+account in one go. Repositories with unpushed commits or an unfinished
+rebase or merge are skipped.
+
+'ghex workspace clone' lists the repositories of the workspace's users or
+organizations through the platform API and clones the missing ones into
+<dir>/<owner>/<repo>, several at a time, set up for the workspace's
+account. Without organizations, the account's own repositories are used.
+
+Examples:
+  ghex workspace add ~/src/work work --org my-company
+  ghex workspace status
+  ghex workspace status ~/src/work --fix
+  ghex workspace clone --dry-run
+  ghex workspace clone ~/src/work --org another-org --jobs 8
+  ghex workspace remove ~/src/work`,
+		Run: func(cmd *cobra.Command, args []string) {
+			runWorkspaceStatus("", false)
+		},
+	}
+
+	addCmd := &cobra.Command{
+		Use:   "add <dir> <account>",
+		Short: i18n.T("Assign a directory to an account"),
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			runWorkspaceAdd(args[0], args[1], orgs)
+		},
+	}
+	addCmd.Flags().StringSliceVar(&orgs, "org", nil, "User or organization whose repositories belong here (repeatable)")
+	workspaceCmd.AddCommand(addCmd)
+
+	workspaceCmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: i18n.T("List workspaces"),
+		Run: func(cmd *cobra.Command, args []string) {
+			runWorkspaceList()
+		},
+	})
+
+	workspaceCmd.AddCommand(&cobra.Command{
+		Use:     "remove <dir>",
+		Aliases: []string{"rm"},
+		Short:   i18n.T("Forget a workspace"),
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runWorkspaceRemove(args[0])
+		},
+	})
+
+	statusCmd := &cobra.Command{
+		Use:   "status [dir]",
+		Short: i18n.T("Check the repositories of workspaces"),
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			dir := ""
+			if len(args) > 0 {
+				dir = args[0]
+			}
+			runWorkspaceStatus(dir, fix)
+		},
+	}
+	statusCmd.Flags().BoolVar(&fix, "fix", false, "Switch mismatched repositories without asking")
+	workspaceCmd.AddCommand(statusCmd)
+
+	cloneCmd := &cobra.Command{
+		Use:   "clone [dir]",
+		Short: i18n.T("Clone the missing repositories of workspaces"),
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			dir := ""
+			if len(args) > 0 {
+				dir = args[0]
+			}
+			runWorkspaceClone(dir, cloneOpts)
+		},
+	}
+	cloneCmd.Flags().StringSliceVar(&cloneOpts.orgs, "org", nil, "Clone from these users or organizations instead of the workspace's")
+	cloneCmd.Flags().IntVarP(&cloneOpts.jobs, "jobs", "j", 4, "Number of clones to run at once")
+	cloneCmd.Flags().BoolVar(&cloneOpts.archived, "archived", false, "Include archived repositories")
+	cloneCmd.Flags().BoolVar(&cloneOpts.dryRun, "dry-run", false, "Only list the repositories that would be cloned")
+	workspaceCmd.AddCommand(cloneCmd)
+
+	return workspaceCmd
+}
+
+// workspaceDir returns dir as a clean absolute path
+func workspaceDir(dir string) string {
+	dir = platform.ExpandPath(dir)
+	if abs, err := filepath.Abs(dir); err == nil {
+		return abs
+	}
+	return filepath.Clean(dir)
+}
+
+func runWorkspaceAdd(dir, accountName string, orgs []string) {
+	cfg, err := config.Load()
+	if err != nil {
+		ui.ShowError(i18n.T("Failed to load config: %v", err))
+		return
+	}
+
+	dir = workspaceDir(dir)
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		ui.ShowError(i18n.T("Not a directory: %s", dir))
+		return
+	}
+
+	manager := account.NewManager(cfg)
+	if err := manager.AddWorkspace(dir, accountName, orgs); err != nil {
+		ui.ShowError(err.Error())
+		return
+	}
+	if err := config.Save(cfg); err != nil {
+		ui.ShowError(i18n.T("Failed to save config: %v", err))
+		return
+	}
+
+	ui.ShowSuccess(i18n.T("Workspace %s uses account '%s' (%d repositories)", dir, accountName, len(account.FindRepos(dir))))
+	ui.ShowInfo(i18n.T("Check it with: ghex workspace status"))
+}
+
+func runWorkspaceList() {
+	cfg, err := config.Load()
+	if err != nil {
+		ui.ShowError(i18n.T("Failed to load config: %v", err))
+		return
+	}
+	if len(cfg.Workspaces) == 0 {
+		ui.ShowInfo(i18n.T("No workspaces defined. Add one with: ghex workspace add <dir> <account>"))
+		return
+	}
+
+	ui.ShowSection(i18n.T("Workspaces"))
+	for _, ws := range cfg.Workspaces {
+		orgs := ""
+		if len(ws.Orgs) > 0 {
+			orgs = ui.Dim(" (" + strings.Join(ws.Orgs, ", ") + ")")
+		}
+		fmt.Printf("  %s %s %s%s\n", ws.Dir, ui.Dim("→"), ui.Primary(ws.Account), orgs)
+	}
+}
+
+func runWorkspaceRemove(dir string) {
+	cfg, err := config.Load()
+	if err != nil {
+		ui.ShowError(i18n.T("Failed to load config: %v", err))
+		return
+	}
+
+	dir = workspaceDir(dir)
+	if err := account.NewManager(cfg).RemoveWorkspace(dir); err != nil {
+		ui.ShowError(err.Error())
+		return
+	}
+	if err := config.Save(cfg); err != nil {
+		ui.ShowError(i18n.T("Failed to save config: %v", err))
+		return
+	}
+	ui.ShowSuccess(i18n.T("Removed workspace %s", dir))
+}
+
+// workspaceRepo is a repository that does not match its workspace's account
+type workspaceRepo struct {
+	path  string
+	ws    config.Workspace
+	check *account.IdentityCheck
+}
+
+// selectWorkspaces returns every workspace, or only the one at dir. It
+// reports the problem and returns nil when there is none.
+func selectWorkspaces(cfg *config.AppConfig, dir string) []config.Workspace {
+	workspaces := cfg.Workspaces
+	if dir != "" {
+		ws := account.FindWorkspace(cfg, workspaceDir(dir))
+		if ws == nil {
+			ui.ShowError(i18n.T("No workspace at %s", workspaceDir(dir)))
+			return nil
+		}
+		workspaces = []config.Workspace{*ws}
+	}
+	if len(workspaces) == 0 {
+		ui.ShowInfo(i18n.T("No workspaces defined. Add one with: ghex workspace add <dir> <account>"))
+	}
+	return workspaces
+}
+
+// runWorkspaceStatus checks the repositories of every workspace, or only of
+// the one at dir, and offers to switch the mismatched ones
+func runWorkspaceStatus(dir string, fix bool) {
+	cfg, err := config.Load()
+	if err != nil {
+		ui.ShowError(i18n.T("Failed to load config: %v", err))
+		return
+	}
+
+	workspaces := selectWorkspaces(cfg, dir)
+	if len(workspaces) == 0 {
+		return
+	}
+
+	manager := account.NewManager(cfg)
+	var mismatched []workspaceRepo
+	for _, ws := range workspaces {
+		ui.ShowSection(fmt.Sprintf("%s → %s", ws.Dir, ws.Account))
+		repos := account.FindRepos(ws.Dir)
+		if len(repos) == 0 {
+			fmt.Printf("  %s\n", ui.Muted(i18n.T("No repositories found")))
+			continue
+		}
+		for _, repo := range repos {
+			name := repo
+			if rel, err := filepath.Rel(ws.Dir, repo); err == nil {
+				name = rel
+			}
+			check := manager.VerifyAccount(ws.Account, repo)
+			if check.OK() {
+				fmt.Printf("  %s %s\n", ui.Success("✓"), name)
+				continue
+			}
+			fmt.Printf("  %s %s\n", ui.Error("✗"), name)
+			for _, problem := range check.Problems {
+				fmt.Printf("      %s %s\n", ui.Dim("•"), problem)
+			}
+			mismatched = append(mismatched, workspaceRepo{path: repo, ws: ws, check: check})
+		}
+	}
+	fmt.Println()
+
+	if len(mismatched) == 0 {
+		ui.ShowSuccess(i18n.T("All repositories use their workspace's account"))
+		return
+	}
+	if !fix && !ui.Confirm(i18n.T("Switch %d repositories to their workspace's account?", len(mismatched))) {
+		return
+	}
+	fixWorkspaceRepos(cfg, manager, mismatched)
+}
+
+// fixWorkspaceRepos switches repositories to their workspace's account,
+// skipping those where rewriting the remote could lose work
+func fixWorkspaceRepos(cfg *config.AppConfig, manager *account.Manager, repos []workspaceRepo) {
+	fixed := 0
+	for _, r := range repos {
+		if r.check.Account == nil {
+			ui.ShowWarning(i18n.T("Skipped %s: account '%s' not found", r.path, r.ws.Account))
+			continue
+		}
+
+		safety, err := account.CheckSwitchSafety(r.path)
+		if err != nil {
+			ui.ShowWarning(i18n.T("Skipped %s: %v", r.path, err))
+			continue
+		}
+		if safety.Operation != "" {
+			ui.ShowWarning(i18n.T("Skipped %s: a %s is in progress", r.path, safety.Operation))
+			continue
+		}
+		if len(safety.Unpushed) > 0 {
+			ui.ShowWarning(i18n.T("Skipped %s: unpushed commits (run 'ghex switch %s' inside it)", r.path, r.ws.Account))
+			continue
+		}
+
+		method := r.check.FixMethod()
+		if err := manager.Switch(r.ws.Account, method, r.path); err != nil {
+			ui.ShowError(fmt.Sprintf("%s: %v", r.path, err))
+			continue
+		}
+		if err := account.RetargetBranches(safety.Stale, r.path); err != nil {
+			ui.ShowWarning(err.Error())
+		}
+		ui.ShowSuccess(i18n.T("Switched %s to %s (%s)", r.path, r.ws.Account, method))
+		fixed++
+	}
+
+	if err := config.Save(cfg); err != nil {
+		ui.ShowWarning(i18n.T("Failed to save config: %v", err))
+	}
+	fmt.Println()
+	ui.ShowInfo(i18n.T("Fixed %d of %d repositories", fixed, len(repos)))
+}
+
+// workspaceCloneOptions holds the flags of 'ghex workspace clone'
+type workspaceCloneOptions struct {
+	orgs     []string
+	jobs     int
+	archived bool
+	dryRun   bool
+}
+
+// runWorkspaceClone clones the repositories of each workspace's users or
+// organizations that are not checked out in it yet
+func runWorkspaceClone(dir string, opts workspaceCloneOptions) {
+	cfg, err := config.Load()
+	if err != nil {
+		ui.ShowError(i18n.T("Failed to load config: %v", err))
+		return
+	}
+
+	workspaces := selectWorkspaces(cfg, dir)
+	if len(workspaces) == 0 {
+		return
+	}
+
+	manager := account.NewManager(cfg)
+	for _, ws := range workspaces {
+		ui.ShowSection(fmt.Sprintf("%s → %s", ws.Dir, ws.Account))
+		acc := manager.Find(ws.Account)
+		if acc == nil {
+			ui.ShowError(i18n.T("Account '%s' not found", ws.Account))
+			continue
+		}
+
+		orgs := opts.orgs
+		if len(orgs) == 0 {
+			orgs = ws.Orgs
+		}
+		if len(orgs) == 0 && acc.Token != nil && acc.Token.Username != "" {
+			orgs = []string{acc.Token.Username}
+		}
+		if len(orgs) == 0 {
+			ui.ShowError(i18n.T("No organizations to clone from; pass --org or add them with 'ghex workspace add %s %s --org <name>'", ws.Dir, ws.Account))
+			continue
+		}
+
+		missing := missingWorkspaceRepos(ws, acc, orgs, opts.archived)
+		if len(missing) == 0 {
+			ui.ShowSuccess(i18n.T("Nothing to clone"))
+			continue
+		}
+		for _, repo := range missing {
+			fmt.Printf("  %s %s\n", ui.Success("+"), repo)
+		}
+		fmt.Println()
+		if opts.dryRun {
+			continue
+		}
+
+		cloned := cloneWorkspaceRepos(manager, ws, acc, missing, opts.jobs)
+		fmt.Println()
+		ui.ShowInfo(i18n.T("Cloned %d of %d repositories", cloned, len(missing)))
+	}
+
+	if err := config.Save(cfg); err != nil {
+		ui.ShowWarning(i18n.T("Failed to save config: %v", err))
+	}
+}
+
+// missingWorkspaceRepos lists the repositories of orgs that have no clone
+// in the workspace yet, as owner/repo names
+func missingWorkspaceRepos(ws config.Workspace, acc *config.Account, orgs []string, archived bool) []string {
+	listOpts := account.RepoListOptions(acc)
+	local := account.FindRepos(ws.Dir)
+
+	var missing []string
+	for _, org := range orgs {
+		spinner := ui.NewSpinner(i18n.T("Listing repositories of %s...", org))
+		spinner.Start()
+		repos, err := git.ListRepos(org, listOpts)
+		if err != nil {
+			spinner.StopWithError(err.Error())
+			continue
+		}
+		spinner.StopWithSuccess(i18n.T("%s: %d repositories", org, len(repos)))
+
+		var wanted []git.RemoteRepo
+		for _, repo := range repos {
+			if archived || !repo.Archived {
+				wanted = append(wanted, repo)
+			}
+		}
+		for _, repo := range account.MissingRepos(acc, wanted, local) {
+			missing = append(missing, repo.FullName)
+		}
+	}
+	return missing
+}
+
+// cloneWorkspaceRepos clones repos into <dir>/<owner>/<repo>, jobs at a
+// time, and switches each clone to the workspace's account. It returns the
+// number cloned.
+func cloneWorkspaceRepos(manager *account.Manager, ws config.Workspace, acc *config.Account, repos []string, jobs int) int {
+	method := account.MethodSSH
+	if acc.SSH == nil && acc.Token != nil {
+		method = account.MethodToken
+	}
+	// Credentials and the SSH host must be in place before the first clone
+	if err := manager.PrepareAuth(acc.Name, method); err != nil {
+		ui.ShowError(i18n.T("Failed to set up account: %v", err))
+		return 0
+	}
+	var configs []string
+	if method == account.MethodToken {
+		configs = append(configs, "credential.helper=store")
+	}
+	if jobs < 1 {
+		jobs = 1
+	}
+
+	var mu sync.Mutex
+	var clonedDirs []string
+	work := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < jobs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for repo := range work {
+				target := filepath.Join(ws.Dir, filepath.FromSlash(repo))
+				err := cloneInto(account.RemoteURL(acc, method, repo), target, configs)
+
+				mu.Lock()
+				if err != nil {
+					ui.ShowError(fmt.Sprintf("%s: %v", repo, err))
+				} else {
+					ui.ShowSuccess(i18n.T("Cloned %s", repo))
+					clonedDirs = append(clonedDirs, target)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, repo := range repos {
+		work <- repo
+	}
+	close(work)
+	wg.Wait()
+
+	// Identity, pin and credentials are set one repository at a time since
+	// Switch records activity in the shared config
+	for _, dir := range clonedDirs {
+		if err := manager.Switch(acc.Name, method, dir); err != nil {
+			ui.ShowWarning(i18n.T("Failed to set up account in %s: %v", dir, err))
+		}
+	}
+	return len(clonedDirs)
+}
+
+// cloneInto clones repoURL to target, creating its parent directories
+func cloneInto(repoURL, target string, configs []string) error {
+	if _, err := os.Stat(target); err == nil {
+		return fmt.Errorf("%s already exists", target)
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	return git.CloneNonInteractive(repoURL, target, configs...)
+}
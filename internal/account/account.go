@@ -3,6 +3,8 @@ package account
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -31,6 +33,12 @@ func (m *Manager) Add(account config.Account) error {
 		}
 	}
 
+	now := time.Now().UTC().Format(time.RFC3339)
+	if account.CreatedAt == "" {
+		account.CreatedAt = now
+	}
+	account.UpdatedAt = now
+
 	m.cfg.Accounts = append(m.cfg.Accounts, account)
 	return nil
 }
@@ -61,10 +69,15 @@ func (m *Manager) List() []config.Account {
 	return m.cfg.Accounts
 }
 
-// Update updates an existing account
+// Update updates an existing account, keeping its creation time and
+// refreshing its modification time
 func (m *Manager) Update(name string, updates config.Account) error {
 	for i, a := range m.cfg.Accounts {
 		if strings.EqualFold(a.Name, name) {
+			if updates.CreatedAt == "" {
+				updates.CreatedAt = a.CreatedAt
+			}
+			updates.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
 			m.cfg.Accounts[i] = updates
 			return nil
 		}
@@ -72,6 +85,27 @@ func (m *Manager) Update(name string, updates config.Account) error {
 	return fmt.Errorf("account '%s' not found", name)
 }
 
+// RecentlyAdded returns the accounts sorted newest first by creation time.
+// Accounts from before timestamps were recorded keep their order at the end.
+func RecentlyAdded(accounts []config.Account) []config.Account {
+	sorted := make([]config.Account, len(accounts))
+	copy(sorted, accounts)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		// RFC3339 UTC timestamps sort lexically; "" sorts last
+		return sorted[i].CreatedAt > sorted[j].CreatedAt
+	})
+	return sorted
+}
+
+// ClonePath returns where a repository should be cloned for an account:
+// <cloneDir>/<owner>/<repo>, or "" if the account has no clone directory
+func ClonePath(acc *config.Account, owner, repo string) string {
+	if acc == nil || acc.CloneDir == "" {
+		return ""
+	}
+	return filepath.Join(platform.ExpandPath(acc.CloneDir), filepath.FromSlash(owner), repo)
+}
+
 // SwitchMethod represents the authentication method to use
 type SwitchMethod string
 
@@ -80,31 +114,29 @@ const (
 	MethodToken SwitchMethod = "token"
 )
 
-// Switch switches the current repository to use a specific account
-func (m *Manager) Switch(accountName string, method SwitchMethod, repoPath string) error {
-	account := m.Find(accountName)
-	if account == nil {
-		return fmt.Errorf("account '%s' not found", accountName)
-	}
-
-	if repoPath == "" {
-		repoPath = "."
+// RemoteURL returns the origin URL Switch sets for repoFullPath (owner/repo)
+// when switching to acc with method
+func RemoteURL(acc *config.Account, method SwitchMethod, repoFullPath string) string {
+	platformType := "github"
+	domain := ""
+	if acc.Platform != nil {
+		platformType = acc.Platform.Type
+		domain = acc.Platform.Domain
 	}
-
-	// Get current remote URL to extract owner/repo
-	remoteURL, err := git.GetRemoteURL("origin", repoPath)
-	if err != nil {
-		return fmt.Errorf("failed to get remote URL: %w", err)
+	if method == MethodSSH {
+		return git.BuildRemoteURLWithPort(platformType, domain, repoFullPath, true, ssh.OptionsForAccount(acc).Port)
 	}
+	return git.BuildRemoteURL(platformType, domain, repoFullPath, false)
+}
 
-	owner, repo, err := git.ParseRepoFromURL(remoteURL)
-	if err != nil {
-		return fmt.Errorf("failed to parse remote URL: %w", err)
+// PrepareAuth sets up what method needs outside a repository: the SSH key
+// and SSH config host block, or the stored token credentials
+func (m *Manager) PrepareAuth(accountName string, method SwitchMethod) error {
+	account := m.Find(accountName)
+	if account == nil {
+		return fmt.Errorf("account '%s' not found", accountName)
 	}
 
-	repoFullPath := fmt.Sprintf("%s/%s", owner, repo)
-
-	// Get platform info
 	platformType := "github"
 	domain := ""
 	if account.Platform != nil {
@@ -128,48 +160,103 @@ func (m *Manager) Switch(accountName string, method SwitchMethod, repoPath strin
 		}
 
 		// Configure SSH host
+		sshOpts := ssh.OptionsForAccount(account)
 		sshHost := git.GetPlatformSSHHost(platformType, domain)
-		if err := ssh.EnsureConfigBlock(sshHost, keyPath, sshHost); err != nil {
+		if err := ssh.EnsureConfigBlockWithOptions(sshHost, keyPath, sshHost, sshOpts); err != nil {
 			return fmt.Errorf("failed to configure SSH: %w", err)
 		}
 
-		// Set remote URL to SSH format
-		newURL := git.BuildRemoteURL(platformType, domain, repoFullPath, true)
-		if err := git.SetRemoteURL(newURL, "origin", repoPath); err != nil {
-			return fmt.Errorf("failed to set remote URL: %w", err)
-		}
-
 	case MethodToken:
 		if account.Token == nil {
 			return fmt.Errorf("account '%s' has no token configuration", accountName)
 		}
 
-		// Set up credential store
-		if err := git.EnsureCredentialStore(); err != nil {
-			return fmt.Errorf("failed to set up credential store: %w", err)
-		}
-
 		// Write credentials
 		host := git.GetPlatformSSHHost(platformType, domain)
 		if err := git.WriteCredentials(account.Token.Username, account.Token.Token, host); err != nil {
 			return fmt.Errorf("failed to write credentials: %w", err)
 		}
 
-		// Set remote URL to HTTPS format
-		newURL := git.BuildRemoteURL(platformType, domain, repoFullPath, false)
-		if err := git.SetRemoteURL(newURL, "origin", repoPath); err != nil {
-			return fmt.Errorf("failed to set remote URL: %w", err)
-		}
-
 	default:
 		return fmt.Errorf("unknown method: %s", method)
 	}
+	return nil
+}
+
+// Switch switches the current repository to use a specific account
+func (m *Manager) Switch(accountName string, method SwitchMethod, repoPath string) error {
+	return m.SwitchWithEmail(accountName, method, repoPath, "")
+}
+
+// SwitchWithEmail is Switch committing as email, one of the account's
+// addresses. Empty keeps the repository's user.email when it is one of
+// them, and uses gitEmail otherwise.
+func (m *Manager) SwitchWithEmail(accountName string, method SwitchMethod, repoPath, email string) error {
+	account := m.Find(accountName)
+	if account == nil {
+		return fmt.Errorf("account '%s' not found", accountName)
+	}
+
+	if repoPath == "" {
+		repoPath = "."
+	}
+
+	if email == "" {
+		email = account.GitEmail
+		if _, current, _ := git.GetCurrentUser(repoPath); account.HasEmail(current) {
+			email = current
+		}
+	} else if !account.HasEmail(email) {
+		return fmt.Errorf("'%s' is not an email of account '%s'", email, accountName)
+	}
+
+	// Get current remote URL to extract owner/repo
+	remoteURL, err := git.GetRemoteURL("origin", repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to get remote URL: %w", err)
+	}
+
+	owner, repo, err := git.ParseRepoFromURL(remoteURL)
+	if err != nil {
+		return fmt.Errorf("failed to parse remote URL: %w", err)
+	}
+
+	repoFullPath := fmt.Sprintf("%s/%s", owner, repo)
+
+	if method == MethodToken {
+		if account.Token == nil {
+			return fmt.Errorf("account '%s' has no token configuration", accountName)
+		}
+
+		// Set up credential store
+		if err := git.EnsureCredentialStore(repoPath); err != nil {
+			return fmt.Errorf("failed to set up credential store: %w", err)
+		}
+	}
+	if err := m.PrepareAuth(accountName, method); err != nil {
+		return err
+	}
+
+	// Set remote URL to the method's format (SSH or HTTPS)
+	if err := git.RewriteRemoteURL(RemoteURL(account, method, repoFullPath), "origin", repoPath); err != nil {
+		return fmt.Errorf("failed to set remote URL: %w", err)
+	}
 
 	// Set local git identity
-	if err := git.SetLocalIdentity(account.GitUserName, account.GitEmail, repoPath); err != nil {
+	if err := git.SetLocalIdentity(account.GitUserName, email, repoPath); err != nil {
 		return fmt.Errorf("failed to set git identity: %w", err)
 	}
 
+	// Pin the repository to this account so push/pull/fetch can verify it
+	if err := PinAccount(accountName, repoPath); err != nil {
+		return err
+	}
+
+	platformType := "github"
+	if account.Platform != nil {
+		platformType = account.Platform.Type
+	}
+
 	// Log activity
 	m.LogActivity(config.ActivityLogEntry{
 		Timestamp:   time.Now().UTC().Format(time.RFC3339),
@@ -1,6 +1,7 @@
 package account
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/dwirx/ghex/internal/config"
@@ -261,3 +262,89 @@ func TestSwitchMethodConstants(t *testing.T) {
 		t.Errorf("Expected MethodToken to be 'token', got '%s'", MethodToken)
 	}
 }
+
+// TestIsAmbiguous tests tie detection between detection candidates
+func TestIsAmbiguous(t *testing.T) {
+	tests := []struct {
+		name       string
+		candidates []MatchScore
+		expected   bool
+	}{
+		{"none", nil, false},
+		{"single", []MatchScore{{AccountName: "a", Score: 50}}, false},
+		{"clear winner", []MatchScore{{AccountName: "a", Score: 80}, {AccountName: "b", Score: 50}}, false},
+		{"tie", []MatchScore{{AccountName: "a", Score: 50}, {AccountName: "b", Score: 50}}, true},
+		{"tie with pin", []MatchScore{
+			{AccountName: "a", Score: 50, MatchedFields: []string{"platform", "pinned"}},
+			{AccountName: "b", Score: 50},
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsAmbiguous(tt.candidates); got != tt.expected {
+				t.Errorf("IsAmbiguous() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestClonePath tests per-account clone locations
+func TestClonePath(t *testing.T) {
+	acc := &config.Account{Name: "work", CloneDir: "/src/work"}
+
+	if got := ClonePath(acc, "org", "repo"); got != filepath.Join("/src/work", "org", "repo") {
+		t.Errorf("Unexpected clone path: %s", got)
+	}
+	if got := ClonePath(acc, "group/sub", "repo"); got != filepath.Join("/src/work", "group", "sub", "repo") {
+		t.Errorf("Unexpected nested clone path: %s", got)
+	}
+	if got := ClonePath(&config.Account{Name: "personal"}, "org", "repo"); got != "" {
+		t.Errorf("Expected no clone path without a clone directory, got %s", got)
+	}
+}
+
+// TestAccountTimestamps tests that the manager stamps added and updated accounts
+func TestAccountTimestamps(t *testing.T) {
+	cfg := config.NewAppConfig()
+	manager := NewManager(cfg)
+
+	_ = manager.Add(config.Account{Name: "work"})
+	acc := manager.Find("work")
+	if acc.CreatedAt == "" || acc.UpdatedAt != acc.CreatedAt {
+		t.Fatalf("Expected matching createdAt/updatedAt, got %q/%q", acc.CreatedAt, acc.UpdatedAt)
+	}
+
+	created := "2020-01-01T00:00:00Z"
+	acc.CreatedAt = created
+	if err := manager.Update("work", config.Account{Name: "work", Notes: "laptop"}); err != nil {
+		t.Fatalf("Failed to update account: %v", err)
+	}
+	acc = manager.Find("work")
+	if acc.CreatedAt != created {
+		t.Errorf("Expected createdAt to be kept, got %q", acc.CreatedAt)
+	}
+	if acc.UpdatedAt <= created {
+		t.Errorf("Expected updatedAt to be refreshed, got %q", acc.UpdatedAt)
+	}
+}
+
+// TestRecentlyAdded tests sorting accounts newest first
+func TestRecentlyAdded(t *testing.T) {
+	accounts := []config.Account{
+		{Name: "legacy"},
+		{Name: "old", CreatedAt: "2023-01-01T00:00:00Z"},
+		{Name: "new", CreatedAt: "2024-06-01T00:00:00Z"},
+	}
+
+	sorted := RecentlyAdded(accounts)
+	want := []string{"new", "old", "legacy"}
+	for i, name := range want {
+		if sorted[i].Name != name {
+			t.Errorf("Position %d: expected %s, got %s", i, name, sorted[i].Name)
+		}
+	}
+	if accounts[0].Name != "legacy" {
+		t.Error("Expected the input slice to be left unsorted")
+	}
+}
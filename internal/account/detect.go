@@ -1,10 +1,14 @@
 package account
 
 import (
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/dwirx/ghex/internal/config"
 	"github.com/dwirx/ghex/internal/git"
+	"github.com/dwirx/ghex/internal/platform"
+	"github.com/dwirx/ghex/internal/ssh"
 )
 
 // Scoring weights for active account detection
@@ -26,13 +30,24 @@ type MatchScore struct {
 
 // DetectActiveWithScore returns the best matching account with confidence score
 func (m *Manager) DetectActiveWithScore(repoPath string) (*MatchScore, error) {
+	candidates := m.DetectCandidates(repoPath)
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	return &candidates[0], nil
+}
+
+// DetectCandidates scores every account against the repository and returns
+// those above MinConfidenceScore, best first. The account the repository
+// is pinned to wins ties.
+func (m *Manager) DetectCandidates(repoPath string) []MatchScore {
 	if repoPath == "" {
 		repoPath = "."
 	}
 
 	// Check if we're in a git repository
 	if !git.IsGitRepo(repoPath) {
-		return nil, nil
+		return nil
 	}
 
 	// Get current git user and remote info
@@ -40,15 +55,28 @@ func (m *Manager) DetectActiveWithScore(repoPath string) (*MatchScore, error) {
 	remoteURL, _ := git.GetRemoteURL("origin", repoPath)
 
 	if userName == "" && userEmail == "" && remoteURL == "" {
-		return nil, nil
+		return nil
 	}
 
 	// Determine auth type and platform from remote URL
 	isSSH := strings.HasPrefix(remoteURL, "git@") || strings.HasPrefix(remoteURL, "ssh://")
 	detectedPlatform := DetectPlatformFromURL(remoteURL)
+	pinned := PinnedAccount(repoPath)
+
+	// What git would actually authenticate with
+	var identityFiles []string
+	storedUser := ""
+	if urlInfo, err := git.ParseURL(remoteURL); err == nil {
+		if isSSH {
+			if resolved, err := ssh.ResolveConfig("git@"+urlInfo.Host, urlInfo.Port); err == nil {
+				identityFiles = resolved.IdentityFiles
+			}
+		} else {
+			storedUser = git.StoredCredentialUser(urlInfo.Host)
+		}
+	}
 
-	var bestMatch *MatchScore
-
+	var candidates []MatchScore
 	for _, account := range m.cfg.Accounts {
 		score := 0
 		matchedFields := []string{}
@@ -62,20 +90,31 @@ func (m *Manager) DetectActiveWithScore(repoPath string) (*MatchScore, error) {
 		}
 
 		// Check git user.email match (30 points)
-		if account.GitEmail != "" && userEmail != "" {
-			if strings.EqualFold(account.GitEmail, userEmail) {
+		if userEmail != "" {
+			if account.HasEmail(userEmail) {
 				score += ScoreUserEmail
 				matchedFields = append(matchedFields, "user.email")
 			}
 		}
 
-		// Check SSH key in use (20 points)
+		// Check the key or credential in use (20 points, 10 if the account
+		// merely has the right auth type)
 		if isSSH && account.SSH != nil {
-			score += ScoreSSHKey
-			matchedFields = append(matchedFields, "ssh")
+			if containsKey(identityFiles, account.SSH.KeyPath) {
+				score += ScoreSSHKey
+				matchedFields = append(matchedFields, "ssh key in use")
+			} else {
+				score += ScoreSSHKey / 2
+				matchedFields = append(matchedFields, "ssh")
+			}
 		} else if !isSSH && account.Token != nil {
-			score += ScoreSSHKey
-			matchedFields = append(matchedFields, "token")
+			if storedUser != "" && strings.EqualFold(storedUser, account.Token.Username) {
+				score += ScoreSSHKey
+				matchedFields = append(matchedFields, "credential stored")
+			} else {
+				score += ScoreSSHKey / 2
+				matchedFields = append(matchedFields, "token")
+			}
 		}
 
 		// Check platform match (20 points)
@@ -88,80 +127,62 @@ func (m *Manager) DetectActiveWithScore(repoPath string) (*MatchScore, error) {
 			matchedFields = append(matchedFields, "platform")
 		}
 
-		// Update best match if this score is higher
-		if score >= MinConfidenceScore && (bestMatch == nil || score > bestMatch.Score) {
-			bestMatch = &MatchScore{
+		if account.Name == pinned {
+			matchedFields = append(matchedFields, "pinned")
+		}
+
+		if score >= MinConfidenceScore {
+			candidates = append(candidates, MatchScore{
 				AccountName:   account.Name,
 				Score:         score,
 				MatchedFields: matchedFields,
 				IsActive:      true,
-			}
+			})
 		}
 	}
 
-	return bestMatch, nil
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].AccountName == pinned
+	})
+	return candidates
 }
 
-// DetectActive detects the currently active account for a repository
-func (m *Manager) DetectActive(repoPath string) (string, error) {
-	if repoPath == "" {
-		repoPath = "."
+// IsAmbiguous reports whether the best candidates tie and none of them is
+// the pinned account, so picking one would be a guess
+func IsAmbiguous(candidates []MatchScore) bool {
+	if len(candidates) < 2 || candidates[0].Score != candidates[1].Score {
+		return false
 	}
-
-	// Check if we're in a git repository
-	if !git.IsGitRepo(repoPath) {
-		return "", nil
+	for _, field := range candidates[0].MatchedFields {
+		if field == "pinned" {
+			return false
+		}
 	}
+	return true
+}
 
-	// Get current git user and remote info
-	userName, userEmail, _ := git.GetCurrentUser(repoPath)
-	remoteURL, _ := git.GetRemoteURL("origin", repoPath)
-
-	if userName == "" && userEmail == "" && remoteURL == "" {
+// DetectActive detects the currently active account for a repository
+// Returns "" when no account matches or several match equally well
+func (m *Manager) DetectActive(repoPath string) (string, error) {
+	candidates := m.DetectCandidates(repoPath)
+	if len(candidates) == 0 || IsAmbiguous(candidates) {
 		return "", nil
 	}
+	return candidates[0].AccountName, nil
+}
 
-	// Determine auth type from remote URL
-	isSSH := strings.HasPrefix(remoteURL, "git@") || strings.HasPrefix(remoteURL, "ssh://")
-
-	// Try to match account based on git identity and remote URL
-	for _, account := range m.cfg.Accounts {
-		matches := 0
-		totalChecks := 0
-
-		// Check git identity match
-		if account.GitUserName != "" {
-			totalChecks++
-			if strings.EqualFold(userName, account.GitUserName) {
-				matches++
-			}
-		}
-
-		if account.GitEmail != "" {
-			totalChecks++
-			if strings.EqualFold(userEmail, account.GitEmail) {
-				matches++
-			}
-		}
-
-		// Check remote URL type match (SSH vs HTTPS)
-		totalChecks++
-		if isSSH && account.SSH != nil {
-			matches++
-		} else if !isSSH && account.Token != nil {
-			matches++
-		}
-
-		// If we have matches and they represent a significant portion
-		if matches > 0 && totalChecks > 0 {
-			threshold := (totalChecks + 1) / 2 // Ceiling division
-			if matches >= threshold {
-				return account.Name, nil
-			}
+// containsKey reports whether keyPath is among ssh's identity files
+func containsKey(identityFiles []string, keyPath string) bool {
+	want := filepath.Clean(platform.ExpandPath(keyPath))
+	for _, f := range identityFiles {
+		if filepath.Clean(platform.ExpandPath(f)) == want {
+			return true
 		}
 	}
-
-	return "", nil
+	return false
 }
 
 // DetectActiveAccount is a convenience function
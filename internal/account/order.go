@@ -0,0 +1,83 @@
+package account
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dwirx/ghex/internal/config"
+)
+
+// Account sort orders for selectors
+const (
+	SortManual       = "manual"       // Order of the config file
+	SortRecent       = "recent"       // Most recently switched to first
+	SortAlphabetical = "alphabetical" // By name
+	SortAdded        = "added"        // Most recently added first
+)
+
+// SortOrders lists the valid account sort orders
+var SortOrders = []string{SortManual, SortRecent, SortAlphabetical, SortAdded}
+
+// ValidateSortOrder checks that order is one of SortOrders
+func ValidateSortOrder(order string) error {
+	for _, o := range SortOrders {
+		if order == o {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown sort order '%s' (use %s)", order, strings.Join(SortOrders, ", "))
+}
+
+// SortForSelection orders accounts for a selector: the account detected
+// for the current repository first, then favorites, then everything else
+// by the configured sort order. The returned pointers refer to the same
+// accounts as the input.
+func SortForSelection(cfg *config.AppConfig, accounts []*config.Account, detected string) []*config.Account {
+	sorted := make([]*config.Account, len(accounts))
+	copy(sorted, accounts)
+
+	var less func(a, b *config.Account) bool
+	switch cfg.AccountSort {
+	case SortRecent:
+		lastUsed := lastSwitched(cfg)
+		less = func(a, b *config.Account) bool { return lastUsed[a.Name] > lastUsed[b.Name] }
+	case SortAlphabetical:
+		less = func(a, b *config.Account) bool { return strings.ToLower(a.Name) < strings.ToLower(b.Name) }
+	case SortAdded:
+		less = func(a, b *config.Account) bool { return a.CreatedAt > b.CreatedAt }
+	default:
+		less = func(a, b *config.Account) bool { return false }
+	}
+
+	rank := func(a *config.Account) int {
+		switch {
+		case detected != "" && strings.EqualFold(a.Name, detected):
+			return 0
+		case a.Favorite:
+			return 1
+		default:
+			return 2
+		}
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ri, rj := rank(sorted[i]), rank(sorted[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return less(sorted[i], sorted[j])
+	})
+	return sorted
+}
+
+// lastSwitched returns the time of each account's latest successful switch
+func lastSwitched(cfg *config.AppConfig) map[string]string {
+	last := map[string]string{}
+	for _, e := range cfg.ActivityLog {
+		if e.Action == "switch" && e.Success && e.Timestamp > last[e.AccountName] {
+			last[e.AccountName] = e.Timestamp
+		}
+	}
+	return last
+}
@@ -0,0 +1,71 @@
+package account
+
+import (
+	"testing"
+
+	"github.com/dwirx/ghex/internal/config"
+)
+
+func selectionNames(cfg *config.AppConfig, detected string) []string {
+	accounts := make([]*config.Account, len(cfg.Accounts))
+	for i := range cfg.Accounts {
+		accounts[i] = &cfg.Accounts[i]
+	}
+	var names []string
+	for _, acc := range SortForSelection(cfg, accounts, detected) {
+		names = append(names, acc.Name)
+	}
+	return names
+}
+
+// TestSortForSelection tests detected-first, favorites-next ordering
+func TestSortForSelection(t *testing.T) {
+	cfg := config.NewAppConfig()
+	cfg.Accounts = []config.Account{
+		{Name: "zeta", CreatedAt: "2024-03-01T00:00:00Z"},
+		{Name: "alpha", CreatedAt: "2024-01-01T00:00:00Z"},
+		{Name: "mid", CreatedAt: "2024-02-01T00:00:00Z", Favorite: true},
+		{Name: "beta", CreatedAt: "2024-04-01T00:00:00Z"},
+	}
+	cfg.ActivityLog = []config.ActivityLogEntry{
+		{Timestamp: "2024-05-01T00:00:00Z", Action: "switch", AccountName: "alpha", Success: true},
+		{Timestamp: "2024-05-02T00:00:00Z", Action: "switch", AccountName: "zeta", Success: true},
+		{Timestamp: "2024-05-03T00:00:00Z", Action: "switch", AccountName: "beta", Success: false},
+	}
+
+	tests := []struct {
+		order    string
+		detected string
+		want     []string
+	}{
+		{SortManual, "", []string{"mid", "zeta", "alpha", "beta"}},
+		{SortAlphabetical, "", []string{"mid", "alpha", "beta", "zeta"}},
+		{SortAdded, "", []string{"mid", "beta", "zeta", "alpha"}},
+		{SortRecent, "", []string{"mid", "zeta", "alpha", "beta"}},
+		{SortAlphabetical, "Zeta", []string{"zeta", "mid", "alpha", "beta"}},
+		{SortManual, "mid", []string{"mid", "zeta", "alpha", "beta"}},
+	}
+
+	for _, tt := range tests {
+		cfg.AccountSort = tt.order
+		got := selectionNames(cfg, tt.detected)
+		for i := range tt.want {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s (detected %q): expected %v, got %v", tt.order, tt.detected, tt.want, got)
+				break
+			}
+		}
+	}
+}
+
+// TestValidateSortOrder tests sort order validation
+func TestValidateSortOrder(t *testing.T) {
+	for _, order := range SortOrders {
+		if err := ValidateSortOrder(order); err != nil {
+			t.Errorf("Expected %s to be valid: %v", order, err)
+		}
+	}
+	if ValidateSortOrder("random") == nil {
+		t.Error("Expected error for unknown sort order")
+	}
+}
@@ -0,0 +1,103 @@
+package account
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dwirx/ghex/internal/config"
+	"github.com/dwirx/ghex/internal/git"
+)
+
+// PinConfigKey is the repository-local git config key holding the pinned account
+const PinConfigKey = "ghex.account"
+
+// PinAccount records accountName as the account a repository belongs to
+func PinAccount(accountName, repoPath string) error {
+	return git.SetLocalConfig(PinConfigKey, accountName, repoPath)
+}
+
+// PinnedAccount returns the account a repository is pinned to, or "" if none
+func PinnedAccount(repoPath string) string {
+	return git.GetLocalConfig(PinConfigKey, repoPath)
+}
+
+// IdentityCheck is the result of comparing a repository against its pinned account
+type IdentityCheck struct {
+	Pinned    string          // Pinned account name ("" = not pinned)
+	Account   *config.Account // Pinned account (nil if it no longer exists)
+	UserName  string          // Current git user.name
+	UserEmail string          // Current git user.email
+	RemoteURL string          // origin URL
+	Problems  []string        // Human-readable mismatches
+}
+
+// OK reports whether the repository matches its pinned account
+// Unpinned repositories are always OK
+func (c *IdentityCheck) OK() bool {
+	return len(c.Problems) == 0
+}
+
+// VerifyPinned checks the repository's git identity and remote against the
+// account it is pinned to
+func (m *Manager) VerifyPinned(repoPath string) *IdentityCheck {
+	if repoPath == "" {
+		repoPath = "."
+	}
+
+	check := &IdentityCheck{Pinned: PinnedAccount(repoPath)}
+	if check.Pinned == "" {
+		return check
+	}
+
+	if !m.compareIdentity(check, check.Pinned, repoPath) {
+		check.Problems = append(check.Problems, fmt.Sprintf("pinned account '%s' no longer exists", check.Pinned))
+	}
+	return check
+}
+
+// compareIdentity fills check with the repository's identity and remote and
+// the ways they differ from accountName. It returns false if the account
+// does not exist.
+func (m *Manager) compareIdentity(check *IdentityCheck, accountName, repoPath string) bool {
+	check.UserName, check.UserEmail, _ = git.GetCurrentUser(repoPath)
+	check.RemoteURL, _ = git.GetRemoteURL("origin", repoPath)
+
+	check.Account = m.Find(accountName)
+	if check.Account == nil {
+		return false
+	}
+	acc := check.Account
+
+	if len(acc.AllEmails()) > 0 && !acc.HasEmail(check.UserEmail) {
+		check.Problems = append(check.Problems,
+			fmt.Sprintf("user.email is '%s', account '%s' uses '%s'", check.UserEmail, acc.Name, strings.Join(acc.AllEmails(), "' or '")))
+	}
+	if acc.GitUserName != "" && !strings.EqualFold(acc.GitUserName, check.UserName) {
+		check.Problems = append(check.Problems,
+			fmt.Sprintf("user.name is '%s', account '%s' uses '%s'", check.UserName, acc.Name, acc.GitUserName))
+	}
+
+	if check.RemoteURL != "" {
+		if isSSH := isSSHRemote(check.RemoteURL); isSSH && acc.SSH == nil {
+			check.Problems = append(check.Problems,
+				fmt.Sprintf("origin uses SSH but account '%s' has no SSH key", acc.Name))
+		} else if !isSSH && acc.Token == nil {
+			check.Problems = append(check.Problems,
+				fmt.Sprintf("origin uses HTTPS but account '%s' has no token", acc.Name))
+		}
+	}
+	return true
+}
+
+// FixMethod returns the switch method that restores the pinned account,
+// keeping the remote's current protocol when the account supports it
+func (c *IdentityCheck) FixMethod() SwitchMethod {
+	if c.Account != nil && c.Account.SSH != nil && (isSSHRemote(c.RemoteURL) || c.Account.Token == nil) {
+		return MethodSSH
+	}
+	return MethodToken
+}
+
+func isSSHRemote(url string) bool {
+	return strings.HasPrefix(url, "git@") || strings.HasPrefix(url, "ssh://")
+}
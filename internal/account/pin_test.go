@@ -0,0 +1,80 @@
+package account
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/dwirx/ghex/internal/config"
+)
+
+// initRepo creates a git repository with a local identity and origin remote
+func initRepo(t *testing.T, name, email, remote string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.name", name},
+		{"config", "user.email", email},
+		{"remote", "add", "origin", remote},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Skipf("git unavailable: %v (%s)", err, out)
+		}
+	}
+	return dir
+}
+
+// TestVerifyPinned tests identity checks against the pinned account
+func TestVerifyPinned(t *testing.T) {
+	cfg := config.NewAppConfig()
+	manager := NewManager(cfg)
+	_ = manager.Add(config.Account{
+		Name:        "work",
+		GitUserName: "Work User",
+		GitEmail:    "work@example.com",
+		SSH:         &config.SshConfig{KeyPath: "~/.ssh/id_work"},
+	})
+
+	t.Run("unpinned", func(t *testing.T) {
+		dir := initRepo(t, "Someone", "someone@example.com", "git@github.com:org/repo.git")
+		if check := manager.VerifyPinned(dir); !check.OK() {
+			t.Errorf("Expected unpinned repo to pass, got %v", check.Problems)
+		}
+	})
+
+	t.Run("matching", func(t *testing.T) {
+		dir := initRepo(t, "Work User", "work@example.com", "git@github.com:org/repo.git")
+		if err := PinAccount("work", dir); err != nil {
+			t.Fatalf("PinAccount failed: %v", err)
+		}
+		if got := PinnedAccount(dir); got != "work" {
+			t.Errorf("Expected pinned account 'work', got '%s'", got)
+		}
+		if check := manager.VerifyPinned(dir); !check.OK() {
+			t.Errorf("Expected matching repo to pass, got %v", check.Problems)
+		}
+	})
+
+	t.Run("wrong email and protocol", func(t *testing.T) {
+		dir := initRepo(t, "Work User", "personal@example.com", "https://github.com/org/repo.git")
+		_ = PinAccount("work", dir)
+		check := manager.VerifyPinned(dir)
+		if len(check.Problems) != 2 {
+			t.Errorf("Expected 2 problems, got %v", check.Problems)
+		}
+		if check.FixMethod() != MethodSSH {
+			t.Errorf("Expected fix method ssh, got %s", check.FixMethod())
+		}
+	})
+
+	t.Run("missing account", func(t *testing.T) {
+		dir := initRepo(t, "Work User", "work@example.com", "git@github.com:org/repo.git")
+		_ = PinAccount("deleted", dir)
+		check := manager.VerifyPinned(dir)
+		if check.OK() || check.Account != nil {
+			t.Error("Expected a problem for a pinned account that no longer exists")
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package account
+
+import (
+	"fmt"
+
+	"github.com/dwirx/ghex/internal/git"
+)
+
+// SwitchSafety describes repository state that makes rewriting the origin
+// remote risky
+type SwitchSafety struct {
+	Operation string           // Unfinished rebase, merge, cherry-pick or revert ("" = none)
+	Unpushed  []git.BranchInfo // Branches with commits not yet pushed to the old remote
+	Stale     []git.BranchInfo // Branches tracking the old URL through something other than origin
+}
+
+// OK reports whether switching needs no confirmation
+func (s *SwitchSafety) OK() bool {
+	return s.Operation == "" && len(s.Unpushed) == 0 && len(s.Stale) == 0
+}
+
+// CheckSwitchSafety inspects a repository before its origin URL or
+// credentials are switched
+func CheckSwitchSafety(repoPath string) (*SwitchSafety, error) {
+	if repoPath == "" {
+		repoPath = "."
+	}
+
+	safety := &SwitchSafety{Operation: git.InProgressOperation(repoPath)}
+
+	originURL, err := git.GetRemoteURL("origin", repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get remote URL: %w", err)
+	}
+
+	branches, err := git.ListBranches(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	remoteURLs := map[string]string{"origin": originURL}
+	for _, b := range branches {
+		if b.Remote == "" || b.Remote == "." {
+			continue
+		}
+
+		remoteURL, ok := remoteURLs[b.Remote]
+		if !ok {
+			// branch.<name>.remote may be a URL rather than a remote name
+			remoteURL = b.Remote
+			if u, err := git.GetRemoteURL(b.Remote, repoPath); err == nil {
+				remoteURL = u
+			}
+			remoteURLs[b.Remote] = remoteURL
+		}
+		if remoteURL != originURL {
+			continue
+		}
+
+		if b.Remote != "origin" {
+			safety.Stale = append(safety.Stale, b)
+		}
+		if b.Ahead > 0 {
+			safety.Unpushed = append(safety.Unpushed, b)
+		}
+	}
+	return safety, nil
+}
+
+// RetargetBranches points branches at the origin remote so they follow the
+// rewritten URL
+func RetargetBranches(branches []git.BranchInfo, repoPath string) error {
+	for _, b := range branches {
+		if err := git.SetBranchRemote(b.Name, "origin", repoPath); err != nil {
+			return fmt.Errorf("failed to retarget %s: %w", b.Name, err)
+		}
+	}
+	return nil
+}
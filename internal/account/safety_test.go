@@ -0,0 +1,57 @@
+package account
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// TestCheckSwitchSafety tests detection of risky repository state
+func TestCheckSwitchSafety(t *testing.T) {
+	const origin = "git@github.com:org/repo.git"
+	dir := initRepo(t, "Work User", "work@example.com", origin)
+
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v (%s)", args, err, out)
+		}
+	}
+	git("commit", "-q", "--allow-empty", "-m", "init")
+	git("branch", "feature")
+	git("branch", "legacy")
+	git("remote", "add", "mirror", origin)
+	git("config", "branch.feature.remote", "mirror")
+	git("config", "branch.feature.merge", "refs/heads/feature")
+	git("config", "branch.legacy.remote", origin)
+	git("config", "branch.legacy.merge", "refs/heads/legacy")
+
+	safety, err := CheckSwitchSafety(dir)
+	if err != nil {
+		t.Fatalf("CheckSwitchSafety failed: %v", err)
+	}
+	if safety.Operation != "" {
+		t.Errorf("Expected no operation in progress, got %s", safety.Operation)
+	}
+	if len(safety.Stale) != 2 {
+		t.Fatalf("Expected 2 stale branches, got %v", safety.Stale)
+	}
+
+	if err := RetargetBranches(safety.Stale, dir); err != nil {
+		t.Fatalf("RetargetBranches failed: %v", err)
+	}
+	if safety, _ = CheckSwitchSafety(dir); len(safety.Stale) != 0 {
+		t.Errorf("Expected no stale branches after retarget, got %v", safety.Stale)
+	}
+
+	// Simulate an interrupted merge
+	if err := os.WriteFile(filepath.Join(dir, ".git", "MERGE_HEAD"), []byte("0000000000000000000000000000000000000000\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if safety, _ = CheckSwitchSafety(dir); safety.Operation != "merge" || safety.OK() {
+		t.Errorf("Expected merge in progress, got %q", safety.Operation)
+	}
+}
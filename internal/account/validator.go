@@ -44,10 +44,10 @@ func (v *DuplicateValidator) ValidateNew(account config.Account) ValidationResul
 	}
 
 	// Check email duplicate on same platform (warning)
-	if account.GitEmail != "" {
-		if conflictAcc := v.CheckEmailDuplicate(account.GitEmail, platformType); conflictAcc != nil {
+	for _, email := range account.AllEmails() {
+		if conflictAcc := v.CheckEmailDuplicate(email, platformType); conflictAcc != nil {
 			result.Warnings = append(result.Warnings,
-				"Email '"+account.GitEmail+"' is already used by account '"+conflictAcc.Name+"' on "+platformType)
+				"Email '"+email+"' is already used by account '"+conflictAcc.Name+"' on "+platformType)
 		}
 	}
 
@@ -83,7 +83,7 @@ func (v *DuplicateValidator) CheckNameDuplicate(name string) bool {
 // CheckEmailDuplicate checks for duplicate email on same platform
 func (v *DuplicateValidator) CheckEmailDuplicate(email, platform string) *config.Account {
 	for i, acc := range v.accounts {
-		if strings.EqualFold(acc.GitEmail, email) {
+		if acc.HasEmail(email) {
 			accPlatform := "github"
 			if acc.Platform != nil && acc.Platform.Type != "" {
 				accPlatform = acc.Platform.Type
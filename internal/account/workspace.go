@@ -0,0 +1,171 @@
+package account
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dwirx/ghex/internal/config"
+	"github.com/dwirx/ghex/internal/git"
+)
+
+// WorkspaceDepth is how many directory levels below a workspace are searched
+// for repositories
+const WorkspaceDepth = 3
+
+// skipDirs are directories never searched for repositories
+var skipDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+}
+
+// FindWorkspace returns the workspace for dir, or nil if there is none
+func FindWorkspace(cfg *config.AppConfig, dir string) *config.Workspace {
+	for i := range cfg.Workspaces {
+		if sameDir(cfg.Workspaces[i].Dir, dir) {
+			return &cfg.Workspaces[i]
+		}
+	}
+	return nil
+}
+
+// AddWorkspace assigns dir to accountName, replacing an earlier assignment
+// of the same directory. Its organizations are replaced when orgs is not
+// empty.
+func (m *Manager) AddWorkspace(dir, accountName string, orgs []string) error {
+	if m.Find(accountName) == nil {
+		return fmt.Errorf("account '%s' not found", accountName)
+	}
+	if ws := FindWorkspace(m.cfg, dir); ws != nil {
+		ws.Account = accountName
+		if len(orgs) > 0 {
+			ws.Orgs = orgs
+		}
+		return nil
+	}
+	m.cfg.Workspaces = append(m.cfg.Workspaces, config.Workspace{Dir: dir, Account: accountName, Orgs: orgs})
+	return nil
+}
+
+// RemoveWorkspace forgets the workspace for dir
+func (m *Manager) RemoveWorkspace(dir string) error {
+	for i, ws := range m.cfg.Workspaces {
+		if sameDir(ws.Dir, dir) {
+			m.cfg.Workspaces = append(m.cfg.Workspaces[:i], m.cfg.Workspaces[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("no workspace at '%s'", dir)
+}
+
+// FindRepos returns the git repositories in dir, including dir itself, up
+// to WorkspaceDepth levels down. Repositories are not searched for nested
+// ones, and hidden and dependency directories are skipped.
+func FindRepos(dir string) []string {
+	var repos []string
+	var walk func(path string, depth int)
+	walk = func(path string, depth int) {
+		if _, err := os.Stat(filepath.Join(path, ".git")); err == nil {
+			repos = append(repos, path)
+			return
+		}
+		if depth >= WorkspaceDepth {
+			return
+		}
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return
+		}
+		for _, e := range entries {
+			if !e.IsDir() || strings.HasPrefix(e.Name(), ".") || skipDirs[e.Name()] {
+				continue
+			}
+			walk(filepath.Join(path, e.Name()), depth+1)
+		}
+	}
+	walk(dir, 0)
+	sort.Strings(repos)
+	return repos
+}
+
+// VerifyAccount checks a repository against accountName like VerifyPinned
+// does against the pinned account. It also reports a pin to another account
+// and an origin that has drifted from the URL Switch would set.
+func (m *Manager) VerifyAccount(accountName, repoPath string) *IdentityCheck {
+	check := &IdentityCheck{Pinned: PinnedAccount(repoPath)}
+	if !m.compareIdentity(check, accountName, repoPath) {
+		check.Problems = append(check.Problems, fmt.Sprintf("account '%s' no longer exists", accountName))
+		return check
+	}
+
+	if check.Pinned != "" && check.Pinned != accountName {
+		check.Problems = append(check.Problems, fmt.Sprintf("pinned to account '%s'", check.Pinned))
+	}
+
+	if check.RemoteURL == "" {
+		check.Problems = append(check.Problems, "no origin remote")
+		return check
+	}
+	info, err := git.ParseURL(check.RemoteURL)
+	if err != nil {
+		return check
+	}
+	expected := RemoteURL(check.Account, check.FixMethod(), info.Owner+"/"+info.Repo)
+	if want, _ := git.ParseURL(expected); !git.SameRepository(check.RemoteURL, expected) ||
+		(want != nil && info.IsSSH && info.Port != want.Port) {
+		check.Problems = append(check.Problems, fmt.Sprintf("origin is '%s', expected '%s'", check.RemoteURL, expected))
+	}
+	return check
+}
+
+// RepoListOptions returns the options for listing repositories with acc
+func RepoListOptions(acc *config.Account) git.RepoListOptions {
+	opts := git.RepoListOptions{Platform: "github", Host: "github.com"}
+	if acc.Platform != nil {
+		opts.Platform = acc.Platform.Type
+		opts.APIURL = acc.Platform.ApiUrl
+		opts.Host = acc.Platform.Domain
+		if opts.Host == "" {
+			opts.Host = git.GetDefaultDomain(acc.Platform.Type)
+		}
+	}
+	if acc.Token != nil {
+		opts.Username = acc.Token.Username
+		opts.Token = acc.Token.Token
+	}
+	return opts
+}
+
+// MissingRepos returns the listed repositories that no repository in
+// repoPaths has as its origin
+func MissingRepos(acc *config.Account, listed []git.RemoteRepo, repoPaths []string) []git.RemoteRepo {
+	var origins []string
+	for _, path := range repoPaths {
+		if origin, err := git.GetRemoteURL("origin", path); err == nil {
+			origins = append(origins, origin)
+		}
+	}
+
+	var missing []git.RemoteRepo
+	for _, repo := range listed {
+		remote := RemoteURL(acc, MethodToken, repo.FullName)
+		found := false
+		for _, origin := range origins {
+			if git.SameRepository(origin, remote) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, repo)
+		}
+	}
+	return missing
+}
+
+// sameDir reports whether two paths name the same directory
+func sameDir(a, b string) bool {
+	return filepath.Clean(a) == filepath.Clean(b)
+}
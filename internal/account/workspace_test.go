@@ -0,0 +1,93 @@
+package account
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dwirx/ghex/internal/config"
+	"github.com/dwirx/ghex/internal/git"
+)
+
+// TestFindRepos tests repository discovery below a workspace directory
+func TestFindRepos(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{
+		"a/.git",
+		"org/b/.git",
+		"org/b/nested/.git",
+		"node_modules/dep/.git",
+		".cache/c/.git",
+		"x/y/z/too-deep/.git",
+	} {
+		if err := os.MkdirAll(filepath.Join(root, filepath.FromSlash(dir)), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := FindRepos(root)
+	want := []string{filepath.Join(root, "a"), filepath.Join(root, "org", "b")}
+	if len(got) != len(want) {
+		t.Fatalf("FindRepos() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("FindRepos()[%d] = %s, want %s", i, got[i], want[i])
+		}
+	}
+}
+
+// TestVerifyAccount tests workspace checks against the workspace's account
+func TestVerifyAccount(t *testing.T) {
+	cfg := config.NewAppConfig()
+	manager := NewManager(cfg)
+	_ = manager.Add(config.Account{
+		Name:        "work",
+		GitUserName: "Work User",
+		GitEmail:    "work@example.com",
+		SSH:         &config.SshConfig{KeyPath: "~/.ssh/id_work"},
+		Platform:    &config.PlatformConfig{Type: "gitlab", Domain: "gitlab.company.com"},
+	})
+
+	t.Run("matching", func(t *testing.T) {
+		dir := initRepo(t, "Work User", "work@example.com", "git@gitlab.company.com:org/repo.git")
+		if check := manager.VerifyAccount("work", dir); !check.OK() {
+			t.Errorf("Expected matching repo to pass, got %v", check.Problems)
+		}
+	})
+
+	t.Run("drifted remote and pin", func(t *testing.T) {
+		dir := initRepo(t, "Work User", "work@example.com", "git@github.com:org/repo.git")
+		_ = PinAccount("personal", dir)
+		check := manager.VerifyAccount("work", dir)
+		if len(check.Problems) != 2 {
+			t.Errorf("Expected pin and remote problems, got %v", check.Problems)
+		}
+	})
+
+	t.Run("add and remove", func(t *testing.T) {
+		if err := manager.AddWorkspace("/src/work", "missing", nil); err == nil {
+			t.Error("Expected an error for an unknown account")
+		}
+		_ = manager.AddWorkspace("/src/work", "work", []string{"company"})
+		_ = manager.AddWorkspace("/src/work/", "work", nil)
+		if len(cfg.Workspaces) != 1 || len(cfg.Workspaces[0].Orgs) != 1 {
+			t.Errorf("Expected one workspace keeping its organization, got %v", cfg.Workspaces)
+		}
+		if err := manager.RemoveWorkspace("/src/work"); err != nil || len(cfg.Workspaces) != 0 {
+			t.Errorf("RemoveWorkspace() = %v, workspaces %v", err, cfg.Workspaces)
+		}
+	})
+}
+
+// TestMissingRepos tests matching listed repositories against local clones
+func TestMissingRepos(t *testing.T) {
+	acc := &config.Account{Name: "work", SSH: &config.SshConfig{KeyPath: "~/.ssh/id_work"}}
+	cloned := initRepo(t, "Work User", "work@example.com", "git@github.com:company/api.git")
+
+	listed := []git.RemoteRepo{{FullName: "company/api"}, {FullName: "company/web"}}
+	missing := MissingRepos(acc, listed, []string{cloned})
+	if len(missing) != 1 || missing[0].FullName != "company/web" {
+		t.Errorf("MissingRepos() = %v, want only company/web", missing)
+	}
+}
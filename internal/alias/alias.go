@@ -0,0 +1,96 @@
+// Package alias expands user-defined command aliases such as
+// `dlr` → `dlx release` before the command line is parsed.
+package alias
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// ValidateName returns an error unless name can be used as an alias
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid alias name '%s' (use letters, digits, '-', '_' and '.')", name)
+	}
+	return nil
+}
+
+// Expand replaces args[0] with its alias expansion, keeping the remaining
+// arguments. Aliases are expanded once, so an alias may not refer to
+// another alias. Args are returned unchanged when args[0] is not an alias.
+func Expand(args []string, aliases map[string]string) ([]string, error) {
+	if len(args) == 0 {
+		return args, nil
+	}
+	expansion, ok := aliases[args[0]]
+	if !ok {
+		return args, nil
+	}
+
+	words, err := Split(expansion)
+	if err != nil {
+		return nil, fmt.Errorf("alias '%s': %w", args[0], err)
+	}
+	if len(words) == 0 {
+		return nil, fmt.Errorf("alias '%s' is empty", args[0])
+	}
+	return append(words, args[1:]...), nil
+}
+
+// Split splits an alias expansion into words the way a shell would, with
+// single quotes, double quotes and backslash escapes
+func Split(s string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else if r == '\\' && i+1 < len(runes) && strings.ContainsRune(`"\$`+"`", runes[i+1]) {
+				i++
+				word.WriteRune(runes[i])
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == '\\' && i+1 < len(runes):
+			i++
+			word.WriteRune(runes[i])
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
@@ -0,0 +1,77 @@
+package alias
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"dlx release", []string{"dlx", "release"}},
+		{"  switch   work ", []string{"switch", "work"}},
+		{`dlx release --version "^1.4 <2"`, []string{"dlx", "release", "--version", "^1.4 <2"}},
+		{`commit -m 'it''s'`, []string{"commit", "-m", "its"}},
+		{`a\ b "c\"d" ''`, []string{"a b", `c"d`, ""}},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		got, err := Split(tt.input)
+		if err != nil {
+			t.Errorf("Split(%q) error: %v", tt.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Split(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	if _, err := Split(`dlx "release`); err == nil {
+		t.Error("Split() should reject an unterminated quote")
+	}
+}
+
+func TestExpand(t *testing.T) {
+	aliases := map[string]string{"dlr": "dlx release", "dlr2": "dlr", "bad": `"x`}
+
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"dlr", "user/repo", "--install"}, []string{"dlx", "release", "user/repo", "--install"}},
+		{[]string{"status"}, []string{"status"}},
+		{[]string{"dlr2"}, []string{"dlr"}}, // Expanded once only
+		{nil, nil},
+	}
+
+	for _, tt := range tests {
+		got, err := Expand(tt.args, aliases)
+		if err != nil {
+			t.Errorf("Expand(%q) error: %v", tt.args, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Expand(%q) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+
+	if _, err := Expand([]string{"bad"}, aliases); err == nil {
+		t.Error("Expand() should report a malformed alias")
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	for _, name := range []string{"dlr", "st", "pull-all", "v2.x"} {
+		if err := ValidateName(name); err != nil {
+			t.Errorf("ValidateName(%q) = %v, want nil", name, err)
+		}
+	}
+	for _, name := range []string{"", "-x", "a b", "a/b"} {
+		if ValidateName(name) == nil {
+			t.Errorf("ValidateName(%q) = nil, want an error", name)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// cachedHeaders are the response headers kept with a cached body
+var cachedHeaders = []string{
+	"Content-Type", "Link", "X-Total-Pages", "X-Next-Page",
+	"X-OAuth-Scopes", "GitHub-Authentication-Token-Expiration",
+}
+
+// cache stores GET responses that carry a validator (ETag or
+// Last-Modified) so they can be revalidated with a conditional request.
+// A 304 answer costs no rate limit on GitHub.
+type cache struct {
+	dir string
+	key string // Token hash, keeps accounts apart without storing tokens
+}
+
+// cacheEntry is a cached response
+type cacheEntry struct {
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"lastModified,omitempty"`
+	Header       http.Header `json:"header"`
+	Body         []byte      `json:"body"`
+}
+
+// newCache returns the cache for a token below dir
+func newCache(dir, token string) *cache {
+	sum := sha256.Sum256([]byte(token))
+	return &cache{dir: filepath.Join(dir, "api"), key: hex.EncodeToString(sum[:8])}
+}
+
+// path returns the cache file of a URL
+func (c *cache) path(apiURL string) string {
+	sum := sha256.Sum256([]byte(c.key + "\x00" + apiURL))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// load returns the cached response of a URL, or nil. An unreadable entry
+// is treated as missing.
+func (c *cache) load(apiURL string) *cacheEntry {
+	data, err := os.ReadFile(c.path(apiURL))
+	if err != nil {
+		return nil
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || (entry.ETag == "" && entry.LastModified == "") {
+		return nil
+	}
+	return &entry
+}
+
+// store caches a response that has a validator. Failures are ignored, the
+// cache only saves requests.
+func (c *cache) store(apiURL string, r *Response) {
+	entry := cacheEntry{
+		ETag:         r.Header.Get("ETag"),
+		LastModified: r.Header.Get("Last-Modified"),
+		Header:       http.Header{},
+		Body:         r.Body,
+	}
+	if entry.ETag == "" && entry.LastModified == "" {
+		return
+	}
+	for _, name := range cachedHeaders {
+		if values := r.Header.Values(name); len(values) > 0 {
+			entry.Header[http.CanonicalHeaderKey(name)] = values
+		}
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	// Responses can list private repositories, keep them private
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(c.dir, ".entry-*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		return
+	}
+	os.Rename(tmp.Name(), c.path(apiURL))
+}
+
+// setConditions makes req conditional on the cached validators
+func (e *cacheEntry) setConditions(req *http.Request) {
+	if e.ETag != "" {
+		req.Header.Set("If-None-Match", e.ETag)
+	}
+	if e.LastModified != "" {
+		req.Header.Set("If-Modified-Since", e.LastModified)
+	}
+}
@@ -0,0 +1,185 @@
+// Package api is the HTTP client for the REST APIs of GitHub, GitLab and
+// Gitea. Clients are shared per API and token, so every feature talking to
+// the same account reuses one client; GET responses are cached on disk and
+// revalidated with conditional requests, and Link headers drive pagination.
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dwirx/ghex/internal/httpclient"
+	"github.com/dwirx/ghex/internal/platform"
+)
+
+// defaultTimeout bounds a single API request
+const defaultTimeout = 30 * time.Second
+
+// maxBodySize caps how much of a response is read
+const maxBodySize = 32 << 20
+
+// Client sends authenticated requests to one platform API
+type Client struct {
+	Platform string // github, gitlab, gitea, codeberg
+	BaseURL  string // API base URL, e.g. https://api.github.com
+	token    string
+	http     *http.Client
+	cache    *cache // nil = no caching
+}
+
+// Response is a complete API response
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	Cached     bool // Served from the cache after a 304 Not Modified
+}
+
+// StatusError is returned for an unexpected HTTP status
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("HTTP %d", e.StatusCode)
+}
+
+var (
+	clientsMu sync.Mutex
+	clients   = map[string]*Client{}
+)
+
+// For returns the shared client of an API and token. Its GET responses are
+// cached in the ghex cache directory, separately for each token.
+func For(platformName, baseURL, token string) *Client {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	key := platformName + "\x00" + baseURL + "\x00" + token
+
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
+	if c, ok := clients[key]; ok {
+		return c
+	}
+	c := NewClient(platformName, baseURL, token, nil)
+	c.cache = newCache(platform.GetCacheDir("ghex"), token)
+	clients[key] = c
+	return c
+}
+
+// NewClient returns an uncached client. A nil httpClient uses the shared
+// transport with the default timeout.
+func NewClient(platformName, baseURL, token string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = httpclient.New(defaultTimeout)
+	}
+	return &Client{
+		Platform: platformName,
+		BaseURL:  strings.TrimSuffix(baseURL, "/"),
+		token:    token,
+		http:     httpClient,
+	}
+}
+
+// URL resolves a path against the base URL; absolute URLs are kept
+func (c *Client) URL(path string) string {
+	if strings.HasPrefix(path, "https://") || strings.HasPrefix(path, "http://") {
+		return path
+	}
+	return c.BaseURL + "/" + strings.TrimPrefix(path, "/")
+}
+
+// Do sends a request and reads the whole response. GET requests are
+// answered from the cache when the server reports them unchanged.
+func (c *Client) Do(method, path string, body io.Reader) (*Response, error) {
+	apiURL := c.URL(path)
+	req, err := http.NewRequest(method, apiURL, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "ghex-cli")
+	if c.Platform == "github" {
+		req.Header.Set("Accept", "application/vnd.github+json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	var cached *cacheEntry
+	if method == "GET" && c.cache != nil {
+		if cached = c.cache.load(apiURL); cached != nil {
+			cached.setConditions(req)
+		}
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		return &Response{StatusCode: http.StatusOK, Header: cached.Header, Body: cached.Body, Cached: true}, nil
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return nil, err
+	}
+	r := &Response{StatusCode: resp.StatusCode, Header: resp.Header, Body: data}
+	if method == "GET" && c.cache != nil && resp.StatusCode == http.StatusOK {
+		c.cache.store(apiURL, r)
+	}
+	return r, nil
+}
+
+// Get sends a GET request. Statuses are left for the caller to check.
+func (c *Client) Get(path string) (*Response, error) {
+	return c.Do("GET", path, nil)
+}
+
+// GetJSON sends a GET request and decodes a 200 response into v. Other
+// statuses are returned for the caller to check.
+func (c *Client) GetJSON(path string, v interface{}) (*Response, error) {
+	resp, err := c.Get(path)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusOK {
+		if err := resp.Decode(v); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// Call sends a request and decodes the JSON response into v unless v is
+// nil. A status outside 2xx returns a *StatusError.
+func (c *Client) Call(method, path string, body io.Reader, v interface{}) error {
+	resp, err := c.Do(method, path, body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &StatusError{StatusCode: resp.StatusCode}
+	}
+	if v == nil {
+		return nil
+	}
+	return resp.Decode(v)
+}
+
+// Decode decodes the JSON body into v
+func (r *Response) Decode(v interface{}) error {
+	if err := json.Unmarshal(r.Body, v); err != nil {
+		return fmt.Errorf("invalid response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestCachedGet tests that a repeated GET is revalidated with its ETag and
+// answered from the cache, separately for each token
+func TestCachedGet(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("LOCALAPPDATA", t.TempDir())
+
+	requests, notModified := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("Authorization") != "Bearer tok" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("X-OAuth-Scopes", "repo")
+		fmt.Fprint(w, `{"login":"me"}`)
+	}))
+	defer server.Close()
+
+	client := For("github", server.URL, "tok")
+	if For("github", server.URL+"/", "tok") != client {
+		t.Error("For() returned a new client for the same account")
+	}
+
+	for i := 0; i < 2; i++ {
+		var user struct {
+			Login string `json:"login"`
+		}
+		resp, err := client.GetJSON("/user", &user)
+		if err != nil || resp.StatusCode != http.StatusOK || user.Login != "me" {
+			t.Fatalf("GetJSON() #%d = %+v, %v, %q", i+1, resp, err, user.Login)
+		}
+		if resp.Cached != (i == 1) || resp.Header.Get("X-OAuth-Scopes") != "repo" {
+			t.Errorf("GetJSON() #%d Cached = %v, scopes = %q", i+1, resp.Cached, resp.Header.Get("X-OAuth-Scopes"))
+		}
+	}
+	if notModified != 1 {
+		t.Errorf("server answered %d conditional requests, want 1", notModified)
+	}
+
+	// Another token never sees the cached response
+	resp, err := For("github", server.URL, "other").Get("/user")
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || resp.Cached {
+		t.Errorf("Get() with another token = %+v, %v, want 401", resp, err)
+	}
+	if requests != 3 {
+		t.Errorf("server got %d requests, want 3", requests)
+	}
+}
+
+// TestPaginate tests following Link headers and stopping early
+func TestPaginate(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/items" {
+			http.NotFound(w, r)
+			return
+		}
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		if page != "3" {
+			next := map[string]string{"1": "2", "2": "3"}[page]
+			w.Header().Set("Link", fmt.Sprintf(`<%s/items?page=%s>; rel="next", <%s/items?page=3>; rel="last"`, server.URL, next, server.URL))
+		}
+		fmt.Fprintf(w, `[%s]`, page)
+	}))
+	defer server.Close()
+
+	client := NewClient("gitea", server.URL, "", nil)
+	var pages []int
+	collect := func(resp *Response) (bool, error) {
+		var items []int
+		if err := resp.Decode(&items); err != nil {
+			return false, err
+		}
+		pages = append(pages, items...)
+		return true, nil
+	}
+	if err := client.Paginate("/items", 10, collect); err != nil || len(pages) != 3 {
+		t.Errorf("Paginate() = %v, %v, want 3 pages", pages, err)
+	}
+
+	pages = nil
+	if err := client.Paginate("/items", 2, collect); err != nil || len(pages) != 2 {
+		t.Errorf("Paginate() with 2 pages = %v, %v", pages, err)
+	}
+
+	err := client.Paginate("/missing", 10, collect)
+	if _, ok := err.(*StatusError); !ok {
+		t.Errorf("Paginate() error = %v, want a StatusError", err)
+	}
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Paginate requests path and follows the rel="next" Link of each page, up
+// to maxPages pages, passing every page to each. It stops when each returns
+// false. A status other than 200 returns a *StatusError.
+func (c *Client) Paginate(path string, maxPages int, each func(*Response) (bool, error)) error {
+	next := path
+	for page := 0; next != "" && page < maxPages; page++ {
+		resp, err := c.Get(next)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return &StatusError{StatusCode: resp.StatusCode}
+		}
+		more, err := each(resp)
+		if err != nil || !more {
+			return err
+		}
+		next = NextLink(resp.Header.Get("Link"))
+	}
+	return nil
+}
+
+// NextLink returns the rel="next" URL of a Link header, or ""
+func NextLink(header string) string {
+	for _, link := range strings.Split(header, ",") {
+		target, params, ok := strings.Cut(link, ";")
+		if !ok {
+			continue
+		}
+		for _, param := range strings.Split(params, ";") {
+			if strings.TrimSpace(param) == `rel="next"` {
+				return strings.Trim(strings.TrimSpace(target), "<>")
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,78 @@
+package backup
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strings"
+
+	"github.com/dwirx/ghex/internal/account"
+	"github.com/dwirx/ghex/internal/config"
+	"github.com/dwirx/ghex/internal/platform"
+	"github.com/dwirx/ghex/internal/ssh"
+)
+
+// Auth fetches the repositories of one account. Credentials are passed to
+// each git command through its environment, so backing up several accounts
+// on the same host never touches the SSH config or the credential store.
+type Auth struct {
+	acc    *config.Account
+	method account.SwitchMethod
+}
+
+// NewAuth prefers the account's token, which needs no SSH setup, and falls
+// back to its SSH key
+func NewAuth(acc *config.Account) Auth {
+	method := account.MethodSSH
+	if acc.Token != nil && acc.Token.Token != "" {
+		method = account.MethodToken
+	}
+	return Auth{acc: acc, method: method}
+}
+
+// Method returns the authentication method used
+func (a Auth) Method() account.SwitchMethod {
+	return a.method
+}
+
+// RemoteURL returns the URL an owner/repo repository is fetched from
+func (a Auth) RemoteURL(fullName string) string {
+	return account.RemoteURL(a.acc, a.method, fullName)
+}
+
+// Env returns the git environment that authenticates fetches from remoteURL
+func (a Auth) Env(remoteURL string) []string {
+	if a.method == account.MethodToken {
+		u, err := url.Parse(remoteURL)
+		if err != nil || u.Host == "" {
+			return nil
+		}
+		user := a.acc.Token.Username
+		if user == "" {
+			user = "x-access-token"
+		}
+		basic := base64.StdEncoding.EncodeToString([]byte(user + ":" + a.acc.Token.Token))
+		return []string{
+			"GIT_CONFIG_COUNT=1",
+			"GIT_CONFIG_KEY_0=http." + u.Scheme + "://" + u.Host + "/.extraHeader",
+			"GIT_CONFIG_VALUE_0=Authorization: Basic " + basic,
+		}
+	}
+
+	if a.acc.SSH == nil || a.acc.SSH.KeyPath == "" {
+		return nil
+	}
+	keyPath := platform.ToSSHPath(platform.ExpandPath(a.acc.SSH.KeyPath))
+	args := []string{"ssh", "-i", shellQuote(keyPath), "-o", "IdentitiesOnly=yes", "-o", "BatchMode=yes"}
+	opts := ssh.OptionsForAccount(a.acc)
+	if opts.ProxyJump != "" {
+		args = append(args, "-J", shellQuote(opts.ProxyJump))
+	} else if opts.ProxyCommand != "" {
+		args = append(args, "-o", shellQuote("ProxyCommand="+opts.ProxyCommand))
+	}
+	return []string{"GIT_SSH_COMMAND=" + strings.Join(args, " ")}
+}
+
+// shellQuote quotes s for the shell git runs GIT_SSH_COMMAND with
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,151 @@
+// Package backup keeps bare mirrors of an account's repositories and
+// optional compressed snapshots of them.
+//
+// A backup directory is laid out as
+//
+//	<dir>/mirrors/<account>/<owner>/<repo>.git
+//	<dir>/archives/<account>/<owner>/<repo>-<timestamp>.tar.gz
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/dwirx/ghex/internal/git"
+)
+
+// stampFormat names snapshots so they sort by time
+const stampFormat = "20060102-150405"
+
+// MirrorPath returns where the mirror of an owner/repo repository is kept
+func MirrorPath(dir, accountName, fullName string) string {
+	return filepath.Join(dir, "mirrors", accountName, filepath.FromSlash(fullName)+".git")
+}
+
+// ArchiveDir returns the directory holding the snapshots of an owner/repo
+// repository
+func ArchiveDir(dir, accountName, fullName string) string {
+	return filepath.Join(dir, "archives", accountName, filepath.Dir(filepath.FromSlash(fullName)))
+}
+
+// Mirror creates the bare mirror at path, or updates it when it exists.
+// It reports whether the mirror was created.
+func Mirror(remoteURL, path string, env []string) (bool, error) {
+	if _, err := os.Stat(filepath.Join(path, "HEAD")); err == nil {
+		return false, git.FetchMirror(path, remoteURL, env)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return false, err
+	}
+	if err := git.CloneMirror(remoteURL, path, env); err != nil {
+		os.RemoveAll(path)
+		return false, err
+	}
+	return true, nil
+}
+
+// Archive writes a gzip-compressed tarball of the mirror at path into dir
+// as <name>-<timestamp>.tar.gz and returns its path
+func Archive(path, dir, name string, now time.Time) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	target := filepath.Join(dir, fmt.Sprintf("%s-%s.tar.gz", name, now.UTC().Format(stampFormat)))
+
+	tmp, err := os.CreateTemp(dir, ".archive-*.tmp")
+	if err != nil {
+		return "", fmt.Errorf("failed to create archive: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	gz := gzip.NewWriter(tmp)
+	tw := tar.NewWriter(gz)
+	root := filepath.Dir(path)
+	err = filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, file)
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(rel)
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err == nil {
+		err = tw.Close()
+	}
+	if err == nil {
+		err = gz.Close()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return "", fmt.Errorf("failed to write archive: %w", err)
+	}
+	return target, nil
+}
+
+// Prune removes all but the newest keep snapshots of name in dir and
+// returns the removed paths
+func Prune(dir, name string, keep int) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	pattern := regexp.MustCompile(`^` + regexp.QuoteMeta(name) + `-\d{8}-\d{6}\.tar\.gz$`)
+	var snapshots []string
+	for _, e := range entries {
+		if !e.IsDir() && pattern.MatchString(e.Name()) {
+			snapshots = append(snapshots, e.Name())
+		}
+	}
+	sort.Strings(snapshots)
+
+	var removed []string
+	for len(snapshots) > keep {
+		path := filepath.Join(dir, snapshots[0])
+		if err := os.Remove(path); err != nil {
+			return removed, err
+		}
+		removed = append(removed, path)
+		snapshots = snapshots[1:]
+	}
+	return removed, nil
+}
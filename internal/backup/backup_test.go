@@ -0,0 +1,112 @@
+package backup
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dwirx/ghex/internal/config"
+)
+
+// gitRun runs git in dir and returns its trimmed output
+func gitRun(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@example.com")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Skipf("git unavailable: %v (%s)", err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// TestMirror tests creating and updating a mirror
+func TestMirror(t *testing.T) {
+	src := t.TempDir()
+	gitRun(t, src, "init", "-q")
+	gitRun(t, src, "commit", "-q", "--allow-empty", "-m", "first")
+
+	path := MirrorPath(t.TempDir(), "work", "company/api")
+	created, err := Mirror(src, path, nil)
+	if err != nil || !created {
+		t.Fatalf("Mirror() = %v, %v, want a new mirror", created, err)
+	}
+
+	gitRun(t, src, "commit", "-q", "--allow-empty", "-m", "second")
+	head := gitRun(t, src, "rev-parse", "HEAD")
+	created, err = Mirror(src, path, nil)
+	if err != nil || created {
+		t.Fatalf("Mirror() = %v, %v, want an updated mirror", created, err)
+	}
+	if got := gitRun(t, path, "rev-parse", "HEAD"); got != head {
+		t.Errorf("Mirror HEAD = %s, want %s", got, head)
+	}
+
+	if _, err := Mirror(filepath.Join(src, "missing"), MirrorPath(t.TempDir(), "work", "company/web"), nil); err == nil {
+		t.Error("Expected an error for a missing repository")
+	}
+}
+
+// TestArchiveAndPrune tests snapshots and keeping the newest ones
+func TestArchiveAndPrune(t *testing.T) {
+	mirror := filepath.Join(t.TempDir(), "api.git")
+	if err := os.MkdirAll(filepath.Join(mirror, "refs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(mirror, "HEAD"), []byte("ref: refs/heads/main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		if _, err := Archive(mirror, dir, "api", now.Add(time.Duration(i)*time.Hour)); err != nil {
+			t.Fatalf("Archive() error = %v", err)
+		}
+	}
+	// Snapshots of another repository sharing the prefix are left alone
+	if _, err := Archive(mirror, dir, "api-v2", now); err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err := Prune(dir, "api", 1)
+	if err != nil || len(removed) != 2 {
+		t.Fatalf("Prune() = %v, %v, want two removed", removed, err)
+	}
+	for _, name := range []string{"api-20260102-050405.tar.gz", "api-v2-20260102-030405.tar.gz"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("Expected %s to be kept", name)
+		}
+	}
+}
+
+// TestAuthEnv tests credentials passed to git per command
+func TestAuthEnv(t *testing.T) {
+	tokenAuth := NewAuth(&config.Account{
+		Name:  "work",
+		Token: &config.TokenConfig{Username: "me", Token: "secret"},
+		SSH:   &config.SshConfig{KeyPath: "~/.ssh/id_work"},
+	})
+	remote := tokenAuth.RemoteURL("company/api")
+	if !strings.HasPrefix(remote, "https://github.com/") {
+		t.Fatalf("RemoteURL() = %s, want an HTTPS URL", remote)
+	}
+	env := strings.Join(tokenAuth.Env(remote), "\n")
+	if !strings.Contains(env, "http.https://github.com/.extraHeader") || strings.Contains(env, "secret") {
+		t.Errorf("Env() = %s, want a host-scoped encoded header", env)
+	}
+
+	sshAuth := NewAuth(&config.Account{
+		Name: "personal",
+		SSH:  &config.SshConfig{KeyPath: "/keys/id personal", ProxyJump: "bastion"},
+	})
+	env = strings.Join(sshAuth.Env(sshAuth.RemoteURL("me/dotfiles")), "\n")
+	if !strings.Contains(env, "-i '/keys/id personal'") || !strings.Contains(env, "-J 'bastion'") {
+		t.Errorf("Env() = %s, want the account's key and jump host", env)
+	}
+}
@@ -0,0 +1,179 @@
+// Package checksum computes and verifies SHA256 and SHA512 digests of files
+// and reads checksum files such as checksums.txt or SHA256SUMS.
+package checksum
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// ErrMismatch is returned when a file doesn't have the expected digest
+var ErrMismatch = errors.New("checksum verification failed - possible security issue")
+
+// Supported algorithms
+const (
+	SHA256 = "sha256"
+	SHA512 = "sha512"
+)
+
+// Digest is an expected hex digest and its algorithm
+type Digest struct {
+	Algorithm string
+	Hex       string
+}
+
+func (d Digest) String() string {
+	return d.Algorithm + ":" + d.Hex
+}
+
+// newHash returns a hash for an algorithm
+func newHash(algorithm string) (hash.Hash, error) {
+	switch strings.ToLower(algorithm) {
+	case SHA256:
+		return sha256.New(), nil
+	case SHA512:
+		return sha512.New(), nil
+	}
+	return nil, fmt.Errorf("unsupported checksum algorithm %q (use %s or %s)", algorithm, SHA256, SHA512)
+}
+
+// AlgorithmFor guesses the algorithm of a hex digest from its length, ""
+// when it matches none
+func AlgorithmFor(hexDigest string) string {
+	switch len(hexDigest) {
+	case sha256.Size * 2:
+		return SHA256
+	case sha512.Size * 2:
+		return SHA512
+	}
+	return ""
+}
+
+// Parse parses "algo:hex", or a bare hex digest whose algorithm is guessed
+// from its length
+func Parse(spec string) (Digest, error) {
+	spec = strings.TrimSpace(spec)
+	algorithm, digest, ok := strings.Cut(spec, ":")
+	if !ok {
+		algorithm, digest = AlgorithmFor(spec), spec
+	}
+	d := Digest{Algorithm: strings.ToLower(algorithm), Hex: strings.ToLower(digest)}
+
+	if d.Algorithm == "" {
+		return Digest{}, fmt.Errorf("invalid checksum %q (expected %s:<hex> or %s:<hex>)", spec, SHA256, SHA512)
+	}
+	if _, err := newHash(d.Algorithm); err != nil {
+		return Digest{}, err
+	}
+	if _, err := hex.DecodeString(d.Hex); err != nil || AlgorithmFor(d.Hex) != d.Algorithm {
+		return Digest{}, fmt.Errorf("invalid %s checksum %q", d.Algorithm, digest)
+	}
+	return d, nil
+}
+
+// Reader computes the hex digest of everything read from r
+func Reader(r io.Reader, algorithm string) (string, error) {
+	h, err := newHash(algorithm)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(h, r); err != nil {
+		return "", fmt.Errorf("failed to calculate checksum: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// File computes the hex digest of a file
+func File(filePath, algorithm string) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer f.Close()
+	return Reader(f, algorithm)
+}
+
+// Bytes computes the SHA256 hex digest of data
+func Bytes(data []byte) string {
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
+
+// Verify checks that a file has the expected digest
+func Verify(filePath string, expected Digest) error {
+	actual, err := File(filePath, expected.Algorithm)
+	if err != nil {
+		return err
+	}
+	want := strings.ToLower(strings.TrimSpace(expected.Hex))
+	if actual != want {
+		return fmt.Errorf("%w: expected %s, got %s", ErrMismatch, want, actual)
+	}
+	return nil
+}
+
+// Entry represents a single entry in a checksums file
+type Entry struct {
+	Checksum string
+	Filename string
+}
+
+// bsdLine matches the BSD format written by `shasum --tag`:
+// "SHA256 (file) = checksum"
+var bsdLine = regexp.MustCompile(`^(?i:SHA\d+) \((.+)\) = ([0-9a-fA-F]+)$`)
+
+// ParseFile parses a checksums file
+// Format: "checksum  filename", "checksum *filename" (binary mode) or
+// "SHA256 (filename) = checksum"
+func ParseFile(content string) ([]Entry, error) {
+	var entries []Entry
+	scanner := bufio.NewScanner(strings.NewReader(content))
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if m := bsdLine.FindStringSubmatch(line); m != nil {
+			entries = append(entries, Entry{Checksum: strings.ToLower(m[2]), Filename: m[1]})
+			continue
+		}
+
+		// Split by whitespace (could be spaces or tabs)
+		parts := strings.Fields(line)
+		if len(parts) < 2 {
+			continue
+		}
+
+		entries = append(entries, Entry{
+			Checksum: strings.ToLower(parts[0]),
+			Filename: strings.TrimPrefix(parts[len(parts)-1], "*"), // Last part is filename
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse checksum file: %w", err)
+	}
+
+	return entries, nil
+}
+
+// Find finds the checksum for a specific filename
+func Find(entries []Entry, filename string) (string, bool) {
+	for _, entry := range entries {
+		if entry.Filename == filename || strings.HasSuffix(entry.Filename, "/"+filename) {
+			return entry.Checksum, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,67 @@
+package checksum
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestParse tests digest specs with and without an algorithm
+func TestParse(t *testing.T) {
+	sha256Hex := Bytes([]byte("hello"))
+	sha512Hex := strings.Repeat("ab", 64)
+
+	tests := []struct {
+		spec    string
+		want    Digest
+		wantErr bool
+	}{
+		{"sha256:" + sha256Hex, Digest{SHA256, sha256Hex}, false},
+		{"SHA256:" + strings.ToUpper(sha256Hex), Digest{SHA256, sha256Hex}, false},
+		{sha512Hex, Digest{SHA512, sha512Hex}, false},
+		{"sha512:" + sha256Hex, Digest{}, true},
+		{"md5:" + sha256Hex, Digest{}, true},
+		{"sha256:xyz", Digest{}, true},
+		{"abc123", Digest{}, true},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.spec)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("Parse(%q) = %v, %v, want %v (error %v)", tt.spec, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+// TestVerifySHA512 tests verifying with SHA512 and the mismatch error
+func TestVerifySHA512(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "test.txt")
+	if err := os.WriteFile(testFile, []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sum, err := File(testFile, SHA512)
+	if err != nil || len(sum) != 128 {
+		t.Fatalf("File(sha512) = %q, %v", sum, err)
+	}
+	if err := Verify(testFile, Digest{SHA512, sum}); err != nil {
+		t.Errorf("Verify() error = %v", err)
+	}
+	if err := Verify(testFile, Digest{SHA512, strings.Repeat("0", 128)}); !errors.Is(err, ErrMismatch) {
+		t.Errorf("Verify() error = %v, want ErrMismatch", err)
+	}
+}
+
+// TestParseFileFormats tests binary-mode and BSD-style lines
+func TestParseFileFormats(t *testing.T) {
+	entries, err := ParseFile("ABC123 *ghex.tar.gz\nSHA512 (ghex.zip) = DEF456\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum, ok := Find(entries, "ghex.tar.gz"); !ok || sum != "abc123" {
+		t.Errorf("Find(ghex.tar.gz) = %q, %v", sum, ok)
+	}
+	if sum, ok := Find(entries, "ghex.zip"); !ok || sum != "def456" {
+		t.Errorf("Find(ghex.zip) = %q, %v", sum, ok)
+	}
+}
@@ -0,0 +1,191 @@
+// Package cleanup finds what ghex leaves behind once accounts are gone:
+// stored credentials for hosts no account uses, SSH Host blocks whose key
+// was deleted, and old binary backups made by updates.
+package cleanup
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dwirx/ghex/internal/account"
+	"github.com/dwirx/ghex/internal/config"
+	"github.com/dwirx/ghex/internal/git"
+	"github.com/dwirx/ghex/internal/platform"
+	"github.com/dwirx/ghex/internal/ssh"
+	"github.com/dwirx/ghex/internal/update"
+)
+
+// Kind is the kind of a stale item
+type Kind string
+
+const (
+	KindCredential Kind = "credential" // Line of ~/.git-credentials
+	KindSSHHost    Kind = "ssh-host"   // Host block of the SSH config
+	KindBackup     Kind = "backup"     // Binary backup made by ghex update
+)
+
+// Item is something stale that can be removed
+type Item struct {
+	Kind   Kind
+	Name   string // Credential host, Host alias or backup file path
+	Detail string
+}
+
+// Find returns the stale items: stored credentials for hosts no account
+// uses, ghex Host blocks pointing at a missing key and update backups older
+// than backupAge
+func Find(cfg *config.AppConfig, backupAge time.Duration, now time.Time) ([]Item, error) {
+	var items []Item
+
+	creds, err := staleCredentials(cfg)
+	if err != nil {
+		return nil, err
+	}
+	items = append(items, creds...)
+
+	hosts, err := staleHostBlocks()
+	if err != nil {
+		return nil, err
+	}
+	items = append(items, hosts...)
+
+	backups, err := oldBackups(update.BackupDir(), backupAge, now)
+	if err != nil {
+		return nil, err
+	}
+	return append(items, backups...), nil
+}
+
+// Remove removes the items and returns the errors, one per failed item
+func Remove(items []Item) []error {
+	var errs []error
+	var hosts []string
+	for _, item := range items {
+		switch item.Kind {
+		case KindCredential:
+			hosts = append(hosts, item.Name)
+		case KindSSHHost:
+			if err := ssh.RemoveHostBlock(item.Name); err != nil {
+				errs = append(errs, fmt.Errorf("Host %s: %w", item.Name, err))
+			}
+		case KindBackup:
+			if err := os.Remove(item.Name); err != nil && !os.IsNotExist(err) {
+				errs = append(errs, err)
+			}
+		}
+	}
+	// One rewrite of the credential file for all hosts
+	if len(hosts) > 0 {
+		if err := git.RemoveStoredCredentials(hosts); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// staleCredentials returns the stored credentials of hosts that no
+// account's platform host matches
+func staleCredentials(cfg *config.AppConfig) ([]Item, error) {
+	creds, err := git.StoredCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	used := map[string]bool{}
+	for i := range cfg.Accounts {
+		used[hostName(account.RepoListOptions(&cfg.Accounts[i]).Host)] = true
+	}
+
+	var items []Item
+	seen := map[string]bool{}
+	for _, cred := range creds {
+		if used[hostName(cred.Host)] || seen[cred.Host] {
+			continue
+		}
+		seen[cred.Host] = true
+		items = append(items, Item{
+			Kind:   KindCredential,
+			Name:   cred.Host,
+			Detail: fmt.Sprintf("user %s, no account uses this host", cred.Username),
+		})
+	}
+	return items, nil
+}
+
+// staleHostBlocks returns the Host blocks written by ghex whose identity
+// file no longer exists
+func staleHostBlocks() ([]Item, error) {
+	blocks, err := ssh.ListHostBlocks()
+	if err != nil {
+		return nil, err
+	}
+
+	var items []Item
+	for _, block := range blocks {
+		if !block.Managed || block.IdentityFile == "" {
+			continue
+		}
+		if _, err := os.Stat(localPath(block.IdentityFile)); !os.IsNotExist(err) {
+			continue
+		}
+		items = append(items, Item{
+			Kind:   KindSSHHost,
+			Name:   block.Alias,
+			Detail: fmt.Sprintf("key %s is missing", block.IdentityFile),
+		})
+	}
+	return items, nil
+}
+
+// oldBackups returns the files in dir last modified more than age ago
+func oldBackups(dir string, age time.Duration, now time.Time) ([]Item, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	var items []Item
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		if now.Sub(info.ModTime()) < age {
+			continue
+		}
+		items = append(items, Item{
+			Kind:   KindBackup,
+			Name:   filepath.Join(dir, entry.Name()),
+			Detail: fmt.Sprintf("%d days old", int(now.Sub(info.ModTime()).Hours()/24)),
+		})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+	return items, nil
+}
+
+// hostName lowercases a host and strips its port
+func hostName(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.ToLower(host)
+}
+
+// localPath expands an IdentityFile path, converting the /c/... paths
+// written for Git Bash back to C:/...
+func localPath(path string) string {
+	path = platform.ExpandPath(path)
+	if runtime.GOOS == "windows" && len(path) >= 3 && path[0] == '/' && path[2] == '/' {
+		path = strings.ToUpper(path[1:2]) + ":" + path[2:]
+	}
+	return path
+}
@@ -0,0 +1,126 @@
+package clipboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/dwirx/ghex/internal/platform"
+)
+
+// ErrUnavailable is returned when no clipboard utility is installed
+var ErrUnavailable = errors.New("no clipboard utility found (install xclip, xsel or wl-clipboard)")
+
+// pasteTimeout bounds reading the clipboard, so a utility waiting for an
+// unreachable display doesn't hold up the caller
+const pasteTimeout = 2 * time.Second
+
+// tool is a clipboard utility and the arguments that make it read stdin
+// (or, for the paste tools, write the clipboard to stdout)
+type tool struct {
+	name string
+	args []string
+}
+
+// tools returns the clipboard utilities to try for the current platform,
+// in order of preference
+func tools() []tool {
+	switch {
+	case platform.IsMacOS():
+		return []tool{{name: "pbcopy"}}
+	case platform.IsWindows():
+		return []tool{{name: "clip.exe"}}
+	}
+
+	var list []tool
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		list = append(list, tool{name: "wl-copy"})
+	}
+	list = append(list,
+		tool{name: "xclip", args: []string{"-selection", "clipboard"}},
+		tool{name: "xsel", args: []string{"--clipboard", "--input"}},
+		// WSL can reach the Windows clipboard
+		tool{name: "clip.exe"},
+	)
+	return list
+}
+
+// pasteTools returns the utilities that print the clipboard, in order of
+// preference
+func pasteTools() []tool {
+	powershell := tool{name: "powershell.exe", args: []string{"-NoProfile", "-NonInteractive", "-Command", "Get-Clipboard"}}
+	switch {
+	case platform.IsMacOS():
+		return []tool{{name: "pbpaste"}}
+	case platform.IsWindows():
+		return []tool{powershell}
+	}
+
+	var list []tool
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		list = append(list, tool{name: "wl-paste", args: []string{"--no-newline"}})
+	}
+	list = append(list,
+		tool{name: "xclip", args: []string{"-selection", "clipboard", "-o"}},
+		tool{name: "xsel", args: []string{"--clipboard", "--output"}},
+		// WSL can reach the Windows clipboard
+		powershell,
+	)
+	return list
+}
+
+// available returns the first utility of list that is installed
+func available(list []tool) (tool, bool) {
+	for _, t := range list {
+		if _, err := exec.LookPath(t.name); err == nil {
+			return t, true
+		}
+	}
+	return tool{}, false
+}
+
+// Available reports whether Copy can reach a clipboard
+func Available() bool {
+	_, ok := available(tools())
+	return ok
+}
+
+// Copy places text on the system clipboard. It returns ErrUnavailable
+// when no clipboard utility is installed, so callers can fall back to
+// printing the text.
+func Copy(text string) error {
+	t, ok := available(tools())
+	if !ok {
+		return ErrUnavailable
+	}
+
+	// Output is not captured: xclip and wl-copy fork a process that
+	// serves the selection and would hold a captured pipe open
+	cmd := exec.Command(t.name, t.args...)
+	cmd.Stdin = strings.NewReader(text)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s failed: %w", t.name, err)
+	}
+	return nil
+}
+
+// Paste returns the text on the system clipboard, or ErrUnavailable when
+// no utility can read it
+func Paste() (string, error) {
+	t, ok := available(pasteTools())
+	if !ok {
+		return "", ErrUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pasteTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, t.name, t.args...).Output()
+	if err != nil {
+		return "", fmt.Errorf("%s failed: %w", t.name, err)
+	}
+	return string(out), nil
+}
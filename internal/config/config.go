@@ -4,14 +4,16 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/dwirx/ghex/internal/platform"
 )
 
 // Manager handles configuration loading and saving
 type Manager struct {
-	primaryPath string
-	legacyPath  string
+	primaryPath      string
+	legacyPath       string
+	permissionIssues []PermissionIssue // found by the last Load
 }
 
 // NewManager creates a new configuration manager
@@ -42,6 +44,7 @@ func (m *Manager) Load() (*AppConfig, error) {
 			if path == m.legacyPath {
 				_ = m.Save(cfg) // Ignore migration errors
 			}
+			m.permissionIssues = m.CheckPermissions()
 			return cfg, nil
 		}
 
@@ -80,9 +83,9 @@ func (m *Manager) loadFromPath(path string) (*AppConfig, error) {
 
 // Save writes the configuration to disk
 func (m *Manager) Save(cfg *AppConfig) error {
-	// Ensure directory exists
+	// Ensure directory exists, private since the config holds tokens
 	dir := filepath.Dir(m.primaryPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
+	if err := os.MkdirAll(dir, configDirMode); err != nil {
 		return err
 	}
 
@@ -95,7 +98,15 @@ func (m *Manager) Save(cfg *AppConfig) error {
 	// Add trailing newline
 	data = append(data, '\n')
 
-	return os.WriteFile(m.primaryPath, data, 0644)
+	if err := os.WriteFile(m.primaryPath, data, configFileMode); err != nil {
+		return err
+	}
+	// WriteFile keeps the mode of an existing file; tighten one created
+	// by older versions, and its directory. Failing to is left for
+	// ghex health to report, the config itself was saved.
+	_ = m.FixPermissions()
+	m.permissionIssues = m.CheckPermissions()
+	return nil
 }
 
 // Global manager instance
@@ -174,11 +185,20 @@ func (a *Account) Clone() Account {
 		GitUserName: a.GitUserName,
 		GitEmail:    a.GitEmail,
 	}
+	if len(a.Emails) > 0 {
+		clone.Emails = append([]string(nil), a.Emails...)
+	}
 	
 	if a.SSH != nil {
 		clone.SSH = &SshConfig{
-			KeyPath:   a.SSH.KeyPath,
-			HostAlias: a.SSH.HostAlias,
+			KeyPath:      a.SSH.KeyPath,
+			HostAlias:    a.SSH.HostAlias,
+			Port:         a.SSH.Port,
+			ProxyJump:    a.SSH.ProxyJump,
+			ProxyCommand: a.SSH.ProxyCommand,
+		}
+		if len(a.SSH.Devices) > 0 {
+			clone.SSH.Devices = append([]SshDevice(nil), a.SSH.Devices...)
 		}
 	}
 	
@@ -209,6 +229,14 @@ func (a *Account) Equals(other *Account) bool {
 	if a.Name != other.Name || a.GitUserName != other.GitUserName || a.GitEmail != other.GitEmail {
 		return false
 	}
+	if len(a.Emails) != len(other.Emails) {
+		return false
+	}
+	for i := range a.Emails {
+		if a.Emails[i] != other.Emails[i] {
+			return false
+		}
+	}
 	
 	// Compare SSH
 	if (a.SSH == nil) != (other.SSH == nil) {
@@ -218,6 +246,17 @@ func (a *Account) Equals(other *Account) bool {
 		if a.SSH.KeyPath != other.SSH.KeyPath || a.SSH.HostAlias != other.SSH.HostAlias {
 			return false
 		}
+		if a.SSH.Port != other.SSH.Port || a.SSH.ProxyJump != other.SSH.ProxyJump || a.SSH.ProxyCommand != other.SSH.ProxyCommand {
+			return false
+		}
+		if len(a.SSH.Devices) != len(other.SSH.Devices) {
+			return false
+		}
+		for i := range a.SSH.Devices {
+			if a.SSH.Devices[i] != other.SSH.Devices[i] {
+				return false
+			}
+		}
 	}
 	
 	// Compare Token
@@ -242,3 +281,55 @@ func (a *Account) Equals(other *Account) bool {
 	
 	return true
 }
+
+// AllEmails returns the addresses the account commits with: gitEmail
+// first, then the other emails, without duplicates
+func (a *Account) AllEmails() []string {
+	var emails []string
+	for _, email := range append([]string{a.GitEmail}, a.Emails...) {
+		if email != "" && !containsFold(emails, email) {
+			emails = append(emails, email)
+		}
+	}
+	return emails
+}
+
+// HasEmail reports whether email is one of the account's addresses
+func (a *Account) HasEmail(email string) bool {
+	return email != "" && containsFold(a.AllEmails(), email)
+}
+
+// RecordDevice records that the account's key lives on a device, replacing
+// what was recorded for the device before: a machine holds one key per
+// account
+func (s *SshConfig) RecordDevice(device SshDevice) {
+	for i, d := range s.Devices {
+		if strings.EqualFold(d.Name, device.Name) {
+			s.Devices[i] = device
+			return
+		}
+	}
+	s.Devices = append(s.Devices, device)
+}
+
+// RemoveDevice forgets a device, returning what was recorded for it and
+// whether there was anything
+func (s *SshConfig) RemoveDevice(name string) (SshDevice, bool) {
+	for i, d := range s.Devices {
+		if strings.EqualFold(d.Name, name) {
+			s.Devices = append(s.Devices[:i], s.Devices[i+1:]...)
+			return d, true
+		}
+	}
+	return SshDevice{}, false
+}
+
+// containsFold reports whether list holds s, ignoring case
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
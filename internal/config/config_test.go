@@ -2,6 +2,7 @@ package config
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 )
 
@@ -259,6 +260,76 @@ func TestAccountEqualsWithOptionalFields(t *testing.T) {
 	if !acc1.Equals(acc2) {
 		t.Error("Expected accounts with same SSH to be equal")
 	}
+
+	// Different jump host
+	acc2.SSH.ProxyJump = "git@bastion.example.com"
+	if acc1.Equals(acc2) {
+		t.Error("Expected accounts with different SSH ProxyJump to not be equal")
+	}
+}
+
+// TestAccountEmails tests the combined list of an account's emails
+func TestAccountEmails(t *testing.T) {
+	acc := &Account{
+		Name:     "test",
+		GitEmail: "me@example.com",
+		Emails:   []string{"1+me@users.noreply.github.com", "ME@example.com", "me@corp.com"},
+	}
+
+	got := acc.AllEmails()
+	want := []string{"me@example.com", "1+me@users.noreply.github.com", "me@corp.com"}
+	if len(got) != len(want) {
+		t.Fatalf("AllEmails() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("AllEmails()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	if !acc.HasEmail("Me@Corp.com") {
+		t.Error("Expected HasEmail to match case-insensitively")
+	}
+	if acc.HasEmail("other@example.com") {
+		t.Error("Expected HasEmail to reject an unknown email")
+	}
+
+	// Emails are part of equality
+	other := acc.Clone()
+	other.Emails = other.Emails[:1]
+	if acc.Equals(&other) {
+		t.Error("Expected accounts with different emails to not be equal")
+	}
+}
+
+// TestSshDevices tests recording, replacing and removing devices
+func TestSshDevices(t *testing.T) {
+	acc := &Account{Name: "work", SSH: &SshConfig{KeyPath: "~/.ssh/id_ed25519_work"}}
+	acc.SSH.RecordDevice(SshDevice{Name: "laptop", KeyPath: "~/.ssh/id_ed25519_work", Fingerprint: "SHA256:a"})
+	acc.SSH.RecordDevice(SshDevice{Name: "desktop", KeyPath: "~/.ssh/id_ed25519_work", Fingerprint: "SHA256:b"})
+	acc.SSH.RecordDevice(SshDevice{Name: "Laptop", KeyPath: "~/.ssh/id_ed25519_work", Fingerprint: "SHA256:c"})
+
+	if len(acc.SSH.Devices) != 2 || acc.SSH.Devices[0].Fingerprint != "SHA256:c" {
+		t.Fatalf("Devices = %+v, want laptop replaced and desktop kept", acc.SSH.Devices)
+	}
+
+	// Devices are cloned and part of equality
+	other := acc.Clone()
+	if !acc.Equals(&other) {
+		t.Error("Expected a clone with the same devices to be equal")
+	}
+	if d, ok := other.SSH.RemoveDevice("desktop"); !ok || d.Fingerprint != "SHA256:b" {
+		t.Errorf("RemoveDevice(desktop) = %+v, %v", d, ok)
+	}
+	if len(acc.SSH.Devices) != 2 {
+		t.Error("Removing a device from a clone changed the original")
+	}
+	if acc.Equals(&other) {
+		t.Error("Expected accounts with different devices to not be equal")
+	}
+	if _, ok := other.SSH.RemoveDevice("desktop"); ok {
+		t.Error("RemoveDevice() of an unknown device succeeded")
+	}
 }
 
 // TestAppConfigToJSON tests app config serialization
@@ -344,3 +415,27 @@ func TestAccountFromJSONWithMissingOptionalFields(t *testing.T) {
 		t.Error("Expected Platform to be nil for minimal account")
 	}
 }
+
+func TestMenuConfigArrange(t *testing.T) {
+	keys := []string{"switch", "list", "add", "deploy", "exit"}
+
+	tests := []struct {
+		name string
+		menu *MenuConfig
+		want []string
+	}{
+		{"nil keeps default", nil, keys},
+		{"hide", &MenuConfig{Hide: []string{"add", "list"}}, []string{"switch", "deploy", "exit"}},
+		{"order moves to front", &MenuConfig{Order: []string{"deploy", "list"}}, []string{"deploy", "list", "switch", "add", "exit"}},
+		{"unknown and hidden keys in order are ignored", &MenuConfig{Hide: []string{"list"}, Order: []string{"nope", "list", "add", "add"}}, []string{"add", "switch", "deploy", "exit"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.menu.Arrange(keys)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Arrange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
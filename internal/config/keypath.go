@@ -0,0 +1,270 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrKeyNotFound is returned for a key path with no value
+var ErrKeyNotFound = errors.New("no value at key path")
+
+// pathStep is one step of a key path: a field (name or [name]) or an array
+// element ([index], or [name] for the element whose name, key or dir is
+// name)
+type pathStep struct {
+	key     string
+	bracket bool
+}
+
+// elementKeys are the fields that identify an element of an array in a
+// key path: accounts by name, menu entries by key, workspaces by dir
+var elementKeys = []string{"name", "key", "dir"}
+
+// parseKeyPath splits a key path like accounts[work].ssh.keyPath
+func parseKeyPath(path string) ([]pathStep, error) {
+	var steps []pathStep
+	rest := path
+	for rest != "" {
+		if rest[0] == '[' {
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid key path %q: missing ]", path)
+			}
+			if end == 1 {
+				return nil, fmt.Errorf("invalid key path %q: empty []", path)
+			}
+			steps = append(steps, pathStep{key: rest[1:end], bracket: true})
+			rest = rest[end+1:]
+			if rest != "" && rest[0] != '.' && rest[0] != '[' {
+				return nil, fmt.Errorf("invalid key path %q: expected . or [ after ]", path)
+			}
+		} else {
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid key path %q: empty key", path)
+			}
+			steps = append(steps, pathStep{key: rest[:end]})
+			rest = rest[end:]
+		}
+		if strings.HasPrefix(rest, ".") {
+			rest = rest[1:]
+			if rest == "" || rest[0] == '.' || rest[0] == '[' {
+				return nil, fmt.Errorf("invalid key path %q: empty key", path)
+			}
+		}
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("empty key path")
+	}
+	return steps, nil
+}
+
+// elementIndex returns the index of the array element a step selects, -1
+// when there is none
+func elementIndex(arr []interface{}, key string) int {
+	if i, err := strconv.Atoi(key); err == nil {
+		if i >= 0 && i < len(arr) {
+			return i
+		}
+		return -1
+	}
+	for i, elem := range arr {
+		switch v := elem.(type) {
+		case map[string]interface{}:
+			for _, field := range elementKeys {
+				if s, ok := v[field].(string); ok && s == key {
+					return i
+				}
+			}
+		case string:
+			if v == key {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// decodeDocument decodes a config as generic JSON, keeping numbers exact
+func decodeDocument(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// GetKey returns the value at a key path in a config's JSON, or
+// ErrKeyNotFound
+func GetKey(data []byte, path string) (interface{}, error) {
+	steps, err := parseKeyPath(path)
+	if err != nil {
+		return nil, err
+	}
+	node, err := decodeDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	for _, step := range steps {
+		switch n := node.(type) {
+		case map[string]interface{}:
+			child, ok := n[step.key]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, path)
+			}
+			node = child
+		case []interface{}:
+			i := elementIndex(n, step.key)
+			if !step.bracket || i < 0 {
+				return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, path)
+			}
+			node = n[i]
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, path)
+		}
+	}
+	return node, nil
+}
+
+// SetKey returns the config JSON with the value at a key path replaced.
+// Missing objects along the path are created; array elements must exist,
+// except that index len appends one.
+func SetKey(data []byte, path string, value interface{}) ([]byte, error) {
+	steps, err := parseKeyPath(path)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := decodeDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	doc, err = setIn(doc, steps, value, path)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// setIn sets the value at steps below node and returns the updated node
+func setIn(node interface{}, steps []pathStep, value interface{}, path string) (interface{}, error) {
+	if len(steps) == 0 {
+		return value, nil
+	}
+	step := steps[0]
+
+	if node == nil {
+		if _, err := strconv.Atoi(step.key); step.bracket && err == nil {
+			node = []interface{}{}
+		} else {
+			node = map[string]interface{}{}
+		}
+	}
+
+	switch n := node.(type) {
+	case map[string]interface{}:
+		child, err := setIn(n[step.key], steps[1:], value, path)
+		if err != nil {
+			return nil, err
+		}
+		n[step.key] = child
+		return n, nil
+	case []interface{}:
+		if !step.bracket {
+			return nil, fmt.Errorf("%s: %q is a list, select an element with [index] or [name]", path, step.key)
+		}
+		i := elementIndex(n, step.key)
+		if i < 0 {
+			if idx, err := strconv.Atoi(step.key); err == nil && idx == len(n) {
+				n = append(n, nil)
+				i = idx
+			} else {
+				return nil, fmt.Errorf("%s: no element [%s]", path, step.key)
+			}
+		}
+		child, err := setIn(n[i], steps[1:], value, path)
+		if err != nil {
+			return nil, err
+		}
+		n[i] = child
+		return n, nil
+	}
+	return nil, fmt.Errorf("%s: cannot set %q inside a %s", path, step.key, jsonKind(node))
+}
+
+// UnsetKey returns the config JSON without the value at a key path,
+// removing the field or array element. It reports false, and returns data
+// unchanged, when there was no value.
+func UnsetKey(data []byte, path string) ([]byte, bool, error) {
+	steps, err := parseKeyPath(path)
+	if err != nil {
+		return nil, false, err
+	}
+	doc, err := decodeDocument(data)
+	if err != nil {
+		return nil, false, err
+	}
+	doc, found := unsetIn(doc, steps)
+	if !found {
+		return data, false, nil
+	}
+	out, err := json.MarshalIndent(doc, "", "  ")
+	return out, true, err
+}
+
+// unsetIn removes the value at steps below node and returns the updated
+// node, and whether there was a value
+func unsetIn(node interface{}, steps []pathStep) (interface{}, bool) {
+	step := steps[0]
+	switch n := node.(type) {
+	case map[string]interface{}:
+		child, ok := n[step.key]
+		if !ok {
+			return node, false
+		}
+		if len(steps) == 1 {
+			delete(n, step.key)
+			return n, true
+		}
+		child, found := unsetIn(child, steps[1:])
+		n[step.key] = child
+		return n, found
+	case []interface{}:
+		i := elementIndex(n, step.key)
+		if !step.bracket || i < 0 {
+			return node, false
+		}
+		if len(steps) == 1 {
+			return append(n[:i], n[i+1:]...), true
+		}
+		child, found := unsetIn(n[i], steps[1:])
+		n[i] = child
+		return n, found
+	}
+	return node, false
+}
+
+// jsonKind names the JSON type of a decoded value
+func jsonKind(v interface{}) string {
+	switch v.(type) {
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "boolean"
+	case []interface{}:
+		return "list"
+	case map[string]interface{}:
+		return "object"
+	}
+	return "null"
+}
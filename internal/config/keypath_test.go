@@ -0,0 +1,113 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+const keyPathConfig = `{
+  "accounts": [
+    {"name": "home", "gitEmail": "me@home.net"},
+    {"name": "work", "ssh": {"keyPath": "~/.ssh/id_work", "port": 2222}}
+  ],
+  "menu": {"hide": ["ssh", "dlx"]}
+}`
+
+// TestGetKey tests looking up values by key path
+func TestGetKey(t *testing.T) {
+	tests := []struct {
+		path string
+		want string // JSON of the value, empty = not found
+	}{
+		{"accounts[work].ssh.port", `2222`},
+		{"accounts[0].gitEmail", `"me@home.net"`},
+		{"accounts[home]", `{"gitEmail":"me@home.net","name":"home"}`},
+		{"menu.hide[dlx]", `"dlx"`},
+		{"accounts[work].gitEmail", ""},
+		{"accounts[other]", ""},
+		{"accounts[5]", ""},
+		{"accounts.name", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := GetKey([]byte(keyPathConfig), tt.path)
+			if tt.want == "" {
+				if !errors.Is(err, ErrKeyNotFound) {
+					t.Errorf("GetKey() error = %v, want ErrKeyNotFound", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetKey() error = %v", err)
+			}
+			data, _ := json.Marshal(got)
+			if string(data) != tt.want {
+				t.Errorf("GetKey() = %s, want %s", data, tt.want)
+			}
+		})
+	}
+}
+
+// TestSetKey tests that values are set, creating objects on the way
+func TestSetKey(t *testing.T) {
+	tests := []struct {
+		path    string
+		value   interface{}
+		wantErr bool
+	}{
+		{path: "accounts[work].gitEmail", value: "x@corp.com"},
+		{path: "accounts[home].platform.type", value: "gitlab"},
+		{path: "aliases[dl.r]", value: "dlx release"},
+		{path: "menu.hide[2]", value: "log"},
+		{path: "accounts[other].gitEmail", value: "x", wantErr: true},
+		{path: "accounts.gitEmail", value: "x", wantErr: true},
+		{path: "accounts[home].name.first", value: "x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			data, err := SetKey([]byte(keyPathConfig), tt.path, tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("SetKey() = %s, want an error", data)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SetKey() error = %v", err)
+			}
+			got, err := GetKey(data, tt.path)
+			if err != nil || got != tt.value {
+				t.Errorf("after SetKey() GetKey() = %v, %v, want %v", got, err, tt.value)
+			}
+		})
+	}
+}
+
+// TestUnsetKey tests removing fields and array elements
+func TestUnsetKey(t *testing.T) {
+	data, found, err := UnsetKey([]byte(keyPathConfig), "accounts[home]")
+	if err != nil || !found {
+		t.Fatalf("UnsetKey() = %v, %v", found, err)
+	}
+	if got, _ := GetKey(data, "accounts[0].name"); got != "work" {
+		t.Errorf("accounts[0].name = %v, want work", got)
+	}
+
+	data, found, err = UnsetKey(data, "accounts[work].ssh.port")
+	if err != nil || !found {
+		t.Fatalf("UnsetKey() = %v, %v", found, err)
+	}
+	if _, err := GetKey(data, "accounts[work].ssh.port"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("port still set after UnsetKey()")
+	}
+
+	if _, found, err := UnsetKey(data, "accounts[home].gitEmail"); err != nil || found {
+		t.Errorf("UnsetKey() of a missing key = %v, %v, want false", found, err)
+	}
+	if _, _, err := UnsetKey(data, "accounts[x"); err == nil {
+		t.Errorf("UnsetKey() accepted an invalid path")
+	}
+}
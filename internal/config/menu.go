@@ -0,0 +1,46 @@
+package config
+
+// Arrange applies the menu customization to keys, the default order of
+// the menu entries: hidden keys are dropped and keys listed in Order move
+// to the front. Unknown keys in Hide and Order are ignored.
+func (m *MenuConfig) Arrange(keys []string) []string {
+	if m == nil {
+		return keys
+	}
+
+	hidden := make(map[string]bool, len(m.Hide))
+	for _, k := range m.Hide {
+		hidden[k] = true
+	}
+	known := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		known[k] = true
+	}
+
+	var arranged []string
+	placed := map[string]bool{}
+	for _, k := range m.Order {
+		if known[k] && !hidden[k] && !placed[k] {
+			arranged = append(arranged, k)
+			placed[k] = true
+		}
+	}
+	for _, k := range keys {
+		if !hidden[k] && !placed[k] {
+			arranged = append(arranged, k)
+		}
+	}
+	return arranged
+}
+
+// CustomEntry returns the custom entry with key, if any
+func (m *MenuConfig) CustomEntry(key string) (MenuEntry, bool) {
+	if m != nil {
+		for _, e := range m.Custom {
+			if e.Key == key {
+				return e, true
+			}
+		}
+	}
+	return MenuEntry{}, false
+}
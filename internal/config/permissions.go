@@ -0,0 +1,76 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dwirx/ghex/internal/platform"
+)
+
+// The config file holds tokens, so it and its directory are private to
+// the user
+const (
+	configFileMode os.FileMode = 0600
+	configDirMode  os.FileMode = 0700
+)
+
+// PermissionIssue is a config file or directory that other users can access
+type PermissionIssue struct {
+	Path string
+	Mode os.FileMode // current permission bits
+	Want os.FileMode
+}
+
+func (p PermissionIssue) String() string {
+	return fmt.Sprintf("%s is %04o, should be %04o", p.Path, p.Mode, p.Want)
+}
+
+// CheckPermissions returns the config file, and its directory, if group
+// or others have any access to them. A private file in a shared directory
+// is fine, so the directory only counts when the file is readable by
+// others. Windows is not checked, its files are protected by ACLs rather
+// than mode bits.
+func (m *Manager) CheckPermissions() []PermissionIssue {
+	if platform.IsWindows() {
+		return nil
+	}
+	info, err := os.Stat(m.primaryPath)
+	if err != nil || info.Mode().Perm()&0044 == 0 {
+		return nil
+	}
+
+	var issues []PermissionIssue
+	for _, p := range []struct {
+		path string
+		want os.FileMode
+	}{
+		{filepath.Dir(m.primaryPath), configDirMode},
+		{m.primaryPath, configFileMode},
+	} {
+		info, err := os.Stat(p.path)
+		if err != nil {
+			continue
+		}
+		if mode := info.Mode().Perm(); mode&0077 != 0 {
+			issues = append(issues, PermissionIssue{Path: p.path, Mode: mode, Want: p.want})
+		}
+	}
+	return issues
+}
+
+// FixPermissions restricts the config file and directory to the user
+func (m *Manager) FixPermissions() error {
+	for _, issue := range m.CheckPermissions() {
+		if err := os.Chmod(issue.Path, issue.Want); err != nil {
+			return fmt.Errorf("failed to restrict %s: %w", issue.Path, err)
+		}
+	}
+	return nil
+}
+
+// PermissionIssues returns what CheckPermissions found when the config
+// was last loaded
+func (m *Manager) PermissionIssues() []PermissionIssue {
+	return m.permissionIssues
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// TestSaveRestrictsPermissions tests that lax permissions are found on load
+// and tightened by Save
+func TestSaveRestrictsPermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("mode bits are not used on Windows")
+	}
+	dir := filepath.Join(t.TempDir(), "ghe")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(path, []byte(`{"accounts": []}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// The umask may have dropped bits, so set them explicitly
+	if err := os.Chmod(path, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := &Manager{primaryPath: path, legacyPath: filepath.Join(dir, "missing.json")}
+	cfg, err := m.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := len(m.PermissionIssues()); got != 2 {
+		t.Fatalf("PermissionIssues() = %v, want the file and directory", m.PermissionIssues())
+	}
+
+	if err := m.Save(cfg); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	for p, want := range map[string]os.FileMode{path: 0600, dir: 0700} {
+		info, err := os.Stat(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := info.Mode().Perm(); got != want {
+			t.Errorf("%s mode = %04o, want %04o", p, got, want)
+		}
+	}
+	if issues := m.PermissionIssues(); len(issues) != 0 {
+		t.Errorf("PermissionIssues() after Save() = %v", issues)
+	}
+}
+
+// TestCheckPermissionsPrivateFile tests that a shared directory alone is
+// not reported when the config file itself is private
+func TestCheckPermissionsPrivateFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("mode bits are not used on Windows")
+	}
+	dir := filepath.Join(t.TempDir(), "ghe")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(path, []byte(`{"accounts": []}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	m := &Manager{primaryPath: path, legacyPath: filepath.Join(dir, "missing.json")}
+	if issues := m.CheckPermissions(); len(issues) != 0 {
+		t.Errorf("CheckPermissions() = %v, want none for a private file", issues)
+	}
+}
@@ -2,8 +2,21 @@ package config
 
 // SshConfig holds SSH authentication configuration
 type SshConfig struct {
-	KeyPath   string `json:"keyPath"`
-	HostAlias string `json:"hostAlias,omitempty"`
+	KeyPath      string      `json:"keyPath"`
+	HostAlias    string      `json:"hostAlias,omitempty"`
+	Port         int         `json:"port,omitempty"`         // custom SSH port (0 = default 22)
+	ProxyJump    string      `json:"proxyJump,omitempty"`    // jump host(s), e.g. user@bastion:22
+	ProxyCommand string      `json:"proxyCommand,omitempty"` // raw ProxyCommand (used when ProxyJump is empty)
+	Devices      []SshDevice `json:"devices,omitempty"`      // machines holding a key of the account
+}
+
+// SshDevice is a machine an account's SSH key was generated or imported on
+type SshDevice struct {
+	Name        string `json:"name"`                  // hostname of the machine
+	KeyPath     string `json:"keyPath"`               // key path on that machine
+	Fingerprint string `json:"fingerprint,omitempty"` // SHA256:... of the public key
+	Comment     string `json:"comment,omitempty"`
+	AddedAt     string `json:"addedAt,omitempty"` // RFC3339
 }
 
 // TokenConfig holds token/PAT authentication configuration
@@ -24,9 +37,15 @@ type Account struct {
 	Name        string          `json:"name"`
 	GitUserName string          `json:"gitUserName,omitempty"`
 	GitEmail    string          `json:"gitEmail,omitempty"`
+	Emails      []string        `json:"emails,omitempty"` // Other addresses to commit with, e.g. a noreply address; gitEmail is the default
 	SSH         *SshConfig      `json:"ssh,omitempty"`
 	Token       *TokenConfig    `json:"token,omitempty"`
 	Platform    *PlatformConfig `json:"platform,omitempty"`
+	CloneDir    string          `json:"cloneDir,omitempty"`  // Clones go to <cloneDir>/<owner>/<repo>
+	Notes       string          `json:"notes,omitempty"`     // Free-form notes shown in the detail view
+	CreatedAt   string          `json:"createdAt,omitempty"` // RFC3339, set by the manager
+	UpdatedAt   string          `json:"updatedAt,omitempty"` // RFC3339, set by the manager
+	Favorite    bool            `json:"favorite,omitempty"`  // Listed before other accounts in selectors
 }
 
 // HealthStatus holds the health check result for an account
@@ -52,12 +71,44 @@ type ActivityLogEntry struct {
 	Error       string `json:"error,omitempty"`
 }
 
+// MenuEntry is a custom interactive menu entry that runs a shell command
+type MenuEntry struct {
+	Key         string `json:"key"` // Used in hide/order; must not clash with a built-in key
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Command     string `json:"command"` // Run with the user's shell in the current directory
+}
+
+// MenuConfig customizes the interactive main menu
+type MenuConfig struct {
+	Hide   []string    `json:"hide,omitempty"`   // Keys of entries to leave out
+	Order  []string    `json:"order,omitempty"`  // Keys shown first, in this order; the rest follow as usual
+	Custom []MenuEntry `json:"custom,omitempty"` // Extra entries, shown before Exit
+}
+
+// Workspace groups the git repositories under a directory with the account
+// they should use
+type Workspace struct {
+	Dir     string   `json:"dir"` // Absolute path, searched for repositories
+	Account string   `json:"account"`
+	Orgs    []string `json:"orgs,omitempty"` // Users or organizations whose repositories belong here
+}
+
 // AppConfig is the main application configuration
 type AppConfig struct {
 	Accounts        []Account          `json:"accounts"`
 	ActivityLog     []ActivityLogEntry `json:"activityLog,omitempty"`
 	HealthChecks    []HealthStatus     `json:"healthChecks,omitempty"`
 	LastHealthCheck string             `json:"lastHealthCheck,omitempty"`
+	AccountSort     string             `json:"accountSort,omitempty"`     // manual, recent, alphabetical or added
+	Language        string             `json:"language,omitempty"`        // Message language (en, id); empty follows the environment
+	Accessible      bool               `json:"accessible,omitempty"`      // Screen-reader friendly output without animations
+	SSHConfigMode   string             `json:"sshConfigMode,omitempty"`   // edit (default), include or print
+	Menu            *MenuConfig        `json:"menu,omitempty"`            // Interactive main menu customization
+	Aliases         map[string]string  `json:"aliases,omitempty"`         // Command aliases, e.g. "dlr": "dlx release"
+	Workspaces      []Workspace        `json:"workspaces,omitempty"`      // Directories whose repositories belong to one account
+	DownloadHeaders map[string]string  `json:"downloadHeaders,omitempty"` // Headers added to every dlx request, e.g. for a corporate proxy
+	BinDir          string             `json:"binDir,omitempty"`          // Where ghex install puts binaries (default: ~/.local/bin)
 }
 
 // NewAppConfig creates a new empty AppConfig
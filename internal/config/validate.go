@@ -0,0 +1,256 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ValidationError is a problem in a config file, at the line of the value
+// it concerns
+type ValidationError struct {
+	Line    int    // 1-based, 0 when unknown
+	Path    string // e.g. accounts[1].ssh.keyPath, empty for syntax errors
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	where := ""
+	if e.Line > 0 {
+		where = fmt.Sprintf("line %d: ", e.Line)
+	}
+	if e.Path != "" {
+		return where + e.Path + ": " + e.Message
+	}
+	return where + e.Message
+}
+
+// Values accepted by the enumerated settings
+var (
+	platformTypes  = []string{"github", "gitlab", "bitbucket", "gitea", "codeberg", "other"}
+	accountSorts   = []string{"manual", "recent", "alphabetical", "added"}
+	sshConfigModes = []string{"edit", "include", "print"}
+)
+
+// Validate checks the contents of a config file: JSON syntax, field names
+// and types, then the values ghex relies on, such as unique account names
+// and known platform types. It returns every problem found, ordered by
+// line; none means the file can be saved.
+func Validate(data []byte) []*ValidationError {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var cfg AppConfig
+	if err := dec.Decode(&cfg); err != nil {
+		return []*ValidationError{decodeError(data, dec, err)}
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return []*ValidationError{{Line: lineAt(data, dec.InputOffset()), Message: "unexpected data after the config object"}}
+	}
+
+	lines := valueLines(data)
+	var errs []*ValidationError
+	report := func(path, format string, args ...interface{}) {
+		errs = append(errs, &ValidationError{Line: lines.of(path), Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	names := map[string]int{}
+	for i, acc := range cfg.Accounts {
+		path := fmt.Sprintf("accounts[%d]", i)
+		switch {
+		case strings.TrimSpace(acc.Name) == "":
+			report(path+".name", "account name is required")
+		case names[acc.Name] > 0:
+			report(path+".name", "duplicate account name %q (also accounts[%d])", acc.Name, names[acc.Name]-1)
+		default:
+			names[acc.Name] = i + 1
+		}
+		if acc.SSH != nil {
+			if strings.TrimSpace(acc.SSH.KeyPath) == "" {
+				report(path+".ssh.keyPath", "SSH key path is required")
+			}
+			if acc.SSH.Port < 0 || acc.SSH.Port > 65535 {
+				report(path+".ssh.port", "port %d is out of range", acc.SSH.Port)
+			}
+		}
+		if acc.Token != nil {
+			if strings.TrimSpace(acc.Token.Username) == "" {
+				report(path+".token.username", "token username is required")
+			}
+			if strings.TrimSpace(acc.Token.Token) == "" {
+				report(path+".token.token", "token is required")
+			}
+		}
+		for j, email := range acc.Emails {
+			if !strings.Contains(email, "@") {
+				report(fmt.Sprintf("%s.emails[%d]", path, j), "invalid email %q", email)
+			}
+		}
+		if acc.Platform != nil {
+			if !oneOf(acc.Platform.Type, platformTypes) {
+				report(path+".platform.type", "unknown platform %q (use %s)", acc.Platform.Type, strings.Join(platformTypes, ", "))
+			}
+		}
+	}
+
+	if cfg.AccountSort != "" && !oneOf(cfg.AccountSort, accountSorts) {
+		report("accountSort", "unknown sort order %q (use %s)", cfg.AccountSort, strings.Join(accountSorts, ", "))
+	}
+	if cfg.SSHConfigMode != "" && !oneOf(cfg.SSHConfigMode, sshConfigModes) {
+		report("sshConfigMode", "unknown SSH config mode %q (use %s)", cfg.SSHConfigMode, strings.Join(sshConfigModes, ", "))
+	}
+
+	for i, ws := range cfg.Workspaces {
+		path := fmt.Sprintf("workspaces[%d]", i)
+		if strings.TrimSpace(ws.Dir) == "" {
+			report(path+".dir", "workspace directory is required")
+		}
+		if names[ws.Account] == 0 {
+			report(path+".account", "no account named %q", ws.Account)
+		}
+	}
+
+	if cfg.Menu != nil {
+		for i, entry := range cfg.Menu.Custom {
+			path := fmt.Sprintf("menu.custom[%d]", i)
+			if strings.TrimSpace(entry.Key) == "" {
+				report(path+".key", "menu entry key is required")
+			}
+			if strings.TrimSpace(entry.Command) == "" {
+				report(path+".command", "menu entry command is required")
+			}
+		}
+	}
+
+	for name := range cfg.DownloadHeaders {
+		if name == "" || strings.ContainsAny(name, " \t:\r\n") {
+			report("downloadHeaders", "invalid header name %q", name)
+		}
+	}
+
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Line < errs[j].Line })
+	return errs
+}
+
+// decodeError turns a decoding error into a ValidationError at the line
+// where decoding stopped
+func decodeError(data []byte, dec *json.Decoder, err error) *ValidationError {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		return &ValidationError{Line: lineAt(data, syntaxErr.Offset), Message: "invalid JSON: " + syntaxErr.Error()}
+	case errors.As(err, &typeErr):
+		return &ValidationError{Line: lineAt(data, typeErr.Offset), Path: typeErr.Field, Message: fmt.Sprintf("expected %s, got a JSON %s", typeErr.Type, typeErr.Value)}
+	case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
+		return &ValidationError{Line: lineAt(data, int64(len(data))), Message: "unexpected end of file"}
+	}
+	// Unknown fields carry no offset, so look for the key
+	msg := strings.TrimPrefix(err.Error(), "json: ")
+	if name, ok := strings.CutPrefix(msg, "unknown field "); ok {
+		if name, err := strconv.Unquote(name); err == nil {
+			return &ValidationError{Line: valueLines(data).firstKey(name), Message: msg}
+		}
+	}
+	return &ValidationError{Line: lineAt(data, dec.InputOffset()), Message: msg}
+}
+
+// lineAt returns the 1-based line of a byte offset
+func lineAt(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+// lineIndex maps value paths like accounts[1].name to their line
+type lineIndex map[string]int
+
+// of returns the line of path, or of its closest parent that has one
+func (l lineIndex) of(path string) int {
+	for path != "" {
+		if line, ok := l[path]; ok {
+			return line
+		}
+		cut := strings.LastIndexAny(path, ".[")
+		if cut < 0 {
+			break
+		}
+		path = path[:cut]
+	}
+	return 0
+}
+
+// firstKey returns the first line with an object key called name, 0 when
+// there is none
+func (l lineIndex) firstKey(name string) int {
+	first := 0
+	for path, line := range l {
+		if (path == name || strings.HasSuffix(path, "."+name)) && (first == 0 || line < first) {
+			first = line
+		}
+	}
+	return first
+}
+
+// valueLines indexes the line of every value in a valid JSON document
+func valueLines(data []byte) lineIndex {
+	lines := lineIndex{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	var walk func(path string) error
+	walk = func(path string) error {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if path != "" {
+			lines[path] = lineAt(data, dec.InputOffset())
+		}
+		switch tok {
+		case json.Delim('{'):
+			for dec.More() {
+				key, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				name, _ := key.(string)
+				child := name
+				if path != "" {
+					child = path + "." + name
+				}
+				if err := walk(child); err != nil {
+					return err
+				}
+			}
+			_, err = dec.Token()
+		case json.Delim('['):
+			for i := 0; dec.More(); i++ {
+				if err := walk(path + "[" + strconv.Itoa(i) + "]"); err != nil {
+					return err
+				}
+			}
+			_, err = dec.Token()
+		}
+		return err
+	}
+	_ = walk("")
+	return lines
+}
+
+// oneOf reports whether value is in values
+func oneOf(value string, values []string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestValidate tests that config problems are reported at their line
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []string // "line: substring" of each error
+	}{
+		{
+			name: "valid",
+			data: `{"accounts": [{"name": "work", "ssh": {"keyPath": "~/.ssh/id_work"}}], "workspaces": [{"dir": "/src", "account": "work"}]}`,
+		},
+		{
+			name: "syntax",
+			data: "{\n  \"accounts\": [\n    {\"name\": \"work\",}\n  ]\n}",
+			want: []string{"3: invalid JSON"},
+		},
+		{
+			name: "wrong type",
+			data: "{\n  \"accounts\": [\n    {\"name\": \"work\", \"ssh\": {\"keyPath\": \"k\", \"port\": \"22\"}}\n  ]\n}",
+			want: []string{"3: expected int"},
+		},
+		{
+			name: "unknown field",
+			data: "{\n  \"accounts\": [],\n  \"langauge\": \"id\"\n}",
+			want: []string{`3: unknown field "langauge"`},
+		},
+		{
+			name: "values",
+			data: `{
+  "accounts": [
+    {"name": "work", "platform": {"type": "githib"}},
+    {"name": "work", "token": {"username": "me", "token": ""}}
+  ],
+  "accountSort": "newest",
+  "workspaces": [{"dir": "/src", "account": "home"}]
+}`,
+			want: []string{
+				`3: accounts[0].platform.type: unknown platform "githib"`,
+				`4: accounts[1].name: duplicate account name "work"`,
+				`4: accounts[1].token.token: token is required`,
+				`6: accountSort: unknown sort order "newest"`,
+				`7: workspaces[0].account: no account named "home"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := Validate([]byte(tt.data))
+			if len(errs) != len(tt.want) {
+				t.Fatalf("Validate() = %v, want %d errors", errs, len(tt.want))
+			}
+			for i, want := range tt.want {
+				line, msg, _ := strings.Cut(want, ": ")
+				got := errs[i].Error()
+				if !strings.HasPrefix(got, "line "+line+": ") || !strings.Contains(got, msg) {
+					t.Errorf("error %d = %q, want line %s with %q", i, got, line, msg)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+// Package dotfiles renders the configured accounts as static git and SSH
+// configuration, for users who manage their dotfiles declaratively instead
+// of switching accounts per repository at runtime.
+package dotfiles
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/dwirx/ghex/internal/config"
+	"github.com/dwirx/ghex/internal/git"
+	"github.com/dwirx/ghex/internal/platform"
+	"github.com/dwirx/ghex/internal/ssh"
+)
+
+// MainFile is the generated file holding the includeIf sections
+const MainFile = "gitconfig"
+
+// DefaultDir is where the generated gitconfig files are expected to live
+const DefaultDir = "~/.config/git/ghex"
+
+// header starts every generated file
+const header = "# Generated by ghex (ghex generate). Edit the accounts in ghex and regenerate.\n"
+
+// File is a generated file
+type File struct {
+	Name    string
+	Content string
+}
+
+// unsafeName matches characters left out of generated file names and aliases
+var unsafeName = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// AccountFile returns the name of an account's generated gitconfig file
+func AccountFile(acc *config.Account) string {
+	return "gitconfig-" + safeName(acc.Name)
+}
+
+// SSHAlias returns the SSH host alias the generated configuration uses for
+// an account: its configured host alias, or <host>-<name>
+func SSHAlias(acc *config.Account) string {
+	if acc.SSH != nil && acc.SSH.HostAlias != "" {
+		return acc.SSH.HostAlias
+	}
+	return sshHost(acc) + "-" + safeName(acc.Name)
+}
+
+// Gitconfig returns the main gitconfig file, with an includeIf section per
+// directory or organization an account is used for, followed by one file
+// per account. The includes point at files in dir.
+//
+// An account applies inside its workspaces and clone directory, and to
+// repositories whose remote belongs to one of its workspace organizations
+// (hasconfig:remote.*.url, git 2.36 or later).
+func Gitconfig(cfg *config.AppConfig, dir string) []File {
+	dir = strings.TrimSuffix(filepath.ToSlash(platform.HomeRelative(dir)), "/")
+
+	var main strings.Builder
+	main.WriteString(header)
+	main.WriteString("# Include it from ~/.gitconfig:\n")
+	main.WriteString("#   [include]\n")
+	fmt.Fprintf(&main, "#   \tpath = %s/%s\n", dir, MainFile)
+
+	files := []File{{Name: MainFile}}
+	for i := range cfg.Accounts {
+		acc := &cfg.Accounts[i]
+		include := dir + "/" + AccountFile(acc)
+
+		main.WriteString("\n")
+		conditions := Conditions(cfg, acc)
+		if len(conditions) == 0 {
+			fmt.Fprintf(&main, "# %s: no workspace, clone directory or organization; include %s where it applies\n", acc.Name, include)
+		}
+		for _, cond := range conditions {
+			fmt.Fprintf(&main, "[includeIf %s]\n\tpath = %s\n", strconv.Quote(cond), include)
+		}
+
+		files = append(files, File{Name: AccountFile(acc), Content: accountGitconfig(acc)})
+	}
+	files[0].Content = main.String()
+	return files
+}
+
+// Conditions returns the includeIf conditions under which an account's
+// gitconfig applies
+func Conditions(cfg *config.AppConfig, acc *config.Account) []string {
+	var conditions []string
+	seen := map[string]bool{}
+	add := func(cond string) {
+		if !seen[cond] {
+			seen[cond] = true
+			conditions = append(conditions, cond)
+		}
+	}
+
+	host := sshHost(acc)
+	for _, ws := range cfg.Workspaces {
+		if !strings.EqualFold(ws.Account, acc.Name) {
+			continue
+		}
+		add("gitdir:" + gitdirPattern(ws.Dir))
+		for _, org := range ws.Orgs {
+			add(fmt.Sprintf("hasconfig:remote.*.url:git@%s:%s/**", host, org))
+			add(fmt.Sprintf("hasconfig:remote.*.url:https://%s/%s/**", host, org))
+		}
+	}
+	if acc.CloneDir != "" {
+		add("gitdir:" + gitdirPattern(acc.CloneDir))
+	}
+	return conditions
+}
+
+// accountGitconfig returns the gitconfig applied to an account's
+// repositories: the identity, SSH remotes rewritten to the account's host
+// alias, and the token username for HTTPS remotes. Token secrets are never
+// written.
+func accountGitconfig(acc *config.Account) string {
+	var b strings.Builder
+	b.WriteString(header)
+	fmt.Fprintf(&b, "# Account: %s\n", acc.Name)
+
+	if acc.GitUserName != "" || acc.GitEmail != "" {
+		b.WriteString("[user]\n")
+		if acc.GitUserName != "" {
+			fmt.Fprintf(&b, "\tname = %s\n", acc.GitUserName)
+		}
+		if acc.GitEmail != "" {
+			fmt.Fprintf(&b, "\temail = %s\n", acc.GitEmail)
+		}
+	}
+
+	host := sshHost(acc)
+	if acc.SSH != nil {
+		fmt.Fprintf(&b, "[url %s]\n", strconv.Quote("git@"+SSHAlias(acc)+":"))
+		fmt.Fprintf(&b, "\tinsteadOf = git@%s:\n", host)
+		if port := ssh.OptionsForAccount(acc).Port; port > 0 && port != 22 {
+			fmt.Fprintf(&b, "\tinsteadOf = ssh://git@%s:%d/\n", host, port)
+		} else {
+			fmt.Fprintf(&b, "\tinsteadOf = ssh://git@%s/\n", host)
+		}
+	}
+	if acc.Token != nil && acc.Token.Username != "" {
+		fmt.Fprintf(&b, "[credential %s]\n", strconv.Quote("https://"+host))
+		fmt.Fprintf(&b, "\tusername = %s\n", acc.Token.Username)
+	}
+	return b.String()
+}
+
+// SSHConfig returns a Host block per account with an SSH key, under the
+// alias the generated gitconfig rewrites its remotes to
+func SSHConfig(cfg *config.AppConfig) string {
+	var b strings.Builder
+	b.WriteString(header)
+	for i := range cfg.Accounts {
+		acc := &cfg.Accounts[i]
+		if acc.SSH == nil || acc.SSH.KeyPath == "" {
+			continue
+		}
+		b.WriteString("\n")
+		b.WriteString(ssh.BuildHostBlock(SSHAlias(acc), acc.SSH.KeyPath, sshHost(acc), ssh.OptionsForAccount(acc)))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// sshHost returns the git host of an account
+func sshHost(acc *config.Account) string {
+	platformType, domain := "github", ""
+	if acc.Platform != nil {
+		platformType, domain = acc.Platform.Type, acc.Platform.Domain
+	}
+	return git.GetPlatformSSHHost(platformType, domain)
+}
+
+// gitdirPattern returns a gitdir: pattern matching every repository below
+// dir, relative to the home directory when inside it so the file works on
+// other machines too
+func gitdirPattern(dir string) string {
+	dir = filepath.ToSlash(platform.HomeRelative(platform.ExpandPath(dir)))
+	return strings.TrimSuffix(dir, "/") + "/"
+}
+
+// safeName returns name usable in file names and SSH aliases
+func safeName(name string) string {
+	name = strings.Trim(unsafeName.ReplaceAllString(name, "-"), "-")
+	if name == "" {
+		return "account"
+	}
+	return strings.ToLower(name)
+}
@@ -0,0 +1,100 @@
+package dotfiles
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dwirx/ghex/internal/config"
+)
+
+// testConfig returns a work account with a workspace, organization and SSH
+// key on a custom port, and a personal token account with a clone directory
+func testConfig(home string) *config.AppConfig {
+	return &config.AppConfig{
+		Accounts: []config.Account{
+			{
+				Name:        "Work",
+				GitUserName: "Work Me",
+				GitEmail:    "me@work.com",
+				SSH:         &config.SshConfig{KeyPath: "~/.ssh/id_work", Port: 2222},
+				Platform:    &config.PlatformConfig{Type: "gitlab", Domain: "git.work.com"},
+			},
+			{
+				Name:     "personal",
+				GitEmail: "me@home.org",
+				Token:    &config.TokenConfig{Username: "me", Token: "secret-token"},
+				CloneDir: filepath.Join(home, "src", "personal"),
+			},
+			{Name: "spare", SSH: &config.SshConfig{KeyPath: "~/.ssh/id_spare", HostAlias: "gh-spare"}},
+		},
+		Workspaces: []config.Workspace{
+			{Dir: filepath.Join(home, "work"), Account: "work", Orgs: []string{"platform"}},
+		},
+	}
+}
+
+// TestGitconfig tests the includeIf sections and per-account files
+func TestGitconfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	files := Gitconfig(testConfig(home), filepath.Join(home, "dotfiles", "git"))
+	if len(files) != 4 || files[0].Name != MainFile {
+		t.Fatalf("files = %+v", files)
+	}
+
+	main := files[0].Content
+	for _, want := range []string{
+		"[includeIf \"gitdir:~/work/\"]\n\tpath = ~/dotfiles/git/gitconfig-work\n",
+		"[includeIf \"hasconfig:remote.*.url:git@git.work.com:platform/**\"]",
+		"[includeIf \"hasconfig:remote.*.url:https://git.work.com/platform/**\"]",
+		"[includeIf \"gitdir:~/src/personal/\"]\n\tpath = ~/dotfiles/git/gitconfig-personal\n",
+		"# spare: no workspace, clone directory or organization",
+	} {
+		if !strings.Contains(main, want) {
+			t.Errorf("main file missing %q:\n%s", want, main)
+		}
+	}
+
+	work := files[1].Content
+	for _, want := range []string{
+		"[user]\n\tname = Work Me\n\temail = me@work.com\n",
+		"[url \"git@git.work.com-work:\"]\n\tinsteadOf = git@git.work.com:\n\tinsteadOf = ssh://git@git.work.com:2222/\n",
+	} {
+		if !strings.Contains(work, want) {
+			t.Errorf("work file missing %q:\n%s", want, work)
+		}
+	}
+
+	personal := files[2].Content
+	if !strings.Contains(personal, "[credential \"https://github.com\"]\n\tusername = me\n") {
+		t.Errorf("personal file missing credential username:\n%s", personal)
+	}
+	for _, f := range files {
+		if strings.Contains(f.Content, "secret-token") {
+			t.Errorf("%s contains the token secret", f.Name)
+		}
+	}
+}
+
+// TestSSHConfig tests that Host blocks use the aliases the gitconfig
+// rewrites remotes to
+func TestSSHConfig(t *testing.T) {
+	home := t.TempDir()
+	out := SSHConfig(testConfig(home))
+
+	for _, want := range []string{
+		"Host git.work.com-work\n  HostName git.work.com\n",
+		"  Port 2222",
+		"Host gh-spare\n  HostName github.com\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("SSH config missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "personal") {
+		t.Errorf("account without SSH key got a Host block:\n%s", out)
+	}
+}
@@ -0,0 +1,100 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/dwirx/ghex/internal/shell"
+)
+
+// BranchInfo describes a local branch and its upstream
+type BranchInfo struct {
+	Name     string // Local branch name
+	Remote   string // branch.<name>.remote (a remote name or a URL)
+	Upstream string // Upstream ref, e.g. origin/main
+	Ahead    int    // Commits not yet on the upstream
+}
+
+// InProgressOperation returns the unfinished rebase, merge, cherry-pick or
+// revert in a repository, or "" if none is in progress
+func InProgressOperation(path string) string {
+	if path == "" {
+		path = "."
+	}
+
+	markers := []struct {
+		file string
+		name string
+	}{
+		{"rebase-merge", "rebase"},
+		{"rebase-apply", "rebase"},
+		{"MERGE_HEAD", "merge"},
+		{"CHERRY_PICK_HEAD", "cherry-pick"},
+		{"REVERT_HEAD", "revert"},
+	}
+
+	for _, m := range markers {
+		gitPath, err := shell.RunInDir(path, "git", "rev-parse", "--git-path", m.file)
+		if err != nil {
+			return ""
+		}
+		if !filepath.IsAbs(gitPath) {
+			gitPath = filepath.Join(path, gitPath)
+		}
+		if _, err := os.Stat(gitPath); err == nil {
+			return m.name
+		}
+	}
+	return ""
+}
+
+// ListBranches returns local branches with their upstream tracking info
+func ListBranches(path string) ([]BranchInfo, error) {
+	if path == "" {
+		path = "."
+	}
+
+	output, err := shell.RunInDir(path, "git", "for-each-ref",
+		"--format=%(refname:short)%09%(upstream:short)%09%(upstream:track,nobracket)", "refs/heads")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	var branches []BranchInfo
+	for _, line := range strings.Split(output, "\n") {
+		if line == "" {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		b := BranchInfo{Name: fields[0]}
+		if len(fields) > 1 {
+			b.Upstream = fields[1]
+		}
+		if len(fields) > 2 {
+			b.Ahead = parseAhead(fields[2])
+		}
+		b.Remote = GetLocalConfig("branch."+b.Name+".remote", path)
+		branches = append(branches, b)
+	}
+	return branches, nil
+}
+
+// SetBranchRemote points a branch's upstream at another remote
+func SetBranchRemote(branch, remote, path string) error {
+	return SetLocalConfig("branch."+branch+".remote", remote, path)
+}
+
+// parseAhead extracts N from "ahead N" or "ahead N, behind M"
+func parseAhead(track string) int {
+	for _, part := range strings.Split(track, ",") {
+		part = strings.TrimSpace(part)
+		if n, ok := strings.CutPrefix(part, "ahead "); ok {
+			ahead, _ := strconv.Atoi(n)
+			return ahead
+		}
+	}
+	return 0
+}
@@ -72,3 +72,43 @@ func CloneToPath(repoURL, basePath, targetName string) (string, error) {
 
 	return Clone(repoURL, targetDir)
 }
+
+// CloneNonInteractive clones repoURL into targetDir without prompting for
+// credentials, so several clones can run at once. configs are key=value
+// settings applied to the new repository before fetching.
+func CloneNonInteractive(repoURL, targetDir string, configs ...string) error {
+	args := []string{"clone"}
+	for _, c := range configs {
+		args = append(args, "-c", c)
+	}
+	args = append(args, repoURL, targetDir)
+
+	if _, err := shell.RunInDirWithEnv("", []string{"GIT_TERMINAL_PROMPT=0"}, "git", args...); err != nil {
+		return fmt.Errorf("failed to clone repository: %w", err)
+	}
+	return nil
+}
+
+// CloneMirror creates a bare mirror of repoURL at targetDir without
+// prompting for credentials. env is added to git's environment, e.g. to
+// pass credentials for this command only.
+func CloneMirror(repoURL, targetDir string, env []string) error {
+	env = append([]string{"GIT_TERMINAL_PROMPT=0"}, env...)
+	if _, err := shell.RunInDirWithEnv("", env, "git", "clone", "--mirror", "--quiet", repoURL, targetDir); err != nil {
+		return fmt.Errorf("failed to mirror repository: %w", err)
+	}
+	return nil
+}
+
+// FetchMirror updates the bare mirror at dir from repoURL, removing refs
+// deleted upstream
+func FetchMirror(dir, repoURL string, env []string) error {
+	if _, err := shell.RunInDir(dir, "git", "remote", "set-url", "origin", repoURL); err != nil {
+		return fmt.Errorf("failed to set mirror URL: %w", err)
+	}
+	env = append([]string{"GIT_TERMINAL_PROMPT=0"}, env...)
+	if _, err := shell.RunInDirWithEnv(dir, env, "git", "fetch", "--prune", "--quiet", "origin"); err != nil {
+		return fmt.Errorf("failed to update mirror: %w", err)
+	}
+	return nil
+}
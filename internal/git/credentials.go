@@ -0,0 +1,365 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dwirx/ghex/internal/platform"
+	"github.com/dwirx/ghex/internal/shell"
+)
+
+// lockTimeout is how long to wait for another process to release a lock
+const lockTimeout = 5 * time.Second
+
+// CredentialsPath returns the credential store file, honoring
+// "credential.helper = store --file <path>"
+func CredentialsPath() string {
+	if path, ok := storeHelperFile(""); ok && path != "" {
+		return path
+	}
+	return platform.GetGitCredentialsPath()
+}
+
+// storeHelperFile looks for a store credential helper configured for the
+// repository at dir ("" = current directory) and returns its --file
+// argument ("" when it uses the default file)
+func storeHelperFile(dir string) (string, bool) {
+	output, err := shell.RunInDir(dir, "git", "config", "--get-all", "credential.helper")
+	if err != nil {
+		return "", false
+	}
+
+	found, file := false, ""
+	for _, helper := range strings.Split(output, "\n") {
+		fields := strings.Fields(helper)
+		if len(fields) == 0 {
+			// An empty value resets the helper list
+			found, file = false, ""
+			continue
+		}
+		if fields[0] != "store" && fields[0] != "git-credential-store" {
+			continue
+		}
+		found, file = true, ""
+		for i, f := range fields[1:] {
+			if f == "--file" && i+2 < len(fields) {
+				file = fields[i+2]
+			} else if strings.HasPrefix(f, "--file=") {
+				file = strings.TrimPrefix(f, "--file=")
+			}
+		}
+	}
+	if file != "" {
+		file = platform.ExpandPath(strings.Trim(file, `"'`))
+	}
+	return file, found
+}
+
+// replaceCredential replaces the credential line for host with newCred,
+// keeping every other line (and its line ending) untouched. The new line
+// goes where the old one was, or at the end of the file.
+func replaceCredential(data []byte, host, newCred string) []byte {
+	eol := "\n"
+	if bytes.Contains(data, []byte("\r\n")) {
+		eol = "\r\n"
+	}
+
+	var out bytes.Buffer
+	replaced := false
+	for len(data) > 0 {
+		line := data
+		if idx := bytes.IndexByte(data, '\n'); idx >= 0 {
+			line = data[:idx+1]
+		}
+		data = data[len(line):]
+
+		if credentialHost(string(line)) == strings.ToLower(host) {
+			if !replaced {
+				out.WriteString(newCred + eol)
+				replaced = true
+			}
+			continue
+		}
+		out.Write(line)
+	}
+
+	if !replaced {
+		if out.Len() > 0 && !bytes.HasSuffix(out.Bytes(), []byte("\n")) {
+			out.WriteString(eol)
+		}
+		out.WriteString(newCred + eol)
+	}
+	return out.Bytes()
+}
+
+// StoredCredentialUser returns the username stored for host in the
+// credential store file, or "" if there is none
+func StoredCredentialUser(host string) string {
+	data, err := os.ReadFile(CredentialsPath())
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if credentialHost(line) != strings.ToLower(host) {
+			continue
+		}
+		if u, err := url.Parse(strings.TrimSpace(line)); err == nil {
+			return u.User.Username()
+		}
+	}
+	return ""
+}
+
+// StoredCredential is a host-wide credential in the credential store file
+type StoredCredential struct {
+	Host     string // Lowercase, with the port if any
+	Username string
+}
+
+// StoredCredentials lists the host-wide credentials in the credential store
+// file, without their secrets
+func StoredCredentials() ([]StoredCredential, error) {
+	data, err := os.ReadFile(CredentialsPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read credentials: %w", err)
+	}
+
+	var creds []StoredCredential
+	for _, line := range strings.Split(string(data), "\n") {
+		host := credentialHost(line)
+		if host == "" {
+			continue
+		}
+		cred := StoredCredential{Host: host}
+		if u, err := url.Parse(strings.TrimSpace(line)); err == nil {
+			cred.Username = u.User.Username()
+		}
+		creds = append(creds, cred)
+	}
+	return creds, nil
+}
+
+// RemoveStoredCredentials removes the credentials for hosts from the
+// credential store file, keeping every other line untouched
+func RemoveStoredCredentials(hosts []string) error {
+	remove := map[string]bool{}
+	for _, h := range hosts {
+		remove[strings.ToLower(h)] = true
+	}
+
+	credPath := CredentialsPath()
+	return withFileLock(credPath, func() error {
+		data, err := os.ReadFile(credPath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return fmt.Errorf("failed to read credentials: %w", err)
+		}
+
+		var out bytes.Buffer
+		for len(data) > 0 {
+			line := data
+			if idx := bytes.IndexByte(data, '\n'); idx >= 0 {
+				line = data[:idx+1]
+			}
+			data = data[len(line):]
+			if !remove[credentialHost(string(line))] {
+				out.Write(line)
+			}
+		}
+		return writeFileAtomic(credPath, out.Bytes(), 0600)
+	})
+}
+
+// credentialHost returns the host of a credential store line, or "" if
+// the line is not a host-wide credential
+func credentialHost(line string) string {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ""
+	}
+	u, err := url.Parse(line)
+	if err != nil || u.User == nil || strings.Trim(u.Path, "/") != "" {
+		return ""
+	}
+	return strings.ToLower(u.Host)
+}
+
+// withFileLock runs fn while holding "<path>.lock", the same lock file git
+// uses, so concurrent ghex and git processes do not clobber each other
+func withFileLock(path string, fn func() error) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	lockPath := path + ".lock"
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			f.Close()
+			break
+		}
+		if !os.IsExist(err) {
+			return fmt.Errorf("failed to lock %s: %w", path, err)
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for lock %s (remove it if no git process is running)", lockPath)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	defer os.Remove(lockPath)
+
+	return fn()
+}
+
+// writeFileAtomic writes data to a temp file and renames it over path,
+// keeping the existing file's permissions
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// CredentialHelper is a configured credential helper
+type CredentialHelper struct {
+	Value string // Helper command, e.g. "store --file ~/.creds" or "osxkeychain"
+	Scope string // system, global, local, worktree or command
+	Key   string // credential.helper or credential.<url>.helper
+}
+
+// Name returns the helper program without arguments or the
+// "git-credential-" prefix
+func (h CredentialHelper) Name() string {
+	fields := strings.Fields(h.Value)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.TrimPrefix(filepath.Base(fields[0]), "git-credential-")
+}
+
+// IsStore reports whether the helper is git's plain-file store, which ghex
+// writes tokens to
+func (h CredentialHelper) IsStore() bool {
+	return h.Name() == "store"
+}
+
+// CredentialHelpers returns the effective credential helpers for a
+// repository, grouped by config key, in the order git queries them
+func CredentialHelpers(path string) map[string][]CredentialHelper {
+	if path == "" {
+		path = "."
+	}
+
+	output, err := shell.RunInDir(path, "git", "config", "--show-scope", "--get-regexp", `^credential\..*helper$`)
+	if err != nil {
+		return nil
+	}
+
+	helpers := map[string][]CredentialHelper{}
+	for _, line := range strings.Split(output, "\n") {
+		scope, entry, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		key, value, _ := strings.Cut(entry, " ")
+		value = strings.TrimSpace(value)
+		if value == "" {
+			// An empty helper clears the ones configured before it
+			helpers[key] = nil
+			continue
+		}
+		helpers[key] = append(helpers[key], CredentialHelper{Value: value, Scope: scope, Key: key})
+	}
+	return helpers
+}
+
+// CredentialHelperConflicts returns helpers that git asks before the store
+// helper ghex writes tokens to. They can answer with another account's
+// credentials, so the token ghex configured is never used.
+func CredentialHelperConflicts(helpers []CredentialHelper) []CredentialHelper {
+	lastStore := -1
+	for i, h := range helpers {
+		if h.IsStore() {
+			lastStore = i
+		}
+	}
+
+	var conflicts []CredentialHelper
+	for i := 0; i < lastStore; i++ {
+		if !helpers[i].IsStore() {
+			conflicts = append(conflicts, helpers[i])
+		}
+	}
+	return conflicts
+}
+
+// PrioritizeStoreHelper redeclares the credential.helper list so store
+// helpers are asked first and the others remain as fallbacks. The list is
+// written to the repository's local config, or the global config when
+// global is set.
+func PrioritizeStoreHelper(path string, global bool) error {
+	if path == "" {
+		path = "."
+	}
+
+	helpers := CredentialHelpers(path)["credential.helper"]
+	ordered := make([]CredentialHelper, 0, len(helpers))
+	for _, h := range helpers {
+		if h.IsStore() {
+			ordered = append(ordered, h)
+		}
+	}
+	for _, h := range helpers {
+		if !h.IsStore() {
+			ordered = append(ordered, h)
+		}
+	}
+
+	scope := "--local"
+	if global {
+		scope = "--global"
+	}
+
+	// Ignore the error: the key may simply not be set in this scope
+	_, _ = shell.RunInDir(path, "git", "config", scope, "--unset-all", "credential.helper")
+
+	// Start with an empty helper to reset lists inherited from other scopes
+	values := []string{""}
+	for _, h := range ordered {
+		values = append(values, h.Value)
+	}
+	for _, v := range values {
+		if _, err := shell.RunInDir(path, "git", "config", scope, "--add", "credential.helper", v); err != nil {
+			return fmt.Errorf("failed to set credential.helper: %w", err)
+		}
+	}
+	return nil
+}
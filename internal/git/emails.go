@@ -0,0 +1,132 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AccountEmail is an email address registered with a platform account
+type AccountEmail struct {
+	Email      string
+	Verified   bool
+	Primary    bool
+	Visibility string // GitHub, primary email only: "public" or "private"
+}
+
+// ListEmails lists the email addresses of the token's user. GitHub needs a
+// token with the user:email (or user) scope.
+func ListEmails(opts RepoListOptions) ([]AccountEmail, error) {
+	client := opts.client()
+	var emails []AccountEmail
+
+	switch opts.Platform {
+	case "github", "gitea", "codeberg":
+		var items []struct {
+			Email      string `json:"email"`
+			Verified   bool   `json:"verified"`
+			Primary    bool   `json:"primary"`
+			Visibility string `json:"visibility"`
+		}
+		if err := client.Call("GET", "/user/emails", nil, &items); err != nil {
+			return nil, fmt.Errorf("failed to list emails: %w", err)
+		}
+		for _, item := range items {
+			emails = append(emails, AccountEmail{Email: item.Email, Verified: item.Verified, Primary: item.Primary, Visibility: item.Visibility})
+		}
+
+	case "gitlab":
+		// The primary email is confirmed before it can be primary; the
+		// others are listed separately
+		var user struct {
+			Email string `json:"email"`
+		}
+		if err := client.Call("GET", "/user", nil, &user); err != nil {
+			return nil, fmt.Errorf("failed to list emails: %w", err)
+		}
+		if user.Email != "" {
+			emails = append(emails, AccountEmail{Email: user.Email, Verified: true, Primary: true})
+		}
+		var items []struct {
+			Email       string  `json:"email"`
+			ConfirmedAt *string `json:"confirmed_at"`
+		}
+		if err := client.Call("GET", "/user/emails", nil, &items); err != nil {
+			return nil, fmt.Errorf("failed to list emails: %w", err)
+		}
+		for _, item := range items {
+			if !strings.EqualFold(item.Email, user.Email) {
+				emails = append(emails, AccountEmail{Email: item.Email, Verified: item.ConfirmedAt != nil})
+			}
+		}
+
+	default:
+		return nil, fmt.Errorf("listing emails is not supported on %s", opts.Platform)
+	}
+	return emails, nil
+}
+
+// IsNoreplyEmail reports whether email is a platform's private commit
+// address, such as 123+user@users.noreply.github.com. These are not listed
+// with the account's emails but always count as verified.
+func IsNoreplyEmail(email string) bool {
+	_, domain, ok := strings.Cut(strings.ToLower(email), "@")
+	return ok && strings.HasPrefix(domain, "users.noreply.")
+}
+
+// EmailVerified looks email up in emails. It reports whether the address
+// is registered and whether it is verified.
+func EmailVerified(email string, emails []AccountEmail) (registered, verified bool) {
+	if IsNoreplyEmail(email) {
+		return true, true
+	}
+	for _, e := range emails {
+		if strings.EqualFold(e.Email, email) {
+			return true, e.Verified
+		}
+	}
+	return false, false
+}
+
+// EmailsPrivate reports whether a GitHub account keeps its email addresses
+// private, which shows as a private primary email. Only then can GitHub
+// block pushes of commits that expose a personal address.
+func EmailsPrivate(emails []AccountEmail) bool {
+	for _, e := range emails {
+		if e.Primary {
+			return e.Visibility == "private"
+		}
+	}
+	return false
+}
+
+// ExposesEmail reports whether committing with email would publish an
+// address a GitHub account keeps private
+func ExposesEmail(email string, emails []AccountEmail) bool {
+	return email != "" && !IsNoreplyEmail(email) && EmailsPrivate(emails)
+}
+
+// NoreplyEmail returns the GitHub user's private commit address,
+// <id>+<login>@users.noreply.<host>
+func NoreplyEmail(opts RepoListOptions) (string, error) {
+	if opts.Platform != "github" {
+		return "", fmt.Errorf("noreply addresses are only known for GitHub")
+	}
+	var user struct {
+		ID    int64  `json:"id"`
+		Login string `json:"login"`
+	}
+	if err := opts.client().Call("GET", "/user", nil, &user); err != nil {
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+	host := opts.Host
+	if host == "" {
+		host = "github.com"
+	}
+	return fmt.Sprintf("%d+%s@users.noreply.%s", user.ID, user.Login, host), nil
+}
+
+// IsEmailPrivacyRejection reports whether push output is GitHub refusing
+// commits that would publish a private email (error GH007)
+func IsEmailPrivacyRejection(output string) bool {
+	return strings.Contains(output, "GH007")
+}
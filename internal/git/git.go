@@ -2,23 +2,28 @@ package git
 
 import (
 	"fmt"
-	"net/http"
 	"net/url"
 	"os"
 	"runtime"
 	"strings"
-	"time"
 
-	"github.com/dwirx/ghex/internal/platform"
+	"github.com/dwirx/ghex/internal/api"
 	"github.com/dwirx/ghex/internal/shell"
 )
 
 // IsGitRepo checks if the given path is inside a git repository
+// Bare repositories and GIT_DIR/GIT_WORK_TREE setups count as repositories
 func IsGitRepo(path string) bool {
-	_, err := shell.RunInDir(path, "git", "rev-parse", "--is-inside-work-tree")
+	_, err := shell.RunInDir(path, "git", "rev-parse", "--git-dir")
 	return err == nil
 }
 
+// IsBareRepo checks if the given path is a bare repository
+func IsBareRepo(path string) bool {
+	result, err := shell.RunInDir(path, "git", "rev-parse", "--is-bare-repository")
+	return err == nil && result == "true"
+}
+
 // convertMSYSPath converts MSYS/Git Bash paths like /c/Users/... to C:/Users/...
 func convertMSYSPath(path string) string {
 	if len(path) >= 2 && path[0] == '/' && path[1] != '/' {
@@ -36,8 +41,13 @@ func convertMSYSPath(path string) string {
 }
 
 // GetGitRoot returns the root directory of the git repository
+// For bare repositories this is the git directory itself
 func GetGitRoot(path string) (string, error) {
-	result, err := shell.RunInDir(path, "git", "rev-parse", "--show-toplevel")
+	args := []string{"rev-parse", "--show-toplevel"}
+	if IsBareRepo(path) {
+		args = []string{"rev-parse", "--absolute-git-dir"}
+	}
+	result, err := shell.RunInDir(path, "git", args...)
 	if err != nil {
 		return "", err
 	}
@@ -94,6 +104,29 @@ func SetLocalIdentity(name, email, path string) error {
 	return nil
 }
 
+// GetLocalConfig returns a value from the repository's local git config
+// Returns an empty string if the key is not set
+func GetLocalConfig(key, path string) string {
+	if path == "" {
+		path = "."
+	}
+
+	value, _ := shell.RunInDir(path, "git", "config", "--local", "--get", key)
+	return value
+}
+
+// SetLocalConfig sets a value in the repository's local git config
+func SetLocalConfig(key, value, path string) error {
+	if path == "" {
+		path = "."
+	}
+
+	if _, err := shell.RunInDir(path, "git", "config", "--local", key, value); err != nil {
+		return fmt.Errorf("failed to set %s: %w", key, err)
+	}
+	return nil
+}
+
 // SetGlobalIdentity sets the global git user.name and user.email
 func SetGlobalIdentity(name, email string) error {
 	if name != "" {
@@ -132,52 +165,34 @@ func GetCurrentBranch(path string) (string, error) {
 	return shell.RunInDir(path, "git", "branch", "--show-current")
 }
 
-// EnsureCredentialStore sets up git credential store
-func EnsureCredentialStore() error {
-	_, err := shell.Run("git", "config", "credential.helper", "store")
+// EnsureCredentialStore sets up git credential store for the repository at path
+// An existing store helper (including "store --file <path>") is kept as is
+func EnsureCredentialStore(path string) error {
+	if _, ok := storeHelperFile(path); ok {
+		return nil
+	}
+	_, err := shell.RunInDir(path, "git", "config", "credential.helper", "store")
 	return err
 }
 
-// WriteCredentials writes credentials to ~/.git-credentials
+// WriteCredentials writes credentials to the credential store file
+// The file is locked while it is rewritten; lines for other hosts,
+// comments and ordering are preserved byte for byte
 func WriteCredentials(username, token, host string) error {
 	if host == "" {
 		host = "github.com"
 	}
 
-	credPath := platform.GetGitCredentialsPath()
+	credPath := CredentialsPath()
+	newCred := fmt.Sprintf("https://%s:%s@%s", url.QueryEscape(username), url.QueryEscape(token), host)
 
-	// Read existing credentials
-	var existing string
-	data, err := os.ReadFile(credPath)
-	if err == nil {
-		existing = string(data)
-		// Normalize line endings (handle Windows \r\n)
-		existing = strings.ReplaceAll(existing, "\r\n", "\n")
-		existing = strings.ReplaceAll(existing, "\r", "\n")
-	}
-
-	// Filter out existing credentials for this host
-	lines := strings.Split(existing, "\n")
-	var filtered []string
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
-		if !strings.Contains(line, "@"+host) {
-			filtered = append(filtered, line)
+	return withFileLock(credPath, func() error {
+		data, err := os.ReadFile(credPath)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read credentials: %w", err)
 		}
-	}
-
-	// Add new credential
-	encodedUser := url.QueryEscape(username)
-	encodedToken := url.QueryEscape(token)
-	newCred := fmt.Sprintf("https://%s:%s@%s", encodedUser, encodedToken, host)
-	filtered = append(filtered, newCred)
-
-	// Write back
-	content := strings.Join(filtered, "\n") + "\n"
-	return os.WriteFile(credPath, []byte(content), 0600)
+		return writeFileAtomic(credPath, replaceCredential(data, host, newCred), 0600)
+	})
 }
 
 // TestTokenAuth tests token authentication against GitHub API
@@ -185,35 +200,27 @@ func TestTokenAuth(username, token string) (bool, string, error) {
 	return TestTokenAuthForHost(username, token, "github.com")
 }
 
-// TestTokenAuthForHost tests token authentication against a specific host's API
+// TestTokenAuthForHost tests token authentication against a specific host's API.
+// The token is sent as a bearer token, which every supported platform accepts;
+// username is kept for callers that still pass it.
 func TestTokenAuthForHost(username, token, host string) (bool, string, error) {
 	// Build API URL based on host
-	var apiURL string
+	var client *api.Client
 	switch host {
 	case "github.com":
-		apiURL = "https://api.github.com/user"
+		client = api.For("github", "https://api.github.com", token)
 	case "gitlab.com":
-		apiURL = "https://gitlab.com/api/v4/user"
+		client = api.For("gitlab", "https://gitlab.com/api/v4", token)
 	default:
 		// For self-hosted GitLab, Gitea, Codeberg, etc.
 		// Try Gitea/Codeberg style API first (most common for self-hosted)
-		apiURL = fmt.Sprintf("https://%s/api/v1/user", host)
+		client = api.For("gitea", fmt.Sprintf("https://%s/api/v1", host), token)
 	}
 
-	req, err := http.NewRequest("GET", apiURL, nil)
-	if err != nil {
-		return false, "", fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.SetBasicAuth(username, token)
-	req.Header.Set("User-Agent", "ghex-cli")
-
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Do(req)
+	resp, err := client.Get("/user")
 	if err != nil {
 		return false, "", fmt.Errorf("request failed: %w", err)
 	}
-	defer resp.Body.Close()
 
 	if resp.StatusCode == 200 {
 		return true, fmt.Sprintf("HTTP %d OK", resp.StatusCode), nil
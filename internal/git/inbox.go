@@ -0,0 +1,142 @@
+package git
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Notification is an unread GitHub notification thread
+type Notification struct {
+	ID        string
+	Title     string
+	Type      string // PullRequest, Issue, Release, Discussion, ...
+	Reason    string // review_requested, mention, assign, ...
+	Repo      string // owner/repo
+	URL       string // Web page of the subject
+	UpdatedAt time.Time
+}
+
+// PullRequest is an open pull request found by a search
+type PullRequest struct {
+	Number    int
+	Title     string
+	Repo      string // owner/repo
+	URL       string
+	UpdatedAt time.Time
+}
+
+// ListNotifications lists the unread notifications of the token's user,
+// newest first
+func ListNotifications(opts RepoListOptions) ([]Notification, error) {
+	if opts.Platform != "github" {
+		return nil, fmt.Errorf("notifications are only supported on GitHub")
+	}
+
+	var items []struct {
+		ID        string    `json:"id"`
+		Reason    string    `json:"reason"`
+		UpdatedAt time.Time `json:"updated_at"`
+		Subject   struct {
+			Title string `json:"title"`
+			URL   string `json:"url"`
+			Type  string `json:"type"`
+		} `json:"subject"`
+		Repository struct {
+			FullName string `json:"full_name"`
+			HTMLURL  string `json:"html_url"`
+		} `json:"repository"`
+	}
+	if err := opts.client().Call("GET", "/notifications?per_page=50", nil, &items); err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	notifications := make([]Notification, len(items))
+	for i, item := range items {
+		notifications[i] = Notification{
+			ID:        item.ID,
+			Title:     item.Subject.Title,
+			Type:      item.Subject.Type,
+			Reason:    item.Reason,
+			Repo:      item.Repository.FullName,
+			URL:       subjectWebURL(item.Subject.URL, item.Subject.Type, item.Repository.FullName, item.Repository.HTMLURL),
+			UpdatedAt: item.UpdatedAt,
+		}
+	}
+	return notifications, nil
+}
+
+// MarkNotificationRead marks a notification thread as read
+func MarkNotificationRead(id string, opts RepoListOptions) error {
+	if err := opts.client().Call("PATCH", "/notifications/threads/"+url.PathEscape(id), nil, nil); err != nil {
+		return fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+	return nil
+}
+
+// ListAssignedPullRequests lists the open pull requests assigned to the
+// token's user, most recently updated first
+func ListAssignedPullRequests(opts RepoListOptions) ([]PullRequest, error) {
+	if opts.Platform != "github" {
+		return nil, fmt.Errorf("pull request search is only supported on GitHub")
+	}
+
+	var result struct {
+		Items []struct {
+			Number        int       `json:"number"`
+			Title         string    `json:"title"`
+			HTMLURL       string    `json:"html_url"`
+			RepositoryURL string    `json:"repository_url"`
+			UpdatedAt     time.Time `json:"updated_at"`
+		} `json:"items"`
+	}
+	query := url.QueryEscape("is:open is:pr assignee:@me")
+	if err := opts.client().Call("GET", "/search/issues?sort=updated&per_page=50&q="+query, nil, &result); err != nil {
+		return nil, fmt.Errorf("failed to search pull requests: %w", err)
+	}
+
+	prs := make([]PullRequest, len(result.Items))
+	for i, item := range result.Items {
+		repo := item.RepositoryURL
+		if idx := strings.Index(repo, "/repos/"); idx >= 0 {
+			repo = repo[idx+len("/repos/"):]
+		}
+		prs[i] = PullRequest{
+			Number:    item.Number,
+			Title:     item.Title,
+			Repo:      repo,
+			URL:       item.HTMLURL,
+			UpdatedAt: item.UpdatedAt,
+		}
+	}
+	return prs, nil
+}
+
+// subjectWebURL turns a notification subject's API URL into its web page,
+// falling back to the repository's pages
+func subjectWebURL(apiURL, subjectType, fullName, repoHTML string) string {
+	prefix := "/repos/" + fullName + "/"
+	idx := strings.Index(apiURL, prefix)
+	if idx < 0 {
+		if subjectType == "Discussion" {
+			return repoHTML + "/discussions"
+		}
+		return repoHTML
+	}
+
+	rest := apiURL[idx+len(prefix):]
+	kind, id, _ := strings.Cut(rest, "/")
+	switch kind {
+	case "pulls":
+		return repoHTML + "/pull/" + id
+	case "issues":
+		return repoHTML + "/issues/" + id
+	case "commits":
+		return repoHTML + "/commit/" + id
+	case "releases":
+		return repoHTML + "/releases"
+	default:
+		return repoHTML
+	}
+}
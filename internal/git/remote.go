@@ -2,7 +2,9 @@ package git
 
 import (
 	"fmt"
+	"net"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -13,10 +15,13 @@ type URLInfo struct {
 	Host     string
 	Owner    string
 	Repo     string
+	Port     int    // SSH port from ssh:// URLs (0 = default)
 	Platform string // github, gitlab, bitbucket, gitea, other
 }
 
-// ParseRepoFromURL extracts owner/repo from a git URL
+// ParseRepoFromURL extracts owner/repo from a git URL.
+// The owner keeps the full namespace for nested groups (e.g. GitLab
+// group/subgroup/project yields owner "group/subgroup").
 func ParseRepoFromURL(rawURL string) (owner, repo string, err error) {
 	if rawURL == "" {
 		return "", "", fmt.Errorf("empty URL")
@@ -29,7 +34,7 @@ func ParseRepoFromURL(rawURL string) (owner, repo string, err error) {
 	if matches := sshPattern.FindStringSubmatch(rawURL); len(matches) == 3 {
 		parts := strings.Split(matches[2], "/")
 		if len(parts) >= 2 {
-			return parts[0], strings.TrimSuffix(parts[len(parts)-1], ".git"), nil
+			return splitNamespace(parts)
 		}
 	}
 
@@ -38,7 +43,7 @@ func ParseRepoFromURL(rawURL string) (owner, repo string, err error) {
 	if matches := sshURLPattern.FindStringSubmatch(rawURL); len(matches) == 3 {
 		parts := strings.Split(matches[2], "/")
 		if len(parts) >= 2 {
-			return parts[0], strings.TrimSuffix(parts[len(parts)-1], ".git"), nil
+			return splitNamespace(parts)
 		}
 	}
 
@@ -47,13 +52,23 @@ func ParseRepoFromURL(rawURL string) (owner, repo string, err error) {
 	if matches := httpsPattern.FindStringSubmatch(rawURL); len(matches) == 3 {
 		parts := strings.Split(matches[2], "/")
 		if len(parts) >= 2 {
-			return parts[0], strings.TrimSuffix(parts[len(parts)-1], ".git"), nil
+			return splitNamespace(parts)
 		}
 	}
 
 	return "", "", fmt.Errorf("unable to parse URL: %s", rawURL)
 }
 
+// splitNamespace splits path segments into namespace (owner) and repo name
+func splitNamespace(parts []string) (owner, repo string, err error) {
+	owner = strings.Join(parts[:len(parts)-1], "/")
+	repo = strings.TrimSuffix(parts[len(parts)-1], ".git")
+	if owner == "" || repo == "" {
+		return "", "", fmt.Errorf("unable to parse repository path: %s", strings.Join(parts, "/"))
+	}
+	return owner, repo, nil
+}
+
 // NormalizeURL normalizes a git URL and adds .git suffix if missing
 func NormalizeURL(rawURL string) (normalized string, isSSH bool, err error) {
 	if rawURL == "" {
@@ -95,6 +110,10 @@ func ParseURL(rawURL string) (*URLInfo, error) {
 	}
 
 	host := detectHost(normalized)
+	port := 0
+	if isSSH {
+		host, port = splitHostPort(host)
+	}
 	platform := detectPlatform(host)
 
 	return &URLInfo{
@@ -103,10 +122,26 @@ func ParseURL(rawURL string) (*URLInfo, error) {
 		Host:     host,
 		Owner:    owner,
 		Repo:     repo,
+		Port:     port,
 		Platform: platform,
 	}, nil
 }
 
+// splitHostPort splits an optional ":port" suffix from an SSH host. IPv6
+// addresses come back without brackets; an invalid port is left in place.
+func splitHostPort(host string) (string, int) {
+	h, p, err := net.SplitHostPort(host)
+	if err != nil {
+		// No port, or a bare IPv6 address
+		return strings.TrimSuffix(strings.TrimPrefix(host, "["), "]"), 0
+	}
+	port, err := strconv.Atoi(p)
+	if err != nil || port < 1 || port > 65535 {
+		return host, 0
+	}
+	return h, port
+}
+
 // detectHost extracts the host from a URL
 func detectHost(rawURL string) string {
 	// SSH format: git@host:path
@@ -209,6 +244,12 @@ func GetPlatformURLConfig(platform string) PlatformURLConfig {
 
 // BuildRemoteURL builds a remote URL for a given platform
 func BuildRemoteURL(platform, domain, repoPath string, useSSH bool) string {
+	return BuildRemoteURLWithPort(platform, domain, repoPath, useSSH, 0)
+}
+
+// BuildRemoteURLWithPort builds a remote URL for a given platform.
+// SSH URLs with a non-default port use the ssh://git@host:port/path form.
+func BuildRemoteURLWithPort(platform, domain, repoPath string, useSSH bool, port int) string {
 	config := GetPlatformURLConfig(platform)
 
 	if domain == "" {
@@ -223,7 +264,15 @@ func BuildRemoteURL(platform, domain, repoPath string, useSSH bool) string {
 		repoPath += ".git"
 	}
 
+	// IPv6 addresses need brackets in URLs
+	if ip := net.ParseIP(domain); ip != nil && ip.To4() == nil {
+		domain = "[" + domain + "]"
+	}
+
 	if useSSH {
+		if port > 0 && port <= 65535 && port != 22 {
+			return fmt.Sprintf("ssh://git@%s:%d/%s", domain, port, repoPath)
+		}
 		return fmt.Sprintf(config.SSHFormat, domain, repoPath)
 	}
 
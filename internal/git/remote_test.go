@@ -0,0 +1,111 @@
+package git
+
+import "testing"
+
+func TestSplitHostPort(t *testing.T) {
+	tests := []struct {
+		input    string
+		wantHost string
+		wantPort int
+	}{
+		{"github.com", "github.com", 0},
+		{"git.company.com:2222", "git.company.com", 2222},
+		{"git.company.com:22", "git.company.com", 22},
+		{"[::1]:2222", "::1", 2222},
+		{"[2001:db8::1]", "2001:db8::1", 0},
+		{"2001:db8::1", "2001:db8::1", 0},
+		{"git.company.com:abc", "git.company.com:abc", 0},
+		{"git.company.com:70000", "git.company.com:70000", 0},
+		{"git.company.com:0", "git.company.com:0", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			host, port := splitHostPort(tt.input)
+			if host != tt.wantHost || port != tt.wantPort {
+				t.Errorf("splitHostPort(%q) = %q, %d, want %q, %d", tt.input, host, port, tt.wantHost, tt.wantPort)
+			}
+		})
+	}
+}
+
+func TestBuildRemoteURLWithPort(t *testing.T) {
+	tests := []struct {
+		name     string
+		platform string
+		domain   string
+		useSSH   bool
+		port     int
+		expected string
+	}{
+		{"default port", "github", "", true, 0, "git@github.com:owner/repo.git"},
+		{"port 22", "gitlab", "git.company.com", true, 22, "git@git.company.com:owner/repo.git"},
+		{"custom port", "gitea", "git.company.com", true, 2222, "ssh://git@git.company.com:2222/owner/repo.git"},
+		{"invalid port", "gitea", "git.company.com", true, 70000, "git@git.company.com:owner/repo.git"},
+		{"https ignores port", "gitea", "git.company.com", false, 2222, "https://git.company.com/owner/repo.git"},
+		{"ipv6 with port", "other", "2001:db8::1", true, 2222, "ssh://git@[2001:db8::1]:2222/owner/repo.git"},
+		{"ipv6 default port", "other", "::1", true, 0, "git@[::1]:owner/repo.git"},
+		{"ipv6 https", "other", "::1", false, 0, "https://[::1]/owner/repo.git"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BuildRemoteURLWithPort(tt.platform, tt.domain, "owner/repo", tt.useSSH, tt.port)
+			if got != tt.expected {
+				t.Errorf("BuildRemoteURLWithPort(%q, %q, %v, %d) = %q, want %q", tt.platform, tt.domain, tt.useSSH, tt.port, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseRepoFromURL(t *testing.T) {
+	tests := []struct {
+		url       string
+		wantOwner string
+		wantRepo  string
+		wantErr   bool
+	}{
+		{"git@github.com:owner/repo.git", "owner", "repo", false},
+		{"https://github.com/owner/repo", "owner", "repo", false},
+		{"git@gitlab.com:group/subgroup/project.git", "group/subgroup", "project", false},
+		{"https://gitlab.com/group/sub/deeper/project.git", "group/sub/deeper", "project", false},
+		{"ssh://git@git.company.com:2222/group/subgroup/project.git", "group/subgroup", "project", false},
+		{"https://gitlab.com/project", "", "", true},
+		{"git@gitlab.com:group/", "", "", true},
+		{"not a url", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			owner, repo, err := ParseRepoFromURL(tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRepoFromURL(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+			}
+			if owner != tt.wantOwner || repo != tt.wantRepo {
+				t.Errorf("ParseRepoFromURL(%q) = %q, %q, want %q, %q", tt.url, owner, repo, tt.wantOwner, tt.wantRepo)
+			}
+		})
+	}
+}
+
+func TestSplitNamespace(t *testing.T) {
+	tests := []struct {
+		parts     []string
+		wantOwner string
+		wantRepo  string
+		wantErr   bool
+	}{
+		{[]string{"owner", "repo"}, "owner", "repo", false},
+		{[]string{"group", "sub", "project.git"}, "group/sub", "project", false},
+		{[]string{"a", "b", "c", "d", "project"}, "a/b/c/d", "project", false},
+		{[]string{"", "repo"}, "", "", true},
+		{[]string{"group", ".git"}, "", "", true},
+	}
+
+	for _, tt := range tests {
+		owner, repo, err := splitNamespace(tt.parts)
+		if (err != nil) != tt.wantErr || owner != tt.wantOwner || repo != tt.wantRepo {
+			t.Errorf("splitNamespace(%v) = %q, %q, %v, want %q, %q (error %v)", tt.parts, owner, repo, err, tt.wantOwner, tt.wantRepo, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/dwirx/ghex/internal/api"
+)
+
+// maxRepoPages caps pagination when listing repositories
+const maxRepoPages = 50
+
+// errOwnerNotFound is returned for an endpoint that doesn't know the owner,
+// e.g. the organization endpoint for a user
+var errOwnerNotFound = errors.New("not found")
+
+// RemoteRepo is a repository listed by a platform API
+type RemoteRepo struct {
+	FullName string // owner/repo, with the full namespace on GitLab
+	Archived bool
+}
+
+// RepoListOptions selects the platform API used to list repositories
+type RepoListOptions struct {
+	Platform string // github, gitlab, gitea, codeberg
+	Host     string // Git host, e.g. github.com or gitlab.company.com
+	APIURL   string // API base URL (empty = derived from Platform and Host)
+	Username string // Account username; its own private repositories are listed too
+	Token    string // Token for private repositories (empty = public only)
+}
+
+// apiBase returns the API base URL for the options
+func (o RepoListOptions) apiBase() string {
+	if o.APIURL != "" {
+		return strings.TrimSuffix(o.APIURL, "/")
+	}
+	switch o.Platform {
+	case "github":
+		if o.Host == "" || o.Host == "github.com" {
+			return "https://api.github.com"
+		}
+		return "https://" + o.Host + "/api/v3"
+	case "gitlab":
+		return "https://" + o.Host + "/api/v4"
+	default:
+		return "https://" + o.Host + "/api/v1"
+	}
+}
+
+// client returns the shared API client of the account
+func (o RepoListOptions) client() *api.Client {
+	return api.For(o.Platform, o.apiBase(), o.Token)
+}
+
+// ListRepos lists the repositories of a user or organization (a group on
+// GitLab) through the platform API
+func ListRepos(owner string, opts RepoListOptions) ([]RemoteRepo, error) {
+	base := opts.apiBase()
+	own := opts.Username != "" && strings.EqualFold(owner, opts.Username) && opts.Token != ""
+	escaped := url.PathEscape(owner)
+
+	var endpoints []string
+	switch opts.Platform {
+	case "github":
+		if own {
+			endpoints = []string{base + "/user/repos?affiliation=owner"}
+		} else {
+			endpoints = []string{base + "/orgs/" + escaped + "/repos?type=all", base + "/users/" + escaped + "/repos"}
+		}
+	case "gitlab":
+		endpoints = []string{
+			base + "/groups/" + escaped + "/projects?include_subgroups=true",
+			base + "/users/" + escaped + "/projects",
+		}
+	case "gitea", "codeberg":
+		if own {
+			endpoints = []string{base + "/user/repos"}
+		} else {
+			endpoints = []string{base + "/orgs/" + escaped + "/repos", base + "/users/" + escaped + "/repos"}
+		}
+	default:
+		return nil, fmt.Errorf("listing repositories is not supported on %s", opts.Platform)
+	}
+
+	var lastErr error
+	for _, endpoint := range endpoints {
+		repos, err := listRepoPages(endpoint, opts)
+		if err == nil {
+			return repos, nil
+		}
+		lastErr = err
+		if !errors.Is(err, errOwnerNotFound) {
+			break
+		}
+	}
+	return nil, fmt.Errorf("failed to list repositories of %s: %w", owner, lastErr)
+}
+
+// listRepoPages fetches every page of a repository listing
+func listRepoPages(endpoint string, opts RepoListOptions) ([]RemoteRepo, error) {
+	sep := "?"
+	if strings.Contains(endpoint, "?") {
+		sep = "&"
+	}
+
+	var repos []RemoteRepo
+	err := opts.client().Paginate(endpoint+sep+"per_page=100&limit=50", maxRepoPages, func(resp *api.Response) (bool, error) {
+		var items []struct {
+			FullName          string `json:"full_name"`           // GitHub, Gitea
+			PathWithNamespace string `json:"path_with_namespace"` // GitLab
+			Archived          bool   `json:"archived"`
+		}
+		if err := resp.Decode(&items); err != nil {
+			return false, err
+		}
+		for _, item := range items {
+			name := item.FullName
+			if name == "" {
+				name = item.PathWithNamespace
+			}
+			repos = append(repos, RemoteRepo{FullName: name, Archived: item.Archived})
+		}
+		return true, nil
+	})
+	var statusErr *api.StatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+		return nil, errOwnerNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return repos, nil
+}
@@ -0,0 +1,149 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dwirx/ghex/internal/shell"
+)
+
+// RemoteConfig is the part of a remote's configuration that must survive a
+// URL rewrite
+type RemoteConfig struct {
+	URLs               []string // remote.<name>.url (the first one is used for fetching)
+	PushURLs           []string // remote.<name>.pushurl
+	Fetch              []string // remote.<name>.fetch refspecs
+	Promisor           string   // remote.<name>.promisor (partial clones)
+	PartialCloneFilter string   // remote.<name>.partialclonefilter
+}
+
+// GetRemoteConfig reads a remote's URLs, refspecs and partial-clone settings
+func GetRemoteConfig(remote, path string) *RemoteConfig {
+	if remote == "" {
+		remote = "origin"
+	}
+	if path == "" {
+		path = "."
+	}
+
+	prefix := "remote." + remote + "."
+	return &RemoteConfig{
+		URLs:               getAllLocalConfig(prefix+"url", path),
+		PushURLs:           getAllLocalConfig(prefix+"pushurl", path),
+		Fetch:              getAllLocalConfig(prefix+"fetch", path),
+		Promisor:           GetLocalConfig(prefix+"promisor", path),
+		PartialCloneFilter: GetLocalConfig(prefix+"partialclonefilter", path),
+	}
+}
+
+// RewriteRemoteURL points a remote at newURL while keeping everything else
+// about it intact. Extra URLs and push URLs for the same repository are
+// rewritten too; push URLs for other repositories (mirrors) are left alone.
+// Fetch refspecs and partial-clone settings are restored if lost.
+func RewriteRemoteURL(newURL, remote, path string) error {
+	if remote == "" {
+		remote = "origin"
+	}
+	if path == "" {
+		path = "."
+	}
+
+	before := GetRemoteConfig(remote, path)
+	if len(before.URLs) == 0 {
+		return SetRemoteURL(newURL, remote, path)
+	}
+	oldURL := before.URLs[0]
+
+	if err := SetRemoteURL(newURL, remote, path); err != nil {
+		return err
+	}
+
+	for _, u := range before.URLs[1:] {
+		if u != newURL && SameRepository(u, oldURL) {
+			if _, err := shell.RunInDir(path, "git", "remote", "set-url", remote, newURL, regexpQuote(u)); err != nil {
+				return fmt.Errorf("failed to rewrite URL %s: %w", u, err)
+			}
+		}
+	}
+
+	for _, u := range before.PushURLs {
+		if u != newURL && SameRepository(u, oldURL) {
+			if _, err := shell.RunInDir(path, "git", "remote", "set-url", "--push", remote, newURL, regexpQuote(u)); err != nil {
+				return fmt.Errorf("failed to rewrite push URL %s: %w", u, err)
+			}
+		}
+	}
+
+	// Make sure nothing above dropped refspecs or partial-clone settings
+	after := GetRemoteConfig(remote, path)
+	prefix := "remote." + remote + "."
+	for _, refspec := range before.Fetch {
+		if !containsString(after.Fetch, refspec) {
+			if _, err := shell.RunInDir(path, "git", "config", "--local", "--add", prefix+"fetch", refspec); err != nil {
+				return fmt.Errorf("failed to restore fetch refspec %s: %w", refspec, err)
+			}
+		}
+	}
+	if before.Promisor != "" && after.Promisor != before.Promisor {
+		if err := SetLocalConfig(prefix+"promisor", before.Promisor, path); err != nil {
+			return err
+		}
+	}
+	if before.PartialCloneFilter != "" && after.PartialCloneFilter != before.PartialCloneFilter {
+		if err := SetLocalConfig(prefix+"partialclonefilter", before.PartialCloneFilter, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SameRepository reports whether two remote URLs point to the same
+// repository on the same host, regardless of protocol
+func SameRepository(a, b string) bool {
+	if a == b {
+		return true
+	}
+	infoA, errA := ParseURL(a)
+	infoB, errB := ParseURL(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return strings.EqualFold(bareHost(infoA.Host), bareHost(infoB.Host)) &&
+		strings.EqualFold(infoA.Owner, infoB.Owner) &&
+		strings.EqualFold(infoA.Repo, infoB.Repo)
+}
+
+// bareHost strips user info and port from a host
+func bareHost(host string) string {
+	if idx := strings.LastIndex(host, "@"); idx >= 0 {
+		host = host[idx+1:]
+	}
+	host, _ = splitHostPort(host)
+	return host
+}
+
+// getAllLocalConfig returns every value of a multi-valued local config key
+func getAllLocalConfig(key, path string) []string {
+	output, err := shell.RunInDir(path, "git", "config", "--local", "--get-all", key)
+	if err != nil || output == "" {
+		return nil
+	}
+	return strings.Split(output, "\n")
+}
+
+// regexpQuote escapes a URL for git's old-url regex argument
+func regexpQuote(s string) string {
+	return "^" + strings.NewReplacer(
+		`\`, `\\`, ".", `\.`, "+", `\+`, "*", `\*`, "?", `\?`, "(", `\(`, ")", `\)`,
+		"[", `\[`, "]", `\]`, "{", `\{`, "}", `\}`, "^", `\^`, "$", `\$`, "|", `\|`,
+	).Replace(s) + "$"
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package git
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TokenInfo describes a personal access token as reported by its platform
+type TokenInfo struct {
+	Valid     bool
+	Login     string    // User the token belongs to
+	Scopes    []string  // nil when the platform doesn't report them
+	ExpiresAt time.Time // Zero when the token doesn't expire or it's unknown
+}
+
+// GetTokenInfo asks the platform who a token belongs to, with its scopes
+// and expiry where the platform reports them. A rejected token returns
+// Valid false and no error.
+func GetTokenInfo(opts RepoListOptions) (*TokenInfo, error) {
+	var user struct {
+		Login    string `json:"login"`    // GitHub, Gitea
+		Username string `json:"username"` // GitLab
+	}
+	resp, err := opts.client().GetJSON("/user", &user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check token: %w", err)
+	}
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return &TokenInfo{}, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to check token: HTTP %d", resp.StatusCode)
+	}
+
+	info := &TokenInfo{Valid: true, Login: user.Login}
+	if info.Login == "" {
+		info.Login = user.Username
+	}
+
+	switch opts.Platform {
+	case "github":
+		// Classic tokens list their scopes; fine-grained tokens don't
+		if header, ok := resp.Header["X-Oauth-Scopes"]; ok {
+			info.Scopes = splitScopes(strings.Join(header, ","))
+		}
+		info.ExpiresAt = parseGitHubExpiry(resp.Header.Get("GitHub-Authentication-Token-Expiration"))
+	case "gitlab":
+		var self struct {
+			Scopes    []string `json:"scopes"`
+			ExpiresAt string   `json:"expires_at"`
+		}
+		if resp, err := opts.client().GetJSON("/personal_access_tokens/self", &self); err == nil && resp.StatusCode == http.StatusOK {
+			info.Scopes = self.Scopes
+			if t, err := time.Parse("2006-01-02", self.ExpiresAt); err == nil {
+				info.ExpiresAt = t
+			}
+		}
+	}
+	return info, nil
+}
+
+// ListUserKeys returns the public SSH keys registered to a user, as
+// "type base64" strings
+func ListUserKeys(username string, opts RepoListOptions) ([]string, error) {
+	var items []struct {
+		Key string `json:"key"`
+	}
+	resp, err := opts.client().GetJSON("/users/"+url.PathEscape(username)+"/keys", &items)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SSH keys of %s: %w", username, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to list SSH keys of %s: HTTP %d", username, resp.StatusCode)
+	}
+
+	keys := make([]string, 0, len(items))
+	for _, item := range items {
+		if fields := strings.Fields(item.Key); len(fields) >= 2 {
+			keys = append(keys, fields[0]+" "+fields[1])
+		}
+	}
+	return keys, nil
+}
+
+// splitScopes splits a comma-separated scope list
+func splitScopes(s string) []string {
+	scopes := []string{}
+	for _, scope := range strings.Split(s, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// parseGitHubExpiry parses GitHub's token expiration header, e.g.
+// "2026-03-01 12:00:00 UTC"
+func parseGitHubExpiry(s string) time.Time {
+	for _, layout := range []string{"2006-01-02 15:04:05 MST", "2006-01-02 15:04:05 -0700"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
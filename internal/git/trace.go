@@ -0,0 +1,83 @@
+package git
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/dwirx/ghex/internal/shell"
+)
+
+// CredentialTrace shows which credential git would use for an HTTPS remote
+type CredentialTrace struct {
+	Helper      string   // Helper that answered ("" = none, git would prompt)
+	Asked       []string // Helpers asked, in order
+	Username    string   // Username returned
+	HasPassword bool     // Whether a password or token was returned
+}
+
+// TraceCredential runs `git credential fill` for a remote URL without
+// prompting and reports which helper answered
+func TraceCredential(remoteURL, path string) (*CredentialTrace, error) {
+	if path == "" {
+		path = "."
+	}
+
+	info, err := ParseURL(remoteURL)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsSSH {
+		return nil, fmt.Errorf("%s is an SSH remote", remoteURL)
+	}
+
+	input := fmt.Sprintf("protocol=https\nhost=%s\npath=%s/%s.git\n\n", bareHost(info.Host), info.Owner, info.Repo)
+	env := []string{
+		"GIT_TRACE=1",
+		"GIT_TERMINAL_PROMPT=0",
+		"GCM_INTERACTIVE=never",
+		"GIT_ASKPASS=",
+		"SSH_ASKPASS=",
+	}
+	stdout, stderr, fillErr := shell.RunWithInput(path, env, input, "git", "credential", "fill")
+
+	trace := &CredentialTrace{}
+	for _, line := range strings.Split(stderr, "\n") {
+		if helper := tracedHelper(line); helper != "" {
+			trace.Asked = append(trace.Asked, helper)
+		}
+	}
+
+	for _, line := range strings.Split(stdout, "\n") {
+		key, value, _ := strings.Cut(line, "=")
+		switch key {
+		case "username":
+			trace.Username = value
+		case "password":
+			trace.HasPassword = value != ""
+		}
+	}
+
+	// git stops asking once a helper returns a complete credential
+	if fillErr == nil && trace.HasPassword && len(trace.Asked) > 0 {
+		trace.Helper = trace.Asked[len(trace.Asked)-1]
+	}
+	return trace, nil
+}
+
+// tracedHelper extracts the helper name from a GIT_TRACE line such as
+// "trace: run_command: 'git credential-osxkeychain get'"
+func tracedHelper(line string) string {
+	_, command, ok := strings.Cut(line, "run_command: ")
+	if !ok {
+		return ""
+	}
+	fields := strings.Fields(strings.Trim(command, "'"))
+	if len(fields) < 2 || fields[len(fields)-1] != "get" {
+		return ""
+	}
+	if fields[0] == "git" && strings.HasPrefix(fields[1], "credential-") {
+		return strings.TrimPrefix(fields[1], "credential-")
+	}
+	return strings.TrimPrefix(filepath.Base(fields[0]), "git-credential-")
+}
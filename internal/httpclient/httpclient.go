@@ -0,0 +1,102 @@
+// Package httpclient provides HTTP clients backed by a single shared
+// Transport so connections are kept alive and reused across API calls and
+// file downloads.
+package httpclient
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/dwirx/ghex/internal/offline"
+)
+
+// transport is shared by every client created in this package. It enables
+// HTTP/2 and keeps enough idle connections per host for parallel downloads
+// from raw.githubusercontent.com and api.github.com.
+var transport = &http.Transport{
+	Proxy: http.ProxyFromEnvironment,
+	DialContext: (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}).DialContext,
+	ForceAttemptHTTP2:     true,
+	MaxIdleConns:          100,
+	MaxIdleConnsPerHost:   16,
+	IdleConnTimeout:       90 * time.Second,
+	TLSHandshakeTimeout:   10 * time.Second,
+	ExpectContinueTimeout: 1 * time.Second,
+}
+
+// extraHeaders are added to every request by headerTransport
+var (
+	extraHeaders   http.Header
+	extraHeadersMu sync.RWMutex
+)
+
+// headerTransport adds the headers set with SetHeaders to each request,
+// replacing the request's own values. In offline mode it fails requests to
+// anything but loopback hosts with offline.ErrOffline.
+type headerTransport struct {
+	base http.RoundTripper
+}
+
+func (t headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !offline.IsLocalHost(req.URL.Host) && offline.Enabled() {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, offline.ErrOffline
+	}
+
+	extraHeadersMu.RLock()
+	headers := extraHeaders
+	extraHeadersMu.RUnlock()
+	if len(headers) == 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	// A RoundTripper must not modify the caller's request
+	req = req.Clone(req.Context())
+	for name, values := range headers {
+		req.Header[name] = append([]string(nil), values...)
+	}
+	return t.base.RoundTrip(req)
+}
+
+// roundTripper is the transport of the clients returned by this package
+var roundTripper http.RoundTripper = headerTransport{base: transport}
+
+// defaultClient is used for requests without a specific timeout
+var defaultClient = &http.Client{Transport: roundTripper}
+
+// SetHeaders sets headers sent with every request made through this
+// package's clients, API calls included, such as a User-Agent or token a
+// proxy requires. They replace headers of the same name set by callers.
+func SetHeaders(h http.Header) {
+	extraHeadersMu.Lock()
+	defer extraHeadersMu.Unlock()
+	extraHeaders = h.Clone()
+}
+
+// Transport returns the shared transport, without the headers set with
+// SetHeaders
+func Transport() *http.Transport {
+	return transport
+}
+
+// Default returns the shared client (no overall timeout)
+func Default() *http.Client {
+	return defaultClient
+}
+
+// New returns a client with the given timeout that uses the shared transport.
+// Callers that need custom redirect handling should use New rather than
+// modifying the Default client.
+func New(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Transport: roundTripper,
+		Timeout:   timeout,
+	}
+}
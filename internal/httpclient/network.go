@@ -0,0 +1,167 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dwirx/ghex/internal/offline"
+)
+
+// Network holds the proxy and TLS settings needed on networks where
+// GitHub can't be reached directly, such as behind a corporate proxy that
+// re-signs TLS traffic with its own CA.
+type Network struct {
+	Proxy    string // Proxy URL, e.g. http://proxy:3128 (empty = HTTP_PROXY/HTTPS_PROXY)
+	Insecure bool   // Skip TLS certificate verification
+	CACert   string // PEM file of CA certificates trusted besides the system ones
+}
+
+// IsSet reports whether any setting differs from the defaults
+func (n Network) IsSet() bool {
+	return n.Proxy != "" || n.Insecure || n.CACert != ""
+}
+
+// apply sets n on t
+func (n Network) apply(t *http.Transport) error {
+	if n.Proxy != "" {
+		proxy, err := proxyFunc(n.Proxy, noProxyEnv())
+		if err != nil {
+			return err
+		}
+		t.Proxy = proxy
+		offline.SetProxied(true)
+	}
+	if n.Insecure || n.CACert != "" {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if t.TLSClientConfig != nil {
+			tlsConfig = t.TLSClientConfig.Clone()
+		}
+		if n.CACert != "" {
+			pool, err := certPool(n.CACert)
+			if err != nil {
+				return err
+			}
+			tlsConfig.RootCAs = pool
+		}
+		tlsConfig.InsecureSkipVerify = n.Insecure
+		t.TLSClientConfig = tlsConfig
+	}
+	return nil
+}
+
+// Configure applies proxy and TLS settings to every client of this
+// package, API calls included
+func Configure(n Network) error {
+	if err := n.apply(transport); err != nil {
+		return err
+	}
+	transport.CloseIdleConnections()
+	return nil
+}
+
+// NewWithNetwork returns a client with the given timeout that uses its own
+// transport with n applied, leaving the shared one alone
+func NewWithNetwork(timeout time.Duration, n Network) (*http.Client, error) {
+	t := transport.Clone()
+	if err := n.apply(t); err != nil {
+		return nil, err
+	}
+	return &http.Client{
+		Transport: headerTransport{base: t},
+		Timeout:   timeout,
+	}, nil
+}
+
+// certPool returns the system CAs plus those in the PEM file at path
+func certPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no PEM certificates found in %s", path)
+	}
+	return pool, nil
+}
+
+// noProxyEnv returns NO_PROXY, or no_proxy
+func noProxyEnv() string {
+	if v := os.Getenv("NO_PROXY"); v != "" {
+		return v
+	}
+	return os.Getenv("no_proxy")
+}
+
+// proxyFunc returns a Transport.Proxy that sends requests through rawURL,
+// except to loopback hosts and those matching noProxy. A URL without a
+// scheme is an HTTP proxy; socks5:// works too.
+func proxyFunc(rawURL, noProxy string) (func(*http.Request) (*url.URL, error), error) {
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "http://" + rawURL
+	}
+	proxyURL, err := url.Parse(rawURL)
+	if err != nil || proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q", rawURL)
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q (use http, https or socks5)", proxyURL.Scheme)
+	}
+
+	return func(req *http.Request) (*url.URL, error) {
+		if bypassProxy(req.URL.Hostname(), noProxy) {
+			return nil, nil
+		}
+		return proxyURL, nil
+	}, nil
+}
+
+// bypassProxy reports whether host is reached directly: loopback hosts,
+// and those matching a NO_PROXY entry (a domain and its subdomains, an IP,
+// a CIDR range, or * for everything)
+func bypassProxy(host, noProxy string) bool {
+	host = strings.ToLower(host)
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	if ip != nil && ip.IsLoopback() {
+		return true
+	}
+
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if entry == "*" {
+			return true
+		}
+		if _, cidr, err := net.ParseCIDR(entry); err == nil {
+			if ip != nil && cidr.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if h, _, err := net.SplitHostPort(entry); err == nil {
+			entry = h
+		}
+		entry = strings.TrimPrefix(strings.TrimPrefix(entry, "*"), ".")
+		if host == entry || strings.HasSuffix(host, "."+entry) {
+			return true
+		}
+	}
+	return false
+}
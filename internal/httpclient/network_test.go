@@ -0,0 +1,53 @@
+package httpclient
+
+import (
+	"net/http"
+	"testing"
+)
+
+// TestProxyFunc tests which requests go through a --proxy proxy
+func TestProxyFunc(t *testing.T) {
+	proxy, err := proxyFunc("proxy.corp:3128", "internal.corp, .example.com,10.0.0.0/8,*.lan")
+	if err != nil {
+		t.Fatalf("proxyFunc() error = %v", err)
+	}
+
+	tests := []struct {
+		url     string
+		proxied bool
+	}{
+		{"https://api.github.com/repos", true},
+		{"https://internal.corp/file", false},
+		{"https://git.internal.corp/file", false},
+		{"https://example.com/file", false},
+		{"https://cdn.example.com/file", false},
+		{"https://notexample.com/file", true},
+		{"http://10.1.2.3:8080/file", false},
+		{"http://192.168.1.1/file", true},
+		{"http://nas.lan/file", false},
+		{"http://localhost:8080/file", false},
+		{"http://127.0.0.1/file", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			got, err := proxy(req)
+			if err != nil {
+				t.Fatalf("proxy() error = %v", err)
+			}
+			if (got != nil) != tt.proxied {
+				t.Errorf("proxy(%s) = %v, want proxied %v", tt.url, got, tt.proxied)
+			}
+			if got != nil && got.String() != "http://proxy.corp:3128" {
+				t.Errorf("proxy(%s) = %v, want http://proxy.corp:3128", tt.url, got)
+			}
+		})
+	}
+
+	for _, bad := range []string{"ftp://proxy:21", "http://"} {
+		if _, err := proxyFunc(bad, ""); err == nil {
+			t.Errorf("proxyFunc(%q) accepted an invalid proxy", bad)
+		}
+	}
+}
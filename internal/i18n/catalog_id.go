@@ -0,0 +1,706 @@
+package i18n
+
+// indonesian holds the Indonesian (Bahasa Indonesia) translations, grouped by
+// the command file that uses them
+var indonesian = map[string]string{
+	// account.go
+	"Copy token %s for %s to the clipboard?":                     "Salin token %s untuk %s ke clipboard?",
+	"Current identity: %s <%s>":                                  "Identitas saat ini: %s <%s>",
+	"%d accounts match this repository equally well":             "%d akun sama-sama cocok dengan repository ini",
+	"Failed to pin account: %v":                                  "Gagal menyematkan akun: %v",
+	"Pinned repository to %s":                                    "Repository disematkan ke %s",
+	"Cannot parse remote URL: %v":                                "Tidak dapat membaca URL remote: %v",
+	"git credential fill failed: %v":                             "git credential fill gagal: %v",
+	"git would authenticate as '%s', but account '%s' uses '%s'": "git akan login sebagai '%s', tetapi akun '%s' memakai '%s'",
+	"ssh would not offer %s, the key for account '%s'":           "ssh tidak akan menawarkan %s, kunci untuk akun '%s'",
+	"Failed to load config: %v":                                  "Gagal memuat konfigurasi: %v",
+	"Account '%s' not found":                                     "Akun '%s' tidak ditemukan",
+	"Failed to save config: %v":                                  "Gagal menyimpan konfigurasi: %v",
+	"Marked '%s' as a favorite":                                  "'%s' ditandai sebagai favorit",
+	"Removed '%s' from favorites":                                "'%s' dihapus dari favorit",
+	"Accounts are now sorted by: %s":                             "Akun sekarang diurutkan menurut: %s",
+	"Unknown language '%s' (use %s or auto)":                     "Bahasa '%s' tidak dikenal (gunakan %s atau auto)",
+	"Language set to: %s":                                        "Bahasa diatur ke: %s",
+	"Unknown value '%s' (use on or off)":                         "Nilai '%s' tidak dikenal (gunakan on atau off)",
+	"Accessible mode: %s":                                        "Mode aksesibel: %s",
+	"Selection error: %v":                                        "Kesalahan pemilihan: %v",
+	"Failed to switch account: %v":                               "Gagal berpindah akun: %v",
+	"Switched to account: %s (%s)":                               "Berpindah ke akun: %s (%s)",
+	"Switched to account: %s":                                    "Berpindah ke akun: %s",
+	"A %s is in progress; finish or abort it before switching if it needs the remote": "Sedang ada %s; selesaikan atau batalkan sebelum berpindah jika memerlukan remote",
+	"Update %d branch upstreams to the new origin?":                                   "Perbarui upstream %d branch ke origin baru?",
+	"Updated upstream for %d branches":                                                "Upstream %d branch diperbarui",
+	"Account with name '%s' already exists":                                           "Akun dengan nama '%s' sudah ada",
+	"SSH key is already used by account '%s'":                                         "Kunci SSH sudah dipakai oleh akun '%s'",
+	"%s username": "Username %s",
+	"Token username '%s' is already used by account '%s' on %s": "Username token '%s' sudah dipakai oleh akun '%s' di %s",
+	"Token: %s": "Token: %s",
+	"Email '%s' is already used by account '%s' on %s":                   "Email '%s' sudah dipakai oleh akun '%s' di %s",
+	"Failed to add account: %v":                                          "Gagal menambahkan akun: %v",
+	"Account '%s' added successfully":                                    "Akun '%s' berhasil ditambahkan",
+	"Scanning host keys for %s...":                                       "Memindai host key untuk %s...",
+	"Could not scan host keys: %v":                                       "Tidak dapat memindai host key: %v",
+	"Host keys for %s:":                                                  "Host key untuk %s:",
+	"Added %d host key(s) to %s":                                         "%d host key ditambahkan ke %s",
+	"Account '%s' updated":                                               "Akun '%s' diperbarui",
+	"Invalid port '%s', keeping current value":                           "Port '%s' tidak valid, tetap memakai nilai saat ini",
+	"Remove account '%s'?":                                               "Hapus akun '%s'?",
+	"Failed to remove account: %v":                                       "Gagal menghapus akun: %v",
+	"Account '%s' removed":                                               "Akun '%s' dihapus",
+	"No git identity configured":                                         "Belum ada identitas git yang diatur",
+	"No account token to copy":                                           "Tidak ada token akun untuk disalin",
+	"Not in a git repository":                                            "Bukan di dalam repository git",
+	"No matching account detected":                                       "Tidak ada akun yang cocok terdeteksi",
+	"No credential helper has a credential; git would prompt":            "Tidak ada credential helper yang menyimpan kredensial; git akan meminta login",
+	"IdentitiesOnly is off: keys loaded in ssh-agent are offered first":  "IdentitiesOnly mati: kunci di ssh-agent ditawarkan lebih dulu",
+	"No accounts configured":                                             "Belum ada akun yang diatur",
+	"Cancelled":                                                          "Dibatalkan",
+	"These branches have unpushed commits for the current remote:":       "Branch berikut memiliki commit yang belum di-push ke remote saat ini:",
+	"These branches track the current URL without going through origin:": "Branch berikut melacak URL saat ini tanpa melalui origin:",
+	"Switch anyway?":                                                     "Tetap berpindah?",
+	"Account label (e.g., work, personal)":                               "Label akun (mis. kantor, pribadi)",
+	"Account name is required":                                           "Nama akun wajib diisi",
+	"Git user.name (optional)":                                           "Git user.name (opsional)",
+	"Git user.email (optional)":                                          "Git user.email (opsional)",
+	"Clone directory, e.g. ~/src/work (optional)":                        "Direktori clone, mis. ~/src/kantor (opsional)",
+	"Notes (optional)":                                                   "Catatan (opsional)",
+	"Custom domain (e.g., git.company.com)":                              "Domain kustom (mis. git.perusahaan.com)",
+	"SSH port (blank for 22)":                                            "Port SSH (kosongkan untuk 22)",
+	"Existing SSH keys found. Select one or enter a new path:":           "Ditemukan kunci SSH. Pilih salah satu atau masukkan path baru:",
+	"Continue anyway?":                                                   "Tetap lanjutkan?",
+	"SSH key path":                                                       "Path kunci SSH",
+	"SSH host alias":                                                     "Alias host SSH",
+	"Configure advanced SSH options (port, jump host, proxy)?":           "Atur opsi SSH lanjutan (port, jump host, proxy)?",
+	"Personal Access Token":                                              "Personal Access Token",
+	"Is this the right token?":                                           "Apakah token ini benar?",
+	"Compare these with the fingerprints your server administrator publishes": "Bandingkan dengan fingerprint yang dipublikasikan administrator server Anda",
+	"Trust these keys and add them to known_hosts?":                           "Percayai kunci ini dan tambahkan ke known_hosts?",
+	"Host keys not added; the first SSH connection will ask to verify them":   "Host key tidak ditambahkan; koneksi SSH pertama akan meminta verifikasi",
+	"No accounts to edit":                 "Tidak ada akun untuk diubah",
+	"Account label":                       "Label akun",
+	"Git user.name":                       "Git user.name",
+	"Git user.email":                      "Git user.email",
+	"Clone directory (\"none\" to clear)": "Direktori clone (\"none\" untuk mengosongkan)",
+	"Notes (\"none\" to clear)":           "Catatan (\"none\" untuk mengosongkan)",
+	"Edit advanced SSH options (port, jump host, proxy)?": "Ubah opsi SSH lanjutan (port, jump host, proxy)?",
+	"ProxyJump host (e.g. user@bastion:22)":               "Host ProxyJump (mis. user@bastion:22)",
+	"ProxyCommand (e.g. nc -X 5 -x proxy:1080 %h %p)":     "ProxyCommand (mis. nc -X 5 -x proxy:1080 %h %p)",
+	"No accounts to remove":                               "Tidak ada akun untuk dihapus",
+	"List all configured accounts":                        "Tampilkan semua akun",
+	"Mark or unmark an account as a favorite":             "Tandai atau hapus tanda favorit pada akun",
+	"Set how accounts are ordered in selectors":           "Atur urutan akun di pemilih",
+	"Set the language of ghex messages":                   "Atur bahasa pesan ghex",
+	"Turn screen-reader friendly output on or off":        "Nyalakan atau matikan tampilan ramah pembaca layar",
+	"Show current repository status":                      "Tampilkan status repository saat ini",
+	"Switch to a specific account":                        "Berpindah ke akun tertentu",
+	"Add a new account":                                   "Tambah akun baru",
+	"Remove an account":                                   "Hapus akun",
+	"Edit an account":                                     "Ubah akun",
+	"Show the git identity and account in use":            "Tampilkan identitas git dan akun yang dipakai",
+	"Skip":                                          "Lewati",
+	"Leave the repository unpinned":                 "Biarkan repository tanpa akun tersemat",
+	"Self-hosted Gitea":                             "Gitea self-hosted",
+	"Other Git platform":                            "Platform Git lain",
+	"🔑 SSH only":                                    "🔑 Hanya SSH",
+	"Use SSH key authentication":                    "Gunakan autentikasi kunci SSH",
+	"🔐 Token only":                                  "🔐 Hanya token",
+	"Use Personal Access Token":                     "Gunakan Personal Access Token",
+	"🔑🔐 Both":                                       "🔑🔐 Keduanya",
+	"Configure both SSH and Token":                  "Atur SSH dan token",
+	"📝 Enter custom path":                           "📝 Masukkan path sendiri",
+	"Type a new SSH key path":                       "Ketik path kunci SSH baru",
+	"Which account does this repository belong to?": "Repository ini milik akun yang mana?",
+	"Select SSH Key":                                "Pilih Kunci SSH",
+	"Select Platform":                               "Pilih Platform",
+	"Select Authentication Method":                  "Pilih Metode Autentikasi",
+	"Select Account to Remove":                      "Pilih Akun untuk Dihapus",
+	"Select Account to Edit":                        "Pilih Akun untuk Diubah",
+	"Select Account (↑/k ↓/j to navigate, enter/l to select)": "Pilih Akun (↑/k ↓/j untuk bergerak, enter/l untuk memilih)",
+	"Add Account": "Tambah Akun",
+	"'%s' is not an email of account '%s' (%s)": "'%s' bukan email akun '%s' (%s)",
+	"in use":                           "sedang dipakai",
+	"default":                          "bawaan",
+	"private noreply":                  "noreply privat",
+	"Commit email for this repository": "Email commit untuk repository ini",
+	"Could not check whether %s is verified: %v":                                     "Tidak dapat memeriksa apakah %s terverifikasi: %v",
+	"%s is not an email of %s on %s; commits with it won't be linked to the account": "%s bukan email %s di %s; commit dengannya tidak akan terhubung ke akun",
+	"%s is not verified on %s; commits with it show as unverified":                   "%s belum terverifikasi di %s; commit dengannya tampil sebagai tidak terverifikasi",
+	"Use it anyway?": "Tetap gunakan?",
+	"Other emails, comma-separated (\"none\" to clear)":                                         "Email lain, pisahkan dengan koma (\"none\" untuk mengosongkan)",
+	"%s keeps its email addresses private, but commits here use %s":                             "%s merahasiakan alamat emailnya, tetapi commit di sini memakai %s",
+	"If the account blocks command line pushes that expose your email, GitHub will reject them": "Jika akun memblokir push dari command line yang membuka email Anda, GitHub akan menolaknya",
+	"Could not look up the noreply address: %v":                                                 "Tidak dapat mencari alamat noreply: %v",
+	"Commit with %s in this repository?":                                                        "Commit dengan %s di repository ini?",
+	"Failed to set user.email: %v":                                                              "Gagal mengatur user.email: %v",
+	"user.email set to %s":                                                                      "user.email diatur ke %s",
+
+	// alias.go
+	"Manage command aliases":    "Kelola alias perintah",
+	"Create or change an alias": "Buat atau ubah alias",
+	"List aliases":              "Tampilkan daftar alias",
+	"Remove an alias":           "Hapus alias",
+	"No aliases defined. Add one with: ghex alias set <name> <command>": "Belum ada alias. Tambahkan dengan: ghex alias set <nama> <perintah>",
+	"Aliases": "Alias",
+	"'%s' is a ghex command and cannot be used as an alias": "'%s' adalah perintah ghex dan tidak dapat digunakan sebagai alias",
+	"Invalid alias: %v": "Alias tidak valid: %v",
+	"'%s' is another alias; aliases are not expanded recursively": "'%s' adalah alias lain; alias tidak diperluas secara rekursif",
+	"Alias set: ghex %s → ghex %s":                                "Alias disetel: ghex %s → ghex %s",
+	"Alias '%s' not found":                                        "Alias '%s' tidak ditemukan",
+	"Alias '%s' removed":                                          "Alias '%s' dihapus",
+
+	// backup.go
+	"Back up the repositories of accounts":                                                  "Cadangkan repositori akun",
+	"Mirror the repositories of accounts":                                                   "Cerminkan repositori akun",
+	"No accounts selected":                                                                  "Tidak ada akun yang dipilih",
+	"Select accounts to back up (space to toggle, enter to confirm)":                        "Pilih akun yang akan dicadangkan (spasi untuk memilih, enter untuk konfirmasi)",
+	"No user or organization to back up; add a token username to the account or pass --org": "Tidak ada pengguna atau organisasi untuk dicadangkan; tambahkan username token ke akun atau gunakan --org",
+	"Mirrored %s":                         "%s dicerminkan",
+	"Failed to prune snapshots of %s: %v": "Gagal memangkas snapshot %s: %v",
+	"Wrote %d snapshots":                  "%d snapshot ditulis",
+	"Backup Summary":                      "Ringkasan Cadangan",
+	"%d new, %d updated, %d failed":       "%d baru, %d diperbarui, %d gagal",
+	", %d snapshots written, %d pruned":   ", %d snapshot ditulis, %d dipangkas",
+	"Directory":                           "Direktori",
+	"Duration":                            "Durasi",
+
+	// clone.go
+	"Cloning: %s":                  "Meng-clone: %s",
+	"Invalid URL: %v":              "URL tidak valid: %v",
+	"%s already exists":            "%s sudah ada",
+	"Failed to create %s: %v":      "Gagal membuat %s: %v",
+	"Clone failed: %v":             "Clone gagal: %v",
+	"Cloned to: %s":                "Di-clone ke: %s",
+	"Failed to set up account: %v": "Gagal menyiapkan akun: %v",
+	"Account '%s' configured":      "Akun '%s' sudah diatur",
+	"Repository: %s/%s":            "Repository: %s/%s",
+	"Enter choice":                 "Masukkan pilihan",
+	"Cloning repository...":        "Meng-clone repository...",
+	"Clone a repository and set up an account for it": "Clone repository dan atur akunnya",
+
+	// cleanup.go
+	"Remove stale credentials, SSH host blocks and update backups": "Hapus kredensial, blok host SSH, dan cadangan update yang usang",
+	"Nothing to clean up":                "Tidak ada yang perlu dibersihkan",
+	"Stored credentials":                 "Kredensial tersimpan",
+	"SSH host blocks":                    "Blok host SSH",
+	"Update backups":                     "Cadangan update",
+	"Dry run: %d items would be removed": "Dry run: %d item akan dihapus",
+	"Remove these %d items?":             "Hapus %d item ini?",
+	"Removed %d items":                   "%d item dihapus",
+
+	// config.go
+	"Work with the config file":                     "Kelola file konfigurasi",
+	"Edit the config file, validated before saving": "Ubah file konfigurasi, divalidasi sebelum disimpan",
+	"No changes":                  "Tidak ada perubahan",
+	"Config saved: %s":            "Konfigurasi disimpan: %s",
+	"The config has %d problems:": "Konfigurasi memiliki %d masalah:",
+	"Edit again?":                 "Ubah lagi?",
+	"Changes discarded, the config file is unchanged": "Perubahan dibuang, file konfigurasi tidak berubah",
+	"Print a config value as JSON":                    "Tampilkan nilai konfigurasi sebagai JSON",
+	"Set a config value":                              "Atur nilai konfigurasi",
+	"Remove a config value":                           "Hapus nilai konfigurasi",
+	"Set %s":                                          "%s diatur",
+	"Unset %s":                                        "%s dihapus",
+	"%s is not set":                                   "%s tidak diatur",
+
+	// dlx.go
+	"Downloading file from GitHub: %s":                              "Mengunduh file dari GitHub: %s",
+	"Downloading directory from GitHub: %s":                         "Mengunduh direktori dari GitHub: %s",
+	"Downloading from repository: %s":                               "Mengunduh dari repositori: %s",
+	"Downloading directory from GitLab: %s":                         "Mengunduh direktori dari GitLab: %s",
+	"Downloading from GitHub: %s":                                   "Mengunduh dari GitHub: %s",
+	"No file at that path, trying it as a directory...":             "Tidak ada file di path itu, mencoba sebagai direktori...",
+	"Invalid choice":                                                "Pilihan tidak valid",
+	"URL on clipboard: %s":                                          "URL di clipboard: %s",
+	"No unfinished downloads to continue":                           "Tidak ada unduhan yang belum selesai untuk dilanjutkan",
+	"%d of %d files left":                                           "%d dari %d file tersisa",
+	"Continue which download?":                                      "Lanjutkan unduhan yang mana?",
+	"URL is required":                                               "URL wajib diisi",
+	"File path is required":                                         "Path file wajib diisi",
+	"Universal file downloader":                                     "Pengunduh file universal",
+	"Download a single file from Git repository":                    "Unduh satu file dari repository Git",
+	"Download a directory from Git repository":                      "Unduh direktori dari repository Git",
+	"Download a repository, picking top-level entries":              "Unduh repository, dengan memilih isi tingkat atas",
+	"Mirror a repository directory, downloading only changed files": "Cerminkan direktori repository, hanya mengunduh file yang berubah",
+	"Download release assets from GitHub, GitLab or Gitea":          "Unduh aset rilis dari GitHub, GitLab atau Gitea",
+	"Download files from a URL list file":                           "Unduh file dari daftar URL",
+	"Download (dlx)":                                                "Unduh (dlx)",
+	"TLS certificate verification is off (--insecure)":              "Verifikasi sertifikat TLS dimatikan (--insecure)",
+
+	// doctor.go
+	"%s is installed":                            "%s terpasang",
+	"%s is not installed or not in PATH":         "%s tidak terpasang atau tidak ada di PATH",
+	"Config cannot be loaded: %v":                "Konfigurasi tidak dapat dimuat: %v",
+	"%d problem(s) found":                        "Ditemukan %d masalah",
+	"%s: %s asked before store":                  "%s: %s ditanya sebelum store",
+	"Failed to reorder credential helpers: %v":   "Gagal mengurutkan ulang credential helper: %v",
+	"Store helper now asked first (%s config)":   "Helper store sekarang ditanya lebih dulu (konfigurasi %s)",
+	"Config loads":                               "Konfigurasi dapat dimuat",
+	"No problems found":                          "Tidak ada masalah",
+	"Credential helpers do not conflict":         "Credential helper tidak bentrok",
+	"Diagnose git and credential setup problems": "Diagnosis masalah pengaturan git dan kredensial",
+	"Doctor": "Diagnosis",
+
+	// generate.go
+	"Generate git and SSH configuration for dotfiles":      "Buat konfigurasi git dan SSH untuk dotfiles",
+	"Generate includeIf gitconfig files for every account": "Buat berkas gitconfig includeIf untuk setiap akun",
+	"Generate SSH Host blocks for every account":           "Buat blok Host SSH untuk setiap akun",
+	"Wrote %d files to %s":                                 "%d berkas ditulis ke %s",
+	"Add this to ~/.gitconfig:":                            "Tambahkan ini ke ~/.gitconfig:",
+	"Host blocks written to %s":                            "Blok Host ditulis ke %s",
+
+	// gh.go
+	"gh %s, as the repository's account":                        "gh %s, sebagai akun repository",
+	"GitHub CLI (gh) not found":                                 "GitHub CLI (gh) tidak ditemukan",
+	"Install it from https://cli.github.com":                    "Pasang dari https://cli.github.com",
+	"Running gh %s as %s":                                       "Menjalankan gh %s sebagai %s",
+	"Run 'ghex switch' to pick the account for this repository": "Jalankan 'ghex switch' untuk memilih akun repository ini",
+	"Account '%s' needs a GitHub token to run gh":               "Akun '%s' memerlukan token GitHub untuk menjalankan gh",
+	"Add one with 'ghex edit'":                                  "Tambahkan dengan 'ghex edit'",
+
+	// git_shortcuts.go
+	"Failed: %v":                                            "Gagal: %v",
+	"Git user.name set to: %s":                              "Git user.name diatur ke: %s",
+	"Git user.email set to: %s":                             "Git user.email diatur ke: %s",
+	"Failed to add files: %v":                               "Gagal menambahkan file: %v",
+	"Committing: %s":                                        "Membuat commit: %s",
+	"Failed to commit: %v":                                  "Gagal membuat commit: %v",
+	"Failed to push: %v":                                    "Gagal push: %v",
+	"git %s cancelled":                                      "git %s dibatalkan",
+	"This repository is pinned to account '%s'":             "Repository ini disematkan ke akun '%s'",
+	"Fetching from origin...":                               "Mengambil dari origin...",
+	"Fetch completed":                                       "Fetch selesai",
+	"Pulling from remote...":                                "Menarik dari remote...",
+	"Pull completed":                                        "Pull selesai",
+	"Pulling with rebase...":                                "Menarik dengan rebase...",
+	"Commit message is required":                            "Pesan commit wajib diisi",
+	"Adding files...":                                       "Menambahkan file...",
+	"Files added":                                           "File ditambahkan",
+	"Committed successfully":                                "Commit berhasil",
+	"Push to origin?":                                       "Push ke origin?",
+	"Push cancelled":                                        "Push dibatalkan",
+	"Pushing to origin...":                                  "Push ke origin...",
+	"Pushed successfully":                                   "Push berhasil",
+	"git checkout -b (create new branch)":                   "git checkout -b (buat branch baru)",
+	"git add, commit, and push":                             "git add, commit, lalu push",
+	"git add, commit, and push (no confirm)":                "git add, commit, lalu push (tanpa konfirmasi)",
+	"Restore the pinned account's identity and credentials": "Pulihkan identitas dan kredensial akun yang disematkan",
+	"➡️  Continue anyway":                                   "➡️  Tetap lanjutkan",
+	"Run git with the current identity":                     "Jalankan git dengan identitas saat ini",
+	"❌ Abort":                                               "❌ Batalkan",
+	"Do nothing":                                            "Jangan lakukan apa pun",
+	"Identity mismatch":                                     "Identitas tidak cocok",
+	"GitHub rejected the push: its commits use an email the account keeps private":                 "GitHub menolak push: commit-nya memakai email yang dirahasiakan akun",
+	"Rewrite the rejected commits with the new email before pushing again, e.g. for the last one:": "Tulis ulang commit yang ditolak dengan email baru sebelum push lagi, misalnya untuk yang terakhir:",
+
+	// health.go
+	"Health check":                 "Pemeriksaan kesehatan",
+	"  Testing SSH with %s...":     "  Menguji SSH dengan %s...",
+	"  Token: %s":                  "  Token: %s",
+	"  Testing Token...":           "  Menguji token...",
+	"No activity logged yet":       "Belum ada aktivitas tercatat",
+	"Check health of all accounts": "Periksa kesehatan semua akun",
+	"Show activity log":            "Tampilkan log aktivitas",
+	"Activity Log":                 "Log Aktivitas",
+	"Health Check":                 "Cek Kesehatan",
+	"%s can be accessed by other users (mode %04o)":                                     "%s dapat diakses pengguna lain (mode %04o)",
+	"Config restricted to your user":                                                    "Konfigurasi dibatasi untuk pengguna Anda",
+	"  Email: %s is kept private on GitHub, pushes of commits using it may be rejected": "  Email: %s dirahasiakan di GitHub, push commit yang memakainya bisa ditolak",
+	"Commit with %s instead: ghex config set accounts[%s].gitEmail %s":                  "Commit dengan %s sebagai gantinya: ghex config set accounts[%s].gitEmail %s",
+
+	// helpers.go
+	"%s skipped (offline)":                "%s dilewati (offline)",
+	"SSH connection test":                 "Uji koneksi SSH",
+	"Token test":                          "Uji token",
+	"Copied %s to the clipboard":          "%s disalin ke clipboard",
+	"Could not fix permissions of %s: %v": "Tidak dapat memperbaiki izin %s: %v",
+	"Fixed permissions of %s":             "Izin %s diperbaiki",
+	"Could not copy %s: %v":               "Tidak dapat menyalin %s: %v",
+	"1. Copy your public key: %s.pub":     "1. Salin public key Anda: %s.pub",
+	"1. Copy your public key (or run: ghex ssh export -a %s --clipboard):": "1. Salin public key Anda (atau jalankan: ghex ssh export -a %s --clipboard):",
+	"SSH key not found: %s":                         "Kunci SSH tidak ditemukan: %s",
+	"🔑 Using key: %s":                               "🔑 Memakai kunci: %s",
+	"🌐 Host: %s %s (%s)":                            "🌐 Host: %s %s (%s)",
+	"Authenticated successfully to %s":              "Berhasil login ke %s",
+	"Make sure your SSH key is added to %s:":        "Pastikan kunci SSH Anda sudah ditambahkan ke %s:",
+	"2. Add it at: %s":                              "2. Tambahkan di: %s",
+	"Successfully authenticated as %s":              "Berhasil login sebagai %s",
+	"\nCreate a new token at: %s":                   "\nBuat token baru di: %s",
+	"🌐 Host: %s":                                    "🌐 Host: %s",
+	"1. Copy your public key:":                      "1. Salin public key Anda:",
+	"Account has no SSH configuration":              "Akun tidak memiliki konfigurasi SSH",
+	"Testing SSH connection...":                     "Menguji koneksi SSH...",
+	"✓ SSH connection test passed!":                 "✓ Uji koneksi SSH berhasil!",
+	"✗ SSH connection test failed!":                 "✗ Uji koneksi SSH gagal!",
+	"Account has no token configuration":            "Akun tidak memiliki konfigurasi token",
+	"Testing token authentication...":               "Menguji autentikasi token...",
+	"✓ Token authentication test passed!":           "✓ Uji autentikasi token berhasil!",
+	"✗ Token authentication failed!":                "✗ Autentikasi token gagal!",
+	"Please check:":                                 "Silakan periksa:",
+	"• Token has not expired":                       "• Token belum kedaluwarsa",
+	"• Token has correct permissions (repo access)": "• Token memiliki izin yang benar (akses repo)",
+	"• Username is correct":                         "• Username sudah benar",
+	"2. Add it to your Git service settings":        "2. Tambahkan di pengaturan layanan Git Anda",
+
+	// inbox.go
+	"Inbox": "Kotak masuk",
+	"Show GitHub notifications and assigned pull requests": "Tampilkan notifikasi GitHub dan pull request yang ditugaskan",
+	"Loading inbox of %s...":                               "Memuat kotak masuk %s...",
+	"%d unread notifications, %d assigned pull requests":   "%d notifikasi belum dibaca, %d pull request ditugaskan",
+	"Inbox zero":      "Kotak masuk kosong",
+	"❌ Exit":          "❌ Keluar",
+	"Close the inbox": "Tutup kotak masuk",
+	"Inbox of %s (↑/k ↓/j to navigate, enter/l to select)": "Kotak masuk %s (↑/k ↓/j untuk navigasi, enter/l untuk memilih)",
+	"Account '%s' needs a GitHub token for the inbox":      "Akun '%s' memerlukan token GitHub untuk kotak masuk",
+	"No GitHub account with a token configured":            "Tidak ada akun GitHub dengan token yang dikonfigurasi",
+	"🌐 Open in browser":                                    "🌐 Buka di browser",
+	"🌐 Open and mark as read":                              "🌐 Buka dan tandai sudah dibaca",
+	"✓ Mark as read":                                       "✓ Tandai sudah dibaca",
+	"← Back":                                               "← Kembali",
+	"Marked as read":                                       "Ditandai sudah dibaca",
+	"assigned pull request • %s":                           "pull request ditugaskan • %s",
+	"just now":                                             "baru saja",
+	"%dm ago":                                              "%d menit lalu",
+	"%dh ago":                                              "%d jam lalu",
+	"%dd ago":                                              "%d hari lalu",
+
+	// install_self.go
+	"Install this ghex binary for the current user":                      "Pasang biner ghex ini untuk pengguna saat ini",
+	"Failed to install ghex: %v":                                         "Gagal memasang ghex: %v",
+	"Installed ghex to %s":                                               "ghex dipasang di %s",
+	"ghex is already installed at %s":                                    "ghex sudah terpasang di %s",
+	"Added %s to your PATH (open a new terminal to use it)":              "%s ditambahkan ke PATH (buka terminal baru untuk menggunakannya)",
+	"For PowerShell completion, add this line to your $PROFILE:":         "Untuk completion PowerShell, tambahkan baris ini ke $PROFILE Anda:",
+	"Could not set up the shell: %v":                                     "Tidak dapat menyiapkan shell: %v",
+	"Updated %s":                                                         "%s diperbarui",
+	"Restart your shell or run: source %s":                               "Mulai ulang shell Anda atau jalankan: source %s",
+	"%s is not in your PATH. Re-run with --add-path or add it yourself.": "%s tidak ada di PATH Anda. Jalankan ulang dengan --add-path atau tambahkan sendiri.",
+
+	// interactive.go
+	"Command failed: %v":                                   "Perintah gagal: %v",
+	"Thank you for using GHEX! 👋":                          "Terima kasih telah memakai GHEX! 👋",
+	"Press Enter to continue...":                           "Tekan Enter untuk melanjutkan...",
+	"🔄 Switch account":                                     "🔄 Pindah akun",
+	"Switch account for current repository":                "Pindah akun untuk repository saat ini",
+	"📋 List accounts":                                      "📋 Daftar akun",
+	"Show all configured accounts":                         "Tampilkan semua akun",
+	"➕ Add account":                                        "➕ Tambah akun",
+	"Add a new GitHub account":                             "Tambah akun GitHub baru",
+	"✏️  Edit account":                                     "✏️  Ubah akun",
+	"Modify an existing account":                           "Ubah akun yang ada",
+	"🗑️  Remove account":                                   "🗑️  Hapus akun",
+	"Delete an account":                                    "Hapus sebuah akun",
+	"🔑 SSH Management":                                     "🔑 Manajemen SSH",
+	"Generate, import, or manage SSH keys":                 "Buat, impor, atau kelola kunci SSH",
+	"🌐 Switch SSH globally":                                "🌐 Pindah SSH secara global",
+	"Change global SSH configuration":                      "Ubah konfigurasi SSH global",
+	"📥 Download (dlx)":                                     "📥 Unduh (dlx)",
+	"Download files from URLs or Git repos":                "Unduh file dari URL atau repo Git",
+	"🧪 Test connection":                                    "🧪 Uji koneksi",
+	"Test SSH/Token authentication":                        "Uji autentikasi SSH/token",
+	"🏥 Health check":                                       "🏥 Cek kesehatan",
+	"Check all account connections":                        "Periksa koneksi semua akun",
+	"📜 Activity log":                                       "📜 Log aktivitas",
+	"View recent activity":                                 "Lihat aktivitas terbaru",
+	"🚪 Exit":                                               "🚪 Keluar",
+	"Quit GHEX":                                            "Keluar dari GHEX",
+	"Repository Context":                                   "Konteks Repository",
+	"Main Menu (↑/k ↓/j navigate, enter/l select, q quit)": "Menu Utama (↑/k ↓/j bergerak, enter/l pilih, q keluar)",
+
+	// redo.go
+	"Repeat a recent command":                        "Ulangi perintah terakhir",
+	"Invalid number: %s":                             "Nomor tidak valid: %s",
+	"No recent commands":                             "Belum ada perintah terakhir",
+	"Recent Commands":                                "Perintah Terakhir",
+	"No recent command #%d (see 'ghex redo --list')": "Tidak ada perintah terakhir #%d (lihat 'ghex redo --list')",
+	"Running: %s":                                    "Menjalankan: %s",
+	"Recent action":                                  "Aksi terakhir",
+
+	// report.go
+	"Export an inventory of accounts, SSH keys and tokens": "Ekspor inventaris akun, kunci SSH, dan token",
+	"Collecting the inventory of %d accounts...":           "Mengumpulkan inventaris %d akun...",
+	"Failed to write %s: %v":                               "Gagal menulis %s: %v",
+	"Report written to %s":                                 "Laporan ditulis ke %s",
+
+	// root.go
+	"Beautiful GitHub Account Switcher & Universal Downloader":                                     "Pengganti akun GitHub & pengunduh universal yang cantik",
+	"The config holds tokens but other users can read it; run 'ghex health' to fix":                "Konfigurasi berisi token tetapi dapat dibaca pengguna lain; jalankan 'ghex health' untuk memperbaikinya",
+	"Input was needed but --non-interactive is set; pass the missing values as arguments or flags": "Masukan diperlukan tetapi --non-interactive aktif; berikan nilai yang kurang sebagai argumen atau flag",
+
+	// ssh.go
+	"Add it at: %s":                                         "Tambahkan di: %s",
+	"Account '%s' has no SSH configuration":                 "Akun '%s' tidak memiliki konfigurasi SSH",
+	"Failed to generate key: %v":                            "Gagal membuat kunci: %v",
+	"Generated SSH key: %s":                                 "Kunci SSH dibuat: %s",
+	"Public key: %s.pub":                                    "Public key: %s.pub",
+	"Copy it with: ghex ssh export -a %s --clipboard":       "Salin dengan: ghex ssh export -a %s --clipboard",
+	"Failed to import key: %v":                              "Gagal mengimpor kunci: %v",
+	"Failed to configure SSH: %v":                           "Gagal mengatur SSH: %v",
+	"Set as default Host %s":                                "Diatur sebagai Host bawaan %s",
+	"Imported SSH key: %s":                                  "Kunci SSH diimpor: %s",
+	"Public key: %s":                                        "Public key: %s",
+	"Fixed permissions for %d SSH key(s)":                   "Izin %d kunci SSH diperbaiki",
+	"Some keys could not be fixed: %v":                      "Beberapa kunci tidak dapat diperbaiki: %v",
+	"All SSH keys already have private permissions":         "Semua kunci SSH sudah memiliki izin privat",
+	"%s does not include %s yet. Add this line at the top:": "%s belum menyertakan %s. Tambahkan baris ini di bagian atas:",
+	"SSH config mode set to: %s":                            "Mode konfigurasi SSH diatur ke: %s",
+	"Add this line at the top of %s:":                       "Tambahkan baris ini di bagian atas %s:",
+	"Testing with key: %s":                                  "Menguji dengan kunci: %s",
+	"Testing SSH connection to %s...":                       "Menguji koneksi SSH ke %s...",
+	"Set global SSH to: %s":                                 "SSH global diatur ke: %s",
+	"SSH key not found at %s. Generate now?":                "Kunci SSH tidak ditemukan di %s. Buat sekarang?",
+	"Updated %s → Host %s %s (%s) using: %s":                "%s diperbarui → Host %s %s (%s) memakai: %s",
+	"Testing SSH connection to %s (%s)...":                  "Menguji koneksi SSH ke %s (%s)...",
+	"Failed to list SSH keys: %v":                           "Gagal menampilkan kunci SSH: %v",
+	"Total: %d keys":                                        "Total: %d kunci",
+	"passphrase":                                            "frasa sandi",
+	"Other files in ~/.ssh, not used as keys:":              "Berkas lain di ~/.ssh, tidak dipakai sebagai kunci:",
+	"PuTTY key":                        "kunci PuTTY",
+	"public key":                       "kunci publik",
+	"certificate":                      "sertifikat",
+	"not a key":                        "bukan kunci",
+	"no account":                       "tanpa akun",
+	"(Host %s)":                        "(Host %s)",
+	"Keys not used by any account: %d": "Kunci yang tidak dipakai akun mana pun: %d",
+	"Accounts whose SSH key file is missing:":                "Akun yang berkas kunci SSH-nya tidak ada:",
+	"No accounts with SSH configured":                        "Tidak ada akun dengan SSH",
+	"No accounts configured. Add an account first.":          "Belum ada akun. Tambahkan akun terlebih dahulu.",
+	"Generating SSH key...":                                  "Membuat kunci SSH...",
+	"Source private key path":                                "Path private key sumber",
+	"Source path is required":                                "Path sumber wajib diisi",
+	"Destination filename":                                   "Nama file tujuan",
+	"No SSH configured":                                      "SSH belum dikonfigurasi",
+	"Every private key in %s is used by an account":          "Semua private key di %s sudah dipakai akun",
+	"Found %d keys in %s not used by any account":            "Ditemukan %d kunci di %s yang tidak dipakai akun mana pun",
+	"Comment: %s":                                            "Komentar: %s",
+	"Used by Host: %s":                                       "Dipakai oleh Host: %s",
+	"%s already uses %s. Replace it?":                        "%s sudah memakai %s. Ganti?",
+	"Failed to generate public key: %v":                      "Gagal membuat public key: %v",
+	"%s → %s (Host %s)":                                      "%s → %s (Host %s)",
+	"No keys imported":                                       "Tidak ada kunci yang diimpor",
+	"Imported %d keys":                                       "%d kunci diimpor",
+	"Test them with: ghex ssh test":                          "Uji dengan: ghex ssh test",
+	"Proposed: %s":                                           "Usulan: %s",
+	"comment matches email":                                  "komentar cocok dengan email",
+	"comment matches username":                               "komentar cocok dengan username",
+	"filename matches account":                               "nama file cocok dengan akun",
+	"Skip this key":                                          "Lewati kunci ini",
+	"Leave it unchanged":                                     "Biarkan apa adanya",
+	"Account for %s":                                         "Akun untuk %s",
+	"Change the comment of an SSH key":                       "Ubah komentar kunci SSH",
+	"New comment":                                            "Komentar baru",
+	"Comment of %s: %s → %s":                                 "Komentar %s: %s → %s",
+	"Comment of %s: %s":                                      "Komentar %s: %s",
+	"ssh-keygen not found: only %s.pub was changed":          "ssh-keygen tidak ditemukan: hanya %s.pub yang diubah",
+	"Show the machines holding each account's SSH keys":      "Tampilkan mesin yang menyimpan kunci SSH tiap akun",
+	"Record this machine for the accounts whose key is here": "Catat mesin ini untuk akun yang kuncinya ada di sini",
+	"Forget a device of an account":                          "Lupakan perangkat sebuah akun",
+	"No account with SSH named %s":                           "Tidak ada akun dengan SSH bernama %s",
+	"SSH Devices":                                            "Perangkat SSH",
+	"no devices recorded":                                    "belum ada perangkat tercatat",
+	"(this device)":                                          "(perangkat ini)",
+	"added %s":                                               "ditambahkan %s",
+	"Keys on this machine (%s) that aren't recorded yet can be added with: ghex ssh devices add": "Kunci di mesin ini (%s) yang belum tercatat bisa ditambahkan dengan: ghex ssh devices add",
+	"Could not read this machine's hostname":                                                     "Tidak bisa membaca hostname mesin ini",
+	"Key %s of %s is not on this machine":                                                        "Kunci %s milik %s tidak ada di mesin ini",
+	"Recorded %s for %s (%s)":                                                                    "%s dicatat untuk %s (%s)",
+	"%s has no device named %s":                                                                  "%s tidak punya perangkat bernama %s",
+	"Removed device %s from %s":                                                                  "Perangkat %s dihapus dari %s",
+	"Revoke the key with fingerprint %s at: %s":                                                  "Cabut kunci dengan fingerprint %s di: %s",
+	"Revoke its key at: %s":                                                                      "Cabut kuncinya di: %s",
+	"The key file %s on this machine was left in place":                                          "Berkas kunci %s di mesin ini tidak dihapus",
+	"Install tools from their release binaries":                                                  "Pasang tool dari binary rilisnya",
+	"Installs": "Pemasangan",
+	"%s is not in your PATH; add it to run the installed tools": "%s tidak ada di PATH; tambahkan agar tool yang terpasang bisa dijalankan",
+	"List installed tools": "Tampilkan tool yang terpasang",
+	"No tools installed yet (use 'ghex install owner/repo')": "Belum ada tool yang terpasang (gunakan 'ghex install owner/repo')",
+	"Installed Tools":        "Tool Terpasang",
+	"(missing)":              "(hilang)",
+	"Remove installed tools": "Hapus tool yang terpasang",
+	"Removed %s (%s)":        "%s dihapus (%s)",
+	"Check that a repository uses the expected account":                           "Periksa bahwa repositori memakai akun yang diharapkan",
+	"%s is not a git repository":                                                  "%s bukan repositori git",
+	"No account expected: pass --expect or pin the repository with 'ghex switch'": "Tidak ada akun yang diharapkan: berikan --expect atau sematkan repositori dengan 'ghex switch'",
+	"Verify":                                               "Verifikasi",
+	"Repository uses account '%s'":                         "Repositori memakai akun '%s'",
+	"Repository doesn't match account '%s'":                "Repositori tidak sesuai dengan akun '%s'",
+	"Run 'ghex switch %s' to fix it":                       "Jalankan 'ghex switch %s' untuk memperbaikinya",
+	"Set as default SSH key for github.com?":               "Jadikan kunci SSH bawaan untuk github.com?",
+	"Test SSH connection now?":                             "Uji koneksi SSH sekarang?",
+	"Make sure your SSH key is added to your Git service:": "Pastikan kunci SSH Anda sudah ditambahkan ke layanan Git Anda:",
+	"No SSH keys found":                                    "Tidak ada kunci SSH",
+	"Testing SSH connection to github.com...":              "Menguji koneksi SSH ke github.com...",
+	"Make sure your SSH key is added to GitHub:":           "Pastikan kunci SSH Anda sudah ditambahkan ke GitHub:",
+	"2. Add it at: https://github.com/settings/keys":       "2. Tambahkan di: https://github.com/settings/keys",
+	"Aborted": "Dibatalkan",
+	"2. Add it at: https://gitlab.com/-/profile/keys":                "2. Tambahkan di: https://gitlab.com/-/profile/keys",
+	"2. Add it at: https://bitbucket.org/account/settings/ssh-keys/": "2. Tambahkan di: https://bitbucket.org/account/settings/ssh-keys/",
+	"2. Add it at: https://codeberg.org/user/settings/keys":          "2. Tambahkan di: https://codeberg.org/user/settings/keys",
+	"2. Add it at your Gitea instance: /user/settings/keys":          "2. Tambahkan di instance Gitea Anda: /user/settings/keys",
+	"No accounts or SSH keys found":                                  "Tidak ada akun atau kunci SSH",
+	"No accounts configured. Testing SSH keys directly...":           "Belum ada akun. Menguji kunci SSH secara langsung...",
+	"No SSH keys found in ~/.ssh":                                    "Tidak ada kunci SSH di ~/.ssh",
+	"Enter host":                                                     "Masukkan host",
+	"Switch SSH globally":                                            "Pindah SSH secara global",
+	"SSH key management":                                             "Manajemen kunci SSH",
+	"Generate a new SSH key":                                         "Buat kunci SSH baru",
+	"Import an existing SSH key":                                     "Impor kunci SSH yang ada",
+	"List SSH keys":                                                  "Daftar kunci SSH",
+	"Print or copy an account's public key":                          "Tampilkan atau salin public key akun",
+	"Fix permissions of every private key in ~/.ssh":                 "Perbaiki izin semua private key di ~/.ssh",
+	"Set how ghex applies changes to ~/.ssh/config":                  "Atur cara ghex menerapkan perubahan ke ~/.ssh/config",
+	"Export or import the SSH Host blocks ghex manages":              "Ekspor atau impor blok Host SSH yang dikelola ghex",
+	"Print the SSH Host blocks ghex manages":                         "Tampilkan blok Host SSH yang dikelola ghex",
+	"Apply exported SSH Host blocks":                                 "Terapkan blok Host SSH yang diekspor",
+	"No Host blocks managed by ghex in %s":                           "Tidak ada blok Host yang dikelola ghex di %s",
+	"Exported %d Host blocks to %s":                                  "%d blok Host diekspor ke %s",
+	"Failed to read %s: %v":                                          "Gagal membaca %s: %v",
+	"Skipped Host %s: not a block ghex writes":                       "Host %s dilewati: bukan blok yang ditulis ghex",
+	"Key %s does not exist on this machine yet":                      "Kunci %s belum ada di mesin ini",
+	"%d Host blocks would be imported":                               "%d blok Host akan diimpor",
+	"Imported %d Host blocks":                                        "%d blok Host diimpor",
+	"Replace the existing Host %s?":                                  "Ganti Host %s yang sudah ada?",
+	"Import Host %s, which runs a ProxyCommand?":                     "Impor Host %s, yang menjalankan ProxyCommand?",
+	"Skipped Host %s":                                                "Host %s dilewati",
+	"🔑 Generate SSH key":                                             "🔑 Buat kunci SSH",
+	"Create a new Ed25519 SSH key pair":                              "Buat pasangan kunci SSH Ed25519 baru",
+	"📥 Import SSH key":                                               "📥 Impor kunci SSH",
+	"Import an existing private key":                                 "Impor private key yang ada",
+	"Set default SSH key for github.com":                             "Atur kunci SSH bawaan untuk github.com",
+	"Test SSH authentication":                                        "Uji autentikasi SSH",
+	"📋 List SSH keys":                                                "📋 Daftar kunci SSH",
+	"Show all SSH keys in ~/.ssh":                                    "Tampilkan semua kunci SSH di ~/.ssh",
+	"📤 Export public key":                                            "📤 Ekspor public key",
+	"🔙 Back":                                                         "🔙 Kembali",
+	"Return to main menu":                                            "Kembali ke menu utama",
+	"🔑 Test SSH key directly":                                        "🔑 Uji kunci SSH langsung",
+	"Select any SSH key from ~/.ssh to test":                         "Pilih kunci SSH mana pun dari ~/.ssh untuk diuji",
+	"Test SSH key authentication":                                    "Uji autentikasi kunci SSH",
+	"Test Personal Access Token":                                     "Uji Personal Access Token",
+	"🔄 Both":                                                         "🔄 Keduanya",
+	"Test both methods":                                              "Uji kedua metode",
+	"🌐 Custom":                                                       "🌐 Kustom",
+	"Enter custom host":                                              "Masukkan host kustom",
+	"Test which authentication method?":                              "Uji metode autentikasi yang mana?",
+	"Select Source SSH Key":                                          "Pilih Kunci SSH Sumber",
+	"Select SSH Key to Test":                                         "Pilih Kunci SSH untuk Diuji",
+	"Select SSH Key for Global Use":                                  "Pilih Kunci SSH untuk Penggunaan Global",
+	"Select Host to Test":                                            "Pilih Host untuk Diuji",
+	"Select Account":                                                 "Pilih Akun",
+	"Select Account to Test":                                         "Pilih Akun untuk Diuji",
+	"Select Account for SSH Key Import":                              "Pilih Akun untuk Impor Kunci SSH",
+	"Select Account for SSH Key Generation":                          "Pilih Akun untuk Pembuatan Kunci SSH",
+	"Select Account for Global SSH":                                  "Pilih Akun untuk SSH Global",
+	"SSH Management":                                                 "Manajemen SSH",
+	"SSH Keys":                                                       "Kunci SSH",
+	"Test Connection":                                                "Uji Koneksi",
+
+	// tools.go
+	"Release checks":              "Pemeriksaan rilis",
+	"Upgrades":                    "Pembaruan alat",
+	"Checking %d tools...":        "Memeriksa %d tool...",
+	"All %d tools are up to date": "Semua %d tool sudah terbaru",
+	"Upgrading %s %s → %s":        "Memperbarui %s %s → %s",
+	"%s is now %s":                "%s sekarang %s",
+	"No tools installed with dlx yet (use 'ghex dlx release --install')": "Belum ada tool yang dipasang dengan dlx (gunakan 'ghex dlx release --install')",
+	"Run 'ghex upgrade --all' or 'ghex upgrade <tool>' to update":        "Jalankan 'ghex upgrade --all' atau 'ghex upgrade <tool>' untuk memperbarui",
+	"Everything is up to date":                                           "Semua sudah terbaru",
+	"Show tools installed with dlx that have newer releases":             "Tampilkan tool dari dlx yang punya rilis lebih baru",
+	"Upgrade tools installed with dlx":                                   "Perbarui tool yang dipasang dengan dlx",
+	"Outdated Tools":                                                     "Tool Usang",
+
+	// uninstall.go
+	"Dry run mode - no files will be removed":       "Mode uji coba - tidak ada file yang dihapus",
+	"Uninstallation cancelled":                      "Pencopotan dibatalkan",
+	"Binary removed":                                "Binary dihapus",
+	"Binary was not installed":                      "Binary tidak terpasang",
+	"Failed to remove binary":                       "Gagal menghapus binary",
+	"Configuration files removed":                   "File konfigurasi dihapus",
+	"Some configuration files could not be removed": "Beberapa file konfigurasi tidak dapat dihapus",
+	"Configuration files preserved":                 "File konfigurasi dipertahankan",
+	"PATH updated":                                  "PATH diperbarui",
+	"Some operations failed:":                       "Beberapa operasi gagal:",
+	"GHEX has been uninstalled!":                    "GHEX telah dicopot!",
+	"Uninstallation incomplete":                     "Pencopotan belum selesai",
+	"Uninstall GHEX from your system":               "Copot GHEX dari sistem Anda",
+	"GHEX Uninstaller":                              "Pencopot GHEX",
+
+	// update.go
+	"Update check":                                    "Pemeriksaan pembaruan",
+	"Failed to initialize updater: %v":                "Gagal menyiapkan pembaru: %v",
+	"Failed to check for updates: %v":                 "Gagal memeriksa pembaruan: %v",
+	"You're already running the latest version (v%s)": "Anda sudah memakai versi terbaru (v%s)",
+	"Current version: v%s":                            "Versi saat ini: v%s",
+	"Latest version:  %s":                             "Versi terbaru:   %s",
+	"Failed to check permissions: %v":                 "Gagal memeriksa izin: %v",
+	"Update failed: %v":                               "Pembaruan gagal: %v",
+	"Successfully updated to %s!":                     "Berhasil diperbarui ke %s!",
+	"Rollback failed: %v":                             "Rollback gagal: %v",
+	"Failed to fetch changelog: %v":                   "Gagal mengambil changelog: %v",
+	"ghex is installed system-wide in %s":             "ghex terpasang untuk seluruh sistem di %s",
+	"Continue with sudo?":                             "Lanjutkan dengan sudo?",
+	"sudo run failed: %v":                             "Menjalankan dengan sudo gagal: %v",
+	"Checking for updates...":                         "Memeriksa pembaruan...",
+	"Run 'ghex update' to install the latest version": "Jalankan 'ghex update' untuk memasang versi terbaru",
+	"Update cancelled":                                "Pembaruan dibatalkan",
+	"Do you want to update?":                          "Perbarui sekarang?",
+	"Downloading update...":                           "Mengunduh pembaruan...",
+	"Installing update...":                            "Memasang pembaruan...",
+	"gh is not installed: only checking that GitHub has an attestation for the release, not its signature": "gh tidak terpasang: hanya memeriksa bahwa GitHub memiliki attestation untuk rilis ini, bukan tanda tangannya",
+	"Last checked %s (use --refresh to check now)":                                                         "Terakhir diperiksa %s (gunakan --refresh untuk memeriksa sekarang)",
+	"You can rollback to the previous version with: ghex update --rollback":                                "Anda dapat kembali ke versi sebelumnya dengan: ghex update --rollback",
+	"Please restart ghex to use the new version":                                                           "Silakan jalankan ulang ghex untuk memakai versi baru",
+	"No backup available for rollback":                                                                     "Tidak ada cadangan untuk rollback",
+	"Rollback cancelled":                                                                                   "Rollback dibatalkan",
+	"Do you want to rollback to the previous version?":                                                     "Kembali ke versi sebelumnya?",
+	"Rolling back to previous version...":                                                                  "Kembali ke versi sebelumnya...",
+	"Successfully rolled back to previous version!":                                                        "Berhasil kembali ke versi sebelumnya!",
+	"Please restart ghex to use the restored version":                                                      "Silakan jalankan ulang ghex untuk memakai versi yang dipulihkan",
+	"No changelog available":                                                                               "Changelog tidak tersedia",
+	"Update ghex to the latest version":                                                                    "Perbarui ghex ke versi terbaru",
+
+	// version.go
+	"Show version information":                 "Tampilkan informasi versi",
+	"Update available: %s (run 'ghex update')": "Pembaruan tersedia: %s (jalankan 'ghex update')",
+
+	// workspace.go
+	"Group repositories in a directory under one account":                     "Kelompokkan repositori dalam satu direktori di bawah satu akun",
+	"Assign a directory to an account":                                        "Tetapkan direktori ke sebuah akun",
+	"List workspaces":                                                         "Tampilkan daftar workspace",
+	"Forget a workspace":                                                      "Lupakan workspace",
+	"Check the repositories of workspaces":                                    "Periksa repositori di workspace",
+	"Not a directory: %s":                                                     "Bukan direktori: %s",
+	"Workspace %s uses account '%s' (%d repositories)":                        "Workspace %s memakai akun '%s' (%d repositori)",
+	"Check it with: ghex workspace status":                                    "Periksa dengan: ghex workspace status",
+	"No workspaces defined. Add one with: ghex workspace add <dir> <account>": "Belum ada workspace. Tambahkan dengan: ghex workspace add <dir> <akun>",
+	"Workspaces":            "Workspace",
+	"Removed workspace %s":  "Workspace %s dihapus",
+	"No workspace at %s":    "Tidak ada workspace di %s",
+	"No repositories found": "Tidak ada repositori ditemukan",
+	"All repositories use their workspace's account":                "Semua repositori memakai akun workspace-nya",
+	"Switch %d repositories to their workspace's account?":          "Alihkan %d repositori ke akun workspace-nya?",
+	"Skipped %s: account '%s' not found":                            "%s dilewati: akun '%s' tidak ditemukan",
+	"Skipped %s: %v":                                                "%s dilewati: %v",
+	"Skipped %s: a %s is in progress":                               "%s dilewati: %s sedang berlangsung",
+	"Skipped %s: unpushed commits (run 'ghex switch %s' inside it)": "%s dilewati: ada commit yang belum di-push (jalankan 'ghex switch %s' di dalamnya)",
+	"Switched %s to %s (%s)":                                        "%s dialihkan ke %s (%s)",
+	"Fixed %d of %d repositories":                                   "%d dari %d repositori diperbaiki",
+	"Clone the missing repositories of workspaces":                  "Clone repositori workspace yang belum ada",
+	"No organizations to clone from; pass --org or add them with 'ghex workspace add %s %s --org <name>'": "Tidak ada organisasi untuk di-clone; berikan --org atau tambahkan dengan 'ghex workspace add %s %s --org <nama>'",
+	"Nothing to clone":                   "Tidak ada yang perlu di-clone",
+	"Cloned %d of %d repositories":       "%d dari %d repositori di-clone",
+	"Listing repositories of %s...":      "Mengambil daftar repositori %s...",
+	"%s: %d repositories":                "%s: %d repositori",
+	"Cloned %s":                          "%s di-clone",
+	"Failed to set up account in %s: %v": "Gagal menyiapkan akun di %s: %v",
+
+	// internal/ssh
+	"ghex is not editing %s (SSH config mode: print). Add or update this block yourself:":                              "ghex tidak mengubah %s (mode konfigurasi SSH: print). Tambahkan atau perbarui blok ini sendiri:",
+	"ghex is not editing %s (SSH config mode: print). Remove the \"Host %s\" block yourself if you no longer need it.": "ghex tidak mengubah %s (mode konfigurasi SSH: print). Hapus blok \"Host %s\" sendiri jika tidak diperlukan lagi.",
+	"Host blocks are written to %s. Add this line at the top of %s for ssh to use them:":                               "Blok Host ditulis ke %s. Tambahkan baris ini di bagian atas %s agar ssh memakainya:",
+
+	// internal/ui
+	"Done":                             "Selesai",
+	"Enter a number (blank to cancel)": "Masukkan nomor (kosongkan untuk batal)",
+	"Enter numbers separated by commas, or \"all\" (blank to cancel)": "Masukkan nomor dipisah koma, atau \"all\" (kosongkan untuk batal)",
+	"[y/N]": "[y/T]",
+}
@@ -0,0 +1,90 @@
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Supported languages
+const (
+	English    = "en"
+	Indonesian = "id"
+)
+
+// Languages lists the supported language codes
+var Languages = []string{English, Indonesian}
+
+// catalogs maps a language to its translations, keyed by the English text.
+// English needs no catalog: a missing entry falls back to the key itself.
+var catalogs = map[string]map[string]string{
+	Indonesian: indonesian,
+}
+
+var current = English
+
+// SetLanguage selects the language used by T. Unsupported codes select English.
+func SetLanguage(lang string) {
+	current = Normalize(lang)
+}
+
+// Language returns the selected language code
+func Language() string {
+	return current
+}
+
+// IsSupported reports whether lang is a supported language code
+func IsSupported(lang string) bool {
+	for _, l := range Languages {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// Normalize turns a locale such as "id_ID.UTF-8" into a supported language
+// code, falling back to English
+func Normalize(locale string) string {
+	lang := strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(lang, "_.@-"); i >= 0 {
+		lang = lang[:i]
+	}
+	if lang == "in" {
+		// Legacy ISO 639 code for Indonesian
+		lang = Indonesian
+	}
+	if IsSupported(lang) {
+		return lang
+	}
+	return English
+}
+
+// Detect picks the language to use. GHEX_LANG wins, then the configured
+// language, then the usual locale variables (LC_ALL, LC_MESSAGES, LANG).
+func Detect(configured string) string {
+	if v := os.Getenv("GHEX_LANG"); v != "" {
+		return Normalize(v)
+	}
+	if configured != "" {
+		return Normalize(configured)
+	}
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(key); v != "" {
+			return Normalize(v)
+		}
+	}
+	return English
+}
+
+// T translates msg into the selected language and formats it with args when
+// any are given. Messages without a translation are returned unchanged.
+func T(msg string, args ...interface{}) string {
+	if tr, ok := catalogs[current][msg]; ok {
+		msg = tr
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
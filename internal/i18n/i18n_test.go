@@ -0,0 +1,90 @@
+package i18n
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var verbPattern = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+
+// TestCatalogVerbs tests that translations keep the format verbs of the
+// English text, in order, so T formats them the same way
+func TestCatalogVerbs(t *testing.T) {
+	for lang, catalog := range catalogs {
+		for msg, tr := range catalog {
+			want := strings.Join(verbPattern.FindAllString(msg, -1), " ")
+			got := strings.Join(verbPattern.FindAllString(tr, -1), " ")
+			if got != want {
+				t.Errorf("%s: %q has verbs %q, want %q", lang, tr, got, want)
+			}
+		}
+	}
+}
+
+// TestNormalize tests locale normalization
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"id":          Indonesian,
+		"id_ID.UTF-8": Indonesian,
+		"in_ID":       Indonesian,
+		"ID":          Indonesian,
+		"en_US.UTF-8": English,
+		"C":           English,
+		"POSIX":       English,
+		"fr_FR":       English,
+		"":            English,
+	}
+	for locale, want := range tests {
+		if got := Normalize(locale); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", locale, got, want)
+		}
+	}
+}
+
+// TestDetect tests the precedence of GHEX_LANG, config and locale variables
+func TestDetect(t *testing.T) {
+	t.Setenv("GHEX_LANG", "")
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "id_ID.UTF-8")
+
+	if got := Detect(""); got != Indonesian {
+		t.Errorf("Detect from LANG = %q, want %q", got, Indonesian)
+	}
+	if got := Detect("en"); got != English {
+		t.Errorf("Detect with config = %q, want %q", got, English)
+	}
+
+	t.Setenv("LC_ALL", "C")
+	if got := Detect(""); got != English {
+		t.Errorf("Detect with LC_ALL=C = %q, want %q", got, English)
+	}
+
+	t.Setenv("GHEX_LANG", "id")
+	if got := Detect("en"); got != Indonesian {
+		t.Errorf("Detect with GHEX_LANG = %q, want %q", got, Indonesian)
+	}
+}
+
+// TestT tests lookup, fallback and formatting
+func TestT(t *testing.T) {
+	defer SetLanguage(English)
+
+	SetLanguage(Indonesian)
+	if got := T("Account '%s' removed", "work"); got != "Akun 'work' dihapus" {
+		t.Errorf("T = %q", got)
+	}
+	if got := T("not in the catalog"); got != "not in the catalog" {
+		t.Errorf("T fallback = %q", got)
+	}
+
+	SetLanguage(English)
+	if got := T("Account '%s' removed", "work"); got != "Account 'work' removed" {
+		t.Errorf("T English = %q", got)
+	}
+	// Without args, % is left alone
+	if got := T("100%"); got != "100%" {
+		t.Errorf("T without args = %q", got)
+	}
+}
@@ -0,0 +1,104 @@
+// Package offline decides whether ghex may use the network. Offline mode is
+// turned on with --offline or GHEX_OFFLINE=1, or detected when github.com
+// can't be resolved; commands then skip update checks, API calls and
+// connection tests instead of waiting for them to time out.
+package offline
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrOffline is returned for network requests made in offline mode
+var ErrOffline = errors.New("network access skipped (offline)")
+
+// EnvVar forces offline mode on (1) or off (0), which also disables detection
+const EnvVar = "GHEX_OFFLINE"
+
+// probeHost is resolved to detect whether there is a network
+const probeHost = "github.com"
+
+// probeTimeout bounds the detection lookup
+const probeTimeout = 1500 * time.Millisecond
+
+var (
+	forced   bool
+	proxied  bool
+	detected bool
+	once     sync.Once
+	mu       sync.RWMutex
+
+	// lookup resolves a host; replaced in tests
+	lookup = net.DefaultResolver.LookupHost
+)
+
+// Set forces offline mode on, as --offline does. Off leaves the decision to
+// GHEX_OFFLINE and detection.
+func Set(on bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	forced = on
+}
+
+// SetProxied tells detection that requests go through a proxy given on
+// the command line, as it assumes for the proxy environment variables
+func SetProxied(on bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	proxied = on
+}
+
+// Enabled reports whether ghex is offline. Detection runs once per process,
+// on first use, so commands that never need the network don't pay for it.
+func Enabled() bool {
+	mu.RLock()
+	on := forced
+	mu.RUnlock()
+	if on {
+		return true
+	}
+	if v, err := strconv.ParseBool(os.Getenv(EnvVar)); err == nil {
+		return v
+	}
+	once.Do(func() { detected = detect() })
+	return detected
+}
+
+// detect reports whether the network looks unavailable. Behind a proxy the
+// proxy resolves names, so a failed local lookup says nothing and the
+// network is assumed to work.
+func detect() bool {
+	mu.RLock()
+	viaProxy := proxied
+	mu.RUnlock()
+	if viaProxy {
+		return false
+	}
+	for _, key := range []string{"HTTPS_PROXY", "https_proxy", "HTTP_PROXY", "http_proxy", "ALL_PROXY", "all_proxy"} {
+		if os.Getenv(key) != "" {
+			return false
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+	defer cancel()
+	_, err := lookup(ctx, probeHost)
+	return err != nil
+}
+
+// IsLocalHost reports whether host (with or without a port) is a loopback
+// address, which stays reachable offline
+func IsLocalHost(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,77 @@
+package offline
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+// TestEnabled tests that --offline and GHEX_OFFLINE override detection and
+// that detection only runs once
+func TestEnabled(t *testing.T) {
+	lookups := 0
+	reachable := false
+	lookup = func(ctx context.Context, host string) ([]string, error) {
+		lookups++
+		if reachable {
+			return []string{"127.0.0.1"}, nil
+		}
+		return nil, errors.New("network is unreachable")
+	}
+	for _, key := range []string{"HTTPS_PROXY", "https_proxy", "HTTP_PROXY", "http_proxy", "ALL_PROXY", "all_proxy"} {
+		t.Setenv(key, "")
+	}
+	reset := func() {
+		Set(false)
+		once = sync.Once{}
+		lookups = 0
+	}
+	t.Cleanup(reset)
+
+	reset()
+	t.Setenv(EnvVar, "")
+	if !Enabled() || !Enabled() || lookups != 1 {
+		t.Errorf("Enabled() without a network = false or %d lookups, want true after 1", lookups)
+	}
+
+	reset()
+	reachable = true
+	if Enabled() {
+		t.Error("Enabled() with a network = true")
+	}
+	Set(true)
+	if !Enabled() {
+		t.Error("Enabled() after Set(true) = false")
+	}
+
+	reset()
+	reachable = false
+	t.Setenv(EnvVar, "0")
+	if Enabled() || lookups != 0 {
+		t.Errorf("Enabled() with %s=0 = true or looked up", EnvVar)
+	}
+
+	reset()
+	t.Setenv(EnvVar, "")
+	t.Setenv("HTTPS_PROXY", "http://proxy:3128")
+	if Enabled() || lookups != 0 {
+		t.Error("Enabled() behind a proxy = true or looked up")
+	}
+}
+
+// TestIsLocalHost tests loopback detection with and without ports
+func TestIsLocalHost(t *testing.T) {
+	tests := map[string]bool{
+		"127.0.0.1:8080": true,
+		"localhost":      true,
+		"[::1]:443":      true,
+		"github.com":     false,
+		"10.0.0.1:80":    false,
+	}
+	for host, want := range tests {
+		if got := IsLocalHost(host); got != want {
+			t.Errorf("IsLocalHost(%q) = %v, want %v", host, got, want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package platform
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// OpenURL opens url in the default browser. BROWSER, when set, is used
+// instead of the platform's opener.
+func OpenURL(url string) error {
+	var cmd *exec.Cmd
+	switch {
+	case os.Getenv("BROWSER") != "":
+		cmd = exec.Command(os.Getenv("BROWSER"), url)
+	case IsMacOS():
+		cmd = exec.Command("open", url)
+	case IsWindows():
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		opener := ""
+		// wslview opens the Windows browser from WSL
+		for _, name := range []string{"xdg-open", "wslview"} {
+			if _, err := exec.LookPath(name); err == nil {
+				opener = name
+				break
+			}
+		}
+		if opener == "" {
+			return fmt.Errorf("no browser opener found (install xdg-utils or set BROWSER)")
+		}
+		cmd = exec.Command(opener, url)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open browser: %w", err)
+	}
+	// The opener returns quickly; don't leave a zombie behind
+	go cmd.Wait()
+	return nil
+}
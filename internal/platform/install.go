@@ -0,0 +1,90 @@
+package platform
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// InstallScope says whether a binary is installed for the whole machine or
+// for the current user only
+type InstallScope string
+
+const (
+	ScopeUser   InstallScope = "user"   // e.g. ~/.local/bin, %LOCALAPPDATA%
+	ScopeSystem InstallScope = "system" // e.g. /usr/local/bin, Program Files
+)
+
+// unixSystemDirs are directories shared by all users on Unix-like systems
+var unixSystemDirs = []string{"/usr", "/bin", "/sbin", "/opt", "/snap", "/nix", "/Applications"}
+
+// DetectInstallScope reports whether the binary at path is installed
+// machine-wide or per-user
+func DetectInstallScope(path string) InstallScope {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	if IsWindows() {
+		for _, env := range []string{"ProgramFiles", "ProgramFiles(x86)", "ProgramW6432", "ProgramData", "SystemRoot"} {
+			if dir := os.Getenv(env); dir != "" && hasPathPrefix(path, dir) {
+				return ScopeSystem
+			}
+		}
+		return ScopeUser
+	}
+
+	if home := GetHomeDir(); home != "" && hasPathPrefix(path, home) {
+		return ScopeUser
+	}
+	for _, dir := range unixSystemDirs {
+		if hasPathPrefix(path, dir) {
+			return ScopeSystem
+		}
+	}
+	return ScopeUser
+}
+
+// hasPathPrefix reports whether path is dir or inside it. Windows paths
+// compare case-insensitively.
+func hasPathPrefix(path, dir string) bool {
+	path, dir = filepath.Clean(path), filepath.Clean(dir)
+	if IsWindows() {
+		path, dir = strings.ToLower(path), strings.ToLower(dir)
+	}
+	return path == dir || strings.HasPrefix(path, strings.TrimSuffix(dir, string(filepath.Separator))+string(filepath.Separator))
+}
+
+// CanElevate reports whether a command can be re-run with sudo: on Unix,
+// when not already root and sudo is installed
+func CanElevate() bool {
+	if IsWindows() || os.Geteuid() == 0 {
+		return false
+	}
+	_, err := exec.LookPath("sudo")
+	return err == nil
+}
+
+// RunElevated runs a command with sudo, connected to the terminal so sudo
+// can ask for a password
+func RunElevated(name string, args ...string) error {
+	if !CanElevate() {
+		return fmt.Errorf("sudo is not available")
+	}
+	cmd := exec.Command("sudo", append([]string{name}, args...)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// WindowsElevationHint explains how to run a ghex command as administrator,
+// since Windows has no sudo to re-run it with
+func WindowsElevationHint(command string) string {
+	return fmt.Sprintf("Open PowerShell or Windows Terminal with \"Run as administrator\" and run: %s\n"+
+		"or, from a normal PowerShell, approve the UAC prompt of:\n"+
+		"  Start-Process ghex -Verb RunAs -ArgumentList '%s'",
+		command, strings.TrimSpace(strings.TrimPrefix(command, "ghex")))
+}
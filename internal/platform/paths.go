@@ -51,9 +51,89 @@ func GetConfigDir(appName string) string {
 	return filepath.Join(configHome, appName)
 }
 
-// GetGitCredentialsPath returns the path to .git-credentials file
+// GetCacheDir returns the cache directory for an application
+func GetCacheDir(appName string) string {
+	if IsWindows() {
+		// Windows: Use %LOCALAPPDATA%
+		localAppData := os.Getenv("LOCALAPPDATA")
+		if localAppData == "" {
+			localAppData = filepath.Join(GetHomeDir(), "AppData", "Local")
+		}
+		return filepath.Join(localAppData, appName, "cache")
+	}
+
+	// Linux/macOS: Use XDG_CACHE_HOME or ~/.cache
+	cacheHome := os.Getenv("XDG_CACHE_HOME")
+	if cacheHome == "" {
+		cacheHome = filepath.Join(GetHomeDir(), ".cache")
+	}
+	return filepath.Join(cacheHome, appName)
+}
+
+// GetGitCredentialsPath returns the path git's credential store uses
+// Like git, it prefers ~/.git-credentials and falls back to
+// $XDG_CONFIG_HOME/git/credentials when only that file exists
 func GetGitCredentialsPath() string {
-	return filepath.Join(GetHomeDir(), ".git-credentials")
+	home := filepath.Join(GetHomeDir(), ".git-credentials")
+	if FileExists(home) {
+		return home
+	}
+	if xdg := filepath.Join(gitXDGDir(), "credentials"); FileExists(xdg) {
+		return xdg
+	}
+	return home
+}
+
+// GetGitGlobalConfigPath returns the global git config file, honoring
+// GIT_CONFIG_GLOBAL and the $XDG_CONFIG_HOME/git/config fallback
+func GetGitGlobalConfigPath() string {
+	if path := os.Getenv("GIT_CONFIG_GLOBAL"); path != "" {
+		return ExpandPath(path)
+	}
+	home := filepath.Join(GetHomeDir(), ".gitconfig")
+	if FileExists(home) {
+		return home
+	}
+	if xdg := filepath.Join(gitXDGDir(), "config"); FileExists(xdg) {
+		return xdg
+	}
+	return home
+}
+
+// gitXDGDir returns git's XDG config directory
+func gitXDGDir() string {
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		configHome = filepath.Join(GetHomeDir(), ".config")
+	}
+	return filepath.Join(configHome, "git")
+}
+
+// GetSSHCommand returns the ssh program and leading arguments git uses,
+// honoring GIT_SSH_COMMAND and GIT_SSH
+func GetSSHCommand() (string, []string) {
+	if command := strings.Fields(os.Getenv("GIT_SSH_COMMAND")); len(command) > 0 {
+		return command[0], command[1:]
+	}
+	if program := os.Getenv("GIT_SSH"); program != "" {
+		return program, nil
+	}
+	return "ssh", nil
+}
+
+// GetSSHConfigPath returns the SSH config file, honoring a -F option in
+// GIT_SSH_COMMAND
+func GetSSHConfigPath() string {
+	_, args := GetSSHCommand()
+	for i, arg := range args {
+		if arg == "-F" && i+1 < len(args) {
+			return ExpandPath(args[i+1])
+		}
+		if strings.HasPrefix(arg, "-F") && len(arg) > 2 {
+			return ExpandPath(arg[2:])
+		}
+	}
+	return filepath.Join(GetSSHDir(), "config")
 }
 
 // NormalizePath normalizes a file path for the current platform
@@ -96,6 +176,22 @@ func IsGitBashEnv() bool {
 	return runtime.GOOS == "windows" && os.Getenv("MSYSTEM") != ""
 }
 
+// HomeRelative replaces the home directory at the start of path with ~,
+// the reverse of ExpandPath, for paths meant to work on other machines
+func HomeRelative(path string) string {
+	home := GetHomeDir()
+	if home == "" {
+		return path
+	}
+	if path == home {
+		return "~"
+	}
+	if rel, ok := strings.CutPrefix(path, home+string(filepath.Separator)); ok {
+		return "~/" + filepath.ToSlash(rel)
+	}
+	return path
+}
+
 // ExpandPath expands environment variables and tilde in a path
 func ExpandPath(path string) string {
 	if path == "" {
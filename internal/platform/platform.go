@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 )
 
 // Platform holds information about the current platform
@@ -48,6 +49,17 @@ func IsUnix() bool {
 	return runtime.GOOS != "windows"
 }
 
+// DeviceName returns the name of this machine: its hostname up to the first
+// dot, lowercased, or "" if it can't be read
+func DeviceName() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return ""
+	}
+	name, _, _ := strings.Cut(strings.TrimSpace(hostname), ".")
+	return strings.ToLower(name)
+}
+
 // DetectShell returns the current shell environment
 func DetectShell() string {
 	if IsWindows() {
@@ -0,0 +1,170 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Formats are the output formats Write accepts
+var Formats = []string{"json", "csv", "markdown"}
+
+// Write writes the report in the given format: json, csv or markdown (md)
+func Write(w io.Writer, r *Report, format string) error {
+	switch strings.ToLower(format) {
+	case "json":
+		return writeJSON(w, r)
+	case "csv":
+		return writeCSV(w, r)
+	case "markdown", "md":
+		return writeMarkdown(w, r)
+	default:
+		return CheckFormat(format)
+	}
+}
+
+// CheckFormat returns an error for a format Write doesn't accept
+func CheckFormat(format string) error {
+	switch strings.ToLower(format) {
+	case "json", "csv", "markdown", "md":
+		return nil
+	}
+	return fmt.Errorf("unknown format %q (use %s)", format, strings.Join(Formats, ", "))
+}
+
+func writeJSON(w io.Writer, r *Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// csvHeader names the columns of the CSV report, one row per account
+var csvHeader = []string{
+	"account", "platform", "host", "git_user_name", "git_email",
+	"ssh_key_path", "ssh_key_type", "ssh_key_fingerprint", "ssh_key_modified_at", "ssh_key_age_days", "ssh_key_registered",
+	"token_username", "token_valid", "token_login", "token_scopes", "token_expires_at",
+	"errors",
+}
+
+func writeCSV(w io.Writer, r *Report) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, acc := range r.Accounts {
+		row := []string{acc.Name, acc.Platform, acc.Host, acc.GitUserName, acc.GitEmail}
+		if k := acc.SSHKey; k != nil {
+			row = append(row, k.Path, k.Type, k.Fingerprint, k.ModifiedAt, strconv.Itoa(k.AgeDays), checkText(k.Registered))
+		} else {
+			row = append(row, "", "", "", "", "", "")
+		}
+		if t := acc.Token; t != nil {
+			row = append(row, t.Username, checkText(t.Valid), t.Login, strings.Join(t.Scopes, " "), t.ExpiresAt)
+		} else {
+			row = append(row, "", "", "", "", "")
+		}
+		row = append(row, strings.Join(acc.errors(), "; "))
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeMarkdown(w io.Writer, r *Report) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# ghex inventory\n\n")
+	fmt.Fprintf(&b, "- Host: %s\n- Generated: %s\n", mdCell(r.Hostname), r.GeneratedAt)
+	if !r.Online {
+		b.WriteString("- Tokens and key registration were not checked (offline)\n")
+	}
+
+	b.WriteString("\n## Accounts\n\n")
+	b.WriteString("| Account | Platform | Host | Git identity |\n|---|---|---|---|\n")
+	for _, acc := range r.Accounts {
+		identity := acc.GitUserName
+		if acc.GitEmail != "" {
+			identity += " <" + acc.GitEmail + ">"
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", mdCell(acc.Name), acc.Platform, mdCell(acc.Host), mdCell(identity))
+	}
+
+	b.WriteString("\n## SSH keys\n\n")
+	b.WriteString("| Account | Path | Type | Fingerprint | Age (days) | Registered |\n|---|---|---|---|---|---|\n")
+	for _, acc := range r.Accounts {
+		if k := acc.SSHKey; k != nil {
+			fingerprint := "-"
+			if k.Fingerprint != "" {
+				fingerprint = "`" + k.Fingerprint + "`"
+			}
+			fmt.Fprintf(&b, "| %s | `%s` | %s | %s | %d | %s |\n",
+				mdCell(acc.Name), mdCell(k.Path), k.Type, fingerprint, k.AgeDays, checkText(k.Registered))
+		}
+	}
+
+	b.WriteString("\n## Tokens\n\n")
+	b.WriteString("| Account | Username | Valid | Scopes | Expires |\n|---|---|---|---|---|\n")
+	for _, acc := range r.Accounts {
+		if t := acc.Token; t != nil {
+			expires := t.ExpiresAt
+			if expires == "" {
+				expires = "-"
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n",
+				mdCell(acc.Name), mdCell(t.Username), checkText(t.Valid), mdCell(strings.Join(t.Scopes, ", ")), expires)
+		}
+	}
+
+	var problems []string
+	for _, acc := range r.Accounts {
+		for _, e := range acc.errors() {
+			problems = append(problems, fmt.Sprintf("- %s: %s\n", acc.Name, e))
+		}
+	}
+	if len(problems) > 0 {
+		b.WriteString("\n## Problems\n\n")
+		b.WriteString(strings.Join(problems, ""))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// errors returns the problems found with the account's key and token
+func (a Account) errors() []string {
+	var errs []string
+	if a.SSHKey != nil && a.SSHKey.Error != "" {
+		errs = append(errs, "SSH key: "+a.SSHKey.Error)
+	}
+	if a.SSHKey != nil && a.SSHKey.Registered != nil && !*a.SSHKey.Registered {
+		errs = append(errs, "SSH key is not registered on "+a.Host)
+	}
+	if a.Token != nil && a.Token.Error != "" {
+		errs = append(errs, "token: "+a.Token.Error)
+	}
+	if a.Token != nil && a.Token.Valid != nil && !*a.Token.Valid {
+		errs = append(errs, "token was rejected by "+a.Host)
+	}
+	return errs
+}
+
+// checkText returns yes, no or unknown for a check result
+func checkText(v *bool) string {
+	switch {
+	case v == nil:
+		return "unknown"
+	case *v:
+		return "yes"
+	default:
+		return "no"
+	}
+}
+
+// mdCell escapes a value for a Markdown table cell
+func mdCell(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
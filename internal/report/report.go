@@ -0,0 +1,172 @@
+// Package report builds an inventory of the configured accounts with their
+// SSH keys and tokens, for auditing a machine. Token secrets are never
+// included.
+package report
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dwirx/ghex/internal/account"
+	"github.com/dwirx/ghex/internal/config"
+	"github.com/dwirx/ghex/internal/git"
+	"github.com/dwirx/ghex/internal/platform"
+	"github.com/dwirx/ghex/internal/ssh"
+)
+
+// Report is the inventory of one machine
+type Report struct {
+	GeneratedAt string    `json:"generatedAt"` // RFC3339
+	Hostname    string    `json:"hostname"`
+	Online      bool      `json:"online"` // Tokens and key registration were checked with the platforms
+	Accounts    []Account `json:"accounts"`
+}
+
+// Account is one configured account
+type Account struct {
+	Name        string `json:"name"`
+	Platform    string `json:"platform"`
+	Host        string `json:"host"`
+	GitUserName string `json:"gitUserName,omitempty"`
+	GitEmail    string `json:"gitEmail,omitempty"`
+	SSHKey      *Key   `json:"sshKey,omitempty"`
+	Token       *Token `json:"token,omitempty"`
+}
+
+// Key is an account's SSH key
+type Key struct {
+	Path        string `json:"path"`
+	Exists      bool   `json:"exists"`
+	Type        string `json:"type,omitempty"`
+	Fingerprint string `json:"fingerprint,omitempty"`
+	Comment     string `json:"comment,omitempty"`
+	ModifiedAt  string `json:"modifiedAt,omitempty"` // RFC3339, the closest to a creation date files offer
+	AgeDays     int    `json:"ageDays"`
+	Registered  *bool  `json:"registered,omitempty"` // Listed on the platform account; nil = not checked
+	Error       string `json:"error,omitempty"`
+}
+
+// Token is an account's personal access token, without the secret
+type Token struct {
+	Username  string   `json:"username"`
+	Valid     *bool    `json:"valid,omitempty"` // nil = not checked
+	Login     string   `json:"login,omitempty"` // User the platform reports for the token
+	Scopes    []string `json:"scopes,omitempty"`
+	ExpiresAt string   `json:"expiresAt,omitempty"` // RFC3339
+	Error     string   `json:"error,omitempty"`
+}
+
+// Collect builds the report for the configured accounts. With online set,
+// tokens are checked with their platform and SSH keys are looked up among
+// the keys registered to the account's user.
+func Collect(cfg *config.AppConfig, online bool, now time.Time) *Report {
+	hostname, _ := os.Hostname()
+	r := &Report{
+		GeneratedAt: now.Format(time.RFC3339),
+		Hostname:    hostname,
+		Online:      online,
+	}
+
+	for i := range cfg.Accounts {
+		acc := &cfg.Accounts[i]
+		opts := account.RepoListOptions(acc)
+		entry := Account{
+			Name:        acc.Name,
+			Platform:    opts.Platform,
+			Host:        opts.Host,
+			GitUserName: acc.GitUserName,
+			GitEmail:    acc.GitEmail,
+		}
+		if acc.Token != nil && acc.Token.Token != "" {
+			entry.Token = &Token{Username: acc.Token.Username}
+			if online {
+				checkToken(entry.Token, opts)
+			}
+		}
+		if acc.SSH != nil && acc.SSH.KeyPath != "" {
+			entry.SSHKey = inspectKey(acc.SSH.KeyPath, now)
+			if online && entry.SSHKey.Fingerprint != "" {
+				checkRegistration(entry.SSHKey, entry.Token, opts)
+			}
+		}
+		r.Accounts = append(r.Accounts, entry)
+	}
+	return r
+}
+
+// inspectKey reads the key file's age and its public key's type,
+// fingerprint and comment
+func inspectKey(keyPath string, now time.Time) *Key {
+	key := &Key{Path: keyPath}
+	path := platform.ExpandPath(keyPath)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		key.Error = "key file not found"
+		return key
+	}
+	key.Exists = true
+	key.ModifiedAt = info.ModTime().Format(time.RFC3339)
+	key.AgeDays = int(now.Sub(info.ModTime()).Hours() / 24)
+
+	// Reading the .pub file avoids asking for the passphrase of the key
+	data, err := os.ReadFile(path + ".pub")
+	if err != nil {
+		key.Error = "public key (.pub) not found"
+		return key
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) >= 2 {
+		key.Type = fields[0]
+		key.Fingerprint = ssh.PublicKeyFingerprint(string(data))
+	}
+	if len(fields) >= 3 {
+		key.Comment = strings.Join(fields[2:], " ")
+	}
+	if key.Fingerprint == "" {
+		key.Error = "invalid public key"
+	}
+	return key
+}
+
+// checkToken fills in what the platform reports about a token
+func checkToken(token *Token, opts git.RepoListOptions) {
+	info, err := git.GetTokenInfo(opts)
+	if err != nil {
+		token.Error = err.Error()
+		return
+	}
+	token.Valid = &info.Valid
+	token.Login = info.Login
+	token.Scopes = info.Scopes
+	if !info.ExpiresAt.IsZero() {
+		token.ExpiresAt = info.ExpiresAt.Format(time.RFC3339)
+	}
+}
+
+// checkRegistration looks the key up among the keys registered to the
+// account's user, the token's user when the account has no username
+func checkRegistration(key *Key, token *Token, opts git.RepoListOptions) {
+	username := opts.Username
+	if username == "" && token != nil {
+		username = token.Login
+	}
+	if username == "" {
+		return
+	}
+
+	keys, err := git.ListUserKeys(username, opts)
+	if err != nil {
+		key.Error = err.Error()
+		return
+	}
+	registered := false
+	for _, k := range keys {
+		if ssh.PublicKeyFingerprint(k) == key.Fingerprint {
+			registered = true
+			break
+		}
+	}
+	key.Registered = &registered
+}
@@ -0,0 +1,119 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dwirx/ghex/internal/config"
+)
+
+const testPublicKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIBGn4dl2aZ1Ly3qW1P0kQ3V3Yg3vYkB7gq0iZf0wA6dD me@work"
+
+// testReport collects a report for an account with a key and a token and
+// one whose key is missing
+func testReport(t *testing.T) *Report {
+	t.Helper()
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "id_work")
+	if err := os.WriteFile(keyPath, []byte("private"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyPath+".pub", []byte(testPublicKey+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	modified := time.Now().Add(-10 * 24 * time.Hour)
+	if err := os.Chtimes(keyPath, modified, modified); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.AppConfig{Accounts: []config.Account{
+		{
+			Name:        "work",
+			GitUserName: "Work Me",
+			GitEmail:    "me@work.com",
+			SSH:         &config.SshConfig{KeyPath: keyPath},
+			Token:       &config.TokenConfig{Username: "me", Token: "ghp_secret"},
+		},
+		{
+			Name:     "lab",
+			SSH:      &config.SshConfig{KeyPath: filepath.Join(dir, "missing")},
+			Platform: &config.PlatformConfig{Type: "gitlab"},
+		},
+	}}
+	return Collect(cfg, false, time.Now())
+}
+
+// TestCollect tests the offline inventory of keys and tokens
+func TestCollect(t *testing.T) {
+	r := testReport(t)
+	if len(r.Accounts) != 2 {
+		t.Fatalf("Collect() returned %d accounts, want 2", len(r.Accounts))
+	}
+
+	work := r.Accounts[0]
+	if work.Platform != "github" || work.Host != "github.com" {
+		t.Errorf("Platform, Host = %s, %s, want github, github.com", work.Platform, work.Host)
+	}
+	key := work.SSHKey
+	if key == nil || !key.Exists || key.Type != "ssh-ed25519" || key.Comment != "me@work" {
+		t.Fatalf("SSHKey = %+v, want the ed25519 key", key)
+	}
+	if !strings.HasPrefix(key.Fingerprint, "SHA256:") || key.AgeDays != 10 {
+		t.Errorf("Fingerprint, AgeDays = %s, %d, want a SHA256 fingerprint 10 days old", key.Fingerprint, key.AgeDays)
+	}
+	if key.Registered != nil || work.Token.Valid != nil {
+		t.Error("Expected no online checks")
+	}
+
+	lab := r.Accounts[1]
+	if lab.Host != "gitlab.com" || lab.SSHKey.Exists || lab.SSHKey.Error == "" || lab.Token != nil {
+		t.Errorf("lab = %+v, want a missing key and no token", lab)
+	}
+}
+
+// TestWrite tests each output format and that token secrets are left out
+func TestWrite(t *testing.T) {
+	r := testReport(t)
+	fingerprint := r.Accounts[0].SSHKey.Fingerprint
+
+	for _, format := range Formats {
+		var buf bytes.Buffer
+		if err := Write(&buf, r, format); err != nil {
+			t.Fatalf("Write(%s) error = %v", format, err)
+		}
+		out := buf.String()
+		if strings.Contains(out, "ghp_secret") {
+			t.Errorf("Write(%s) leaked the token", format)
+		}
+		if !strings.Contains(out, fingerprint) {
+			t.Errorf("Write(%s) is missing the key fingerprint", format)
+		}
+
+		switch format {
+		case "json":
+			var decoded Report
+			if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil || len(decoded.Accounts) != 2 {
+				t.Errorf("JSON round trip = %v, %v", decoded, err)
+			}
+		case "csv":
+			rows, err := csv.NewReader(&buf).ReadAll()
+			if err != nil || len(rows) != 3 || len(rows[1]) != len(csvHeader) {
+				t.Errorf("CSV rows = %v, %v, want a header and two accounts", rows, err)
+			}
+		case "markdown":
+			if !strings.Contains(out, "## SSH keys") || !strings.Contains(out, "## Problems") {
+				t.Errorf("Markdown = %s, want key and problem sections", out)
+			}
+		}
+	}
+
+	if err := Write(&bytes.Buffer{}, r, "xml"); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
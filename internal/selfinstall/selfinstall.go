@@ -0,0 +1,124 @@
+// Package selfinstall copies the running ghex binary to a stable location
+// and wires it into the user's shell, replacing the install scripts.
+package selfinstall
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dwirx/ghex/internal/platform"
+)
+
+// DefaultDir returns the per-user install directory, matching the install
+// scripts on Windows
+func DefaultDir() string {
+	if platform.IsWindows() {
+		localAppData := os.Getenv("LOCALAPPDATA")
+		if localAppData == "" {
+			localAppData = filepath.Join(platform.GetHomeDir(), "AppData", "Local")
+		}
+		return filepath.Join(localAppData, "ghex")
+	}
+	return filepath.Join(platform.GetHomeDir(), ".local", "bin")
+}
+
+// BinaryName returns the file name of the installed binary
+func BinaryName() string {
+	if platform.IsWindows() {
+		return "ghex.exe"
+	}
+	return "ghex"
+}
+
+// CopySelf copies the running binary into dir and returns the installed
+// path. copied is false when the running binary already is that file.
+func CopySelf(dir string) (target string, copied bool, err error) {
+	src, err := os.Executable()
+	if err != nil {
+		return "", false, fmt.Errorf("failed to locate the running binary: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(src); err == nil {
+		src = resolved
+	}
+
+	dir = platform.ExpandPath(dir)
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	target = filepath.Join(dir, BinaryName())
+
+	if sameFile(src, target) {
+		return target, false, nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", false, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	if err := copyAtomic(src, target); err != nil {
+		return "", false, err
+	}
+	return target, true, nil
+}
+
+// sameFile reports whether a and b are the same existing file
+func sameFile(a, b string) bool {
+	infoA, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+	infoB, err := os.Stat(b)
+	if err != nil {
+		return false
+	}
+	return os.SameFile(infoA, infoB)
+}
+
+// copyAtomic copies src to a temp file next to dst and renames it over dst,
+// so a running copy of dst is never left half written
+func copyAtomic(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(dst), ".ghex-install-*")
+	if err != nil {
+		return fmt.Errorf("failed to write to %s: %w", filepath.Dir(dst), err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, in); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0755); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), dst); err != nil {
+		return fmt.Errorf("failed to install %s: %w", dst, err)
+	}
+	return nil
+}
+
+// InPath reports whether dir is listed in PATH
+func InPath(dir string) bool {
+	for _, entry := range filepath.SplitList(os.Getenv("PATH")) {
+		if entry == "" {
+			continue
+		}
+		if platform.IsWindows() {
+			if strings.EqualFold(filepath.Clean(entry), filepath.Clean(dir)) {
+				return true
+			}
+		} else if filepath.Clean(entry) == filepath.Clean(dir) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,184 @@
+package selfinstall
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dwirx/ghex/internal/platform"
+	"github.com/dwirx/ghex/internal/shell"
+)
+
+// Markers around the block ghex manages in shell rc files. Everything
+// between them is replaced on reinstall and removed on uninstall.
+const (
+	blockStart = "# >>> ghex >>>"
+	blockEnd   = "# <<< ghex <<<"
+)
+
+// ShellSetup selects what the rc file block sets up
+type ShellSetup struct {
+	BinDir     string // Added to PATH when AddPath is set
+	Binary     string // Installed binary, used to load completions
+	AddPath    bool
+	Completion bool
+}
+
+// RCFile returns the rc file of shellName (bash, zsh or fish), or "" when
+// the shell is not supported
+func RCFile(shellName string) string {
+	home := platform.GetHomeDir()
+	switch shellName {
+	case "bash":
+		return filepath.Join(home, ".bashrc")
+	case "zsh":
+		if zdotdir := os.Getenv("ZDOTDIR"); zdotdir != "" {
+			return filepath.Join(zdotdir, ".zshrc")
+		}
+		return filepath.Join(home, ".zshrc")
+	case "fish":
+		return filepath.Join(platform.GetConfigDir("fish"), "config.fish")
+	default:
+		return ""
+	}
+}
+
+// Block returns the lines ghex adds to the rc file of shellName, without
+// markers
+func (s ShellSetup) Block(shellName string) string {
+	binDir := platform.ToSSHPath(s.BinDir)
+	binary := platform.ToSSHPath(s.Binary)
+
+	var lines []string
+	if shellName == "fish" {
+		if s.AddPath {
+			lines = append(lines, fmt.Sprintf("fish_add_path %q", binDir))
+		}
+		if s.Completion {
+			lines = append(lines, fmt.Sprintf("%q completion fish | source", binary))
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	if s.AddPath {
+		lines = append(lines, fmt.Sprintf("export PATH=%q:\"$PATH\"", binDir))
+	}
+	if s.Completion {
+		if shellName == "zsh" {
+			// Completions register through compdef, which compinit defines
+			lines = append(lines, "command -v compdef >/dev/null || { autoload -U compinit && compinit; }")
+		}
+		lines = append(lines, fmt.Sprintf("source <(%q completion %s)", binary, shellName))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// WriteRCBlock adds, replaces or removes the ghex block in the rc file of
+// shellName and returns the file and whether it changed. A PATH entry for
+// the same directory added by an earlier run is kept, and setup.AddPath is
+// set to match.
+func WriteRCBlock(shellName string, setup *ShellSetup) (string, bool, error) {
+	path := RCFile(shellName)
+	if path == "" {
+		return "", false, fmt.Errorf("unsupported shell '%s' (use bash, zsh or fish)", shellName)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return path, false, err
+	}
+	if !setup.AddPath {
+		withPath := *setup
+		withPath.AddPath, withPath.Completion = true, false
+		setup.AddPath = strings.Contains(string(data), withPath.Block(shellName))
+	}
+
+	content := applyBlock(string(data), setup.Block(shellName))
+	if content == string(data) {
+		return path, false, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return path, false, err
+	}
+	return path, true, os.WriteFile(path, []byte(content), 0644)
+}
+
+// RCFilesWithBlock returns the supported rc files that contain a ghex block
+func RCFilesWithBlock() []string {
+	var files []string
+	for _, shellName := range []string{"bash", "zsh", "fish"} {
+		path := RCFile(shellName)
+		if data, err := os.ReadFile(path); err == nil && strings.Contains(string(data), blockStart) {
+			files = append(files, path)
+		}
+	}
+	return files
+}
+
+// RemoveRCBlocks removes the ghex block from every supported rc file and
+// returns the files changed
+func RemoveRCBlocks() ([]string, error) {
+	var changed []string
+	for _, path := range RCFilesWithBlock() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if err := os.WriteFile(path, []byte(removeBlock(string(data))), 0644); err != nil {
+			return changed, err
+		}
+		changed = append(changed, path)
+	}
+	return changed, nil
+}
+
+// applyBlock replaces the marked block in content with block, or appends
+// it when there is none. An empty block removes it.
+func applyBlock(content, block string) string {
+	content = removeBlock(content)
+	if block == "" {
+		return content
+	}
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	if content != "" {
+		content += "\n"
+	}
+	return content + blockStart + "\n" + block + "\n" + blockEnd + "\n"
+}
+
+// removeBlock removes the marked block and the blank line before it
+func removeBlock(content string) string {
+	start := strings.Index(content, blockStart)
+	if start < 0 {
+		return content
+	}
+	end := strings.Index(content[start:], blockEnd)
+	if end < 0 {
+		return content
+	}
+	end += start + len(blockEnd)
+	if end < len(content) && content[end] == '\n' {
+		end++
+	}
+
+	before := content[:start]
+	if strings.HasSuffix(before, "\n\n") {
+		before = before[:len(before)-1]
+	}
+	return before + content[end:]
+}
+
+// AddToWindowsPath appends dir to the user's PATH in the registry, the way
+// install.ps1 does. New terminals pick it up.
+func AddToWindowsPath(dir string) error {
+	script := fmt.Sprintf(`$p = [Environment]::GetEnvironmentVariable('Path', 'User'); `+
+		`if (($p -split ';') -notcontains '%[1]s') { [Environment]::SetEnvironmentVariable('Path', ($p.TrimEnd(';') + ';%[1]s').TrimStart(';'), 'User') }`,
+		strings.ReplaceAll(dir, "'", "''"))
+	if out, err := shell.Exec("powershell", "-NoProfile", "-Command", script); err != nil {
+		return fmt.Errorf("failed to update PATH: %s", strings.TrimSpace(out))
+	}
+	return nil
+}
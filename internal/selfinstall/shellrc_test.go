@@ -0,0 +1,41 @@
+package selfinstall
+
+import "testing"
+
+func TestApplyBlock(t *testing.T) {
+	block := "export PATH=\"/home/u/.local/bin\":\"$PATH\""
+	wrapped := blockStart + "\n" + block + "\n" + blockEnd + "\n"
+
+	tests := []struct {
+		name    string
+		content string
+		block   string
+		want    string
+	}{
+		{"empty file", "", block, wrapped},
+		{"appends after a blank line", "alias ll='ls -l'\n", block, "alias ll='ls -l'\n\n" + wrapped},
+		{"adds missing newline", "alias ll='ls -l'", block, "alias ll='ls -l'\n\n" + wrapped},
+		{"replaces existing block", "a\n\n" + blockStart + "\nold\n" + blockEnd + "\nb\n", block, "a\nb\n\n" + wrapped},
+		{"empty block removes", "a\n\n" + wrapped, "", "a\n"},
+		{"unterminated block is left alone", "a\n" + blockStart + "\nold\n", "", "a\n" + blockStart + "\nold\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := applyBlock(tt.content, tt.block); got != tt.want {
+				t.Errorf("applyBlock() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyBlockIsIdempotent(t *testing.T) {
+	setup := ShellSetup{BinDir: "/home/u/.local/bin", Binary: "/home/u/.local/bin/ghex", AddPath: true, Completion: true}
+	once := applyBlock("alias ll='ls -l'\n", setup.Block("zsh"))
+	if twice := applyBlock(once, setup.Block("zsh")); twice != once {
+		t.Errorf("second applyBlock() changed the file:\n%s\nwant:\n%s", twice, once)
+	}
+	if got := removeBlock(once); got != "alias ll='ls -l'\n" {
+		t.Errorf("removeBlock() = %q, want the original content", got)
+	}
+}
@@ -3,8 +3,10 @@ package shell
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"runtime"
 	"strings"
 )
 
@@ -53,6 +55,45 @@ func RunInDir(dir, name string, args ...string) (string, error) {
 	return strings.TrimSpace(stdout.String()), nil
 }
 
+// RunInDirWithEnv executes a command in a directory with extra environment
+// variables (KEY=VALUE) appended to the current environment
+func RunInDirWithEnv(dir string, env []string, name string, args ...string) (string, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		errMsg := stderr.String()
+		if errMsg != "" {
+			return stdout.String(), fmt.Errorf("%w: %s", err, strings.TrimSpace(errMsg))
+		}
+		return stdout.String(), err
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// RunWithInput executes a command in a directory, feeding input on stdin,
+// and returns stdout and stderr separately
+func RunWithInput(dir string, env []string, input, name string, args ...string) (string, string, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = strings.NewReader(input)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	return stdout.String(), stderr.String(), err
+}
+
 // Exec executes a command and returns combined stdout and stderr
 // It doesn't return an error for non-zero exit codes (useful for commands like ssh -T)
 func Exec(name string, args ...string) (string, error) {
@@ -89,6 +130,18 @@ func RunInteractive(name string, args ...string) error {
 	return cmd.Run()
 }
 
+// RunInteractiveWithEnv runs an interactive command with extra environment
+// variables
+func RunInteractiveWithEnv(env []string, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	return cmd.Run()
+}
+
 // RunInteractiveInDir runs an interactive command in a specific directory
 func RunInteractiveInDir(dir, name string, args ...string) error {
 	cmd := exec.Command(name, args...)
@@ -100,6 +153,28 @@ func RunInteractiveInDir(dir, name string, args ...string) error {
 	return cmd.Run()
 }
 
+// RunInteractiveCapture runs an interactive command like RunInteractive
+// and also returns what it wrote to stderr
+func RunInteractiveCapture(name string, args ...string) (string, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = io.MultiWriter(os.Stderr, &stderr)
+
+	err := cmd.Run()
+	return stderr.String(), err
+}
+
+// RunCommandLine runs a command line with the system shell (sh -c, or
+// cmd /C on Windows outside Git Bash), connected to the terminal
+func RunCommandLine(line string) error {
+	if runtime.GOOS == "windows" && os.Getenv("MSYSTEM") == "" {
+		return RunInteractive("cmd", "/C", line)
+	}
+	return RunInteractive("sh", "-c", line)
+}
+
 // CommandExists checks if a command exists in PATH
 func CommandExists(name string) bool {
 	_, err := exec.LookPath(name)
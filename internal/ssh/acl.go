@@ -0,0 +1,70 @@
+package ssh
+
+import (
+	"fmt"
+	"os/user"
+	"strings"
+
+	"github.com/dwirx/ghex/internal/shell"
+)
+
+// Well-known SIDs of groups that must not be able to read a private key.
+// SIDs are used instead of names because group names are localized.
+var broadGroupSIDs = []string{
+	"*S-1-1-0",      // Everyone
+	"*S-1-5-11",     // Authenticated Users
+	"*S-1-5-32-545", // BUILTIN\Users
+}
+
+// restrictWindowsACL is the ACL equivalent of chmod 600 for native Windows
+// OpenSSH, which ignores file modes and refuses private keys that other
+// users can read ("bad permissions"). It removes inherited entries, grants
+// the current user full control and drops any grants to broad groups.
+func restrictWindowsACL(path string) error {
+	current, err := user.Current()
+	if err != nil {
+		return fmt.Errorf("failed to look up current user: %w", err)
+	}
+
+	// Inherited entries from the profile directory usually grant Users or
+	// Authenticated Users read access; /grant:r replaces the user's entries
+	steps := [][]string{
+		{path, "/inheritance:r"},
+		{path, "/grant:r", current.Username + ":F"},
+		append([]string{path, "/remove:g"}, broadGroupSIDs...),
+	}
+	for _, args := range steps {
+		if out, err := shell.Exec("icacls", args...); err != nil {
+			return fmt.Errorf("icacls %s failed: %s", args[1], strings.TrimSpace(out))
+		}
+	}
+	return nil
+}
+
+// windowsACLIsPrivate reports whether the current user is the only principal
+// in the file's ACL, as left by restrictWindowsACL. icacls prints one entry
+// per line as "PRINCIPAL:(flags)(rights)", the first line prefixed with the path.
+func windowsACLIsPrivate(path string) bool {
+	current, err := user.Current()
+	if err != nil {
+		return false
+	}
+	out, err := shell.Exec("icacls", path)
+	if err != nil {
+		return false
+	}
+
+	entries := 0
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), path))
+		principal, _, found := strings.Cut(line, ":(")
+		if !found {
+			continue
+		}
+		if !strings.EqualFold(strings.TrimSpace(principal), current.Username) {
+			return false
+		}
+		entries++
+	}
+	return entries > 0
+}
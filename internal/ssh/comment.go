@@ -0,0 +1,105 @@
+package ssh
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dwirx/ghex/internal/platform"
+	"github.com/dwirx/ghex/internal/shell"
+)
+
+// KeyComment returns the comment of the public key next to a private key,
+// usually the email or user@host it was generated for
+func KeyComment(privateKeyPath string) string {
+	data, err := os.ReadFile(platform.ExpandPath(privateKeyPath) + ".pub")
+	if err != nil {
+		return ""
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) < 3 {
+		return ""
+	}
+	return strings.Join(fields[2:], " ")
+}
+
+// DeviceComment returns the comment for a key of account on device:
+// "account (device)", so keys of the same account on a laptop and a desktop
+// can be told apart. The device is left out when unknown or already there.
+func DeviceComment(account, device string) string {
+	if device == "" {
+		return account
+	}
+	if _, d := SplitDeviceComment(account); strings.EqualFold(d, device) {
+		return account
+	}
+	return fmt.Sprintf("%s (%s)", account, device)
+}
+
+// SplitDeviceComment splits a comment made by DeviceComment into the
+// account part and the device; device is "" for other comments
+func SplitDeviceComment(comment string) (string, string) {
+	comment = strings.TrimSpace(comment)
+	open := strings.LastIndex(comment, " (")
+	if open < 0 || !strings.HasSuffix(comment, ")") {
+		return comment, ""
+	}
+	return comment[:open], comment[open+2 : len(comment)-1]
+}
+
+// SetKeyComment changes the comment of a key pair. With ssh-keygen, both
+// files are rewritten (ssh-keygen -c, which also converts PEM keys to the
+// OpenSSH format); it asks for the passphrase of a protected key on the
+// terminal. Without ssh-keygen only the .pub file is updated. It reports
+// whether the private key was updated too.
+func SetKeyComment(keyPath, comment string) (bool, error) {
+	keyPath = platform.ExpandPath(keyPath)
+	if strings.ContainsAny(comment, "\r\n") {
+		return false, fmt.Errorf("the comment must be a single line")
+	}
+
+	info, err := IdentifyKey(keyPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read key: %w", err)
+	}
+	if info.Kind != KindPrivateKey {
+		return false, fmt.Errorf("%s is not a private key (%s)", keyPath, info.Kind)
+	}
+
+	if shell.CommandExists("ssh-keygen") {
+		args := []string{"-c", "-C", comment, "-f", platform.ToSSHPath(keyPath)}
+		if info.Encrypted {
+			err = shell.RunInteractive("ssh-keygen", args...)
+		} else {
+			_, err = shell.Run("ssh-keygen", append(args, "-P", "")...)
+		}
+		if err != nil {
+			return false, fmt.Errorf("ssh-keygen failed: %w", err)
+		}
+		return true, SetKeyPermissions(keyPath)
+	}
+
+	return false, setPublicKeyComment(keyPath+".pub", comment)
+}
+
+// setPublicKeyComment replaces the comment of a public key file, keeping
+// the algorithm and key
+func setPublicKeyComment(pubPath, comment string) error {
+	data, err := os.ReadFile(pubPath)
+	if err != nil {
+		return fmt.Errorf("failed to read public key: %w", err)
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) < 2 {
+		return fmt.Errorf("%s is not a public key", pubPath)
+	}
+
+	line := fields[0] + " " + fields[1]
+	if comment != "" {
+		line += " " + comment
+	}
+	if err := os.WriteFile(pubPath, []byte(line+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write public key: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package ssh
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dwirx/ghex/internal/shell"
+)
+
+// TestSetPublicKeyComment tests replacing, adding and removing the comment
+// of a public key file
+func TestSetPublicKeyComment(t *testing.T) {
+	sshDir := setupKeyDir(t)
+	key := filepath.Join(sshDir, "id_work")
+	writeKey(t, key, 0600)
+
+	tests := []struct {
+		pub, comment, want string
+	}{
+		{"ssh-ed25519 AAAA old@laptop\n", "me@work.example", "me@work.example"},
+		{"ssh-ed25519 AAAA\n", "work laptop", "work laptop"},
+		{"ssh-ed25519 AAAA old\n", "", ""},
+	}
+	for _, tt := range tests {
+		if err := os.WriteFile(key+".pub", []byte(tt.pub), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := setPublicKeyComment(key+".pub", tt.comment); err != nil {
+			t.Fatalf("setPublicKeyComment(%q) error = %v", tt.comment, err)
+		}
+		if got := KeyComment(key); got != tt.want {
+			t.Errorf("comment of %q after setting %q = %q, want %q", tt.pub, tt.comment, got, tt.want)
+		}
+	}
+
+	if err := os.WriteFile(key+".pub", []byte("garbage"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := setPublicKeyComment(key+".pub", "x"); err == nil {
+		t.Error("setPublicKeyComment() on a file without a key succeeded")
+	}
+}
+
+// TestSetKeyComment tests rewriting both files of a generated key pair
+func TestSetKeyComment(t *testing.T) {
+	if !shell.CommandExists("ssh-keygen") {
+		t.Skip("ssh-keygen not installed")
+	}
+	sshDir := setupKeyDir(t)
+	key := filepath.Join(sshDir, "id_ed25519")
+	if err := GenerateKey(key, "old@laptop"); err != nil {
+		t.Fatal(err)
+	}
+
+	private, err := SetKeyComment(key, "me@work.example")
+	if err != nil || !private {
+		t.Fatalf("SetKeyComment() = %v, %v, want the private key updated", private, err)
+	}
+	if got := KeyComment(key); got != "me@work.example" {
+		t.Errorf("KeyComment() = %q, want me@work.example", got)
+	}
+
+	if _, err := SetKeyComment(key+".pub", "x"); err == nil {
+		t.Error("SetKeyComment() on a public key succeeded")
+	}
+}
+
+// TestDeviceComment tests adding the device to a comment and splitting it
+// off again
+func TestDeviceComment(t *testing.T) {
+	tests := []struct {
+		account, device, want string
+	}{
+		{"me@work.example", "laptop", "me@work.example (laptop)"},
+		{"me@work.example", "", "me@work.example"},
+		{"me@work.example (laptop)", "Laptop", "me@work.example (laptop)"},
+	}
+	for _, tt := range tests {
+		got := DeviceComment(tt.account, tt.device)
+		if got != tt.want {
+			t.Errorf("DeviceComment(%q, %q) = %q, want %q", tt.account, tt.device, got, tt.want)
+		}
+		if account, device := SplitDeviceComment(got); tt.device != "" && (account != "me@work.example" || device != "laptop") {
+			t.Errorf("SplitDeviceComment(%q) = %q, %q", got, account, device)
+		}
+	}
+	if account, device := SplitDeviceComment("me@laptop"); account != "me@laptop" || device != "" {
+		t.Errorf("SplitDeviceComment(me@laptop) = %q, %q", account, device)
+	}
+}
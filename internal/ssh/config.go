@@ -5,25 +5,69 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/dwirx/ghex/internal/config"
+	"github.com/dwirx/ghex/internal/i18n"
 	"github.com/dwirx/ghex/internal/platform"
+	"github.com/dwirx/ghex/internal/shell"
 )
 
+// HostOptions holds optional per-host settings for Host blocks and connection tests
+type HostOptions struct {
+	Port         int    // SSH port (0 = default)
+	ProxyJump    string // Jump host(s) for bastion setups
+	ProxyCommand string // Raw ProxyCommand, ignored when ProxyJump is set
+}
+
+// OptionsForAccount builds HostOptions for an account
+func OptionsForAccount(acc *config.Account) HostOptions {
+	if acc == nil {
+		return HostOptions{}
+	}
+	return OptionsFromConfig(acc.SSH)
+}
+
+// OptionsFromConfig builds HostOptions from an account's SSH configuration
+func OptionsFromConfig(cfg *config.SshConfig) HostOptions {
+	if cfg == nil {
+		return HostOptions{}
+	}
+	return HostOptions{
+		Port:         cfg.Port,
+		ProxyJump:    cfg.ProxyJump,
+		ProxyCommand: cfg.ProxyCommand,
+	}
+}
+
 // GetSSHConfigPath returns the path to SSH config file
 func GetSSHConfigPath() string {
-	return filepath.Join(platform.GetSSHDir(), "config")
+	return platform.GetSSHConfigPath()
 }
 
 // EnsureConfigBlock ensures an SSH Host block exists in the config file
 // If the block already exists, it updates it; otherwise, it appends a new block
 func EnsureConfigBlock(alias, keyPath, hostname string) error {
+	return EnsureConfigBlockWithOptions(alias, keyPath, hostname, HostOptions{})
+}
+
+// EnsureConfigBlockWithOptions is like EnsureConfigBlock but also writes
+// Port, ProxyJump and ProxyCommand directives when set.
+// The block goes where the SSH config mode says: ~/.ssh/config, the
+// included ghex.conf, or nowhere (printed for the user to add).
+func EnsureConfigBlockWithOptions(alias, keyPath, hostname string, opts HostOptions) error {
 	if hostname == "" {
 		hostname = "github.com"
 	}
 
-	sshDir := platform.GetSSHDir()
-	configPath := GetSSHConfigPath()
+	if configMode == ConfigModePrint {
+		printManualBlock(BuildHostBlock(alias, keyPath, hostname, opts))
+		return nil
+	}
+
+	configPath := ConfigTargetPath()
+	sshDir := filepath.Dir(configPath)
 
 	// Ensure SSH directory exists with proper permissions
 	if err := platform.EnsureDir(sshDir, 0700); err != nil {
@@ -46,7 +90,7 @@ func EnsureConfigBlock(alias, keyPath, hostname string) error {
 	}
 
 	// Build the new Host block
-	block := buildHostBlock(alias, keyPath, hostname)
+	block := BuildHostBlock(alias, keyPath, hostname, opts)
 
 	// Check if Host block already exists
 	if containsHostBlock(content, alias) {
@@ -68,19 +112,33 @@ func EnsureConfigBlock(alias, keyPath, hostname string) error {
 		return fmt.Errorf("failed to write SSH config: %w", err)
 	}
 
+	if configMode == ConfigModeInclude {
+		warnMissingInclude()
+	}
 	return nil
 }
 
-// buildHostBlock creates an SSH Host block string
-func buildHostBlock(alias, keyPath, hostname string) string {
+// BuildHostBlock creates an SSH Host block string
+func BuildHostBlock(alias, keyPath, hostname string, opts HostOptions) string {
 	// Normalize path separators for SSH config using ToSSHPath
 	// This handles Git Bash (C:/path -> /c/path) and Windows backslashes
 	keyPath = platform.ToSSHPath(keyPath)
-	return fmt.Sprintf(`Host %s
+	block := fmt.Sprintf(`Host %s
   HostName %s
   User git
   IdentityFile %s
   IdentitiesOnly yes`, alias, hostname, keyPath)
+
+	if opts.Port > 0 && opts.Port != 22 {
+		block += "\n  Port " + strconv.Itoa(opts.Port)
+	}
+	if opts.ProxyJump != "" {
+		block += "\n  ProxyJump " + opts.ProxyJump
+	} else if opts.ProxyCommand != "" {
+		block += "\n  ProxyCommand " + opts.ProxyCommand
+	}
+
+	return block
 }
 
 // containsHostBlock checks if a Host block exists in the config
@@ -129,7 +187,11 @@ func updateHostBlock(content, alias, newBlock string) string {
 
 // RemoveHostBlock removes a Host block from the SSH config
 func RemoveHostBlock(alias string) error {
-	configPath := GetSSHConfigPath()
+	if configMode == ConfigModePrint {
+		fmt.Println(i18n.T("ghex is not editing %s (SSH config mode: print). Remove the \"Host %s\" block yourself if you no longer need it.", GetSSHConfigPath(), alias))
+		return nil
+	}
+	configPath := ConfigTargetPath()
 
 	data, err := os.ReadFile(configPath)
 	if err != nil {
@@ -177,9 +239,114 @@ func RemoveHostBlock(alias string) error {
 	return os.WriteFile(configPath, []byte(newContent), 0600)
 }
 
+// HostBlock is a Host block of the SSH config file ghex writes to
+type HostBlock struct {
+	Alias        string
+	HostName     string
+	IdentityFile string
+	Port         int
+	ProxyJump    string
+	ProxyCommand string
+	Managed      bool // Written by ghex: in the include-mode file, or shaped like the blocks ghex writes
+}
+
+// Options returns the block's port and proxy settings
+func (b HostBlock) Options() HostOptions {
+	return HostOptions{Port: b.Port, ProxyJump: b.ProxyJump, ProxyCommand: b.ProxyCommand}
+}
+
+// ghexBlockKeys are the keywords of the Host blocks ghex writes
+var ghexBlockKeys = map[string]bool{
+	"hostname": true, "user": true, "identityfile": true, "identitiesonly": true,
+	"port": true, "proxyjump": true, "proxycommand": true,
+}
+
+// ListHostBlocks parses the single-alias Host blocks of the file ghex writes
+// Host blocks to. It returns nothing in print mode.
+func ListHostBlocks() ([]HostBlock, error) {
+	configPath := ConfigTargetPath()
+	if configPath == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read SSH config: %w", err)
+	}
+	return ParseHostBlocks(string(data), configMode == ConfigModeInclude), nil
+}
+
+// ParseHostBlocks parses the single-alias Host blocks of SSH config
+// content. Blocks are marked Managed when allManaged is set or when they
+// are shaped like the blocks ghex writes.
+func ParseHostBlocks(content string, allManaged bool) []HostBlock {
+	var blocks []HostBlock
+	var current *HostBlock
+	var keys map[string]string
+	handWritten := false // Extra or repeated options, wherever they appear in the block
+	finish := func() {
+		if current == nil {
+			return
+		}
+		current.Managed = current.Managed || (!handWritten &&
+			keys["user"] == "git" && strings.EqualFold(keys["identitiesonly"], "yes") && current.IdentityFile != "")
+		blocks = append(blocks, *current)
+		current = nil
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, _ := strings.Cut(line, " ")
+		key = strings.ToLower(key)
+		value = strings.Trim(strings.TrimSpace(value), `"`)
+
+		switch key {
+		case "host", "match":
+			finish()
+			// Patterns and Match blocks are never ghex's
+			if key == "host" && !strings.ContainsAny(value, " *?!") {
+				current = &HostBlock{Alias: value, Managed: allManaged}
+				keys = map[string]string{}
+				handWritten = false
+			}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		if !ghexBlockKeys[key] || keys[key] != "" {
+			// Extra or repeated options mean the block was written by hand
+			handWritten = true
+		}
+		keys[key] = value
+		switch key {
+		case "hostname":
+			current.HostName = value
+		case "identityfile":
+			current.IdentityFile = value
+		case "port":
+			current.Port, _ = strconv.Atoi(value)
+		case "proxyjump":
+			current.ProxyJump = value
+		case "proxycommand":
+			current.ProxyCommand = value
+		}
+	}
+	finish()
+	return blocks
+}
+
 // GetHostBlock retrieves a Host block from the SSH config
 func GetHostBlock(alias string) (string, error) {
-	configPath := GetSSHConfigPath()
+	configPath := ConfigTargetPath()
+	if configPath == "" {
+		configPath = GetSSHConfigPath()
+	}
 
 	data, err := os.ReadFile(configPath)
 	if err != nil {
@@ -217,3 +384,47 @@ func GetHostBlock(alias string) (string, error) {
 
 	return strings.Join(blockLines, "\n"), nil
 }
+
+// ResolvedConfig is the effective SSH configuration for a host, as
+// reported by `ssh -G`
+type ResolvedConfig struct {
+	HostName       string
+	User           string
+	Port           int
+	IdentityFiles  []string
+	IdentitiesOnly bool
+}
+
+// ResolveConfig asks ssh which settings it would use to connect to host,
+// using the same ssh command git does
+func ResolveConfig(host string, port int) (*ResolvedConfig, error) {
+	program, args := platform.GetSSHCommand()
+	args = append(args, "-G")
+	if port > 0 {
+		args = append(args, "-p", strconv.Itoa(port))
+	}
+	args = append(args, host)
+
+	output, err := shell.Run(program, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ssh -G %s failed: %w", host, err)
+	}
+
+	resolved := &ResolvedConfig{}
+	for _, line := range strings.Split(output, "\n") {
+		key, value, _ := strings.Cut(strings.TrimSpace(line), " ")
+		switch strings.ToLower(key) {
+		case "hostname":
+			resolved.HostName = value
+		case "user":
+			resolved.User = value
+		case "port":
+			resolved.Port, _ = strconv.Atoi(value)
+		case "identityfile":
+			resolved.IdentityFiles = append(resolved.IdentityFiles, value)
+		case "identitiesonly":
+			resolved.IdentitiesOnly = value == "yes"
+		}
+	}
+	return resolved, nil
+}
@@ -0,0 +1,41 @@
+package ssh
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestParseHostBlocksManaged tests which blocks are recognized as written
+// by ghex, whatever the order of their options
+func TestParseHostBlocksManaged(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  bool
+	}{
+		{"ghex block", []string{"HostName github.com", "User git", "IdentityFile ~/.ssh/id_work", "IdentitiesOnly yes"}, true},
+		{"ghex block with port and proxy", []string{"HostName h", "User git", "IdentityFile ~/.ssh/id", "IdentitiesOnly yes", "Port 2222", "ProxyJump bastion"}, true},
+		{"extra option first", []string{"ForwardAgent yes", "User git", "IdentityFile ~/.ssh/id", "IdentitiesOnly yes"}, false},
+		{"extra option before user", []string{"HostName box", "ForwardAgent yes", "User git", "IdentityFile ~/.ssh/id", "IdentitiesOnly yes"}, false},
+		{"extra option last", []string{"HostName box", "User git", "IdentityFile ~/.ssh/id", "IdentitiesOnly yes", "ForwardAgent yes"}, false},
+		{"repeated option", []string{"User git", "IdentityFile ~/.ssh/a", "IdentityFile ~/.ssh/b", "IdentitiesOnly yes"}, false},
+		{"other user", []string{"HostName box", "User me", "IdentityFile ~/.ssh/id", "IdentitiesOnly yes"}, false},
+		{"no identity file", []string{"HostName box", "User git", "IdentitiesOnly yes"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := "Host box\n  " + strings.Join(tt.lines, "\n  ") + "\n\nHost next\n  HostName n\n  User git\n  IdentityFile ~/.ssh/n\n  IdentitiesOnly yes\n"
+			blocks := ParseHostBlocks(content, false)
+			if len(blocks) != 2 {
+				t.Fatalf("ParseHostBlocks() = %+v, want 2 blocks", blocks)
+			}
+			if blocks[0].Managed != tt.want {
+				t.Errorf("Managed = %v, want %v", blocks[0].Managed, tt.want)
+			}
+			if !blocks[1].Managed {
+				t.Error("A hand-written block made the next block hand-written")
+			}
+		})
+	}
+}
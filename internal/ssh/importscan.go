@@ -0,0 +1,187 @@
+package ssh
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dwirx/ghex/internal/config"
+	"github.com/dwirx/ghex/internal/platform"
+)
+
+// Reasons an import proposal gives for its account, strongest first
+const (
+	MatchEmail    = "comment matches email"
+	MatchUsername = "comment matches username"
+	MatchFilename = "filename matches account"
+)
+
+// matchRank orders the reasons, lower is stronger
+var matchRank = map[string]int{MatchEmail: 0, MatchUsername: 1, MatchFilename: 2}
+
+// ImportProposal is a key found in ~/.ssh that no account uses, with the
+// account it probably belongs to
+type ImportProposal struct {
+	Key     KeyInfo
+	Comment string   // Comment of the key's .pub file (empty if there is none)
+	Account string   // Proposed account (empty if nothing matched)
+	Reason  string   // Why Account was proposed, one of the Match constants
+	Hosts   []string // Aliases of the Host blocks naming the key as IdentityFile
+}
+
+// ProposeImports returns a proposal for each private key among keys that no
+// account is configured with. Keys are matched to accounts by the comment
+// of their public key (against the account's emails and usernames) and by
+// their filename (against account names and usernames). Accounts whose key
+// file exists are left out, and each account is proposed once, for its
+// strongest match. Hosts lists the blocks among blocks, ghex-managed or not,
+// that use the key.
+func ProposeImports(cfg *config.AppConfig, blocks []HostBlock, keys []KeyInfo) []ImportProposal {
+	var paths []string
+	for _, key := range keys {
+		if key.Kind == KindPrivateKey {
+			paths = append(paths, key.Path)
+		}
+	}
+	uses := KeyUses(cfg, nil, paths)
+
+	var candidates []*config.Account
+	if cfg != nil {
+		for i := range cfg.Accounts {
+			acc := &cfg.Accounts[i]
+			if acc.SSH != nil && acc.SSH.KeyPath != "" && platform.FileExists(platform.ExpandPath(acc.SSH.KeyPath)) {
+				continue
+			}
+			candidates = append(candidates, acc)
+		}
+	}
+
+	type match struct {
+		proposal int
+		account  string
+		reason   string
+	}
+	var proposals []ImportProposal
+	var matches []match
+	for _, key := range keys {
+		if key.Kind != KindPrivateKey || !uses[key.Path].Orphan() {
+			continue
+		}
+		p := ImportProposal{Key: key, Comment: KeyComment(key.Path)}
+		for _, block := range blocks {
+			if block.IdentityFile != "" && keyPathID(block.IdentityFile) == keyPathID(key.Path) {
+				p.Hosts = append(p.Hosts, block.Alias)
+			}
+		}
+		for _, acc := range candidates {
+			if reason := matchKey(acc, p.Comment, filepath.Base(key.Path)); reason != "" {
+				matches = append(matches, match{proposal: len(proposals), account: acc.Name, reason: reason})
+			}
+		}
+		proposals = append(proposals, p)
+	}
+
+	// Assign the strongest matches first; matches keeps key order within a
+	// rank, so the first key wins a tie
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matchRank[matches[i].reason] < matchRank[matches[j].reason]
+	})
+	taken := map[string]bool{}
+	for _, m := range matches {
+		if taken[m.account] || proposals[m.proposal].Account != "" {
+			continue
+		}
+		taken[m.account] = true
+		proposals[m.proposal].Account = m.account
+		proposals[m.proposal].Reason = m.reason
+	}
+	return proposals
+}
+
+// matchKey returns why a key with comment and filename belongs to acc, or
+// "" if it doesn't seem to
+func matchKey(acc *config.Account, comment, filename string) string {
+	comment, _ = SplitDeviceComment(comment)
+	comment = strings.ToLower(comment)
+	names := []string{strings.ToLower(acc.Name), strings.ToLower(acc.GitUserName)}
+
+	if comment != "" {
+		for _, email := range append([]string{acc.GitEmail}, acc.Emails...) {
+			if email != "" && strings.EqualFold(email, comment) {
+				return MatchEmail
+			}
+		}
+		user, _, _ := strings.Cut(comment, "@")
+		for _, name := range names {
+			if name != "" && (name == comment || name == user) {
+				return MatchUsername
+			}
+		}
+	}
+
+	for _, part := range filenameParts.Split(strings.ToLower(strings.TrimPrefix(filename, "id_")), -1) {
+		for _, name := range names {
+			if name != "" && name == part {
+				return MatchFilename
+			}
+		}
+	}
+	// Account names may contain separators themselves, e.g. "my-work"
+	base := strings.ToLower(filename)
+	for _, name := range names {
+		if strings.ContainsAny(name, "_-.") && (strings.HasSuffix(base, "_"+name) || strings.HasSuffix(base, "-"+name)) {
+			return MatchFilename
+		}
+	}
+	return ""
+}
+
+// filenameParts splits key filenames like id_ed25519_work into words
+var filenameParts = regexp.MustCompile(`[_.-]+`)
+
+// unsafeKeyName matches what NormalizedKeyName drops from account names
+var unsafeKeyName = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// NormalizedKeyName returns the filename a key for account is given, in
+// the form ssh-keygen names keys: id_<type>_<account>, e.g. id_ed25519_work
+func NormalizedKeyName(key KeyInfo, account string) string {
+	keyType := strings.ReplaceAll(strings.ToLower(key.Type), "-", "_")
+	switch keyType {
+	case "ed25519", "ed25519_sk", "ecdsa", "ecdsa_sk", "rsa", "dsa":
+	default:
+		keyType = "ed25519"
+	}
+	name := unsafeKeyName.ReplaceAllString(strings.ToLower(account), "")
+	if name == "" {
+		name = "account"
+	}
+	return fmt.Sprintf("id_%s_%s", keyType, name)
+}
+
+// RenameKey moves a private key and its public key to destPath. It fails
+// rather than replace an existing file.
+func RenameKey(srcPath, destPath string) error {
+	srcPath = platform.ExpandPath(srcPath)
+	destPath = platform.ExpandPath(destPath)
+
+	if platform.FileExists(destPath) {
+		return fmt.Errorf("%s already exists", destPath)
+	}
+	hasPub := platform.FileExists(srcPath + ".pub")
+	if hasPub && platform.FileExists(destPath+".pub") {
+		return fmt.Errorf("%s.pub already exists", destPath)
+	}
+
+	if err := os.Rename(srcPath, destPath); err != nil {
+		return fmt.Errorf("failed to rename key: %w", err)
+	}
+	if hasPub {
+		if err := os.Rename(srcPath+".pub", destPath+".pub"); err != nil {
+			return fmt.Errorf("failed to rename public key: %w", err)
+		}
+	}
+	return SetKeyPermissions(destPath)
+}
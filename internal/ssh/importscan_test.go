@@ -0,0 +1,109 @@
+package ssh
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dwirx/ghex/internal/config"
+)
+
+// TestProposeImports tests matching unused keys to accounts by comment and
+// filename, each account proposed once for its strongest match
+func TestProposeImports(t *testing.T) {
+	sshDir := setupKeyDir(t)
+
+	writePub := func(name, comment string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(sshDir, name+".pub"), []byte("ssh-ed25519 AAAA "+comment+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{"id_ed25519", "id_rsa", "id_personal", "id_used", "id_other"} {
+		writeKey(t, filepath.Join(sshDir, name), 0600)
+	}
+	writePub("id_ed25519", "Me@Work.example (laptop)")
+	writePub("id_rsa", "me@work.example")
+	writePub("id_other", "someone@laptop")
+
+	cfg := &config.AppConfig{Accounts: []config.Account{
+		{Name: "work", GitEmail: "me@work.example"},
+		{Name: "personal", GitUserName: "me-home"},
+		{Name: "used", SSH: &config.SshConfig{KeyPath: "~/.ssh/id_used"}},
+	}}
+
+	var keys []KeyInfo
+	for _, name := range []string{"id_ed25519", "id_rsa", "id_personal", "id_used", "id_other"} {
+		keys = append(keys, KeyInfo{Path: filepath.Join(sshDir, name), Kind: KindPrivateKey})
+	}
+
+	blocks := []HostBlock{{Alias: "old-work", IdentityFile: "~/.ssh/id_rsa"}}
+
+	got := map[string]ImportProposal{}
+	for _, p := range ProposeImports(cfg, blocks, keys) {
+		got[filepath.Base(p.Key.Path)] = p
+	}
+	if _, ok := got["id_used"]; ok {
+		t.Errorf("key used by an account was proposed: %+v", got["id_used"])
+	}
+	if p := got["id_ed25519"]; p.Account != "work" || p.Reason != MatchEmail || p.Comment != "Me@Work.example (laptop)" {
+		t.Errorf("id_ed25519 = %+v, want work by email", p)
+	}
+	if p := got["id_rsa"]; p.Account != "" || len(p.Hosts) != 1 || p.Hosts[0] != "old-work" {
+		t.Errorf("id_rsa = %+v, want no account (work is taken) and Host old-work", p)
+	}
+	if p := got["id_personal"]; p.Account != "personal" || p.Reason != MatchFilename {
+		t.Errorf("id_personal = %+v, want personal by filename", p)
+	}
+	if p := got["id_other"]; p.Account != "" {
+		t.Errorf("id_other = %+v, want no account", p)
+	}
+}
+
+// TestNormalizedKeyName tests the id_<type>_<account> names
+func TestNormalizedKeyName(t *testing.T) {
+	tests := []struct {
+		key     KeyInfo
+		account string
+		want    string
+	}{
+		{KeyInfo{Type: "ED25519"}, "work", "id_ed25519_work"},
+		{KeyInfo{Type: "RSA"}, "Work Account", "id_rsa_workaccount"},
+		{KeyInfo{Type: "ECDSA-SK"}, "my-work", "id_ecdsa_sk_my-work"},
+		{KeyInfo{}, "work", "id_ed25519_work"},
+	}
+	for _, tt := range tests {
+		if got := NormalizedKeyName(tt.key, tt.account); got != tt.want {
+			t.Errorf("NormalizedKeyName(%q, %q) = %q, want %q", tt.key.Type, tt.account, got, tt.want)
+		}
+	}
+}
+
+// TestRenameKey tests moving a key with its public key and refusing to
+// replace an existing key
+func TestRenameKey(t *testing.T) {
+	sshDir := setupKeyDir(t)
+	src := filepath.Join(sshDir, "id_ed25519")
+	dest := filepath.Join(sshDir, "id_ed25519_work")
+	writeKey(t, src, 0600)
+	if err := os.WriteFile(src+".pub", []byte("ssh-ed25519 AAAA me\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RenameKey(src, dest); err != nil {
+		t.Fatalf("RenameKey() error = %v", err)
+	}
+	for _, path := range []string{dest, dest + ".pub"} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("%s missing after rename: %v", path, err)
+		}
+	}
+	if _, err := os.Stat(src); !os.IsNotExist(err) {
+		t.Errorf("source still exists after rename")
+	}
+
+	writeKey(t, src, 0600)
+	if err := RenameKey(src, dest); err == nil {
+		t.Error("RenameKey() onto an existing key succeeded")
+	}
+}
@@ -0,0 +1,109 @@
+package ssh
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/dwirx/ghex/internal/platform"
+)
+
+// keyScan is the last listing of the key files in an SSH directory. It
+// stays valid while the directory's modification time is unchanged, which
+// adding, removing or renaming a file changes.
+type keyScan struct {
+	dir     string
+	modTime time.Time
+	files   []KeyInfo
+}
+
+var (
+	keyScanMu   sync.Mutex
+	lastKeyScan *keyScan
+)
+
+// cachedKeyFiles returns the files of the last scan of dir, if it is still
+// valid
+func cachedKeyFiles(dir string, info os.FileInfo) ([]KeyInfo, bool) {
+	keyScanMu.Lock()
+	defer keyScanMu.Unlock()
+	if lastKeyScan == nil || lastKeyScan.dir != dir || !lastKeyScan.modTime.Equal(info.ModTime()) {
+		return nil, false
+	}
+	return append([]KeyInfo{}, lastKeyScan.files...), true
+}
+
+// rememberKeyFiles stores a scan of dir made when it had info's
+// modification time
+func rememberKeyFiles(dir string, info os.FileInfo, files []KeyInfo) {
+	keyScanMu.Lock()
+	defer keyScanMu.Unlock()
+	lastKeyScan = &keyScan{dir: dir, modTime: info.ModTime(), files: append([]KeyInfo{}, files...)}
+}
+
+// permissionCacheFile holds the keys FixAllKeyPermissions found private,
+// in the ghex cache directory
+const permissionCacheFile = "ssh-permissions.json"
+
+// checkedKey is the state of a key when its permissions were last found
+// private
+type checkedKey struct {
+	ModTime time.Time   `json:"mod_time"`
+	Mode    os.FileMode `json:"mode"`
+}
+
+// permissionCache remembers which keys had private permissions, so later
+// runs skip the checks of unchanged keys. That matters on Windows and in
+// Git Bash, where each check starts icacls or stat.
+type permissionCache struct {
+	Keys map[string]checkedKey `json:"keys"`
+}
+
+// permissionCachePath returns the path of the permission cache
+func permissionCachePath() string {
+	return filepath.Join(platform.GetCacheDir("ghex"), permissionCacheFile)
+}
+
+// loadPermissionCache reads the permission cache, empty when there is none
+func loadPermissionCache() *permissionCache {
+	cache := &permissionCache{Keys: map[string]checkedKey{}}
+	data, err := os.ReadFile(permissionCachePath())
+	if err != nil {
+		return cache
+	}
+	if json.Unmarshal(data, cache) != nil || cache.Keys == nil {
+		cache.Keys = map[string]checkedKey{}
+	}
+	return cache
+}
+
+// unchanged reports whether key was found private and has neither been
+// modified nor had its mode changed since
+func (c *permissionCache) unchanged(key string, info os.FileInfo) bool {
+	checked, ok := c.Keys[key]
+	return ok && checked.ModTime.Equal(info.ModTime()) && checked.Mode == info.Mode()
+}
+
+// save writes the cache, keeping only the given keys. Failing to write it
+// only costs the next run a full check, so errors are ignored.
+func (c *permissionCache) save(keys []string) {
+	kept := map[string]checkedKey{}
+	for _, key := range keys {
+		if checked, ok := c.Keys[key]; ok {
+			kept[key] = checked
+		}
+	}
+	c.Keys = kept
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return
+	}
+	path := permissionCachePath()
+	if err := platform.EnsureDir(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0600)
+}
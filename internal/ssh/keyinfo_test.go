@@ -0,0 +1,139 @@
+package ssh
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// wireString encodes fields as SSH wire format strings
+func wireString(fields ...[]byte) []byte {
+	var out []byte
+	for _, f := range fields {
+		out = binary.BigEndian.AppendUint32(out, uint32(len(f)))
+		out = append(out, f...)
+	}
+	return out
+}
+
+// openSSHKey returns an OpenSSH private key file holding the public key
+// blob, encrypted with cipher ("none" for no passphrase)
+func openSSHKey(cipher string, blob []byte) string {
+	data := []byte("openssh-key-v1\x00")
+	data = append(data, wireString([]byte(cipher), []byte("none"), nil)...)
+	data = binary.BigEndian.AppendUint32(data, 1)
+	data = append(data, wireString(blob, []byte("private part"))...)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "OPENSSH PRIVATE KEY", Bytes: data}))
+}
+
+// TestClassifyKey tests telling keys, certificates and other files apart
+func TestClassifyKey(t *testing.T) {
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edBlob := wireString([]byte("ssh-ed25519"), edPub)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaBlob := wireString([]byte("ssh-rsa"), []byte{1, 0, 1}, append([]byte{0}, rsaKey.N.Bytes()...))
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(edPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certBlob := wireString([]byte("ssh-ed25519-cert-v01@openssh.com"), []byte("nonce"), edPub)
+	putty := strings.Join([]string{
+		"PuTTY-User-Key-File-3: ssh-rsa",
+		"Encryption: aes256-cbc",
+		"Comment: work",
+		"Public-Lines: 1",
+		base64.StdEncoding.EncodeToString(rsaBlob),
+		"Private-Lines: 1",
+		"AAAA",
+	}, "\n")
+
+	tests := []struct {
+		name string
+		data string
+		want KeyInfo
+	}{
+		{"openssh ed25519", openSSHKey("none", edBlob), KeyInfo{Kind: KindPrivateKey, Type: "ED25519", Bits: 256}},
+		{"openssh rsa encrypted", openSSHKey("aes256-ctr", rsaBlob), KeyInfo{Kind: KindPrivateKey, Type: "RSA", Bits: 1024, Encrypted: true}},
+		{"pkcs1 rsa", string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})), KeyInfo{Kind: KindPrivateKey, Type: "RSA", Bits: 1024}},
+		{"pkcs8 ed25519", string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8})), KeyInfo{Kind: KindPrivateKey, Type: "ED25519", Bits: 256}},
+		{"putty", putty, KeyInfo{Kind: KindPuTTYKey, Type: "RSA", Bits: 1024, Encrypted: true}},
+		{"public key", "ssh-ed25519 " + base64.StdEncoding.EncodeToString(edBlob) + " me@laptop", KeyInfo{Kind: KindPublicKey, Type: "ED25519", Bits: 256}},
+		{"certificate", "ssh-ed25519-cert-v01@openssh.com " + base64.StdEncoding.EncodeToString(certBlob), KeyInfo{Kind: KindCertificate, Type: "ED25519", Bits: 256}},
+		{"notes", "passwords are in the vault\n", KeyInfo{Kind: KindOther}},
+		{"pem csr", string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: []byte{1}})), KeyInfo{Kind: KindOther}},
+	}
+	for _, tt := range tests {
+		if got := ClassifyKey([]byte(tt.data)); got != tt.want {
+			t.Errorf("%s: ClassifyKey() = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestKeyInfoLabel tests that sizes are left out where they are fixed
+func TestKeyInfoLabel(t *testing.T) {
+	for _, tt := range []struct {
+		info KeyInfo
+		want string
+	}{
+		{KeyInfo{Kind: KindPrivateKey, Type: "RSA", Bits: 4096}, "RSA 4096"},
+		{KeyInfo{Kind: KindPrivateKey, Type: "ED25519", Bits: 256}, "ED25519"},
+		{KeyInfo{Kind: KindPrivateKey}, "private key"},
+	} {
+		if got := tt.info.Label(); got != tt.want {
+			t.Errorf("Label() of %+v = %q, want %q", tt.info, got, tt.want)
+		}
+	}
+}
+
+// TestListPrivateKeysByContent tests that only files holding a private key
+// are listed as keys
+func TestListPrivateKeysByContent(t *testing.T) {
+	sshDir := setupKeyDir(t)
+	writeKey(t, filepath.Join(sshDir, "id_work"), 0600)
+	for name, content := range map[string]string{
+		"notes.txt":    "remember to rotate keys\n",
+		"work.ppk":     "PuTTY-User-Key-File-3: ssh-ed25519\nEncryption: none\n",
+		"id_work.pub":  "ssh-ed25519 AAAA me@laptop\n",
+		"known_hosts2": "github.com ssh-ed25519 AAAA\n",
+	} {
+		if err := os.WriteFile(filepath.Join(sshDir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	keys, err := ListPrivateKeys()
+	if err != nil || len(keys) != 1 || filepath.Base(keys[0]) != "id_work" {
+		t.Fatalf("ListPrivateKeys() = %v, %v, want only id_work", keys, err)
+	}
+
+	files, err := ListKeyFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	kinds := map[string]KeyKind{}
+	for _, f := range files {
+		kinds[filepath.Base(f.Path)] = f.Kind
+	}
+	if kinds["work.ppk"] != KindPuTTYKey || kinds["notes.txt"] != KindOther || kinds["known_hosts2"] != KindOther {
+		t.Errorf("ListKeyFiles() kinds = %v", kinds)
+	}
+	if _, ok := kinds["id_work.pub"]; ok {
+		t.Errorf("ListKeyFiles() lists public keys: %v", kinds)
+	}
+}
@@ -0,0 +1,129 @@
+package ssh
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/dwirx/ghex/internal/platform"
+	"github.com/dwirx/ghex/internal/shell"
+)
+
+// HostKey is a public host key as found in known_hosts
+type HostKey struct {
+	Host string // Host pattern ("host" or "[host]:port")
+	Type string // e.g. ssh-ed25519
+	Key  string // Base64-encoded key
+}
+
+// Fingerprint returns the key's SHA256 fingerprint as shown by ssh
+func (k HostKey) Fingerprint() string {
+	return fingerprint(k.Key)
+}
+
+// PublicKeyFingerprint returns the SHA256 fingerprint of an authorized_keys
+// style public key ("type base64 [comment]"), or "" if it can't be parsed
+func PublicKeyFingerprint(publicKey string) string {
+	fields := strings.Fields(publicKey)
+	if len(fields) < 2 {
+		return ""
+	}
+	return fingerprint(fields[1])
+}
+
+// fingerprint returns the SHA256 fingerprint of a base64-encoded key
+func fingerprint(key string) string {
+	raw, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(raw)
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+}
+
+// Line returns the known_hosts line for the key
+func (k HostKey) Line() string {
+	return fmt.Sprintf("%s %s %s", k.Host, k.Type, k.Key)
+}
+
+// GetKnownHostsPath returns the path to the user's known_hosts file
+func GetKnownHostsPath() string {
+	return filepath.Join(platform.GetSSHDir(), "known_hosts")
+}
+
+// knownHostPattern returns how host appears in known_hosts
+func knownHostPattern(host string, port int) string {
+	if port > 0 && port != 22 {
+		return fmt.Sprintf("[%s]:%d", host, port)
+	}
+	return host
+}
+
+// IsKnownHost reports whether known_hosts already has a key for host
+// (hashed entries included)
+func IsKnownHost(host string, port int) bool {
+	_, err := shell.Run("ssh-keygen", "-F", knownHostPattern(host, port), "-f", GetKnownHostsPath())
+	return err == nil
+}
+
+// ScanHostKeys fetches a server's public host keys with ssh-keyscan
+func ScanHostKeys(host string, port int) ([]HostKey, error) {
+	args := []string{"-T", "10"}
+	if port > 0 && port != 22 {
+		args = append(args, "-p", strconv.Itoa(port))
+	}
+	args = append(args, host)
+
+	// ssh-keyscan prints progress comments on stderr; only stdout has keys
+	output, err := shell.Run("ssh-keyscan", args...)
+	if err != nil && output == "" {
+		return nil, fmt.Errorf("ssh-keyscan %s failed: %w", host, err)
+	}
+
+	var keys []HostKey
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		keys = append(keys, HostKey{Host: fields[0], Type: fields[1], Key: fields[2]})
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no host keys returned by %s", host)
+	}
+	return keys, nil
+}
+
+// AddKnownHosts appends host keys to known_hosts
+func AddKnownHosts(keys []HostKey) error {
+	path := GetKnownHostsPath()
+	if err := platform.EnsureDir(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create SSH directory: %w", err)
+	}
+
+	// Make sure the new entries start on their own line
+	prefix := ""
+	if data, err := os.ReadFile(path); err == nil && len(data) > 0 && !strings.HasSuffix(string(data), "\n") {
+		prefix = "\n"
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open known_hosts: %w", err)
+	}
+	defer f.Close()
+
+	var b strings.Builder
+	b.WriteString(prefix)
+	for _, k := range keys {
+		b.WriteString(k.Line() + "\n")
+	}
+	if _, err := f.WriteString(b.String()); err != nil {
+		return fmt.Errorf("failed to write known_hosts: %w", err)
+	}
+	return nil
+}
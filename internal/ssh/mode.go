@@ -0,0 +1,110 @@
+package ssh
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/dwirx/ghex/internal/i18n"
+	"github.com/dwirx/ghex/internal/platform"
+)
+
+// How ghex applies Host blocks. Users who manage ~/.ssh/config with
+// chezmoi, ansible and the like can keep ghex from rewriting it.
+const (
+	ConfigModeEdit    = "edit"    // Edit ~/.ssh/config in place (default)
+	ConfigModeInclude = "include" // Write to ~/.ssh/ghex.conf, pulled in with an Include line
+	ConfigModePrint   = "print"   // Write nothing; print the block to add by hand
+)
+
+// ConfigModes lists the valid SSH config modes
+var ConfigModes = []string{ConfigModeEdit, ConfigModeInclude, ConfigModePrint}
+
+// managedConfigName is the file written in include mode, next to ~/.ssh/config
+const managedConfigName = "ghex.conf"
+
+var configMode = ConfigModeEdit
+
+// SetConfigMode selects how Host blocks are applied. An empty or unknown
+// mode selects ConfigModeEdit.
+func SetConfigMode(mode string) {
+	if ValidateConfigMode(mode) != nil || mode == "" {
+		mode = ConfigModeEdit
+	}
+	configMode = mode
+}
+
+// ConfigMode returns the selected SSH config mode
+func ConfigMode() string {
+	return configMode
+}
+
+// ValidateConfigMode returns an error unless mode is a known SSH config mode
+// or empty (the default)
+func ValidateConfigMode(mode string) error {
+	if mode == "" {
+		return nil
+	}
+	for _, m := range ConfigModes {
+		if m == mode {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown SSH config mode '%s' (use %s)", mode, strings.Join(ConfigModes, ", "))
+}
+
+// GetManagedConfigPath returns the file ghex writes Host blocks to in
+// include mode
+func GetManagedConfigPath() string {
+	return filepath.Join(filepath.Dir(GetSSHConfigPath()), managedConfigName)
+}
+
+// ConfigTargetPath returns the file Host blocks are written to, or "" in
+// print mode
+func ConfigTargetPath() string {
+	switch configMode {
+	case ConfigModeInclude:
+		return GetManagedConfigPath()
+	case ConfigModePrint:
+		return ""
+	default:
+		return GetSSHConfigPath()
+	}
+}
+
+// HasManagedInclude reports whether ~/.ssh/config includes the file written
+// in include mode
+func HasManagedInclude() bool {
+	data, err := os.ReadFile(GetSSHConfigPath())
+	if err != nil {
+		return false
+	}
+	pattern := regexp.MustCompile(`(?mi)^\s*Include\s+.*` + regexp.QuoteMeta(managedConfigName) + `\b`)
+	return pattern.Match(data)
+}
+
+// IncludeLine returns the line to add to the top of ~/.ssh/config in
+// include mode
+func IncludeLine() string {
+	return "Include " + platform.ToSSHPath(GetManagedConfigPath())
+}
+
+// printManualBlock shows a Host block for the user to apply themselves
+func printManualBlock(block string) {
+	fmt.Println(i18n.T("ghex is not editing %s (SSH config mode: print). Add or update this block yourself:", GetSSHConfigPath()))
+	fmt.Println()
+	fmt.Println(block)
+	fmt.Println()
+}
+
+// warnMissingInclude reminds the user that blocks in the managed file have
+// no effect until ~/.ssh/config includes it
+func warnMissingInclude() {
+	if HasManagedInclude() {
+		return
+	}
+	fmt.Println(i18n.T("Host blocks are written to %s. Add this line at the top of %s for ssh to use them:", GetManagedConfigPath(), GetSSHConfigPath()))
+	fmt.Println("  " + IncludeLine())
+}
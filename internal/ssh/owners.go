@@ -0,0 +1,88 @@
+package ssh
+
+import (
+	"os"
+	"strings"
+
+	"github.com/dwirx/ghex/internal/config"
+	"github.com/dwirx/ghex/internal/platform"
+)
+
+// KeyUse is what refers to an SSH key
+type KeyUse struct {
+	Accounts []string // Accounts configured with the key
+	Hosts    []string // Aliases of the Host blocks ghex manages that use the key
+}
+
+// Orphan reports whether no account uses the key
+func (u KeyUse) Orphan() bool {
+	return len(u.Accounts) == 0
+}
+
+// MissingKey is an account whose configured key file doesn't exist
+type MissingKey struct {
+	Account string
+	KeyPath string
+}
+
+// keyPathID returns a path in the form key paths are compared in: expanded,
+// cleaned, and on Windows case-insensitive
+func keyPathID(path string) string {
+	path = platform.NormalizePath(path)
+	if platform.IsWindows() {
+		path = strings.ToLower(path)
+	}
+	return path
+}
+
+// KeyUses returns what refers to each of keys: the accounts configured with
+// it and the ghex-managed blocks among blocks naming it as IdentityFile
+func KeyUses(cfg *config.AppConfig, blocks []HostBlock, keys []string) map[string]KeyUse {
+	byID := map[string]*KeyUse{}
+	for _, key := range keys {
+		byID[keyPathID(key)] = &KeyUse{}
+	}
+
+	if cfg != nil {
+		for _, acc := range cfg.Accounts {
+			if acc.SSH == nil || acc.SSH.KeyPath == "" {
+				continue
+			}
+			if use, ok := byID[keyPathID(acc.SSH.KeyPath)]; ok {
+				use.Accounts = append(use.Accounts, acc.Name)
+			}
+		}
+	}
+	for _, block := range blocks {
+		if !block.Managed || block.IdentityFile == "" {
+			continue
+		}
+		if use, ok := byID[keyPathID(block.IdentityFile)]; ok {
+			use.Hosts = append(use.Hosts, block.Alias)
+		}
+	}
+
+	uses := make(map[string]KeyUse, len(keys))
+	for _, key := range keys {
+		uses[key] = *byID[keyPathID(key)]
+	}
+	return uses
+}
+
+// MissingAccountKeys returns the accounts whose configured key file doesn't
+// exist
+func MissingAccountKeys(cfg *config.AppConfig) []MissingKey {
+	if cfg == nil {
+		return nil
+	}
+	var missing []MissingKey
+	for _, acc := range cfg.Accounts {
+		if acc.SSH == nil || acc.SSH.KeyPath == "" {
+			continue
+		}
+		if _, err := os.Stat(platform.ExpandPath(acc.SSH.KeyPath)); os.IsNotExist(err) {
+			missing = append(missing, MissingKey{Account: acc.Name, KeyPath: acc.SSH.KeyPath})
+		}
+	}
+	return missing
+}
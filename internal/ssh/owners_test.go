@@ -0,0 +1,63 @@
+package ssh
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/dwirx/ghex/internal/config"
+)
+
+// TestKeyUses tests matching keys to the accounts and managed Host blocks
+// that use them, whether their paths are written with ~ or in full
+func TestKeyUses(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	sshDir := filepath.Join(home, ".ssh")
+	work := filepath.Join(sshDir, "id_work")
+	old := filepath.Join(sshDir, "id_old")
+
+	cfg := &config.AppConfig{Accounts: []config.Account{
+		{Name: "work", SSH: &config.SshConfig{KeyPath: "~/.ssh/id_work"}},
+		{Name: "work-alt", SSH: &config.SshConfig{KeyPath: work}},
+		{Name: "token-only"},
+	}}
+	blocks := []HostBlock{
+		{Alias: "github-work", IdentityFile: "~/.ssh/id_work", Managed: true},
+		{Alias: "hand-written", IdentityFile: old},
+	}
+
+	uses := KeyUses(cfg, blocks, []string{work, old})
+	if got := uses[work]; !reflect.DeepEqual(got.Accounts, []string{"work", "work-alt"}) || !reflect.DeepEqual(got.Hosts, []string{"github-work"}) || got.Orphan() {
+		t.Errorf("uses of id_work = %+v", got)
+	}
+	if got := uses[old]; !got.Orphan() || len(got.Hosts) != 0 {
+		t.Errorf("uses of id_old = %+v, want an orphan without managed hosts", got)
+	}
+}
+
+// TestMissingAccountKeys tests finding accounts whose key file is gone
+func TestMissingAccountKeys(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	sshDir := filepath.Join(home, ".ssh")
+	if err := os.MkdirAll(sshDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	writeKey(t, filepath.Join(sshDir, "id_work"), 0600)
+
+	cfg := &config.AppConfig{Accounts: []config.Account{
+		{Name: "work", SSH: &config.SshConfig{KeyPath: "~/.ssh/id_work"}},
+		{Name: "gone", SSH: &config.SshConfig{KeyPath: "~/.ssh/id_gone"}},
+		{Name: "token-only"},
+	}}
+	want := []MissingKey{{Account: "gone", KeyPath: "~/.ssh/id_gone"}}
+	if got := MissingAccountKeys(cfg); !reflect.DeepEqual(got, want) {
+		t.Errorf("MissingAccountKeys() = %+v, want %+v", got, want)
+	}
+}
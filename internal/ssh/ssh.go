@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/dwirx/ghex/internal/platform"
@@ -109,10 +110,32 @@ func EnsurePublicKey(privateKeyPath string) (string, error) {
 	return pubPath, nil
 }
 
+// ReadPublicKey returns the public key for a private key, generating the
+// .pub file first if it is missing
+func ReadPublicKey(privateKeyPath string) (string, error) {
+	pubPath, err := EnsurePublicKey(privateKeyPath)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(pubPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read public key: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
 // SetKeyPermissions sets proper permissions on SSH key files
 func SetKeyPermissions(keyPath string) error {
 	keyPath = platform.ExpandPath(keyPath)
 
+	// Native Windows OpenSSH checks ACLs, not modes
+	if platform.IsWindows() && !isGitBash() {
+		if err := restrictWindowsACL(keyPath); err != nil {
+			return fmt.Errorf("failed to set private key permissions: %w", err)
+		}
+		return nil
+	}
+
 	// Set private key permissions (600)
 	if err := os.Chmod(keyPath, 0600); err != nil {
 		// On Windows, chmod might not work as expected
@@ -145,6 +168,32 @@ func EnsureKeyPermissions(keyPath string) (bool, error) {
 		return false, fmt.Errorf("cannot access key: %w", err)
 	}
 
+	// Modes mean nothing to native Windows OpenSSH; it checks the ACL
+	if platform.IsWindows() && !isGitBash() {
+		if windowsACLIsPrivate(keyPath) {
+			return false, nil
+		}
+		if err := restrictWindowsACL(keyPath); err != nil {
+			return false, fmt.Errorf("failed to fix permissions: %w", err)
+		}
+		return true, nil
+	}
+
+	// Go only sees the read-only attribute on Windows, so ask Git Bash for
+	// the mode it emulates and fix it with its chmod
+	if isGitBash() {
+		posixPath := platform.ToSSHPath(keyPath)
+		if out, err := shell.Exec("stat", "-c", "%a", posixPath); err == nil {
+			if mode := strings.TrimSpace(out); mode == "600" || mode == "400" {
+				return false, nil
+			}
+		}
+		if out, err := shell.Exec("chmod", "600", posixPath); err != nil {
+			return false, fmt.Errorf("failed to fix permissions: %s", strings.TrimSpace(out))
+		}
+		return true, nil
+	}
+
 	// Get current permissions
 	currentMode := info.Mode().Perm()
 
@@ -154,9 +203,7 @@ func EnsureKeyPermissions(keyPath string) (bool, error) {
 	if needsFix {
 		// Fix permissions
 		if err := os.Chmod(keyPath, 0600); err != nil {
-			if !platform.IsWindows() {
-				return false, fmt.Errorf("failed to fix permissions: %w", err)
-			}
+			return false, fmt.Errorf("failed to fix permissions: %w", err)
 		}
 		return true, nil
 	}
@@ -170,57 +217,44 @@ func TestConnection(host string) (bool, string, error) {
 }
 
 // FixAllKeyPermissions fixes permissions for all SSH keys in ~/.ssh directory
+// It is only run on request (ghex ssh fix-permissions): ~/.ssh can hold
+// files ghex does not manage, such as hardware-token stubs, which should not
+// be touched behind the user's back. Keys whose mode is already private are
+// left alone and not counted, and keys found private by an earlier run are
+// not checked again until they are modified or their mode changes.
 func FixAllKeyPermissions() (int, error) {
 	keys, err := ListPrivateKeys()
 	if err != nil {
 		return 0, err
 	}
 
+	cache := loadPermissionCache()
+	defer cache.save(keys)
+
 	fixed := 0
+	var errs []string
 	for _, key := range keys {
-		// Use ForceFixKeyPermissions for more reliable permission fixing
-		if ForceFixKeyPermissions(key) {
+		if info, err := os.Stat(key); err == nil && cache.unchanged(key, info) {
+			continue
+		}
+		ok, err := EnsureKeyPermissions(key)
+		if err != nil {
+			delete(cache.Keys, key)
+			errs = append(errs, fmt.Sprintf("%s: %v", filepath.Base(key), err))
+			continue
+		}
+		if ok {
 			fixed++
 		}
-	}
-
-	return fixed, nil
-}
-
-// ForceFixKeyPermissions uses chmod command to fix permissions (more reliable)
-func ForceFixKeyPermissions(keyPath string) bool {
-	keyPath = platform.ExpandPath(keyPath)
-
-	// Check if file exists
-	if _, err := os.Stat(keyPath); err != nil {
-		return false
-	}
-
-	// Use chmod command directly for more reliable permission fixing
-	// Also works in Git Bash on Windows (which has Unix chmod available)
-	if !platform.IsWindows() || isGitBash() {
-		// Convert path to SSH/POSIX format for Git Bash compatibility
-		// e.g. C:\Users\hendr\.ssh\key -> /c/Users/hendr/.ssh/key
-		chmodPath := platform.ToSSHPath(keyPath)
-
-		// Fix private key permissions (600)
-		_, _ = shell.Exec("chmod", "600", chmodPath)
-
-		// Fix public key permissions (644) if exists
-		pubPath := keyPath + ".pub"
-		if platform.FileExists(pubPath) {
-			_, _ = shell.Exec("chmod", "644", platform.ToSSHPath(pubPath))
+		if info, err := os.Stat(key); err == nil {
+			cache.Keys[key] = checkedKey{ModTime: info.ModTime(), Mode: info.Mode()}
 		}
-		return true
 	}
 
-	// On Windows (PowerShell/cmd), use os.Chmod (may not work perfectly but try anyway)
-	_ = os.Chmod(keyPath, 0600)
-	pubPath := keyPath + ".pub"
-	if platform.FileExists(pubPath) {
-		_ = os.Chmod(pubPath, 0644)
+	if len(errs) > 0 {
+		return fixed, fmt.Errorf("%s", strings.Join(errs, "; "))
 	}
-	return true
+	return fixed, nil
 }
 
 // isGitBash returns true if running in Git Bash / MSYS2 on Windows
@@ -234,31 +268,65 @@ func EnsureSSHDirPermissions() {
 
 	// Run chmod on non-Windows OR when running in Git Bash (which has Unix chmod available)
 	if !platform.IsWindows() || isGitBash() {
-		// Convert path to SSH/POSIX format for Git Bash compatibility
-		sshDirPosix := platform.ToSSHPath(sshDir)
-
 		// Fix SSH directory permissions (700)
-		_, _ = shell.Exec("chmod", "700", sshDirPosix)
+		chmodIfWritable(sshDir, "700")
 
 		// Fix SSH config permissions (600) if exists
-		configPath := filepath.Join(sshDir, "config")
+		configPath := GetSSHConfigPath()
 		if platform.FileExists(configPath) {
-			_, _ = shell.Exec("chmod", "600", platform.ToSSHPath(configPath))
+			chmodIfWritable(configPath, "600")
 		}
+		return
+	}
+
+	// Native Windows OpenSSH also rejects a config file others can write
+	if configPath := GetSSHConfigPath(); platform.FileExists(configPath) {
+		_ = restrictWindowsACL(configPath)
 	}
 }
 
+// chmodIfWritable runs chmod on path only when group or others can write
+// to it, which is what makes ssh refuse it. Other modes are the user's choice.
+func chmodIfWritable(path, mode string) {
+	// Convert path to SSH/POSIX format for Git Bash compatibility
+	posixPath := platform.ToSSHPath(path)
+	if isGitBash() {
+		out, err := shell.Exec("stat", "-c", "%a", posixPath)
+		if perm, perr := strconv.ParseUint(strings.TrimSpace(out), 8, 32); err == nil && perr == nil && perm&0022 == 0 {
+			return
+		}
+	} else if info, err := os.Stat(path); err != nil || info.Mode().Perm()&0022 == 0 {
+		return
+	}
+	_, _ = shell.Exec("chmod", mode, posixPath)
+}
+
 // TestConnectionWithKey tests SSH connection to a host using a specific SSH key
 func TestConnectionWithKey(host, keyPath string) (bool, string, error) {
+	return TestConnectionWithOptions(host, keyPath, HostOptions{})
+}
+
+// TestConnectionWithOptions tests SSH connection using a specific key and
+// per-host options (custom port, ProxyJump/ProxyCommand for bastion hosts).
+// The options are passed on the command line because the SSH config file is
+// ignored when a key is given explicitly.
+func TestConnectionWithOptions(host, keyPath string, opts HostOptions) (bool, string, error) {
+	ok, msg, _, err := testConnection(host, keyPath, opts, false)
+	return ok, msg, err
+}
+
+// TestConnectionVerbose is like TestConnectionWithOptions but runs ssh with
+// -vvv and also returns the debug log of the handshake
+func TestConnectionVerbose(host, keyPath string, opts HostOptions) (bool, string, string, error) {
+	return testConnection(host, keyPath, opts, true)
+}
+
+func testConnection(host, keyPath string, opts HostOptions, verbose bool) (bool, string, string, error) {
 	if host == "" {
 		host = "github.com"
 	}
 
-	// First, fix permissions for ALL SSH keys to avoid "bad permissions" errors
-	// This is critical because SSH will scan all keys and fail if any has bad permissions
-	FixAllKeyPermissions()
-
-	// Also ensure SSH directory and config have correct permissions
+	// ssh refuses a group- or world-writable ~/.ssh or config file
 	EnsureSSHDirPermissions()
 
 	args := []string{
@@ -266,15 +334,19 @@ func TestConnectionWithKey(host, keyPath string) (bool, string, error) {
 		"-o", "StrictHostKeyChecking=no",
 		"-o", "ConnectTimeout=10",
 		"-o", "BatchMode=yes",
-		"-o", "LogLevel=ERROR", // Suppress warnings
+	}
+	if verbose {
+		args = append(args, "-vvv")
+	} else {
+		args = append(args, "-o", "LogLevel=ERROR") // Suppress warnings
 	}
 
 	// If keyPath is provided, use it exclusively
 	if keyPath != "" {
 		keyPath = platform.ExpandPath(keyPath)
 
-		// Force fix permissions using chmod command (more reliable than os.Chmod)
-		ForceFixKeyPermissions(keyPath)
+		// Only the key being tested is fixed, and only if its mode is wrong
+		_, _ = EnsureKeyPermissions(keyPath)
 
 		// IMPORTANT: These options ensure ONLY the specified key is used
 		// -F /dev/null - Ignore SSH config file completely (Linux/Mac/Git Bash)
@@ -292,9 +364,25 @@ func TestConnectionWithKey(host, keyPath string) (bool, string, error) {
 		args = append(args, "-i", platform.ToSSHPath(keyPath))
 	}
 
+	if opts.Port > 0 && opts.Port != 22 {
+		args = append(args, "-p", strconv.Itoa(opts.Port))
+	}
+	if opts.ProxyJump != "" {
+		args = append(args, "-J", opts.ProxyJump)
+	} else if opts.ProxyCommand != "" {
+		args = append(args, "-o", "ProxyCommand="+opts.ProxyCommand)
+	}
+
 	args = append(args, fmt.Sprintf("git@%s", host))
 
-	output, err := shell.Exec("ssh", args...)
+	// Use the same ssh program git does; its extra options (e.g. -F) only
+	// apply when no key is forced, since those tests rely on the config
+	program, sshArgs := platform.GetSSHCommand()
+	if keyPath == "" {
+		args = append(sshArgs, args...)
+	}
+	output, err := shell.Exec(program, args...)
+	output, debugLog := splitDebugLog(output)
 
 	// SSH -T returns exit code 1 for successful auth on GitHub/GitLab/Gitea
 	// Check output for success patterns
@@ -340,29 +428,77 @@ func TestConnectionWithKey(host, keyPath string) (bool, string, error) {
 				if matches := userRe.FindStringSubmatch(output); len(matches) > 1 {
 					username := strings.TrimSpace(matches[1])
 					if username != "" {
-						return true, fmt.Sprintf("Successfully authenticated as %s", username), nil
+						return true, fmt.Sprintf("Successfully authenticated as %s", username), debugLog, nil
 					}
 				}
 			}
-			return true, "Successfully authenticated", nil
+			return true, "Successfully authenticated", debugLog, nil
 		}
 	}
 
 	if err != nil {
-		return false, output, err
+		return false, output, debugLog, err
 	}
 
-	return false, output, nil
+	return false, output, debugLog, nil
+}
+
+// splitDebugLog separates ssh -v debug output from the server's messages
+func splitDebugLog(output string) (string, string) {
+	var messages, debug []string
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "debug"), strings.HasPrefix(trimmed, "OpenSSH_"),
+			strings.HasPrefix(trimmed, "Authenticated to"), strings.HasPrefix(trimmed, "Transferred:"),
+			strings.HasPrefix(trimmed, "Bytes per second"):
+			debug = append(debug, line)
+		default:
+			messages = append(messages, line)
+		}
+	}
+	return strings.Join(messages, "\n"), strings.Join(debug, "\n")
 }
 
-// ListPrivateKeys returns a list of SSH private keys in the SSH directory
+// ListPrivateKeys returns a list of SSH private keys in the SSH directory:
+// the files whose content is an OpenSSH or PEM private key
 func ListPrivateKeys() ([]string, error) {
+	files, err := ListKeyFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []string{}
+	for _, f := range files {
+		if f.Kind == KindPrivateKey {
+			keys = append(keys, f.Path)
+		}
+	}
+	return keys, nil
+}
+
+// ListKeyFiles identifies the files in the SSH directory by their content,
+// leaving out public keys next to their private key and the files OpenSSH
+// keeps there (config, known_hosts, authorized_keys). The listing is reused
+// until a file is added to, removed from or renamed in the directory.
+func ListKeyFiles() ([]KeyInfo, error) {
 	sshDir := platform.GetSSHDir()
 
+	info, err := os.Stat(sshDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []KeyInfo{}, nil
+		}
+		return nil, err
+	}
+	if files, ok := cachedKeyFiles(sshDir, info); ok {
+		return files, nil
+	}
+
 	entries, err := os.ReadDir(sshDir)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return []string{}, nil
+			return []KeyInfo{}, nil
 		}
 		return nil, err
 	}
@@ -375,9 +511,9 @@ func ListPrivateKeys() ([]string, error) {
 		"authorized_keys2": true,
 	}
 
-	var keys []string
+	files := []KeyInfo{}
 	for _, entry := range entries {
-		if entry.IsDir() {
+		if !entry.Type().IsRegular() {
 			continue
 		}
 
@@ -393,15 +529,15 @@ func ListPrivateKeys() ([]string, error) {
 			continue
 		}
 
-		// Skip PuTTY key files
-		if strings.HasSuffix(name, ".ppk") {
+		keyInfo, err := IdentifyKey(filepath.Join(sshDir, name))
+		if err != nil {
 			continue
 		}
-
-		keys = append(keys, filepath.Join(sshDir, name))
+		files = append(files, keyInfo)
 	}
 
-	return keys, nil
+	rememberKeyFiles(sshDir, info, files)
+	return files, nil
 }
 
 // SuggestKeyFilenames suggests destination filenames for SSH keys
@@ -0,0 +1,107 @@
+package ssh
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dwirx/ghex/internal/platform"
+)
+
+// ExportHostBlocks returns the Host blocks ghex manages in the form ghex
+// writes them, with key paths below the home directory written as ~/... so
+// they work on another machine. Hand-written blocks are left out.
+func ExportHostBlocks() (string, []HostBlock, error) {
+	blocks, err := ListHostBlocks()
+	if err != nil {
+		return "", nil, err
+	}
+
+	var exported []HostBlock
+	var b strings.Builder
+	fmt.Fprintf(&b, "# ghex SSH Host blocks, exported %s\n", time.Now().Format("2006-01-02"))
+	b.WriteString("# Apply with: ghex ssh config import <file>\n")
+	for _, block := range blocks {
+		if !block.Managed {
+			continue
+		}
+		block.IdentityFile = platform.HomeRelative(platform.ExpandPath(block.IdentityFile))
+		b.WriteString("\n")
+		b.WriteString(BuildHostBlock(block.Alias, block.IdentityFile, block.HostName, block.Options()))
+		b.WriteString("\n")
+		exported = append(exported, block)
+	}
+	return b.String(), exported, nil
+}
+
+// ImportBlock is a Host block of an import file
+type ImportBlock struct {
+	HostBlock
+	Replaces bool // A different block with the same alias is applied already
+}
+
+// NeedsConfirmation reports whether applying the block should be confirmed
+// first: it replaces an existing block or runs a command to connect.
+func (b ImportBlock) NeedsConfirmation() bool {
+	return b.Replaces || b.ProxyCommand != ""
+}
+
+// PlanImport parses the Host blocks of content that are shaped like the
+// ones ghex writes and marks those replacing a different block with the
+// same alias. It also returns the aliases of the blocks that were not
+// written by ghex.
+func PlanImport(content string) ([]ImportBlock, []string, error) {
+	existing, err := ListHostBlocks()
+	if err != nil {
+		return nil, nil, err
+	}
+	current := make(map[string]HostBlock, len(existing))
+	for _, block := range existing {
+		current[block.Alias] = block
+	}
+
+	var planned []ImportBlock
+	var skipped []string
+	for _, block := range ParseHostBlocks(content, false) {
+		if !block.Managed {
+			skipped = append(skipped, block.Alias)
+			continue
+		}
+		old, ok := current[block.Alias]
+		planned = append(planned, ImportBlock{HostBlock: block, Replaces: ok && !sameHostBlock(old, block)})
+	}
+	return planned, skipped, nil
+}
+
+// sameHostBlock reports whether two blocks write the same settings
+func sameHostBlock(a, b HostBlock) bool {
+	return a.HostName == b.HostName && a.Options() == b.Options() &&
+		platform.ExpandPath(a.IdentityFile) == platform.ExpandPath(b.IdentityFile)
+}
+
+// ImportHostBlocks applies Host blocks returned by PlanImport, replacing
+// blocks with the same alias, wherever the SSH config mode says. It returns
+// the applied blocks.
+func ImportHostBlocks(blocks []ImportBlock) ([]HostBlock, error) {
+	var imported []HostBlock
+	for _, block := range blocks {
+		if err := EnsureConfigBlockWithOptions(block.Alias, block.IdentityFile, block.HostName, block.Options()); err != nil {
+			return imported, fmt.Errorf("failed to import Host %s: %w", block.Alias, err)
+		}
+		imported = append(imported, block.HostBlock)
+	}
+	return imported, nil
+}
+
+// MissingKeys returns the identity files of blocks that don't exist on this
+// machine
+func MissingKeys(blocks []HostBlock) []string {
+	var missing []string
+	for _, block := range blocks {
+		if _, err := os.Stat(platform.ExpandPath(block.IdentityFile)); os.IsNotExist(err) {
+			missing = append(missing, block.IdentityFile)
+		}
+	}
+	return missing
+}
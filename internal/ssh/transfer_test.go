@@ -0,0 +1,134 @@
+package ssh
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestExportImportHostBlocks tests moving ghex's Host blocks from one home
+// directory to another, leaving hand-written blocks behind
+func TestExportImportHostBlocks(t *testing.T) {
+	SetConfigMode(ConfigModeEdit)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	sshDir := filepath.Join(home, ".ssh")
+	if err := os.MkdirAll(sshDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	config := strings.Join([]string{
+		"Host *",
+		"  AddKeysToAgent yes",
+		"",
+		"Host gitlab.work.com",
+		"  HostName gitlab.work.com",
+		"  User git",
+		"  IdentityFile " + filepath.Join(sshDir, "id_work"),
+		"  IdentitiesOnly yes",
+		"  Port 2222",
+		"  ProxyJump bastion.work.com",
+		"",
+		"Host box",
+		"  HostName box.example.com",
+		"  ForwardAgent yes",
+		"",
+	}, "\n")
+	if err := os.WriteFile(filepath.Join(sshDir, "config"), []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	exported, blocks, err := ExportHostBlocks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks) != 1 || blocks[0].Alias != "gitlab.work.com" {
+		t.Fatalf("exported blocks = %+v", blocks)
+	}
+	for _, want := range []string{"IdentityFile ~/.ssh/id_work", "Port 2222", "ProxyJump bastion.work.com"} {
+		if !strings.Contains(exported, want) {
+			t.Errorf("export missing %q:\n%s", want, exported)
+		}
+	}
+	if strings.Contains(exported, "box") || strings.Contains(exported, "AddKeysToAgent") {
+		t.Errorf("export contains hand-written blocks:\n%s", exported)
+	}
+
+	// Import on a machine that already has its own block for the host
+	other := t.TempDir()
+	t.Setenv("HOME", other)
+	t.Setenv("USERPROFILE", other)
+	existing := "Host gitlab.work.com\n  HostName gitlab.work.com\n  User git\n  IdentityFile ~/.ssh/id_old\n  IdentitiesOnly yes\n"
+	if err := os.MkdirAll(filepath.Join(other, ".ssh"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(other, ".ssh", "config"), []byte(existing), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	planned, skipped, err := PlanImport(exported + "\nHost manual\n  HostName m\n  ForwardAgent yes\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(planned) != 1 || len(skipped) != 1 || skipped[0] != "manual" {
+		t.Fatalf("planned = %+v, skipped = %v", planned, skipped)
+	}
+	if !planned[0].Replaces || !planned[0].NeedsConfirmation() {
+		t.Errorf("Expected the block to be marked as replacing id_old: %+v", planned[0])
+	}
+	imported, err := ImportHostBlocks(planned)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(imported) != 1 {
+		t.Fatalf("imported = %+v", imported)
+	}
+	if missing := MissingKeys(imported); len(missing) != 1 {
+		t.Errorf("missing keys = %v", missing)
+	}
+
+	data, err := os.ReadFile(filepath.Join(other, ".ssh", "config"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+	if strings.Contains(got, "id_old") || strings.Count(got, "Host gitlab.work.com") != 1 {
+		t.Errorf("existing block not replaced:\n%s", got)
+	}
+	if !strings.Contains(got, "  Port 2222\n  ProxyJump bastion.work.com") || strings.Contains(got, "manual") {
+		t.Errorf("unexpected imported config:\n%s", got)
+	}
+
+	// Importing the same blocks again replaces nothing
+	planned, _, err = PlanImport(exported)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(planned) != 1 || planned[0].NeedsConfirmation() {
+		t.Errorf("Expected an unchanged block to need no confirmation: %+v", planned)
+	}
+}
+
+// TestImportBlockNeedsConfirmation tests which imported blocks are
+// confirmed before they are applied
+func TestImportBlockNeedsConfirmation(t *testing.T) {
+	tests := []struct {
+		name  string
+		block ImportBlock
+		want  bool
+	}{
+		{"new block", ImportBlock{HostBlock: HostBlock{Alias: "a", Port: 2222, ProxyJump: "bastion"}}, false},
+		{"replaces a block", ImportBlock{HostBlock: HostBlock{Alias: "a"}, Replaces: true}, true},
+		{"proxy command", ImportBlock{HostBlock: HostBlock{Alias: "a", ProxyCommand: "nc %h %p"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.block.NeedsConfirmation(); got != tt.want {
+				t.Errorf("NeedsConfirmation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
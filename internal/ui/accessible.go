@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dwirx/ghex/internal/i18n"
+)
+
+// accessible replaces spinners, redrawn progress bars and full-screen
+// selectors with plain line-by-line output that screen readers can follow
+var accessible bool
+
+// SetAccessible turns screen-reader friendly output on or off
+func SetAccessible(on bool) {
+	accessible = on
+}
+
+// Accessible reports whether screen-reader friendly output is on
+func Accessible() bool {
+	return accessible
+}
+
+// DetectAccessible decides whether to use screen-reader friendly output.
+// GHEX_ACCESSIBLE (1/0, true/false) wins over the configured value.
+func DetectAccessible(configured bool) bool {
+	if v := os.Getenv("GHEX_ACCESSIBLE"); v != "" {
+		if on, err := strconv.ParseBool(v); err == nil {
+			return on
+		}
+	}
+	return configured
+}
+
+// showNumberedItems prints a title and numbered items
+func showNumberedItems(title string, items []SelectorItem) {
+	// Drop key hints meant for the interactive selector, e.g. "(↑/k ↓/j ...)"
+	if i := strings.Index(title, " (↑"); i >= 0 {
+		title = title[:i]
+	}
+	fmt.Println()
+	fmt.Println(BoldPrimaryStyle.Render(title))
+	for i, item := range items {
+		fmt.Printf("  %d. %s\n", i+1, item.Title)
+		if item.Description != "" {
+			fmt.Printf("     %s\n", MutedStyle.Render(item.Description))
+		}
+	}
+}
+
+// runNumberedSelector asks for one item by number. Empty or invalid input
+// cancels, like q/esc in the interactive selector.
+func runNumberedSelector(title string, items []SelectorItem) int {
+	showNumberedItems(title, items)
+	idx, err := strconv.Atoi(PromptLine(i18n.T("Enter a number (blank to cancel)"), ""))
+	if err != nil || idx < 1 || idx > len(items) {
+		return -1
+	}
+	return idx - 1
+}
+
+// runNumberedMultiSelector asks for items by comma-separated numbers, or
+// "all", and returns them in display order. Empty input cancels.
+func runNumberedMultiSelector(title string, items []SelectorItem) []int {
+	showNumberedItems(title, items)
+	answer := PromptLine(i18n.T("Enter numbers separated by commas, or \"all\" (blank to cancel)"), "")
+	if answer == "" {
+		return nil
+	}
+
+	var selected []int
+	seen := map[int]bool{}
+	for _, field := range strings.Split(answer, ",") {
+		field = strings.TrimSpace(field)
+		if strings.EqualFold(field, "all") {
+			selected = selected[:0]
+			for i := range items {
+				selected = append(selected, i)
+			}
+			return selected
+		}
+		idx, err := strconv.Atoi(field)
+		if err != nil || idx < 1 || idx > len(items) || seen[idx] {
+			continue
+		}
+		seen[idx] = true
+		selected = append(selected, idx-1)
+	}
+	sort.Ints(selected)
+	return selected
+}
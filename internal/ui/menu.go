@@ -14,6 +14,10 @@ type MenuItem struct {
 
 // SelectMenu displays a simple menu and returns the selected index
 func SelectMenu(title string, items []MenuItem) (int, error) {
+	if nonInteractive {
+		refuseInput()
+		return -1, ErrNonInteractive
+	}
 	fmt.Println()
 	fmt.Println(BoldPrimaryStyle.Render(title))
 	fmt.Println(MutedStyle.Render(strings.Repeat("─", 50)))
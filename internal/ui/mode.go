@@ -0,0 +1,49 @@
+package ui
+
+import "errors"
+
+// ErrNonInteractive is returned by selectors that need an answer while
+// prompts are turned off
+var ErrNonInteractive = errors.New("input needed, but prompts are turned off (--non-interactive)")
+
+// quiet drops spinners, progress bars and informational messages, leaving
+// warnings, errors and the output a command exists to print
+var quiet bool
+
+// nonInteractive makes prompts take their default answer and selectors
+// fail, for CI pipelines and cron jobs where nobody can answer
+var nonInteractive bool
+
+// inputRefused records that a prompt without a default was refused
+var inputRefused bool
+
+// SetQuiet turns quiet output on or off
+func SetQuiet(on bool) {
+	quiet = on
+}
+
+// Quiet reports whether quiet output is on
+func Quiet() bool {
+	return quiet
+}
+
+// SetNonInteractive turns prompts off or on
+func SetNonInteractive(on bool) {
+	nonInteractive = on
+}
+
+// NonInteractive reports whether prompts are turned off
+func NonInteractive() bool {
+	return nonInteractive
+}
+
+// InputRefused reports whether a prompt or selector needed an answer that
+// non-interactive mode could not give, so the run should fail
+func InputRefused() bool {
+	return inputRefused
+}
+
+// refuseInput records a prompt without a default in non-interactive mode
+func refuseInput() {
+	inputRefused = true
+}
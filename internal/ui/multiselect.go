@@ -0,0 +1,228 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// MultiSelectorModel is the bubbletea model for selecting several items.
+// Typing filters the list; checked items stay checked while hidden.
+type MultiSelectorModel struct {
+	items    []SelectorItem
+	visible  []int // indexes of the items matching filter
+	cursor   int   // position in visible
+	checked  map[int]bool
+	filter   string
+	title    string
+	done     bool
+	canceled bool
+}
+
+// NewMultiSelector creates a new multi-selector model
+func NewMultiSelector(title string, items []SelectorItem) MultiSelectorModel {
+	m := MultiSelectorModel{
+		items:   items,
+		checked: make(map[int]bool),
+		title:   title,
+	}
+	m.applyFilter()
+	return m
+}
+
+// applyFilter lists the items whose title or description contains the
+// filter, ignoring case. The cursor stays on its item while it is shown.
+func (m *MultiSelectorModel) applyFilter() {
+	current := -1
+	if m.cursor < len(m.visible) {
+		current = m.visible[m.cursor]
+	}
+	needle := strings.ToLower(m.filter)
+	m.visible = nil
+	m.cursor = 0
+	for i, item := range m.items {
+		if needle == "" || strings.Contains(strings.ToLower(item.Title+" "+item.Description), needle) {
+			if i == current {
+				m.cursor = len(m.visible)
+			}
+			m.visible = append(m.visible, i)
+		}
+	}
+}
+
+func (m MultiSelectorModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m MultiSelectorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			// Clear the filter first, cancel when there is none
+			if m.filter != "" {
+				m.filter = ""
+				m.applyFilter()
+				break
+			}
+			m.canceled = true
+			m.done = true
+			return m, tea.Quit
+
+		case "ctrl+c":
+			m.canceled = true
+			m.done = true
+			return m, tea.Quit
+
+		case "up", "ctrl+p":
+			if len(m.visible) == 0 {
+				break
+			}
+			if m.cursor > 0 {
+				m.cursor--
+			} else {
+				m.cursor = len(m.visible) - 1 // Wrap to bottom
+			}
+
+		case "down", "ctrl+n":
+			if len(m.visible) == 0 {
+				break
+			}
+			if m.cursor < len(m.visible)-1 {
+				m.cursor++
+			} else {
+				m.cursor = 0 // Wrap to top
+			}
+
+		case " ":
+			if len(m.visible) > 0 {
+				i := m.visible[m.cursor]
+				m.checked[i] = !m.checked[i]
+			}
+
+		case "ctrl+a":
+			// Toggle all shown: select them unless they are all selected
+			all := true
+			for _, i := range m.visible {
+				all = all && m.checked[i]
+			}
+			for _, i := range m.visible {
+				m.checked[i] = !all
+			}
+
+		case "backspace":
+			if r := []rune(m.filter); len(r) > 0 {
+				m.filter = string(r[:len(r)-1])
+				m.applyFilter()
+			}
+
+		case "enter":
+			m.done = true
+			return m, tea.Quit
+
+		default:
+			if msg.Type == tea.KeyRunes {
+				m.filter += string(msg.Runes)
+				m.applyFilter()
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m MultiSelectorModel) View() string {
+	if m.done {
+		return ""
+	}
+
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(PrimaryColor).
+		MarginBottom(1)
+
+	b.WriteString(titleStyle.Render(m.title))
+	b.WriteString("\n\n")
+
+	if m.filter != "" {
+		b.WriteString(lipgloss.NewStyle().Foreground(AccentColor).Render("Filter: " + m.filter))
+		b.WriteString("\n\n")
+		if len(m.visible) == 0 {
+			b.WriteString(lipgloss.NewStyle().Foreground(MutedColor).Render("  No matches"))
+			b.WriteString("\n")
+		}
+	}
+
+	for pos, i := range m.visible {
+		item := m.items[i]
+		cursor := "  "
+		style := lipgloss.NewStyle().Foreground(TextColor)
+
+		if pos == m.cursor {
+			cursor = "▸ "
+			style = lipgloss.NewStyle().
+				Foreground(AccentColor).
+				Bold(true)
+		}
+
+		check := "[ ]"
+		if m.checked[i] {
+			check = "[✓]"
+		}
+
+		line := fmt.Sprintf("%s%s %s", cursor, check, item.Title)
+		b.WriteString(style.Render(line))
+		if item.Description != "" {
+			b.WriteString(" ")
+			b.WriteString(lipgloss.NewStyle().Foreground(MutedColor).Render(item.Description))
+		}
+		b.WriteString("\n")
+	}
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(MutedColor).
+		MarginTop(1)
+
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render(fmt.Sprintf("%d selected • type to filter • space toggle • ctrl+a all • enter confirm • esc cancel", len(m.Selected()))))
+
+	return b.String()
+}
+
+// Selected returns the checked indexes in display order (nil if canceled)
+func (m MultiSelectorModel) Selected() []int {
+	if m.canceled {
+		return nil
+	}
+	var selected []int
+	for i := range m.items {
+		if m.checked[i] {
+			selected = append(selected, i)
+		}
+	}
+	return selected
+}
+
+// RunMultiSelector runs the interactive multi-selector and returns the checked indexes
+func RunMultiSelector(title string, items []SelectorItem) ([]int, error) {
+	if nonInteractive {
+		refuseInput()
+		return nil, ErrNonInteractive
+	}
+	if accessible {
+		return runNumberedMultiSelector(title, items), nil
+	}
+	model := NewMultiSelector(title, items)
+	p := tea.NewProgram(model)
+
+	finalModel, err := p.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	return finalModel.(MultiSelectorModel).Selected(), nil
+}
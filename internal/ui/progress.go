@@ -0,0 +1,231 @@
+package ui
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/progress"
+)
+
+// ProgressBar renders a single-line byte progress bar on stdout with the
+// transfer speed and the estimated time left. A bar created with
+// NewFilesProgressBar also counts finished files of a multi-file download.
+type ProgressBar struct {
+	label      string
+	total      int64
+	current    int64
+	files      int
+	filesDone  int
+	bar        progress.Model
+	startTime  time.Time // First byte count seen, the baseline of the speed
+	moved      int64     // Bytes transferred since startTime, excluding resumed bytes
+	lastRender time.Time
+	started    bool
+	mu         sync.Mutex
+}
+
+// NewProgressBar creates a progress bar; total <= 0 means unknown size
+func NewProgressBar(label string, total int64) *ProgressBar {
+	return &ProgressBar{
+		label: label,
+		total: total,
+		bar: progress.New(
+			progress.WithDefaultGradient(),
+			progress.WithWidth(30),
+			progress.WithoutPercentage(),
+		),
+	}
+}
+
+// NewFilesProgressBar creates a progress bar aggregating files downloads of
+// total bytes together; call FileDone as each one finishes
+func NewFilesProgressBar(files int, total int64) *ProgressBar {
+	p := NewProgressBar("", total)
+	p.files = files
+	return p
+}
+
+// Write implements io.Writer so the bar can be used with io.TeeReader/io.MultiWriter
+func (p *ProgressBar) Write(b []byte) (int, error) {
+	p.Add(int64(len(b)))
+	return len(b), nil
+}
+
+// Add advances the bar by n bytes
+func (p *ProgressBar) Add(n int64) {
+	p.mu.Lock()
+	if p.startTime.IsZero() {
+		p.startTime = time.Now()
+	}
+	p.current += n
+	p.moved += n
+	p.mu.Unlock()
+	p.render(false)
+}
+
+// Set sets the current byte count (e.g. when resuming a partial download).
+// The first count is taken as the baseline, so resumed bytes do not inflate
+// the speed.
+func (p *ProgressBar) Set(current int64) {
+	p.mu.Lock()
+	if p.startTime.IsZero() {
+		p.startTime = time.Now()
+	} else if current > p.current {
+		p.moved += current - p.current
+	}
+	p.current = current
+	p.mu.Unlock()
+	p.render(false)
+}
+
+// FileDone counts one more finished file of a multi-file download
+func (p *ProgressBar) FileDone() {
+	p.mu.Lock()
+	p.filesDone++
+	p.mu.Unlock()
+	p.render(false)
+}
+
+// Finish renders the final state and ends the line
+func (p *ProgressBar) Finish() {
+	if quiet {
+		return
+	}
+	p.render(true)
+	fmt.Println()
+}
+
+func (p *ProgressBar) render(force bool) {
+	if quiet {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if accessible {
+		p.renderAccessible(force)
+		return
+	}
+
+	// Throttle redraws to keep terminals responsive on fast connections
+	if !force && time.Since(p.lastRender) < 100*time.Millisecond {
+		return
+	}
+	p.lastRender = time.Now()
+
+	label := TextStyle.Render(p.currentLabel())
+	speed := p.speed()
+
+	if p.total <= 0 {
+		line := fmt.Sprintf("\r  %s %s", label, MutedStyle.Render(FormatBytes(p.current)))
+		if speed > 0 {
+			line += " " + MutedStyle.Render(formatSpeed(speed))
+		}
+		fmt.Print(line + "\033[K")
+		return
+	}
+
+	ratio := float64(p.current) / float64(p.total)
+	if ratio > 1 {
+		ratio = 1
+	}
+
+	stats := fmt.Sprintf("%s/%s", FormatBytes(p.current), FormatBytes(p.total))
+	if speed > 0 {
+		stats += " " + formatSpeed(speed)
+		if remaining := p.total - p.current; remaining > 0 && !force {
+			eta := time.Duration(float64(remaining) / speed * float64(time.Second))
+			stats += " ETA " + formatETA(eta)
+		}
+	}
+	if force && !p.startTime.IsZero() {
+		stats += " in " + formatETA(time.Since(p.startTime))
+	}
+
+	fmt.Printf("\r  %s %s %5.1f%% %s\033[K",
+		label,
+		p.bar.ViewAs(ratio),
+		ratio*100,
+		MutedStyle.Render(stats))
+}
+
+// currentLabel returns the label, or the finished file count of a
+// multi-file bar
+func (p *ProgressBar) currentLabel() string {
+	if p.files > 0 {
+		return fmt.Sprintf("%d/%d files", p.filesDone, p.files)
+	}
+	return p.label
+}
+
+// speed returns the average transfer rate in bytes per second, 0 until
+// there is enough data to tell
+func (p *ProgressBar) speed() float64 {
+	if p.startTime.IsZero() || p.moved == 0 {
+		return 0
+	}
+	elapsed := time.Since(p.startTime).Seconds()
+	if elapsed < 0.2 {
+		return 0
+	}
+	return float64(p.moved) / elapsed
+}
+
+// renderAccessible prints one line when the transfer starts and one when it
+// finishes, instead of redrawing the bar in place
+func (p *ProgressBar) renderAccessible(final bool) {
+	if !final {
+		if !p.started {
+			p.started = true
+			size := ""
+			if p.total > 0 {
+				size = " " + MutedStyle.Render(FormatBytes(p.total))
+			}
+			label := p.label
+			if p.files > 0 {
+				label = fmt.Sprintf("%d files", p.files)
+			}
+			fmt.Printf("  %s%s...\n", TextStyle.Render(label), size)
+		}
+		return
+	}
+	summary := FormatBytes(p.current)
+	if speed := p.speed(); speed > 0 {
+		summary += " " + formatSpeed(speed)
+	}
+	fmt.Printf("  %s %s", TextStyle.Render(p.currentLabel()), MutedStyle.Render(summary))
+}
+
+// formatSpeed returns a human-readable transfer rate
+func formatSpeed(bytesPerSec float64) string {
+	return FormatBytes(int64(bytesPerSec)) + "/s"
+}
+
+// formatETA returns a short duration such as 45s, 3m12s or 1h05m
+func formatETA(d time.Duration) string {
+	d = d.Round(time.Second)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	default:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
+
+// FormatBytes returns a human-readable byte size
+func FormatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,20 @@
+package ui
+
+import (
+	"fmt"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// ShowQR prints text as a QR code drawn with half-block characters, for
+// moving a key or link to a phone or another machine
+func ShowQR(text string) error {
+	code, err := qrcode.New(text, qrcode.Low)
+	if err != nil {
+		return fmt.Errorf("failed to encode QR code: %w", err)
+	}
+	// Light modules are drawn as blocks in the terminal's foreground colour,
+	// which scans correctly on the usual dark themes
+	fmt.Println(code.ToSmallString(false))
+	return nil
+}
@@ -140,6 +140,13 @@ func (m SelectorModel) Selected() int {
 
 // RunSelector runs the interactive selector and returns the selected index
 func RunSelector(title string, items []SelectorItem) (int, error) {
+	if nonInteractive {
+		refuseInput()
+		return -1, ErrNonInteractive
+	}
+	if accessible {
+		return runNumberedSelector(title, items), nil
+	}
 	model := NewSelector(title, items)
 	p := tea.NewProgram(model)
 
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"sync"
 	"time"
+
+	"github.com/dwirx/ghex/internal/i18n"
 )
 
 // Spinner represents a loading spinner
@@ -35,6 +37,15 @@ func (s *Spinner) Start() {
 	s.running = true
 	s.mu.Unlock()
 
+	if quiet {
+		return
+	}
+	if accessible {
+		// One line per state change instead of an animation
+		fmt.Println(TextStyle.Render(s.message + "..."))
+		return
+	}
+
 	go func() {
 		ticker := time.NewTicker(80 * time.Millisecond)
 		defer ticker.Stop()
@@ -58,43 +69,61 @@ func (s *Spinner) Start() {
 
 // Stop stops the spinner and clears the line
 func (s *Spinner) Stop() {
+	if s.stop() && accessible && !quiet {
+		// The start line has no matching end otherwise
+		fmt.Println(MutedStyle.Render(i18n.T("Done")))
+	}
+}
+
+// stop ends the animation, reporting whether the spinner was running
+func (s *Spinner) stop() bool {
 	s.mu.Lock()
 	if !s.running {
 		s.mu.Unlock()
-		return
+		return false
 	}
 	s.running = false
 	s.mu.Unlock()
 
+	if accessible || quiet {
+		return true
+	}
+
 	s.done <- true
 
 	// Clear the spinner line
 	fmt.Print("\r\033[K")
+	return true
 }
 
 // StopWithMessage stops the spinner and displays a final message
 func (s *Spinner) StopWithMessage(message string) {
-	s.Stop()
+	s.stop()
 	fmt.Println(message)
 }
 
 // StopWithSuccess stops the spinner and displays a success message
 func (s *Spinner) StopWithSuccess(message string) {
-	s.Stop()
+	s.stop()
 	ShowSuccess(message)
 }
 
 // StopWithError stops the spinner and displays an error message
 func (s *Spinner) StopWithError(message string) {
-	s.Stop()
+	s.stop()
 	ShowError(message)
 }
 
 // UpdateMessage updates the spinner message
 func (s *Spinner) UpdateMessage(message string) {
 	s.mu.Lock()
+	changed := s.running && s.message != message
 	s.message = message
 	s.mu.Unlock()
+
+	if accessible && changed && !quiet {
+		fmt.Println(TextStyle.Render(message + "..."))
+	}
 }
 
 // WithSpinner executes a function while showing a spinner
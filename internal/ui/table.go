@@ -98,6 +98,9 @@ func buildAccountRow(acc config.Account, isActive bool, healthStatus *config.Hea
 
 	// Name
 	row.Name = acc.Name
+	if acc.Favorite {
+		row.Name = "★ " + acc.Name
+	}
 
 	// Platform with icon
 	platformType := "github"
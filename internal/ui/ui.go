@@ -2,9 +2,11 @@ package ui
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/dwirx/ghex/internal/i18n"
 )
 
 // ASCII art title for GHEX
@@ -18,6 +20,16 @@ const asciiTitle = `
 
 // ShowTitle displays the application title
 func ShowTitle() {
+	if quiet {
+		return
+	}
+	if accessible {
+		// Screen readers spell out the box-drawing art character by character
+		fmt.Println(BoldPrimaryStyle.Render("GHEX - GitHub Account Switcher & Universal Downloader"))
+		fmt.Println()
+		return
+	}
+
 	// Apply gradient-like effect using primary and secondary colors
 	lines := strings.Split(asciiTitle, "\n")
 	for i, line := range lines {
@@ -74,6 +86,9 @@ func ShowBox(content string, opts BoxOptions) {
 
 // ShowSuccess displays a success message
 func ShowSuccess(message string) {
+	if quiet {
+		return
+	}
 	fmt.Println(SuccessStyle.Render("✓ ") + TextStyle.Render(message))
 }
 
@@ -89,11 +104,17 @@ func ShowWarning(message string) {
 
 // ShowInfo displays an info message
 func ShowInfo(message string) {
+	if quiet {
+		return
+	}
 	fmt.Println(AccentStyle.Render("ℹ ") + TextStyle.Render(message))
 }
 
 // ShowSection displays a section header
 func ShowSection(title string) {
+	if quiet {
+		return
+	}
 	fmt.Println()
 	fmt.Println(SectionStyle.Render("▶ " + title))
 	fmt.Println(MutedStyle.Render(strings.Repeat("─", 50)))
@@ -126,17 +147,54 @@ func ShowIndentedKeyValue(key, value string, indent int) {
 	fmt.Printf("%s%s: %s\n", prefix, MutedStyle.Render(key), TextStyle.Render(value))
 }
 
-// Confirm prompts for yes/no confirmation
+// ShowDetails displays a collapsed block of text: a header, the highlighted
+// lines, and the full text only if the user chooses to expand it
+func ShowDetails(title, text string, highlights []string) {
+	if text == "" {
+		return
+	}
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+
+	fmt.Printf("%s %s %s\n", AccentStyle.Render("▸"), TextStyle.Render(title), DimStyle.Render(fmt.Sprintf("(%d lines)", len(lines))))
+	for _, line := range lines {
+		for _, h := range highlights {
+			if strings.Contains(line, h) {
+				fmt.Printf("  %s\n", TextStyle.Render(strings.TrimSpace(line)))
+				break
+			}
+		}
+	}
+
+	if !Confirm("Expand " + strings.ToLower(title) + "?") {
+		return
+	}
+	fmt.Printf("%s %s\n", AccentStyle.Render("▾"), TextStyle.Render(title))
+	for _, line := range lines {
+		fmt.Printf("  %s\n", DimStyle.Render(line))
+	}
+}
+
+// Confirm prompts for yes/no confirmation. Without prompts the answer is
+// the default, no.
 func Confirm(message string) bool {
-	fmt.Printf("%s %s [y/N]: ", PrimaryStyle.Render("◉"), TextStyle.Render(message))
+	if nonInteractive {
+		return false
+	}
+	fmt.Printf("%s %s %s: ", PrimaryStyle.Render("◉"), TextStyle.Render(message), i18n.T("[y/N]"))
 	var response string
 	_, _ = fmt.Scanln(&response)
 	response = strings.ToLower(strings.TrimSpace(response))
-	return response == "y" || response == "yes"
+	// "ya" is the Indonesian yes; accepting it everywhere is harmless
+	return response == "y" || response == "yes" || response == "ya"
 }
 
-// Prompt prompts for text input
+// Prompt prompts for text input. Without prompts the input is refused and
+// empty.
 func Prompt(message string) string {
+	if nonInteractive {
+		refuseInput()
+		return ""
+	}
 	fmt.Printf("%s %s: ", PrimaryStyle.Render("◇"), TextStyle.Render(message))
 	var response string
 	_, _ = fmt.Scanln(&response)
@@ -145,6 +203,9 @@ func Prompt(message string) string {
 
 // PromptWithDefault prompts for text input with a default value
 func PromptWithDefault(message, defaultValue string) string {
+	if nonInteractive {
+		return defaultAnswer(defaultValue)
+	}
 	if defaultValue != "" {
 		fmt.Printf("%s %s [%s]: ", PrimaryStyle.Render("◇"), TextStyle.Render(message), DimStyle.Render(defaultValue))
 	} else {
@@ -161,8 +222,60 @@ func PromptWithDefault(message, defaultValue string) string {
 
 // PromptPassword prompts for password input (note: this doesn't hide input in basic implementation)
 func PromptPassword(message string) string {
+	if nonInteractive {
+		refuseInput()
+		return ""
+	}
 	fmt.Printf("%s %s: ", PrimaryStyle.Render("◇"), TextStyle.Render(message))
 	var response string
 	_, _ = fmt.Scanln(&response)
 	return strings.TrimSpace(response)
 }
+
+// PromptLine prompts for a whole line of text, spaces included, with a
+// default value
+func PromptLine(message, defaultValue string) string {
+	if nonInteractive {
+		return defaultAnswer(defaultValue)
+	}
+	if defaultValue != "" {
+		fmt.Printf("%s %s [%s]: ", PrimaryStyle.Render("◇"), TextStyle.Render(message), DimStyle.Render(defaultValue))
+	} else {
+		fmt.Printf("%s %s: ", PrimaryStyle.Render("◇"), TextStyle.Render(message))
+	}
+
+	// Read byte by byte so no input is buffered away from later prompts
+	var line []byte
+	buf := make([]byte, 1)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if n == 0 || err != nil || buf[0] == '\n' {
+			break
+		}
+		line = append(line, buf[0])
+	}
+
+	response := strings.TrimSpace(string(line))
+	if response == "" {
+		return defaultValue
+	}
+	return response
+}
+
+// defaultAnswer is the answer to a prompt in non-interactive mode: its
+// default, or nothing, refusing the input, when it has none
+func defaultAnswer(defaultValue string) string {
+	if defaultValue == "" {
+		refuseInput()
+	}
+	return defaultValue
+}
+
+// MaskSecret hides a token for display, keeping only enough of its start
+// and end to recognise it (e.g. "ghp_••••••••a1b2")
+func MaskSecret(secret string) string {
+	if len(secret) < 12 {
+		return strings.Repeat("•", len(secret))
+	}
+	return secret[:4] + strings.Repeat("•", 8) + secret[len(secret)-4:]
+}
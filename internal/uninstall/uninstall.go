@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/dwirx/ghex/internal/platform"
+	"github.com/dwirx/ghex/internal/selfinstall"
 )
 
 // Options holds uninstall configuration
@@ -17,14 +18,17 @@ type Options struct {
 	Purge      bool // Remove config files
 	KeepConfig bool // Explicitly keep config files
 	DryRun     bool // Preview without removing
+	Elevate    bool // Remove the binary with sudo (system-wide Unix installs)
 }
 
 // Preview holds information about what will be removed
 type Preview struct {
 	BinaryPath    string   `json:"binary_path"`
+	Scope         string   `json:"scope"` // "system" or "user"
 	ConfigPath    string   `json:"config_path"`
 	LegacyConfig  string   `json:"legacy_config,omitempty"`
-	PathEntry     string   `json:"path_entry,omitempty"` // Windows only
+	PathEntry     string   `json:"path_entry,omitempty"`     // Windows only
+	ShellRCFiles  []string `json:"shell_rc_files,omitempty"` // Blocks added by install-self
 	FilesToRemove []string `json:"files_to_remove"`
 }
 
@@ -53,29 +57,48 @@ func NewService() *Service {
 		legacyConfig: platform.GetConfigDir("github-switch"),
 	}
 
-	if platform.IsWindows() {
-		// Windows: %LOCALAPPDATA%\ghex\ghex.exe
-		localAppData := os.Getenv("LOCALAPPDATA")
-		if localAppData == "" {
-			localAppData = filepath.Join(platform.GetHomeDir(), "AppData", "Local")
+	// Try to find the actual binary location, which may be a machine-wide
+	// install (e.g. Program Files) rather than the installer's default
+	if binaryPath, err := exec.LookPath("ghex"); err == nil {
+		if resolved, err := filepath.EvalSymlinks(binaryPath); err == nil {
+			binaryPath = resolved
 		}
-		s.installDir = filepath.Join(localAppData, "ghex")
-		s.binaryPath = filepath.Join(s.installDir, "ghex.exe")
+		s.binaryPath = binaryPath
+		s.installDir = filepath.Dir(binaryPath)
+	} else if userBinary := filepath.Join(selfinstall.DefaultDir(), selfinstall.BinaryName()); platform.IsWindows() || platform.FileExists(userBinary) {
+		// Per-user default of install-self and install.ps1:
+		// ~/.local/bin/ghex, or %LOCALAPPDATA%\ghex\ghex.exe on Windows
+		s.binaryPath = userBinary
+		s.installDir = filepath.Dir(userBinary)
 	} else {
-		// Try to find the actual binary location
-		if binaryPath, err := exec.LookPath("ghex"); err == nil {
-			s.binaryPath = binaryPath
-			s.installDir = filepath.Dir(binaryPath)
-		} else {
-			// Fallback to common locations
-			s.binaryPath = "/usr/local/bin/ghex"
-			s.installDir = "/usr/local/bin"
-		}
+		// Fallback to common locations
+		s.binaryPath = "/usr/local/bin/ghex"
+		s.installDir = "/usr/local/bin"
 	}
 
 	return s
 }
 
+// GetScope reports whether the binary is installed machine-wide or per-user
+func (s *Service) GetScope() platform.InstallScope {
+	return platform.DetectInstallScope(s.binaryPath)
+}
+
+// NeedsElevation reports whether removing the binary needs administrator
+// rights, i.e. its directory is not writable by the current user
+func (s *Service) NeedsElevation() bool {
+	if !platform.FileExists(s.binaryPath) {
+		return false
+	}
+	f, err := os.CreateTemp(s.installDir, ".ghex_permission_check_*")
+	if err != nil {
+		return true
+	}
+	f.Close()
+	os.Remove(f.Name())
+	return false
+}
+
 // GetBinaryPath returns the path to the installed binary
 func (s *Service) GetBinaryPath() string {
 	return s.binaryPath
@@ -100,6 +123,7 @@ func (s *Service) GetInstallDir() string {
 func (s *Service) GetPreview() *Preview {
 	preview := &Preview{
 		BinaryPath:    s.binaryPath,
+		Scope:         string(s.GetScope()),
 		ConfigPath:    s.configPath,
 		FilesToRemove: []string{},
 	}
@@ -127,6 +151,8 @@ func (s *Service) GetPreview() *Preview {
 		}
 	}
 
+	preview.ShellRCFiles = selfinstall.RCFilesWithBlock()
+
 	return preview
 }
 
@@ -148,7 +174,11 @@ func (s *Service) Execute(opts Options) *Result {
 	// Remove binary
 	binaryExisted := platform.FileExists(s.binaryPath)
 	if binaryExisted {
-		if err := s.RemoveBinary(); err != nil {
+		removeBinary := s.RemoveBinary
+		if opts.Elevate {
+			removeBinary = s.RemoveBinaryElevated
+		}
+		if err := removeBinary(); err != nil {
 			result.Errors = append(result.Errors, fmt.Sprintf("Failed to remove binary: %v", err))
 			result.Success = false
 		} else {
@@ -184,6 +214,13 @@ func (s *Service) Execute(opts Options) *Result {
 		}
 	}
 
+	// PATH and completion lines added by `ghex install-self`
+	if changed, err := selfinstall.RemoveRCBlocks(); err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("Failed to update shell rc files: %v", err))
+	} else if len(changed) > 0 {
+		result.PathUpdated = true
+	}
+
 	return result
 }
 
@@ -209,6 +246,18 @@ func (s *Service) RemoveBinary() error {
 	return nil
 }
 
+// RemoveBinaryElevated removes the binary with sudo. Only this step runs
+// privileged; config files belong to the user and are removed without it.
+func (s *Service) RemoveBinaryElevated() error {
+	if !platform.FileExists(s.binaryPath) {
+		return nil
+	}
+	if err := platform.RunElevated("rm", "-f", s.binaryPath); err != nil {
+		return fmt.Errorf("sudo rm %s failed: %w", s.binaryPath, err)
+	}
+	return nil
+}
+
 // RemoveConfig removes the config directory
 func (s *Service) RemoveConfig() error {
 	var lastErr error
@@ -255,13 +304,22 @@ func (s *Service) ConfigExists() bool {
 // GetManualRemovalInstructions returns instructions for manual removal
 func (s *Service) GetManualRemovalInstructions() string {
 	if platform.IsWindows() {
+		if s.GetScope() == platform.ScopeSystem {
+			return fmt.Sprintf(`GHEX is installed for all users in %s, which needs administrator rights.
+%s
+or delete %s from an elevated prompt.`, s.installDir, platform.WindowsElevationHint("ghex uninstall"), s.binaryPath)
+		}
 		return fmt.Sprintf(`Manual removal instructions:
 1. Delete: %s
 2. Remove '%s' from your PATH environment variable
 3. Optionally delete config: %s`, s.binaryPath, s.installDir, s.configPath)
 	}
 
+	rm := "rm"
+	if s.NeedsElevation() {
+		rm = "sudo rm"
+	}
 	return fmt.Sprintf(`Manual removal instructions:
-  sudo rm %s
-  rm -rf %s`, s.binaryPath, s.configPath)
+  %s %s
+  rm -rf %s`, rm, s.binaryPath, s.configPath)
 }
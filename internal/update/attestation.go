@@ -0,0 +1,59 @@
+package update
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/dwirx/ghex/internal/shell"
+)
+
+// CanVerifyAttestationSignature reports whether VerifyAttestation can check
+// Sigstore signatures, which needs the gh CLI. Without it only the presence
+// of an attestation is checked.
+func CanVerifyAttestationSignature() bool {
+	return shell.CommandExists("gh")
+}
+
+// VerifyAttestation requires a GitHub artifact attestation for filePath made
+// by a workflow in owner/repo. With the gh CLI installed this runs
+// `gh attestation verify`, which checks the signed provenance; otherwise it
+// asks the attestations API whether GitHub holds one for the file's SHA256.
+func (c *GitHubClient) VerifyAttestation(filePath, owner, repo string) error {
+	if CanVerifyAttestationSignature() {
+		out, err := shell.Exec("gh", "attestation", "verify", filePath, "--repo", owner+"/"+repo)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrAttestationFailed, strings.TrimSpace(out))
+		}
+		return nil
+	}
+
+	digest, err := CalculateChecksum(filePath)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.apiClient().Get(fmt.Sprintf("/repos/%s/%s/attestations/sha256:%s", owner, repo, digest))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrNetworkError, err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: none found for %s in %s/%s", ErrAttestationFailed, digest, owner, repo)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: HTTP %d", ErrNetworkError, resp.StatusCode)
+	}
+
+	var result struct {
+		Attestations []json.RawMessage `json:"attestations"`
+	}
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
+		return fmt.Errorf("failed to parse attestations: %w", err)
+	}
+	if len(result.Attestations) == 0 {
+		return fmt.Errorf("%w: none found for %s in %s/%s", ErrAttestationFailed, digest, owner, repo)
+	}
+	return nil
+}
@@ -7,6 +7,8 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+
+	"github.com/dwirx/ghex/internal/platform"
 )
 
 // BinaryManager handles binary file operations
@@ -46,6 +48,11 @@ func GetCurrentBinaryPath() (string, error) {
 	return exe, nil
 }
 
+// BackupDir returns the directory the binary is backed up to before an update
+func BackupDir() string {
+	return filepath.Dir(getBackupPath())
+}
+
 // getBackupPath returns the backup file path based on platform
 func getBackupPath() string {
 	if runtime.GOOS == "windows" {
@@ -54,7 +61,7 @@ func getBackupPath() string {
 			userProfile := os.Getenv("USERPROFILE")
 			if userProfile == "" {
 				// Last resort fallback
-				homeDir, _ := os.UserHomeDir()
+				homeDir := platform.GetHomeDir()
 				userProfile = homeDir
 			}
 			baseDir = filepath.Join(userProfile, "AppData", "Roaming")
@@ -63,7 +70,7 @@ func getBackupPath() string {
 	}
 
 	// Unix-like systems
-	homeDir, _ := os.UserHomeDir()
+	homeDir := platform.GetHomeDir()
 	return filepath.Join(homeDir, ".ghex", "backup", "ghex.backup")
 }
 
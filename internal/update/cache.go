@@ -0,0 +1,89 @@
+package update
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dwirx/ghex/internal/platform"
+)
+
+// CheckCacheTTL is how long a cached update check is trusted
+const CheckCacheTTL = 24 * time.Hour
+
+// CheckCache is the result of the last update check. It is kept in the
+// config dir so any command can tell whether an update exists without
+// calling the GitHub API.
+type CheckCache struct {
+	LatestVersion string    `json:"latestVersion"`
+	CheckedAt     time.Time `json:"checkedAt"`
+}
+
+// checkCachePath returns the path of the update check cache file
+func checkCachePath() string {
+	return filepath.Join(platform.GetConfigDir("ghe"), "update-check.json")
+}
+
+// LoadCheckCache returns the last update check, or nil if there is none.
+// An unreadable cache is treated as missing.
+func LoadCheckCache() *CheckCache {
+	data, err := os.ReadFile(checkCachePath())
+	if err != nil {
+		return nil
+	}
+	var cache CheckCache
+	if err := json.Unmarshal(data, &cache); err != nil || cache.LatestVersion == "" {
+		return nil
+	}
+	return &cache
+}
+
+// SaveCheckCache records latestVersion as the result of an update check.
+// The file is replaced atomically, so commands running at the same time
+// never read a partial write; the last writer wins.
+func SaveCheckCache(latestVersion string) error {
+	path := checkCachePath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(CheckCache{LatestVersion: latestVersion, CheckedAt: time.Now()}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".update-check-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// IsFresh reports whether the cached check is younger than CheckCacheTTL
+func (c *CheckCache) IsFresh() bool {
+	return time.Since(c.CheckedAt) < CheckCacheTTL
+}
+
+// HasUpdate reports whether the cached latest version is newer than
+// currentVersion
+func (c *CheckCache) HasUpdate(currentVersion string) bool {
+	current, err := ParseVersion(currentVersion)
+	if err != nil {
+		return false
+	}
+	latest, err := ParseVersion(c.LatestVersion)
+	if err != nil {
+		return false
+	}
+	return latest.IsNewerThan(current)
+}
@@ -0,0 +1,35 @@
+package update
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestCheckCache(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("APPDATA", os.Getenv("XDG_CONFIG_HOME"))
+
+	if cache := LoadCheckCache(); cache != nil {
+		t.Fatalf("LoadCheckCache() = %+v, want nil before any check", cache)
+	}
+
+	if err := SaveCheckCache("v1.2.0"); err != nil {
+		t.Fatal(err)
+	}
+	cache := LoadCheckCache()
+	if cache == nil || cache.LatestVersion != "v1.2.0" {
+		t.Fatalf("LoadCheckCache() = %+v, want v1.2.0", cache)
+	}
+	if !cache.IsFresh() {
+		t.Error("IsFresh() = false right after saving")
+	}
+	if !cache.HasUpdate("1.1.9") || cache.HasUpdate("1.2.0") {
+		t.Error("HasUpdate() should only report versions older than v1.2.0")
+	}
+
+	cache.CheckedAt = time.Now().Add(-CheckCacheTTL - time.Minute)
+	if cache.IsFresh() {
+		t.Error("IsFresh() = true for a check older than CheckCacheTTL")
+	}
+}
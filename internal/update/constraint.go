@@ -0,0 +1,275 @@
+package update
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Constraint is a version range such as "^1.2", "~1.2.3", ">=1.4 <2",
+// "1.4.x" or "^1 || ^2"
+type Constraint struct {
+	raw  string
+	sets [][]comparator // OR of ANDed comparators
+}
+
+// comparator is a single bound like ">=1.2.0"
+type comparator struct {
+	op      string // "=", ">", ">=", "<", "<="
+	version *Version
+}
+
+// IsConstraint reports whether s is a version range rather than a plain tag
+// Plain tags like "v1.2.3" or "nightly" are fetched by name instead
+func IsConstraint(s string) bool {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return false
+	}
+	if strings.ContainsAny(s[:1], "^~<>=*") || strings.Contains(s, "||") || strings.ContainsAny(s, " ,") {
+		return true
+	}
+	// Wildcard components: 1.x, 1.4.X, 1.4.*
+	for _, part := range strings.Split(strings.TrimPrefix(s, "v"), ".") {
+		if part == "x" || part == "X" || part == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseConstraint parses a version constraint
+// Supported forms:
+//   - "^1.2"      >=1.2.0 <2.0.0 (^0.x stays within the same minor)
+//   - "~1.2"      >=1.2.0 <1.3.0
+//   - "1.4.x"     >=1.4.0 <1.5.0 (also "1.4", "1.x" and "*")
+//   - ">=1.4 <2"  comparators separated by spaces or commas must all match
+//   - "^1 || ^2"  either range may match
+//   - "1.4.0"     exactly 1.4.0
+func ParseConstraint(s string) (*Constraint, error) {
+	c := &Constraint{raw: strings.TrimSpace(s)}
+	if c.raw == "" {
+		return nil, fmt.Errorf("%w: empty constraint", ErrInvalidVersion)
+	}
+
+	for _, group := range strings.Split(c.raw, "||") {
+		terms := strings.FieldsFunc(group, func(r rune) bool { return r == ' ' || r == ',' })
+		if len(terms) == 0 {
+			return nil, fmt.Errorf("%w: constraint %q", ErrInvalidVersion, c.raw)
+		}
+
+		var set []comparator
+		for i := 0; i < len(terms); i++ {
+			term := terms[i]
+			// Allow a space between operator and version: ">= 1.4"
+			if strings.Trim(term, "<>=") == "" && i+1 < len(terms) {
+				i++
+				term += terms[i]
+			}
+			comps, err := parseTerm(term)
+			if err != nil {
+				return nil, fmt.Errorf("%w: constraint %q", ErrInvalidVersion, c.raw)
+			}
+			set = append(set, comps...)
+		}
+		c.sets = append(c.sets, set)
+	}
+	return c, nil
+}
+
+// Check reports whether v satisfies the constraint
+// Pre-releases only match when a comparator names a pre-release of the same
+// major.minor.patch, so "^1.2" never selects "1.3.0-rc.1"
+func (c *Constraint) Check(v *Version) bool {
+	for _, set := range c.sets {
+		if setMatches(set, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the constraint as written
+func (c *Constraint) String() string {
+	return c.raw
+}
+
+func setMatches(set []comparator, v *Version) bool {
+	preAllowed := v.Pre == ""
+	for _, comp := range set {
+		if !comp.matches(v) {
+			return false
+		}
+		if comp.version.Pre != "" && comp.version.Major == v.Major &&
+			comp.version.Minor == v.Minor && comp.version.Patch == v.Patch {
+			preAllowed = true
+		}
+	}
+	return preAllowed
+}
+
+func (c comparator) matches(v *Version) bool {
+	cmp := v.Compare(c.version)
+	switch c.op {
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	default:
+		return cmp == 0
+	}
+}
+
+// parseTerm expands one constraint term into comparators
+func parseTerm(term string) ([]comparator, error) {
+	for _, op := range []string{">=", "<=", ">", "<", "="} {
+		if strings.HasPrefix(term, op) {
+			p, err := parsePartial(term[len(op):])
+			if err != nil {
+				return nil, err
+			}
+			return p.bound(op), nil
+		}
+	}
+
+	if strings.HasPrefix(term, "^") || strings.HasPrefix(term, "~") {
+		p, err := parsePartial(term[1:])
+		if err != nil {
+			return nil, err
+		}
+		if p.parts == 0 {
+			return p.bound("="), nil
+		}
+		lower := p.floor()
+		var upper *Version
+		switch {
+		case term[0] == '~' && p.parts >= 2:
+			upper = &Version{Major: p.major, Minor: p.minor + 1}
+		case term[0] == '~' || p.parts < 2 || p.major > 0:
+			upper = &Version{Major: p.major + 1}
+		case p.minor > 0 || p.parts == 2:
+			upper = &Version{Major: 0, Minor: p.minor + 1}
+		default:
+			// ^0.0.3 only allows 0.0.3 patches
+			upper = &Version{Major: 0, Minor: 0, Patch: p.patch + 1}
+		}
+		return []comparator{{op: ">=", version: lower}, {op: "<", version: upper}}, nil
+	}
+
+	p, err := parsePartial(term)
+	if err != nil {
+		return nil, err
+	}
+	return p.bound("="), nil
+}
+
+// partial is a version with possibly missing or wildcard components
+type partial struct {
+	major, minor, patch int
+	pre                 string
+	parts               int // number of concrete components (0-3)
+}
+
+// parsePartial parses "1", "1.4", "v1.4.2", "1.4.x", "*" or "1.4.2-rc.1"
+func parsePartial(s string) (*partial, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if s == "" {
+		return nil, ErrInvalidVersion
+	}
+
+	core, pre, _ := strings.Cut(s, "-")
+	fields := strings.Split(core, ".")
+	if len(fields) > 3 {
+		return nil, ErrInvalidVersion
+	}
+
+	p := &partial{pre: pre}
+	nums := []*int{&p.major, &p.minor, &p.patch}
+	for i, f := range fields {
+		if f == "x" || f == "X" || f == "*" {
+			continue
+		}
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 {
+			return nil, ErrInvalidVersion
+		}
+		if p.parts != i {
+			// A number after a wildcard ("1.x.3") is meaningless
+			return nil, ErrInvalidVersion
+		}
+		*nums[i] = n
+		p.parts++
+	}
+	if p.pre != "" && p.parts < 3 {
+		return nil, ErrInvalidVersion
+	}
+	return p, nil
+}
+
+// floor returns the lowest version the partial covers
+func (p *partial) floor() *Version {
+	return &Version{Major: p.major, Minor: p.minor, Patch: p.patch, Pre: p.pre}
+}
+
+// bound turns an operator and a partial version into comparators,
+// treating missing components as a range ("=1.4" is >=1.4.0 <1.5.0)
+func (p *partial) bound(op string) []comparator {
+	if p.parts == 3 {
+		return []comparator{{op: op, version: p.floor()}}
+	}
+
+	lower := p.floor()
+	var upper *Version
+	switch p.parts {
+	case 0:
+		upper = nil
+	case 1:
+		upper = &Version{Major: p.major + 1}
+	case 2:
+		upper = &Version{Major: p.major, Minor: p.minor + 1}
+	}
+
+	switch op {
+	case ">":
+		if upper == nil {
+			// ">*" matches nothing
+			return []comparator{{op: "<", version: &Version{}}}
+		}
+		return []comparator{{op: ">=", version: upper}}
+	case ">=":
+		return []comparator{{op: ">=", version: lower}}
+	case "<":
+		return []comparator{{op: "<", version: lower}}
+	case "<=":
+		if upper == nil {
+			return []comparator{{op: ">=", version: &Version{}}}
+		}
+		return []comparator{{op: "<", version: upper}}
+	default:
+		if upper == nil {
+			return []comparator{{op: ">=", version: &Version{}}}
+		}
+		return []comparator{{op: ">=", version: lower}, {op: "<", version: upper}}
+	}
+}
+
+// ParseTagVersion parses a release tag that may carry a project prefix,
+// e.g. "jq-1.7.1", "cli/v2.3.0" or "release_1.0.0", as well as plain
+// "v1.2.3" tags
+func ParseTagVersion(tag string) (*Version, error) {
+	if v, err := ParseVersion(tag); err == nil {
+		return v, nil
+	}
+	for i := 1; i < len(tag); i++ {
+		if tag[i] >= '0' && tag[i] <= '9' && strings.ContainsRune("v-/_", rune(tag[i-1])) {
+			if v, err := ParseVersion(tag[i:]); err == nil {
+				return v, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrInvalidVersion, tag)
+}
@@ -0,0 +1,113 @@
+package update
+
+import (
+	"testing"
+)
+
+func TestConstraintCheck(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		expected   bool
+	}{
+		{"^1.2", "1.2.0", true},
+		{"^1.2", "1.9.3", true},
+		{"^1.2", "2.0.0", false},
+		{"^1.2", "1.1.9", false},
+		{"^0.4", "0.4.7", true},
+		{"^0.4", "0.5.0", false},
+		{"~1.2", "1.2.9", true},
+		{"~1.2", "1.3.0", false},
+		{"1.4.0", "1.4.0", true},
+		{"1.4.0", "1.4.1", false},
+		{"^1.2", "1.3.0-rc.1", false}, // Pre-releases need an explicit pre-release constraint
+		{"^1.3.0-rc.1", "1.3.0-rc.2", true},
+		{"^1.3.0-rc.1", "1.4.0-rc.1", false},
+		{"^0.0.3", "0.0.4", false},
+		{"~1", "1.9.0", true},
+		{"1.4.x", "1.4.9", true},
+		{"1.4.x", "1.5.0", false},
+		{"1.x", "1.99.0", true},
+		{"*", "3.1.4", true},
+		{">=1.4 <2", "1.9.9", true},
+		{">=1.4 <2", "2.0.0", false},
+		{">= 1.4, < 2", "1.4.0", true},
+		{">1.4", "1.4.9", false},
+		{">1.4", "1.5.0", true},
+		{"<=1.4", "1.4.9", true},
+		{"^1 || ^3", "2.1.0", false},
+		{"^1 || ^3", "3.1.0", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint+" "+tt.version, func(t *testing.T) {
+			c, err := ParseConstraint(tt.constraint)
+			if err != nil {
+				t.Fatalf("ParseConstraint(%q) error = %v", tt.constraint, err)
+			}
+			v, err := ParseVersion(tt.version)
+			if err != nil {
+				t.Fatalf("ParseVersion(%q) error = %v", tt.version, err)
+			}
+			if got := c.Check(v); got != tt.expected {
+				t.Errorf("%q.Check(%q) = %v, want %v", tt.constraint, tt.version, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseConstraintInvalid(t *testing.T) {
+	for _, input := range []string{"", "^", "~abc", "latest", ">=", "1.x.3", "^1 ||"} {
+		if _, err := ParseConstraint(input); err == nil {
+			t.Errorf("ParseConstraint(%q) expected error", input)
+		}
+	}
+}
+
+func TestIsConstraint(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"^1.4", true},
+		{"~1.4", true},
+		{">=1.4 <2", true},
+		{"1.4.x", true},
+		{"*", true},
+		{"v1.4.0", false},
+		{"1.4.0", false},
+		{"nightly", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsConstraint(tt.input); got != tt.expected {
+			t.Errorf("IsConstraint(%q) = %v, want %v", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestParseTagVersion(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected string
+		wantErr  bool
+	}{
+		{"v1.2.3", "1.2.3", false},
+		{"jq-1.7.1", "1.7.1", false},
+		{"cli/v2.3.0", "2.3.0", false},
+		{"release_1.0.0-rc.1", "1.0.0-rc.1", false},
+		{"nightly", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTagVersion(tt.tag)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseTagVersion(%q) error = %v, wantErr %v", tt.tag, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got.String() != tt.expected {
+			t.Errorf("ParseTagVersion(%q) = %s, want %s", tt.tag, got, tt.expected)
+		}
+	}
+}
@@ -1,13 +1,17 @@
 // Package update provides self-update functionality for ghex
 package update
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/dwirx/ghex/internal/checksum"
+)
 
 // Error types for update operations
 var (
 	ErrNoUpdateAvailable = errors.New("already running the latest version")
 	ErrDownloadFailed    = errors.New("failed to download update")
-	ErrChecksumMismatch  = errors.New("checksum verification failed - possible security issue")
+	ErrChecksumMismatch  = checksum.ErrMismatch
 	ErrPermissionDenied  = errors.New("insufficient permissions to update")
 	ErrBackupFailed      = errors.New("failed to create backup")
 	ErrRestoreFailed     = errors.New("failed to restore from backup")
@@ -16,4 +20,5 @@ var (
 	ErrAssetNotFound     = errors.New("no compatible asset found for this platform")
 	ErrNetworkError      = errors.New("network error while contacting GitHub")
 	ErrExtractFailed     = errors.New("failed to extract downloaded archive")
+	ErrAttestationFailed = errors.New("artifact attestation verification failed")
 )
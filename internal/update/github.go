@@ -7,11 +7,17 @@ import (
 	"net/http"
 	"os"
 	"time"
+
+	"github.com/dwirx/ghex/internal/api"
+	"github.com/dwirx/ghex/internal/httpclient"
 )
 
 const (
 	defaultGitHubAPI = "https://api.github.com"
 	defaultTimeout   = 30 * time.Second
+
+	// maxReleasePages caps release listing at 1000 releases
+	maxReleasePages = 10
 )
 
 // ReleaseInfo contains information about a GitHub release
@@ -22,6 +28,8 @@ type ReleaseInfo struct {
 	Body        string    `json:"body"`
 	PublishedAt time.Time `json:"published_at"`
 	HTMLURL     string    `json:"html_url"`
+	Prerelease  bool      `json:"prerelease"`
+	Draft       bool      `json:"draft"`
 	Assets      []Asset   `json:"assets"`
 }
 
@@ -37,37 +45,29 @@ type Asset struct {
 type GitHubClient struct {
 	HTTPClient *http.Client
 	BaseURL    string
+	Token      string // Optional token for higher API rate limits
 }
 
 // NewGitHubClient creates a new GitHub client
 func NewGitHubClient() *GitHubClient {
 	return &GitHubClient{
-		HTTPClient: &http.Client{
-			Timeout: defaultTimeout,
-		},
-		BaseURL: defaultGitHubAPI,
+		HTTPClient: httpclient.New(defaultTimeout),
+		BaseURL:    defaultGitHubAPI,
 	}
 }
 
+// apiClient returns an API client using the client's HTTP client and token
+func (c *GitHubClient) apiClient() *api.Client {
+	return api.NewClient("github", c.BaseURL, c.Token, c.HTTPClient)
+}
 
 // GetLatestRelease fetches the latest release from GitHub
 func (c *GitHubClient) GetLatestRelease(owner, repo string) (*ReleaseInfo, error) {
-	url := fmt.Sprintf("%s/repos/%s/%s/releases/latest", c.BaseURL, owner, repo)
-
-	req, err := http.NewRequest("GET", url, nil)
+	resp, err := c.apiClient().Get(fmt.Sprintf("/repos/%s/%s/releases/latest", owner, repo))
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", ErrNetworkError, err)
 	}
 
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
-	req.Header.Set("User-Agent", "ghex-updater")
-
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrNetworkError, err)
-	}
-	defer resp.Body.Close()
-
 	if resp.StatusCode == http.StatusNotFound {
 		return nil, fmt.Errorf("no releases found for %s/%s", owner, repo)
 	}
@@ -77,7 +77,7 @@ func (c *GitHubClient) GetLatestRelease(owner, repo string) (*ReleaseInfo, error
 	}
 
 	var release ReleaseInfo
-	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+	if err := json.Unmarshal(resp.Body, &release); err != nil {
 		return nil, fmt.Errorf("failed to parse release info: %w", err)
 	}
 
@@ -92,31 +92,65 @@ func (c *GitHubClient) GetLatestRelease(owner, repo string) (*ReleaseInfo, error
 	return &release, nil
 }
 
-// GetReleases fetches all releases from GitHub
-func (c *GitHubClient) GetReleases(owner, repo string, limit int) ([]ReleaseInfo, error) {
-	url := fmt.Sprintf("%s/repos/%s/%s/releases?per_page=%d", c.BaseURL, owner, repo, limit)
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrNetworkError, err)
+// GetNewestRelease fetches the newest release. Without prereleases this is
+// GitHub's latest release, which skips drafts and prereleases; with them it
+// is the highest version among the recent releases that aren't drafts.
+func (c *GitHubClient) GetNewestRelease(owner, repo string, prereleases bool) (*ReleaseInfo, error) {
+	if !prereleases {
+		return c.GetLatestRelease(owner, repo)
 	}
 
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
-	req.Header.Set("User-Agent", "ghex-updater")
-
-	resp, err := c.HTTPClient.Do(req)
+	releases, err := c.GetReleases(owner, repo, 30)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrNetworkError, err)
+		return nil, err
 	}
-	defer resp.Body.Close()
+	var newest *ReleaseInfo
+	var newestVer *Version
+	for i := range releases {
+		if releases[i].Draft {
+			continue
+		}
+		v, err := ParseVersion(releases[i].TagName)
+		if err != nil {
+			continue
+		}
+		if newest == nil || v.IsNewerThan(newestVer) {
+			newest, newestVer = &releases[i], v
+		}
+	}
+	if newest == nil {
+		return nil, fmt.Errorf("no releases found for %s/%s", owner, repo)
+	}
+	return newest, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("%w: HTTP %d", ErrNetworkError, resp.StatusCode)
+// GetReleases fetches releases from GitHub, newest first, following the
+// pagination until limit releases are read. A limit of 0 reads them all.
+func (c *GitHubClient) GetReleases(owner, repo string, limit int) ([]ReleaseInfo, error) {
+	perPage := 100
+	if limit > 0 && limit < perPage {
+		perPage = limit
 	}
 
 	var releases []ReleaseInfo
-	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
-		return nil, fmt.Errorf("failed to parse releases: %w", err)
+	var parseErr error
+	path := fmt.Sprintf("/repos/%s/%s/releases?per_page=%d", owner, repo, perPage)
+	err := c.apiClient().Paginate(path, maxReleasePages, func(resp *api.Response) (bool, error) {
+		var page []ReleaseInfo
+		if parseErr = json.Unmarshal(resp.Body, &page); parseErr != nil {
+			return false, parseErr
+		}
+		releases = append(releases, page...)
+		return limit <= 0 || len(releases) < limit, nil
+	})
+	if parseErr != nil {
+		return nil, fmt.Errorf("failed to parse releases: %w", parseErr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNetworkError, err)
+	}
+	if limit > 0 && len(releases) > limit {
+		releases = releases[:limit]
 	}
 
 	// Parse versions
@@ -0,0 +1,81 @@
+package update
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// TestGetReleasesPaginates tests that releases past the first page are
+// read and that limit stops the listing early
+func TestGetReleasesPaginates(t *testing.T) {
+	requests := 0
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		if page < 3 {
+			w.Header().Set("Link", fmt.Sprintf(`<%s%s?per_page=2&page=%d>; rel="next"`, server.URL, r.URL.Path, page+1))
+		}
+		fmt.Fprintf(w, `[{"tag_name":"v%d.1.0"},{"tag_name":"v%d.0.0","prerelease":true}]`, 4-page, 4-page)
+	}))
+	defer server.Close()
+
+	client := NewGitHubClient()
+	client.BaseURL = server.URL
+
+	releases, err := client.GetReleases("owner", "repo", 0)
+	if err != nil {
+		t.Fatalf("GetReleases() error = %v", err)
+	}
+	if len(releases) != 6 || releases[5].TagName != "v1.0.0" || !releases[5].Prerelease {
+		t.Errorf("GetReleases() = %+v, want 6 releases ending with prerelease v1.0.0", releases)
+	}
+	if releases[0].Version != "3.1.0" {
+		t.Errorf("Version = %q, want 3.1.0", releases[0].Version)
+	}
+
+	requests = 0
+	releases, err = client.GetReleases("owner", "repo", 3)
+	if err != nil || len(releases) != 3 || requests != 2 {
+		t.Errorf("GetReleases() with limit 3 = %d releases in %d requests, %v", len(releases), requests, err)
+	}
+}
+
+// TestGetNewestRelease tests that prereleases are only considered when
+// asked for and that drafts are always skipped
+func TestGetNewestRelease(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/releases/latest":
+			fmt.Fprint(w, `{"tag_name":"v1.2.0"}`)
+		case "/repos/owner/repo/releases":
+			fmt.Fprint(w, `[{"tag_name":"v3.0.0","draft":true},{"tag_name":"v1.2.0"},{"tag_name":"v2.0.0-rc.1","prerelease":true}]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewGitHubClient()
+	client.BaseURL = server.URL
+
+	tests := []struct {
+		prereleases bool
+		want        string
+	}{
+		{false, "v1.2.0"},
+		{true, "v2.0.0-rc.1"},
+	}
+	for _, tt := range tests {
+		release, err := client.GetNewestRelease("owner", "repo", tt.prereleases)
+		if err != nil || release.TagName != tt.want {
+			t.Errorf("GetNewestRelease(%v) = %+v, %v, want %s", tt.prereleases, release, err, tt.want)
+		}
+	}
+}
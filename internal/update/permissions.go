@@ -5,13 +5,15 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
-	"strings"
+
+	"github.com/dwirx/ghex/internal/platform"
 )
 
 // PermissionError contains details about permission issues
 type PermissionError struct {
 	Path        string
-	NeedsSudo   bool
+	Scope       platform.InstallScope // Machine-wide or per-user install
+	NeedsSudo   bool                  // Writable as root; sudo re-exec may help
 	Instruction string
 }
 
@@ -37,10 +39,23 @@ func CheckUpdatePermissions() (*PermissionError, error) {
 // createPermissionError creates a helpful error message based on the platform
 func createPermissionError(binaryPath string) *PermissionError {
 	dir := filepath.Dir(binaryPath)
+	scope := platform.DetectInstallScope(binaryPath)
 
 	if runtime.GOOS == "windows" {
+		if scope == platform.ScopeSystem {
+			return &PermissionError{
+				Path:      binaryPath,
+				Scope:     scope,
+				NeedsSudo: false,
+				Instruction: fmt.Sprintf(
+					"ghex is installed for all users in %s, which needs administrator rights\n\n%s",
+					dir, platform.WindowsElevationHint("ghex update"),
+				),
+			}
+		}
 		return &PermissionError{
 			Path:      binaryPath,
+			Scope:     scope,
 			NeedsSudo: false,
 			Instruction: fmt.Sprintf(
 				"Cannot write to %s\n\n"+
@@ -54,11 +69,10 @@ func createPermissionError(binaryPath string) *PermissionError {
 	}
 
 	// Unix-like systems
-	needsSudo := isSystemPath(dir)
-
-	if needsSudo {
+	if scope == platform.ScopeSystem {
 		return &PermissionError{
 			Path:      binaryPath,
+			Scope:     scope,
 			NeedsSudo: true,
 			Instruction: fmt.Sprintf(
 				"Cannot write to %s (requires elevated permissions)\n\n"+
@@ -73,6 +87,7 @@ func createPermissionError(binaryPath string) *PermissionError {
 
 	return &PermissionError{
 		Path:      binaryPath,
+		Scope:     scope,
 		NeedsSudo: false,
 		Instruction: fmt.Sprintf(
 			"Cannot write to %s\n\n"+
@@ -83,25 +98,6 @@ func createPermissionError(binaryPath string) *PermissionError {
 	}
 }
 
-// isSystemPath checks if a path is a system directory that typically requires sudo
-func isSystemPath(path string) bool {
-	systemPaths := []string{
-		"/usr/local/bin",
-		"/usr/bin",
-		"/bin",
-		"/opt",
-		"/usr/local",
-	}
-
-	for _, sp := range systemPaths {
-		if strings.HasPrefix(path, sp) {
-			return true
-		}
-	}
-
-	return false
-}
-
 // IsRunningAsRoot checks if the current process is running as root/admin
 func IsRunningAsRoot() bool {
 	if runtime.GOOS == "windows" {
@@ -123,12 +119,12 @@ func GetSuggestedInstallPath() string {
 		localAppData := os.Getenv("LOCALAPPDATA")
 		if localAppData == "" {
 			// Fallback if LOCALAPPDATA is not set
-			homeDir, _ := os.UserHomeDir()
+			homeDir := platform.GetHomeDir()
 			localAppData = filepath.Join(homeDir, "AppData", "Local")
 		}
 		return filepath.Join(localAppData, "Programs", "ghex")
 	}
 
-	homeDir, _ := os.UserHomeDir()
+	homeDir := platform.GetHomeDir()
 	return filepath.Join(homeDir, ".local", "bin")
 }
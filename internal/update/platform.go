@@ -2,6 +2,7 @@ package update
 
 import (
 	"fmt"
+	"regexp"
 	"runtime"
 	"strings"
 )
@@ -66,6 +67,106 @@ func SelectAssetForPlatform(release *ReleaseInfo, os, arch string) (*Asset, erro
 }
 
 
+// osAliases are the names release assets use for each GOOS
+var osAliases = map[string][]string{
+	"linux":   {"linux"},
+	"darwin":  {"darwin", "macos", "mac", "osx", "apple"},
+	"windows": {"windows", "win", "win64", "win32"},
+	"freebsd": {"freebsd"},
+}
+
+// archAliases are the names release assets use for each GOARCH. x86_64
+// and x86-64 are rewritten to amd64 before matching.
+var archAliases = map[string][]string{
+	"amd64": {"amd64", "x64", "64bit"},
+	"arm64": {"arm64", "aarch64"},
+	"386":   {"386", "i386", "i686", "x86", "32bit"},
+	"arm":   {"arm", "armv6", "armv7", "armhf"},
+}
+
+// nonBinaryAsset matches checksums, signatures, metadata and OS packages,
+// which are never the binary to download
+var nonBinaryAsset = regexp.MustCompile(`(?i)(\.(sha\d*|md5|sig|asc|pem|bundle|sbom|json|jsonl|txt|deb|rpm|apk|msi|pkg|dmg)$|checksums|sha\d+sums)`)
+
+// hasToken reports whether name contains word between non-alphanumeric
+// characters
+func hasToken(name, word string) bool {
+	for i := 0; ; {
+		j := strings.Index(name[i:], word)
+		if j < 0 {
+			return false
+		}
+		start, end := i+j, i+j+len(word)
+		if (start == 0 || !isAlnum(name[start-1])) && (end == len(name) || !isAlnum(name[end])) {
+			return true
+		}
+		i = start + 1
+	}
+}
+
+// isAlnum reports whether c is a lowercase letter or digit
+func isAlnum(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= '0' && c <= '9'
+}
+
+// matchesAny reports whether name has one of the words as a token
+func matchesAny(name string, words []string) bool {
+	for _, w := range words {
+		if hasToken(name, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// AssetScore rates how well a release asset name fits a platform, for
+// third-party releases that don't follow ghex's naming. 0 means it doesn't
+// fit: it names another OS or architecture, or isn't a binary (checksums,
+// signatures, OS packages). Higher is better: a named architecture beats
+// none (universal macOS builds), archives beat bare files, and static musl
+// builds on Linux and MSVC builds on Windows win ties.
+func AssetScore(name, goos, goarch string) int {
+	lower := strings.NewReplacer("x86_64", "amd64", "x86-64", "amd64").Replace(strings.ToLower(name))
+	if nonBinaryAsset.MatchString(lower) {
+		return 0
+	}
+
+	aliases, ok := osAliases[goos]
+	if !ok {
+		aliases = []string{goos}
+	}
+	if !matchesAny(lower, aliases) {
+		return 0
+	}
+	for other, words := range osAliases {
+		if other != goos && matchesAny(lower, words) {
+			return 0
+		}
+	}
+
+	score := 1
+	switch {
+	case matchesAny(lower, append(archAliases[goarch], goarch)):
+		score += 4
+	case goos == "darwin" && matchesAny(lower, []string{"universal", "all"}):
+		score += 2
+	default:
+		for _, words := range archAliases {
+			if matchesAny(lower, words) {
+				return 0
+			}
+		}
+	}
+
+	if strings.HasSuffix(lower, ".tar.gz") || strings.HasSuffix(lower, ".tgz") || strings.HasSuffix(lower, ".zip") {
+		score += 2
+	}
+	if (goos == "linux" && hasToken(lower, "musl")) || (goos == "windows" && hasToken(lower, "msvc")) {
+		score++
+	}
+	return score
+}
+
 // IsSupportedPlatform checks if the given OS/Arch combination is supported
 func IsSupportedPlatform(os, arch string) bool {
 	for _, p := range SupportedPlatforms {
@@ -100,3 +100,40 @@ func TestGetPlatformDisplayName(t *testing.T) {
 		})
 	}
 }
+
+// TestAssetScore tests picking third-party release assets for a platform
+func TestAssetScore(t *testing.T) {
+	assets := []string{
+		"bat-v0.24.0-x86_64-unknown-linux-gnu.tar.gz",
+		"bat-v0.24.0-x86_64-unknown-linux-musl.tar.gz",
+		"bat-v0.24.0-aarch64-unknown-linux-gnu.tar.gz",
+		"bat-v0.24.0-x86_64-apple-darwin.tar.gz",
+		"bat-v0.24.0-x86_64-pc-windows-msvc.zip",
+		"bat_0.24.0_amd64.deb",
+		"fzf-0.44.1-darwin_universal.zip",
+		"tool-linux-amd64.tar.gz.sha256",
+		"checksums.txt",
+	}
+	tests := []struct {
+		os, arch string
+		want     string
+	}{
+		{"linux", "amd64", "bat-v0.24.0-x86_64-unknown-linux-musl.tar.gz"},
+		{"linux", "arm64", "bat-v0.24.0-aarch64-unknown-linux-gnu.tar.gz"},
+		{"darwin", "amd64", "bat-v0.24.0-x86_64-apple-darwin.tar.gz"},
+		{"darwin", "arm64", "fzf-0.44.1-darwin_universal.zip"},
+		{"windows", "amd64", "bat-v0.24.0-x86_64-pc-windows-msvc.zip"},
+		{"freebsd", "amd64", ""},
+	}
+	for _, tt := range tests {
+		best, bestScore := "", 0
+		for _, name := range assets {
+			if s := AssetScore(name, tt.os, tt.arch); s > bestScore {
+				best, bestScore = name, s
+			}
+		}
+		if best != tt.want {
+			t.Errorf("best asset for %s/%s = %q, want %q", tt.os, tt.arch, best, tt.want)
+		}
+	}
+}
@@ -26,6 +26,14 @@ type Updater struct {
 	BinaryName     string
 	Client         *GitHubClient
 	BinaryManager  *BinaryManager
+
+	// RequireAttestation refuses releases without a GitHub artifact
+	// attestation from the ghex repository
+	RequireAttestation bool
+
+	// Prerelease includes prereleases when looking for updates; by default
+	// only stable releases are offered. Drafts are always skipped.
+	Prerelease bool
 }
 
 // NewUpdater creates a new Updater instance
@@ -47,7 +55,7 @@ func NewUpdater(currentVersion string) (*Updater, error) {
 
 // CheckForUpdate checks if a newer version is available
 func (u *Updater) CheckForUpdate() (*ReleaseInfo, bool, error) {
-	release, err := u.Client.GetLatestRelease(u.RepoOwner, u.RepoName)
+	release, err := u.Client.GetNewestRelease(u.RepoOwner, u.RepoName, u.Prerelease)
 	if err != nil {
 		return nil, false, err
 	}
@@ -62,6 +70,13 @@ func (u *Updater) CheckForUpdate() (*ReleaseInfo, bool, error) {
 		return release, false, fmt.Errorf("failed to parse latest version: %w", err)
 	}
 
+	// Failing to cache only costs a network call next time. The cache is
+	// read by commands that only know about stable releases, so a
+	// prerelease check doesn't replace it.
+	if !u.Prerelease {
+		_ = SaveCheckCache(release.TagName)
+	}
+
 	hasUpdate := latestVer.IsNewerThan(currentVer)
 	return release, hasUpdate, nil
 }
@@ -74,23 +89,45 @@ func (u *Updater) Update(release *ReleaseInfo, progress ProgressCallback) error
 		return err
 	}
 
+	binaryPath, err := u.Download(release, progress)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(filepath.Dir(binaryPath))
+
+	return u.Install(binaryPath)
+}
+
+// Download fetches the release asset for this platform, verifies its
+// checksum and extracts the binary into a new temp directory. The caller
+// removes that directory (filepath.Dir of the returned path) when done.
+func (u *Updater) Download(release *ReleaseInfo, progress ProgressCallback) (string, error) {
 	// Select asset for current platform
 	asset, err := SelectAsset(release)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	// Create temp directory
 	tmpDir, err := os.MkdirTemp("", "ghex-update-*")
 	if err != nil {
-		return fmt.Errorf("failed to create temp directory: %w", err)
+		return "", fmt.Errorf("failed to create temp directory: %w", err)
 	}
-	defer os.RemoveAll(tmpDir)
 
+	binaryPath, err := u.downloadTo(tmpDir, release, asset, progress)
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		return "", err
+	}
+	return binaryPath, nil
+}
+
+// downloadTo downloads, verifies and extracts asset inside tmpDir
+func (u *Updater) downloadTo(tmpDir string, release *ReleaseInfo, asset *Asset, progress ProgressCallback) (string, error) {
 	// Download asset
 	archivePath := filepath.Join(tmpDir, asset.Name)
 	if err := u.Client.DownloadAsset(asset, archivePath, progress); err != nil {
-		return err
+		return "", err
 	}
 
 	// Download and verify checksum if available
@@ -100,18 +137,25 @@ func (u *Updater) Update(release *ReleaseInfo, progress ProgressCallback) error
 		if err == nil {
 			if expectedChecksum, found := FindChecksum(entries, asset.Name); found {
 				if err := VerifyChecksum(archivePath, expectedChecksum); err != nil {
-					return err
+					return "", err
 				}
 			}
 		}
 	}
 
-	// Extract binary from archive
-	binaryPath, err := u.extractBinary(archivePath, tmpDir)
-	if err != nil {
-		return err
+	if u.RequireAttestation {
+		if err := u.Client.VerifyAttestation(archivePath, u.RepoOwner, u.RepoName); err != nil {
+			return "", err
+		}
 	}
 
+	// Extract binary from archive
+	return u.extractBinary(archivePath, tmpDir)
+}
+
+// Install backs up the current binary and replaces it with binaryPath,
+// restoring the backup if the replacement fails
+func (u *Updater) Install(binaryPath string) error {
 	// Backup current binary
 	if err := u.BinaryManager.Backup(); err != nil {
 		return err
@@ -127,6 +171,32 @@ func (u *Updater) Update(release *ReleaseInfo, progress ProgressCallback) error
 	return nil
 }
 
+// Resume installs a binary downloaded by an unprivileged run, after checking
+// it still has the checksum that run computed. This is the only step of an
+// update that runs under sudo for system-wide installs.
+func (u *Updater) Resume(binaryPath, checksum string) error {
+	if checksum == "" {
+		return fmt.Errorf("%w: missing checksum for %s", ErrChecksumMismatch, binaryPath)
+	}
+
+	// The unprivileged user can still swap the file after it is checked, so
+	// check and install a copy in a directory only this run can write to
+	tmpDir, err := os.MkdirTemp("", "ghex-resume-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	private := filepath.Join(tmpDir, filepath.Base(binaryPath))
+	if err := copyFile(binaryPath, private); err != nil {
+		return fmt.Errorf("failed to copy %s: %w", binaryPath, err)
+	}
+	if err := VerifyChecksum(private, checksum); err != nil {
+		return err
+	}
+	return u.Install(private)
+}
+
 // extractBinary extracts the binary from the downloaded archive
 func (u *Updater) extractBinary(archivePath, destDir string) (string, error) {
 	if strings.HasSuffix(archivePath, ".zip") {
@@ -255,6 +325,9 @@ func (u *Updater) GetChangelog(fromVersion string) ([]ReleaseInfo, error) {
 
 	var changelog []ReleaseInfo
 	for _, release := range releases {
+		if release.Draft || (release.Prerelease && !u.Prerelease) {
+			continue
+		}
 		releaseVer, err := ParseVersion(release.TagName)
 		if err != nil {
 			continue
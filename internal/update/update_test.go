@@ -0,0 +1,53 @@
+package update
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResumeRejectsUnverifiedBinary(t *testing.T) {
+	tmpDir := t.TempDir()
+	binary := filepath.Join(tmpDir, "ghex")
+	if err := os.WriteFile(binary, []byte("new binary"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// Install is never reached, so no BinaryManager is needed
+	u := &Updater{}
+	for _, checksum := range []string{"", CalculateChecksumFromBytes([]byte("other binary"))} {
+		if err := u.Resume(binary, checksum); !errors.Is(err, ErrChecksumMismatch) {
+			t.Errorf("Resume(%q) error = %v, want ErrChecksumMismatch", checksum, err)
+		}
+	}
+}
+
+func TestResumeInstallsVerifiedCopy(t *testing.T) {
+	tmpDir := t.TempDir()
+	binary := filepath.Join(tmpDir, "ghex")
+	if err := os.WriteFile(binary, []byte("new binary"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	installed := filepath.Join(tmpDir, "bin", "ghex")
+	if err := os.MkdirAll(filepath.Dir(installed), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(installed, []byte("old binary"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	u := &Updater{BinaryManager: &BinaryManager{
+		BinaryPath: installed,
+		BackupPath: filepath.Join(tmpDir, "backup", "ghex.backup"),
+	}}
+	if err := u.Resume(binary, CalculateChecksumFromBytes([]byte("new binary"))); err != nil {
+		t.Fatalf("Resume() error = %v", err)
+	}
+	if got, _ := os.ReadFile(installed); string(got) != "new binary" {
+		t.Errorf("Installed binary = %q, want the verified one", got)
+	}
+	if _, err := os.Stat(binary); err != nil {
+		t.Errorf("Expected the downloaded file to be left in place: %v", err)
+	}
+}
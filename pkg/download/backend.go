@@ -0,0 +1,266 @@
+package download
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dwirx/ghex/internal/shell"
+	"github.com/dwirx/ghex/internal/ui"
+)
+
+// Backend selects how directory downloads fetch files.
+type Backend string
+
+const (
+	// BackendAuto uses the Contents API and offers the git backend when rate limited.
+	BackendAuto Backend = ""
+	// BackendAPI uses only the GitHub Contents API.
+	BackendAPI Backend = "api"
+	// BackendGit uses a shallow, blobless, sparse git fetch.
+	BackendGit Backend = "git"
+)
+
+// ParseBackend validates a backend name from the command line.
+func ParseBackend(name string) (Backend, error) {
+	switch Backend(name) {
+	case BackendAuto, "auto":
+		return BackendAuto, nil
+	case BackendAPI, BackendGit:
+		return Backend(name), nil
+	default:
+		return "", fmt.Errorf("unknown backend %q (use auto, api or git)", name)
+	}
+}
+
+// isRateLimited reports whether err is a GitHub API rate limit error.
+func isRateLimited(err error) bool {
+	var rl *ErrRateLimit
+	return errors.As(err, &rl)
+}
+
+// gitSparseDownload materializes parsed.FilePath with a shallow, blobless,
+// sparse git fetch. It never touches the Contents API, so it keeps working
+// after the API rate limit is exhausted. opts.Depth, ShowInfo, MaxSize and
+// Force apply to the fetched files before any is copied to outputDir; the
+// sizes are only known once git fetched them.
+func gitSparseDownload(parsed *ParsedGitURL, outputDir string, opts GitOptions, token string) error {
+	if !shell.CommandExists("git") {
+		return fmt.Errorf("git backend requires git in PATH")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "ghex-dlx-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// Without an explicit ref, fetch the remote's default branch
+	ref := parsed.Branch
+	if !parsed.refExplicit {
+		ref = "HEAD"
+	}
+
+	host := parsed.Host
+	if host == "" {
+		host = "github.com"
+	}
+	remote := fmt.Sprintf("https://%s/%s.git", host, parsed.FullPath())
+
+	spinner := ui.NewSpinner("Fetching with git (sparse, blobless)...")
+	spinner.Start()
+	root, err := gitFetchPath(tmpDir, remote, ref, parsed.FilePath, gitEnv(host, token))
+	if err != nil {
+		spinner.StopWithError("git fetch failed")
+		return err
+	}
+	spinner.StopWithSuccess("Fetched with git")
+
+	srcDir := filepath.Join(root, filepath.FromSlash(parsed.FilePath))
+	files, err := checkedOutFiles(srcDir, opts.Depth)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		ui.ShowWarning("No files found in directory")
+		return nil
+	}
+	if opts.ShowInfo {
+		showFileList(files, "")
+	}
+	if !confirmDownloadSize(files, opts.MaxSize, opts.Force) {
+		ui.ShowInfo("Cancelled")
+		return nil
+	}
+
+	copied, skipped, err := copyTree(srcDir, outputDir, files, opts.Overwrite, remote)
+	if err != nil {
+		return err
+	}
+	return treeResult{downloaded: copied, skipped: skipped}.finish(outputDir)
+}
+
+// gitFetchPath materializes path of ref from remote below dir and returns
+// the directory holding the repository's files. Servers without partial
+// clone support get the same fetch without the blob filter, and when
+// fetching fails, `git archive --remote` is tried.
+func gitFetchPath(dir, remote, ref, path string, env []string) (string, error) {
+	checkout := filepath.Join(dir, "checkout")
+	if err := os.Mkdir(checkout, 0700); err != nil {
+		return "", fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	git := func(args ...string) error {
+		if _, err := shell.RunInDirWithEnv(checkout, env, "git", args...); err != nil {
+			return fmt.Errorf("git %s: %w", args[0], err)
+		}
+		return nil
+	}
+
+	steps := [][]string{
+		{"init", "-q"},
+		{"remote", "add", "origin", remote},
+	}
+	if path != "" {
+		steps = append(steps, []string{"sparse-checkout", "set", "--no-cone", "/" + path})
+	}
+	for _, args := range steps {
+		if err := git(args...); err != nil {
+			return "", err
+		}
+	}
+
+	fetchErr := git("fetch", "-q", "--depth", "1", "--filter=blob:none", "origin", ref)
+	if fetchErr != nil {
+		fetchErr = git("fetch", "-q", "--depth", "1", "origin", ref)
+	}
+	if fetchErr == nil {
+		if err := git("checkout", "-q", "FETCH_HEAD"); err != nil {
+			return "", err
+		}
+		return checkout, nil
+	}
+
+	extracted, err := gitArchivePath(dir, remote, ref, path, env)
+	if err != nil {
+		return "", fmt.Errorf("%w; %v", fetchErr, err)
+	}
+	return extracted, nil
+}
+
+// gitArchivePath unpacks path of ref from `git archive --remote` below dir
+// and returns the directory holding the repository's files. Only servers
+// that enable upload-archive, usually over SSH, support it.
+func gitArchivePath(dir, remote, ref, path string, env []string) (string, error) {
+	archive := filepath.Join(dir, "archive.tar.gz")
+	args := []string{"archive", "--format=tar.gz", "--remote=" + remote, "--output=" + archive, ref}
+	if path != "" {
+		args = append(args, path)
+	}
+	if _, err := shell.RunInDirWithEnv(dir, env, "git", args...); err != nil {
+		return "", fmt.Errorf("git archive: %w", err)
+	}
+
+	extracted := filepath.Join(dir, "archive")
+	if _, err := ExtractArchive(archive, extracted, 0, true); err != nil {
+		return "", err
+	}
+	return extracted, nil
+}
+
+// checkedOutFiles lists the regular files below src, skipping .git and,
+// with maxDepth > 0, files nested deeper than maxDepth directories. Paths
+// are relative to src, with forward slashes.
+func checkedOutFiles(src string, maxDepth int) ([]TreeFile, error) {
+	var files []TreeFile
+	err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if maxDepth > 0 && strings.Count(rel, "/") > maxDepth {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files = append(files, TreeFile{Path: rel, Size: info.Size()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fetched files: %w", err)
+	}
+	return files, nil
+}
+
+// gitEnv returns environment variables for non-interactive git, passing the
+// token as an HTTP header through GIT_CONFIG_* so it never appears in argv
+// or in the temporary repository's config. The header is scoped to host.
+func gitEnv(host, token string) []string {
+	env := []string{"GIT_TERMINAL_PROMPT=0"}
+	if token != "" {
+		basic := base64.StdEncoding.EncodeToString([]byte("x-access-token:" + token))
+		env = append(env,
+			"GIT_CONFIG_COUNT=1",
+			"GIT_CONFIG_KEY_0=http.https://"+host+"/.extraHeader",
+			"GIT_CONFIG_VALUE_0=Authorization: Basic "+basic,
+		)
+	}
+	return env
+}
+
+// copyTree copies files, relative to src, into dst and records each file as
+// coming from remote. Returns the number of files copied and skipped.
+func copyTree(src, dst string, files []TreeFile, overwrite bool, remote string) (int, int, error) {
+	copied, skipped := 0, 0
+	for _, file := range files {
+		rel := filepath.FromSlash(file.Path)
+		target := filepath.Join(dst, rel)
+		start := time.Now()
+
+		if !overwrite {
+			if _, err := os.Stat(target); err == nil {
+				skipped++
+				recordFile(remote, target, start, &ErrFileExists{Path: target})
+				continue
+			}
+		}
+
+		err := copyFileAtomic(filepath.Join(src, rel), target)
+		recordFile(remote, target, start, err)
+		if err != nil {
+			return copied, skipped, fmt.Errorf("failed to copy files: %w", err)
+		}
+		copied++
+	}
+	return copied, skipped, nil
+}
+
+// copyFileAtomic copies src to dst with WriteAtomic
+func copyFileAtomic(src, dst string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return WriteAtomic(dst, f)
+}
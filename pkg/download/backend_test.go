@@ -0,0 +1,138 @@
+package download
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/dwirx/ghex/internal/shell"
+	"github.com/dwirx/ghex/internal/ui"
+)
+
+// newTestRepo creates a git repository holding files and makes
+// https://github.com/owner/repo fetch from it.
+func newTestRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	if !shell.CommandExists("git") {
+		t.Skip("git is not installed")
+	}
+
+	repo := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(repo, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "files"},
+	} {
+		if _, err := shell.RunInDir(repo, "git", args...); err != nil {
+			t.Fatalf("git %s: %v", args[0], err)
+		}
+	}
+
+	t.Setenv("GIT_CONFIG_COUNT", "1")
+	t.Setenv("GIT_CONFIG_KEY_0", "url.file://"+filepath.ToSlash(repo)+".insteadOf")
+	t.Setenv("GIT_CONFIG_VALUE_0", "https://github.com/owner/repo.git")
+	return repo
+}
+
+func TestGitSparseDownload(t *testing.T) {
+	newTestRepo(t, map[string]string{
+		"docs/a.md":            "a",
+		"docs/sub/b.md":        "bb",
+		"docs/sub/deep/c.md":   "ccc",
+		"other/skipped.md":     "not requested",
+		"docs/sub/deep/d/e.md": strings.Repeat("e", 2048),
+	})
+	ui.SetNonInteractive(true)
+	t.Cleanup(func() { ui.SetNonInteractive(false) })
+
+	tests := []struct {
+		name      string
+		opts      GitOptions
+		existing  string // file already in the output directory
+		wantFiles []string
+	}{
+		{name: "whole path", wantFiles: []string{"a.md", "sub/b.md", "sub/deep/c.md", "sub/deep/d/e.md"}},
+		{name: "depth", opts: GitOptions{Depth: 1}, wantFiles: []string{"a.md", "sub/b.md"}},
+		{name: "over max size", opts: GitOptions{MaxSize: 1024}},
+		{name: "force over max size", opts: GitOptions{MaxSize: 1024, Force: true, Depth: 2}, wantFiles: []string{"a.md", "sub/b.md", "sub/deep/c.md"}},
+		{name: "existing file kept", opts: GitOptions{Depth: 1}, existing: "a.md", wantFiles: []string{"a.md", "sub/b.md"}},
+		{name: "existing file overwritten", opts: GitOptions{Depth: 1, Overwrite: true}, existing: "a.md", wantFiles: []string{"a.md", "sub/b.md"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := parseGitURL("https://github.com/owner/repo/tree/main/docs")
+			if err != nil {
+				t.Fatal(err)
+			}
+			out := filepath.Join(t.TempDir(), "docs")
+			if tt.existing != "" {
+				if err := os.MkdirAll(out, 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(out, tt.existing), []byte("local"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := gitSparseDownload(parsed, out, tt.opts, ""); err != nil {
+				t.Fatalf("gitSparseDownload() error = %v", err)
+			}
+
+			var got []string
+			filepath.WalkDir(out, func(path string, d os.DirEntry, err error) error {
+				if err == nil && !d.IsDir() {
+					rel, _ := filepath.Rel(out, path)
+					got = append(got, filepath.ToSlash(rel))
+				}
+				return nil
+			})
+			sort.Strings(got)
+			if strings.Join(got, ",") != strings.Join(tt.wantFiles, ",") {
+				t.Errorf("Files = %v, want %v", got, tt.wantFiles)
+			}
+
+			if tt.existing != "" {
+				data, _ := os.ReadFile(filepath.Join(out, tt.existing))
+				if kept := string(data) == "local"; kept == tt.opts.Overwrite {
+					t.Errorf("%s = %q with overwrite %v", tt.existing, data, tt.opts.Overwrite)
+				}
+			}
+		})
+	}
+}
+
+func TestGitArchivePath(t *testing.T) {
+	repo := newTestRepo(t, map[string]string{
+		"docs/a.md":     "a",
+		"docs/sub/b.md": "bb",
+		"other/c.md":    "c",
+	})
+
+	dir := t.TempDir()
+	root, err := gitArchivePath(dir, "file://"+filepath.ToSlash(repo), "HEAD", "docs", nil)
+	if err != nil {
+		t.Fatalf("gitArchivePath() error = %v", err)
+	}
+	files, err := checkedOutFiles(filepath.Join(root, "docs"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 || files[0].Path != "a.md" || files[1].Path != "sub/b.md" || files[1].Size != 2 {
+		t.Errorf("Archived files = %+v, want a.md and sub/b.md", files)
+	}
+	if _, err := os.Stat(filepath.Join(root, "other")); !os.IsNotExist(err) {
+		t.Error("Expected only the requested path to be archived")
+	}
+}
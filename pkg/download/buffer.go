@@ -0,0 +1,28 @@
+package download
+
+import (
+	"io"
+	"sync"
+)
+
+// copyBufferSize is the size of pooled copy buffers.
+const copyBufferSize = 32 * 1024
+
+// bufferPool reuses copy buffers across the many small files of a
+// directory download instead of allocating one per file.
+var bufferPool = sync.Pool{
+	New: func() any {
+		b := make([]byte, copyBufferSize)
+		return &b
+	},
+}
+
+// copyBuffered copies src to dst using a pooled buffer.
+func copyBuffered(dst io.Writer, src io.Reader) (int64, error) {
+	bp := bufferPool.Get().(*[]byte)
+	defer bufferPool.Put(bp)
+
+	// Hide ReaderFrom/WriterTo so io.CopyBuffer uses the pooled buffer
+	// instead of letting *os.File allocate its own.
+	return io.CopyBuffer(struct{ io.Writer }{dst}, struct{ io.Reader }{src}, *bp)
+}
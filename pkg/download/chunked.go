@@ -0,0 +1,173 @@
+package download
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/dwirx/ghex/internal/ui"
+)
+
+// minChunkSize keeps smaller files from being split into many tiny requests.
+const minChunkSize = 1 << 20
+
+// chunkedURL downloads size bytes of rawURL over several connections, each
+// fetching one byte range into its place in a temp file that is renamed to
+// outPath once every range is complete.
+func chunkedURL(rawURL, outName, outPath string, size int64, opts Options, client *http.Client) error {
+	connections := int64(opts.Connections)
+	if limit := size / minChunkSize; connections > limit {
+		connections = limit
+	}
+	if connections < 1 {
+		connections = 1
+	}
+	chunk := (size + connections - 1) / connections
+
+	if opts.ShowInfo {
+		fmt.Printf("  URL:  %s\n", rawURL)
+		fmt.Printf("  Size: %s\n", formatSize(size))
+		fmt.Printf("  Dest: %s\n", outPath)
+	}
+
+	dir := filepath.Dir(outPath)
+	tmpFile, err := os.CreateTemp(dir, ".download-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+	success := false
+	defer func() {
+		if !success {
+			tmpFile.Close()
+			os.Remove(tmpPath)
+		}
+	}()
+	if err := tmpFile.Truncate(size); err != nil {
+		return fmt.Errorf("failed to allocate %s: %w", tmpPath, err)
+	}
+
+	var bar *ui.ProgressBar
+	if opts.ShowProgress {
+		fmt.Printf("  Downloading → %s (%d connections)\n", outPath, connections)
+		bar = ui.NewProgressBar(outName, size)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+	for start := int64(0); start < size; start += chunk {
+		end := start + chunk - 1
+		if end >= size {
+			end = size - 1
+		}
+		wg.Add(1)
+		go func(start, end int64) {
+			defer wg.Done()
+			if err := fetchRangeWithRetry(ctx, client, rawURL, opts, tmpFile, start, end, bar); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(start, end)
+	}
+	wg.Wait()
+	if bar != nil {
+		bar.Finish()
+	}
+	if firstErr != nil {
+		return firstErr
+	}
+
+	if err := tmpFile.Sync(); err != nil {
+		return fmt.Errorf("failed to sync temp file: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to close temp file: %w", err)
+	}
+	if err := os.Rename(tmpPath, outPath); err != nil {
+		return fmt.Errorf("failed to rename temp file: %w", err)
+	}
+	success = true
+
+	if opts.ShowProgress {
+		fmt.Printf("  ✓ Saved: %s\n", outPath)
+	}
+	return nil
+}
+
+// fetchRangeWithRetry downloads bytes start..end of rawURL into f at the
+// same offsets. A retry continues after the bytes already written.
+func fetchRangeWithRetry(ctx context.Context, client *http.Client, rawURL string, opts Options, f *os.File, start, end int64, bar *ui.ProgressBar) error {
+	retries := opts.effectiveRetries()
+	var err error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(1<<uint(attempt-1)) * time.Second):
+			}
+		}
+		var n int64
+		n, err = fetchRange(ctx, client, rawURL, opts, f, start, end, bar)
+		start += n
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+		if he, ok := err.(*ErrHTTP); ok && he.StatusCode < 500 && he.StatusCode != http.StatusTooManyRequests {
+			return err
+		}
+	}
+	return err
+}
+
+// fetchRange writes bytes start..end of rawURL into f and returns how many
+// bytes were written.
+func fetchRange(ctx context.Context, client *http.Client, rawURL string, opts Options, f *os.File, start, end int64, bar *ui.ProgressBar) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", rawURL, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "ghex-downloader/1.0")
+	if opts.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+opts.Token)
+	}
+	for k, v := range opts.Headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", start, end))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch URL: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusPartialContent {
+		// A 200 would send the whole file into this chunk's slot
+		return 0, &ErrHTTP{StatusCode: resp.StatusCode, Status: resp.Status, URL: rawURL}
+	}
+
+	var w io.Writer = io.NewOffsetWriter(f, start)
+	if bar != nil {
+		w = io.MultiWriter(w, bar)
+	}
+	n, err := copyBuffered(w, io.LimitReader(resp.Body, end-start+1))
+	if err != nil {
+		return n, fmt.Errorf("download interrupted: %w", err)
+	}
+	if n != end-start+1 {
+		return n, fmt.Errorf("download interrupted: got %d of %d bytes", n, end-start+1)
+	}
+	return n, nil
+}
@@ -0,0 +1,87 @@
+package download
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestChunkedDownload(t *testing.T) {
+	content := testContent(4*minChunkSize + 123)
+	// A server that answers Range requests with the whole file
+	ignoreRange := func(w http.ResponseWriter, r *http.Request) bool {
+		if r.Method == http.MethodHead {
+			w.Header().Set("Accept-Ranges", "bytes")
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			return true
+		}
+		w.Write(content)
+		return true
+	}
+
+	tests := []struct {
+		name        string
+		connections int
+		ranges      bool
+		handler     func(w http.ResponseWriter, r *http.Request) bool
+		wantRanges  int
+		wantErr     bool
+	}{
+		{name: "parallel ranges", connections: 4, ranges: true, wantRanges: 4},
+		{name: "capped by size", connections: 16, ranges: true, wantRanges: 4},
+		{name: "single connection", connections: 1, ranges: true, wantRanges: 0},
+		{name: "no range support", connections: 4, ranges: false, wantRanges: 0},
+		{name: "range ignored", connections: 4, handler: ignoreRange, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newFileServer(t, content, tt.handler, tt.ranges)
+			dir := t.TempDir()
+
+			err := FromURL(srv.URL+"/file.bin", Options{
+				OutputDir:       dir,
+				FollowRedirects: true,
+				Retries:         -1,
+				Connections:     tt.connections,
+			})
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected an error when the server ignores ranges")
+				}
+				// The first failing range cancels the others
+				if len(srv.rangeHeaders()) == 0 {
+					t.Error("Expected range requests")
+				}
+				entries, _ := os.ReadDir(dir)
+				if len(entries) != 0 {
+					t.Errorf("Expected the temp file to be removed, found %d entries", len(entries))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FromURL() error = %v", err)
+			}
+			if got := len(srv.rangeHeaders()); got != tt.wantRanges {
+				t.Errorf("Got %d range requests, want %d", got, tt.wantRanges)
+			}
+			got, err := os.ReadFile(filepath.Join(dir, "file.bin"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, content) {
+				t.Errorf("Downloaded %d bytes that differ from the %d bytes served", len(got), len(content))
+			}
+			for _, rng := range srv.rangeHeaders() {
+				if !strings.HasPrefix(rng, "bytes=") || !strings.Contains(rng, "-") {
+					t.Errorf("Unexpected Range header %q", rng)
+				}
+			}
+		})
+	}
+}
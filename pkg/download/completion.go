@@ -0,0 +1,161 @@
+package download
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dwirx/ghex/internal/httpclient"
+	"github.com/dwirx/ghex/internal/platform"
+)
+
+// completionCacheTTL is how long API results used for shell completion are reused.
+const completionCacheTTL = 10 * time.Minute
+
+// completionTimeout keeps completion responsive when the API is slow.
+const completionTimeout = 3 * time.Second
+
+type completionCacheEntry struct {
+	Fetched time.Time `json:"fetched"`
+	Items   []string  `json:"items"`
+}
+
+// CompleteRepoRef returns shell completion candidates for an owner/repo[:ref]
+// argument. "owner/" completes repository names and "owner/repo:" completes
+// branch and tag names. Results are cached on disk for a few minutes.
+func CompleteRepoRef(toComplete, token string) []string {
+	if token == "" {
+		token = os.Getenv("GITHUB_TOKEN")
+	}
+	if token == "" {
+		owner, _, _ := strings.Cut(toComplete, "/")
+		token = registeredToken("github.com", owner)
+	}
+
+	if repoPart, refPrefix, ok := strings.Cut(toComplete, ":"); ok {
+		owner, repo, ok := strings.Cut(repoPart, "/")
+		if !ok || owner == "" || repo == "" {
+			return nil
+		}
+		var out []string
+		for _, ref := range cachedList("refs:"+repoPart, func() ([]string, error) {
+			return listRefNames(owner, repo, token)
+		}) {
+			if strings.HasPrefix(ref, refPrefix) {
+				out = append(out, repoPart+":"+ref)
+			}
+		}
+		return out
+	}
+
+	owner, repoPrefix, ok := strings.Cut(toComplete, "/")
+	if !ok || owner == "" {
+		return nil
+	}
+	var out []string
+	for _, repo := range cachedList("repos:"+owner, func() ([]string, error) {
+		return listRepoNames(owner, token)
+	}) {
+		if strings.HasPrefix(repo, repoPrefix) {
+			out = append(out, owner+"/"+repo)
+		}
+	}
+	return out
+}
+
+// cachedList returns cached items for key, refreshing them with fetch when stale.
+func cachedList(key string, fetch func() ([]string, error)) []string {
+	cachePath := filepath.Join(platform.GetCacheDir("ghex"), "completion.json")
+
+	cache := map[string]completionCacheEntry{}
+	if data, err := os.ReadFile(cachePath); err == nil {
+		_ = json.Unmarshal(data, &cache)
+	}
+
+	if entry, ok := cache[key]; ok && time.Since(entry.Fetched) < completionCacheTTL {
+		return entry.Items
+	}
+
+	items, err := fetch()
+	if err != nil {
+		// Fall back to stale data rather than nothing
+		return cache[key].Items
+	}
+
+	cache[key] = completionCacheEntry{Fetched: time.Now(), Items: items}
+	// Names of private repositories and refs, keep them private
+	if data, err := json.Marshal(cache); err == nil {
+		if err := os.MkdirAll(filepath.Dir(cachePath), 0700); err == nil {
+			if err := os.WriteFile(cachePath, data, 0600); err == nil {
+				// WriteFile keeps the mode of a file written by older versions
+				_ = os.Chmod(cachePath, 0600)
+			}
+		}
+	}
+	return items
+}
+
+// listRepoNames lists repository names for a user or organization.
+func listRepoNames(owner, token string) ([]string, error) {
+	var repos []struct {
+		Name string `json:"name"`
+	}
+	apiURL := fmt.Sprintf("https://api.github.com/users/%s/repos?per_page=100&sort=updated", owner)
+	if err := getCompletionJSON(apiURL, token, &repos); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(repos))
+	for _, r := range repos {
+		names = append(names, r.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// listRefNames lists branch and tag names for a repository.
+func listRefNames(owner, repo, token string) ([]string, error) {
+	var names []string
+	for _, kind := range []string{"branches", "tags"} {
+		var refs []struct {
+			Name string `json:"name"`
+		}
+		apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/%s?per_page=100", owner, repo, kind)
+		if err := getCompletionJSON(apiURL, token, &refs); err != nil {
+			return nil, err
+		}
+		for _, r := range refs {
+			names = append(names, r.Name)
+		}
+	}
+	return names, nil
+}
+
+// getCompletionJSON performs a short-timeout API GET and decodes the JSON response.
+func getCompletionJSON(apiURL, token string, v interface{}) error {
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("User-Agent", "ghex-downloader/1.0")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := httpclient.New(completionTimeout).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return &ErrHTTP{StatusCode: resp.StatusCode, Status: resp.Status, URL: apiURL}
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
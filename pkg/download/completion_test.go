@@ -0,0 +1,49 @@
+package download
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/dwirx/ghex/internal/platform"
+)
+
+func TestCachedList(t *testing.T) {
+	useTempJobsDir(t)
+	cachePath := filepath.Join(platform.GetCacheDir("ghex"), "completion.json")
+
+	calls := 0
+	fetch := func() ([]string, error) {
+		calls++
+		return []string{"private-repo"}, nil
+	}
+	for i := 0; i < 2; i++ {
+		if got := cachedList("repos:owner", fetch); !reflect.DeepEqual(got, []string{"private-repo"}) {
+			t.Fatalf("cachedList() = %v", got)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Fetched %d times, want the second call served from the cache", calls)
+	}
+
+	failing := func() ([]string, error) { return nil, errors.New("offline") }
+	if got := cachedList("repos:other", failing); got != nil {
+		t.Errorf("cachedList() without data = %v, want nil", got)
+	}
+
+	if runtime.GOOS == "windows" {
+		return
+	}
+	for path, want := range map[string]os.FileMode{cachePath: 0600, filepath.Dir(cachePath): 0700} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if perm := info.Mode().Perm(); perm != want {
+			t.Errorf("%s mode = %o, want %o", path, perm, want)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package download
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// giteaContentsURL returns the Contents API URL for path at the parsed ref.
+func giteaContentsURL(parsed *ParsedGitURL, path string) string {
+	apiURL := giteaRepoAPI(parsed) + "/contents"
+	if path != "" {
+		apiURL += "/" + escapePath(path)
+	}
+	return apiURL + "?ref=" + url.QueryEscape(parsed.Branch)
+}
+
+// giteaRawURL returns the API URL serving a file's content. Unlike the web
+// raw route it accepts the token for private repositories.
+func giteaRawURL(parsed *ParsedGitURL, path string) string {
+	return giteaRepoAPI(parsed) + "/raw/" + escapePath(path) + "?ref=" + url.QueryEscape(parsed.Branch)
+}
+
+// giteaIsDirectory asks the Contents API whether parsed.FilePath is a
+// directory, since Gitea's /src/ URLs look the same for files and folders.
+// The API answers with a list for directories and an object for files.
+func giteaIsDirectory(parsed *ParsedGitURL, token string) (bool, error) {
+	var entry json.RawMessage
+	if err := getAPIJSON(giteaContentsURL(parsed, parsed.FilePath), token, &entry); err != nil {
+		return false, err
+	}
+	return strings.HasPrefix(strings.TrimSpace(string(entry)), "["), nil
+}
@@ -8,8 +8,10 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-	"sync"
 	"time"
+
+	"github.com/dwirx/ghex/internal/httpclient"
+	"github.com/dwirx/ghex/internal/ui"
 )
 
 // Options configures a generic HTTP download.
@@ -21,9 +23,19 @@ type Options struct {
 	ShowInfo        bool              // Show file info before download
 	FollowRedirects bool              // Follow HTTP redirects
 	Token           string            // Bearer token for authentication
-	Retries         int               // Max retry attempts (0 = use default 3)
+	Retries         int               // Max retry attempts (0 = default, see SetRetries; negative = none)
 	Timeout         time.Duration     // HTTP timeout (0 = use default 5 minutes)
 	Headers         map[string]string // Additional HTTP headers
+	Resume          bool              // Keep partial data in a ".part" file so the download can be resumed
+	Connections     int               // Parallel range requests for large files (0 or 1 = single stream)
+
+	Verification // Expected checksum; a mismatching file is deleted
+	Network      // Proxy and TLS settings for this download only (see SetNetwork)
+
+	Extract         bool // Unpack a downloaded archive into the output directory
+	StripComponents int  // Leading path components dropped when extracting
+
+	progress *ui.ProgressBar // Shared bar of a multi-file download, advanced by the bytes written
 }
 
 // DefaultOptions returns sensible default download options.
@@ -46,20 +58,29 @@ func (o Options) effectiveTimeout() time.Duration {
 
 // effectiveRetries returns the retry count to use, applying the default if not set.
 func (o Options) effectiveRetries() int {
-	if o.Retries <= 0 {
-		return 3
-	}
-	return o.Retries
+	return retryCount(o.Retries)
 }
 
 // FromURL downloads a file from a generic HTTP/HTTPS URL.
 func FromURL(rawURL string, opts Options) error {
+	start := time.Now()
+	outPath, err := fromURL(rawURL, opts)
+	recordFile(rawURL, outPath, start, err)
+	return err
+}
+
+// fromURL implements FromURL, returning the output path once it is known
+func fromURL(rawURL string, opts Options) (string, error) {
 	if !strings.HasPrefix(rawURL, "http://") && !strings.HasPrefix(rawURL, "https://") {
-		return fmt.Errorf("invalid URL (must start with http:// or https://): %s", rawURL)
+		return "", fmt.Errorf("invalid URL (must start with http:// or https://): %s", rawURL)
 	}
 
-	client := &http.Client{
-		Timeout: opts.effectiveTimeout(),
+	client := httpclient.New(opts.effectiveTimeout())
+	if opts.Network.IsSet() {
+		var err error
+		if client, err = httpclient.NewWithNetwork(opts.effectiveTimeout(), opts.Network); err != nil {
+			return "", err
+		}
 	}
 	if !opts.FollowRedirects {
 		client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
@@ -67,67 +88,6 @@ func FromURL(rawURL string, opts Options) error {
 		}
 	}
 
-	// Build request with auth headers
-	req, err := http.NewRequest("GET", rawURL, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-	if opts.Token != "" {
-		req.Header.Set("Authorization", "Bearer "+opts.Token)
-	}
-	for k, v := range opts.Headers {
-		req.Header.Set(k, v)
-	}
-
-	// Retry loop with exponential backoff
-	maxRetries := opts.effectiveRetries()
-	var resp *http.Response
-	for attempt := 0; attempt <= maxRetries; attempt++ {
-		if attempt > 0 {
-			backoff := time.Duration(1<<uint(attempt-1)) * time.Second
-			time.Sleep(backoff)
-			// Re-create request for retry (body already consumed)
-			req, err = http.NewRequest("GET", rawURL, nil)
-			if err != nil {
-				return fmt.Errorf("failed to create request: %w", err)
-			}
-			if opts.Token != "" {
-				req.Header.Set("Authorization", "Bearer "+opts.Token)
-			}
-			for k, v := range opts.Headers {
-				req.Header.Set(k, v)
-			}
-		}
-
-		resp, err = client.Do(req)
-		if err != nil {
-			if attempt < maxRetries {
-				continue
-			}
-			return fmt.Errorf("failed to fetch URL: %w", err)
-		}
-
-		// Don't retry on client errors (4xx) except 429
-		if resp.StatusCode == http.StatusTooManyRequests ||
-			resp.StatusCode >= 500 {
-			resp.Body.Close()
-			if attempt < maxRetries {
-				continue
-			}
-			return &ErrHTTP{StatusCode: resp.StatusCode, Status: resp.Status, URL: rawURL}
-		}
-
-		break
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode == http.StatusNotFound {
-		return &ErrNotFound{URL: rawURL}
-	}
-	if resp.StatusCode != http.StatusOK {
-		return &ErrHTTP{StatusCode: resp.StatusCode, Status: resp.Status, URL: rawURL}
-	}
-
 	// Determine output filename
 	outName := opts.Output
 	if outName == "" {
@@ -141,7 +101,7 @@ func FromURL(rawURL string, opts Options) error {
 	outPath := outName
 	if opts.OutputDir != "" {
 		if err := os.MkdirAll(opts.OutputDir, 0755); err != nil {
-			return fmt.Errorf("failed to create output directory: %w", err)
+			return "", fmt.Errorf("failed to create output directory: %w", err)
 		}
 		outPath = filepath.Join(opts.OutputDir, outName)
 	}
@@ -149,10 +109,78 @@ func FromURL(rawURL string, opts Options) error {
 	// Check overwrite
 	if !opts.Overwrite {
 		if _, err := os.Stat(outPath); err == nil {
-			return &ErrFileExists{Path: outPath}
+			return outPath, &ErrFileExists{Path: outPath}
 		}
 	}
 
+	// Resolve the checksum first, so a bad one fails before the download
+	want, err := opts.expectedDigest(client, opts.Token, rawURL, filenameFromURL(rawURL), outName)
+	if err != nil {
+		return outPath, err
+	}
+
+	if err := fetchURL(rawURL, outName, outPath, opts, client); err != nil {
+		return outPath, err
+	}
+	if want != nil {
+		if err := verifyDownload(outPath, want, opts.ShowProgress); err != nil {
+			return outPath, err
+		}
+	}
+	if opts.Extract {
+		return outPath, extractDownload(outPath, opts.StripComponents, opts.Overwrite, opts.ShowProgress)
+	}
+	return outPath, nil
+}
+
+// fetchURL downloads rawURL to outPath, resuming a partial download or
+// using parallel connections when asked to
+func fetchURL(rawURL, outName, outPath string, opts Options, client *http.Client) error {
+	// A partial file left by an interrupted --resume run is picked up
+	// automatically
+	if _, err := os.Stat(outPath + partSuffix); opts.Resume || err == nil {
+		return resumeURL(rawURL, outName, outPath, opts, client)
+	}
+
+	if opts.Connections > 1 {
+		size, acceptRanges := probeRanges(rawURL, opts, client)
+		if size > 0 && strings.EqualFold(acceptRanges, "bytes") {
+			return chunkedURL(rawURL, outName, outPath, size, opts, client)
+		}
+		if opts.ShowProgress {
+			fmt.Println("  Server does not support range requests, using a single connection")
+		}
+	}
+
+	// Build request with auth headers
+	req, err := http.NewRequest("GET", rawURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if opts.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+opts.Token)
+	}
+	for k, v := range opts.Headers {
+		req.Header.Set(k, v)
+	}
+
+	// Retry rate limits, server errors and network failures with backoff
+	resp, err := doWithRetries(client, req, opts.effectiveRetries())
+	if err != nil {
+		if isRateLimited(err) {
+			return err
+		}
+		return fmt.Errorf("failed to fetch URL: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return &ErrNotFound{URL: rawURL}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return &ErrHTTP{StatusCode: resp.StatusCode, Status: resp.Status, URL: rawURL}
+	}
+
 	if opts.ShowInfo {
 		fmt.Printf("  URL:  %s\n", rawURL)
 		fmt.Printf("  Size: %s\n", formatSize(resp.ContentLength))
@@ -163,8 +191,21 @@ func FromURL(rawURL string, opts Options) error {
 		fmt.Printf("  Downloading → %s\n", outPath)
 	}
 
+	var body io.Reader = resp.Body
+	bar := opts.progress
+	if bar == nil && opts.ShowProgress {
+		bar = ui.NewProgressBar(outName, resp.ContentLength)
+	}
+	if bar != nil {
+		body = io.TeeReader(resp.Body, bar)
+	}
+
 	// Write atomically: write to temp file then rename
-	if err := WriteAtomic(outPath, resp.Body); err != nil {
+	err = WriteAtomic(outPath, body)
+	if bar != nil && opts.progress == nil {
+		bar.Finish()
+	}
+	if err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
 	}
 
@@ -202,7 +243,7 @@ func WriteAtomic(path string, r io.Reader) error {
 		}
 	}()
 
-	if _, err := io.Copy(tmpFile, r); err != nil {
+	if _, err := copyBuffered(tmpFile, r); err != nil {
 		return fmt.Errorf("failed to write data: %w", err)
 	}
 
@@ -219,50 +260,13 @@ func WriteAtomic(path string, r io.Reader) error {
 }
 
 // Multiple downloads multiple files from a list of URLs with bounded concurrency.
-// At most 5 downloads run in parallel.
+// At most DefaultQueueParallel downloads run in parallel.
 func Multiple(urls []string, opts Options) error {
-	const maxParallel = 5
-
-	type result struct {
-		url string
-		err error
-	}
-
-	results := make([]result, len(urls))
-	sem := make(chan struct{}, maxParallel)
-	var wg sync.WaitGroup
-
+	items := make([]QueueItem, len(urls))
 	for i, u := range urls {
-		wg.Add(1)
-		go func(idx int, url string) {
-			defer wg.Done()
-			sem <- struct{}{}
-			defer func() { <-sem }()
-
-			fmt.Printf("[%d/%d] %s\n", idx+1, len(urls), url)
-			err := FromURL(url, opts)
-			results[idx] = result{url: url, err: err}
-		}(i, u)
-	}
-
-	wg.Wait()
-
-	var errs []string
-	succeeded := 0
-	for _, r := range results {
-		if r.err != nil {
-			errs = append(errs, fmt.Sprintf("%s: %v", r.url, r.err))
-		} else {
-			succeeded++
-		}
+		items[i] = QueueItem{URL: u}
 	}
-
-	fmt.Printf("\nSummary: %d succeeded, %d failed\n", succeeded, len(errs))
-
-	if len(errs) > 0 {
-		return fmt.Errorf("some downloads failed:\n%s", strings.Join(errs, "\n"))
-	}
-	return nil
+	return runQueue(items, DefaultQueueParallel, opts, nil)
 }
 
 // filenameFromURL extracts the filename from a URL path.
@@ -13,18 +13,26 @@ func (e *ErrNotFound) Error() string {
 	return fmt.Sprintf("not found: %s", e.URL)
 }
 
-// ErrRateLimit is returned when GitHub API rate limit is exceeded.
+// ErrRateLimit is returned when an API rate limit is exceeded.
 type ErrRateLimit struct {
 	ResetAt string
+	Host    string // API host (empty = GitHub)
 }
 
 // Error implements the error interface.
 func (e *ErrRateLimit) Error() string {
+	if e.Host != "" && e.Host != "api.github.com" {
+		msg := fmt.Sprintf("Rate limit of %s exceeded", e.Host)
+		if e.ResetAt != "" {
+			msg += fmt.Sprintf(" (resets at %s)", e.ResetAt)
+		}
+		return msg + ". Pass --token or add a token to a ghex account to increase limits."
+	}
 	msg := "GitHub API rate limit exceeded"
 	if e.ResetAt != "" {
 		msg += fmt.Sprintf(" (resets at %s)", e.ResetAt)
 	}
-	return msg + ". Set GITHUB_TOKEN environment variable to increase limits."
+	return msg + ". Set GITHUB_TOKEN or add a token to a ghex account to increase limits."
 }
 
 // ErrHTTP is returned for unexpected HTTP status codes.
@@ -48,3 +56,32 @@ type ErrFileExists struct {
 func (e *ErrFileExists) Error() string {
 	return fmt.Sprintf("file already exists: %s (use --overwrite to replace)", e.Path)
 }
+
+// ErrIsDirectory is returned when a single file was asked for but the URL
+// points to a directory.
+type ErrIsDirectory struct {
+	URL string
+}
+
+// Error implements the error interface.
+func (e *ErrIsDirectory) Error() string {
+	return fmt.Sprintf("%s is a directory; download it with 'ghex dlx dir'", e.URL)
+}
+
+// ErrIncomplete is returned when some files of a multi-file download failed.
+// Each failure has already been reported.
+type ErrIncomplete struct {
+	Failed int
+	Total  int
+}
+
+// Error implements the error interface.
+func (e *ErrIncomplete) Error() string {
+	return fmt.Sprintf("%d of %d files failed to download", e.Failed, e.Total)
+}
+
+// IsNotFound reports whether err means the requested resource does not exist.
+func IsNotFound(err error) bool {
+	var nf *ErrNotFound
+	return isErrNotFound(err, &nf)
+}
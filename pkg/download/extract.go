@@ -0,0 +1,368 @@
+package download
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ulikunitz/xz"
+)
+
+// archiveSuffixes are the archive formats isArchive recognizes
+var archiveSuffixes = []string{".zip", ".tar.gz", ".tgz", ".tar.xz", ".txz", ".tar.bz2", ".tbz2", ".tbz"}
+
+// isArchive reports whether name is an archive format extractFile and
+// ExtractArchive understand.
+func isArchive(name string) bool {
+	name = strings.ToLower(name)
+	for _, suffix := range archiveSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// openTar returns a tar reader for a compressed tarball, picking the
+// decompressor from the file name.
+func openTar(archivePath string, f io.Reader) (*tar.Reader, error) {
+	name := strings.ToLower(archivePath)
+	switch {
+	case strings.HasSuffix(name, ".tar.xz") || strings.HasSuffix(name, ".txz"):
+		xzr, err := xz.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open archive: %w", err)
+		}
+		return tar.NewReader(xzr), nil
+	case strings.HasSuffix(name, ".tar.bz2") || strings.HasSuffix(name, ".tbz2") || strings.HasSuffix(name, ".tbz"):
+		return tar.NewReader(bzip2.NewReader(f)), nil
+	default:
+		gzr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open archive: %w", err)
+		}
+		return tar.NewReader(gzr), nil
+	}
+}
+
+// extractFile extracts the first file whose base name is name from a
+// .zip or tar archive into destDir and returns its path. On Windows
+// a ".exe" suffix is also accepted.
+func extractFile(archivePath, name, destDir string) (string, error) {
+	destPath := filepath.Join(destDir, "extracted-"+name)
+	matches := func(entry string) bool {
+		base := filepath.Base(entry)
+		return base == name || base == name+".exe"
+	}
+
+	if strings.HasSuffix(strings.ToLower(archivePath), ".zip") {
+		r, err := zip.OpenReader(archivePath)
+		if err != nil {
+			return "", fmt.Errorf("failed to open archive: %w", err)
+		}
+		defer r.Close()
+
+		for _, f := range r.File {
+			if f.FileInfo().IsDir() || !matches(f.Name) {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return "", fmt.Errorf("failed to read %s: %w", f.Name, err)
+			}
+			defer rc.Close()
+			return destPath, writeExtracted(destPath, rc)
+		}
+		return "", fmt.Errorf("%s not found in %s", name, filepath.Base(archivePath))
+	}
+
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer f.Close()
+
+	tr, err := openTar(archivePath, f)
+	if err != nil {
+		return "", err
+	}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to read archive: %w", err)
+		}
+		if header.Typeflag == tar.TypeReg && matches(header.Name) {
+			return destPath, writeExtracted(destPath, tr)
+		}
+	}
+	return "", fmt.Errorf("%s not found in %s", name, filepath.Base(archivePath))
+}
+
+// writeExtracted writes an archive entry to path.
+func writeExtracted(path string, r io.Reader) error {
+	out, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if _, err := copyBuffered(out, r); err != nil {
+		out.Close()
+		return fmt.Errorf("failed to extract %s: %w", filepath.Base(path), err)
+	}
+	return out.Close()
+}
+
+// ExtractArchive unpacks a .zip, .tar.gz, .tar.xz or .tar.bz2 archive into
+// destDir and returns the number of files written. strip drops that many
+// leading path components from every entry, like tar --strip-components.
+// Entries that would land outside destDir — absolute paths, ".." or writes
+// through a symlink — and links pointing outside it, also by way of links
+// extracted earlier, are rejected.
+func ExtractArchive(archivePath, destDir string, strip int, overwrite bool) (int, error) {
+	if !isArchive(archivePath) {
+		return 0, fmt.Errorf("%s is not a supported archive (%s)", filepath.Base(archivePath), strings.Join(archiveSuffixes, ", "))
+	}
+	if destDir == "" {
+		destDir = "."
+	}
+	root, err := filepath.Abs(destDir)
+	if err != nil {
+		return 0, err
+	}
+	x := &extractor{root: root, strip: strip, overwrite: overwrite}
+
+	if strings.HasSuffix(strings.ToLower(archivePath), ".zip") {
+		r, err := zip.OpenReader(archivePath)
+		if err != nil {
+			return 0, fmt.Errorf("failed to open archive: %w", err)
+		}
+		defer r.Close()
+
+		for _, f := range r.File {
+			if err := x.zipEntry(f); err != nil {
+				return x.files, err
+			}
+		}
+		return x.files, nil
+	}
+
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer f.Close()
+
+	tr, err := openTar(archivePath, f)
+	if err != nil {
+		return 0, err
+	}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return x.files, nil
+		}
+		if err != nil {
+			return x.files, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if err := x.tarEntry(header, tr); err != nil {
+			return x.files, err
+		}
+	}
+}
+
+// extractDownload unpacks a downloaded archive next to it
+func extractDownload(archivePath string, strip int, overwrite, showProgress bool) error {
+	destDir := filepath.Dir(archivePath)
+	n, err := ExtractArchive(archivePath, destDir, strip, overwrite)
+	if err != nil {
+		return fmt.Errorf("failed to extract %s: %w", filepath.Base(archivePath), err)
+	}
+	if showProgress {
+		fmt.Printf("  ✓ Extracted %d files → %s\n", n, destDir)
+	}
+	return nil
+}
+
+// extractor writes archive entries below root
+type extractor struct {
+	root      string
+	strip     int
+	overwrite bool
+	files     int
+}
+
+// target returns where an entry goes, "" when stripping leaves nothing
+func (x *extractor) target(name string) (string, error) {
+	name = strings.ReplaceAll(name, "\\", "/")
+	parts := strings.Split(strings.Trim(name, "/"), "/")
+	if len(parts) <= x.strip {
+		return "", nil
+	}
+	rel := filepath.Clean(filepath.FromSlash(strings.Join(parts[x.strip:], "/")))
+	if rel == "." {
+		return "", nil
+	}
+	if strings.HasPrefix(name, "/") || filepath.IsAbs(rel) || filepath.VolumeName(rel) != "" ||
+		rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("refusing to extract %s: path leaves the output directory", name)
+	}
+	path := filepath.Join(x.root, rel)
+
+	// A symlink extracted earlier must not redirect later entries
+	for dir := filepath.Dir(path); dir != x.root && len(dir) > len(x.root); dir = filepath.Dir(dir) {
+		if info, err := os.Lstat(dir); err == nil && info.Mode()&os.ModeSymlink != 0 {
+			return "", fmt.Errorf("refusing to extract %s: path goes through a symlink", name)
+		}
+	}
+	return path, nil
+}
+
+// insideRoot reports whether a link at path pointing to linkTarget stays
+// below root. The target is resolved through the symlinks already on disk,
+// so a link to "s/../x" where s is a link to "." is caught. path's
+// directory must exist.
+func (x *extractor) insideRoot(path, linkTarget string) bool {
+	if filepath.IsAbs(linkTarget) || filepath.VolumeName(linkTarget) != "" {
+		return false
+	}
+	root, err := filepath.EvalSymlinks(x.root)
+	if err != nil {
+		return false
+	}
+	cur, err := filepath.EvalSymlinks(filepath.Dir(path))
+	if err != nil {
+		return false
+	}
+	for _, part := range strings.Split(linkTarget, string(filepath.Separator)) {
+		switch part {
+		case "", ".":
+		case "..":
+			cur = filepath.Dir(cur)
+		default:
+			cur = filepath.Join(cur, part)
+			if info, err := os.Lstat(cur); err == nil && info.Mode()&os.ModeSymlink != 0 {
+				if cur, err = filepath.EvalSymlinks(cur); err != nil {
+					return false
+				}
+			}
+		}
+	}
+	return cur == root || strings.HasPrefix(cur, root+string(filepath.Separator))
+}
+
+func (x *extractor) tarEntry(header *tar.Header, r io.Reader) error {
+	path, err := x.target(header.Name)
+	if err != nil || path == "" {
+		return err
+	}
+
+	switch header.Typeflag {
+	case tar.TypeDir:
+		return os.MkdirAll(path, 0755)
+	case tar.TypeReg:
+		return x.writeFile(path, r, header.FileInfo().Mode())
+	case tar.TypeSymlink:
+		return x.symlink(path, header.Linkname, header.Name)
+	case tar.TypeLink:
+		source, err := x.target(header.Linkname)
+		if err != nil || source == "" {
+			return fmt.Errorf("refusing to extract %s: link leaves the output directory", header.Name)
+		}
+		// Only regular files extracted earlier can be linked, never a
+		// symlink that could point anywhere
+		if info, err := os.Lstat(source); err != nil || !info.Mode().IsRegular() {
+			return fmt.Errorf("refusing to extract %s: link source is not a regular file", header.Name)
+		}
+		in, err := os.Open(source)
+		if err != nil {
+			return fmt.Errorf("failed to extract %s: %w", header.Name, err)
+		}
+		defer in.Close()
+		return x.writeFile(path, in, header.FileInfo().Mode())
+	default:
+		// Devices, FIFOs and the like are never needed from a download
+		return nil
+	}
+}
+
+func (x *extractor) zipEntry(f *zip.File) error {
+	path, err := x.target(f.Name)
+	if err != nil || path == "" {
+		return err
+	}
+	mode := f.Mode()
+	if mode.IsDir() {
+		return os.MkdirAll(path, 0755)
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", f.Name, err)
+	}
+	defer rc.Close()
+
+	if mode&os.ModeSymlink != 0 {
+		linkTarget, err := io.ReadAll(io.LimitReader(rc, 4096))
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", f.Name, err)
+		}
+		return x.symlink(path, string(linkTarget), f.Name)
+	}
+	return x.writeFile(path, rc, mode)
+}
+
+// writeFile writes an extracted file, keeping its permission bits
+func (x *extractor) writeFile(path string, r io.Reader, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if info, err := os.Lstat(path); err == nil {
+		if !x.overwrite {
+			return &ErrFileExists{Path: path}
+		}
+		if info.Mode()&os.ModeSymlink != 0 || info.IsDir() {
+			return fmt.Errorf("refusing to overwrite %s", path)
+		}
+	}
+	perm := mode.Perm()
+	if perm == 0 {
+		perm = 0644
+	}
+	out, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if _, err := copyBuffered(out, r); err != nil {
+		out.Close()
+		return fmt.Errorf("failed to extract %s: %w", filepath.Base(path), err)
+	}
+	x.files++
+	return out.Close()
+}
+
+// symlink creates a link that stays inside the output directory
+func (x *extractor) symlink(path, linkTarget, name string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if !x.insideRoot(path, filepath.FromSlash(linkTarget)) {
+		return fmt.Errorf("refusing to extract %s: link points outside the output directory", name)
+	}
+	if _, err := os.Lstat(path); err == nil {
+		if !x.overwrite {
+			return &ErrFileExists{Path: path}
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+	return os.Symlink(linkTarget, path)
+}
@@ -0,0 +1,310 @@
+package download
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// archiveEntry is an entry of a test archive.
+type archiveEntry struct {
+	name string
+	body string
+	link string // Symlink target, or hard link source for tar.TypeLink
+	typ  byte   // tar type flag (default tar.TypeReg)
+}
+
+func writeTarGz(t *testing.T, path string, entries []archiveEntry) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		typ := e.typ
+		if typ == 0 {
+			typ = tar.TypeReg
+		}
+		header := &tar.Header{Name: e.name, Typeflag: typ, Linkname: e.link, Mode: 0644, Size: int64(len(e.body))}
+		if typ == tar.TypeDir {
+			header.Mode = 0755
+		}
+		if typ != tar.TypeReg {
+			header.Size = 0
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if typ == tar.TypeReg {
+			if _, err := tw.Write([]byte(e.body)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeZip(t *testing.T, path string, entries []archiveEntry) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zw := zip.NewWriter(f)
+	for _, e := range entries {
+		header := &zip.FileHeader{Name: e.name, Method: zip.Deflate}
+		body := e.body
+		if e.typ == tar.TypeSymlink {
+			header.SetMode(os.ModeSymlink | 0777)
+			body = e.link
+		} else {
+			header.SetMode(0644)
+		}
+		w, err := zw.CreateHeader(header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExtractArchive(t *testing.T) {
+	tests := []struct {
+		name      string
+		format    string // ".tar.gz" or ".zip"
+		entries   []archiveEntry
+		strip     int
+		wantFiles []string // Files expected below the output directory
+		wantErr   string
+	}{
+		{
+			name:   "tar files and links",
+			format: ".tar.gz",
+			entries: []archiveEntry{
+				{name: "bin/", typ: tar.TypeDir},
+				{name: "bin/tool", body: "tool"},
+				{name: "bin/alias", link: "tool", typ: tar.TypeSymlink},
+				{name: "bin/copy", link: "bin/tool", typ: tar.TypeLink},
+			},
+			wantFiles: []string{"bin/tool", "bin/alias", "bin/copy"},
+		},
+		{
+			name:      "tar strip components",
+			format:    ".tar.gz",
+			entries:   []archiveEntry{{name: "tool-1.0/", typ: tar.TypeDir}, {name: "tool-1.0/bin/tool", body: "tool"}},
+			strip:     1,
+			wantFiles: []string{"bin/tool"},
+		},
+		{
+			name:    "tar parent path",
+			format:  ".tar.gz",
+			entries: []archiveEntry{{name: "../x", body: "evil"}},
+			wantErr: "leaves the output directory",
+		},
+		{
+			name:    "tar nested parent path",
+			format:  ".tar.gz",
+			entries: []archiveEntry{{name: "a/../../x", body: "evil"}},
+			wantErr: "leaves the output directory",
+		},
+		{
+			name:    "tar absolute path",
+			format:  ".tar.gz",
+			entries: []archiveEntry{{name: "/tmp/x", body: "evil"}},
+			wantErr: "leaves the output directory",
+		},
+		{
+			name:    "tar absolute symlink",
+			format:  ".tar.gz",
+			entries: []archiveEntry{{name: "passwd", link: "/etc/passwd", typ: tar.TypeSymlink}},
+			wantErr: "link points outside",
+		},
+		{
+			name:    "tar relative symlink outside",
+			format:  ".tar.gz",
+			entries: []archiveEntry{{name: "up", link: "../..", typ: tar.TypeSymlink}},
+			wantErr: "link points outside",
+		},
+		{
+			name:   "tar write through symlink",
+			format: ".tar.gz",
+			entries: []archiveEntry{
+				{name: "sub/", typ: tar.TypeDir},
+				{name: "here", link: "sub", typ: tar.TypeSymlink},
+				{name: "here/x", body: "evil"},
+			},
+			wantErr: "through a symlink",
+		},
+		{
+			name:   "tar symlink chain",
+			format: ".tar.gz",
+			entries: []archiveEntry{
+				{name: "lib/libfoo.so.1.2", body: "lib"},
+				{name: "lib/libfoo.so.1", link: "libfoo.so.1.2", typ: tar.TypeSymlink},
+				{name: "lib/libfoo.so", link: "libfoo.so.1", typ: tar.TypeSymlink},
+				{name: "lib/current", link: ".", typ: tar.TypeSymlink},
+				{name: "lib/again", link: "current/libfoo.so", typ: tar.TypeSymlink},
+			},
+			wantFiles: []string{"lib/libfoo.so.1.2", "lib/libfoo.so.1", "lib/libfoo.so", "lib/again"},
+		},
+		{
+			name:   "tar symlink through earlier symlink",
+			format: ".tar.gz",
+			entries: []archiveEntry{
+				{name: "s", link: ".", typ: tar.TypeSymlink},
+				{name: "y", link: "s/../secret", typ: tar.TypeSymlink},
+				{name: "leak", link: "y", typ: tar.TypeLink},
+			},
+			wantErr: "link points outside",
+		},
+		{
+			name:   "tar hard link to symlink",
+			format: ".tar.gz",
+			entries: []archiveEntry{
+				{name: "tool", body: "tool"},
+				{name: "y", link: "tool", typ: tar.TypeSymlink},
+				{name: "leak", link: "y", typ: tar.TypeLink},
+			},
+			wantErr: "not a regular file",
+		},
+		{
+			name:    "tar hard link outside",
+			format:  ".tar.gz",
+			entries: []archiveEntry{{name: "x", link: "../secret", typ: tar.TypeLink}},
+			wantErr: "link leaves the output directory",
+		},
+		{
+			name:      "zip files",
+			format:    ".zip",
+			entries:   []archiveEntry{{name: "tool-1.0/README.md", body: "readme"}, {name: "tool-1.0/tool", body: "tool"}},
+			strip:     1,
+			wantFiles: []string{"README.md", "tool"},
+		},
+		{
+			name:    "zip parent path",
+			format:  ".zip",
+			entries: []archiveEntry{{name: "../x", body: "evil"}},
+			wantErr: "leaves the output directory",
+		},
+		{
+			name:    "zip backslash parent path",
+			format:  ".zip",
+			entries: []archiveEntry{{name: `..\x`, body: "evil"}},
+			wantErr: "leaves the output directory",
+		},
+		{
+			name:    "zip absolute symlink",
+			format:  ".zip",
+			entries: []archiveEntry{{name: "passwd", link: "/etc/passwd", typ: tar.TypeSymlink}},
+			wantErr: "link points outside",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, e := range tt.entries {
+				if e.typ == tar.TypeSymlink && tt.wantErr == "" && runtime.GOOS == "windows" {
+					t.Skip("creating symlinks needs extra privileges on Windows")
+				}
+			}
+			base := t.TempDir()
+			if err := os.MkdirAll(filepath.Join(base, "out"), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(base, "out", "secret"), []byte("secret"), 0600); err != nil {
+				t.Fatal(err)
+			}
+			archive := filepath.Join(base, "archive"+tt.format)
+			if tt.format == ".zip" {
+				writeZip(t, archive, tt.entries)
+			} else {
+				writeTarGz(t, archive, tt.entries)
+			}
+			dest := filepath.Join(base, "out", "dest")
+			if err := os.MkdirAll(dest, 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			n, err := ExtractArchive(archive, dest, tt.strip, false)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ExtractArchive() error = %v, want %q", err, tt.wantErr)
+				}
+				for _, outside := range []string{filepath.Join(base, "out", "x"), filepath.Join(base, "x"), filepath.Join(dest, "sub", "x")} {
+					if _, err := os.Lstat(outside); err == nil {
+						t.Errorf("%s was written", outside)
+					}
+				}
+				if _, err := os.Lstat(filepath.Join(dest, "leak")); err == nil {
+					t.Error("leak was written")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExtractArchive() error = %v", err)
+			}
+			for _, name := range tt.wantFiles {
+				if _, err := os.Lstat(filepath.Join(dest, filepath.FromSlash(name))); err != nil {
+					t.Errorf("Expected %s to be extracted: %v", name, err)
+				}
+			}
+			regular := 0
+			for _, e := range tt.entries {
+				if e.typ == 0 || e.typ == tar.TypeLink {
+					regular++
+				}
+			}
+			if n != regular {
+				t.Errorf("ExtractArchive() = %d files, want %d", n, regular)
+			}
+		})
+	}
+}
+
+func TestExtractArchiveOverwrite(t *testing.T) {
+	base := t.TempDir()
+	archive := filepath.Join(base, "archive.tar.gz")
+	writeTarGz(t, archive, []archiveEntry{{name: "file.txt", body: "new"}})
+	dest := filepath.Join(base, "out")
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dest, "file.txt"), []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ExtractArchive(archive, dest, 0, false); err == nil {
+		t.Fatal("Expected an error for an existing file without overwrite")
+	}
+	if _, err := ExtractArchive(archive, dest, 0, true); err != nil {
+		t.Fatalf("ExtractArchive() with overwrite error = %v", err)
+	}
+	if got, _ := os.ReadFile(filepath.Join(dest, "file.txt")); string(got) != "new" {
+		t.Errorf("file.txt = %q, want %q", got, "new")
+	}
+}
+
+func TestExtractArchiveUnsupported(t *testing.T) {
+	if _, err := ExtractArchive("file.rar", t.TempDir(), 0, false); err == nil {
+		t.Error("Expected an error for an unsupported archive")
+	}
+}
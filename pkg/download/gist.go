@@ -0,0 +1,168 @@
+package download
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dwirx/ghex/internal/ui"
+)
+
+// GistOptions configures a gist download.
+type GistOptions struct {
+	File      string // Only download this file (empty = every file)
+	Output    string // Output filename, for a single file
+	OutputDir string // Output directory (empty = current directory)
+	Overwrite bool   // Overwrite existing files
+	Token     string // Access token (falls back to GITHUB_TOKEN or a configured account's token)
+	Parallel  int    // Files downloaded at once (0 = DefaultParallel)
+
+	Verification // Expected checksum, for a single file
+}
+
+// gistIDPattern matches gist IDs, which are hex strings
+var gistIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8,}$`)
+
+// gistResponse is the response of the GitHub Gists API.
+type gistResponse struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	Truncated   bool   `json:"truncated"`
+	Owner       *struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+	Files map[string]struct {
+		Filename string `json:"filename"`
+		Size     int64  `json:"size"`
+		RawURL   string `json:"raw_url"`
+	} `json:"files"`
+}
+
+// parseGistURL returns the owner (empty when the URL has none), ID and
+// revision (empty = latest) of https://gist.github.com/[user/]<id>[/<revision>].
+func parseGistURL(rawURL string) (owner, id, revision string, ok bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || !strings.EqualFold(u.Host, "gist.github.com") {
+		return "", "", "", false
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) > 0 {
+		parts[len(parts)-1] = strings.TrimSuffix(parts[len(parts)-1], ".git")
+	}
+
+	switch {
+	case len(parts) == 1 && gistIDPattern.MatchString(parts[0]):
+		return "", parts[0], "", true
+	case len(parts) == 2 && gistIDPattern.MatchString(parts[1]):
+		return parts[0], parts[1], "", true
+	case len(parts) == 3 && gistIDPattern.MatchString(parts[1]) && isCommitSHA(parts[2]):
+		return parts[0], parts[1], parts[2], true
+	}
+	// Raw file links (/raw/...) are plain downloads
+	return "", "", "", false
+}
+
+// IsGistURL reports whether url is a gist page on gist.github.com
+func IsGistURL(url string) bool {
+	_, _, _, ok := parseGistURL(url)
+	return ok
+}
+
+// GitGist downloads the files of a gist, keeping their names. Secret gists
+// work too since their URL is all it takes to read them.
+func GitGist(url string, opts GistOptions) error {
+	owner, id, revision, ok := parseGistURL(url)
+	if !ok {
+		return fmt.Errorf("not a gist URL: %s", url)
+	}
+
+	token := releaseToken(&ParsedGitURL{Platform: "github", Host: "github.com", Owner: owner}, opts.Token)
+
+	apiURL := "https://api.github.com/gists/" + id
+	if revision != "" {
+		apiURL += "/" + revision
+	}
+	var gist gistResponse
+	if err := getAPIJSON(apiURL, token, &gist); err != nil {
+		return err
+	}
+
+	var files []TreeFile
+	for name, f := range gist.Files {
+		if f.Filename != "" {
+			name = f.Filename
+		}
+		if opts.File != "" && name != opts.File {
+			continue
+		}
+		// Gist file names have no directories; anything else is refused
+		if strings.ContainsAny(name, `/\`) || !filepath.IsLocal(name) {
+			ui.ShowWarning(fmt.Sprintf("Skipping %q: not a plain file name", name))
+			continue
+		}
+		files = append(files, TreeFile{Path: name, URL: f.RawURL, Size: f.Size})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	ui.ShowSection("Downloading Gist")
+	ui.ShowKeyValue("Gist", id)
+	if gist.Owner != nil {
+		ui.ShowKeyValue("Owner", gist.Owner.Login)
+	}
+	if gist.Description != "" {
+		ui.ShowKeyValue("Description", gist.Description)
+	}
+	if revision != "" {
+		ui.ShowKeyValue("Revision", revision)
+	}
+	fmt.Println()
+
+	if opts.File != "" && len(files) == 0 {
+		names := make([]string, 0, len(gist.Files))
+		for name := range gist.Files {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("gist %s has no file %s (files: %s)", id, opts.File, strings.Join(names, ", "))
+	}
+	if len(files) == 0 {
+		ui.ShowWarning("Gist has no files")
+		return nil
+	}
+	if gist.Truncated {
+		ui.ShowWarning("The gist has more files than the API lists; clone it to get all of them")
+	}
+
+	if len(files) == 1 {
+		f := files[0]
+		output := opts.Output
+		if output == "" {
+			output = f.Path
+		}
+		return FromURL(f.URL, Options{
+			Output:          output,
+			OutputDir:       opts.OutputDir,
+			Overwrite:       opts.Overwrite,
+			ShowProgress:    true,
+			FollowRedirects: true,
+			Verification:    opts.Verification,
+		})
+	}
+	if opts.Output != "" {
+		return fmt.Errorf("--output needs a single file; pick one with --file")
+	}
+	if opts.IsSet() {
+		return errVerifyMultiple
+	}
+
+	outputDir := opts.OutputDir
+	if outputDir == "" {
+		outputDir = "."
+	}
+	// Raw gist URLs need no token, so none is sent to gist.githubusercontent.com
+	result, _ := downloadFiles(files, "", outputDir, opts.Overwrite, opts.Parallel, "", nil)
+	return result.finish(outputDir)
+}
@@ -2,47 +2,120 @@ package download
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
-	"os"
+	"net/url"
+	"path"
 	"path/filepath"
-	"regexp"
+	"runtime"
 	"strings"
+	"sync"
 
+	"github.com/dwirx/ghex/internal/httpclient"
 	"github.com/dwirx/ghex/internal/platform"
 	"github.com/dwirx/ghex/internal/ui"
+	"github.com/dwirx/ghex/internal/update"
 )
 
 // GitOptions configures git download behavior.
 type GitOptions struct {
-	Branch    string // Branch/tag/commit (empty = default branch)
-	Output    string // Output filename for single file
-	OutputDir string // Output directory
-	Depth     int    // Max directory depth (0 = unlimited)
-	Overwrite bool   // Overwrite existing files
-	ShowInfo  bool   // Show file info before download
-	Token     string // GitHub personal access token (falls back to GITHUB_TOKEN env var)
+	Branch    string  // Branch/tag/commit (empty = default branch)
+	Output    string  // Output filename for single file
+	OutputDir string  // Output directory
+	Depth     int     // Max directory depth (0 = unlimited)
+	Overwrite bool    // Overwrite existing files
+	ShowInfo  bool    // Show file info before download
+	Token     string  // Personal access token (falls back to GITHUB_TOKEN, GITLAB_TOKEN, GITEA_TOKEN or BITBUCKET_TOKEN by platform)
+	MaxSize   int64   // Ask before downloading more than this many bytes (0 = DefaultConfirmSize, <0 = never)
+	Force     bool    // Skip the large download confirmation
+	Backend   Backend // Directory download backend (empty = API with git fallback)
+	Parallel  int     // Files of a directory downloaded at once (0 = DefaultParallel)
+
+	Verification // Expected checksum of a single file
 }
 
+// DefaultParallel is the number of files directory downloads fetch at once.
+const DefaultParallel = 4
+
+// DefaultConfirmSize is the total size above which directory downloads ask for confirmation.
+const DefaultConfirmSize int64 = 100 * 1024 * 1024
+
 // ReleaseOptions configures release download behavior.
 type ReleaseOptions struct {
-	Version   string // Release version/tag (empty = latest)
+	Version   string // Release tag or constraint like "^1.4" (empty = latest)
 	Asset     string // Asset name filter
+	Auto      bool   // Pick the asset built for this OS and architecture, without prompting
 	OutputDir string // Output directory
 	ListOnly  bool   // Only list assets, don't download
-	Token     string // GitHub personal access token
+	Token     string // Access token (empty = GITHUB_TOKEN, GITLAB_TOKEN, GITEA_TOKEN or BITBUCKET_TOKEN by host)
 	Overwrite bool   // Overwrite existing files
+	Install   bool   // Install the asset as a tracked binary (see ghex outdated)
+	BinDir    string // Install directory (default: ~/.local/bin)
+	Binary    string // Binary to extract from archives / installed name (default: repo name)
+	QR        bool   // Show the selected asset's download URL as a QR code instead of downloading
+
+	RequireAttestation bool // With Install, refuse assets without a GitHub artifact attestation
+
+	Extract         bool // Unpack downloaded archive assets into the output directory
+	StripComponents int  // Leading path components dropped when extracting
+
+	ListAll    bool   // List every release and pick one (with ListOnly, just list them)
+	TagPattern string // With ListAll, only releases whose tag matches this glob, like "v1.*"
+
+	Prerelease bool // Include prereleases (default: stable releases only; drafts are always skipped)
+}
+
+// filter returns the releases the options allow.
+func (o ReleaseOptions) filter() releaseFilter {
+	return releaseFilter{Prerelease: o.Prerelease}
+}
+
+// releaseFilter says which releases count when resolving or listing them.
+// The zero value keeps stable releases only. Drafts never count: they are
+// only listed for tokens with push access and can't be fetched by tag.
+type releaseFilter struct {
+	Prerelease bool
+}
+
+// allows reports whether a release passes the filter.
+func (f releaseFilter) allows(r Release) bool {
+	return !r.Draft && (f.Prerelease || !r.Prerelease)
+}
+
+// matches reports whether a tag satisfies a constraint. Ranges skip
+// pre-release versions like 1.5.0-rc.1; with prereleases allowed they are
+// checked as the version they lead up to.
+func (f releaseFilter) matches(constraint *update.Constraint, tag string) bool {
+	v, err := update.ParseTagVersion(tag)
+	if err != nil {
+		return false
+	}
+	if f.Prerelease && v.Pre != "" {
+		stable := *v
+		stable.Pre = ""
+		v = &stable
+	}
+	return constraint.Check(v)
 }
 
 // ParsedGitURL represents a parsed git URL.
 type ParsedGitURL struct {
-	Platform    string // github, gitlab, bitbucket
+	Platform    string // Provider name: github, gitlab, gitea, bitbucket or a registered one
+	Host        string // e.g. github.com, gitlab.com or a self-hosted domain (may include a port)
 	Owner       string
 	Repo        string
 	Branch      string
 	FilePath    string
 	IsDirectory bool
+
+	refPath     string // ref and path as given in the URL, used to resolve slashed refs
+	refExplicit bool   // ref came from the URL or --branch (no main→master fallback)
+}
+
+// FullPath returns the repository path including nested groups (e.g. group/subgroup/project).
+func (p *ParsedGitURL) FullPath() string {
+	return p.Owner + "/" + p.Repo
 }
 
 // GitFile downloads a single file from a git repository.
@@ -52,20 +125,13 @@ func GitFile(url string, opts GitOptions) error {
 		return err
 	}
 
-	if opts.Branch != "" {
-		parsed.Branch = opts.Branch
-	}
-
 	if parsed.IsDirectory {
-		ui.ShowWarning("This appears to be a directory. Use GitDirectory instead.")
-		return nil
+		return &ErrIsDirectory{URL: url}
 	}
 
-	// Resolve token: explicit option takes precedence over env var
-	token := opts.Token
-	if token == "" {
-		token = os.Getenv("GITHUB_TOKEN")
-	}
+	token := releaseToken(parsed, opts.Token)
+
+	applyRef(parsed, opts.Branch, token)
 
 	rawURL := toRawURL(parsed)
 	filename := opts.Output
@@ -74,8 +140,8 @@ func GitFile(url string, opts GitOptions) error {
 	}
 
 	ui.ShowSection("Downloading File")
-	ui.ShowKeyValue("Repository", fmt.Sprintf("%s/%s", parsed.Owner, parsed.Repo))
-	ui.ShowKeyValue("Branch", parsed.Branch)
+	ui.ShowKeyValue("Repository", parsed.FullPath())
+	ui.ShowKeyValue("Ref", refLabel(parsed))
 	ui.ShowKeyValue("File", parsed.FilePath)
 	fmt.Println()
 
@@ -87,17 +153,27 @@ func GitFile(url string, opts GitOptions) error {
 		ShowInfo:        opts.ShowInfo,
 		FollowRedirects: true,
 		Token:           token,
+		Verification:    opts.Verification,
 	}
 
 	err = FromURL(rawURL, downloadOpts)
 	if err != nil {
-		// If main branch 404s and no explicit branch was set, try master
 		var notFound *ErrNotFound
-		if isErrNotFound(err, &notFound) && parsed.Branch == "main" && opts.Branch == "" {
-			parsed.Branch = "master"
-			rawURL = toRawURL(parsed)
-			ui.ShowInfo("Branch 'main' not found, trying 'master'...")
-			err = FromURL(rawURL, downloadOpts)
+		if isErrNotFound(err, &notFound) && opts.Branch == "" {
+			if resolveRef(parsed, token) {
+				// The branch name contains slashes, retry with the resolved split
+				ui.ShowInfo(fmt.Sprintf("Resolved ref '%s', retrying...", parsed.Branch))
+				err = FromURL(toRawURL(parsed), downloadOpts)
+			} else if !parsed.refExplicit && parsed.Branch == "main" {
+				// If main branch 404s and no ref was given, try master
+				parsed.Branch = "master"
+				rawURL = toRawURL(parsed)
+				ui.ShowInfo("Branch 'main' not found, trying 'master'...")
+				err = FromURL(rawURL, downloadOpts)
+				if err == nil {
+					rememberDefaultBranch(parsed, token)
+				}
+			}
 		}
 	}
 	return err
@@ -105,41 +181,74 @@ func GitFile(url string, opts GitOptions) error {
 
 // isErrNotFound checks if err is an ErrNotFound and sets target if so.
 func isErrNotFound(err error, target **ErrNotFound) bool {
-	if nf, ok := err.(*ErrNotFound); ok {
-		*target = nf
+	if errors.As(err, target) {
 		return true
 	}
 	// Also check ErrHTTP with 404
-	if he, ok := err.(*ErrHTTP); ok && he.StatusCode == 404 {
-		return true
-	}
-	return false
+	var he *ErrHTTP
+	return errors.As(err, &he) && he.StatusCode == 404
 }
 
-// GitDirectory downloads a directory from a git repository.
-func GitDirectory(url string, opts GitOptions) error {
+// GitPath downloads the file or directory url points to. Where both look
+// the same, like Gitea/Forgejo /src/ URLs, the provider is asked which one
+// it is (see DirectoryChecker).
+func GitPath(url string, opts GitOptions) error {
 	parsed, err := parseGitURL(url)
 	if err != nil {
 		return err
 	}
+	p, err := providerFor(parsed)
+	if err != nil {
+		return err
+	}
 
-	if opts.Branch != "" {
-		parsed.Branch = opts.Branch
+	isDir := parsed.IsDirectory
+	if checker, ok := p.(DirectoryChecker); ok && parsed.FilePath != "" {
+		token := releaseToken(parsed, opts.Token)
+		applyRef(parsed, opts.Branch, token)
+		isDir, err = checker.IsDirectory(parsed, token)
+		if IsNotFound(err) && !parsed.refExplicit && parsed.Branch == "main" {
+			parsed.Branch = "master"
+			isDir, err = checker.IsDirectory(parsed, token)
+			if err == nil {
+				rememberDefaultBranch(parsed, token)
+			}
+		}
+		if err != nil {
+			return err
+		}
 	}
 
-	if parsed.Platform != "github" {
-		return fmt.Errorf("directory download only supported for GitHub")
+	if isDir {
+		return GitDirectory(url, opts)
 	}
+	return GitFile(url, opts)
+}
 
-	// Resolve token: explicit option takes precedence over env var
-	token := opts.Token
-	if token == "" {
-		token = os.Getenv("GITHUB_TOKEN")
+// GitDirectory downloads a directory from a git repository into
+// opts.OutputDir, in a directory named opts.Output (default: the repository
+// name). Existing files are skipped unless opts.Overwrite is set, and
+// opts.ShowInfo lists the files before downloading. When files failed, it
+// returns an *ErrIncomplete.
+func GitDirectory(url string, opts GitOptions) error {
+	if opts.IsSet() {
+		return errVerifyMultiple
+	}
+	parsed, err := parseGitURL(url)
+	if err != nil {
+		return err
 	}
 
+	token := releaseToken(parsed, opts.Token)
+
+	applyRef(parsed, opts.Branch, token)
+
 	ui.ShowSection("Downloading Directory")
-	ui.ShowKeyValue("Repository", fmt.Sprintf("%s/%s", parsed.Owner, parsed.Repo))
-	ui.ShowKeyValue("Branch", parsed.Branch)
+	ui.ShowKeyValue("Repository", parsed.FullPath())
+	if parsed.Host != "" && parsed.Host != "github.com" && parsed.Host != "gitlab.com" {
+		ui.ShowKeyValue("Host", parsed.Host)
+	}
+	ui.ShowKeyValue("Ref", refLabel(parsed))
 	if parsed.FilePath != "" {
 		ui.ShowKeyValue("Path", parsed.FilePath)
 	} else {
@@ -147,18 +256,31 @@ func GitDirectory(url string, opts GitOptions) error {
 	}
 	fmt.Println()
 
+	// Determine output directory
+	outputDir := opts.OutputDir
+	if opts.Output != "" {
+		outputDir = filepath.Join(opts.OutputDir, opts.Output)
+	} else if outputDir == "" {
+		outputDir = parsed.Repo
+	}
+
+	if opts.Backend == BackendGit {
+		return gitSparseDownload(parsed, outputDir, opts, token)
+	}
+
 	// Fetch directory contents
-	files, err := fetchDirectoryContents(parsed, opts.Depth, token)
-	if err != nil {
-		// If main branch fails and no explicit branch was set, try master
-		if parsed.Branch == "main" && opts.Branch == "" {
-			parsed.Branch = "master"
-			ui.ShowInfo("Branch 'main' not found, trying 'master'...")
-			files, err = fetchDirectoryContents(parsed, opts.Depth, token)
-		}
-		if err != nil {
-			return err
+	files, err := listDirectory(parsed, opts.Branch != "", opts.Depth, token)
+	if isRateLimited(err) {
+		if opts.Backend == BackendAuto {
+			ui.ShowWarning(err.Error())
+			if ui.Confirm("Fall back to a sparse git clone instead?") {
+				return gitSparseDownload(parsed, outputDir, opts, token)
+			}
 		}
+		return err
+	}
+	if err != nil {
+		return err
 	}
 
 	if len(files) == 0 {
@@ -166,116 +288,250 @@ func GitDirectory(url string, opts GitOptions) error {
 		return nil
 	}
 
-	ui.ShowInfo(fmt.Sprintf("Found %d files", len(files)))
-
-	// Determine output directory
-	outputDir := opts.OutputDir
-	if outputDir == "" {
-		outputDir = parsed.Repo
+	if opts.ShowInfo {
+		showFileList(files, parsed.FilePath)
+	}
+	if !confirmDownloadSize(files, opts.MaxSize, opts.Force) {
+		ui.ShowInfo("Cancelled")
+		return nil
 	}
 
-	successful := 0
-	for _, file := range files {
-		relPath := file.Path
-		if parsed.FilePath != "" {
-			relPath = strings.TrimPrefix(file.Path, parsed.FilePath+"/")
+	// Files finished by an earlier, interrupted run are skipped
+	jobFiles := make([]JobFile, len(files))
+	for i, f := range files {
+		jobFiles[i] = JobFile{Path: relativePath(f.Path, parsed.FilePath), URL: f.URL, Size: f.Size}
+	}
+	job := startJob(JobDir, url, parsed.Branch, outputDir, jobFiles, opts.Overwrite)
+	if pending := job.Pending(); len(pending) < len(files) {
+		ui.ShowInfo(fmt.Sprintf("%d of %d files already downloaded, resuming the rest", len(files)-len(pending), len(files)))
+		remaining := make(map[string]bool, len(pending))
+		for _, f := range pending {
+			remaining[f.Path] = true
 		}
-
-		outputPath := filepath.Join(outputDir, relPath)
-		dir := filepath.Dir(outputPath)
-		if err := platform.EnsureDir(dir, 0755); err != nil {
-			ui.ShowError(fmt.Sprintf("Failed to create directory: %v", err))
-			continue
+		var left []TreeFile
+		for _, f := range files {
+			if remaining[relativePath(f.Path, parsed.FilePath)] {
+				left = append(left, f)
+			}
 		}
+		files = left
+	}
 
-		downloadOpts := Options{
-			Output:          filepath.Base(outputPath),
-			OutputDir:       dir,
-			Overwrite:       opts.Overwrite,
-			ShowProgress:    false,
-			FollowRedirects: true,
-			Token:           token,
-		}
+	result, _ := downloadFiles(files, parsed.FilePath, outputDir, opts.Overwrite, opts.Parallel, token, job)
+	err = result.finish(outputDir)
+	job.finish()
+	return err
+}
 
-		if err := FromURL(file.URL, downloadOpts); err != nil {
-			ui.ShowError(fmt.Sprintf("Failed to download %s: %v", file.Path, err))
-		} else {
-			successful++
+// listDirectory lists the files below parsed.FilePath. Without an explicit
+// ref it retries with a ref containing slashes resolved, then with master
+// when main doesn't exist. A rate limit is returned right away.
+func listDirectory(parsed *ParsedGitURL, refGiven bool, depth int, token string) ([]TreeFile, error) {
+	files, err := fetchDirectoryContents(parsed, depth, token)
+	if isRateLimited(err) {
+		return nil, err
+	}
+	if err != nil && !refGiven && resolveRef(parsed, token) {
+		// The branch name contains slashes, retry with the resolved split
+		ui.ShowInfo(fmt.Sprintf("Resolved ref '%s', retrying...", parsed.Branch))
+		files, err = fetchDirectoryContents(parsed, depth, token)
+	}
+	if err != nil && !parsed.refExplicit && parsed.Branch == "main" {
+		// If main branch fails and no ref was given, try master
+		parsed.Branch = "master"
+		ui.ShowInfo("Branch 'main' not found, trying 'master'...")
+		files, err = fetchDirectoryContents(parsed, depth, token)
+		if err == nil {
+			rememberDefaultBranch(parsed, token)
 		}
 	}
+	return files, err
+}
 
-	ui.ShowSuccess(fmt.Sprintf("Downloaded %d/%d files to %s", successful, len(files), outputDir))
-	return nil
+// confirmDownloadSize shows the total size of files and asks for confirmation
+// when it exceeds maxSize. Returns false if the user declines.
+func confirmDownloadSize(files []TreeFile, maxSize int64, force bool) bool {
+	var total int64
+	for _, f := range files {
+		total += f.Size
+	}
+	if total > 0 {
+		ui.ShowInfo(fmt.Sprintf("Found %d files (%s)", len(files), formatSize(total)))
+	} else {
+		// GitLab's tree API doesn't report file sizes
+		ui.ShowInfo(fmt.Sprintf("Found %d files", len(files)))
+	}
+
+	if maxSize == 0 {
+		maxSize = DefaultConfirmSize
+	}
+	if force || maxSize < 0 || total <= maxSize {
+		return true
+	}
+
+	ui.ShowWarning(fmt.Sprintf("Total download size %s exceeds %s", formatSize(total), formatSize(maxSize)))
+	return ui.Confirm("Continue with download?")
 }
 
-// GitRelease downloads release assets from GitHub.
-func GitRelease(url string, opts ReleaseOptions) error {
-	parsed, err := parseGitURL(url)
-	if err != nil {
-		return err
+// showFileList prints the files of a multi-file download with their sizes
+func showFileList(files []TreeFile, basePath string) {
+	for _, f := range files {
+		size := ""
+		if f.Size > 0 {
+			size = formatSize(f.Size)
+		}
+		ui.ShowIndentedKeyValue(relativePath(f.Path, basePath), size, 1)
 	}
+	fmt.Println()
+}
 
-	if parsed.Platform != "github" {
-		return fmt.Errorf("release download only supported for GitHub")
+// treeResult counts the files of a multi-file download
+type treeResult struct {
+	downloaded int
+	skipped    int // Already existed and overwrite was off
+	failed     int
+}
+
+// finish prints the summary of a multi-file download into outputDir and
+// returns an *ErrIncomplete when files failed
+func (r treeResult) finish(outputDir string) error {
+	if r.skipped > 0 {
+		ui.ShowWarning(fmt.Sprintf("Skipped %d existing files (use --overwrite to replace)", r.skipped))
+	}
+	total := r.downloaded + r.skipped + r.failed
+	ui.ShowSuccess(fmt.Sprintf("Downloaded %d/%d files to %s", r.downloaded, total, outputDir))
+	if r.failed > 0 {
+		return &ErrIncomplete{Failed: r.failed, Total: total}
 	}
+	return nil
+}
 
-	// Resolve token: explicit option takes precedence over env var
-	token := opts.Token
-	if token == "" {
-		token = os.Getenv("GITHUB_TOKEN")
+// downloadFiles downloads files into outputDir, preserving paths relative to
+// basePath, parallel at a time (0 = DefaultParallel) behind one progress bar.
+// Existing files are skipped unless overwrite is set; failures are listed
+// once all downloads finished. With a job, partial data is kept for resuming
+// and each outcome is recorded. Returns the counts and the error of each
+// file (nil when it succeeded, *ErrFileExists when it was skipped).
+func downloadFiles(files []TreeFile, basePath, outputDir string, overwrite bool, parallel int, token string, job *Job) (treeResult, []error) {
+	if parallel <= 0 {
+		parallel = DefaultParallel
 	}
 
-	ui.ShowSection("GitHub Release")
-	ui.ShowKeyValue("Repository", fmt.Sprintf("%s/%s", parsed.Owner, parsed.Repo))
+	var total int64
+	for _, f := range files {
+		total += f.Size
+	}
+	bar := ui.NewFilesProgressBar(len(files), total)
+
+	errs := make([]error, len(files))
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i, file := range files {
+		wg.Add(1)
+		go func(i int, file TreeFile) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			errs[i] = downloadFile(file, basePath, outputDir, overwrite, token, bar, job)
+			bar.FileDone()
+		}(i, file)
+	}
+	wg.Wait()
+	bar.Finish()
+
+	var result treeResult
+	for i, err := range errs {
+		var exists *ErrFileExists
+		switch {
+		case err == nil:
+			result.downloaded++
+		case errors.As(err, &exists):
+			result.skipped++
+		default:
+			ui.ShowError(fmt.Sprintf("Failed to download %s: %v", files[i].Path, err))
+			result.failed++
+		}
+	}
+	return result, errs
+}
 
-	// Fetch release info
-	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/latest", parsed.Owner, parsed.Repo)
-	if opts.Version != "" {
-		apiURL = fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/tags/%s", parsed.Owner, parsed.Repo, opts.Version)
+// downloadFile downloads one file of a directory download, adding its bytes
+// to bar. With a job, the download resumes a ".part" file and its outcome
+// is recorded.
+func downloadFile(file TreeFile, basePath, outputDir string, overwrite bool, token string, bar *ui.ProgressBar, job *Job) error {
+	rel := relativePath(file.Path, basePath)
+	outputPath := filepath.Join(outputDir, rel)
+	dir := filepath.Dir(outputPath)
+	if err := platform.EnsureDir(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
-	req, err := http.NewRequest("GET", apiURL, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+	if job != nil {
+		err := Resumable(file.URL, filepath.Base(outputPath), ResumableOptions{
+			OutputDir:    dir,
+			Overwrite:    overwrite,
+			Token:        token,
+			ExpectedSize: file.Size,
+			progress:     bar,
+		})
+		job.record(rel, err)
+		return err
 	}
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
-	req.Header.Set("User-Agent", "ghex-downloader/1.0")
-	if token != "" {
-		req.Header.Set("Authorization", "Bearer "+token)
+
+	return FromURL(file.URL, Options{
+		Output:          filepath.Base(outputPath),
+		OutputDir:       dir,
+		Overwrite:       overwrite,
+		ShowProgress:    false,
+		FollowRedirects: true,
+		Token:           token,
+		progress:        bar,
+	})
+}
+
+// relativePath returns a repository path relative to the downloaded
+// directory basePath
+func relativePath(path, basePath string) string {
+	if basePath == "" {
+		return path
 	}
+	return strings.TrimPrefix(path, basePath+"/")
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+// GitRelease downloads release assets from any provider's releases.
+func GitRelease(url string, opts ReleaseOptions) error {
+	parsed, err := parseGitURL(url)
 	if err != nil {
-		return fmt.Errorf("failed to fetch release: %w", err)
+		return err
 	}
-	defer resp.Body.Close()
 
-	// Check for rate limiting
-	if resp.StatusCode == http.StatusForbidden {
-		if resp.Header.Get("X-RateLimit-Remaining") == "0" {
-			resetAt := resp.Header.Get("X-RateLimit-Reset")
-			return &ErrRateLimit{ResetAt: resetAt}
-		}
-	}
+	token := releaseToken(parsed, opts.Token)
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("release not found: %s", resp.Status)
+	ui.ShowSection(platformTitle(parsed.Platform) + " Release")
+	ui.ShowKeyValue("Repository", parsed.FullPath())
+	if parsed.Host != "" && parsed.Host != "github.com" && parsed.Host != "gitlab.com" {
+		ui.ShowKeyValue("Host", parsed.Host)
 	}
 
-	var release struct {
-		TagName     string `json:"tag_name"`
-		Name        string `json:"name"`
-		PublishedAt string `json:"published_at"`
-		Assets      []struct {
-			Name               string `json:"name"`
-			Size               int64  `json:"size"`
-			BrowserDownloadURL string `json:"browser_download_url"`
-		} `json:"assets"`
+	var tag string
+	if opts.ListAll || opts.TagPattern != "" {
+		tag, err = pickRelease(parsed, opts, token)
+		if err != nil || tag == "" {
+			return err
+		}
+	} else {
+		tag, err = resolveReleaseTag(parsed, opts.Version, opts.filter(), token)
+		if err != nil {
+			return err
+		}
+	}
+	if update.IsConstraint(opts.Version) {
+		ui.ShowKeyValue("Constraint", opts.Version)
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
-		return fmt.Errorf("failed to parse release: %w", err)
+	release, err := fetchRelease(parsed, tag, token)
+	if err != nil {
+		return err
 	}
 
 	ui.ShowKeyValue("Version", release.TagName)
@@ -292,11 +548,7 @@ func GitRelease(url string, opts ReleaseOptions) error {
 	// Filter assets
 	assets := release.Assets
 	if opts.Asset != "" {
-		var filtered []struct {
-			Name               string `json:"name"`
-			Size               int64  `json:"size"`
-			BrowserDownloadURL string `json:"browser_download_url"`
-		}
+		var filtered []ReleaseAsset
 		for _, a := range assets {
 			if strings.Contains(strings.ToLower(a.Name), strings.ToLower(opts.Asset)) {
 				filtered = append(filtered, a)
@@ -310,214 +562,534 @@ func GitRelease(url string, opts ReleaseOptions) error {
 		return nil
 	}
 
-	// List assets
-	fmt.Println(ui.Primary("Available assets:"))
-	for i, asset := range assets {
-		size := formatSize(asset.Size)
-		fmt.Printf("  %s %s (%s)\n", ui.Dim(fmt.Sprintf("[%d]", i+1)), asset.Name, size)
+	if opts.Auto {
+		asset, err := autoSelectAsset(assets, runtime.GOOS, runtime.GOARCH)
+		if err != nil {
+			return err
+		}
+		ui.ShowInfo(fmt.Sprintf("Selected %s for %s", asset.Name, update.GetPlatformDisplayName(runtime.GOOS, runtime.GOARCH)))
+		assets = []ReleaseAsset{*asset}
+	}
+
+	// Downloads pick from a multi-select list, which shows the assets itself
+	pick := !opts.Auto && !opts.ListOnly && !opts.Install && !opts.QR
+	if !pick {
+		fmt.Println(ui.Primary("Available assets:"))
+		for i, asset := range assets {
+			size := formatSize(asset.Size)
+			fmt.Printf("  %s %s (%s)\n", ui.Dim(fmt.Sprintf("[%d]", i+1)), asset.Name, size)
+		}
+		fmt.Println()
 	}
-	fmt.Println()
 
 	if opts.ListOnly {
 		return nil
 	}
 
-	// Select asset
-	choice := ui.Prompt("Select asset to download (number or 'all')")
-	if choice == "" {
-		return nil
+	if opts.Install {
+		return installReleaseAsset(parsed, release, assets, opts, token)
 	}
 
-	var toDownload []struct {
-		Name               string `json:"name"`
-		Size               int64  `json:"size"`
-		BrowserDownloadURL string `json:"browser_download_url"`
+	if opts.QR {
+		return showReleaseQR(parsed, assets, token)
 	}
 
-	if choice == "all" {
-		toDownload = assets
-	} else {
-		var idx int
-		_, _ = fmt.Sscanf(choice, "%d", &idx)
-		if idx < 1 || idx > len(assets) {
-			return fmt.Errorf("invalid selection")
+	toDownload := assets
+	if pick {
+		items := make([]ui.SelectorItem, len(assets))
+		for i, a := range assets {
+			items[i] = ui.SelectorItem{Title: a.Name, Description: formatSize(a.Size), Value: a.Name}
+		}
+		idxs, err := ui.RunMultiSelector(fmt.Sprintf("Select assets from %s to download", release.TagName), items)
+		if err != nil {
+			return fmt.Errorf("selection error: %w", err)
+		}
+		if len(idxs) == 0 {
+			ui.ShowInfo("Nothing selected")
+			return nil
+		}
+		toDownload = make([]ReleaseAsset, 0, len(idxs))
+		for _, i := range idxs {
+			toDownload = append(toDownload, assets[i])
 		}
-		toDownload = append(toDownload, assets[idx-1])
 	}
 
-	// Download selected assets
-	for _, asset := range toDownload {
-		downloadOpts := Options{
-			Output:          asset.Name,
-			OutputDir:       opts.OutputDir,
-			Overwrite:       opts.Overwrite,
-			ShowProgress:    true,
-			FollowRedirects: true,
-			Token:           token,
+	// Assets finished by an earlier, interrupted run are skipped
+	files := make([]JobFile, len(toDownload))
+	for i, asset := range toDownload {
+		files[i] = JobFile{Path: asset.Name, URL: asset.BrowserDownloadURL, Size: asset.Size}
+	}
+	job := startJob(JobRelease, url, release.TagName, opts.OutputDir, files, opts.Overwrite)
+	if opts.Extract {
+		job.Extract, job.StripComponents = true, opts.StripComponents
+		job.save()
+	}
+	pending := job.Pending()
+	if len(pending) < len(files) {
+		ui.ShowInfo(fmt.Sprintf("%d of %d assets already downloaded, resuming the rest", len(files)-len(pending), len(files)))
+	}
+
+	err = downloadReleaseFiles(job, pending, opts.OutputDir, opts.Overwrite, token)
+	job.finish()
+	return err
+}
+
+// downloadReleaseFiles downloads release assets of a job into outputDir,
+// resuming partial files and verifying them against the API size, and
+// unpacks archives when the job extracts. When assets failed, it returns
+// an *ErrIncomplete.
+func downloadReleaseFiles(job *Job, files []JobFile, outputDir string, overwrite bool, token string) error {
+	failed := 0
+	for _, f := range files {
+		downloadOpts := ResumableOptions{
+			OutputDir:    outputDir,
+			Overwrite:    overwrite,
+			Token:        token,
+			ExpectedSize: f.Size,
+			ShowProgress: true,
 		}
 
-		if err := FromURL(asset.BrowserDownloadURL, downloadOpts); err != nil {
-			ui.ShowError(fmt.Sprintf("Failed to download %s: %v", asset.Name, err))
+		err := Resumable(f.URL, f.Path, downloadOpts)
+		job.record(f.Path, err)
+		if err != nil {
+			ui.ShowError(fmt.Sprintf("Failed to download %s: %v", f.Path, err))
+			var exists *ErrFileExists
+			if !errors.As(err, &exists) {
+				failed++
+			}
+			continue
+		}
+		if job.Extract && isArchive(f.Path) {
+			if err := extractDownload(filepath.Join(outputDir, f.Path), job.StripComponents, overwrite, true); err != nil {
+				ui.ShowError(err.Error())
+				failed++
+			}
 		}
 	}
-
+	if failed > 0 {
+		return &ErrIncomplete{Failed: failed, Total: len(files)}
+	}
 	return nil
 }
 
-// parseGitURL parses a git repository URL.
-func parseGitURL(url string) (*ParsedGitURL, error) {
-	parsed := &ParsedGitURL{
-		Branch: "main",
+// autoSelectAsset returns the asset that fits goos/goarch best by name
+// (see update.AssetScore). Assets that fit equally well are an error
+// listing them, to be narrowed down with --asset.
+func autoSelectAsset(assets []ReleaseAsset, goos, goarch string) (*ReleaseAsset, error) {
+	var best []ReleaseAsset
+	bestScore := 0
+	for _, a := range assets {
+		score := update.AssetScore(a.Name, goos, goarch)
+		if score == 0 || score < bestScore {
+			continue
+		}
+		if score > bestScore {
+			best, bestScore = nil, score
+		}
+		best = append(best, a)
 	}
 
-	// GitHub patterns
-	githubBlobPattern := regexp.MustCompile(`github\.com/([^/]+)/([^/]+)/blob/([^/]+)/(.+)`)
-	githubTreePattern := regexp.MustCompile(`github\.com/([^/]+)/([^/]+)/tree/([^/]+)/(.+)`)
-	githubRepoPattern := regexp.MustCompile(`github\.com/([^/]+)/([^/]+)`)
+	platform := update.GetPlatformDisplayName(goos, goarch)
+	switch len(best) {
+	case 0:
+		return nil, fmt.Errorf("no asset looks built for %s; pick one with --asset", platform)
+	case 1:
+		return &best[0], nil
+	default:
+		names := make([]string, len(best))
+		for i, a := range best {
+			names[i] = a.Name
+		}
+		return nil, fmt.Errorf("several assets fit %s: %s; narrow them down with --asset", platform, strings.Join(names, ", "))
+	}
+}
 
-	if matches := githubBlobPattern.FindStringSubmatch(url); matches != nil {
-		parsed.Platform = "github"
-		parsed.Owner = matches[1]
-		parsed.Repo = matches[2]
-		parsed.Branch = matches[3]
-		parsed.FilePath = matches[4]
-		parsed.IsDirectory = false
-		return parsed, nil
+// pickRelease lists every release matching the tag pattern and version
+// constraint with its date and prerelease flag, and prompts for one. It
+// returns "" when nothing was picked or only a list was asked for.
+func pickRelease(parsed *ParsedGitURL, opts ReleaseOptions, token string) (string, error) {
+	version := strings.TrimSpace(opts.Version)
+	var constraint *update.Constraint
+	if update.IsConstraint(version) {
+		c, err := update.ParseConstraint(version)
+		if err != nil {
+			return "", err
+		}
+		constraint = c
+	} else if version != "" && version != "latest" {
+		return "", fmt.Errorf("--list-all picks the release itself; use a range like \"^1.4\" instead of the tag %s", version)
+	}
+	if opts.TagPattern != "" {
+		if _, err := path.Match(opts.TagPattern, ""); err != nil {
+			return "", fmt.Errorf("invalid tag pattern %q: %w", opts.TagPattern, err)
+		}
 	}
 
-	if matches := githubTreePattern.FindStringSubmatch(url); matches != nil {
-		parsed.Platform = "github"
-		parsed.Owner = matches[1]
-		parsed.Repo = matches[2]
-		parsed.Branch = matches[3]
-		parsed.FilePath = matches[4]
-		parsed.IsDirectory = true
-		return parsed, nil
+	all, err := listReleases(parsed, token)
+	if err != nil {
+		return "", err
+	}
+	filter := opts.filter()
+	var releases []Release
+	for _, r := range all {
+		if !filter.allows(r) {
+			continue
+		}
+		if opts.TagPattern != "" {
+			if ok, _ := path.Match(opts.TagPattern, r.TagName); !ok {
+				continue
+			}
+		}
+		if constraint != nil && !filter.matches(constraint, r.TagName) {
+			continue
+		}
+		releases = append(releases, r)
+	}
+	if len(releases) == 0 {
+		ui.ShowWarning(fmt.Sprintf("No releases found matching: %s", strings.TrimSpace(opts.TagPattern+" "+version)))
+		return "", nil
 	}
 
-	if matches := githubRepoPattern.FindStringSubmatch(url); matches != nil {
-		parsed.Platform = "github"
-		parsed.Owner = matches[1]
-		parsed.Repo = strings.TrimSuffix(matches[2], ".git")
-		parsed.FilePath = "" // repo root
-		parsed.IsDirectory = true
-		return parsed, nil
+	fmt.Println()
+	fmt.Println(ui.Primary(fmt.Sprintf("Releases (%d):", len(releases))))
+	width := 0
+	for _, r := range releases {
+		width = max(width, len(r.TagName))
 	}
+	for i, r := range releases {
+		date := "          "
+		if len(r.PublishedAt) >= 10 {
+			date = r.PublishedAt[:10]
+		}
+		line := fmt.Sprintf("  %s %-*s  %s", ui.Dim(fmt.Sprintf("[%d]", i+1)), width, r.TagName, ui.Dim(date))
+		if r.Prerelease {
+			line += "  " + ui.Warning("prerelease")
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
 
-	// GitLab patterns
-	gitlabBlobPattern := regexp.MustCompile(`gitlab\.com/([^/]+)/([^/]+)/-/blob/([^/]+)/(.+)`)
-	gitlabTreePattern := regexp.MustCompile(`gitlab\.com/([^/]+)/([^/]+)/-/tree/([^/]+)/(.+)`)
+	if opts.ListOnly {
+		return "", nil
+	}
 
-	if matches := gitlabBlobPattern.FindStringSubmatch(url); matches != nil {
-		parsed.Platform = "gitlab"
-		parsed.Owner = matches[1]
-		parsed.Repo = matches[2]
-		parsed.Branch = matches[3]
-		parsed.FilePath = matches[4]
-		parsed.IsDirectory = false
-		return parsed, nil
+	choice := ui.Prompt("Select release (number)")
+	if choice == "" {
+		return "", nil
 	}
+	var idx int
+	_, _ = fmt.Sscanf(choice, "%d", &idx)
+	if idx < 1 || idx > len(releases) {
+		return "", fmt.Errorf("invalid selection")
+	}
+	return releases[idx-1].TagName, nil
+}
 
-	if matches := gitlabTreePattern.FindStringSubmatch(url); matches != nil {
-		parsed.Platform = "gitlab"
-		parsed.Owner = matches[1]
-		parsed.Repo = matches[2]
-		parsed.Branch = matches[3]
-		parsed.FilePath = matches[4]
-		parsed.IsDirectory = true
-		return parsed, nil
+// showReleaseQR shows an asset's download URL as a QR code so it can be
+// opened on a phone or another machine.
+func showReleaseQR(parsed *ParsedGitURL, assets []ReleaseAsset, token string) error {
+	asset := &assets[0]
+	if len(assets) > 1 {
+		choice := ui.Prompt("Select asset to show (number)")
+		var idx int
+		_, _ = fmt.Sscanf(choice, "%d", &idx)
+		if idx < 1 || idx > len(assets) {
+			return fmt.Errorf("invalid selection")
+		}
+		asset = &assets[idx-1]
 	}
 
-	return nil, fmt.Errorf("unsupported URL format: %s", url)
+	fmt.Println(asset.BrowserDownloadURL)
+	if err := ui.ShowQR(asset.BrowserDownloadURL); err != nil {
+		return err
+	}
+	if token != "" {
+		// The token is never put in the URL
+		ui.ShowWarning(fmt.Sprintf("%s may be private; the link needs a signed-in browser", parsed.FullPath()))
+	}
+	return nil
 }
 
-// toRawURL converts a parsed URL to raw download URL.
-func toRawURL(parsed *ParsedGitURL) string {
-	switch parsed.Platform {
+// releaseToken returns the explicit token, the platform's token
+// environment variable (GITHUB_TOKEN, GITLAB_TOKEN, GITEA_TOKEN or
+// BITBUCKET_TOKEN), or the
+// token of a registered account on the URL's host. A token is never sent
+// to another platform or host.
+func releaseToken(parsed *ParsedGitURL, explicit string) string {
+	if explicit != "" {
+		return explicit
+	}
+	if env := providerTokenEnv(parsed); env != "" {
+		return env
+	}
+	return registeredToken(parsed.Host, parsed.Owner)
+}
+
+// platformTitle returns the display name of a platform.
+func platformTitle(platform string) string {
+	switch platform {
 	case "github":
-		return fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/%s/%s",
-			parsed.Owner, parsed.Repo, parsed.Branch, parsed.FilePath)
+		return "GitHub"
 	case "gitlab":
-		return fmt.Sprintf("https://gitlab.com/%s/%s/-/raw/%s/%s",
-			parsed.Owner, parsed.Repo, parsed.Branch, parsed.FilePath)
+		return "GitLab"
+	case "gitea":
+		return "Gitea"
+	case "bitbucket":
+		return "Bitbucket"
 	default:
-		return ""
+		return strings.ToUpper(platform[:1]) + platform[1:]
 	}
 }
 
-type fileInfo struct {
-	Path string
-	URL  string
+// ReleaseAsset is a downloadable file attached to a release.
+type ReleaseAsset struct {
+	Name               string `json:"name"`
+	Size               int64  `json:"size"`
+	BrowserDownloadURL string `json:"browser_download_url"`
 }
 
-// fetchDirectoryContents fetches all files in a directory using the GitHub Contents API.
-// token is optional; if provided it is sent as Authorization: Bearer <token>.
-func fetchDirectoryContents(parsed *ParsedGitURL, maxDepth int, token string) ([]fileInfo, error) {
-	var files []fileInfo
+// Release is a release and its assets, in the shape of the GitHub release
+// API response, which Gitea answers in too.
+type Release struct {
+	TagName     string         `json:"tag_name"`
+	Name        string         `json:"name"`
+	PublishedAt string         `json:"published_at"`
+	Prerelease  bool           `json:"prerelease"`
+	Draft       bool           `json:"draft"`
+	Assets      []ReleaseAsset `json:"assets"`
+}
 
-	var fetchRecursive func(path string, depth int) error
-	fetchRecursive = func(path string, depth int) error {
-		if maxDepth > 0 && depth > maxDepth {
-			return nil
-		}
+// fetchRelease fetches a release by tag (empty = latest) from the
+// repository's provider.
+func fetchRelease(parsed *ParsedGitURL, tag, token string) (*Release, error) {
+	p, err := providerFor(parsed)
+	if err != nil {
+		return nil, err
+	}
+	return p.Release(parsed, tag, token)
+}
 
-		apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/contents/%s?ref=%s",
-			parsed.Owner, parsed.Repo, path, parsed.Branch)
+// maxReleasePages caps how many pages of releases are listed.
+const maxReleasePages = 10
 
-		req, err := http.NewRequest("GET", apiURL, nil)
-		if err != nil {
-			return err
-		}
-		req.Header.Set("Accept", "application/vnd.github.v3+json")
-		req.Header.Set("User-Agent", "ghex-downloader/1.0")
-		if token != "" {
-			req.Header.Set("Authorization", "Bearer "+token)
+// listReleases lists every release, newest first, without assets.
+func listReleases(parsed *ParsedGitURL, token string) ([]Release, error) {
+	p, err := providerFor(parsed)
+	if err != nil {
+		return nil, err
+	}
+	return p.Releases(parsed, token)
+}
+
+// fetchGitHubRelease fetches a GitHub release by tag (empty = latest).
+func fetchGitHubRelease(owner, repo, tag, token string) (*Release, error) {
+	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/latest", owner, repo)
+	if tag != "" {
+		apiURL = fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/tags/%s", owner, repo, url.PathEscape(tag))
+	}
+
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("User-Agent", "ghex-downloader/1.0")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := doAPI(httpclient.Default(), req)
+	if err != nil {
+		if isRateLimited(err) {
+			return nil, err
 		}
+		return nil, fmt.Errorf("failed to fetch release: %w", err)
+	}
+	defer resp.Body.Close()
 
-		client := &http.Client{}
-		resp, err := client.Do(req)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("release not found: %s", resp.Status)
+	}
+
+	var release Release
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, fmt.Errorf("failed to parse release: %w", err)
+	}
+	return &release, nil
+}
+
+// resolveReleaseTag turns a requested version into a release tag, or ""
+// for the platform's latest release. Constraints like "^1.4" pick the
+// newest release the filter allows.
+func resolveReleaseTag(parsed *ParsedGitURL, version string, filter releaseFilter, token string) (string, error) {
+	version = strings.TrimSpace(version)
+	if version == "" || version == "latest" {
+		if filter == (releaseFilter{}) {
+			return "", nil
+		}
+		releases, err := listReleases(parsed, token)
 		if err != nil {
-			return err
+			return "", err
 		}
-		defer resp.Body.Close()
-
-		// Check for rate limiting
-		if resp.StatusCode == http.StatusForbidden {
-			if resp.Header.Get("X-RateLimit-Remaining") == "0" {
-				resetAt := resp.Header.Get("X-RateLimit-Reset")
-				return &ErrRateLimit{ResetAt: resetAt}
+		for _, r := range releases {
+			if filter.allows(r) {
+				return r.TagName, nil
 			}
 		}
+		return "", fmt.Errorf("no releases found for %s", parsed.FullPath())
+	}
+	if !update.IsConstraint(version) {
+		return version, nil
+	}
+
+	constraint, err := update.ParseConstraint(version)
+	if err != nil {
+		return "", err
+	}
 
-		if resp.StatusCode == http.StatusNotFound {
-			return &ErrNotFound{URL: apiURL}
+	releases, err := listReleases(parsed, token)
+	if err != nil {
+		return "", err
+	}
+
+	var best *update.Version
+	bestTag := ""
+	for _, r := range releases {
+		if !filter.allows(r) || !filter.matches(constraint, r.TagName) {
+			continue
+		}
+		v, _ := update.ParseTagVersion(r.TagName)
+		if best == nil || v.IsNewerThan(best) {
+			best, bestTag = v, r.TagName
 		}
+	}
+	if bestTag == "" {
+		return "", fmt.Errorf("no release of %s matches %s", parsed.FullPath(), constraint)
+	}
+	return bestTag, nil
+}
 
-		if resp.StatusCode != http.StatusOK {
-			return &ErrHTTP{StatusCode: resp.StatusCode, Status: resp.Status, URL: apiURL}
+// toRawURL converts a parsed URL to raw download URL.
+func toRawURL(parsed *ParsedGitURL) string {
+	p, err := providerFor(parsed)
+	if err != nil {
+		return ""
+	}
+	return p.RawURL(parsed)
+}
+
+// contentEntry is a single entry returned by the GitHub Contents API.
+type contentEntry struct {
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	Type        string `json:"type"`
+	Size        int64  `json:"size"`
+	SHA         string `json:"sha"`
+	DownloadURL string `json:"download_url"`
+}
+
+// listContents lists a single directory level using the GitHub Contents API,
+// or the Gitea/Forgejo one, which answers in the same shape.
+func listContents(parsed *ParsedGitURL, path, token string) ([]contentEntry, error) {
+	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/contents/%s?ref=%s",
+		parsed.Owner, parsed.Repo, escapePath(path), url.QueryEscape(parsed.Branch))
+	if parsed.Platform == "gitea" {
+		apiURL = giteaContentsURL(parsed, path)
+	}
+
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("User-Agent", "ghex-downloader/1.0")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := doAPI(httpclient.Default(), req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, &ErrNotFound{URL: apiURL}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &ErrHTTP{StatusCode: resp.StatusCode, Status: resp.Status, URL: apiURL}
+	}
+
+	// Decode straight from the response to avoid buffering large listings
+	var contents []contentEntry
+	if err := json.NewDecoder(resp.Body).Decode(&contents); err != nil {
+		return nil, err
+	}
+	if parsed.Platform == "gitea" {
+		// The web raw URLs in download_url don't work for private repositories
+		for i := range contents {
+			contents[i].DownloadURL = giteaRawURL(parsed, contents[i].Path)
 		}
+	}
 
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return err
+	return contents, nil
+}
+
+// fetchDirectoryContents fetches all files in a directory. Listings are
+// cached by the commit the ref resolves to, so a repeated download of an
+// unchanged ref skips the listing calls.
+// token is optional; if provided it is sent as Authorization: Bearer <token>.
+func fetchDirectoryContents(parsed *ParsedGitURL, maxDepth int, token string) ([]TreeFile, error) {
+	key := ""
+	if commit := resolveCommit(parsed, token); commit != "" {
+		key = listingCacheKey(parsed, commit, maxDepth, token)
+		var files []TreeFile
+		if loadMeta(key, listingTTL, &files) {
+			return files, nil
 		}
+	}
+
+	files, err := listDirectoryFiles(parsed, maxDepth, token)
+	if err == nil && key != "" {
+		storeMeta(key, files)
+	}
+	return files, err
+}
+
+// listDirectoryFiles lists the files in a directory with the repository's
+// provider.
+func listDirectoryFiles(parsed *ParsedGitURL, maxDepth int, token string) ([]TreeFile, error) {
+	p, err := providerFor(parsed)
+	if err != nil {
+		return nil, err
+	}
+	return p.ListTree(parsed, maxDepth, token)
+}
+
+// walkContents lists the files below parsed.FilePath by walking the
+// Contents API one directory at a time.
+func walkContents(parsed *ParsedGitURL, maxDepth int, token string) ([]TreeFile, error) {
+	var files []TreeFile
 
-		var contents []struct {
-			Name        string `json:"name"`
-			Path        string `json:"path"`
-			Type        string `json:"type"`
-			DownloadURL string `json:"download_url"`
+	var fetchRecursive func(path string, depth int) error
+	fetchRecursive = func(path string, depth int) error {
+		if maxDepth > 0 && depth > maxDepth {
+			return nil
 		}
 
-		if err := json.Unmarshal(body, &contents); err != nil {
+		contents, err := listContents(parsed, path, token)
+		if err != nil {
 			return err
 		}
 
 		for _, item := range contents {
 			if item.Type == "file" {
-				files = append(files, fileInfo{
+				files = append(files, TreeFile{
 					Path: item.Path,
 					URL:  item.DownloadURL,
+					Size: item.Size,
+					SHA:  item.SHA,
 				})
 			} else if item.Type == "dir" {
 				if err := fetchRecursive(item.Path, depth+1); err != nil {
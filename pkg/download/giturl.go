@@ -0,0 +1,426 @@
+package download
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/dwirx/ghex/internal/httpclient"
+)
+
+// parseGitURL parses a git repository URL.
+// Query strings and fragments (e.g. ?raw=1, #L10) are ignored and path
+// segments are URL-decoded, so an encoded branch such as feature%2Fx stays
+// a single ref. Unencoded branch names containing slashes are ambiguous and
+// resolved later by resolveRef.
+func parseGitURL(rawURL string) (*ParsedGitURL, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL: %w", err)
+	}
+
+	segments, err := pathSegments(u)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL path: %w", err)
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	for _, p := range providers {
+		parsed := p.ParseURL(host, segments)
+		if parsed == nil {
+			continue
+		}
+		if parsed.Platform == "" {
+			parsed.Platform = p.Name()
+		}
+		if parsed.Host == "" {
+			parsed.Host = host
+		}
+		return parsed, nil
+	}
+	return nil, fmt.Errorf("unsupported URL format: %s", rawURL)
+}
+
+// IsRepoPathURL reports whether rawURL points at a ref, file or directory
+// inside a repository of a registered provider, e.g.
+// https://bitbucket.org/workspace/repo/src/main/docs.
+func IsRepoPathURL(rawURL string) bool {
+	parsed, err := parseGitURL(rawURL)
+	return err == nil && parsed.refExplicit
+}
+
+// customHosts maps self-hosted domains to their platform.
+var customHosts = map[string]string{}
+
+// RegisterHost makes URLs on a self-hosted domain recognizable, e.g. the
+// custom domains of configured accounts. platform is a PlatformConfig type;
+// "codeberg" and "forgejo" are treated as "gitea". Other types are ignored.
+func RegisterHost(domain, platform string) {
+	domain = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), "www.")
+	if domain == "" {
+		return
+	}
+	switch platform {
+	case "gitlab":
+		customHosts[domain] = "gitlab"
+	case "gitea", "codeberg", "forgejo":
+		customHosts[domain] = "gitea"
+	}
+}
+
+// accountToken is a token of a configured account.
+type accountToken struct {
+	host     string
+	username string
+	token    string
+}
+
+// accountTokens are used when neither --token nor the environment gives one.
+var accountTokens []accountToken
+
+// RegisterToken makes a configured account's token available for downloads
+// from domain when no token is given, which avoids anonymous rate limits.
+// For an owner's repositories the account with that username is preferred.
+func RegisterToken(domain, username, token string) {
+	domain = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), "www.")
+	if domain == "" || token == "" {
+		return
+	}
+	accountTokens = append(accountTokens, accountToken{host: domain, username: username, token: token})
+}
+
+// registeredToken returns the token of an account on host, preferring the
+// account named owner, or "" if there is none.
+func registeredToken(host, owner string) string {
+	found := ""
+	for _, t := range accountTokens {
+		if t.host != host {
+			continue
+		}
+		if owner != "" && strings.EqualFold(t.username, owner) {
+			return t.token
+		}
+		if found == "" {
+			found = t.token
+		}
+	}
+	return found
+}
+
+// hostPlatform returns the platform served on host, or "" if unknown.
+func hostPlatform(host string) string {
+	if p, ok := customHosts[host]; ok {
+		return p
+	}
+	// Also match registered domains given without the port
+	if name, _, ok := strings.Cut(host, ":"); ok {
+		if p, ok := customHosts[name]; ok {
+			return p
+		}
+	}
+
+	switch {
+	case host == "gitlab.com" || strings.HasPrefix(host, "gitlab."):
+		// Self-hosted GitLab instances conventionally live on gitlab.<domain>
+		return "gitlab"
+	case host == "codeberg.org" || strings.HasPrefix(host, "gitea.") || strings.HasPrefix(host, "forgejo."):
+		return "gitea"
+	}
+	return ""
+}
+
+// pathSegments splits the escaped URL path and decodes each segment.
+func pathSegments(u *url.URL) ([]string, error) {
+	var segments []string
+	for _, seg := range strings.Split(u.EscapedPath(), "/") {
+		if seg == "" {
+			continue
+		}
+		decoded, err := url.PathUnescape(seg)
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, decoded)
+	}
+	return segments, nil
+}
+
+// escapePath URL-escapes each segment of a slash-separated path.
+func escapePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, seg := range segments {
+		segments[i] = url.PathEscape(seg)
+	}
+	return strings.Join(segments, "/")
+}
+
+// parseGitHubSegments handles owner/repo[/blob|tree|raw/ref/path...].
+func parseGitHubSegments(segments []string) *ParsedGitURL {
+	if len(segments) < 2 {
+		return nil
+	}
+
+	parsed := &ParsedGitURL{
+		Platform:    "github",
+		Owner:       segments[0],
+		Repo:        strings.TrimSuffix(segments[1], ".git"),
+		Branch:      "main",
+		IsDirectory: true, // repo root
+	}
+
+	if len(segments) >= 4 {
+		switch segments[2] {
+		case "blob", "raw":
+			parsed.SetRefPath(segments[3:], false)
+		case "tree":
+			parsed.SetRefPath(segments[3:], true)
+		}
+	}
+
+	return parsed
+}
+
+// parseGitLabSegments handles group/.../project[/-/blob|tree|raw/ref/path...].
+func parseGitLabSegments(segments []string) *ParsedGitURL {
+	sep := -1
+	for i, seg := range segments {
+		if seg == "-" {
+			sep = i
+			break
+		}
+	}
+
+	namespace := segments
+	if sep >= 0 {
+		namespace = segments[:sep]
+	}
+	if len(namespace) < 2 {
+		return nil
+	}
+
+	parsed := &ParsedGitURL{
+		Platform:    "gitlab",
+		Owner:       strings.Join(namespace[:len(namespace)-1], "/"),
+		Repo:        strings.TrimSuffix(namespace[len(namespace)-1], ".git"),
+		Branch:      "main",
+		IsDirectory: true, // repo root
+	}
+
+	if sep >= 0 && len(segments) >= sep+3 {
+		switch segments[sep+1] {
+		case "blob", "raw":
+			parsed.SetRefPath(segments[sep+2:], false)
+		case "tree":
+			parsed.SetRefPath(segments[sep+2:], true)
+		}
+	}
+
+	return parsed
+}
+
+// isGitLabPathForm reports whether segments point into a repository the
+// way only GitLab does: group/.../project/-/blob|tree|raw/ref/...
+func isGitLabPathForm(segments []string) bool {
+	for i, seg := range segments {
+		if seg == "-" {
+			return i >= 2 && len(segments) >= i+3 &&
+				(segments[i+1] == "blob" || segments[i+1] == "tree" || segments[i+1] == "raw")
+		}
+	}
+	return false
+}
+
+// parseGiteaSegments handles owner/repo[/src|raw/branch|tag|commit/ref/path...].
+func parseGiteaSegments(segments []string) *ParsedGitURL {
+	if len(segments) < 2 {
+		return nil
+	}
+
+	parsed := &ParsedGitURL{
+		Platform:    "gitea",
+		Owner:       segments[0],
+		Repo:        strings.TrimSuffix(segments[1], ".git"),
+		Branch:      "main",
+		IsDirectory: true, // repo root
+	}
+
+	if len(segments) >= 5 && (segments[2] == "src" || segments[2] == "raw") {
+		switch segments[3] {
+		case "branch", "tag", "commit":
+			parsed.SetRefPath(segments[4:], segments[2] == "src" && len(segments) == 5)
+		}
+	}
+
+	return parsed
+}
+
+// SetRefPath sets the ref and path from URL segments, the first being the
+// ref, e.g. main/docs/intro.md. The joined value is kept so refs containing
+// slashes can be re-split once the repository's refs are known.
+func (p *ParsedGitURL) SetRefPath(rest []string, isDir bool) {
+	p.Branch = rest[0]
+	p.FilePath = strings.Join(rest[1:], "/")
+	p.IsDirectory = isDir
+	p.refPath = strings.Join(rest, "/")
+	p.refExplicit = true
+}
+
+// commitSHAPattern matches full or abbreviated commit SHAs.
+var commitSHAPattern = regexp.MustCompile(`^[0-9a-fA-F]{7,40}$`)
+
+// isCommitSHA reports whether ref looks like a commit SHA.
+func isCommitSHA(ref string) bool {
+	return commitSHAPattern.MatchString(ref)
+}
+
+// applyRef applies an explicit --branch value and expands abbreviated
+// commit SHAs, which raw.githubusercontent.com does not accept.
+func applyRef(parsed *ParsedGitURL, branch, token string) {
+	if branch != "" {
+		parsed.Branch = branch
+		parsed.refPath = ""
+		parsed.refExplicit = true
+	} else if !parsed.refExplicit {
+		// Start from the default branch an earlier run found, if any
+		if def := cachedDefaultBranch(parsed, token); def != "" {
+			parsed.Branch = def
+		}
+	}
+
+	if parsed.Platform == "github" && isCommitSHA(parsed.Branch) && len(parsed.Branch) < 40 {
+		if full := fetchCommitSHA(parsed, token); full != "" {
+			parsed.Branch = full
+		}
+	}
+}
+
+// refLabel describes the ref for download headers.
+func refLabel(parsed *ParsedGitURL) string {
+	switch {
+	case !parsed.refExplicit:
+		return parsed.Branch + " (default)"
+	case len(parsed.Branch) == 40 && isCommitSHA(parsed.Branch):
+		return parsed.Branch[:7] + " (commit " + parsed.Branch + ")"
+	default:
+		return parsed.Branch
+	}
+}
+
+// fetchCommitSHA resolves a ref to its full commit SHA via the GitHub API.
+// Returns an empty string if the ref can't be resolved.
+func fetchCommitSHA(parsed *ParsedGitURL, token string) string {
+	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/commits/%s",
+		parsed.Owner, parsed.Repo, url.PathEscape(parsed.Branch))
+
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return ""
+	}
+	req.Header.Set("Accept", "application/vnd.github.sha")
+	req.Header.Set("User-Agent", "ghex-downloader/1.0")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := doAPI(httpclient.Default(), req)
+	if err != nil {
+		return ""
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return ""
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 128))
+	if err != nil {
+		return ""
+	}
+	sha := strings.TrimSpace(string(body))
+	if len(sha) != 40 || !isCommitSHA(sha) {
+		return ""
+	}
+	return sha
+}
+
+// resolveRef disambiguates ref names containing slashes (e.g. feature/login)
+// by asking the GitHub API which branches and tags match the first segment.
+// Returns true if the ref/path split changed.
+func resolveRef(parsed *ParsedGitURL, token string) bool {
+	if parsed.Platform != "github" || !strings.Contains(parsed.refPath, "/") {
+		return false
+	}
+
+	first := strings.SplitN(parsed.refPath, "/", 2)[0]
+	best := ""
+	for _, kind := range []string{"heads", "tags"} {
+		apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/git/matching-refs/%s/%s",
+			parsed.Owner, parsed.Repo, kind, url.PathEscape(first))
+		for _, name := range fetchMatchingRefs(apiURL, "refs/"+kind+"/", token) {
+			if parsed.refPath != name && !strings.HasPrefix(parsed.refPath, name+"/") {
+				continue
+			}
+			// A file URL needs at least one path segment after the ref
+			if !parsed.IsDirectory && parsed.refPath == name {
+				continue
+			}
+			if len(name) > len(best) {
+				best = name
+			}
+		}
+	}
+
+	if best == "" || best == parsed.Branch {
+		return false
+	}
+
+	parsed.Branch = best
+	parsed.FilePath = strings.TrimPrefix(strings.TrimPrefix(parsed.refPath, best), "/")
+	return true
+}
+
+// fetchMatchingRefs returns ref names from a matching-refs API call with prefix stripped.
+// Errors are treated as "no matches" since resolution is best-effort.
+func fetchMatchingRefs(apiURL, prefix, token string) []string {
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("User-Agent", "ghex-downloader/1.0")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := doAPI(httpclient.Default(), req)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil
+	}
+
+	var refs []struct {
+		Ref string `json:"ref"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&refs); err != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(refs))
+	for _, r := range refs {
+		names = append(names, strings.TrimPrefix(r.Ref, prefix))
+	}
+	return names
+}
@@ -0,0 +1,104 @@
+package download
+
+import (
+	"testing"
+)
+
+func TestParseGitURL(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected *ParsedGitURL // nil = unsupported
+	}{
+		// GitHub
+		{"https://github.com/owner/repo", &ParsedGitURL{Platform: "github", Host: "github.com", Owner: "owner", Repo: "repo", Branch: "main", IsDirectory: true}},
+		{"github.com/owner/repo.git", &ParsedGitURL{Platform: "github", Host: "github.com", Owner: "owner", Repo: "repo", Branch: "main", IsDirectory: true}},
+		{"https://www.github.com/owner/repo/blob/dev/docs/a.md", &ParsedGitURL{Platform: "github", Host: "github.com", Owner: "owner", Repo: "repo", Branch: "dev", FilePath: "docs/a.md"}},
+		{"https://github.com/owner/repo/tree/v1.0/src", &ParsedGitURL{Platform: "github", Host: "github.com", Owner: "owner", Repo: "repo", Branch: "v1.0", FilePath: "src", IsDirectory: true}},
+		{"https://github.com/owner/repo/blob/feature%2Fx/a.go?raw=1#L10", &ParsedGitURL{Platform: "github", Host: "github.com", Owner: "owner", Repo: "repo", Branch: "feature/x", FilePath: "a.go"}},
+		{"https://raw.githubusercontent.com/owner/repo/main/install.sh", &ParsedGitURL{Platform: "github", Host: "github.com", Owner: "owner", Repo: "repo", Branch: "main", FilePath: "install.sh"}},
+
+		// GitLab, with nested groups
+		{"https://gitlab.com/group/project", &ParsedGitURL{Platform: "gitlab", Host: "gitlab.com", Owner: "group", Repo: "project", Branch: "main", IsDirectory: true}},
+		{"https://gitlab.com/group/sub/deeper/project/-/blob/main/a.md", &ParsedGitURL{Platform: "gitlab", Host: "gitlab.com", Owner: "group/sub/deeper", Repo: "project", Branch: "main", FilePath: "a.md"}},
+		{"https://gitlab.com/group/sub/project/-/tree/v2/docs/api", &ParsedGitURL{Platform: "gitlab", Host: "gitlab.com", Owner: "group/sub", Repo: "project", Branch: "v2", FilePath: "docs/api", IsDirectory: true}},
+		{"https://gitlab.example.com:8443/team/project.git", &ParsedGitURL{Platform: "gitlab", Host: "gitlab.example.com:8443", Owner: "team", Repo: "project", Branch: "main", IsDirectory: true}},
+		{"https://code.example.com/group/sub/project/-/blob/main/docs/a.md", &ParsedGitURL{Platform: "gitlab", Host: "code.example.com", Owner: "group/sub", Repo: "project", Branch: "main", FilePath: "docs/a.md"}},
+		{"https://code.example.com/group/project/-/tree/dev", &ParsedGitURL{Platform: "gitlab", Host: "code.example.com", Owner: "group", Repo: "project", Branch: "dev", IsDirectory: true}},
+
+		// Gitea, Forgejo and Codeberg
+		{"https://codeberg.org/owner/repo", &ParsedGitURL{Platform: "gitea", Host: "codeberg.org", Owner: "owner", Repo: "repo", Branch: "main", IsDirectory: true}},
+		{"https://codeberg.org/owner/repo/src/branch/main", &ParsedGitURL{Platform: "gitea", Host: "codeberg.org", Owner: "owner", Repo: "repo", Branch: "main", IsDirectory: true}},
+		{"https://codeberg.org/owner/repo/src/tag/v1/docs/a.md", &ParsedGitURL{Platform: "gitea", Host: "codeberg.org", Owner: "owner", Repo: "repo", Branch: "v1", FilePath: "docs/a.md"}},
+		{"https://gitea.example.com/owner/repo/raw/commit/abc1234/a.txt", &ParsedGitURL{Platform: "gitea", Host: "gitea.example.com", Owner: "owner", Repo: "repo", Branch: "abc1234", FilePath: "a.txt"}},
+
+		// Bitbucket
+		{"https://bitbucket.org/workspace/repo", &ParsedGitURL{Platform: "bitbucket", Host: "bitbucket.org", Owner: "workspace", Repo: "repo", Branch: "main", IsDirectory: true}},
+		{"https://bitbucket.org/workspace/repo/src/main", &ParsedGitURL{Platform: "bitbucket", Host: "bitbucket.org", Owner: "workspace", Repo: "repo", Branch: "main", IsDirectory: true}},
+		{"https://bitbucket.org/workspace/repo/src/dev/lib/a.py", &ParsedGitURL{Platform: "bitbucket", Host: "bitbucket.org", Owner: "workspace", Repo: "repo", Branch: "dev", FilePath: "lib/a.py"}},
+
+		// Unsupported
+		{"https://example.com/owner/repo", nil},
+		{"https://github.com/owner", nil},
+		{"https://gitlab.com/project", nil},
+		{"https://code.example.com/group/project", nil},
+		{"https://code.example.com/project/-/blob/main/a.md", nil},
+		{"https://code.example.com/group/project/-/issues/1", nil},
+		{"https://raw.githubusercontent.com/owner/repo", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			got, err := parseGitURL(tt.url)
+			if tt.expected == nil {
+				if err == nil {
+					t.Errorf("parseGitURL(%q) = %+v, want an error", tt.url, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseGitURL(%q) error = %v", tt.url, err)
+			}
+			if got.Platform != tt.expected.Platform || got.Host != tt.expected.Host ||
+				got.Owner != tt.expected.Owner || got.Repo != tt.expected.Repo ||
+				got.Branch != tt.expected.Branch || got.FilePath != tt.expected.FilePath ||
+				got.IsDirectory != tt.expected.IsDirectory {
+				t.Errorf("parseGitURL(%q) = %+v, want %+v", tt.url, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseGitURLRegisteredHost(t *testing.T) {
+	t.Cleanup(func() { delete(customHosts, "git.example.com") })
+
+	if _, err := parseGitURL("https://git.example.com/owner/repo"); err == nil {
+		t.Fatal("Expected an unregistered host to be unsupported")
+	}
+
+	RegisterHost("Git.Example.com", "forgejo")
+	got, err := parseGitURL("https://git.example.com:3000/owner/repo/src/branch/main/README.md")
+	if err != nil {
+		t.Fatalf("parseGitURL() error = %v", err)
+	}
+	if got.Platform != "gitea" || got.Host != "git.example.com:3000" || got.FilePath != "README.md" {
+		t.Errorf("parseGitURL() = %+v, want a gitea file URL on git.example.com:3000", got)
+	}
+}
+
+func TestIsRepoPathURL(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected bool
+	}{
+		{"https://github.com/owner/repo/blob/main/a.md", true},
+		{"https://bitbucket.org/workspace/repo/src/main/docs", true},
+		{"https://github.com/owner/repo", false},
+		{"https://example.com/file.tar.gz", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsRepoPathURL(tt.url); got != tt.expected {
+			t.Errorf("IsRepoPathURL(%q) = %v, want %v", tt.url, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package download
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/dwirx/ghex/internal/httpclient"
+)
+
+// SetHeaders sets headers sent with every download and API request, such
+// as a User-Agent or token that a proxy or mirror requires. They replace
+// the headers ghex sets itself.
+func SetHeaders(h http.Header) {
+	httpclient.SetHeaders(h)
+}
+
+// Network holds proxy and TLS settings: a proxy URL (empty =
+// HTTP_PROXY/HTTPS_PROXY, NO_PROXY is honored either way), skipping
+// certificate verification, and a PEM file of extra CA certificates.
+type Network = httpclient.Network
+
+// SetNetwork applies proxy and TLS settings to every download and API
+// request
+func SetNetwork(n Network) error {
+	return httpclient.Configure(n)
+}
+
+// ParseHeaders parses curl-style "Name: value" headers. A header given
+// more than once is sent with every value.
+func ParseHeaders(lines []string) (http.Header, error) {
+	h := http.Header{}
+	for _, line := range lines {
+		name, value, ok := strings.Cut(line, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" || strings.ContainsAny(name, " \t") {
+			return nil, fmt.Errorf("invalid header %q (expected \"Name: value\")", line)
+		}
+		h.Add(name, strings.TrimSpace(value))
+	}
+	return h, nil
+}
@@ -0,0 +1,372 @@
+package download
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dwirx/ghex/internal/platform"
+	"github.com/dwirx/ghex/internal/ui"
+	"github.com/dwirx/ghex/internal/update"
+)
+
+// InstalledTool records a release binary installed by dlx.
+type InstalledTool struct {
+	Name        string    `json:"name"`
+	Repo        string    `json:"repo"`                 // owner/repo (GitLab: group/.../project)
+	Host        string    `json:"host,omitempty"`       // Empty = github.com
+	Version     string    `json:"version"`              // Installed release tag
+	Constraint  string    `json:"constraint,omitempty"` // Range upgrades must stay within (empty = latest)
+	Asset       string    `json:"asset"`                // Glob used to pick the asset on upgrade
+	Binary      string    `json:"binary,omitempty"`     // File extracted from an archive asset
+	Path        string    `json:"path"`
+	Attested    bool      `json:"attested,omitempty"` // Upgrades also require an artifact attestation
+	InstalledAt time.Time `json:"installedAt"`
+}
+
+// OutdatedTool pairs an installed tool with the newest matching release.
+type OutdatedTool struct {
+	Tool   InstalledTool
+	Latest string // Newest release tag allowed by the tool's constraint
+}
+
+// installedStatePath returns the path of the installed tools state file.
+func installedStatePath() string {
+	return filepath.Join(platform.GetConfigDir("ghe"), "tools.json")
+}
+
+// ListInstalled returns installed tools sorted by name.
+func ListInstalled() ([]InstalledTool, error) {
+	state, err := loadInstalled()
+	if err != nil {
+		return nil, err
+	}
+
+	tools := make([]InstalledTool, 0, len(state))
+	for _, t := range state {
+		tools = append(tools, t)
+	}
+	sort.Slice(tools, func(i, j int) bool { return tools[i].Name < tools[j].Name })
+	return tools, nil
+}
+
+func loadInstalled() (map[string]InstalledTool, error) {
+	state := map[string]InstalledTool{}
+	data, err := os.ReadFile(installedStatePath())
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read installed tools: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse installed tools: %w", err)
+	}
+	return state, nil
+}
+
+// recordInstall adds or replaces a tool in the state file.
+func recordInstall(tool InstalledTool) error {
+	state, err := loadInstalled()
+	if err != nil {
+		return err
+	}
+	tool.InstalledAt = time.Now()
+	state[tool.Name] = tool
+	return saveInstalled(state)
+}
+
+// saveInstalled writes the state file.
+func saveInstalled(state map[string]InstalledTool) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	statePath := installedStatePath()
+	if err := os.MkdirAll(filepath.Dir(statePath), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(statePath, data, 0644)
+}
+
+// RemoveInstalled deletes an installed tool's binary and forgets the tool.
+// A binary that is already gone is not an error.
+func RemoveInstalled(name string) (InstalledTool, error) {
+	state, err := loadInstalled()
+	if err != nil {
+		return InstalledTool{}, err
+	}
+	tool, ok := state[name]
+	if !ok {
+		return InstalledTool{}, fmt.Errorf("%s is not installed with ghex", name)
+	}
+
+	if err := os.Remove(tool.Path); err != nil && !os.IsNotExist(err) {
+		return tool, fmt.Errorf("failed to remove %s: %w", tool.Path, err)
+	}
+	delete(state, name)
+	return tool, saveInstalled(state)
+}
+
+// CheckOutdated resolves the newest allowed release for each tool and
+// returns the ones whose installed tag differs.
+func CheckOutdated(tools []InstalledTool, token string) ([]OutdatedTool, []error) {
+	var outdated []OutdatedTool
+	var errs []error
+	for _, t := range tools {
+		latest, err := latestTag(t, token)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", t.Name, err))
+			continue
+		}
+		if latest != t.Version {
+			outdated = append(outdated, OutdatedTool{Tool: t, Latest: latest})
+		}
+	}
+	return outdated, errs
+}
+
+// UpgradeTool installs the newest allowed release of tool in place.
+func UpgradeTool(tool InstalledTool, token string) (string, error) {
+	parsed, err := tool.repoURL()
+	if err != nil {
+		return "", err
+	}
+	token = releaseToken(parsed, token)
+
+	tag, err := latestTag(tool, token)
+	if err != nil {
+		return "", err
+	}
+	release, err := fetchRelease(parsed, tag, token)
+	if err != nil {
+		return "", err
+	}
+	asset, err := matchManifestAsset(release.Assets, tool.Asset)
+	if err != nil {
+		return "", err
+	}
+
+	var attest *ParsedGitURL
+	if tool.Attested {
+		attest = parsed
+	}
+	if err := installAsset(*asset, tool.Binary, tool.Path, token, attest); err != nil {
+		return "", err
+	}
+
+	tool.Version = release.TagName
+	if err := recordInstall(tool); err != nil {
+		return "", err
+	}
+	return release.TagName, nil
+}
+
+// latestTag returns the newest release tag allowed by the tool's constraint.
+func latestTag(tool InstalledTool, token string) (string, error) {
+	parsed, err := tool.repoURL()
+	if err != nil {
+		return "", err
+	}
+	token = releaseToken(parsed, token)
+
+	tag, err := resolveReleaseTag(parsed, tool.Constraint, releaseFilter{}, token)
+	if err != nil || tag != "" {
+		return tag, err
+	}
+	release, err := fetchRelease(parsed, "", token)
+	if err != nil {
+		return "", err
+	}
+	return release.TagName, nil
+}
+
+// repoURL parses the tool's repository location.
+func (t InstalledTool) repoURL() (*ParsedGitURL, error) {
+	host := t.Host
+	if host == "" {
+		host = "github.com"
+	}
+	return parseGitURL("https://" + host + "/" + t.Repo)
+}
+
+// assetPattern turns an asset name into a glob that still matches after an
+// upgrade, by replacing the version embedded in the name with "*".
+// e.g. "fzf-0.44.1-linux_amd64.tar.gz" for tag "v0.44.1" becomes
+// "fzf-*-linux_amd64.tar.gz".
+func assetPattern(name, tag string) string {
+	for _, v := range []string{tag, strings.TrimPrefix(tag, "v")} {
+		if v != "" && strings.Contains(name, v) {
+			return strings.ReplaceAll(name, v, "*")
+		}
+	}
+	return name
+}
+
+// installAsset downloads a release asset and installs it at target,
+// extracting binary from .tar.gz/.zip assets when set. When attest is set,
+// the asset must have a GitHub artifact attestation from that repository.
+func installAsset(asset ReleaseAsset, binary, target, token string, attest *ParsedGitURL) error {
+	start := time.Now()
+	err := installAssetTo(asset, binary, target, token, attest)
+	// Record the installed binary rather than the temporary download
+	recordFile(asset.BrowserDownloadURL, target, start, err)
+	return err
+}
+
+// installAssetTo implements installAsset
+func installAssetTo(asset ReleaseAsset, binary, target, token string, attest *ParsedGitURL) error {
+	tmpDir, err := os.MkdirTemp("", "ghex-release-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	err = resumable(asset.BrowserDownloadURL, asset.Name, filepath.Join(tmpDir, asset.Name), ResumableOptions{
+		OutputDir:    tmpDir,
+		Overwrite:    true,
+		Token:        token,
+		ExpectedSize: asset.Size,
+		ShowProgress: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	src := filepath.Join(tmpDir, asset.Name)
+	if attest != nil {
+		if err := verifyAttestation(attest, src, token); err != nil {
+			return err
+		}
+	}
+	if binary != "" && isArchive(asset.Name) {
+		if src, err = extractFile(src, binary, tmpDir); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := WriteAtomic(target, f); err != nil {
+		return err
+	}
+	if runtime.GOOS != "windows" {
+		return os.Chmod(target, 0755)
+	}
+	return nil
+}
+
+// verifyAttestation requires a GitHub artifact attestation for a downloaded
+// release asset.
+func verifyAttestation(parsed *ParsedGitURL, file, token string) error {
+	if parsed.Platform != "github" {
+		return fmt.Errorf("artifact attestations are only available for GitHub releases")
+	}
+	if !update.CanVerifyAttestationSignature() {
+		ui.ShowWarning("gh is not installed: only checking that GitHub has an attestation for the asset, not its signature")
+	}
+
+	client := update.NewGitHubClient()
+	client.Token = token
+	return client.VerifyAttestation(file, parsed.Owner, parsed.Repo)
+}
+
+// constraintOf returns version when it is a range worth keeping for
+// upgrades; "latest" and exact tags upgrade to the latest release.
+func constraintOf(version string) string {
+	if update.IsConstraint(version) {
+		return strings.TrimSpace(version)
+	}
+	return ""
+}
+
+// DefaultBinDir returns the default install directory for ghex install and
+// dlx --install.
+func DefaultBinDir() string {
+	if platform.IsWindows() {
+		return filepath.Join(platform.GetHomeDir(), "AppData", "Local", "Programs", "ghex", "bin")
+	}
+	return filepath.Join(platform.GetHomeDir(), ".local", "bin")
+}
+
+// installReleaseAsset installs one release asset as a binary and records it
+// so `ghex outdated` and `ghex upgrade` can track it.
+func installReleaseAsset(parsed *ParsedGitURL, release *Release, assets []ReleaseAsset, opts ReleaseOptions, token string) error {
+	asset := &assets[0]
+	if len(assets) > 1 {
+		choice := ui.Prompt("Select asset to install (number)")
+		var idx int
+		_, _ = fmt.Sscanf(choice, "%d", &idx)
+		if idx < 1 || idx > len(assets) {
+			return fmt.Errorf("invalid selection")
+		}
+		asset = &assets[idx-1]
+	}
+
+	name := opts.Binary
+	if name == "" {
+		name = parsed.Repo
+	}
+	binary := ""
+	if isArchive(asset.Name) {
+		binary = name
+	}
+
+	binDir := opts.BinDir
+	if binDir == "" {
+		binDir = DefaultBinDir()
+	}
+	binDir = platform.ExpandPath(binDir)
+	target := filepath.Join(binDir, name)
+	if runtime.GOOS == "windows" && !strings.HasSuffix(target, ".exe") {
+		target += ".exe"
+	}
+
+	if !opts.Overwrite && platform.FileExists(target) {
+		if installed, _ := loadInstalled(); installed[name].Path != target {
+			return &ErrFileExists{Path: target}
+		}
+	}
+
+	var attest *ParsedGitURL
+	if opts.RequireAttestation {
+		attest = parsed
+	}
+	if err := installAsset(*asset, binary, target, token, attest); err != nil {
+		return err
+	}
+
+	err := recordInstall(InstalledTool{
+		Name:       name,
+		Repo:       parsed.FullPath(),
+		Host:       hostOf(parsed),
+		Version:    release.TagName,
+		Constraint: constraintOf(opts.Version),
+		Asset:      assetPattern(asset.Name, release.TagName),
+		Binary:     binary,
+		Path:       target,
+		Attested:   opts.RequireAttestation,
+	})
+	if err != nil {
+		ui.ShowWarning(fmt.Sprintf("Installed, but failed to record %s: %v", name, err))
+	}
+
+	ui.ShowSuccess(fmt.Sprintf("Installed %s %s → %s", name, release.TagName, target))
+	return nil
+}
+
+// hostOf returns the host to record for a tool, omitting the github.com default.
+func hostOf(parsed *ParsedGitURL) string {
+	if parsed.Host == "github.com" {
+		return ""
+	}
+	return parsed.Host
+}
@@ -0,0 +1,290 @@
+package download
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dwirx/ghex/internal/platform"
+	"github.com/dwirx/ghex/internal/ui"
+)
+
+// Kinds of multi-file downloads recorded as jobs.
+const (
+	JobDir     = "dir"
+	JobRelease = "release"
+)
+
+// jobTTL is how long an unfinished job is offered to 'dlx --continue'.
+const jobTTL = 30 * 24 * time.Hour
+
+// JobFile is a file of a job.
+type JobFile struct {
+	Path   string `json:"path"` // Output path relative to the job's OutputDir
+	URL    string `json:"url"`
+	Size   int64  `json:"size,omitempty"`   // Expected size (0 = unknown)
+	Status string `json:"status"`           // "pending", "done" or "failed"
+	Offset int64  `json:"offset,omitempty"` // Bytes of partial data kept when the file last failed
+	Error  string `json:"error,omitempty"`
+}
+
+// Job is the state of a directory or release download, written as files
+// finish, so an interrupted or partly failed run can be continued with
+// ContinueJob. Partial data of unfinished files is kept in ".part" files
+// next to their output path and resumed from its end.
+type Job struct {
+	ID        string    `json:"id"`
+	Kind      string    `json:"kind"`          // JobDir or JobRelease
+	Source    string    `json:"source"`        // URL the files come from
+	Ref       string    `json:"ref,omitempty"` // Branch or release tag
+	OutputDir string    `json:"outputDir"`     // Absolute output directory
+	Files     []JobFile `json:"files"`
+	Updated   time.Time `json:"updated"`
+
+	// Release jobs unpack archives like the run that started them
+	Extract         bool `json:"extract,omitempty"`
+	StripComponents int  `json:"stripComponents,omitempty"`
+
+	mu    sync.Mutex
+	index map[string]int // Files by Path
+}
+
+// jobsDir returns the directory job files are kept in.
+func jobsDir() string {
+	return filepath.Join(platform.GetCacheDir("ghex"), "dlx-jobs")
+}
+
+// jobID identifies the download of source into outputDir, so running the
+// same download again picks up its job.
+func jobID(kind, source, outputDir string) string {
+	sum := sha256.Sum256([]byte(kind + "\x00" + source + "\x00" + outputDir))
+	return hex.EncodeToString(sum[:8])
+}
+
+// startJob returns the job of downloading files from source into outputDir
+// and writes its state. A job left by an earlier run of the same download
+// keeps the files recorded as done, unless overwrite is set.
+func startJob(kind, source, ref, outputDir string, files []JobFile, overwrite bool) *Job {
+	if abs, err := filepath.Abs(outputDir); err == nil {
+		outputDir = abs
+	}
+	job := &Job{
+		ID:        jobID(kind, source, outputDir),
+		Kind:      kind,
+		Source:    source,
+		Ref:       ref,
+		OutputDir: outputDir,
+		Files:     files,
+	}
+
+	var done map[string]bool
+	if old, err := loadJob(job.path()); err == nil && old.Ref == ref && !overwrite {
+		done = map[string]bool{}
+		for _, f := range old.Files {
+			if f.Status == "done" {
+				done[f.Path] = true
+			}
+		}
+	}
+	for i := range job.Files {
+		job.Files[i].Status = "pending"
+		if done[job.Files[i].Path] && job.fileDone(job.Files[i]) {
+			job.Files[i].Status = "done"
+		}
+	}
+	job.save()
+	return job
+}
+
+// loadJob reads a job file.
+func loadJob(path string) (*Job, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	job := &Job{}
+	if err := json.Unmarshal(data, job); err != nil {
+		return nil, fmt.Errorf("invalid job file %s: %w", path, err)
+	}
+	return job, nil
+}
+
+// ListJobs returns the unfinished jobs, most recently updated first. Jobs
+// untouched for 30 days are deleted.
+func ListJobs() ([]*Job, error) {
+	entries, err := os.ReadDir(jobsDir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []*Job
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		path := filepath.Join(jobsDir(), e.Name())
+		job, err := loadJob(path)
+		if err != nil || time.Since(job.Updated) > jobTTL {
+			os.Remove(path)
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Updated.After(jobs[j].Updated) })
+	return jobs, nil
+}
+
+// path returns the job's state file.
+func (j *Job) path() string {
+	return filepath.Join(jobsDir(), j.ID+".json")
+}
+
+// outputPath returns where f is written.
+func (j *Job) outputPath(f JobFile) string {
+	return filepath.Join(j.OutputDir, filepath.FromSlash(f.Path))
+}
+
+// fileDone reports whether f's output file is complete on disk.
+func (j *Job) fileDone(f JobFile) bool {
+	info, err := os.Stat(j.outputPath(f))
+	return err == nil && (f.Size <= 0 || info.Size() == f.Size)
+}
+
+// Pending returns the files not downloaded yet.
+func (j *Job) Pending() []JobFile {
+	var pending []JobFile
+	for _, f := range j.Files {
+		if f.Status != "done" {
+			pending = append(pending, f)
+		}
+	}
+	return pending
+}
+
+// Remaining returns the number of files not downloaded yet and the bytes
+// they still need, counting the partial data kept for them. The bytes are
+// 0 when the sizes are unknown.
+func (j *Job) Remaining() (int, int64) {
+	pending := j.Pending()
+	var bytes int64
+	for _, f := range pending {
+		if f.Size <= 0 {
+			continue
+		}
+		bytes += f.Size
+		if info, err := os.Stat(j.outputPath(f) + partSuffix); err == nil && info.Size() <= f.Size {
+			bytes -= info.Size()
+		}
+	}
+	return len(pending), bytes
+}
+
+// record stores the outcome of the file at path (relative to OutputDir)
+// and writes the state, so files finished before an interruption are not
+// downloaded again. A file that already existed counts as done.
+func (j *Job) record(path string, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.index == nil {
+		j.index = make(map[string]int, len(j.Files))
+		for i, f := range j.Files {
+			j.index[f.Path] = i
+		}
+	}
+	i, ok := j.index[path]
+	if !ok {
+		return
+	}
+
+	f := &j.Files[i]
+	var exists *ErrFileExists
+	if err == nil || errors.As(err, &exists) {
+		f.Status, f.Offset, f.Error = "done", 0, ""
+	} else {
+		f.Status, f.Error = "failed", err.Error()
+		f.Offset = 0
+		if info, statErr := os.Stat(j.outputPath(*f) + partSuffix); statErr == nil {
+			f.Offset = info.Size()
+		}
+	}
+	j.saveLocked()
+}
+
+// finish deletes the state once every file is done. Otherwise it keeps it
+// and tells how to continue.
+func (j *Job) finish() {
+	if len(j.Pending()) == 0 {
+		os.Remove(j.path())
+		return
+	}
+	ui.ShowInfo("Run 'ghex dlx --continue' to retry the remaining files")
+}
+
+// save writes the state file.
+func (j *Job) save() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.saveLocked()
+}
+
+func (j *Job) saveLocked() {
+	j.Updated = time.Now().UTC()
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err == nil {
+		// File URLs of private repositories can carry access tokens
+		err = os.MkdirAll(jobsDir(), 0700)
+	}
+	if err == nil {
+		err = os.WriteFile(j.path(), data, 0600)
+	}
+	if err != nil {
+		ui.ShowWarning(fmt.Sprintf("Failed to write download state: %v", err))
+	}
+}
+
+// ContinueJob downloads the files a job hasn't finished, resuming partial
+// files from where they stopped. token is used for files of private
+// repositories; empty falls back to the host's token variable.
+func ContinueJob(job *Job, token string) error {
+	parsed, err := parseGitURL(job.Source)
+	if err != nil {
+		return err
+	}
+	token = releaseToken(parsed, token)
+
+	pending := job.Pending()
+	ui.ShowSection("Continuing Download")
+	ui.ShowKeyValue("Source", job.Source)
+	if job.Ref != "" {
+		ui.ShowKeyValue("Ref", job.Ref)
+	}
+	ui.ShowKeyValue("Output", job.OutputDir)
+	ui.ShowKeyValue("Files", fmt.Sprintf("%d of %d left", len(pending), len(job.Files)))
+	fmt.Println()
+
+	if job.Kind == JobRelease {
+		err := downloadReleaseFiles(job, pending, job.OutputDir, false, token)
+		job.finish()
+		return err
+	}
+
+	files := make([]TreeFile, len(pending))
+	for i, f := range pending {
+		files[i] = TreeFile{Path: f.Path, URL: f.URL, Size: f.Size}
+	}
+	result, _ := downloadFiles(files, "", job.OutputDir, false, 0, token, job)
+	err = result.finish(job.OutputDir)
+	job.finish()
+	return err
+}
@@ -0,0 +1,251 @@
+package download
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// useTempJobsDir keeps job files of a test in a temporary cache directory.
+func useTempJobsDir(t *testing.T) {
+	t.Helper()
+	cache := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cache)
+	t.Setenv("LOCALAPPDATA", cache)
+	t.Setenv("GITHUB_TOKEN", "")
+}
+
+func TestStartJob(t *testing.T) {
+	useTempJobsDir(t)
+	out := t.TempDir()
+	if err := os.WriteFile(filepath.Join(out, "a.txt"), []byte("aaa"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	files := func() []JobFile {
+		return []JobFile{{Path: "a.txt", URL: "https://example.com/a", Size: 3}, {Path: "b.txt", URL: "https://example.com/b"}}
+	}
+
+	job := startJob(JobDir, "https://github.com/owner/repo/tree/main/docs", "main", out, files(), false)
+	job.record("a.txt", nil)
+	job.record("b.txt", errors.New("connection reset"))
+
+	tests := []struct {
+		name      string
+		ref       string
+		overwrite bool
+		setup     func()
+		wantDone  bool // a.txt carried over as done
+	}{
+		{name: "same download", ref: "main", wantDone: true},
+		{name: "overwrite", ref: "main", overwrite: true},
+		{name: "other ref", ref: "dev"},
+		{name: "file changed on disk", ref: "main", setup: func() {
+			os.WriteFile(filepath.Join(out, "a.txt"), []byte("a"), 0644)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setup != nil {
+				tt.setup()
+			}
+			again := startJob(JobDir, job.Source, tt.ref, out, files(), tt.overwrite)
+			if again.ID != job.ID {
+				t.Errorf("ID = %s, want %s for the same source and output", again.ID, job.ID)
+			}
+			if got := again.Files[0].Status == "done"; got != tt.wantDone {
+				t.Errorf("a.txt done = %v, want %v", got, tt.wantDone)
+			}
+			if again.Files[1].Status != "pending" {
+				t.Errorf("b.txt status = %q, want pending", again.Files[1].Status)
+			}
+			// Restore the state the next case starts from
+			job.record("a.txt", nil)
+		})
+	}
+}
+
+func TestJobRecord(t *testing.T) {
+	useTempJobsDir(t)
+	out := t.TempDir()
+	job := startJob(JobRelease, "https://github.com/owner/repo", "v1", out, []JobFile{
+		{Path: "a.tar.gz", Size: 10},
+		{Path: "b.zip", Size: 10},
+		{Path: "c.txt"},
+	}, false)
+
+	if err := os.WriteFile(filepath.Join(out, "b.zip"+partSuffix), []byte("1234"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	job.record("a.tar.gz", nil)
+	job.record("b.zip", errors.New("download interrupted"))
+	job.record("c.txt", &ErrFileExists{Path: "c.txt"})
+	job.record("unknown", nil)
+
+	saved, err := loadJob(job.path())
+	if err != nil {
+		t.Fatalf("loadJob() error = %v", err)
+	}
+	want := []JobFile{
+		{Path: "a.tar.gz", Size: 10, Status: "done"},
+		{Path: "b.zip", Size: 10, Status: "failed", Offset: 4, Error: "download interrupted"},
+		{Path: "c.txt", Status: "done"},
+	}
+	if len(saved.Files) != len(want) {
+		t.Fatalf("Saved %d files, want %d", len(saved.Files), len(want))
+	}
+	for i, f := range saved.Files {
+		if f != want[i] {
+			t.Errorf("File %d = %+v, want %+v", i, f, want[i])
+		}
+	}
+
+	if n, size := saved.Remaining(); n != 1 || size != 6 {
+		t.Errorf("Remaining() = %d, %d, want 1 file and 6 bytes", n, size)
+	}
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(job.path())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if perm := info.Mode().Perm(); perm != 0600 {
+			t.Errorf("Job file mode = %o, want 600", perm)
+		}
+	}
+}
+
+func TestJobFinish(t *testing.T) {
+	useTempJobsDir(t)
+	job := startJob(JobDir, "https://github.com/owner/repo", "main", t.TempDir(), []JobFile{{Path: "a"}, {Path: "b"}}, false)
+
+	job.record("a", nil)
+	job.record("b", errors.New("timeout"))
+	job.finish()
+	if _, err := os.Stat(job.path()); err != nil {
+		t.Fatalf("Expected the job to be kept while files are pending: %v", err)
+	}
+
+	job.record("b", nil)
+	job.finish()
+	if _, err := os.Stat(job.path()); !os.IsNotExist(err) {
+		t.Errorf("Expected the finished job to be deleted, got %v", err)
+	}
+}
+
+func TestListJobs(t *testing.T) {
+	useTempJobsDir(t)
+	if jobs, err := ListJobs(); err != nil || len(jobs) != 0 {
+		t.Fatalf("ListJobs() without jobs = %v, %v", jobs, err)
+	}
+
+	older := startJob(JobDir, "https://github.com/owner/a", "main", t.TempDir(), []JobFile{{Path: "x"}}, false)
+	time.Sleep(10 * time.Millisecond)
+	newer := startJob(JobDir, "https://github.com/owner/b", "main", t.TempDir(), []JobFile{{Path: "x"}}, false)
+
+	expired := &Job{ID: "expired", Kind: JobDir, Updated: time.Now().Add(-jobTTL - time.Hour)}
+	data, _ := json.Marshal(expired)
+	if err := os.WriteFile(expired.path(), data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(jobsDir(), "broken.json"), []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	jobs, err := ListJobs()
+	if err != nil {
+		t.Fatalf("ListJobs() error = %v", err)
+	}
+	if len(jobs) != 2 || jobs[0].ID != newer.ID || jobs[1].ID != older.ID {
+		t.Errorf("ListJobs() = %d jobs, want the newer job before the older one", len(jobs))
+	}
+	for _, name := range []string{"expired.json", "broken.json"} {
+		if _, err := os.Stat(filepath.Join(jobsDir(), name)); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be deleted", name)
+		}
+	}
+}
+
+func TestContinueJob(t *testing.T) {
+	content := testContent(40000)
+
+	tests := []struct {
+		name      string
+		kind      string
+		broken    bool // c.bin is missing on the server
+		wantRange string
+		wantErr   bool
+		wantKept  bool // job still on disk afterwards
+	}{
+		{name: "dir resumes partial file", kind: JobDir, wantRange: "bytes=1000-"},
+		{name: "release resumes partial file", kind: JobRelease, wantRange: "bytes=1000-"},
+		{name: "release with missing asset", kind: JobRelease, broken: true, wantRange: "bytes=1000-", wantErr: true, wantKept: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useTempJobsDir(t)
+			srv := newFileServer(t, content, func(w http.ResponseWriter, r *http.Request) bool {
+				if r.URL.Path == "/c.bin" && tt.broken {
+					http.NotFound(w, r)
+					return true
+				}
+				return false
+			}, true)
+
+			out := t.TempDir()
+			size := int64(len(content))
+			job := startJob(tt.kind, "https://github.com/owner/repo", "v1", out, []JobFile{
+				{Path: "a.bin", URL: srv.URL + "/a.bin", Size: size},
+				{Path: "b.bin", URL: srv.URL + "/b.bin", Size: size},
+				{Path: "c.bin", URL: srv.URL + "/c.bin", Size: size},
+			}, false)
+			if err := os.WriteFile(filepath.Join(out, "a.bin"), content, 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(out, "b.bin"+partSuffix), content[:1000], 0644); err != nil {
+				t.Fatal(err)
+			}
+			job.record("a.bin", nil)
+			job.record("b.bin", errors.New("interrupted"))
+			job.record("c.bin", errors.New("interrupted"))
+
+			loaded, err := loadJob(job.path())
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = ContinueJob(loaded, "")
+
+			var incomplete *ErrIncomplete
+			if tt.wantErr {
+				if !errors.As(err, &incomplete) || incomplete.Failed != 1 || incomplete.Total != 2 {
+					t.Fatalf("ContinueJob() error = %v, want 1 of 2 files failed", err)
+				}
+			} else if err != nil {
+				t.Fatalf("ContinueJob() error = %v", err)
+			}
+
+			if ranges := srv.rangeHeaders(); len(ranges) != 1 || ranges[0] != tt.wantRange {
+				t.Errorf("Range headers = %v, want [%s]", ranges, tt.wantRange)
+			}
+			if got, _ := os.ReadFile(filepath.Join(out, "b.bin")); len(got) != len(content) {
+				t.Errorf("b.bin has %d bytes, want %d", len(got), len(content))
+			}
+
+			saved, err := loadJob(job.path())
+			if (err == nil) != tt.wantKept {
+				t.Fatalf("Job kept = %v, want %v", err == nil, tt.wantKept)
+			}
+			if tt.wantKept {
+				if pending := saved.Pending(); len(pending) != 1 || pending[0].Path != "c.bin" || pending[0].Status != "failed" {
+					t.Errorf("Pending() = %+v, want c.bin failed", pending)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package download
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/dwirx/ghex/internal/platform"
+	"github.com/dwirx/ghex/internal/ui"
+	"gopkg.in/yaml.v3"
+)
+
+// Manifest lists release binaries to install and keep up to date.
+//
+//	dir: ~/.local/bin
+//	tools:
+//	  - repo: junegunn/fzf
+//	    version: "^0.44"
+//	    asset: "*{os}_{arch}.tar.gz"
+//	    binary: fzf
+type Manifest struct {
+	Dir   string         `yaml:"dir"`   // Default install directory
+	Tools []ManifestTool `yaml:"tools"` // Tools to install
+}
+
+// ManifestTool is a single release binary in a manifest.
+type ManifestTool struct {
+	Repo    string `yaml:"repo"`    // owner/repo or GitHub URL
+	Version string `yaml:"version"` // "latest", an exact tag, or a constraint like "^1.2"
+	Asset   string `yaml:"asset"`   // Glob or substring; {os} and {arch} are replaced
+	Binary  string `yaml:"binary"`  // File to extract when the asset is an archive
+	Path    string `yaml:"path"`    // Install path (default: dir/binary or dir/asset)
+}
+
+// ManifestOptions configures a manifest run.
+type ManifestOptions struct {
+	Token    string // GitHub personal access token
+	Force    bool   // Reinstall even when the lock file says a tool is current
+	ListOnly bool   // Only show what would be installed
+}
+
+// manifestLockEntry records what was installed for a tool.
+type manifestLockEntry struct {
+	Tag   string `json:"tag"`
+	Asset string `json:"asset"`
+	Path  string `json:"path"`
+}
+
+// LoadManifest reads a manifest file.
+func LoadManifest(manifestPath string) (*Manifest, error) {
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	var m Manifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	for i, t := range m.Tools {
+		if t.Repo == "" {
+			return nil, fmt.Errorf("tool #%d: repo is required", i+1)
+		}
+		if t.Asset == "" {
+			return nil, fmt.Errorf("%s: asset is required", t.Repo)
+		}
+	}
+	return &m, nil
+}
+
+// GitReleaseManifest installs every tool in a manifest, skipping tools whose
+// resolved release is already installed. Installed tags are recorded in a
+// "<manifest>.lock" file next to the manifest.
+func GitReleaseManifest(manifestPath string, opts ManifestOptions) error {
+	m, err := LoadManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+
+	// Tokens default per host (GITHUB_TOKEN / GITLAB_TOKEN) in installManifestTool
+	token := opts.Token
+
+	lockPath := manifestPath + ".lock"
+	lock := map[string]manifestLockEntry{}
+	if data, err := os.ReadFile(lockPath); err == nil {
+		_ = json.Unmarshal(data, &lock)
+	}
+
+	dir := m.Dir
+	if dir == "" {
+		dir = "."
+	}
+	dir = platform.ExpandPath(dir)
+
+	ui.ShowSection("Release Manifest")
+	ui.ShowKeyValue("Manifest", manifestPath)
+	ui.ShowKeyValue("Tools", fmt.Sprintf("%d", len(m.Tools)))
+	fmt.Println()
+
+	installed, current, failed := 0, 0, 0
+	for _, tool := range m.Tools {
+		entry, err := installManifestTool(tool, dir, lock[tool.Repo], opts, token)
+		switch {
+		case err != nil:
+			ui.ShowError(fmt.Sprintf("%s: %v", tool.Repo, err))
+			failed++
+		case entry == nil:
+			current++
+		default:
+			lock[tool.Repo] = *entry
+			installed++
+		}
+	}
+	fmt.Println()
+
+	if !opts.ListOnly && installed > 0 {
+		data, err := json.MarshalIndent(lock, "", "  ")
+		if err == nil {
+			err = os.WriteFile(lockPath, data, 0644)
+		}
+		if err != nil {
+			ui.ShowWarning(fmt.Sprintf("Failed to write lock file: %v", err))
+		}
+	}
+
+	verb := "installed"
+	if opts.ListOnly {
+		verb = "to install"
+	}
+	ui.ShowSuccess(fmt.Sprintf("%d %s, %d up to date, %d failed", installed, verb, current, failed))
+	if failed > 0 {
+		return fmt.Errorf("%d tools failed", failed)
+	}
+	return nil
+}
+
+// installManifestTool installs one tool. It returns nil, nil when the tool
+// is already up to date.
+func installManifestTool(tool ManifestTool, dir string, prev manifestLockEntry, opts ManifestOptions, token string) (*manifestLockEntry, error) {
+	repoURL := tool.Repo
+	if sh, ok := ParseShorthand(repoURL); ok {
+		repoURL = sh.RepoURL()
+	}
+	parsed, err := parseGitURL(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	token = releaseToken(parsed, token)
+
+	tag, err := resolveReleaseTag(parsed, tool.Version, releaseFilter{}, token)
+	if err != nil {
+		return nil, err
+	}
+	release, err := fetchRelease(parsed, tag, token)
+	if err != nil {
+		return nil, err
+	}
+
+	asset, err := matchManifestAsset(release.Assets, tool.Asset)
+	if err != nil {
+		return nil, err
+	}
+
+	target := tool.Path
+	if target == "" {
+		name := tool.Binary
+		if name == "" {
+			name = asset.Name
+		}
+		target = filepath.Join(dir, name)
+	}
+	target = platform.ExpandPath(target)
+
+	if !opts.Force && prev.Tag == release.TagName && prev.Asset == asset.Name && platform.FileExists(target) {
+		fmt.Printf("  %s %s %s\n", ui.Dim("="), tool.Repo, ui.Dim(release.TagName))
+		return nil, nil
+	}
+
+	from := prev.Tag
+	if from == "" {
+		from = "new"
+	}
+	fmt.Printf("  %s %s %s → %s (%s)\n", ui.Primary("↓"), tool.Repo, ui.Dim(from), release.TagName, asset.Name)
+	if opts.ListOnly {
+		return &manifestLockEntry{Tag: release.TagName, Asset: asset.Name, Path: target}, nil
+	}
+
+	if err := installAsset(*asset, tool.Binary, target, token, nil); err != nil {
+		return nil, err
+	}
+
+	// Track the install so `ghex outdated` covers manifest tools too
+	name := tool.Binary
+	if name == "" {
+		name = parsed.Repo
+	}
+	err = recordInstall(InstalledTool{
+		Name:       name,
+		Repo:       parsed.FullPath(),
+		Host:       hostOf(parsed),
+		Version:    release.TagName,
+		Constraint: constraintOf(tool.Version),
+		Asset:      assetPattern(asset.Name, release.TagName),
+		Binary:     tool.Binary,
+		Path:       target,
+	})
+	if err != nil {
+		ui.ShowWarning(fmt.Sprintf("Failed to record %s: %v", name, err))
+	}
+
+	return &manifestLockEntry{Tag: release.TagName, Asset: asset.Name, Path: target}, nil
+}
+
+// matchManifestAsset finds the single asset matching pattern. Patterns
+// containing glob characters use path.Match; others match as a substring.
+func matchManifestAsset(assets []ReleaseAsset, pattern string) (*ReleaseAsset, error) {
+	pattern = strings.NewReplacer("{os}", runtime.GOOS, "{arch}", runtime.GOARCH).Replace(pattern)
+	isGlob := strings.ContainsAny(pattern, "*?[")
+
+	var matches []ReleaseAsset
+	for _, a := range assets {
+		var ok bool
+		if isGlob {
+			ok, _ = path.Match(pattern, a.Name)
+		} else {
+			ok = strings.Contains(strings.ToLower(a.Name), strings.ToLower(pattern))
+		}
+		if ok {
+			matches = append(matches, a)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no asset matches %q", pattern)
+	case 1:
+		return &matches[0], nil
+	default:
+		names := make([]string, len(matches))
+		for i, a := range matches {
+			names[i] = a.Name
+		}
+		return nil, fmt.Errorf("asset pattern %q is ambiguous: %s", pattern, strings.Join(names, ", "))
+	}
+}
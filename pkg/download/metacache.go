@@ -0,0 +1,171 @@
+package download
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dwirx/ghex/internal/platform"
+)
+
+// How long cached repository metadata is reused. A ref is re-resolved
+// after a few minutes so pushes show up quickly; a listing is keyed by
+// the commit it was made at and never goes stale, the TTL only bounds
+// how long unused entries are trusted.
+const (
+	repoMetaTTL = 24 * time.Hour
+	refTTL      = 5 * time.Minute
+	listingTTL  = 7 * 24 * time.Hour
+)
+
+// repoMeta is the repository metadata kept in the cache
+type repoMeta struct {
+	DefaultBranch string `json:"default_branch"`
+}
+
+// metaCacheEntry is a cached value and when it was fetched
+type metaCacheEntry struct {
+	Fetched time.Time       `json:"fetched"`
+	Value   json.RawMessage `json:"value"`
+}
+
+// metaCachePath returns the cache file of a key. Keys include a hash of the
+// token, so listings of private repositories stay with the token that could
+// read them.
+func metaCachePath(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(platform.GetCacheDir("ghex"), "dlx", hex.EncodeToString(sum[:])+".json")
+}
+
+// loadMeta decodes the cached value of key into v. It reports false when
+// there is none, it is older than ttl or unreadable.
+func loadMeta(key string, ttl time.Duration, v interface{}) bool {
+	data, err := os.ReadFile(metaCachePath(key))
+	if err != nil {
+		return false
+	}
+	var entry metaCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || time.Since(entry.Fetched) >= ttl {
+		return false
+	}
+	return json.Unmarshal(entry.Value, v) == nil
+}
+
+// storeMeta caches v under key. Failures are ignored, the cache only saves
+// API calls.
+func storeMeta(key string, v interface{}) {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	data, err := json.Marshal(metaCacheEntry{Fetched: time.Now(), Value: value})
+	if err != nil {
+		return
+	}
+	path := metaCachePath(key)
+	// Listings can name files of private repositories, keep them private
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".entry-*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		return
+	}
+	os.Rename(tmp.Name(), path)
+}
+
+// repoCacheKey identifies a repository as seen with a token
+func repoCacheKey(parsed *ParsedGitURL, token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return strings.Join([]string{parsed.Platform, parsed.Host, parsed.FullPath(), hex.EncodeToString(sum[:8])}, "\x00")
+}
+
+// fetchRepoMeta returns a repository's metadata, from the cache while it is
+// fresh.
+func fetchRepoMeta(parsed *ParsedGitURL, token string) (*repoMeta, error) {
+	key := "repo\x00" + repoCacheKey(parsed, token)
+	var meta repoMeta
+	if loadMeta(key, repoMetaTTL, &meta) {
+		return &meta, nil
+	}
+
+	p, err := providerFor(parsed)
+	if err != nil {
+		return nil, err
+	}
+	src, ok := p.(metadataSource)
+	if !ok {
+		return nil, fmt.Errorf("repository metadata not supported for %s", parsed.Platform)
+	}
+	fetched, err := src.fetchRepoMeta(parsed, token)
+	if err != nil {
+		return nil, err
+	}
+	meta = *fetched
+	storeMeta(key, meta)
+	return &meta, nil
+}
+
+// cachedDefaultBranch returns the default branch known from an earlier
+// run, without asking the API
+func cachedDefaultBranch(parsed *ParsedGitURL, token string) string {
+	var meta repoMeta
+	if loadMeta("repo\x00"+repoCacheKey(parsed, token), repoMetaTTL, &meta) {
+		return meta.DefaultBranch
+	}
+	return ""
+}
+
+// rememberDefaultBranch records parsed.Branch as the default branch once a
+// download without a ref found it, so the next run starts there instead of
+// guessing main first
+func rememberDefaultBranch(parsed *ParsedGitURL, token string) {
+	if parsed.refExplicit {
+		return
+	}
+	storeMeta("repo\x00"+repoCacheKey(parsed, token), repoMeta{DefaultBranch: parsed.Branch})
+}
+
+// resolveCommit returns the commit parsed.Branch points to, "" when it
+// can't be resolved. Resolutions are cached for refTTL.
+func resolveCommit(parsed *ParsedGitURL, token string) string {
+	if len(parsed.Branch) == 40 && isCommitSHA(parsed.Branch) {
+		return strings.ToLower(parsed.Branch)
+	}
+
+	key := "ref\x00" + repoCacheKey(parsed, token) + "\x00" + parsed.Branch
+	var sha string
+	if loadMeta(key, refTTL, &sha) {
+		return sha
+	}
+
+	if p, err := providerFor(parsed); err == nil {
+		if src, ok := p.(metadataSource); ok {
+			sha = src.commitSHA(parsed, token)
+		}
+	}
+	if sha != "" {
+		storeMeta(key, sha)
+	}
+	return sha
+}
+
+// listingCacheKey identifies a directory listing. The ref is part of the
+// key since file URLs are built with it.
+func listingCacheKey(parsed *ParsedGitURL, commit string, maxDepth int, token string) string {
+	return strings.Join([]string{"tree", repoCacheKey(parsed, token), parsed.Branch, commit, parsed.FilePath, strconv.Itoa(maxDepth)}, "\x00")
+}
@@ -0,0 +1,109 @@
+package download
+
+import (
+	"fmt"
+	"os"
+)
+
+// Provider is a git forge that files, directories and releases can be
+// downloaded from. GitHub, GitLab, Gitea/Forgejo and Bitbucket are built
+// in; RegisterProvider adds others without touching the download commands.
+type Provider interface {
+	// Name identifies the provider in ParsedGitURL.Platform, e.g. "github".
+	Name() string
+
+	// ParseURL parses the URL-decoded path segments of a URL on host, or
+	// returns nil when the URL isn't one of the provider's. Platform and
+	// Host are filled in when left empty; SetRefPath sets the ref and path.
+	ParseURL(host string, segments []string) *ParsedGitURL
+
+	// RawURL returns the URL serving the content of parsed.FilePath at
+	// parsed.Branch.
+	RawURL(parsed *ParsedGitURL) string
+
+	// ListTree lists the files below parsed.FilePath, at most maxDepth
+	// directories deep (0 = unlimited). A missing ref or path is an
+	// *ErrNotFound, so downloads without a ref can fall back to master.
+	ListTree(parsed *ParsedGitURL, maxDepth int, token string) ([]TreeFile, error)
+
+	// Releases lists the releases, newest first. Assets may be left out.
+	Releases(parsed *ParsedGitURL, token string) ([]Release, error)
+
+	// Release fetches a release with its assets by tag (empty = latest).
+	Release(parsed *ParsedGitURL, tag, token string) (*Release, error)
+}
+
+// DirectoryChecker is implemented by providers whose URLs look the same for
+// files and directories, like Gitea's /src/ URLs. GitPath asks it which one
+// a URL points to.
+type DirectoryChecker interface {
+	IsDirectory(parsed *ParsedGitURL, token string) (bool, error)
+}
+
+// TreeFile is a file in a directory listing.
+type TreeFile struct {
+	Path string // Path in the repository
+	URL  string // URL serving the content
+	Size int64  // Size in bytes (0 when the listing doesn't report it)
+	SHA  string // Git blob SHA, empty when the listing doesn't report it
+}
+
+// tokenSource is implemented by providers whose token can come from an
+// environment variable.
+type tokenSource interface {
+	tokenEnv() string
+}
+
+// metadataSource is implemented by providers that can look up the default
+// branch and the commit a ref points to, which the metadata cache uses.
+type metadataSource interface {
+	fetchRepoMeta(parsed *ParsedGitURL, token string) (*repoMeta, error)
+	commitSHA(parsed *ParsedGitURL, token string) string
+}
+
+// archiveSource is implemented by providers serving snapshot archives.
+type archiveSource interface {
+	// archiveURL returns the URL of a .tar.gz of the repository at ref
+	// (empty = default branch).
+	archiveURL(parsed *ParsedGitURL, ref, token string) (string, error)
+}
+
+// providers are asked in order which of them a URL belongs to.
+var providers = []Provider{githubProvider{}, gitlabProvider{}, giteaProvider{}, bitbucketProvider{}}
+
+// RegisterProvider adds a forge to the downloads, replacing a provider of
+// the same name. It is asked before the ones registered earlier, so it can
+// also take over hosts the built-in providers claim. Register providers
+// before downloading, e.g. from an init function.
+func RegisterProvider(p Provider) {
+	kept := []Provider{p}
+	for _, existing := range providers {
+		if existing.Name() != p.Name() {
+			kept = append(kept, existing)
+		}
+	}
+	providers = kept
+}
+
+// providerFor returns the provider of a parsed URL.
+func providerFor(parsed *ParsedGitURL) (Provider, error) {
+	for _, p := range providers {
+		if p.Name() == parsed.Platform {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported platform: %s", parsed.Platform)
+}
+
+// providerTokenEnv returns the token in the provider's environment
+// variable, if it has one.
+func providerTokenEnv(parsed *ParsedGitURL) string {
+	p, err := providerFor(parsed)
+	if err != nil {
+		return ""
+	}
+	if src, ok := p.(tokenSource); ok {
+		return os.Getenv(src.tokenEnv())
+	}
+	return ""
+}
@@ -0,0 +1,196 @@
+package download
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// bitbucketProvider downloads from bitbucket.org. Its API is addressed by
+// workspace and repository slug, which ParsedGitURL keeps as Owner and Repo.
+type bitbucketProvider struct{}
+
+// bitbucketDownloadsTag names the one "release" Bitbucket has: the files
+// uploaded to the repository's Downloads page.
+const bitbucketDownloadsTag = "downloads"
+
+// bitbucketMaxDepth is the listing depth used for unlimited downloads.
+const bitbucketMaxDepth = 100
+
+// bitbucketSrcEntry is an entry of the Bitbucket source API.
+type bitbucketSrcEntry struct {
+	Type string `json:"type"` // "commit_file" or "commit_directory"
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+// bitbucketDownload is a file on the Downloads page.
+type bitbucketDownload struct {
+	Name      string `json:"name"`
+	Size      int64  `json:"size"`
+	CreatedOn string `json:"created_on"`
+	Links     struct {
+		Self struct {
+			Href string `json:"href"`
+		} `json:"self"`
+	} `json:"links"`
+}
+
+func (bitbucketProvider) Name() string { return "bitbucket" }
+
+// ParseURL handles workspace/repo[/src|raw/ref/path...]. Source URLs look
+// the same for files and directories, so only the repository root is known
+// to be a directory.
+func (bitbucketProvider) ParseURL(host string, segments []string) *ParsedGitURL {
+	if host != "bitbucket.org" || len(segments) < 2 {
+		return nil
+	}
+	parsed := &ParsedGitURL{
+		Owner:       segments[0],
+		Repo:        strings.TrimSuffix(segments[1], ".git"),
+		Branch:      "main",
+		IsDirectory: true, // repo root
+	}
+	if len(segments) >= 4 && (segments[2] == "src" || segments[2] == "raw") {
+		parsed.SetRefPath(segments[3:], segments[2] == "src" && len(segments) == 4)
+	}
+	return parsed
+}
+
+// RawURL uses the source API, which unlike the web raw route accepts the
+// token for private repositories.
+func (bitbucketProvider) RawURL(parsed *ParsedGitURL) string {
+	return bitbucketSrcURL(parsed, parsed.FilePath)
+}
+
+// ListTree lists the directory with the source API, which recurses into
+// subdirectories by itself up to max_depth and pages with "next" links.
+// max_depth counts the directory itself as a level. Sizes are reported,
+// blob SHAs are not.
+func (bitbucketProvider) ListTree(parsed *ParsedGitURL, maxDepth int, token string) ([]TreeFile, error) {
+	depth := bitbucketMaxDepth
+	if maxDepth > 0 {
+		depth = maxDepth + 1
+	}
+	apiURL := bitbucketSrcURL(parsed, parsed.FilePath) + "/?pagelen=100&max_depth=" + strconv.Itoa(depth)
+
+	var files []TreeFile
+	for i := 0; i < maxTreePages && apiURL != ""; i++ {
+		var page struct {
+			Values []bitbucketSrcEntry `json:"values"`
+			Next   string              `json:"next"`
+		}
+		if err := getAPIJSON(apiURL, token, &page); err != nil {
+			return nil, err
+		}
+		for _, entry := range page.Values {
+			if entry.Type != "commit_file" {
+				continue
+			}
+			files = append(files, TreeFile{
+				Path: entry.Path,
+				URL:  bitbucketSrcURL(parsed, entry.Path),
+				Size: entry.Size,
+			})
+		}
+		apiURL = page.Next
+	}
+	return files, nil
+}
+
+// Releases lists the Downloads page as a single release, since Bitbucket
+// has no releases of its own.
+func (bitbucketProvider) Releases(parsed *ParsedGitURL, token string) ([]Release, error) {
+	return []Release{{TagName: bitbucketDownloadsTag, Name: "Downloads"}}, nil
+}
+
+// Release returns the files of the Downloads page, newest first.
+func (bitbucketProvider) Release(parsed *ParsedGitURL, tag, token string) (*Release, error) {
+	if tag != "" && tag != bitbucketDownloadsTag {
+		return nil, fmt.Errorf("Bitbucket has no tagged releases, only the repository's Downloads; leave out the version")
+	}
+
+	release := &Release{TagName: bitbucketDownloadsTag, Name: "Downloads"}
+	apiURL := bitbucketRepoAPI(parsed) + "/downloads?pagelen=100"
+	for i := 0; i < maxReleasePages && apiURL != ""; i++ {
+		var page struct {
+			Values []bitbucketDownload `json:"values"`
+			Next   string              `json:"next"`
+		}
+		if err := getAPIJSON(apiURL, token, &page); err != nil {
+			return nil, err
+		}
+		for _, d := range page.Values {
+			if release.PublishedAt == "" {
+				release.PublishedAt = d.CreatedOn
+			}
+			release.Assets = append(release.Assets, ReleaseAsset{Name: d.Name, Size: d.Size, BrowserDownloadURL: d.Links.Self.Href})
+		}
+		apiURL = page.Next
+	}
+	return release, nil
+}
+
+// IsDirectory asks the source API for the path's metadata.
+func (bitbucketProvider) IsDirectory(parsed *ParsedGitURL, token string) (bool, error) {
+	var entry bitbucketSrcEntry
+	if err := getAPIJSON(bitbucketSrcURL(parsed, parsed.FilePath)+"?format=meta", token, &entry); err != nil {
+		return false, err
+	}
+	return entry.Type == "commit_directory", nil
+}
+
+func (bitbucketProvider) tokenEnv() string { return "BITBUCKET_TOKEN" }
+
+// fetchRepoMeta reads the default branch, which Bitbucket calls the main
+// branch.
+func (bitbucketProvider) fetchRepoMeta(parsed *ParsedGitURL, token string) (*repoMeta, error) {
+	var repo struct {
+		MainBranch struct {
+			Name string `json:"name"`
+		} `json:"mainbranch"`
+	}
+	if err := getAPIJSON(bitbucketRepoAPI(parsed), token, &repo); err != nil {
+		return nil, err
+	}
+	return &repoMeta{DefaultBranch: repo.MainBranch.Name}, nil
+}
+
+func (bitbucketProvider) commitSHA(parsed *ParsedGitURL, token string) string {
+	var commit struct {
+		Hash string `json:"hash"`
+	}
+	if getAPIJSON(bitbucketRepoAPI(parsed)+"/commit/"+url.PathEscape(parsed.Branch), token, &commit) != nil {
+		return ""
+	}
+	return commit.Hash
+}
+
+// archiveURL looks up the main branch when no ref is given.
+func (bitbucketProvider) archiveURL(parsed *ParsedGitURL, ref, token string) (string, error) {
+	if ref == "" {
+		meta, err := fetchRepoMeta(parsed, token)
+		if err != nil {
+			return "", err
+		}
+		ref = meta.DefaultBranch
+	}
+	return fmt.Sprintf("https://bitbucket.org/%s/%s/get/%s.tar.gz",
+		url.PathEscape(parsed.Owner), url.PathEscape(parsed.Repo), url.PathEscape(ref)), nil
+}
+
+// bitbucketRepoAPI returns the API base URL for a repository.
+func bitbucketRepoAPI(parsed *ParsedGitURL) string {
+	return fmt.Sprintf("https://api.bitbucket.org/2.0/repositories/%s/%s", url.PathEscape(parsed.Owner), url.PathEscape(parsed.Repo))
+}
+
+// bitbucketSrcURL returns the source API URL of path at the parsed ref,
+// which serves file contents and directory listings.
+func bitbucketSrcURL(parsed *ParsedGitURL, path string) string {
+	apiURL := bitbucketRepoAPI(parsed) + "/src/" + url.PathEscape(parsed.Branch)
+	if path != "" {
+		apiURL += "/" + escapePath(path)
+	}
+	return apiURL
+}
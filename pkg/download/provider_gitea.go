@@ -0,0 +1,74 @@
+package download
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// giteaProvider downloads from Codeberg and other Gitea/Forgejo hosts.
+type giteaProvider struct{}
+
+func (giteaProvider) Name() string { return "gitea" }
+
+func (giteaProvider) ParseURL(host string, segments []string) *ParsedGitURL {
+	if hostPlatform(host) != "gitea" {
+		return nil
+	}
+	return parseGiteaSegments(segments)
+}
+
+// RawURL uses the legacy /raw/<ref>/ route, which accepts branches, tags
+// and commits alike.
+func (giteaProvider) RawURL(parsed *ParsedGitURL) string {
+	return fmt.Sprintf("https://%s/%s/%s/raw/%s/%s",
+		parsed.Host, parsed.Owner, parsed.Repo, escapePath(parsed.Branch), escapePath(parsed.FilePath))
+}
+
+// ListTree walks the Contents API, Gitea has no recursive listing.
+func (giteaProvider) ListTree(parsed *ParsedGitURL, maxDepth int, token string) ([]TreeFile, error) {
+	return walkContents(parsed, maxDepth, token)
+}
+
+func (giteaProvider) Releases(parsed *ParsedGitURL, token string) ([]Release, error) {
+	return listGiteaReleases(parsed, token)
+}
+
+func (giteaProvider) Release(parsed *ParsedGitURL, tag, token string) (*Release, error) {
+	return fetchGiteaRelease(parsed, tag, token)
+}
+
+func (giteaProvider) IsDirectory(parsed *ParsedGitURL, token string) (bool, error) {
+	return giteaIsDirectory(parsed, token)
+}
+
+func (giteaProvider) tokenEnv() string { return "GITEA_TOKEN" }
+
+func (giteaProvider) fetchRepoMeta(parsed *ParsedGitURL, token string) (*repoMeta, error) {
+	var meta repoMeta
+	err := getAPIJSON(giteaRepoAPI(parsed), token, &meta)
+	return &meta, err
+}
+
+func (giteaProvider) commitSHA(parsed *ParsedGitURL, token string) string {
+	var commit struct {
+		SHA string `json:"sha"`
+	}
+	apiURL := giteaRepoAPI(parsed) + "/git/commits/" + url.PathEscape(parsed.Branch) + "?stat=false&files=false&verification=false"
+	if getAPIJSON(apiURL, token, &commit) != nil {
+		return ""
+	}
+	return commit.SHA
+}
+
+// archiveURL looks up the default branch when no ref is given, since the
+// archive endpoint needs one.
+func (giteaProvider) archiveURL(parsed *ParsedGitURL, ref, token string) (string, error) {
+	if ref == "" {
+		meta, err := fetchRepoMeta(parsed, token)
+		if err != nil {
+			return "", err
+		}
+		ref = meta.DefaultBranch
+	}
+	return fmt.Sprintf("%s/archive/%s.tar.gz", giteaRepoAPI(parsed), escapePath(ref)), nil
+}
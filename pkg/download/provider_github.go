@@ -0,0 +1,95 @@
+package download
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dwirx/ghex/internal/ui"
+	"github.com/dwirx/ghex/internal/update"
+)
+
+// githubProvider downloads from github.com.
+type githubProvider struct{}
+
+func (githubProvider) Name() string { return "github" }
+
+// ParseURL handles github.com URLs and raw.githubusercontent.com file URLs.
+func (githubProvider) ParseURL(host string, segments []string) *ParsedGitURL {
+	switch host {
+	case "github.com":
+		return parseGitHubSegments(segments)
+	case "raw.githubusercontent.com":
+		if len(segments) < 4 {
+			return nil
+		}
+		parsed := &ParsedGitURL{Owner: segments[0], Repo: segments[1], Host: "github.com"}
+		parsed.SetRefPath(segments[2:], false)
+		return parsed
+	}
+	return nil
+}
+
+func (githubProvider) RawURL(parsed *ParsedGitURL) string {
+	return fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/%s/%s",
+		parsed.Owner, parsed.Repo, escapePath(parsed.Branch), escapePath(parsed.FilePath))
+}
+
+// ListTree uses one recursive Git Trees call, falling back to walking the
+// Contents API when the tree is truncated.
+func (githubProvider) ListTree(parsed *ParsedGitURL, maxDepth int, token string) ([]TreeFile, error) {
+	files, err := fetchGitHubTree(parsed, maxDepth, token)
+	if err != errTreeTruncated {
+		return files, err
+	}
+	ui.ShowInfo("Repository tree is too large for one listing, walking directories instead...")
+	return walkContents(parsed, maxDepth, token)
+}
+
+func (githubProvider) Releases(parsed *ParsedGitURL, token string) ([]Release, error) {
+	client := update.NewGitHubClient()
+	client.Token = token
+	releases, err := client.GetReleases(parsed.Owner, parsed.Repo, 0)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]Release, len(releases))
+	for i, r := range releases {
+		list[i] = Release{TagName: r.TagName, Name: r.Name, Prerelease: r.Prerelease, Draft: r.Draft}
+		if !r.PublishedAt.IsZero() {
+			list[i].PublishedAt = r.PublishedAt.Format(time.RFC3339)
+		}
+	}
+	return list, nil
+}
+
+func (githubProvider) Release(parsed *ParsedGitURL, tag, token string) (*Release, error) {
+	return fetchGitHubRelease(parsed.Owner, parsed.Repo, tag, token)
+}
+
+func (githubProvider) tokenEnv() string { return "GITHUB_TOKEN" }
+
+func (githubProvider) fetchRepoMeta(parsed *ParsedGitURL, token string) (*repoMeta, error) {
+	var meta repoMeta
+	err := getAPIJSON(fmt.Sprintf("https://api.github.com/repos/%s/%s", parsed.Owner, parsed.Repo), token, &meta)
+	return &meta, err
+}
+
+func (githubProvider) commitSHA(parsed *ParsedGitURL, token string) string {
+	return fetchCommitSHA(parsed, token)
+}
+
+// archiveURL uses the API with a token, which works for private
+// repositories, and codeload otherwise.
+func (githubProvider) archiveURL(parsed *ParsedGitURL, ref, token string) (string, error) {
+	if token != "" {
+		apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/tarball", parsed.Owner, parsed.Repo)
+		if ref != "" {
+			apiURL += "/" + escapePath(ref)
+		}
+		return apiURL, nil
+	}
+	if ref == "" {
+		ref = "HEAD"
+	}
+	return fmt.Sprintf("https://codeload.github.com/%s/%s/tar.gz/%s", parsed.Owner, parsed.Repo, escapePath(ref)), nil
+}
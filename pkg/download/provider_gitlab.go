@@ -0,0 +1,73 @@
+package download
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// gitlabProvider downloads from gitlab.com and self-hosted GitLab.
+type gitlabProvider struct{}
+
+func (gitlabProvider) Name() string { return "gitlab" }
+
+func (gitlabProvider) ParseURL(host string, segments []string) *ParsedGitURL {
+	switch hostPlatform(host) {
+	case "gitlab":
+	case "":
+		// The /-/blob/ form is GitLab's own, so it identifies self-hosted
+		// instances on any domain
+		if !isGitLabPathForm(segments) {
+			return nil
+		}
+	default:
+		return nil
+	}
+	return parseGitLabSegments(segments)
+}
+
+func (gitlabProvider) RawURL(parsed *ParsedGitURL) string {
+	host := parsed.Host
+	if host == "" {
+		host = "gitlab.com"
+	}
+	return fmt.Sprintf("https://%s/%s/%s/-/raw/%s/%s",
+		host, parsed.Owner, parsed.Repo, escapePath(parsed.Branch), escapePath(parsed.FilePath))
+}
+
+func (gitlabProvider) ListTree(parsed *ParsedGitURL, maxDepth int, token string) ([]TreeFile, error) {
+	return fetchGitLabTree(parsed, maxDepth, token)
+}
+
+func (gitlabProvider) Releases(parsed *ParsedGitURL, token string) ([]Release, error) {
+	return listGitLabReleases(parsed, token)
+}
+
+func (gitlabProvider) Release(parsed *ParsedGitURL, tag, token string) (*Release, error) {
+	return fetchGitLabRelease(parsed, tag, token)
+}
+
+func (gitlabProvider) tokenEnv() string { return "GITLAB_TOKEN" }
+
+func (gitlabProvider) fetchRepoMeta(parsed *ParsedGitURL, token string) (*repoMeta, error) {
+	var meta repoMeta
+	err := getAPIJSON(gitlabProjectAPI(parsed), token, &meta)
+	return &meta, err
+}
+
+func (gitlabProvider) commitSHA(parsed *ParsedGitURL, token string) string {
+	var commit struct {
+		ID string `json:"id"`
+	}
+	if getAPIJSON(gitlabProjectAPI(parsed)+"/repository/commits/"+url.PathEscape(parsed.Branch), token, &commit) != nil {
+		return ""
+	}
+	return commit.ID
+}
+
+func (gitlabProvider) archiveURL(parsed *ParsedGitURL, ref, token string) (string, error) {
+	archiveURL := gitlabProjectAPI(parsed) + "/repository/archive.tar.gz"
+	if ref != "" {
+		archiveURL += "?sha=" + url.QueryEscape(ref)
+	}
+	return archiveURL, nil
+}
@@ -0,0 +1,77 @@
+package download
+
+import (
+	"testing"
+)
+
+// fakeProvider claims URLs on one host.
+type fakeProvider struct {
+	name string
+	host string
+}
+
+func (p fakeProvider) Name() string { return p.name }
+
+func (p fakeProvider) ParseURL(host string, segments []string) *ParsedGitURL {
+	if host != p.host || len(segments) < 2 {
+		return nil
+	}
+	return &ParsedGitURL{Owner: segments[0], Repo: segments[1], Branch: "trunk", IsDirectory: true}
+}
+
+func (fakeProvider) RawURL(parsed *ParsedGitURL) string { return "" }
+
+func (fakeProvider) ListTree(parsed *ParsedGitURL, maxDepth int, token string) ([]TreeFile, error) {
+	return nil, nil
+}
+
+func (fakeProvider) Releases(parsed *ParsedGitURL, token string) ([]Release, error) { return nil, nil }
+
+func (fakeProvider) Release(parsed *ParsedGitURL, tag, token string) (*Release, error) {
+	return nil, nil
+}
+
+func TestRegisterProvider(t *testing.T) {
+	saved := providers
+	t.Cleanup(func() { providers = saved })
+
+	RegisterProvider(fakeProvider{name: "forge", host: "forge.example.com"})
+	parsed, err := parseGitURL("https://forge.example.com/owner/repo")
+	if err != nil {
+		t.Fatalf("parseGitURL() error = %v", err)
+	}
+	if parsed.Platform != "forge" || parsed.Host != "forge.example.com" || parsed.Branch != "trunk" {
+		t.Errorf("parseGitURL() = %+v, want a forge URL filled in by the registry", parsed)
+	}
+	if p, err := providerFor(parsed); err != nil || p.Name() != "forge" {
+		t.Errorf("providerFor() = %v, %v, want forge", p, err)
+	}
+
+	// Registered providers are asked first, so they can take over hosts
+	RegisterProvider(fakeProvider{name: "mirror", host: "github.com"})
+	if parsed, err := parseGitURL("https://github.com/owner/repo"); err != nil || parsed.Platform != "mirror" {
+		t.Errorf("parseGitURL(github.com) = %+v, %v, want the mirror provider", parsed, err)
+	}
+
+	// A provider of the same name is replaced
+	count := len(providers)
+	RegisterProvider(fakeProvider{name: "forge", host: "forge.example.org"})
+	if len(providers) != count {
+		t.Errorf("Registering a provider again gave %d providers, want %d", len(providers), count)
+	}
+	if _, err := parseGitURL("https://forge.example.com/owner/repo"); err == nil {
+		t.Error("Expected the replaced provider's host to be unsupported")
+	}
+}
+
+func TestProviderFor(t *testing.T) {
+	for _, name := range []string{"github", "gitlab", "gitea", "bitbucket"} {
+		p, err := providerFor(&ParsedGitURL{Platform: name})
+		if err != nil || p.Name() != name {
+			t.Errorf("providerFor(%s) = %v, %v", name, p, err)
+		}
+	}
+	if _, err := providerFor(&ParsedGitURL{Platform: "svn"}); err == nil {
+		t.Error("Expected an error for an unknown platform")
+	}
+}
@@ -0,0 +1,418 @@
+package download
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dwirx/ghex/internal/ui"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultQueueParallel is the number of queue entries downloaded at once.
+const DefaultQueueParallel = 5
+
+// QueueItem is a single download of a queue file.
+type QueueItem struct {
+	URL      string            `yaml:"url" json:"url"`
+	Output   string            `yaml:"output" json:"output"`     // Output path; a trailing slash names a directory (default: the URL's file name)
+	Checksum string            `yaml:"checksum" json:"checksum"` // "sha256:<hex>" or "sha512:<hex>"
+	Headers  map[string]string `yaml:"headers" json:"headers"`   // Extra request headers
+}
+
+// key identifies the item in the state file.
+func (i QueueItem) key() string {
+	if i.Output == "" {
+		return i.URL
+	}
+	return i.URL + " " + i.Output
+}
+
+// checkOutput rejects an output path that is absolute or leaves the
+// output directory, the way extraction refuses such archive entries.
+func (i QueueItem) checkOutput() error {
+	if i.Output == "" {
+		return nil
+	}
+	name := strings.ReplaceAll(i.Output, "\\", "/")
+	rel := filepath.Clean(filepath.FromSlash(name))
+	if strings.HasPrefix(name, "/") || filepath.IsAbs(rel) || filepath.VolumeName(rel) != "" ||
+		rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("output %s leaves the output directory", i.Output)
+	}
+	return nil
+}
+
+// options returns the download options of the item, with its output path
+// below base.OutputDir.
+func (i QueueItem) options(base Options) Options {
+	opts := base
+	opts.Checksum = i.Checksum
+	if len(i.Headers) > 0 {
+		opts.Headers = map[string]string{}
+		for k, v := range base.Headers {
+			opts.Headers[k] = v
+		}
+		for k, v := range i.Headers {
+			opts.Headers[k] = v
+		}
+	}
+
+	switch {
+	case i.Output == "":
+	case strings.HasSuffix(i.Output, "/") || strings.HasSuffix(i.Output, string(filepath.Separator)):
+		opts.OutputDir = filepath.Join(base.OutputDir, i.Output)
+	default:
+		opts.OutputDir = filepath.Join(base.OutputDir, filepath.Dir(i.Output))
+		opts.Output = filepath.Base(i.Output)
+	}
+	return opts
+}
+
+// target returns the path a URL is downloaded to with opts, as FromURL
+// picks it.
+func target(rawURL string, opts Options) string {
+	name := opts.Output
+	if name == "" {
+		name = filenameFromURL(rawURL)
+	}
+	if name == "" {
+		name = "download"
+	}
+	return filepath.Join(opts.OutputDir, name)
+}
+
+// QueueOptions configures a queue run.
+type QueueOptions struct {
+	OutputDir string // Directory output paths are relative to (empty = current directory)
+	Parallel  int    // Entries downloaded at once (0 = DefaultQueueParallel)
+	Overwrite bool   // Download entries again even when their file exists
+	Restart   bool   // Ignore the state file; existing files are still skipped unless Overwrite
+}
+
+// LoadQueue reads a queue file. Files ending in .yaml, .yml or .json hold
+// a list of items, at the top level or under "items"; other files have one
+// download per line, where blank lines and # comments are skipped:
+//
+//	# URL [output] [checksum=sha256:<hex>] [header="Name: value"]...
+//	https://example.com/a.tar.gz
+//	https://example.com/b.zip vendor/b.zip checksum=sha256:9f86d0...
+//	https://example.com/c.bin header="Authorization: Bearer xyz"
+func LoadQueue(queuePath string) ([]QueueItem, error) {
+	data, err := os.ReadFile(queuePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file list: %w", err)
+	}
+
+	var items []QueueItem
+	switch strings.ToLower(filepath.Ext(queuePath)) {
+	case ".yaml", ".yml", ".json":
+		items, err = parseQueueDocument(data)
+	default:
+		items, err = parseQueueLines(string(data))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no URLs found in file list")
+	}
+	for n, item := range items {
+		if err := item.checkOutput(); err != nil {
+			return nil, fmt.Errorf("entry #%d: %w", n+1, err)
+		}
+	}
+	return items, nil
+}
+
+// parseQueueDocument parses a YAML or JSON queue; JSON is valid YAML, so
+// one parser reads both.
+func parseQueueDocument(data []byte) ([]QueueItem, error) {
+	var items []QueueItem
+	if err := yaml.Unmarshal(data, &items); err != nil {
+		var doc struct {
+			Items []QueueItem `yaml:"items"`
+		}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse queue: %w", err)
+		}
+		items = doc.Items
+	}
+
+	for i, item := range items {
+		if !strings.HasPrefix(item.URL, "http://") && !strings.HasPrefix(item.URL, "https://") {
+			return nil, fmt.Errorf("entry #%d: url must start with http:// or https://", i+1)
+		}
+	}
+	return items, nil
+}
+
+// parseQueueLines parses the line format described at LoadQueue.
+func parseQueueLines(content string) ([]QueueItem, error) {
+	// Normalize line endings (handle Windows \r\n)
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	content = strings.ReplaceAll(content, "\r", "\n")
+
+	var items []QueueItem
+	for n, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		item, err := parseQueueLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// parseQueueLine parses "URL [output] [checksum=...] [header=...]...". A
+// bare sha256:/sha512: field is taken as the checksum.
+func parseQueueLine(line string) (QueueItem, error) {
+	fields, err := splitQuoted(line)
+	if err != nil {
+		return QueueItem{}, err
+	}
+
+	item := QueueItem{URL: fields[0]}
+	for _, field := range fields[1:] {
+		key, value, ok := strings.Cut(field, "=")
+		switch {
+		case ok && (key == "output" || key == "out"):
+			item.Output = value
+		case ok && key == "checksum":
+			item.Checksum = value
+		case ok && key == "header":
+			h, err := ParseHeaders([]string{value})
+			if err != nil {
+				return QueueItem{}, err
+			}
+			if item.Headers == nil {
+				item.Headers = map[string]string{}
+			}
+			for name := range h {
+				item.Headers[name] = h.Get(name)
+			}
+		case strings.HasPrefix(field, "sha256:") || strings.HasPrefix(field, "sha512:"):
+			item.Checksum = field
+		case item.Output == "" && !ok:
+			item.Output = field
+		default:
+			return QueueItem{}, fmt.Errorf("unexpected field %q (expected output, checksum=... or header=...)", field)
+		}
+	}
+	return item, nil
+}
+
+// splitQuoted splits s at whitespace outside single or double quotes,
+// removing the quotes.
+func splitQuoted(s string) ([]string, error) {
+	var fields []string
+	var b strings.Builder
+	var quote rune
+	inField := false
+	for _, r := range s {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			b.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote, inField = r, true
+		case r == ' ' || r == '\t':
+			if inField {
+				fields = append(fields, b.String())
+				b.Reset()
+				inField = false
+			}
+		default:
+			b.WriteRune(r)
+			inField = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	if inField {
+		fields = append(fields, b.String())
+	}
+	return fields, nil
+}
+
+// queueEntry is the recorded outcome of a queue item.
+type queueEntry struct {
+	Status  string    `json:"status"` // "done" or "failed"
+	Path    string    `json:"path,omitempty"`
+	Error   string    `json:"error,omitempty"`
+	Updated time.Time `json:"updated"`
+}
+
+// queueState is the "<queue>.state.json" sidecar file recording which
+// entries finished, so an interrupted or partly failed run can be resumed.
+type queueState struct {
+	mu      sync.Mutex
+	path    string
+	Entries map[string]queueEntry `json:"entries"`
+}
+
+// QueueStatePath returns the state file kept next to a queue file.
+func QueueStatePath(queuePath string) string {
+	return queuePath + ".state.json"
+}
+
+// loadQueueState reads the state of a queue, empty when there is none.
+func loadQueueState(queuePath string) *queueState {
+	state := &queueState{path: QueueStatePath(queuePath), Entries: map[string]queueEntry{}}
+	if data, err := os.ReadFile(state.path); err == nil {
+		if json.Unmarshal(data, state) != nil || state.Entries == nil {
+			state.Entries = map[string]queueEntry{}
+		}
+	}
+	return state
+}
+
+// done reports whether item was downloaded to path and the file is still
+// there.
+func (s *queueState) done(item QueueItem, path string) bool {
+	entry, ok := s.Entries[item.key()]
+	if !ok || entry.Status != "done" || filepath.Clean(entry.Path) != filepath.Clean(path) {
+		return false
+	}
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// record stores the outcome of item and writes the state file, so entries
+// finished before an interruption are not downloaded again.
+func (s *queueState) record(item QueueItem, path string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry := queueEntry{Status: "done", Path: path, Updated: time.Now().UTC()}
+	var exists *ErrFileExists
+	if err != nil && !errors.As(err, &exists) {
+		entry.Status = "failed"
+		entry.Error = err.Error()
+	}
+	s.Entries[item.key()] = entry
+
+	data, mErr := json.MarshalIndent(s, "", "  ")
+	if mErr == nil {
+		// Entry URLs can carry access tokens
+		mErr = os.WriteFile(s.path, data, 0600)
+	}
+	if mErr == nil {
+		// WriteFile keeps the mode of a file written by older versions
+		mErr = os.Chmod(s.path, 0600)
+	}
+	if mErr != nil {
+		ui.ShowWarning(fmt.Sprintf("Failed to write state file: %v", mErr))
+	}
+}
+
+// DownloadQueue downloads the entries of a queue file (see LoadQueue),
+// opts.Parallel at a time. Outcomes are recorded in QueueStatePath, so a
+// later run skips the entries already done and resumes the failed and
+// unfinished ones from their partial data.
+func DownloadQueue(queuePath string, opts QueueOptions) error {
+	items, err := LoadQueue(queuePath)
+	if err != nil {
+		return err
+	}
+
+	state := loadQueueState(queuePath)
+	if opts.Restart {
+		state.Entries = map[string]queueEntry{}
+	}
+
+	base := DefaultOptions()
+	base.OutputDir = opts.OutputDir
+	base.Overwrite = opts.Overwrite
+	base.Resume = true
+
+	var pending []QueueItem
+	for _, item := range items {
+		if !opts.Overwrite && state.done(item, target(item.URL, item.options(base))) {
+			continue
+		}
+		pending = append(pending, item)
+	}
+	if len(pending) == 0 {
+		ui.ShowSuccess(fmt.Sprintf("All %d entries are downloaded (%s)", len(items), state.path))
+		return nil
+	}
+	if done := len(items) - len(pending); done > 0 {
+		ui.ShowInfo(fmt.Sprintf("%d of %d entries already downloaded (%s), resuming the rest", done, len(items), state.path))
+	}
+
+	return runQueue(pending, opts.Parallel, base, state.record)
+}
+
+// runQueue downloads items with bounded concurrency, reporting each
+// outcome to record when it is set. Files that already exist are skipped.
+func runQueue(items []QueueItem, parallel int, base Options, record func(QueueItem, string, error)) error {
+	if parallel <= 0 {
+		parallel = DefaultQueueParallel
+	}
+
+	type result struct {
+		url string
+		err error
+	}
+
+	results := make([]result, len(items))
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+
+	for i, item := range items {
+		wg.Add(1)
+		go func(idx int, item QueueItem) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			fmt.Printf("[%d/%d] %s\n", idx+1, len(items), item.URL)
+			start := time.Now()
+			path, err := fromURL(item.URL, item.options(base))
+			recordFile(item.URL, path, start, err)
+			if record != nil {
+				record(item, path, err)
+			}
+			results[idx] = result{url: item.URL, err: err}
+		}(i, item)
+	}
+
+	wg.Wait()
+
+	var errs []string
+	succeeded, skipped := 0, 0
+	for _, r := range results {
+		var exists *ErrFileExists
+		switch {
+		case errors.As(r.err, &exists):
+			skipped++
+		case r.err != nil:
+			errs = append(errs, fmt.Sprintf("%s: %v", r.url, r.err))
+		default:
+			succeeded++
+		}
+	}
+
+	if skipped > 0 {
+		fmt.Printf("\nSummary: %d succeeded, %d skipped (already exist), %d failed\n", succeeded, skipped, len(errs))
+	} else {
+		fmt.Printf("\nSummary: %d succeeded, %d failed\n", succeeded, len(errs))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("some downloads failed:\n%s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package download
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestParseQueueLine(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected QueueItem
+		wantErr  bool
+	}{
+		{"https://example.com/a.tar.gz", QueueItem{URL: "https://example.com/a.tar.gz"}, false},
+		{"https://example.com/b.zip vendor/b.zip", QueueItem{URL: "https://example.com/b.zip", Output: "vendor/b.zip"}, false},
+		{"https://example.com/b.zip out=vendor/ checksum=sha256:abc", QueueItem{URL: "https://example.com/b.zip", Output: "vendor/", Checksum: "sha256:abc"}, false},
+		{"https://example.com/c.bin sha512:def", QueueItem{URL: "https://example.com/c.bin", Checksum: "sha512:def"}, false},
+		{`https://example.com/c.bin header="Authorization: Bearer xyz"`, QueueItem{URL: "https://example.com/c.bin", Headers: map[string]string{"Authorization": "Bearer xyz"}}, false},
+		{"https://example.com/c.bin 'my file.bin'", QueueItem{URL: "https://example.com/c.bin", Output: "my file.bin"}, false},
+		{"https://example.com/c.bin a b", QueueItem{}, true},
+		{`https://example.com/c.bin "unterminated`, QueueItem{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, err := parseQueueLine(tt.line)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseQueueLine(%q) error = %v, wantErr %v", tt.line, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("parseQueueLine(%q) = %+v, want %+v", tt.line, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLoadQueue(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    int
+		wantErr bool
+	}{
+		{"list.txt", "# comment\r\nhttps://example.com/a\r\n\r\nhttps://example.com/b out.bin\r\n", 2, false},
+		{"list.yaml", "- url: https://example.com/a\n  output: dir/\n- url: https://example.com/b\n", 2, false},
+		{"list.yml", "items:\n  - url: https://example.com/a\n", 1, false},
+		{"list.json", `[{"url": "https://example.com/a", "checksum": "sha256:abc"}]`, 1, false},
+		{"bad.yaml", "- url: ftp://example.com/a\n", 0, true},
+		{"empty.txt", "# nothing\n", 0, true},
+		{"parent.txt", "https://example.com/a ../../x\n", 0, true},
+		{"nested-parent.yaml", "- url: https://example.com/a\n  output: dir/../../x\n", 0, true},
+		{"absolute.yaml", "- url: https://example.com/a\n  output: /etc/x\n", 0, true},
+		{"backslash.txt", "https://example.com/a ..\\x\n", 0, true},
+		{"inside.txt", "https://example.com/a dir/../x\n", 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.name)
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			items, err := LoadQueue(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadQueue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(items) != tt.want {
+				t.Errorf("LoadQueue() = %d items, want %d", len(items), tt.want)
+			}
+		})
+	}
+}
+
+func TestQueueItemOptions(t *testing.T) {
+	base := Options{OutputDir: "out", Headers: map[string]string{"X-Base": "1"}}
+	tests := []struct {
+		item    QueueItem
+		wantDir string
+		wantOut string
+	}{
+		{QueueItem{URL: "https://example.com/a.bin"}, "out", ""},
+		{QueueItem{URL: "https://example.com/a.bin", Output: "vendor/"}, filepath.Join("out", "vendor"), ""},
+		{QueueItem{URL: "https://example.com/a.bin", Output: "vendor/b.bin"}, filepath.Join("out", "vendor"), "b.bin"},
+	}
+
+	for _, tt := range tests {
+		opts := tt.item.options(base)
+		if opts.OutputDir != tt.wantDir || opts.Output != tt.wantOut {
+			t.Errorf("options(%+v) = dir %q, output %q, want %q, %q", tt.item, opts.OutputDir, opts.Output, tt.wantDir, tt.wantOut)
+		}
+	}
+
+	opts := QueueItem{URL: "https://example.com/a", Headers: map[string]string{"X-Item": "2"}}.options(base)
+	if opts.Headers["X-Base"] != "1" || opts.Headers["X-Item"] != "2" {
+		t.Errorf("Headers = %v, want base and item headers", opts.Headers)
+	}
+	if _, ok := base.Headers["X-Item"]; ok {
+		t.Error("Item headers leaked into the base options")
+	}
+}
+
+func TestDownloadQueue(t *testing.T) {
+	var mu sync.Mutex
+	requests := map[string]int{}
+	broken := true
+	srv := newFileServer(t, nil, func(w http.ResponseWriter, r *http.Request) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Method == http.MethodGet {
+			requests[r.URL.Path]++
+		}
+		if r.URL.Path == "/c.txt" && broken {
+			http.NotFound(w, r)
+			return true
+		}
+		w.Write([]byte("content of " + r.URL.Path))
+		return true
+	}, false)
+
+	dir := t.TempDir()
+	queuePath := filepath.Join(dir, "list.txt")
+	list := srv.URL + "/a.txt\n" + srv.URL + "/b.txt sub/b.txt\n" + srv.URL + "/c.txt\n"
+	if err := os.WriteFile(queuePath, []byte(list), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(dir, "out")
+
+	err := DownloadQueue(queuePath, QueueOptions{OutputDir: out, Parallel: 2})
+	if err == nil || !strings.Contains(err.Error(), "c.txt") {
+		t.Fatalf("DownloadQueue() error = %v, want c.txt to fail", err)
+	}
+	for _, name := range []string{"a.txt", filepath.Join("sub", "b.txt")} {
+		if _, err := os.Stat(filepath.Join(out, name)); err != nil {
+			t.Errorf("Expected %s to be downloaded: %v", name, err)
+		}
+	}
+
+	state := loadQueueState(queuePath)
+	if len(state.Entries) != 3 {
+		t.Fatalf("State has %d entries, want 3", len(state.Entries))
+	}
+	if e := state.Entries[srv.URL+"/c.txt"]; e.Status != "failed" || e.Error == "" {
+		t.Errorf("State of c.txt = %+v, want failed with the error", e)
+	}
+	if info, err := os.Stat(QueueStatePath(queuePath)); err != nil {
+		t.Fatal(err)
+	} else if perm := info.Mode().Perm(); runtime.GOOS != "windows" && perm != 0600 {
+		t.Errorf("State file mode = %o, want 600", perm)
+	}
+
+	// The next run only downloads the failed entry
+	mu.Lock()
+	broken = false
+	mu.Unlock()
+	if err := DownloadQueue(queuePath, QueueOptions{OutputDir: out}); err != nil {
+		t.Fatalf("DownloadQueue() second run error = %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if requests["/a.txt"] != 1 || requests["/b.txt"] != 1 || requests["/c.txt"] != 2 {
+		t.Errorf("Requests = %v, want a and b once and c twice", requests)
+	}
+	if got, _ := os.ReadFile(filepath.Join(out, "c.txt")); string(got) != "content of /c.txt" {
+		t.Errorf("c.txt = %q", got)
+	}
+}
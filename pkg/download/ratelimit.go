@@ -0,0 +1,150 @@
+package download
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// defaultRetries is the retry count used when options leave it at 0.
+var defaultRetries = 3
+
+// maxRetryWait is the longest wait before a retry. A rate limit that resets
+// later fails right away with ErrRateLimit, so callers can fall back.
+const maxRetryWait = time.Minute
+
+// SetRetries sets how many times API requests, and downloads whose options
+// leave Retries at 0, are retried after rate limits, server errors and
+// network failures. 0 disables retries.
+func SetRetries(n int) {
+	if n < 0 {
+		n = 0
+	}
+	defaultRetries = n
+}
+
+// retryCount returns the retry count for an option value: positive values
+// as is, negative as none and 0 as the package default.
+func retryCount(n int) int {
+	switch {
+	case n > 0:
+		return n
+	case n < 0:
+		return 0
+	default:
+		return defaultRetries
+	}
+}
+
+// doAPI sends a bodiless API request with the default retry count.
+func doAPI(client *http.Client, req *http.Request) (*http.Response, error) {
+	return doWithRetries(client, req, retryCount(0))
+}
+
+// doWithRetries sends a bodiless request, retrying with exponential backoff
+// on network errors, 429, 5xx and rate-limited 403 responses. Retry-After
+// and X-RateLimit-Reset are honored. A rate limit that persists returns
+// ErrRateLimit; other responses are returned for the caller to check.
+func doWithRetries(client *http.Client, req *http.Request, retries int) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := client.Do(req.Clone(req.Context()))
+		if err != nil {
+			if attempt >= retries {
+				return nil, err
+			}
+			time.Sleep(backoff(attempt))
+			continue
+		}
+		if !isRetryable(resp) {
+			return resp, nil
+		}
+
+		limited := isRateLimitResponse(resp)
+		wait := retryWait(resp, attempt)
+		if attempt >= retries || wait > maxRetryWait {
+			if limited {
+				drainAndClose(resp)
+				return nil, rateLimitError(resp)
+			}
+			return resp, nil
+		}
+		drainAndClose(resp)
+
+		if limited {
+			fmt.Printf("  Rate limited by %s, retrying in %s\n", req.URL.Host, wait)
+		}
+		time.Sleep(wait)
+	}
+}
+
+// isRetryable reports whether a response is worth retrying.
+func isRetryable(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode >= 500 ||
+		isRateLimitResponse(resp)
+}
+
+// isRateLimitResponse reports whether a response is a primary or secondary
+// rate limit. GitHub answers both with 403, others with 429.
+func isRateLimitResponse(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusForbidden:
+		return resp.Header.Get("X-RateLimit-Remaining") == "0" || resp.Header.Get("Retry-After") != ""
+	default:
+		return false
+	}
+}
+
+// retryWait returns how long to wait before retrying: Retry-After when set,
+// the rate limit reset time when the quota is used up, otherwise the
+// exponential backoff for the attempt.
+func retryWait(resp *http.Response, attempt int) time.Duration {
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, ok := rateLimitReset(resp); ok {
+			if wait := time.Until(reset).Round(time.Second) + time.Second; wait > 0 {
+				return wait
+			}
+		}
+	}
+	return backoff(attempt)
+}
+
+// backoff returns 1s, 2s, 4s, ... for attempts 0, 1, 2, ...
+func backoff(attempt int) time.Duration {
+	if attempt > 5 {
+		attempt = 5
+	}
+	return time.Duration(1<<uint(attempt)) * time.Second
+}
+
+// rateLimitReset returns the time from the X-RateLimit-Reset header (Unix
+// seconds).
+func rateLimitReset(resp *http.Response) (time.Time, bool) {
+	secs, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil || secs <= 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(secs, 0), true
+}
+
+// rateLimitError returns the ErrRateLimit for a rate-limited response.
+func rateLimitError(resp *http.Response) *ErrRateLimit {
+	e := &ErrRateLimit{Host: resp.Request.URL.Host}
+	if reset, ok := rateLimitReset(resp); ok {
+		e.ResetAt = reset.Local().Format("15:04:05")
+	}
+	return e
+}
+
+// drainAndClose discards a response body so the connection can be reused.
+func drainAndClose(resp *http.Response) {
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	resp.Body.Close()
+}
@@ -0,0 +1,49 @@
+package download
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// giteaRepoAPI returns the API base URL for a Gitea/Forgejo repository.
+func giteaRepoAPI(parsed *ParsedGitURL) string {
+	host := parsed.Host
+	if host == "" {
+		host = "codeberg.org"
+	}
+	return fmt.Sprintf("https://%s/api/v1/repos/%s/%s", host, url.PathEscape(parsed.Owner), url.PathEscape(parsed.Repo))
+}
+
+// fetchGiteaRelease fetches a Gitea/Forgejo release by tag (empty = latest).
+// The response shape matches GitHub's, so Release decodes it directly.
+func fetchGiteaRelease(parsed *ParsedGitURL, tag, token string) (*Release, error) {
+	apiURL := giteaRepoAPI(parsed) + "/releases/latest"
+	if tag != "" {
+		apiURL = giteaRepoAPI(parsed) + "/releases/tags/" + url.PathEscape(tag)
+	}
+
+	var release Release
+	if err := getAPIJSON(apiURL, token, &release); err != nil {
+		return nil, err
+	}
+	return &release, nil
+}
+
+// listGiteaReleases lists releases, newest first. Gitea caps pages at 50
+// entries by default, so pages are read until a short one.
+func listGiteaReleases(parsed *ParsedGitURL, token string) ([]Release, error) {
+	const perPage = 50
+	var releases []Release
+	for page := 1; page <= maxReleasePages; page++ {
+		var entries []Release
+		apiURL := fmt.Sprintf("%s/releases?limit=%d&page=%d", giteaRepoAPI(parsed), perPage, page)
+		if err := getAPIJSON(apiURL, token, &entries); err != nil {
+			return nil, err
+		}
+		releases = append(releases, entries...)
+		if len(entries) < perPage {
+			break
+		}
+	}
+	return releases, nil
+}
@@ -0,0 +1,192 @@
+package download
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/dwirx/ghex/internal/httpclient"
+)
+
+// gitlabRelease is the subset of the GitLab releases API response used here.
+type gitlabRelease struct {
+	TagName    string `json:"tag_name"`
+	Name       string `json:"name"`
+	ReleasedAt string `json:"released_at"`
+	Assets     struct {
+		Links []struct {
+			Name           string `json:"name"`
+			URL            string `json:"url"`
+			DirectAssetURL string `json:"direct_asset_url"`
+		} `json:"links"`
+	} `json:"assets"`
+}
+
+// gitlabPackage is a package registry entry.
+type gitlabPackage struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// gitlabPackageFile is a file in a package registry entry.
+type gitlabPackageFile struct {
+	FileName string `json:"file_name"`
+	Size     int64  `json:"size"`
+}
+
+// gitlabProjectAPI returns the API base URL for a project.
+func gitlabProjectAPI(parsed *ParsedGitURL) string {
+	host := parsed.Host
+	if host == "" {
+		host = "gitlab.com"
+	}
+	return fmt.Sprintf("https://%s/api/v4/projects/%s", host, url.PathEscape(parsed.FullPath()))
+}
+
+// fetchGitLabRelease fetches a GitLab release by tag (empty = latest).
+// Release links and generic package registry files published under the
+// same version are both returned as assets.
+func fetchGitLabRelease(parsed *ParsedGitURL, tag, token string) (*Release, error) {
+	base := gitlabProjectAPI(parsed)
+
+	var gl gitlabRelease
+	if tag == "" {
+		// Releases are sorted by released_at, newest first
+		var releases []gitlabRelease
+		if err := getAPIJSON(base+"/releases?per_page=1", token, &releases); err != nil {
+			return nil, err
+		}
+		if len(releases) == 0 {
+			return nil, fmt.Errorf("no releases found for %s", parsed.FullPath())
+		}
+		gl = releases[0]
+	} else if err := getAPIJSON(base+"/releases/"+url.PathEscape(tag), token, &gl); err != nil {
+		return nil, err
+	}
+
+	release := &Release{
+		TagName:     gl.TagName,
+		Name:        gl.Name,
+		PublishedAt: gl.ReleasedAt,
+	}
+	for _, link := range gl.Assets.Links {
+		downloadURL := link.DirectAssetURL
+		if downloadURL == "" {
+			downloadURL = link.URL
+		}
+		release.Assets = append(release.Assets, ReleaseAsset{Name: link.Name, BrowserDownloadURL: downloadURL})
+	}
+
+	// Package registry files are optional; a project without the registry
+	// enabled simply contributes nothing.
+	if files, err := listGitLabPackageAssets(base, gl.TagName, token); err == nil {
+		release.Assets = append(release.Assets, files...)
+	}
+	return release, nil
+}
+
+// listGitLabPackageAssets lists generic package files whose package version
+// matches the release tag (with or without a leading "v").
+func listGitLabPackageAssets(base, tag, token string) ([]ReleaseAsset, error) {
+	var assets []ReleaseAsset
+	for _, version := range uniqueStrings(tag, strings.TrimPrefix(tag, "v")) {
+		var packages []gitlabPackage
+		apiURL := fmt.Sprintf("%s/packages?package_type=generic&package_version=%s&per_page=100", base, url.QueryEscape(version))
+		if err := getAPIJSON(apiURL, token, &packages); err != nil {
+			return nil, err
+		}
+
+		for _, pkg := range packages {
+			if pkg.Version != version {
+				continue
+			}
+			var files []gitlabPackageFile
+			if err := getAPIJSON(fmt.Sprintf("%s/packages/%d/package_files?per_page=100", base, pkg.ID), token, &files); err != nil {
+				return nil, err
+			}
+			for _, f := range files {
+				assets = append(assets, ReleaseAsset{
+					Name: f.FileName,
+					Size: f.Size,
+					BrowserDownloadURL: fmt.Sprintf("%s/packages/generic/%s/%s/%s", base,
+						url.PathEscape(pkg.Name), url.PathEscape(pkg.Version), url.PathEscape(f.FileName)),
+				})
+			}
+		}
+	}
+	return assets, nil
+}
+
+// listGitLabReleases lists releases, newest first. GitLab releases have no
+// prerelease flag.
+func listGitLabReleases(parsed *ParsedGitURL, token string) ([]Release, error) {
+	var releases []Release
+	page := "1"
+	for i := 0; i < maxReleasePages && page != ""; i++ {
+		var entries []gitlabRelease
+		next, err := getAPIPage(gitlabProjectAPI(parsed)+"/releases?per_page=100&page="+page, token, &entries)
+		if err != nil {
+			return nil, err
+		}
+		page = next
+		for _, r := range entries {
+			releases = append(releases, Release{TagName: r.TagName, Name: r.Name, PublishedAt: r.ReleasedAt})
+		}
+	}
+	return releases, nil
+}
+
+// getAPIJSON performs a GitLab or Gitea API GET and decodes the JSON
+// response. Both accept personal access tokens as Bearer tokens.
+func getAPIJSON(apiURL, token string, v interface{}) error {
+	_, err := getAPIPage(apiURL, token, v)
+	return err
+}
+
+// getAPIPage is getAPIJSON for paginated endpoints. It also returns the
+// next page number from GitLab's X-Next-Page header, empty on the last page.
+func getAPIPage(apiURL, token string, v interface{}) (string, error) {
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "ghex-downloader/1.0")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := doAPI(httpclient.Default(), req)
+	if err != nil {
+		if isRateLimited(err) {
+			return "", err
+		}
+		return "", fmt.Errorf("failed to fetch %s: %w", apiURL, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Header.Get("X-Next-Page"), json.NewDecoder(resp.Body).Decode(v)
+	case http.StatusNotFound:
+		return "", &ErrNotFound{URL: apiURL}
+	default:
+		return "", &ErrHTTP{StatusCode: resp.StatusCode, Status: resp.Status, URL: apiURL}
+	}
+}
+
+// uniqueStrings returns the non-empty values with duplicates removed.
+func uniqueStrings(values ...string) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, v := range values {
+		if v != "" && !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,208 @@
+package download
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dwirx/ghex/internal/ui"
+)
+
+// RepoOptions configures whole-repository download behavior.
+type RepoOptions struct {
+	Branch    string // Branch/tag/commit (empty = default branch)
+	OutputDir string // Output directory (default: repo name)
+	Depth     int    // Max directory depth (0 = unlimited)
+	Overwrite bool   // Overwrite existing files
+	Token     string // Access token (falls back to GITHUB_TOKEN, GITLAB_TOKEN or GITEA_TOKEN by host, or a configured account's token)
+	All       bool   // Download everything without showing the picker
+	MaxSize   int64  // Ask before downloading more than this many bytes (0 = DefaultConfirmSize, <0 = never)
+	Force     bool   // Skip the large download confirmation
+	Parallel  int    // Files downloaded at once (0 = DefaultParallel)
+	Archive   bool   // Download one archive of the whole repository and extract it (always used for GitLab and Gitea)
+
+	Verification // Rejected: checksums only apply to single files
+}
+
+// GitRepo downloads a repository. Unless opts.All is set, it lists the
+// top-level entries and lets the user pick which ones to fetch, so a bare
+// owner/repo URL doesn't silently pull thousands of files. With
+// opts.Archive, and always outside GitHub, it downloads a snapshot
+// archive of the whole repository instead.
+func GitRepo(rawURL string, opts RepoOptions) error {
+	if opts.IsSet() {
+		return errVerifyMultiple
+	}
+	parsed, err := parseGitURL(rawURL)
+	if err != nil {
+		return err
+	}
+
+	token := releaseToken(parsed, opts.Token)
+
+	applyRef(parsed, opts.Branch, token)
+
+	if opts.Archive || parsed.Platform != "github" {
+		return repoArchive(parsed, opts, token)
+	}
+
+	entries, err := listContents(parsed, parsed.FilePath, token)
+	if err != nil && !parsed.refExplicit && parsed.Branch == "main" {
+		parsed.Branch = "master"
+		ui.ShowInfo("Branch 'main' not found, trying 'master'...")
+		entries, err = listContents(parsed, parsed.FilePath, token)
+		if err == nil {
+			rememberDefaultBranch(parsed, token)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		ui.ShowWarning("Repository is empty")
+		return nil
+	}
+
+	selected := entries
+	if !opts.All {
+		items := make([]ui.SelectorItem, len(entries))
+		for i, e := range entries {
+			title := "📄 " + e.Name
+			desc := formatSize(e.Size)
+			if e.Type == "dir" {
+				title = "📁 " + e.Name + "/"
+				desc = "directory"
+			}
+			items[i] = ui.SelectorItem{Title: title, Description: desc, Value: e.Path}
+		}
+
+		idxs, err := ui.RunMultiSelector(fmt.Sprintf("Select entries from %s (%s)", parsed.FullPath(), refLabel(parsed)), items)
+		if err != nil {
+			return fmt.Errorf("selection error: %w", err)
+		}
+		if len(idxs) == 0 {
+			ui.ShowInfo("Nothing selected")
+			return nil
+		}
+
+		selected = make([]contentEntry, 0, len(idxs))
+		for _, i := range idxs {
+			selected = append(selected, entries[i])
+		}
+	}
+
+	ui.ShowSection("Downloading Repository")
+	ui.ShowKeyValue("Repository", parsed.FullPath())
+	ui.ShowKeyValue("Ref", refLabel(parsed))
+	ui.ShowKeyValue("Entries", fmt.Sprintf("%d of %d", len(selected), len(entries)))
+	fmt.Println()
+
+	var files []TreeFile
+	for _, e := range selected {
+		switch e.Type {
+		case "file":
+			files = append(files, TreeFile{Path: e.Path, URL: e.DownloadURL, Size: e.Size, SHA: e.SHA})
+		case "dir":
+			sub := *parsed
+			sub.FilePath = e.Path
+			dirFiles, err := fetchDirectoryContents(&sub, opts.Depth, token)
+			if err != nil {
+				ui.ShowWarning(fmt.Sprintf("Failed to list %s: %v", e.Path, err))
+				continue
+			}
+			files = append(files, dirFiles...)
+		}
+	}
+
+	if len(files) == 0 {
+		ui.ShowWarning("No files found")
+		return nil
+	}
+
+	if !confirmDownloadSize(files, opts.MaxSize, opts.Force) {
+		ui.ShowInfo("Cancelled")
+		return nil
+	}
+
+	outputDir := opts.OutputDir
+	if outputDir == "" {
+		outputDir = parsed.Repo
+	}
+
+	result, _ := downloadFiles(files, parsed.FilePath, outputDir, opts.Overwrite, opts.Parallel, token, nil)
+	return result.finish(outputDir)
+}
+
+// repoArchive downloads the repository as one .tar.gz snapshot and
+// extracts it, which takes a single request however many files there are.
+func repoArchive(parsed *ParsedGitURL, opts RepoOptions, token string) error {
+	if parsed.FilePath != "" {
+		return fmt.Errorf("archives contain the whole repository; use 'ghex dlx dir' for %s", parsed.FilePath)
+	}
+
+	archiveURL, err := repoArchiveURL(parsed, token)
+	if err != nil {
+		return err
+	}
+
+	ref := "default branch"
+	if parsed.refExplicit {
+		ref = refLabel(parsed)
+	}
+	ui.ShowSection("Downloading Repository Archive")
+	ui.ShowKeyValue("Repository", parsed.FullPath())
+	ui.ShowKeyValue("Ref", ref)
+	fmt.Println()
+
+	outputDir := opts.OutputDir
+	if outputDir == "" {
+		outputDir = parsed.Repo
+	}
+
+	tmpDir, err := os.MkdirTemp("", "ghex-repo-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	archiveName := parsed.Repo + ".tar.gz"
+	if err := Resumable(archiveURL, archiveName, ResumableOptions{
+		OutputDir:    tmpDir,
+		Token:        token,
+		ShowProgress: true,
+	}); err != nil {
+		if IsNotFound(err) {
+			return fmt.Errorf("repository or ref not found: %s@%s", parsed.FullPath(), ref)
+		}
+		return err
+	}
+
+	// Archives wrap everything in a "<repo>-<ref>/" directory
+	n, err := ExtractArchive(filepath.Join(tmpDir, archiveName), outputDir, 1, opts.Overwrite)
+	if err != nil {
+		return fmt.Errorf("failed to extract archive: %w", err)
+	}
+	ui.ShowSuccess(fmt.Sprintf("Extracted %d files to %s", n, outputDir))
+	return nil
+}
+
+// repoArchiveURL returns the .tar.gz archive URL of the repository at the
+// requested ref, or at the default branch when none was given, from the
+// provider.
+func repoArchiveURL(parsed *ParsedGitURL, token string) (string, error) {
+	ref := ""
+	if parsed.refExplicit {
+		ref = parsed.Branch
+	}
+
+	p, err := providerFor(parsed)
+	if err != nil {
+		return "", err
+	}
+	src, ok := p.(archiveSource)
+	if !ok {
+		return "", fmt.Errorf("repository archives not supported for %s", parsed.Platform)
+	}
+	return src.archiveURL(parsed, ref, token)
+}
@@ -0,0 +1,97 @@
+package download
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"time"
+)
+
+// Statuses of a FileResult
+const (
+	StatusDownloaded = "downloaded"
+	StatusSkipped    = "skipped" // The file exists and Overwrite is off
+	StatusFailed     = "failed"
+)
+
+// FileResult is the outcome of downloading one file
+type FileResult struct {
+	URL        string `json:"url"`
+	Path       string `json:"path"`
+	Status     string `json:"status"`
+	Bytes      int64  `json:"bytes"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+// Recorder collects the result of every file downloaded while it is set
+// with SetRecorder, e.g. for machine-readable output
+type Recorder struct {
+	mu    sync.Mutex
+	files []FileResult
+}
+
+// Files returns the recorded results in the order the downloads started
+func (r *Recorder) Files() []FileResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]FileResult(nil), r.files...)
+}
+
+// add records res. A later result for the same path, such as a retry on
+// another branch after a 404, replaces the earlier one.
+func (r *Recorder) add(res FileResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, f := range r.files {
+		if f.Path == res.Path {
+			r.files[i] = res
+			return
+		}
+	}
+	r.files = append(r.files, res)
+}
+
+var (
+	recorderMu sync.Mutex
+	recorder   *Recorder
+)
+
+// SetRecorder makes every following download report its result to r; nil
+// stops recording
+func SetRecorder(r *Recorder) {
+	recorderMu.Lock()
+	recorder = r
+	recorderMu.Unlock()
+}
+
+// recordFile reports the download of rawURL to path, started at start and
+// ending with err, to the recorder if one is set
+func recordFile(rawURL, path string, start time.Time, err error) {
+	recorderMu.Lock()
+	r := recorder
+	recorderMu.Unlock()
+	if r == nil || path == "" {
+		return
+	}
+
+	res := FileResult{
+		URL:        rawURL,
+		Path:       path,
+		Status:     StatusDownloaded,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	var exists *ErrFileExists
+	switch {
+	case errors.As(err, &exists):
+		res.Status = StatusSkipped
+		res.Error = err.Error()
+	case err != nil:
+		res.Status = StatusFailed
+		res.Error = err.Error()
+	}
+	if info, statErr := os.Stat(path); statErr == nil && err == nil {
+		res.Bytes = info.Size()
+	}
+	r.add(res)
+}
@@ -0,0 +1,274 @@
+package download
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dwirx/ghex/internal/httpclient"
+	"github.com/dwirx/ghex/internal/ui"
+)
+
+// partSuffix is appended to in-progress downloads so they can be resumed.
+const partSuffix = ".part"
+
+// ResumableOptions configures a resumable download.
+type ResumableOptions struct {
+	OutputDir    string // Output directory (empty = current directory)
+	Overwrite    bool   // Overwrite existing files
+	Token        string // Bearer token for authentication
+	ExpectedSize int64  // Size reported by the API (0 = unknown, skips verification)
+	ShowProgress bool   // Show a progress bar
+	Retries      int    // Max resume attempts on transfer errors (0 = default, see SetRetries)
+
+	headers  map[string]string // Extra request headers (generic URL downloads)
+	client   *http.Client      // HTTP client (nil = httpclient.Default())
+	progress *ui.ProgressBar   // Shared bar of a multi-file download, advanced by the bytes written
+}
+
+// Resumable downloads rawURL to filename, keeping partial data in a
+// "<filename>.part" file so interrupted downloads continue with an HTTP
+// Range request instead of starting over. The final size is checked
+// against ExpectedSize when known.
+func Resumable(rawURL, filename string, opts ResumableOptions) error {
+	outPath := filename
+	if opts.OutputDir != "" {
+		outPath = filepath.Join(opts.OutputDir, filename)
+	}
+	start := time.Now()
+	err := resumable(rawURL, filename, outPath, opts)
+	recordFile(rawURL, outPath, start, err)
+	return err
+}
+
+// resumable implements Resumable for the output path outPath
+func resumable(rawURL, filename, outPath string, opts ResumableOptions) error {
+	if opts.OutputDir != "" {
+		if err := os.MkdirAll(opts.OutputDir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+
+	if !opts.Overwrite {
+		if _, err := os.Stat(outPath); err == nil {
+			return &ErrFileExists{Path: outPath}
+		}
+	}
+
+	partPath := outPath + partSuffix
+
+	var bar *ui.ProgressBar
+	if opts.ShowProgress {
+		fmt.Printf("  Downloading → %s\n", outPath)
+		bar = ui.NewProgressBar(filename, opts.ExpectedSize)
+	}
+	if opts.progress != nil {
+		// Partial data counts towards the shared bar once, not per attempt
+		if info, err := os.Stat(partPath); err == nil {
+			opts.progress.Add(info.Size())
+		}
+	}
+
+	retries := retryCount(opts.Retries)
+
+	var err error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(time.Duration(1<<uint(attempt-1)) * time.Second)
+		}
+		err = fetchToPart(rawURL, partPath, opts, bar)
+		if err == nil {
+			break
+		}
+		// Client errors won't succeed on retry
+		if _, ok := err.(*ErrNotFound); ok {
+			break
+		}
+		if he, ok := err.(*ErrHTTP); ok && he.StatusCode < 500 && he.StatusCode != http.StatusTooManyRequests &&
+			he.StatusCode != http.StatusRequestedRangeNotSatisfiable {
+			break
+		}
+	}
+	if bar != nil {
+		bar.Finish()
+	}
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(partPath)
+	if err != nil {
+		return fmt.Errorf("failed to stat download: %w", err)
+	}
+	if opts.ExpectedSize > 0 && info.Size() != opts.ExpectedSize {
+		os.Remove(partPath)
+		return fmt.Errorf("size mismatch for %s: expected %d bytes, got %d", filename, opts.ExpectedSize, info.Size())
+	}
+
+	if err := os.Rename(partPath, outPath); err != nil {
+		return fmt.Errorf("failed to rename %s: %w", partPath, err)
+	}
+
+	if opts.ShowProgress {
+		fmt.Printf("  ✓ Saved: %s\n", outPath)
+	}
+	return nil
+}
+
+// fetchToPart appends the remaining bytes of rawURL to partPath.
+func fetchToPart(rawURL, partPath string, opts ResumableOptions, bar *ui.ProgressBar) error {
+	var offset int64
+	if info, err := os.Stat(partPath); err == nil {
+		offset = info.Size()
+	}
+
+	// Already complete from a previous run
+	if opts.ExpectedSize > 0 && offset == opts.ExpectedSize {
+		if bar != nil {
+			bar.Set(offset)
+		}
+		return nil
+	}
+	// Stale partial file larger than the asset: start over
+	if opts.ExpectedSize > 0 && offset > opts.ExpectedSize {
+		os.Remove(partPath)
+		offset = 0
+	}
+
+	req, err := http.NewRequest("GET", rawURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "ghex-downloader/1.0")
+	if opts.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+opts.Token)
+	}
+	for k, v := range opts.headers {
+		req.Header.Set(k, v)
+	}
+	if offset > 0 {
+		req.Header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+	}
+
+	client := opts.client
+	if client == nil {
+		client = httpclient.Default()
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch URL: %w", err)
+	}
+	defer resp.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		flags |= os.O_APPEND
+	case http.StatusOK:
+		// Server ignored the Range header, so rewrite from the start
+		flags |= os.O_TRUNC
+		if opts.progress != nil {
+			opts.progress.Add(-offset)
+		}
+		offset = 0
+	case http.StatusRequestedRangeNotSatisfiable:
+		// Partial file is unusable, drop it so the next attempt starts fresh
+		os.Remove(partPath)
+		return &ErrHTTP{StatusCode: resp.StatusCode, Status: resp.Status, URL: rawURL}
+	case http.StatusNotFound:
+		return &ErrNotFound{URL: rawURL}
+	default:
+		return &ErrHTTP{StatusCode: resp.StatusCode, Status: resp.Status, URL: rawURL}
+	}
+
+	f, err := os.OpenFile(partPath, flags, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", partPath, err)
+	}
+	defer f.Close()
+
+	var w io.Writer = f
+	if bar != nil {
+		bar.Set(offset)
+		w = io.MultiWriter(f, bar)
+	} else if opts.progress != nil {
+		w = io.MultiWriter(f, opts.progress)
+	}
+
+	if _, err := copyBuffered(w, resp.Body); err != nil {
+		return fmt.Errorf("download interrupted: %w", err)
+	}
+	return nil
+}
+
+// resumeURL downloads a generic URL through Resumable. A HEAD request first
+// asks the server for the size and whether it accepts Range requests; a
+// partial file is discarded when the server says it doesn't.
+func resumeURL(rawURL, outName, outPath string, opts Options, client *http.Client) error {
+	size, acceptRanges := probeRanges(rawURL, opts, client)
+	partPath := outPath + partSuffix
+	if info, err := os.Stat(partPath); err == nil {
+		if !strings.EqualFold(acceptRanges, "none") {
+			fmt.Printf("  Resuming from %s\n", formatSize(info.Size()))
+		} else {
+			fmt.Println("  Server does not support resuming, starting over")
+			os.Remove(partPath)
+		}
+	}
+
+	if opts.ShowInfo {
+		fmt.Printf("  URL:  %s\n", rawURL)
+		fmt.Printf("  Size: %s\n", formatSize(size))
+		fmt.Printf("  Dest: %s\n", outPath)
+	}
+
+	// FromURL records the result, not Resumable
+	return resumable(rawURL, outName, outPath, ResumableOptions{
+		OutputDir:    opts.OutputDir,
+		Overwrite:    opts.Overwrite,
+		Token:        opts.Token,
+		ExpectedSize: size,
+		ShowProgress: opts.ShowProgress,
+		Retries:      opts.effectiveRetries(),
+		headers:      opts.Headers,
+		client:       client,
+	})
+}
+
+// probeRanges sends a HEAD request and returns the content length (0 when
+// unknown) and the Accept-Ranges header ("" when unknown). Resuming still
+// tries servers that omit the header, since fetchToPart falls back to a
+// full download when the Range header is ignored.
+func probeRanges(rawURL string, opts Options, client *http.Client) (int64, string) {
+	req, err := http.NewRequest("HEAD", rawURL, nil)
+	if err != nil {
+		return 0, ""
+	}
+	req.Header.Set("User-Agent", "ghex-downloader/1.0")
+	if opts.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+opts.Token)
+	}
+	for k, v := range opts.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, ""
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, ""
+	}
+
+	size := resp.ContentLength
+	if size < 0 || resp.Header.Get("Content-Encoding") != "" {
+		size = 0
+	}
+	return size, resp.Header.Get("Accept-Ranges")
+}
@@ -0,0 +1,160 @@
+package download
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testContent returns n bytes of varying content.
+func testContent(n int) []byte {
+	data := make([]byte, n)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	return data
+}
+
+// fileServer serves content, answering Range requests when ranges is set
+// and recording the Range headers it got.
+type fileServer struct {
+	*httptest.Server
+	mu     sync.Mutex
+	ranges []string
+}
+
+func newFileServer(t *testing.T, content []byte, handler func(w http.ResponseWriter, r *http.Request) bool, ranges bool) *fileServer {
+	t.Helper()
+	s := &fileServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		if rng := r.Header.Get("Range"); rng != "" {
+			s.ranges = append(s.ranges, rng)
+		}
+		s.mu.Unlock()
+
+		if handler != nil && handler(w, r) {
+			return
+		}
+		if ranges {
+			http.ServeContent(w, r, "file", time.Time{}, bytes.NewReader(content))
+			return
+		}
+		w.Write(content)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// rangeHeaders returns the Range headers received so far.
+func (s *fileServer) rangeHeaders() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.ranges...)
+}
+
+func TestResumable(t *testing.T) {
+	content := testContent(64 << 10)
+	size := int64(len(content))
+	notSatisfiable := func(w http.ResponseWriter, r *http.Request) bool {
+		w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+		return true
+	}
+
+	tests := []struct {
+		name      string
+		part      []byte // Partial data left by an earlier run
+		ranges    bool   // Server answers Range requests with 206
+		handler   func(w http.ResponseWriter, r *http.Request) bool
+		expected  int64 // ExpectedSize
+		wantRange string
+		wantErr   string
+		wantPart  bool // .part file kept after the call
+	}{
+		{name: "fresh download", ranges: true, expected: size},
+		{name: "206 resumes", part: content[:1000], ranges: true, expected: size, wantRange: "bytes=1000-"},
+		{name: "200 restarts", part: []byte("stale data"), ranges: false, expected: size, wantRange: "bytes=10-"},
+		{name: "size unknown", part: content[:5], ranges: true, wantRange: "bytes=5-"},
+		{name: "already complete", part: content, ranges: true, expected: size},
+		{name: "stale part larger than file", part: append(append([]byte(nil), content...), 'x'), ranges: true, expected: size},
+		{name: "416 drops part", part: content[:1000], handler: notSatisfiable, expected: size, wantRange: "bytes=1000-", wantErr: "416"},
+		{name: "size mismatch", ranges: true, expected: size + 1, wantErr: "size mismatch"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newFileServer(t, content, tt.handler, tt.ranges)
+			dir := t.TempDir()
+			outPath := filepath.Join(dir, "file.bin")
+			if tt.part != nil {
+				if err := os.WriteFile(outPath+partSuffix, tt.part, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := Resumable(srv.URL+"/file.bin", "file.bin", ResumableOptions{
+				OutputDir:    dir,
+				ExpectedSize: tt.expected,
+				Retries:      -1,
+			})
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Resumable() error = %v, want %q", err, tt.wantErr)
+				}
+				if _, err := os.Stat(outPath); err == nil {
+					t.Error("Expected no output file after a failed download")
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("Resumable() error = %v", err)
+				}
+				got, err := os.ReadFile(outPath)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(got, content) {
+					t.Errorf("Downloaded %d bytes, want the %d bytes served", len(got), len(content))
+				}
+			}
+
+			if _, err := os.Stat(outPath + partSuffix); (err == nil) != tt.wantPart {
+				t.Errorf(".part file kept = %v, want %v", err == nil, tt.wantPart)
+			}
+			ranges := srv.rangeHeaders()
+			if tt.wantRange == "" && len(ranges) > 0 {
+				t.Errorf("Range headers = %v, want none", ranges)
+			}
+			if tt.wantRange != "" && (len(ranges) != 1 || ranges[0] != tt.wantRange) {
+				t.Errorf("Range headers = %v, want [%s]", ranges, tt.wantRange)
+			}
+		})
+	}
+}
+
+func TestResumableExistingFile(t *testing.T) {
+	srv := newFileServer(t, []byte("new"), nil, true)
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "file.txt")
+	if err := os.WriteFile(outPath, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := Resumable(srv.URL+"/file.txt", "file.txt", ResumableOptions{OutputDir: dir, Retries: -1})
+	if _, ok := err.(*ErrFileExists); !ok {
+		t.Fatalf("Resumable() error = %v, want *ErrFileExists", err)
+	}
+
+	if err := Resumable(srv.URL+"/file.txt", "file.txt", ResumableOptions{OutputDir: dir, Overwrite: true, Retries: -1}); err != nil {
+		t.Fatalf("Resumable() with Overwrite error = %v", err)
+	}
+	if got, _ := os.ReadFile(outPath); string(got) != "new" {
+		t.Errorf("File content = %q, want %q", got, "new")
+	}
+}
@@ -0,0 +1,98 @@
+package download
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// shorthandOwnerPattern matches GitHub owner names. Owners have no dots,
+// which keeps host names like example.com/file.tar.gz from passing as
+// shorthand.
+var shorthandOwnerPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// shorthandNamePattern matches GitHub repository names.
+var shorthandNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// Shorthand is a parsed owner/repo reference without a full URL.
+//
+// Accepted forms:
+//
+//	owner/repo
+//	owner/repo@ref
+//	owner/repo@ref:path
+//	owner/repo:ref
+//	owner/repo:ref:path
+//	owner/repo::path   (default branch)
+type Shorthand struct {
+	Owner string
+	Repo  string
+	Ref   string // empty = default branch
+	Path  string
+}
+
+// ParseShorthand parses an owner/repo[@ref][:ref[:path]] argument.
+// Returns false if arg is a URL or doesn't look like shorthand.
+func ParseShorthand(arg string) (*Shorthand, bool) {
+	arg = strings.TrimSpace(arg)
+	if arg == "" || strings.Contains(arg, "://") || strings.HasPrefix(arg, "git@") {
+		return nil, false
+	}
+
+	parts := strings.SplitN(arg, ":", 3)
+	head := parts[0]
+
+	sh := &Shorthand{}
+	if repoPart, ref, ok := strings.Cut(head, "@"); ok {
+		head = repoPart
+		sh.Ref = ref
+		if len(parts) > 2 {
+			// owner/repo@ref:a:b — the rest is all path
+			sh.Path = parts[1] + ":" + parts[2]
+		} else if len(parts) > 1 {
+			sh.Path = parts[1]
+		}
+	} else {
+		if len(parts) > 1 {
+			sh.Ref = parts[1]
+		}
+		if len(parts) > 2 {
+			sh.Path = parts[2]
+		}
+	}
+
+	owner, repo, ok := strings.Cut(head, "/")
+	if !ok || !shorthandOwnerPattern.MatchString(owner) || !shorthandNamePattern.MatchString(repo) {
+		return nil, false
+	}
+	sh.Owner = owner
+	sh.Repo = strings.TrimSuffix(repo, ".git")
+	sh.Path = strings.Trim(sh.Path, "/")
+	return sh, true
+}
+
+// RepoURL returns the repository URL.
+func (s *Shorthand) RepoURL() string {
+	return fmt.Sprintf("https://github.com/%s/%s", s.Owner, s.Repo)
+}
+
+// BlobURL returns a file URL for Path. The default branch is addressed as HEAD.
+func (s *Shorthand) BlobURL() string {
+	return fmt.Sprintf("%s/blob/%s/%s", s.RepoURL(), s.refOrHead(), s.Path)
+}
+
+// TreeURL returns a directory URL for Path (the repo root when Path is empty).
+func (s *Shorthand) TreeURL() string {
+	u := fmt.Sprintf("%s/tree/%s", s.RepoURL(), s.refOrHead())
+	if s.Path != "" {
+		u += "/" + s.Path
+	}
+	return u
+}
+
+func (s *Shorthand) refOrHead() string {
+	if s.Ref == "" {
+		return "HEAD"
+	}
+	return s.Ref
+}
@@ -0,0 +1,52 @@
+package download
+
+import (
+	"testing"
+)
+
+func TestParseShorthand(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected *Shorthand
+	}{
+		{"owner/repo", &Shorthand{Owner: "owner", Repo: "repo"}},
+		{"owner/repo.git", &Shorthand{Owner: "owner", Repo: "repo"}},
+		{"owner/my.repo", &Shorthand{Owner: "owner", Repo: "my.repo"}},
+		{"owner/repo@v1.2.0", &Shorthand{Owner: "owner", Repo: "repo", Ref: "v1.2.0"}},
+		{"owner/repo@main:docs/", &Shorthand{Owner: "owner", Repo: "repo", Ref: "main", Path: "docs"}},
+		{"owner/repo@main:a:b", &Shorthand{Owner: "owner", Repo: "repo", Ref: "main", Path: "a:b"}},
+		{"owner/repo:dev", &Shorthand{Owner: "owner", Repo: "repo", Ref: "dev"}},
+		{"owner/repo:dev:src/main.go", &Shorthand{Owner: "owner", Repo: "repo", Ref: "dev", Path: "src/main.go"}},
+		{"owner/repo::README.md", &Shorthand{Owner: "owner", Repo: "repo", Path: "README.md"}},
+		{"example.com/file.tar.gz", nil},
+		{"cdn.example.org/tool", nil},
+		{"https://github.com/owner/repo", nil},
+		{"git@github.com:owner/repo.git", nil},
+		{"owner", nil},
+		{"owner/", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := ParseShorthand(tt.input)
+			if ok != (tt.expected != nil) {
+				t.Fatalf("ParseShorthand(%q) ok = %v, want %v", tt.input, ok, tt.expected != nil)
+			}
+			if ok && *got != *tt.expected {
+				t.Errorf("ParseShorthand(%q) = %+v, want %+v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestShorthandURLs(t *testing.T) {
+	sh := &Shorthand{Owner: "owner", Repo: "repo", Path: "docs"}
+	if got := sh.TreeURL(); got != "https://github.com/owner/repo/tree/HEAD/docs" {
+		t.Errorf("TreeURL() = %q", got)
+	}
+	sh.Ref = "v1"
+	if got := sh.BlobURL(); got != "https://github.com/owner/repo/blob/v1/docs" {
+		t.Errorf("BlobURL() = %q", got)
+	}
+}
@@ -0,0 +1,258 @@
+package download
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/dwirx/ghex/internal/ui"
+)
+
+// ManifestName is the file GitSync keeps in the output directory to know
+// which version of each file it has.
+const ManifestName = ".ghex-manifest.json"
+
+// SyncOptions configures incremental directory sync.
+type SyncOptions struct {
+	Branch    string // Branch/tag/commit (empty = default branch)
+	OutputDir string // Output directory (default: last path component, or the repo name)
+	Depth     int    // Max directory depth (0 = unlimited)
+	Token     string // Access token (empty = GITHUB_TOKEN, GITLAB_TOKEN or GITEA_TOKEN by host)
+	Parallel  int    // Files downloaded at once (0 = DefaultParallel)
+	Prune     bool   // Delete local files that were removed upstream
+}
+
+// syncManifest records what a sync downloaded.
+type syncManifest struct {
+	Repository string         `json:"repository"`
+	Path       string         `json:"path,omitempty"`
+	Ref        string         `json:"ref"`
+	SyncedAt   time.Time      `json:"syncedAt"`
+	Files      []manifestFile `json:"files"`
+}
+
+// manifestFile is a synced file, its path relative to the output directory.
+type manifestFile struct {
+	Path string `json:"path"`
+	SHA  string `json:"sha"`
+	Size int64  `json:"size,omitempty"`
+}
+
+// GitSync mirrors a repository directory into a local one. The first run
+// downloads everything and writes ManifestName with each file's blob SHA;
+// later runs only download files whose SHA changed or that are missing
+// locally. Files removed upstream are deleted with opts.Prune and reported
+// otherwise.
+func GitSync(url string, opts SyncOptions) error {
+	parsed, err := parseGitURL(url)
+	if err != nil {
+		return err
+	}
+
+	token := releaseToken(parsed, opts.Token)
+	applyRef(parsed, opts.Branch, token)
+
+	outputDir := opts.OutputDir
+	if outputDir == "" {
+		outputDir = parsed.Repo
+		if parsed.FilePath != "" {
+			outputDir = filepath.Base(parsed.FilePath)
+		}
+	}
+
+	ui.ShowSection("Syncing Directory")
+	ui.ShowKeyValue("Repository", parsed.FullPath())
+	if parsed.FilePath != "" {
+		ui.ShowKeyValue("Path", parsed.FilePath)
+	}
+	ui.ShowKeyValue("Output", outputDir)
+
+	manifestPath := filepath.Join(outputDir, ManifestName)
+	previous, err := loadSyncManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+	if previous != nil && (previous.Repository != parsed.FullPath() || previous.Path != parsed.FilePath) {
+		return fmt.Errorf("%s was synced from %s, not %s", outputDir, previous.source(), parsed.FullPath()+pathSuffix(parsed.FilePath))
+	}
+
+	files, err := listDirectory(parsed, opts.Branch != "", opts.Depth, token)
+	if err != nil {
+		return err
+	}
+	ui.ShowKeyValue("Ref", refLabel(parsed))
+	fmt.Println()
+
+	known := map[string]manifestFile{}
+	if previous != nil {
+		for _, f := range previous.Files {
+			known[f.Path] = f
+		}
+	}
+
+	// Files are downloaded when their SHA changed, is unknown, or the local
+	// copy is gone
+	var changed []TreeFile
+	var unchanged []manifestFile
+	remote := map[string]bool{}
+	for _, f := range files {
+		rel := relativePath(f.Path, parsed.FilePath)
+		if rel == ManifestName {
+			continue
+		}
+		remote[rel] = true
+		if old, ok := known[rel]; ok && f.SHA != "" && old.SHA == f.SHA && fileExists(filepath.Join(outputDir, rel)) {
+			unchanged = append(unchanged, old)
+			continue
+		}
+		changed = append(changed, f)
+	}
+
+	var removed []manifestFile
+	for _, f := range known {
+		if !remote[f.Path] {
+			removed = append(removed, f)
+		}
+	}
+	sort.Slice(removed, func(i, j int) bool { return removed[i].Path < removed[j].Path })
+
+	manifest := syncManifest{
+		Repository: parsed.FullPath(),
+		Path:       parsed.FilePath,
+		Ref:        parsed.Branch,
+		Files:      unchanged,
+	}
+
+	downloaded := 0
+	if len(changed) > 0 {
+		ui.ShowInfo(fmt.Sprintf("%d changed, %d unchanged", len(changed), len(unchanged)))
+		result, errs := downloadFiles(changed, parsed.FilePath, outputDir, true, opts.Parallel, token, nil)
+		downloaded = result.downloaded
+		for i, f := range changed {
+			rel := relativePath(f.Path, parsed.FilePath)
+			switch {
+			case errs[i] == nil:
+				manifest.Files = append(manifest.Files, manifestFile{Path: rel, SHA: f.SHA, Size: f.Size})
+			case known[rel].Path != "":
+				// Keep the old entry so the next sync retries the file
+				manifest.Files = append(manifest.Files, known[rel])
+			}
+		}
+	}
+
+	pruned := 0
+	for _, f := range removed {
+		if !opts.Prune {
+			// Remembered so a later --prune still deletes it
+			manifest.Files = append(manifest.Files, f)
+			continue
+		}
+		if err := removeSyncedFile(outputDir, f.Path); err != nil {
+			ui.ShowWarning(fmt.Sprintf("Failed to delete %s: %v", f.Path, err))
+			manifest.Files = append(manifest.Files, f)
+			continue
+		}
+		pruned++
+	}
+
+	sort.Slice(manifest.Files, func(i, j int) bool { return manifest.Files[i].Path < manifest.Files[j].Path })
+	manifest.SyncedAt = time.Now()
+	if err := saveSyncManifest(manifestPath, &manifest); err != nil {
+		return err
+	}
+
+	ui.ShowSuccess(fmt.Sprintf("Synced %s: %d downloaded, %d unchanged, %d deleted", outputDir, downloaded, len(unchanged), pruned))
+	if !opts.Prune && len(removed) > 0 {
+		ui.ShowInfo(fmt.Sprintf("%d files were removed upstream; run with --prune to delete them:", len(removed)))
+		for _, f := range removed {
+			fmt.Printf("  - %s\n", f.Path)
+		}
+	}
+	if downloaded < len(changed) {
+		return fmt.Errorf("%d files failed to download", len(changed)-downloaded)
+	}
+	return nil
+}
+
+// source describes where a manifest was synced from
+func (m *syncManifest) source() string {
+	return m.Repository + pathSuffix(m.Path)
+}
+
+// pathSuffix returns ":path" for a non-empty path
+func pathSuffix(path string) string {
+	if path == "" {
+		return ""
+	}
+	return ":" + path
+}
+
+// loadSyncManifest reads a sync manifest, nil when there is none yet
+func loadSyncManifest(path string) (*syncManifest, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", ManifestName, err)
+	}
+	var m syncManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	return &m, nil
+}
+
+// saveSyncManifest writes a sync manifest atomically
+func saveSyncManifest(path string, m *syncManifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".ghex-manifest-*")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", ManifestName, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", ManifestName, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", ManifestName, err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// removeSyncedFile deletes a synced file and the directories it leaves
+// empty. Paths outside outputDir, which only an edited manifest has, are
+// refused.
+func removeSyncedFile(outputDir, rel string) error {
+	if !filepath.IsLocal(filepath.FromSlash(rel)) {
+		return fmt.Errorf("path leaves %s", outputDir)
+	}
+	path := filepath.Join(outputDir, filepath.FromSlash(rel))
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	root := filepath.Clean(outputDir)
+	for dir := filepath.Dir(path); dir != root && dir != "."; dir = filepath.Dir(dir) {
+		// Fails, and stops, at the first directory that isn't empty
+		if os.Remove(dir) != nil {
+			break
+		}
+	}
+	return nil
+}
+
+// fileExists reports whether path exists
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,99 @@
+package download
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/dwirx/ghex/internal/httpclient"
+)
+
+// errTreeTruncated is returned when GitHub cut a recursive tree listing
+// short, which happens above 100,000 entries or 7 MB.
+var errTreeTruncated = errors.New("tree listing truncated")
+
+// githubTreeResponse is the response of the GitHub Git Trees API.
+type githubTreeResponse struct {
+	SHA       string `json:"sha"`
+	Truncated bool   `json:"truncated"`
+	Tree      []struct {
+		Path string `json:"path"`
+		Type string `json:"type"` // "blob", "tree" or "commit" (submodule)
+		SHA  string `json:"sha"`
+		Size int64  `json:"size"`
+	} `json:"tree"`
+}
+
+// fetchGitHubTree lists the files below parsed.FilePath with a single
+// recursive Git Trees API call instead of one Contents call per directory.
+// It returns errTreeTruncated when the listing is incomplete, and
+// ErrNotFound for a missing ref or path.
+func fetchGitHubTree(parsed *ParsedGitURL, maxDepth int, token string) ([]TreeFile, error) {
+	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/git/trees/%s?recursive=1",
+		parsed.Owner, parsed.Repo, url.PathEscape(parsed.Branch))
+
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("User-Agent", "ghex-downloader/1.0")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := doAPI(httpclient.Default(), req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusUnprocessableEntity {
+		return nil, &ErrNotFound{URL: apiURL}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &ErrHTTP{StatusCode: resp.StatusCode, Status: resp.Status, URL: apiURL}
+	}
+
+	var tree githubTreeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tree); err != nil {
+		return nil, fmt.Errorf("failed to parse tree: %w", err)
+	}
+	if tree.Truncated {
+		return nil, errTreeTruncated
+	}
+
+	prefix := ""
+	if parsed.FilePath != "" {
+		prefix = parsed.FilePath + "/"
+	}
+	var files []TreeFile
+	found := prefix == ""
+	for _, entry := range tree.Tree {
+		if !strings.HasPrefix(entry.Path, prefix) {
+			continue
+		}
+		found = true
+		if entry.Type != "blob" {
+			continue
+		}
+		rel := strings.TrimPrefix(entry.Path, prefix)
+		if maxDepth > 0 && strings.Count(rel, "/") > maxDepth {
+			continue
+		}
+		files = append(files, TreeFile{
+			Path: entry.Path,
+			URL: fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/%s/%s",
+				parsed.Owner, parsed.Repo, escapePath(parsed.Branch), escapePath(entry.Path)),
+			Size: entry.Size,
+			SHA:  entry.SHA,
+		})
+	}
+	if !found {
+		return nil, &ErrNotFound{URL: apiURL + " (" + parsed.FilePath + ")"}
+	}
+	return files, nil
+}
@@ -0,0 +1,64 @@
+package download
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// maxTreePages caps pagination of the GitLab tree API (100 entries per page).
+const maxTreePages = 100
+
+// gitlabTreeEntry is an entry returned by the GitLab repository tree API.
+type gitlabTreeEntry struct {
+	ID   string `json:"id"` // Blob SHA
+	Path string `json:"path"`
+	Type string `json:"type"` // "blob", "tree" or "commit" (submodule)
+}
+
+// fetchGitLabTree lists the files below parsed.FilePath with the GitLab
+// repository tree API, which answers 404 for a missing ref or path. Files are fetched through the repository files API,
+// which accepts the token for private projects, unlike the /-/raw/ URLs.
+// Sizes are unknown because the tree API doesn't report them.
+func fetchGitLabTree(parsed *ParsedGitURL, maxDepth int, token string) ([]TreeFile, error) {
+	base := gitlabProjectAPI(parsed)
+	query := url.Values{}
+	query.Set("ref", parsed.Branch)
+	query.Set("recursive", "true")
+	query.Set("per_page", "100")
+	if parsed.FilePath != "" {
+		query.Set("path", parsed.FilePath)
+	}
+
+	var files []TreeFile
+	page := "1"
+	for i := 0; i < maxTreePages && page != ""; i++ {
+		query.Set("page", page)
+		var entries []gitlabTreeEntry
+		next, err := getAPIPage(base+"/repository/tree?"+query.Encode(), token, &entries)
+		if err != nil {
+			return nil, err
+		}
+		page = next
+
+		for _, entry := range entries {
+			if entry.Type != "blob" {
+				continue
+			}
+			rel := entry.Path
+			if parsed.FilePath != "" {
+				rel = strings.TrimPrefix(entry.Path, parsed.FilePath+"/")
+			}
+			if maxDepth > 0 && strings.Count(rel, "/") > maxDepth {
+				continue
+			}
+			files = append(files, TreeFile{
+				Path: entry.Path,
+				URL: fmt.Sprintf("%s/repository/files/%s/raw?ref=%s",
+					base, url.PathEscape(entry.Path), url.QueryEscape(parsed.Branch)),
+				SHA: entry.ID,
+			})
+		}
+	}
+	return files, nil
+}
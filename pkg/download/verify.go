@@ -0,0 +1,131 @@
+package download
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dwirx/ghex/internal/checksum"
+	"github.com/dwirx/ghex/internal/platform"
+)
+
+// errVerifyMultiple is returned when a checksum is given for a download of
+// several files.
+var errVerifyMultiple = errors.New("--checksum and --checksum-file only apply to single-file downloads")
+
+// Verification is the digest a downloaded file must have. A file that
+// doesn't match is deleted.
+type Verification struct {
+	Checksum     string // "sha256:<hex>" or "sha512:<hex>" (a bare digest's algorithm follows from its length)
+	ChecksumFile string // URL or path of a checksums file (SHA256SUMS style) listing the file
+}
+
+// IsSet reports whether a checksum was given
+func (v Verification) IsSet() bool {
+	return v.Checksum != "" || v.ChecksumFile != ""
+}
+
+// expectedDigest resolves the digest a download must have. names are the
+// file names looked up in a checksums file, the URL's first. It returns nil
+// when no checksum was given.
+func (v Verification) expectedDigest(client *http.Client, token, rawURL string, names ...string) (*checksum.Digest, error) {
+	if v.Checksum != "" {
+		d, err := checksum.Parse(v.Checksum)
+		if err != nil {
+			return nil, err
+		}
+		return &d, nil
+	}
+	if v.ChecksumFile == "" {
+		return nil, nil
+	}
+
+	content, err := readChecksumFile(client, token, rawURL, v.ChecksumFile)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := checksum.ParseFile(content)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		sum, ok := checksum.Find(entries, name)
+		if !ok {
+			continue
+		}
+		algorithm := checksum.AlgorithmFor(sum)
+		if algorithm == "" {
+			return nil, fmt.Errorf("unsupported checksum for %s in %s", name, v.ChecksumFile)
+		}
+		return &checksum.Digest{Algorithm: algorithm, Hex: sum}, nil
+	}
+	return nil, fmt.Errorf("%s has no checksum for %s", v.ChecksumFile, names[0])
+}
+
+// readChecksumFile reads a local checksums file or downloads one. The token
+// is only sent to the host of the file being verified.
+func readChecksumFile(client *http.Client, token, rawURL, source string) (string, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		data, err := os.ReadFile(platform.ExpandPath(source))
+		if err != nil {
+			return "", fmt.Errorf("failed to read checksum file: %w", err)
+		}
+		return string(data), nil
+	}
+
+	req, err := http.NewRequest("GET", source, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	if token != "" && sameHost(source, rawURL) {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := doWithRetries(client, req, retryCount(0))
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch checksum file: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return "", &ErrNotFound{URL: source}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", &ErrHTTP{StatusCode: resp.StatusCode, Status: resp.Status, URL: source}
+	}
+	// Checksum files are small; a megabyte is plenty
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("failed to read checksum file: %w", err)
+	}
+	return string(data), nil
+}
+
+// sameHost reports whether two URLs have the same host
+func sameHost(a, b string) bool {
+	ua, errA := url.Parse(a)
+	ub, errB := url.Parse(b)
+	return errA == nil && errB == nil && strings.EqualFold(ua.Host, ub.Host)
+}
+
+// verifyDownload checks the downloaded file against want and deletes it on
+// a mismatch
+func verifyDownload(path string, want *checksum.Digest, showProgress bool) error {
+	if err := checksum.Verify(path, *want); err != nil {
+		if errors.Is(err, checksum.ErrMismatch) {
+			os.Remove(path)
+			return fmt.Errorf("%s: %w (deleted)", filepath.Base(path), err)
+		}
+		return err
+	}
+	if showProgress {
+		fmt.Printf("  ✓ Checksum verified (%s)\n", want.Algorithm)
+	}
+	return nil
+}